@@ -0,0 +1,92 @@
+package minisql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/RichardKnop/minisql/internal/minisql"
+)
+
+// AffectedKeys executes an UPDATE or DELETE query on db and returns the key of
+// every row it modified, in the order the rows were modified. A key holds the
+// primary key column values in primary key order, or the row ID as a single
+// int64 for tables without a primary key; for UPDATE it is the key the row had
+// before the statement ran. Collecting keys is cheaper than RETURNING because
+// no other columns are read. db must have been opened with
+// sql.Open("minisql", dsn).
+//
+// Example:
+//
+//	keys, err := minisql.AffectedKeys(ctx, db, `delete from sessions where expires_at < ?`, now)
+//	if err != nil { ... }
+//	for _, key := range keys {
+//		fmt.Println(key[0])
+//	}
+func AffectedKeys(ctx context.Context, db *sql.DB, query string, args ...any) ([][]any, error) {
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("minisql: AffectedKeys: acquire connection: %w", err)
+	}
+	defer conn.Close()
+
+	var keys [][]any
+	err = conn.Raw(func(c any) error {
+		mc, ok := c.(*Conn)
+		if !ok {
+			return fmt.Errorf("minisql: AffectedKeys: unexpected connection type %T", c)
+		}
+		keys, err = mc.affectedKeys(ctx, query, args)
+		return err
+	})
+	return keys, err
+}
+
+func (c *Conn) affectedKeys(ctx context.Context, query string, args []any) (keys [][]any, err error) {
+	start := time.Now()
+	defer func() {
+		c.logSlowQuery(query, time.Since(start), err)
+	}()
+
+	stmt, err := c.db.PrepareStatement(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	if stmt.Kind != minisql.Update && stmt.Kind != minisql.Delete {
+		return nil, errors.New("minisql: AffectedKeys: query must be an UPDATE or DELETE")
+	}
+	if len(args) > 0 {
+		internalArgs, err := c.batchRowArgs(args)
+		if err != nil {
+			return nil, err
+		}
+		stmt, err = stmt.BindArguments(internalArgs...)
+		if err != nil {
+			return nil, err
+		}
+	}
+	stmt.ReturnAffectedKeys = true
+
+	result, err := c.executeStatement(ctx, stmt)
+	if err != nil {
+		return nil, err
+	}
+	return driverKeys(result.AffectedKeys), nil
+}
+
+// driverKeys converts engine affected keys to the values database/sql scans.
+func driverKeys(affected []minisql.AffectedKey) [][]any {
+	if affected == nil {
+		return nil
+	}
+	keys := make([][]any, len(affected))
+	for i, key := range affected {
+		keys[i] = make([]any, len(key))
+		for j, value := range key {
+			keys[i][j] = driverValue(value)
+		}
+	}
+	return keys
+}
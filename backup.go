@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"io"
 )
 
 // Backup creates a consistent, point-in-time copy of db at destPath.
@@ -39,3 +40,32 @@ func Backup(ctx context.Context, db *sql.DB, destPath string) error {
 		return mc.db.Backup(ctx, destPath)
 	})
 }
+
+// BackupTo streams a consistent, point-in-time copy of db to w, page by page
+// in file order, so w can be a network connection, a compressor or an object
+// store upload.  The bytes written form a standalone database file with the
+// same contents Backup would write to disk; transactions committed after the
+// backup starts are not included.
+//
+//	f, err := os.Create("/path/to/backup.db.gz")
+//	gz := gzip.NewWriter(f)
+//	if err := minisql.BackupTo(ctx, db, gz); err != nil {
+//	    log.Fatal(err)
+//	}
+//
+// BackupTo must not be called from inside an explicit user transaction.
+func BackupTo(ctx context.Context, db *sql.DB, w io.Writer) error {
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("minisql: BackupTo: acquire connection: %w", err)
+	}
+	defer conn.Close()
+
+	return conn.Raw(func(c any) error {
+		mc, ok := c.(*Conn)
+		if !ok {
+			return fmt.Errorf("minisql: BackupTo: unexpected connection type %T", c)
+		}
+		return mc.db.BackupTo(ctx, w)
+	})
+}
@@ -0,0 +1,129 @@
+package minisql
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ExecBatch executes query once for every element of rows, binding the
+// element's values to the query's ? placeholders, and returns the total number
+// of rows affected:
+//
+//	n, err := minisql.ExecBatch(ctx, db, `insert into users(email, name) values(?, ?);`, [][]any{
+//	    {"alice@example.com", "Alice"},
+//	    {"bob@example.com", "Bob"},
+//	})
+//
+// The query is parsed once. A single-row INSERT … VALUES is expanded into one
+// multi-row INSERT, so it is also validated and executed once; other write
+// statements are re-bound and executed per row. All rows run in one
+// transaction, so an error leaves the database unchanged; the error names the
+// offending row.
+//
+// ExecBatch must not be called from inside an explicit user transaction.
+func ExecBatch(ctx context.Context, db *sql.DB, query string, rows [][]any) (int64, error) {
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("minisql: ExecBatch: acquire connection: %w", err)
+	}
+	defer conn.Close()
+
+	var affected int64
+	err = conn.Raw(func(c any) error {
+		mc, ok := c.(*Conn)
+		if !ok {
+			return fmt.Errorf("minisql: ExecBatch: unexpected connection type %T", c)
+		}
+		affected, err = mc.execBatch(ctx, query, rows)
+		return err
+	})
+	return affected, err
+}
+
+func (c *Conn) execBatch(ctx context.Context, query string, rows [][]any) (affected int64, err error) {
+	start := time.Now()
+	defer func() {
+		c.logSlowQuery(query, time.Since(start), err)
+	}()
+
+	stmt, err := c.db.PrepareStatement(ctx, query)
+	if err != nil {
+		return 0, err
+	}
+	if stmt.ReadOnly() || len(stmt.ReturningFields) > 0 {
+		return 0, errors.New("minisql: ExecBatch: query must not return rows")
+	}
+	if len(rows) == 0 {
+		return 0, nil
+	}
+
+	batchArgs := make([][]any, len(rows))
+	for i, row := range rows {
+		batchArgs[i], err = c.batchRowArgs(row)
+		if err != nil {
+			return 0, fmt.Errorf("batch row %d: %w", i, err)
+		}
+	}
+
+	if bound, ok, err := stmt.BindMany(batchArgs); err != nil {
+		return 0, err
+	} else if ok {
+		result, err := c.executeStatement(ctx, bound)
+		return int64(result.RowsAffected), err
+	}
+
+	execRows := func(ctx context.Context) error {
+		for i, args := range batchArgs {
+			bound, err := stmt.BindArguments(args...)
+			if err != nil {
+				return fmt.Errorf("batch row %d: %w", i, err)
+			}
+			result, err := c.db.ExecuteStatement(ctx, bound)
+			if err != nil {
+				return fmt.Errorf("batch row %d: %w", i, err)
+			}
+			affected += int64(result.RowsAffected)
+		}
+		return nil
+	}
+	if c.HasActiveTransaction() {
+		err = execRows(c.TransactionContext(ctx))
+	} else {
+		err = c.db.GetTransactionManager().ExecuteInTransaction(ctx, execRows)
+		if err == nil {
+			c.db.AutoVacuumAfterCommit(ctx)
+		}
+	}
+	if err != nil {
+		return 0, err
+	}
+	return affected, nil
+}
+
+// batchRowArgs converts one row of ExecBatch arguments the way database/sql
+// converts the arguments of a single Exec call.
+func (c *Conn) batchRowArgs(row []any) ([]any, error) {
+	args := make([]any, len(row))
+	for i, value := range row {
+		nv := driver.NamedValue{Ordinal: i + 1, Value: value}
+		if err := c.CheckNamedValue(&nv); errors.Is(err, driver.ErrSkip) {
+			converted, err := driver.DefaultParameterConverter.ConvertValue(value)
+			if err != nil {
+				return nil, fmt.Errorf("argument %d: %w", i+1, err)
+			}
+			nv.Value = converted
+		} else if err != nil {
+			return nil, err
+		}
+		arg, err := toInternalArg(nv)
+		if err != nil {
+			return nil, err
+		}
+		args[i] = arg
+	}
+	return args, nil
+}
@@ -0,0 +1,123 @@
+package minisql
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExecBatch(t *testing.T) {
+	t.Parallel()
+
+	tempFile, err := os.CreateTemp("", "minisql-batch-test")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = os.Remove(tempFile.Name())
+		_ = os.Remove(tempFile.Name() + "-wal")
+	})
+
+	db, err := sql.Open("minisql", tempFile.Name())
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, db.Close()) })
+	db.SetMaxOpenConns(1)
+	db.SetMaxIdleConns(1)
+
+	ctx := context.Background()
+	_, err = db.ExecContext(ctx, `create table "users" (
+		id int8 primary key autoincrement,
+		email varchar(255) not null unique,
+		age int4,
+		created timestamp
+	);`)
+	require.NoError(t, err)
+
+	created := time.Date(2024, 5, 1, 12, 30, 0, 0, time.UTC)
+	countUsers := func() int {
+		var count int
+		require.NoError(t, db.QueryRowContext(ctx, `select count(*) from users;`).Scan(&count))
+		return count
+	}
+
+	t.Run("Insert binds every row to one statement", func(t *testing.T) {
+		n, err := ExecBatch(ctx, db, `insert into users(email, age, created) values(?, ?, ?);`, [][]any{
+			{"alice@example.com", 30, created},
+			{"bob@example.com", nil, created},
+			{"cara@example.com", int64(41), nil},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), n)
+		assert.Equal(t, 3, countUsers())
+
+		var (
+			age       sql.NullInt32
+			createdAt sql.NullTime
+		)
+		require.NoError(t, db.QueryRowContext(ctx, `select age, created from users where email = 'bob@example.com';`).Scan(&age, &createdAt))
+		assert.False(t, age.Valid)
+		assert.True(t, createdAt.Valid)
+		assert.True(t, created.Equal(createdAt.Time))
+	})
+
+	t.Run("Insert with fields out of column order", func(t *testing.T) {
+		n, err := ExecBatch(ctx, db, `insert into users(age, email) values(?, ?);`, [][]any{
+			{60, "fay@example.com"},
+			{61, "gus@example.com"},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), n)
+
+		var age int32
+		require.NoError(t, db.QueryRowContext(ctx, `select age from users where email = 'gus@example.com';`).Scan(&age))
+		assert.Equal(t, int32(61), age)
+		_, err = db.ExecContext(ctx, `delete from users where age >= 60;`)
+		require.NoError(t, err)
+		assert.Equal(t, 3, countUsers())
+	})
+
+	t.Run("Failing row rolls back the whole batch", func(t *testing.T) {
+		_, err := ExecBatch(ctx, db, `insert into users(email, age) values(?, ?);`, [][]any{
+			{"dan@example.com", 20},
+			{"alice@example.com", 21},
+		})
+		require.Error(t, err)
+		assert.Equal(t, 3, countUsers())
+	})
+
+	t.Run("Wrong argument count names the row", func(t *testing.T) {
+		_, err := ExecBatch(ctx, db, `insert into users(email, age) values(?, ?);`, [][]any{
+			{"dan@example.com", 20},
+			{"erin@example.com"},
+		})
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "batch row 1: expected 2 arguments, got 1")
+		assert.Equal(t, 3, countUsers())
+	})
+
+	t.Run("Update binds and executes each row in one transaction", func(t *testing.T) {
+		n, err := ExecBatch(ctx, db, `update users set age = ? where email = ?;`, [][]any{
+			{50, "alice@example.com"},
+			{51, "bob@example.com"},
+			{52, "nobody@example.com"},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), n)
+
+		var age int32
+		require.NoError(t, db.QueryRowContext(ctx, `select age from users where email = 'bob@example.com';`).Scan(&age))
+		assert.Equal(t, int32(51), age)
+	})
+
+	t.Run("Queries returning rows are rejected", func(t *testing.T) {
+		_, err := ExecBatch(ctx, db, `select * from users where id = ?;`, [][]any{{1}})
+		require.Error(t, err)
+
+		_, err = ExecBatch(ctx, db, `insert into users(email) values(?) returning id;`, [][]any{{"hal@example.com"}})
+		require.Error(t, err)
+		assert.Equal(t, 3, countUsers())
+	})
+}
@@ -4,6 +4,8 @@ package benchmarks
 
 import (
 	"fmt"
+	"math/rand/v2"
+	"strings"
 	"testing"
 )
 
@@ -161,3 +163,59 @@ func BenchmarkInsert_MultiValues(b *testing.B) {
 		})
 	}
 }
+
+// BenchmarkInsert_MultiValues10k measures a single INSERT ... VALUES statement
+// carrying 10,000 rows with explicit primary keys, once with the keys in
+// ascending order and once shuffled. MiniSQL inserts the rows of a multi-row
+// VALUES list in key order, so the shuffled case should stay close to the
+// ascending one instead of paying a full index descent per row. Values are
+// inlined as literals to stay under SQLite's bound parameter limit.
+func BenchmarkInsert_MultiValues10k(b *testing.B) {
+	const batchSize = 10_000
+
+	ascending := make([]int, batchSize)
+	for i := range ascending {
+		ascending[i] = i + 1
+	}
+	shuffled := make([]int, batchSize)
+	copy(shuffled, ascending)
+	rand.New(rand.NewPCG(1, 2)).Shuffle(len(shuffled), func(i, j int) {
+		shuffled[i], shuffled[j] = shuffled[j], shuffled[i]
+	})
+
+	buildInsert := func(ids []int) string {
+		var sb strings.Builder
+		sb.WriteString("insert into bench_rows (id, name, age, email) values ")
+		for i, id := range ids {
+			if i > 0 {
+				sb.WriteString(", ")
+			}
+			fmt.Fprintf(&sb, "(%d, 'user-%d', %d, 'user%d@example.com')", id, id, id%100, id)
+		}
+		return sb.String()
+	}
+
+	for _, keys := range []struct {
+		name string
+		ids  []int
+	}{
+		{"ascending", ascending},
+		{"shuffled", shuffled},
+	} {
+		query := buildInsert(keys.ids)
+		for _, d := range drivers {
+			b.Run(keys.name+"/"+d.name, func(b *testing.B) {
+				for range b.N {
+					b.StopTimer()
+					db, cleanup := openDB(b, d)
+					b.StartTimer()
+					mustExec(b, db, query)
+					b.StopTimer()
+					cleanup()
+					b.StartTimer()
+				}
+				b.ReportMetric(float64(batchSize), "rows/op")
+			})
+		}
+	}
+}
@@ -115,6 +115,70 @@ func BenchmarkSelect_Limit(b *testing.B) {
 	}
 }
 
+// BenchmarkSelect_OrderByPKDescLimit measures a "latest first" query: the
+// primary key is walked in reverse and the scan stops after LIMIT rows.
+func BenchmarkSelect_OrderByPKDescLimit(b *testing.B) {
+	const limit = 10
+	for _, d := range drivers {
+		b.Run(d.name, func(b *testing.B) {
+			db, cleanup := openDB(b, d)
+			defer cleanup()
+			seedRows(b, db, d, seedN)
+
+			var query string
+			switch d.name {
+			case "minisql":
+				query = `select id, name, age, email from "bench_rows" order by id desc limit 10`
+			default:
+				query = `SELECT id, name, age, email FROM bench_rows ORDER BY id DESC LIMIT 10`
+			}
+
+			stmt, err := db.Prepare(query)
+			if err != nil {
+				b.Fatalf("prepare: %v", err)
+			}
+			defer stmt.Close()
+
+			b.ResetTimer()
+			for range b.N {
+				rows, err := stmt.Query()
+				if err != nil {
+					b.Fatalf("query: %v", err)
+				}
+				var (
+					n      int
+					prevID int64 = 1<<63 - 1
+				)
+				for rows.Next() {
+					var (
+						rowID int64
+						name  string
+						age   int
+						email string
+					)
+					if err := rows.Scan(&rowID, &name, &age, &email); err != nil {
+						rows.Close()
+						b.Fatalf("scan: %v", err)
+					}
+					if rowID >= prevID {
+						rows.Close()
+						b.Fatalf("expected descending ids, got %d after %d", rowID, prevID)
+					}
+					prevID = rowID
+					n += 1
+				}
+				rows.Close()
+				if err := rows.Err(); err != nil {
+					b.Fatalf("rows err: %v", err)
+				}
+				if n != limit {
+					b.Fatalf("expected %d rows, got %d", limit, n)
+				}
+			}
+		})
+	}
+}
+
 // BenchmarkSelect_FullScan measures a sequential full-table scan with no WHERE
 // clause.
 func BenchmarkSelect_FullScan(b *testing.B) {
@@ -497,3 +561,85 @@ func BenchmarkSelect_RangeScan(b *testing.B) {
 		})
 	}
 }
+
+// BenchmarkSelect_TimeRangeMinMax measures a narrow range query on an
+// unindexed, time-ordered column of an append-only table, with and without
+// MINMAX page hints. With hints the scan skips every leaf page outside the
+// range; without them it reads the whole table. MINMAX is minisql-specific,
+// so there is no SQLite counterpart.
+func BenchmarkSelect_TimeRangeMinMax(b *testing.B) {
+	const (
+		startMicros = int64(1_700_000_000_000_000)
+		stepMicros  = int64(1_000_000)
+		window      = int64(100) // rows per query, 1% of seedN
+	)
+	variants := []struct {
+		name   string
+		minMax string
+	}{
+		{"minmax", " minmax"},
+		{"no_hint", ""},
+	}
+	for _, v := range variants {
+		b.Run("minisql/"+v.name, func(b *testing.B) {
+			db, cleanup := openDB(b, drivers[0])
+			defer cleanup()
+			mustExec(b, db, `create table "bench_events" (
+				id      int8 primary key autoincrement,
+				ts      int8 not null`+v.minMax+`,
+				payload varchar(255)
+			)`)
+
+			tx, err := db.Begin()
+			if err != nil {
+				b.Fatalf("begin: %v", err)
+			}
+			insert, err := tx.Prepare(`insert into "bench_events" (ts, payload) values (?, ?)`)
+			if err != nil {
+				_ = tx.Rollback()
+				b.Fatalf("prepare insert: %v", err)
+			}
+			for i := range seedN {
+				if _, err := insert.Exec(startMicros+int64(i)*stepMicros, fmt.Sprintf("event-%06d", i)); err != nil {
+					_ = tx.Rollback()
+					b.Fatalf("insert row %d: %v", i, err)
+				}
+			}
+			insert.Close()
+			if err := tx.Commit(); err != nil {
+				b.Fatalf("commit seed: %v", err)
+			}
+
+			stmt, err := db.Prepare(`select id, ts from "bench_events" where ts >= ? and ts < ?`)
+			if err != nil {
+				b.Fatalf("prepare: %v", err)
+			}
+			defer stmt.Close()
+
+			b.ResetTimer()
+			for i := range b.N {
+				lo := startMicros + int64(i%(seedN/int(window)))*window*stepMicros
+				rows, err := stmt.Query(lo, lo+window*stepMicros)
+				if err != nil {
+					b.Fatalf("query: %v", err)
+				}
+				var n int64
+				for rows.Next() {
+					var id, ts int64
+					if err := rows.Scan(&id, &ts); err != nil {
+						rows.Close()
+						b.Fatalf("scan: %v", err)
+					}
+					n += 1
+				}
+				rows.Close()
+				if err := rows.Err(); err != nil {
+					b.Fatalf("rows err: %v", err)
+				}
+				if n != window {
+					b.Fatalf("expected %d rows, got %d", window, n)
+				}
+			}
+		})
+	}
+}
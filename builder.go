@@ -0,0 +1,244 @@
+package minisql
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"io"
+
+	"github.com/RichardKnop/minisql/internal/minisql"
+)
+
+// Statement is a parsed or built SQL statement; see InsertInto, SelectFrom,
+// UpdateTable and DeleteFrom.
+type Statement = minisql.Statement
+
+// Statement builders. Each constructs the Statement the parser would produce
+// for the equivalent SQL; run it with ExecStatement or QueryStatement.
+type (
+	InsertBuilder = minisql.InsertBuilder
+	SelectBuilder = minisql.SelectBuilder
+	UpdateBuilder = minisql.UpdateBuilder
+	DeleteBuilder = minisql.DeleteBuilder
+)
+
+// Errors returned by the statement builders from Build.
+var (
+	ErrBuilderNoTable     = minisql.ErrBuilderNoTable
+	ErrBuilderNoColumns   = minisql.ErrBuilderNoColumns
+	ErrBuilderNoValues    = minisql.ErrBuilderNoValues
+	ErrBuilderValueCount  = minisql.ErrBuilderValueCount
+	ErrBuilderNoUpdates   = minisql.ErrBuilderNoUpdates
+	ErrBuilderInvalidSize = minisql.ErrBuilderInvalidSize
+)
+
+// Condition is one comparison in a WHERE clause; build it with the FieldIs*
+// functions.
+type Condition = minisql.Condition
+
+// Field names a column in a condition.
+type Field = minisql.Field
+
+// OperandType tells a condition how to read its value.
+type OperandType = minisql.OperandType
+
+// Operand types for the value of a FieldIs* condition.
+const (
+	OperandPlaceholder  = minisql.OperandPlaceholder
+	OperandQuotedString = minisql.OperandQuotedString
+	OperandBoolean      = minisql.OperandBoolean
+	OperandInteger      = minisql.OperandInteger
+	OperandFloat        = minisql.OperandFloat
+)
+
+// Direction is the sort direction of SelectBuilder.OrderBy.
+type Direction = minisql.Direction
+
+// Sort directions.
+const (
+	Asc  = minisql.Asc
+	Desc = minisql.Desc
+)
+
+// InsertInto starts an INSERT INTO table statement.
+//
+// Example:
+//
+//	stmt, err := minisql.InsertInto("users").
+//		Columns("email", "name").
+//		Values("alice@example.com", "Alice").
+//		Build()
+func InsertInto(table string) *InsertBuilder { return minisql.InsertInto(table) }
+
+// SelectFrom starts a SELECT … FROM table statement.
+func SelectFrom(table string) *SelectBuilder { return minisql.SelectFrom(table) }
+
+// UpdateTable starts an UPDATE table statement.
+func UpdateTable(table string) *UpdateBuilder { return minisql.UpdateTable(table) }
+
+// DeleteFrom starts a DELETE FROM table statement.
+func DeleteFrom(table string) *DeleteBuilder { return minisql.DeleteFrom(table) }
+
+// FieldIsEqual creates a field = value condition.
+func FieldIsEqual(field Field, operandType OperandType, value any) Condition {
+	return minisql.FieldIsEqual(field, operandType, value)
+}
+
+// FieldIsNotEqual creates a field != value condition.
+func FieldIsNotEqual(field Field, operandType OperandType, value any) Condition {
+	return minisql.FieldIsNotEqual(field, operandType, value)
+}
+
+// FieldIsGreater creates a field > value condition.
+func FieldIsGreater(field Field, operandType OperandType, value any) Condition {
+	return minisql.FieldIsGreater(field, operandType, value)
+}
+
+// FieldIsGreaterOrEqual creates a field >= value condition.
+func FieldIsGreaterOrEqual(field Field, operandType OperandType, value any) Condition {
+	return minisql.FieldIsGreaterOrEqual(field, operandType, value)
+}
+
+// FieldIsLess creates a field < value condition.
+func FieldIsLess(field Field, operandType OperandType, value any) Condition {
+	return minisql.FieldIsLess(field, operandType, value)
+}
+
+// FieldIsLessOrEqual creates a field <= value condition.
+func FieldIsLessOrEqual(field Field, operandType OperandType, value any) Condition {
+	return minisql.FieldIsLessOrEqual(field, operandType, value)
+}
+
+// FieldIsLike creates a field LIKE pattern condition.
+func FieldIsLike(field Field, operandType OperandType, value any) Condition {
+	return minisql.FieldIsLike(field, operandType, value)
+}
+
+// FieldIsNotLike creates a field NOT LIKE pattern condition.
+func FieldIsNotLike(field Field, operandType OperandType, value any) Condition {
+	return minisql.FieldIsNotLike(field, operandType, value)
+}
+
+// FieldIsInAny creates a field IN (values...) condition.
+func FieldIsInAny(field Field, values ...any) Condition {
+	return minisql.FieldIsInAny(field, values...)
+}
+
+// FieldIsNotInAny creates a field NOT IN (values...) condition.
+func FieldIsNotInAny(field Field, values ...any) Condition {
+	return minisql.FieldIsNotInAny(field, values...)
+}
+
+// FieldIsBetween creates a field BETWEEN low AND high condition.
+func FieldIsBetween(field Field, low, high any) Condition {
+	return minisql.FieldIsBetween(field, low, high)
+}
+
+// FieldIsNotBetween creates a field NOT BETWEEN low AND high condition.
+func FieldIsNotBetween(field Field, low, high any) Condition {
+	return minisql.FieldIsNotBetween(field, low, high)
+}
+
+// FieldIsNull creates a field IS NULL condition.
+func FieldIsNull(field Field) Condition { return minisql.FieldIsNull(field) }
+
+// FieldIsNotNull creates a field IS NOT NULL condition.
+func FieldIsNotNull(field Field) Condition { return minisql.FieldIsNotNull(field) }
+
+// ExecStatement executes a statement built with InsertInto, UpdateTable or
+// DeleteFrom on db, which must have been opened with
+// sql.Open("minisql", dsn). The statement runs in its own transaction, like
+// db.ExecContext. The returned sql.Result is a Result, so the keys collected
+// by a statement built with ReturningKeys are available from
+// Result.AffectedKeys.
+//
+// Example:
+//
+//	stmt, err := minisql.UpdateTable("users").
+//		Set("name", "Bob").
+//		Where(minisql.FieldIsEqual(minisql.Field{Name: "id"}, minisql.OperandInteger, 2)).
+//		Build()
+//	if err != nil { ... }
+//	res, err := minisql.ExecStatement(ctx, db, stmt)
+func ExecStatement(ctx context.Context, db *sql.DB, stmt Statement) (sql.Result, error) {
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("minisql: ExecStatement: acquire connection: %w", err)
+	}
+	defer conn.Close()
+
+	var result Result
+	err = conn.Raw(func(c any) error {
+		mc, ok := c.(*Conn)
+		if !ok {
+			return fmt.Errorf("minisql: ExecStatement: unexpected connection type %T", c)
+		}
+		res, err := mc.executeStatement(ctx, stmt)
+		if err != nil {
+			return err
+		}
+		result = Result{
+			rowsAffected: int64(res.RowsAffected),
+			lastInsertID: res.LastInsertID,
+			affectedKeys: driverKeys(res.AffectedKeys),
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// QueryStatement runs a statement built with SelectFrom on db and returns the
+// result column names and rows. Values have the Go types database/sql scans
+// into an any: int64, float64, bool, string, time.Time or nil for NULL.
+//
+// Example:
+//
+//	stmt, err := minisql.SelectFrom("users").Columns("id", "name").OrderBy("id", minisql.Asc).Build()
+//	if err != nil { ... }
+//	columns, rows, err := minisql.QueryStatement(ctx, db, stmt)
+func QueryStatement(ctx context.Context, db *sql.DB, stmt Statement) ([]string, [][]any, error) {
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("minisql: QueryStatement: acquire connection: %w", err)
+	}
+	defer conn.Close()
+
+	var (
+		columns []string
+		values  [][]any
+	)
+	err = conn.Raw(func(c any) error {
+		mc, ok := c.(*Conn)
+		if !ok {
+			return fmt.Errorf("minisql: QueryStatement: unexpected connection type %T", c)
+		}
+		rows, err := mc.queryStatement(ctx, stmt)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		columns = rows.Columns()
+		dest := make([]driver.Value, len(columns))
+		for {
+			if err := rows.Next(dest); err == io.EOF {
+				return nil
+			} else if err != nil {
+				return err
+			}
+			row := make([]any, len(dest))
+			for i, value := range dest {
+				row[i] = value
+			}
+			values = append(values, row)
+		}
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return columns, values, nil
+}
@@ -26,7 +26,9 @@ Options:
   -c <query>      Execute a single SQL statement and exit (no shell).
                   May be specified multiple times to run several statements.
   -csv            Set output mode to CSV (default: table).
-  -o <file>       Write query output to a file in CSV format (implies -csv).
+  -json           Set output mode to JSON.
+  -o <file>       Write query output to a file in CSV format (implies -csv
+                  unless -json is given).
                   Errors and status messages are still printed to stderr.
   -version        Print version information and exit.
   -h, --help      Show this message.
@@ -36,6 +38,7 @@ Examples:
   minisql -c 'select * from "users"' my.db
   minisql -c 'create table "t" (id int8)' -c 'insert into "t" values (1)' my.db
   minisql -csv -c 'select * from "users"' my.db
+  minisql -json -c 'select * from "users"' my.db | jq '.[].name'
   minisql -o report.csv -c 'select * from "users"' my.db
 `
 
@@ -47,11 +50,13 @@ func run() int {
 	var (
 		queries     multiFlag
 		csvMode     bool
+		jsonMode    bool
 		outputFile  string
 		showVersion bool
 	)
 	flag.Var(&queries, "c", "SQL statement to execute (may be repeated)")
 	flag.BoolVar(&csvMode, "csv", false, "output in CSV format")
+	flag.BoolVar(&jsonMode, "json", false, "output in JSON format")
 	flag.StringVar(&outputFile, "o", "", "write query output to file (implies -csv)")
 	flag.BoolVar(&showVersion, "version", false, "print version and exit")
 	flag.Usage = func() { fmt.Fprint(os.Stderr, usage) }
@@ -88,6 +93,9 @@ func run() int {
 	if csvMode || outputFile != "" {
 		sh.mode = modeCSV
 	}
+	if jsonMode {
+		sh.mode = modeJSON
+	}
 	if outputFile != "" {
 		f, err := os.Create(outputFile)
 		if err != nil {
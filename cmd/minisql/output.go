@@ -1,7 +1,9 @@
 package main
 
 import (
+	"database/sql"
 	"encoding/csv"
+	"encoding/json"
 	"fmt"
 	"io"
 	"strings"
@@ -13,6 +15,7 @@ type outputMode int
 const (
 	modeTable outputMode = iota
 	modeCSV
+	modeJSON
 )
 
 // printResult writes query results to w in the configured mode.
@@ -83,3 +86,71 @@ func printCSV(w io.Writer, cols []string, rows [][]string) {
 	}
 	cw.Flush()
 }
+
+// jsonColumnTypes returns the SQL type name of every result column, or empty
+// names when the driver cannot report them.
+func jsonColumnTypes(rows *sql.Rows) []string {
+	types, err := rows.ColumnTypes()
+	if err != nil {
+		return nil
+	}
+	names := make([]string, len(types))
+	for i, t := range types {
+		names[i] = t.DatabaseTypeName()
+	}
+	return names
+}
+
+// printJSON writes rows as a JSON array with one object per row, keyed by
+// column name in result order. Numbers and booleans keep their JSON types,
+// NULL becomes null and JSON or VECTOR columns are embedded as JSON values
+// rather than strings.
+func printJSON(w io.Writer, cols, types []string, rows [][]any) {
+	keys := make([][]byte, len(cols))
+	for i, col := range cols {
+		keys[i], _ = json.Marshal(col)
+	}
+
+	var sb strings.Builder
+	sb.WriteString("[")
+	for r, row := range rows {
+		if r > 0 {
+			sb.WriteString(",")
+		}
+		sb.WriteString("\n  {")
+		for i, v := range row {
+			if i > 0 {
+				sb.WriteString(", ")
+			}
+			sb.Write(keys[i])
+			sb.WriteString(": ")
+			var typeName string
+			if i < len(types) {
+				typeName = types[i]
+			}
+			sb.Write(jsonValue(v, typeName))
+		}
+		sb.WriteString("}")
+	}
+	if len(rows) > 0 {
+		sb.WriteString("\n")
+	}
+	sb.WriteString("]\n")
+	fmt.Fprint(w, sb.String())
+}
+
+// jsonValue encodes a scanned value of a column with the given SQL type name.
+func jsonValue(v any, typeName string) []byte {
+	if text, ok := v.(string); ok && (typeName == "JSON" || typeName == "VECTOR") && json.Valid([]byte(text)) {
+		return []byte(text)
+	}
+	if b, ok := v.([]byte); ok {
+		v = string(b)
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		// NaN and infinities have no JSON number form.
+		data, _ = json.Marshal(formatValue(v))
+	}
+	return data
+}
@@ -2,18 +2,22 @@ package main
 
 import (
 	"bufio"
+	"context"
 	"database/sql"
 	"errors"
 	"fmt"
 	"io"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 	"unicode"
 
 	"github.com/mattn/go-isatty"
 	"github.com/peterh/liner"
+
+	"github.com/RichardKnop/minisql"
 )
 
 const (
@@ -190,29 +194,74 @@ func isSelectLike(query string) bool {
 	return hasReturning(strings.ToUpper(query))
 }
 
+// isVacuum reports whether query is a VACUUM statement, which reports
+// reclaimed pages rather than affected rows.
+func isVacuum(query string) bool {
+	fields := strings.Fields(strings.TrimSuffix(strings.TrimSpace(query), ";"))
+	return len(fields) == 1 && strings.EqualFold(fields[0], "VACUUM")
+}
+
 // hasReturning does a lightweight scan for a RETURNING keyword outside quotes.
 func hasReturning(upper string) bool {
 	return strings.Contains(upper, "RETURNING")
 }
 
 func (s *shell) exec(query string) {
-	start := time.Now()
+	timing := s.startTiming()
 
 	if isSelectLike(query) {
-		s.execQuery(query, start)
+		s.execQuery(query, timing)
 	} else {
-		s.execStatement(query, start)
+		s.execStatement(query, timing)
+	}
+}
+
+// queryTiming holds what .timer needs to report on one statement: when it
+// started and the engine metrics just before it ran.
+type queryTiming struct {
+	start   time.Time
+	before  minisql.Metrics
+	metrics bool
+}
+
+func (s *shell) startTiming() queryTiming {
+	var timing queryTiming
+	if s.timer {
+		before, err := minisql.ReadMetrics(context.Background(), s.db)
+		timing.before, timing.metrics = before, err == nil
+	}
+	timing.start = time.Now()
+	return timing
+}
+
+// printTiming reports the elapsed time and, when metrics are available, the
+// pages the statement read from the page cache and from disk. Any Rows must
+// be closed first: the shell holds a single connection.
+func (s *shell) printTiming(timing queryTiming) {
+	if !s.timer {
+		return
+	}
+	elapsed := time.Since(timing.start)
+	if !timing.metrics {
+		fmt.Fprintf(s.errOut, "Time: %.3fs\n", elapsed.Seconds())
+		return
+	}
+	after, err := minisql.ReadMetrics(context.Background(), s.db)
+	if err != nil {
+		fmt.Fprintf(s.errOut, "Time: %.3fs\n", elapsed.Seconds())
+		return
 	}
+	fmt.Fprintf(s.errOut, "Time: %.3fs (pages: %d cached, %d read)\n", elapsed.Seconds(),
+		after.PageCacheHits-timing.before.PageCacheHits,
+		after.PageCacheMisses-timing.before.PageCacheMisses)
 }
 
 // execQuery runs statements that return rows (SELECT, EXPLAIN, WITH, RETURNING).
-func (s *shell) execQuery(query string, start time.Time) {
+func (s *shell) execQuery(query string, timing queryTiming) {
 	rows, err := s.db.Query(query)
 	if err != nil {
 		fmt.Fprintf(s.errOut, "Error: %v\n", err)
-		if s.timer {
-			fmt.Fprintf(s.errOut, "Time: %.3fs\n", time.Since(start).Seconds())
-		}
+		s.printTiming(timing)
 		return
 	}
 	defer rows.Close()
@@ -223,7 +272,15 @@ func (s *shell) execQuery(query string, start time.Time) {
 		return
 	}
 
-	var resultRows [][]string
+	var (
+		resultRows [][]string
+		jsonRows   [][]any
+		jsonTypes  []string
+	)
+	if s.mode == modeJSON {
+		// Column types are only available until the rows are exhausted.
+		jsonTypes = jsonColumnTypes(rows)
+	}
 	vals := make([]any, len(cols))
 	ptrs := make([]any, len(cols))
 	for i := range vals {
@@ -235,8 +292,16 @@ func (s *shell) execQuery(query string, start time.Time) {
 			fmt.Fprintf(s.errOut, "Error: %v\n", err)
 			return
 		}
+		if s.mode == modeJSON {
+			jsonRows = append(jsonRows, append([]any(nil), vals...))
+			continue
+		}
 		row := make([]string, len(cols))
 		for i, v := range vals {
+			if v == nil && s.mode == modeCSV {
+				// An empty field is how CSV consumers expect NULL.
+				continue
+			}
 			row[i] = formatValue(v)
 		}
 		resultRows = append(resultRows, row)
@@ -245,35 +310,38 @@ func (s *shell) execQuery(query string, start time.Time) {
 		fmt.Fprintf(s.errOut, "Error: %v\n", err)
 		return
 	}
+	rows.Close()
 
 	if len(cols) > 0 {
-		printResult(s.out, cols, resultRows, s.mode)
+		if s.mode == modeJSON {
+			printJSON(s.out, cols, jsonTypes, jsonRows)
+		} else {
+			printResult(s.out, cols, resultRows, s.mode)
+		}
 	}
 
-	if s.timer {
-		fmt.Fprintf(s.errOut, "Time: %.3fs\n", time.Since(start).Seconds())
-	}
+	s.printTiming(timing)
 }
 
 // execStatement runs DML/DDL via db.Exec and reports rows affected.
-func (s *shell) execStatement(query string, start time.Time) {
+func (s *shell) execStatement(query string, timing queryTiming) {
 	result, err := s.db.Exec(query)
 	if err != nil {
 		fmt.Fprintf(s.errOut, "Error: %v\n", err)
-		if s.timer {
-			fmt.Fprintf(s.errOut, "Time: %.3fs\n", time.Since(start).Seconds())
-		}
+		s.printTiming(timing)
 		return
 	}
 
 	n, err := result.RowsAffected()
 	if err == nil && n > 0 {
-		fmt.Fprintf(s.errOut, "%d row(s) affected\n", n)
+		if isVacuum(query) {
+			fmt.Fprintf(s.errOut, "%d page(s) reclaimed\n", n)
+		} else {
+			fmt.Fprintf(s.errOut, "%d row(s) affected\n", n)
+		}
 	}
 
-	if s.timer {
-		fmt.Fprintf(s.errOut, "Time: %.3fs\n", time.Since(start).Seconds())
-	}
+	s.printTiming(timing)
 }
 
 func formatValue(v any) string {
@@ -314,8 +382,10 @@ func (s *shell) dotCommand(cmd string) {
 			s.mode = modeTable
 		case "csv":
 			s.mode = modeCSV
+		case "json":
+			s.mode = modeJSON
 		default:
-			fmt.Fprintf(s.errOut, "Error: unknown mode %q (choose: table, csv)\n", fields[1])
+			fmt.Fprintf(s.errOut, "Error: unknown mode %q (choose: table, csv, json)\n", fields[1])
 		}
 
 	case ".timer":
@@ -333,57 +403,262 @@ func (s *shell) dotCommand(cmd string) {
 		}
 
 	case ".tables":
-		s.exec(`SELECT name FROM "minisql_schema" WHERE type = 1 AND name != 'minisql_schema' ORDER BY name`)
+		s.printTables()
 
 	case ".schema":
-		var query string
+		var table string
+		if len(fields) >= 2 {
+			table = fields[1]
+		}
+		s.printSchema(table)
+
+	case ".count":
 		if len(fields) < 2 {
-			query = `SELECT sql FROM "minisql_schema" WHERE type = 1 AND name != 'minisql_schema' ORDER BY name`
-		} else {
-			query = fmt.Sprintf(`SELECT sql FROM "minisql_schema" WHERE name = %s AND type = 1`, quoteString(fields[1]))
+			fmt.Fprintln(s.errOut, "Error: usage: .count TABLE")
+			return
+		}
+		s.printRowCount(fields[1])
+
+	case ".stats":
+		s.printStats()
+
+	case ".backup":
+		if len(fields) < 2 {
+			fmt.Fprintln(s.errOut, "Error: usage: .backup FILE")
+			return
+		}
+		s.backup(fields[1])
+
+	case ".import":
+		s.importCSV(fields[1:])
+
+	case ".dump":
+		if err := minisql.Dump(context.Background(), s.db, s.out, fields[1:]...); err != nil {
+			fmt.Fprintf(s.errOut, "Error: %v\n", err)
 		}
-		s.printDDL(query)
 
 	default:
 		fmt.Fprintf(s.errOut, "Error: unknown dot command %q — try .help\n", fields[0])
 	}
 }
 
-// printDDL runs query (which must SELECT a single text column) and prints each
-// value as raw text, which is the natural display for CREATE statements.
-func (s *shell) printDDL(query string) {
+// Schema object types stored in the type column of minisql_schema.
+const (
+	schemaTypeTable          = 1
+	schemaTypeSecondaryIndex = 4
+)
+
+// printSchema prints the stored CREATE TABLE statement of table, or of every
+// user table when table is empty, each followed by the CREATE INDEX
+// statements of its secondary indexes. Primary keys and unique constraints
+// are part of the table definition.
+func (s *shell) printSchema(table string) {
+	query := fmt.Sprintf(`SELECT type, name, tbl_name, sql FROM "minisql_schema" WHERE type = %d OR type = %d ORDER BY name`,
+		schemaTypeTable, schemaTypeSecondaryIndex)
 	rows, err := s.db.Query(query)
 	if err != nil {
 		fmt.Fprintf(s.errOut, "Error: %v\n", err)
 		return
 	}
 	defer rows.Close()
-	first := true
+
+	var (
+		tables  []string
+		ddl     = make(map[string]string)
+		indexes = make(map[string][]string)
+	)
 	for rows.Next() {
-		var ddl string
-		if err := rows.Scan(&ddl); err != nil {
+		var (
+			kind      int64
+			name      string
+			tableName sql.NullString
+			stmt      sql.NullString
+		)
+		if err := rows.Scan(&kind, &name, &tableName, &stmt); err != nil {
 			fmt.Fprintf(s.errOut, "Error: %v\n", err)
 			return
 		}
-		if !first {
-			fmt.Fprintln(s.out)
+		clean := strings.TrimRight(strings.TrimSpace(stmt.String), ";") + ";"
+		switch {
+		case kind == schemaTypeTable && name != "minisql_schema":
+			if table == "" || name == table {
+				tables = append(tables, name)
+				ddl[name] = clean
+			}
+		case kind == schemaTypeSecondaryIndex && stmt.Valid:
+			indexes[tableName.String] = append(indexes[tableName.String], clean)
 		}
-		first = false
-		clean := strings.TrimRight(strings.TrimSpace(ddl), ";")
-		fmt.Fprintln(s.out, clean+";")
 	}
 	if err := rows.Err(); err != nil {
 		fmt.Fprintf(s.errOut, "Error: %v\n", err)
+		return
+	}
+
+	for i, name := range tables {
+		if i > 0 {
+			fmt.Fprintln(s.out)
+		}
+		fmt.Fprintln(s.out, ddl[name])
+		for _, index := range indexes[name] {
+			fmt.Fprintln(s.out, index)
+		}
+	}
+}
+
+// printTables prints a result set with one row per user table: its column,
+// row and page counts and its indexes. It honours the output mode like a
+// query result does.
+func (s *shell) printTables() {
+	tables, err := minisql.Tables(context.Background(), s.db)
+	if err != nil {
+		fmt.Fprintf(s.errOut, "Error: %v\n", err)
+		return
+	}
+
+	cols := []string{"name", "columns", "rows", "pages", "indexes"}
+	if s.mode == modeJSON {
+		rows := make([][]any, 0, len(tables))
+		for _, t := range tables {
+			rows = append(rows, []any{t.Name, t.Columns, t.Rows, t.Pages, strings.Join(t.Indexes, ", ")})
+		}
+		printJSON(s.out, cols, nil, rows)
+		return
+	}
+	rows := make([][]string, 0, len(tables))
+	for _, t := range tables {
+		rows = append(rows, []string{
+			t.Name,
+			strconv.Itoa(t.Columns),
+			strconv.FormatInt(t.Rows, 10),
+			strconv.Itoa(t.Pages),
+			strings.Join(t.Indexes, ", "),
+		})
+	}
+	printResult(s.out, cols, rows, s.mode)
+}
+
+// printRowCount prints the number of rows in table from the engine's running
+// count, without scanning the table.
+func (s *shell) printRowCount(table string) {
+	n, err := minisql.RowCount(context.Background(), s.db, table)
+	if err != nil {
+		fmt.Fprintf(s.errOut, "Error: %v\n", err)
+		return
+	}
+	fmt.Fprintln(s.out, n)
+}
+
+// backup writes an online, point-in-time copy of the database to path.
+func (s *shell) backup(path string) {
+	if err := minisql.Backup(context.Background(), s.db, path); err != nil {
+		fmt.Fprintf(s.errOut, "Error: %v\n", err)
+	}
+}
+
+// importCSV parses the arguments of .import and loads a CSV file into a
+// table, reporting how many rows were imported.
+func (s *shell) importCSV(args []string) {
+	var (
+		opts       []minisql.ImportOption
+		positional []string
+		skipped    int
+	)
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--delimiter":
+			if i+1 >= len(args) {
+				fmt.Fprintln(s.errOut, "Error: --delimiter requires a value")
+				return
+			}
+			i++
+			delimiter, err := parseDelimiter(args[i])
+			if err != nil {
+				fmt.Fprintf(s.errOut, "Error: %v\n", err)
+				return
+			}
+			opts = append(opts, minisql.WithCSVDelimiter(delimiter))
+		case "--empty-null":
+			opts = append(opts, minisql.WithCSVEmptyAsNull())
+		case "--skip-header":
+			opts = append(opts, minisql.WithCSVSkipHeader())
+		case "--no-header":
+			opts = append(opts, minisql.WithCSVNoHeader())
+		case "--continue":
+			opts = append(opts, minisql.WithContinueOnError(func(_ int, err error) {
+				skipped++
+				fmt.Fprintf(s.errOut, "Warning: skipped %v\n", err)
+			}))
+		default:
+			if strings.HasPrefix(args[i], "--") {
+				fmt.Fprintf(s.errOut, "Error: unknown .import option %q\n", args[i])
+				return
+			}
+			positional = append(positional, args[i])
+		}
+	}
+	if len(positional) != 2 {
+		fmt.Fprintln(s.errOut, "Error: usage: .import [--delimiter C] [--empty-null] [--skip-header|--no-header] [--continue] FILE TABLE")
+		return
+	}
+
+	f, err := os.Open(positional[0])
+	if err != nil {
+		fmt.Fprintf(s.errOut, "Error: %v\n", err)
+		return
+	}
+	defer f.Close()
+
+	n, err := minisql.ImportCSV(context.Background(), s.db, positional[1], f, opts...)
+	if err != nil {
+		fmt.Fprintf(s.errOut, "Error: %v\n", err)
+		return
+	}
+	if skipped > 0 {
+		fmt.Fprintf(s.errOut, "%d row(s) imported, %d skipped\n", n, skipped)
+		return
+	}
+	fmt.Fprintf(s.errOut, "%d row(s) imported\n", n)
+}
+
+// parseDelimiter accepts a single character, or "tab" / "\t" for a tab.
+func parseDelimiter(value string) (rune, error) {
+	if value == "tab" || value == `\t` {
+		return '\t', nil
+	}
+	runes := []rune(value)
+	if len(runes) != 1 {
+		return 0, fmt.Errorf("delimiter must be a single character, got %q", value)
+	}
+	return runes[0], nil
+}
+
+// printStats prints the engine's cumulative query and cache counters.
+func (s *shell) printStats() {
+	m, err := minisql.ReadMetrics(context.Background(), s.db)
+	if err != nil {
+		fmt.Fprintf(s.errOut, "Error: %v\n", err)
+		return
 	}
+	fmt.Fprintf(s.errOut, "queries:          %d (%d slow)\n", m.QueriesTotal, m.QueriesSlow)
+	fmt.Fprintf(s.errOut, "statement cache:  %d hits, %d misses\n", m.StmtCacheHits, m.StmtCacheMisses)
+	fmt.Fprintf(s.errOut, "page cache:       %d hits, %d misses (%.1f%% hit ratio), %d/%d pages, %d evictions\n",
+		m.PageCacheHits, m.PageCacheMisses, 100*m.PageCacheHitRatio(), m.PageCacheSize, m.PageCacheCapacity, m.PageCacheEvictions)
 }
 
 func (s *shell) printHelp() {
 	fmt.Fprint(s.out, `Dot commands (single-line only):
   .help              Show this message
-  .tables            List user tables
-  .schema [table]    Show CREATE statement(s)
-  .mode MODE         Set output mode: table (default), csv
-  .timer on|off      Toggle query timing
+  .tables            List user tables with column, row and page counts
+  .schema [table]    Show CREATE TABLE and CREATE INDEX statement(s)
+  .count TABLE       Show the number of rows in a table
+  .mode MODE         Set output mode: table (default), csv, json
+  .timer on|off      Toggle query timing and page counts
+  .stats             Show query and cache statistics
+  .backup FILE       Write an online backup of the database to FILE
+  .dump [TABLE...]   Print SQL that recreates the database or tables
+  .import FILE TABLE Import CSV rows from FILE into TABLE; options:
+                       --delimiter C, --empty-null, --skip-header,
+                       --no-header, --continue
   .quit / .exit      Exit the shell
 
 SQL statements are terminated with a semicolon (;).
@@ -395,6 +670,8 @@ func (s *shell) modeName() string {
 	switch s.mode {
 	case modeCSV:
 		return "csv"
+	case modeJSON:
+		return "json"
 	default:
 		return "table"
 	}
@@ -3,7 +3,9 @@ package main
 import (
 	"bufio"
 	"database/sql"
+	"encoding/json"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -200,6 +202,11 @@ func TestShell_Exec_Timer(t *testing.T) {
 	sh.timer = true
 	sh.exec(`select * from "t"`)
 	assert.Contains(t, out.String(), "Time:")
+	assert.Regexp(t, `pages: \d+ cached, \d+ read`, out.String())
+
+	out.Reset()
+	sh.exec(`insert into "t" (id) values (1)`)
+	assert.Regexp(t, `Time: \d+\.\d{3}s \(pages: \d+ cached, \d+ read\)`, out.String())
 }
 
 func TestShell_Exec_CSV(t *testing.T) {
@@ -216,6 +223,44 @@ func TestShell_Exec_CSV(t *testing.T) {
 	assert.Contains(t, out.String(), "1,alice")
 }
 
+func TestShell_Exec_CSV_NullIsEmpty(t *testing.T) {
+	db := openTestDB(t)
+	_, err := db.Exec(`create table "t" (id int8, name varchar(255) null)`)
+	require.NoError(t, err)
+	_, err = db.Exec(`insert into "t" (id, name) values (1, null)`)
+	require.NoError(t, err)
+
+	sh, out := newTestShell(db, "")
+	sh.mode = modeCSV
+	sh.exec(`select * from "t"`)
+	assert.Equal(t, "id,name\n1,\n", out.String())
+}
+
+func TestShell_Exec_JSON(t *testing.T) {
+	db := openTestDB(t)
+	_, err := db.Exec(`create table "t" (id int8, name varchar(255) null, score double null, active boolean, doc json null)`)
+	require.NoError(t, err)
+	_, err = db.Exec(`insert into "t" (id, name, score, active, doc) values (1, 'alice "a"', 1.5, true, '{"tags": ["x"]}'), (2, null, null, false, null)`)
+	require.NoError(t, err)
+
+	sh, out := newTestShell(db, "")
+	sh.dotCommand(".mode json")
+	sh.exec(`select * from "t" order by id`)
+
+	var got []map[string]any
+	require.NoError(t, json.Unmarshal([]byte(out.String()), &got))
+	assert.Equal(t, []map[string]any{
+		{"id": float64(1), "name": `alice "a"`, "score": 1.5, "active": true, "doc": map[string]any{"tags": []any{"x"}}},
+		{"id": float64(2), "name": nil, "score": nil, "active": false, "doc": nil},
+	}, got)
+	assert.True(t, strings.HasPrefix(out.String(), `[
+  {"id": 1, "name": `))
+
+	out.Reset()
+	sh.exec(`select * from "t" where id = 3`)
+	assert.Equal(t, "[]\n", out.String())
+}
+
 // --- shell.dotCommand ---
 
 func TestShell_DotMode(t *testing.T) {
@@ -225,6 +270,9 @@ func TestShell_DotMode(t *testing.T) {
 	sh.dotCommand(".mode csv")
 	assert.Equal(t, modeCSV, sh.mode)
 
+	sh.dotCommand(".mode json")
+	assert.Equal(t, modeJSON, sh.mode)
+
 	sh.dotCommand(".mode table")
 	assert.Equal(t, modeTable, sh.mode)
 }
@@ -283,16 +331,214 @@ func TestShell_DotUnknown(t *testing.T) {
 	assert.Contains(t, out.String(), "Error:")
 }
 
+func TestShell_DotStats(t *testing.T) {
+	db := openTestDB(t)
+	_, err := db.Exec(`create table "users" (id int8)`)
+	require.NoError(t, err)
+
+	sh, out := newTestShell(db, "")
+	for range 3 {
+		sh.exec(`select id from "users";`)
+	}
+	out.Reset()
+	sh.dotCommand(".stats")
+	got := out.String()
+	assert.Contains(t, got, "queries:          4 (0 slow)")
+	assert.Contains(t, got, "statement cache:  2 hits, 2 misses")
+	assert.Contains(t, got, "hit ratio), ")
+	assert.Contains(t, got, "/2000 pages, 0 evictions")
+}
+
 func TestShell_DotTables(t *testing.T) {
 	db := openTestDB(t)
 	_, err := db.Exec(`create table "users" (id int8)`)
 	require.NoError(t, err)
 
+	_, err = db.Exec(`insert into "users" (id) values (1), (2), (3)`)
+	require.NoError(t, err)
+
 	sh, out := newTestShell(db, "")
 	sh.dotCommand(".tables")
 	got := out.String()
-	assert.Contains(t, got, "users")
+	assert.Contains(t, got, "name   columns  rows  pages  indexes")
+	assert.Contains(t, got, "users  1        3     1")
 	assert.NotContains(t, got, "minisql_schema")
+
+	out.Reset()
+	sh.mode = modeCSV
+	sh.dotCommand(".tables")
+	assert.Equal(t, "name,columns,rows,pages,indexes\nusers,1,3,1,\n", out.String())
+
+	out.Reset()
+	sh.mode = modeJSON
+	sh.dotCommand(".tables")
+	assert.Equal(t, "[\n  {\"name\": \"users\", \"columns\": 1, \"rows\": 3, \"pages\": 1, \"indexes\": \"\"}\n]\n", out.String())
+}
+
+func TestShell_DotCount(t *testing.T) {
+	db := openTestDB(t)
+	_, err := db.Exec(`create table "users" (id int8)`)
+	require.NoError(t, err)
+	_, err = db.Exec(`insert into "users" (id) values (1), (2), (3)`)
+	require.NoError(t, err)
+
+	sh, out := newTestShell(db, "")
+	sh.dotCommand(".count users")
+	assert.Equal(t, "3\n", out.String())
+
+	out.Reset()
+	sh.dotCommand(".count nosuchtable")
+	assert.Contains(t, out.String(), "Error:")
+
+	out.Reset()
+	sh.dotCommand(".count")
+	assert.Contains(t, out.String(), "Error: usage: .count TABLE")
+}
+
+func TestShell_DotBackup(t *testing.T) {
+	db := openTestDB(t)
+	_, err := db.Exec(`create table "users" (id int8)`)
+	require.NoError(t, err)
+	_, err = db.Exec(`insert into "users" (id) values (1), (2), (3)`)
+	require.NoError(t, err)
+
+	backupPath := filepath.Join(t.TempDir(), "backup.db")
+	sh, out := newTestShell(db, "")
+	sh.dotCommand(".backup " + backupPath)
+	assert.Empty(t, out.String())
+
+	backup, err := sql.Open("minisql", backupPath)
+	require.NoError(t, err)
+	backup.SetMaxOpenConns(1)
+	t.Cleanup(func() { backup.Close() })
+	var n int64
+	require.NoError(t, backup.QueryRow(`select count(*) from "users"`).Scan(&n))
+	assert.Equal(t, int64(3), n)
+
+	sh.dotCommand(".backup")
+	assert.Contains(t, out.String(), "Error: usage: .backup FILE")
+}
+
+func TestShell_DotImport(t *testing.T) {
+	db := openTestDB(t)
+	_, err := db.Exec(`create table "users" (id int8, name varchar(50), email varchar(100) null)`)
+	require.NoError(t, err)
+
+	csvPath := filepath.Join(t.TempDir(), "users.csv")
+	require.NoError(t, os.WriteFile(csvPath, []byte("id|name|email\n1|alice|\n2|bob|bob@example.com\n"), 0o600))
+
+	sh, out := newTestShell(db, "")
+	sh.dotCommand(".import --delimiter | --empty-null " + csvPath + " users")
+	assert.Equal(t, "2 row(s) imported\n", out.String())
+
+	var n int64
+	require.NoError(t, db.QueryRow(`select count(*) from "users" where email is null`).Scan(&n))
+	assert.Equal(t, int64(1), n)
+
+	badPath := filepath.Join(t.TempDir(), "bad.csv")
+	require.NoError(t, os.WriteFile(badPath, []byte("id,name\n3,carol\nx,dave\n"), 0o600))
+
+	out.Reset()
+	sh.dotCommand(".import " + badPath + " users")
+	assert.Contains(t, out.String(), `Error: line 3: column "id": expected an integer, got "x"`)
+
+	out.Reset()
+	sh.dotCommand(".import --continue " + badPath + " users")
+	assert.Contains(t, out.String(), "Warning: skipped line 3")
+	assert.Contains(t, out.String(), "1 row(s) imported, 1 skipped")
+
+	out.Reset()
+	sh.dotCommand(".import " + badPath)
+	assert.Contains(t, out.String(), "Error: usage: .import")
+}
+
+func TestShell_DotDump(t *testing.T) {
+	db := openTestDB(t)
+	for _, stmt := range []string{
+		`create table "users" (id int8 primary key autoincrement, name varchar(50) not null, bio text, active boolean, score double, joined timestamp, uid uuid, doc json, emb vector(3))`,
+		`create index "users_name" on "users" (name)`,
+		`create table "posts" (id int8 primary key, user_id int8 not null, title varchar(100), title_len int8 generated always as (length(title)) stored, constraint "posts_user" foreign key (user_id) references "users" (id) on delete cascade on update restrict)`,
+		`insert into "users" (name, bio, active, score, joined, uid, doc, emb) values ('o''brien', 'line one
+line two; with semicolon', true, -1.25, '2024-01-02 03:04:05.123456', '550e8400-e29b-41d4-a716-446655440000', '{"a": [1, "x"]}', '[0.5, 1, -2]')`,
+		`insert into "users" (name) values ('nulls')`,
+		`insert into "posts" (id, user_id, title) values (10, 1, 'hello')`,
+	} {
+		_, err := db.Exec(stmt)
+		require.NoError(t, err, stmt)
+	}
+
+	sh, out := newTestShell(db, "")
+	sh.dotCommand(".dump")
+	dump := out.String()
+	require.True(t, strings.HasPrefix(dump, `create table "users"`), dump)
+	require.True(t, strings.HasSuffix(dump, "commit;\n"), dump)
+	// Parents are created before the tables referencing them, and all DDL
+	// before the data.
+	assert.Less(t, strings.Index(dump, `create table "users"`), strings.Index(dump, `create table "posts"`))
+	assert.Less(t, strings.Index(dump, `create table "posts"`), strings.Index(dump, "begin;\n"))
+	assert.Contains(t, dump, `'o''brien'`)
+	assert.Contains(t, dump, `insert into "posts" ("id", "user_id", "title") values (10, 1, 'hello');`)
+
+	// Replaying the dump into an empty database recreates the same dump.
+	restored := openTestDB(t)
+	replay, replayOut := newTestShell(restored, dump)
+	replay.run()
+	assert.NotContains(t, replayOut.String(), "Error")
+
+	check, checkOut := newTestShell(restored, "")
+	check.dotCommand(".dump")
+	assert.Equal(t, dump, checkOut.String())
+
+	var titleLen int64
+	require.NoError(t, restored.QueryRow(`select title_len from "posts" where id = 10`).Scan(&titleLen))
+	assert.Equal(t, int64(5), titleLen)
+
+	out.Reset()
+	sh.dotCommand(".dump posts")
+	assert.NotContains(t, out.String(), `create table "users"`)
+	assert.Contains(t, out.String(), `create table "posts"`)
+
+	out.Reset()
+	sh.dotCommand(".dump missing")
+	assert.Contains(t, out.String(), "Error:")
+}
+
+func TestShell_Transaction(t *testing.T) {
+	db := openTestDB(t)
+	input := strings.Join([]string{
+		`create table "users" (id int8);`,
+		`begin;`,
+		`insert into "users" (id) values (1);`,
+		`insert into "users" (id) values (2);`,
+		`rollback;`,
+		`select count(*) from "users";`,
+		`begin;`,
+		`insert into "users" (id) values (3);`,
+		`commit;`,
+		`select count(*) from "users";`,
+		`commit;`,
+	}, "\n")
+
+	sh, out := newTestShell(db, input)
+	sh.run()
+
+	assert.Equal(t, strings.Join([]string{
+		"1 row(s) affected",
+		"1 row(s) affected",
+		"COUNT(*)",
+		"--------",
+		"0       ",
+		"1 row(s) affected",
+		"COUNT(*)",
+		"--------",
+		"1       ",
+		"Error: no transaction in progress",
+		"",
+	}, "\n"), out.String())
+
+	var count int
+	require.NoError(t, db.QueryRow(`select count(*) from "users"`).Scan(&count))
+	assert.Equal(t, 1, count)
 }
 
 func TestShell_DotSchema_All(t *testing.T) {
@@ -308,6 +554,38 @@ func TestShell_DotSchema_All(t *testing.T) {
 	assert.NotContains(t, got, "minisql_schema")
 }
 
+func TestShell_DotSchema_Indexes(t *testing.T) {
+	db := openTestDB(t)
+	for _, stmt := range []string{
+		`create table "users" (id int8 primary key, email varchar(255) unique, name varchar(100))`,
+		`create index "users_name" on "users" (name)`,
+		`create table "accounts" (id int8, owner varchar(100))`,
+		`create index "accounts_owner" on "accounts" (owner)`,
+	} {
+		_, err := db.Exec(stmt)
+		require.NoError(t, err)
+	}
+
+	sh, out := newTestShell(db, "")
+	sh.dotCommand(".schema")
+	assert.Equal(t, strings.Join([]string{
+		`create table "accounts" (id int8, owner varchar(100));`,
+		`create index "accounts_owner" on "accounts" (owner);`,
+		``,
+		`create table "users" (id int8 primary key, email varchar(255) unique, name varchar(100));`,
+		`create index "users_name" on "users" (name);`,
+		``,
+	}, "\n"), out.String())
+
+	out.Reset()
+	sh.dotCommand(".schema users")
+	assert.Equal(t, strings.Join([]string{
+		`create table "users" (id int8 primary key, email varchar(255) unique, name varchar(100));`,
+		`create index "users_name" on "users" (name);`,
+		``,
+	}, "\n"), out.String())
+}
+
 func TestShell_DotSchema_Specific(t *testing.T) {
 	db := openTestDB(t)
 	_, err := db.Exec(`create table "users" (id int8, email varchar(255))`)
@@ -345,6 +623,18 @@ func TestShell_Run_MultiLine(t *testing.T) {
 	assert.NotContains(t, out.String(), "Error:")
 }
 
+func TestShell_Run_TrailingComment(t *testing.T) {
+	db := openTestDB(t)
+	_, err := db.Exec(`create table "t" (id int8)`)
+	require.NoError(t, err)
+
+	input := "-- seed data\ninsert into \"t\" (id) values (1);\n/* done */;\n-- trailing comment\n"
+	sh, out := newTestShell(db, input)
+	sh.run()
+	assert.NotContains(t, out.String(), "Error:")
+	assert.Contains(t, out.String(), "1 row(s) affected")
+}
+
 func TestShell_Run_DotCommandInRun(t *testing.T) {
 	db := openTestDB(t)
 	_, err := db.Exec(`create table "items" (id int8)`)
@@ -434,6 +724,23 @@ func TestShell_Exec_Delete_RowsAffected(t *testing.T) {
 	assert.Contains(t, out.String(), "3 row(s) affected")
 }
 
+func TestShell_Exec_Vacuum_PagesReclaimed(t *testing.T) {
+	db := openTestDB(t)
+	_, err := db.Exec(`create table "t" (id int8, name varchar(255))`)
+	require.NoError(t, err)
+	for i := range 500 {
+		_, err = db.Exec(`insert into "t" (id, name) values (?, ?)`, i, strings.Repeat("x", 200))
+		require.NoError(t, err)
+	}
+	_, err = db.Exec(`delete from "t"`)
+	require.NoError(t, err)
+
+	sh, out := newTestShell(db, "")
+	sh.exec(`vacuum;`)
+	assert.Contains(t, out.String(), "page(s) reclaimed")
+	assert.NotContains(t, out.String(), "row(s)")
+}
+
 func TestShell_Exec_DDL_Silent(t *testing.T) {
 	db := openTestDB(t)
 
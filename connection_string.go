@@ -23,6 +23,24 @@ const (
 	SynchronousFull   = minisql.SynchronousFull
 )
 
+// IntegerOverflow re-exports the internal type so callers can use it without
+// importing the internal package.
+type IntegerOverflow = minisql.IntegerOverflow
+
+// Integer overflow policy constants.
+const (
+	IntegerOverflowError = minisql.IntegerOverflowError
+	IntegerOverflowWrap  = minisql.IntegerOverflowWrap
+)
+
+// ErrIntegerOverflow is returned when INT4/INT8 arithmetic or a CAST to an
+// integer type overflows under the default IntegerOverflowError policy.
+var ErrIntegerOverflow = minisql.ErrIntegerOverflow
+
+// ErrSubqueryTooLarge is returned when an IN (SELECT ...) subquery yields more
+// distinct values than the max_subquery_rows limit.
+var ErrSubqueryTooLarge = minisql.ErrSubqueryTooLarge
+
 // DefaultWALCheckpointThreshold is the number of WAL frames that triggers an
 // automatic checkpoint when WAL mode is enabled.
 const DefaultWALCheckpointThreshold = 1000
@@ -46,14 +64,28 @@ type ConnectionConfig struct {
 	FilePath               string          // Database file path
 	WALCheckpointThreshold int             // Auto-checkpoint threshold in WAL frames (default: 1000)
 	WALWriteBufferSize     int             // WAL write-buffer size in bytes (default: 64 KiB; 0 = flush every commit)
+	CheckpointInterval     time.Duration   // Checkpoint the WAL in the background at this interval (0 = disabled)
 	LogLevel               string          // Log level: debug, info, warn, error (default: warn)
 	MaxCachedPages         int             // Maximum number of pages to cache (default: 2000, 0 = use default)
 	SlowQueryThreshold     time.Duration   // Log queries at WARN when elapsed time meets or exceeds this duration (0 = disabled)
+	QueryTimeout           time.Duration   // Cancel statements that run longer than this duration (0 = disabled)
 	Synchronous            SynchronousMode // WAL fsync mode: off, normal (default), full
 	ParallelScan           bool            // Enable concurrent leaf-page scanning (default: false)
 	EncryptionKey          []byte          // AES-256-CTR page encryption key (nil = no encryption)
 	SortMemLimit           int64           // Max bytes in memory before ORDER BY spills to disk (default: 4 MiB; 0 = disabled)
 	HNSWVecCacheSize       int             // Max vector entries per HNSW index LRU cache (default: 4096)
+	SafeMode               bool            // Reject UPDATE/DELETE without a WHERE clause (default: false)
+	IntegerOverflow        IntegerOverflow // Integer overflow policy: error (default) or wrap
+	AutoVacuumThreshold    float64         // Free-page ratio that triggers an automatic VACUUM (default: 0 = disabled)
+	QueryLog               string          // Query log destination: file path or "zap" (default: "" = disabled)
+	QueryLogRedact         bool            // Replace bound argument values in the query log (default: false)
+	MaxIdentifierLength    int             // Max length of table, column and index names (default: 0 = 64)
+	MaxSubqueryRows        int             // Max distinct values an IN subquery may materialise (default: 0 = 1000000)
+	GrowChunkPages         int             // Extend the database file this many pages at a time (default: 0 = one page)
+	QueryCacheSize         int             // Number of SELECT results to cache (default: 0 = disabled)
+	InsertBatchSize        int             // Split multi-row INSERTs into batches of N rows (default: 0 = disabled)
+	InsertBatchCommit      bool            // Commit each auto-commit INSERT batch separately (default: false)
+	StatementCacheSize     int             // Number of parsed queries to cache by SQL text (default: 0 = use default of 1000)
 }
 
 // DefaultConnectionConfig returns default configuration.
@@ -77,13 +109,27 @@ func DefaultConnectionConfig(filePath string) *ConnectionConfig {
 // Supported parameters:
 //   - wal_checkpoint_threshold=N        : Auto-checkpoint after N WAL frames (default: 1000; 0 = disabled)
 //   - wal_write_buffer_size=N           : WAL write-buffer in bytes (default: 65536; 0 = flush every commit)
+//   - checkpoint_interval=30s           : Checkpoint the WAL in the background at this interval (default: 0 = off)
 //   - log_level=debug|info|warn|error   : Set logging level (default: warn)
 //   - max_cached_pages=N                : Page cache size in pages (default: 2000)
 //   - slow_query_threshold=50ms         : Log queries taking at least this long (0 = disabled)
+//   - query_timeout=5s                  : Cancel statements running longer than this (0 = disabled)
 //   - synchronous=off|normal|full       : WAL fsync mode (default: normal, matching SQLite WAL default)
 //   - parallel_scan=on|off              : Enable concurrent leaf-page scanning (default: off)
 //   - encryption_key=<hex>             : Hex-encoded AES-256-CTR encryption key (default: no encryption)
 //   - hnsw_vec_cache_size=N            : Per-index HNSW vector LRU cache size in entries (default: 4096)
+//   - safe_mode=on|off                 : Reject UPDATE/DELETE without a WHERE clause (default: off)
+//   - integer_overflow=error|wrap      : Fail or wrap around on INT4/INT8 arithmetic and cast overflow (default: error)
+//   - auto_vacuum=R                    : VACUUM automatically once free pages reach ratio R of the file, 0 < R <= 1 (default: 0 = off)
+//   - query_log=<path>|zap             : Record every statement to a JSON-lines file or the zap logger (default: off)
+//   - query_log_redact=on|off          : Redact bound argument values in the query log (default: off)
+//   - max_identifier_length=N          : Max length of table, column and index names, 1..512 (default: 64)
+//   - max_subquery_rows=N              : Max distinct values an IN (SELECT ...) subquery may materialise (default: 1000000)
+//   - grow_chunk_pages=N               : Extend the database file N pages at a time, e.g. 256 = 1 MiB (default: 0 = off)
+//   - query_cache=N                    : Cache up to N small SELECT results until their tables are written (default: 0 = off)
+//   - insert_batch_size=N              : Insert multi-row VALUES lists N rows at a time (default: 0 = off)
+//   - insert_batch_commit=on|off       : Commit each auto-commit INSERT batch in its own transaction (default: off)
+//   - statement_cache=N                : Cache the parsed form of up to N distinct queries (default: 1000)
 //
 // Examples:
 //   - "./my.db"                                       : Default settings
@@ -93,6 +139,7 @@ func DefaultConnectionConfig(filePath string) *ConnectionConfig {
 //   - "./my.db?synchronous=full"                      : fsync on every commit (maximum durability)
 //   - "./my.db?parallel_scan=on"                      : Enable parallel full table scans
 //   - "./my.db?encryption_key=deadbeef..."            : Enable transparent page encryption
+//   - "./my.db?query_log=./queries.log"               : Log every statement to a file
 //   - "./my.db?log_level=info&max_cached_pages=500"   : Multiple parameters
 func ParseConnectionString(connStr string) (*ConnectionConfig, error) {
 	// Split on first '?' to separate path from query params
@@ -152,6 +199,15 @@ func ParseConnectionString(connStr string) (*ConnectionConfig, error) {
 		config.MaxCachedPages = maxPages
 	}
 
+	// Parse checkpoint_interval parameter
+	if intervalStr := queryParams.Get("checkpoint_interval"); intervalStr != "" {
+		interval, err := time.ParseDuration(intervalStr)
+		if err != nil || interval < 0 {
+			return nil, fmt.Errorf("invalid checkpoint_interval parameter: must be a non-negative duration, got %q", intervalStr)
+		}
+		config.CheckpointInterval = interval
+	}
+
 	// Parse slow_query_threshold parameter
 	if thresholdStr := queryParams.Get("slow_query_threshold"); thresholdStr != "" {
 		threshold, err := time.ParseDuration(thresholdStr)
@@ -161,6 +217,15 @@ func ParseConnectionString(connStr string) (*ConnectionConfig, error) {
 		config.SlowQueryThreshold = threshold
 	}
 
+	// Parse query_timeout parameter
+	if timeoutStr := queryParams.Get("query_timeout"); timeoutStr != "" {
+		timeout, err := time.ParseDuration(timeoutStr)
+		if err != nil || timeout < 0 {
+			return nil, fmt.Errorf("invalid query_timeout parameter: must be a non-negative duration, got %q", timeoutStr)
+		}
+		config.QueryTimeout = timeout
+	}
+
 	// Parse synchronous parameter
 	if syncStr := queryParams.Get("synchronous"); syncStr != "" {
 		switch strings.ToLower(syncStr) {
@@ -187,6 +252,60 @@ func ParseConnectionString(connStr string) (*ConnectionConfig, error) {
 		}
 	}
 
+	// Parse safe_mode parameter
+	if smStr := queryParams.Get("safe_mode"); smStr != "" {
+		switch strings.ToLower(smStr) {
+		case "on", "1", "true":
+			config.SafeMode = true
+		case "off", "0", "false":
+			config.SafeMode = false
+		default:
+			return nil, fmt.Errorf("invalid safe_mode parameter: expected on or off, got %q", smStr)
+		}
+	}
+
+	// Parse integer_overflow parameter
+	if ioStr := queryParams.Get("integer_overflow"); ioStr != "" {
+		switch strings.ToLower(ioStr) {
+		case "error":
+			config.IntegerOverflow = IntegerOverflowError
+		case "wrap":
+			config.IntegerOverflow = IntegerOverflowWrap
+		default:
+			return nil, fmt.Errorf("invalid integer_overflow parameter: expected error or wrap, got %q", ioStr)
+		}
+	}
+
+	// Parse auto_vacuum parameter (free-page ratio; 0 = disabled)
+	if ratioStr := queryParams.Get("auto_vacuum"); ratioStr != "" {
+		ratio, err := strconv.ParseFloat(ratioStr, 64)
+		if err != nil || ratio < 0 || ratio > 1 {
+			return nil, fmt.Errorf("invalid auto_vacuum parameter: must be a ratio between 0 and 1, got %q", ratioStr)
+		}
+		// VACUUM rewrites the database file, which an in-memory database lacks.
+		if ratio > 0 && config.FilePath == MemoryPath {
+			return nil, fmt.Errorf("invalid auto_vacuum parameter: %w", ErrInMemoryDatabase)
+		}
+		config.AutoVacuumThreshold = ratio
+	}
+
+	// Parse query_log parameter (file path, or "zap" to use the driver logger)
+	if queryLog := queryParams.Get("query_log"); queryLog != "" {
+		config.QueryLog = queryLog
+	}
+
+	// Parse query_log_redact parameter
+	if redactStr := queryParams.Get("query_log_redact"); redactStr != "" {
+		switch strings.ToLower(redactStr) {
+		case "on", "1", "true":
+			config.QueryLogRedact = true
+		case "off", "0", "false":
+			config.QueryLogRedact = false
+		default:
+			return nil, fmt.Errorf("invalid query_log_redact parameter: expected on or off, got %q", redactStr)
+		}
+	}
+
 	// Parse encryption_key parameter (hex-encoded, minimum 16 bytes / 32 hex chars)
 	if keyHex := queryParams.Get("encryption_key"); keyHex != "" {
 		key, err := hex.DecodeString(keyHex)
@@ -220,6 +339,72 @@ func ParseConnectionString(connStr string) (*ConnectionConfig, error) {
 		config.SortMemLimit = limit
 	}
 
+	// Parse max_identifier_length parameter (characters; 1..MaxInlineVarchar)
+	if lengthStr := queryParams.Get("max_identifier_length"); lengthStr != "" {
+		length, err := strconv.Atoi(lengthStr)
+		if err != nil || length <= 0 || length > minisql.MaxInlineVarchar {
+			return nil, fmt.Errorf("invalid max_identifier_length parameter: must be an integer between 1 and %d, got %q", minisql.MaxInlineVarchar, lengthStr)
+		}
+		config.MaxIdentifierLength = length
+	}
+
+	// Parse max_subquery_rows parameter (distinct values; must be positive)
+	if rowsStr := queryParams.Get("max_subquery_rows"); rowsStr != "" {
+		rows, err := strconv.Atoi(rowsStr)
+		if err != nil || rows <= 0 {
+			return nil, fmt.Errorf("invalid max_subquery_rows parameter: must be a positive integer, got %q", rowsStr)
+		}
+		config.MaxSubqueryRows = rows
+	}
+
+	// Parse grow_chunk_pages parameter (0 = grow one page at a time)
+	if chunkStr := queryParams.Get("grow_chunk_pages"); chunkStr != "" {
+		chunk, err := strconv.Atoi(chunkStr)
+		if err != nil || chunk < 0 {
+			return nil, fmt.Errorf("invalid grow_chunk_pages parameter: must be a non-negative integer, got %q", chunkStr)
+		}
+		config.GrowChunkPages = chunk
+	}
+
+	// Parse query_cache parameter (number of cached results; 0 = disabled)
+	if sizeStr := queryParams.Get("query_cache"); sizeStr != "" {
+		size, err := strconv.Atoi(sizeStr)
+		if err != nil || size < 0 {
+			return nil, fmt.Errorf("invalid query_cache parameter: must be a non-negative integer, got %q", sizeStr)
+		}
+		config.QueryCacheSize = size
+	}
+
+	// Parse insert_batch_size parameter (rows per INSERT batch; 0 = disabled)
+	if sizeStr := queryParams.Get("insert_batch_size"); sizeStr != "" {
+		size, err := strconv.Atoi(sizeStr)
+		if err != nil || size < 0 {
+			return nil, fmt.Errorf("invalid insert_batch_size parameter: must be a non-negative integer, got %q", sizeStr)
+		}
+		config.InsertBatchSize = size
+	}
+
+	// Parse insert_batch_commit parameter
+	if ibcStr := queryParams.Get("insert_batch_commit"); ibcStr != "" {
+		switch strings.ToLower(ibcStr) {
+		case "on", "1", "true":
+			config.InsertBatchCommit = true
+		case "off", "0", "false":
+			config.InsertBatchCommit = false
+		default:
+			return nil, fmt.Errorf("invalid insert_batch_commit parameter: expected on or off, got %q", ibcStr)
+		}
+	}
+
+	// Parse statement_cache parameter (0 = use default)
+	if sizeStr := queryParams.Get("statement_cache"); sizeStr != "" {
+		size, err := strconv.Atoi(sizeStr)
+		if err != nil || size < 0 {
+			return nil, fmt.Errorf("invalid statement_cache parameter: must be a non-negative integer (0 = use default), got %q", sizeStr)
+		}
+		config.StatementCacheSize = size
+	}
+
 	return config, nil
 }
 
@@ -112,7 +112,7 @@ func TestParseConnectionString(t *testing.T) {
 		},
 		{
 			name:    "all parameters",
-			connStr: "./test.db?wal_checkpoint_threshold=200&log_level=info&max_cached_pages=4000&slow_query_threshold=75ms",
+			connStr: "./test.db?wal_checkpoint_threshold=200&log_level=info&max_cached_pages=4000&slow_query_threshold=75ms&query_timeout=5s",
 			wantConfig: &ConnectionConfig{
 				FilePath:               "./test.db",
 				WALCheckpointThreshold: 200,
@@ -120,6 +120,7 @@ func TestParseConnectionString(t *testing.T) {
 				LogLevel:               "info",
 				MaxCachedPages:         4000,
 				SlowQueryThreshold:     75 * time.Millisecond,
+				QueryTimeout:           5 * time.Second,
 				Synchronous:            SynchronousNormal,
 				SortMemLimit:           DefaultSortMemLimit,
 				HNSWVecCacheSize:       DefaultHNSWVecCacheSize,
@@ -240,6 +241,12 @@ func TestParseConnectionString(t *testing.T) {
 			wantErr:     true,
 			errContains: "invalid slow_query_threshold parameter",
 		},
+		{
+			name:        "invalid query timeout",
+			connStr:     "./test.db?query_timeout=later",
+			wantErr:     true,
+			errContains: "invalid query_timeout parameter",
+		},
 		{
 			name:        "invalid synchronous value",
 			connStr:     "./test.db?synchronous=extra",
@@ -284,6 +291,123 @@ func TestParseConnectionString(t *testing.T) {
 			wantErr:     true,
 			errContains: "invalid parallel_scan parameter",
 		},
+		{
+			name:    "safe_mode=on",
+			connStr: "./test.db?safe_mode=on",
+			wantConfig: &ConnectionConfig{
+				FilePath:               "./test.db",
+				WALCheckpointThreshold: DefaultWALCheckpointThreshold,
+				WALWriteBufferSize:     DefaultWALWriteBufferSize,
+				LogLevel:               "warn",
+				MaxCachedPages:         minisql.PageCacheSize,
+				Synchronous:            SynchronousNormal,
+				SortMemLimit:           DefaultSortMemLimit,
+				HNSWVecCacheSize:       DefaultHNSWVecCacheSize,
+				SafeMode:               true,
+			},
+			wantErr: false,
+		},
+		{
+			name:        "invalid safe_mode value",
+			connStr:     "./test.db?safe_mode=maybe",
+			wantErr:     true,
+			errContains: "invalid safe_mode parameter",
+		},
+		{
+			name:    "integer_overflow=wrap",
+			connStr: "./test.db?integer_overflow=wrap",
+			wantConfig: &ConnectionConfig{
+				FilePath:               "./test.db",
+				WALCheckpointThreshold: DefaultWALCheckpointThreshold,
+				WALWriteBufferSize:     DefaultWALWriteBufferSize,
+				LogLevel:               "warn",
+				MaxCachedPages:         minisql.PageCacheSize,
+				Synchronous:            SynchronousNormal,
+				SortMemLimit:           DefaultSortMemLimit,
+				HNSWVecCacheSize:       DefaultHNSWVecCacheSize,
+				IntegerOverflow:        IntegerOverflowWrap,
+			},
+			wantErr: false,
+		},
+		{
+			name:        "invalid integer_overflow value",
+			connStr:     "./test.db?integer_overflow=saturate",
+			wantErr:     true,
+			errContains: "invalid integer_overflow parameter",
+		},
+		{
+			name:    "auto_vacuum ratio",
+			connStr: "./test.db?auto_vacuum=0.25",
+			wantConfig: &ConnectionConfig{
+				FilePath:               "./test.db",
+				WALCheckpointThreshold: DefaultWALCheckpointThreshold,
+				WALWriteBufferSize:     DefaultWALWriteBufferSize,
+				LogLevel:               "warn",
+				MaxCachedPages:         minisql.PageCacheSize,
+				Synchronous:            SynchronousNormal,
+				SortMemLimit:           DefaultSortMemLimit,
+				HNSWVecCacheSize:       DefaultHNSWVecCacheSize,
+				AutoVacuumThreshold:    0.25,
+			},
+			wantErr: false,
+		},
+		{
+			name:    "checkpoint_interval duration",
+			connStr: "./test.db?checkpoint_interval=30s",
+			wantConfig: &ConnectionConfig{
+				FilePath:               "./test.db",
+				WALCheckpointThreshold: DefaultWALCheckpointThreshold,
+				WALWriteBufferSize:     DefaultWALWriteBufferSize,
+				CheckpointInterval:     30 * time.Second,
+				LogLevel:               "warn",
+				MaxCachedPages:         minisql.PageCacheSize,
+				Synchronous:            SynchronousNormal,
+				SortMemLimit:           DefaultSortMemLimit,
+				HNSWVecCacheSize:       DefaultHNSWVecCacheSize,
+			},
+			wantErr: false,
+		},
+		{
+			name:        "invalid checkpoint_interval value",
+			connStr:     "./test.db?checkpoint_interval=soon",
+			wantErr:     true,
+			errContains: "invalid checkpoint_interval parameter",
+		},
+		{
+			name:        "invalid auto_vacuum value",
+			connStr:     "./test.db?auto_vacuum=1.5",
+			wantErr:     true,
+			errContains: "invalid auto_vacuum parameter",
+		},
+		{
+			name:        "auto_vacuum for an in-memory database",
+			connStr:     ":memory:?auto_vacuum=0.1",
+			wantErr:     true,
+			errContains: "invalid auto_vacuum parameter: not supported for an in-memory database",
+		},
+		{
+			name:    "query_log with redaction",
+			connStr: "./test.db?query_log=./queries.log&query_log_redact=on",
+			wantConfig: &ConnectionConfig{
+				FilePath:               "./test.db",
+				WALCheckpointThreshold: DefaultWALCheckpointThreshold,
+				WALWriteBufferSize:     DefaultWALWriteBufferSize,
+				LogLevel:               "warn",
+				MaxCachedPages:         minisql.PageCacheSize,
+				Synchronous:            SynchronousNormal,
+				SortMemLimit:           DefaultSortMemLimit,
+				HNSWVecCacheSize:       DefaultHNSWVecCacheSize,
+				QueryLog:               "./queries.log",
+				QueryLogRedact:         true,
+			},
+			wantErr: false,
+		},
+		{
+			name:        "invalid query_log_redact value",
+			connStr:     "./test.db?query_log=zap&query_log_redact=maybe",
+			wantErr:     true,
+			errContains: "invalid query_log_redact parameter",
+		},
 		{
 			name:    "encryption_key set",
 			connStr: "./test.db?encryption_key=" + hex.EncodeToString([]byte("my-secret-key-32bytes-long-paddd")),
@@ -369,6 +493,145 @@ func TestParseConnectionString(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name:    "max_identifier_length=128",
+			connStr: "./test.db?max_identifier_length=128",
+			wantConfig: &ConnectionConfig{
+				FilePath:               "./test.db",
+				WALCheckpointThreshold: DefaultWALCheckpointThreshold,
+				WALWriteBufferSize:     DefaultWALWriteBufferSize,
+				LogLevel:               "warn",
+				MaxCachedPages:         minisql.PageCacheSize,
+				Synchronous:            SynchronousNormal,
+				SortMemLimit:           DefaultSortMemLimit,
+				HNSWVecCacheSize:       DefaultHNSWVecCacheSize,
+				MaxIdentifierLength:    128,
+			},
+			wantErr: false,
+		},
+		{
+			name:        "invalid max_identifier_length - too large",
+			connStr:     "./test.db?max_identifier_length=513",
+			wantErr:     true,
+			errContains: "invalid max_identifier_length parameter",
+		},
+		{
+			name:    "max_subquery_rows=5000",
+			connStr: "./test.db?max_subquery_rows=5000",
+			wantConfig: &ConnectionConfig{
+				FilePath:               "./test.db",
+				WALCheckpointThreshold: DefaultWALCheckpointThreshold,
+				WALWriteBufferSize:     DefaultWALWriteBufferSize,
+				LogLevel:               "warn",
+				MaxCachedPages:         minisql.PageCacheSize,
+				Synchronous:            SynchronousNormal,
+				SortMemLimit:           DefaultSortMemLimit,
+				HNSWVecCacheSize:       DefaultHNSWVecCacheSize,
+				MaxSubqueryRows:        5000,
+			},
+			wantErr: false,
+		},
+		{
+			name:        "invalid max_subquery_rows - zero",
+			connStr:     "./test.db?max_subquery_rows=0",
+			wantErr:     true,
+			errContains: "invalid max_subquery_rows parameter",
+		},
+		{
+			name:    "grow_chunk_pages=256",
+			connStr: "./test.db?grow_chunk_pages=256",
+			wantConfig: &ConnectionConfig{
+				FilePath:               "./test.db",
+				WALCheckpointThreshold: DefaultWALCheckpointThreshold,
+				WALWriteBufferSize:     DefaultWALWriteBufferSize,
+				LogLevel:               "warn",
+				MaxCachedPages:         minisql.PageCacheSize,
+				Synchronous:            SynchronousNormal,
+				SortMemLimit:           DefaultSortMemLimit,
+				HNSWVecCacheSize:       DefaultHNSWVecCacheSize,
+				GrowChunkPages:         256,
+			},
+			wantErr: false,
+		},
+		{
+			name:        "invalid grow_chunk_pages - negative",
+			connStr:     "./test.db?grow_chunk_pages=-1",
+			wantErr:     true,
+			errContains: "invalid grow_chunk_pages parameter",
+		},
+		{
+			name:    "query_cache=100",
+			connStr: "./test.db?query_cache=100",
+			wantConfig: &ConnectionConfig{
+				FilePath:               "./test.db",
+				WALCheckpointThreshold: DefaultWALCheckpointThreshold,
+				WALWriteBufferSize:     DefaultWALWriteBufferSize,
+				LogLevel:               "warn",
+				MaxCachedPages:         minisql.PageCacheSize,
+				Synchronous:            SynchronousNormal,
+				SortMemLimit:           DefaultSortMemLimit,
+				HNSWVecCacheSize:       DefaultHNSWVecCacheSize,
+				QueryCacheSize:         100,
+			},
+			wantErr: false,
+		},
+		{
+			name:        "invalid query_cache - not a number",
+			connStr:     "./test.db?query_cache=lots",
+			wantErr:     true,
+			errContains: "invalid query_cache parameter",
+		},
+		{
+			name:    "statement_cache=50",
+			connStr: "./test.db?statement_cache=50",
+			wantConfig: &ConnectionConfig{
+				FilePath:               "./test.db",
+				WALCheckpointThreshold: DefaultWALCheckpointThreshold,
+				WALWriteBufferSize:     DefaultWALWriteBufferSize,
+				LogLevel:               "warn",
+				MaxCachedPages:         minisql.PageCacheSize,
+				Synchronous:            SynchronousNormal,
+				SortMemLimit:           DefaultSortMemLimit,
+				HNSWVecCacheSize:       DefaultHNSWVecCacheSize,
+				StatementCacheSize:     50,
+			},
+			wantErr: false,
+		},
+		{
+			name:        "invalid statement_cache - negative",
+			connStr:     "./test.db?statement_cache=-5",
+			wantErr:     true,
+			errContains: "invalid statement_cache parameter",
+		},
+		{
+			name:    "insert_batch_size=1000&insert_batch_commit=on",
+			connStr: "./test.db?insert_batch_size=1000&insert_batch_commit=on",
+			wantConfig: &ConnectionConfig{
+				FilePath:               "./test.db",
+				WALCheckpointThreshold: DefaultWALCheckpointThreshold,
+				WALWriteBufferSize:     DefaultWALWriteBufferSize,
+				LogLevel:               "warn",
+				MaxCachedPages:         minisql.PageCacheSize,
+				Synchronous:            SynchronousNormal,
+				SortMemLimit:           DefaultSortMemLimit,
+				HNSWVecCacheSize:       DefaultHNSWVecCacheSize,
+				InsertBatchSize:        1000,
+				InsertBatchCommit:      true,
+			},
+			wantErr: false,
+		},
+		{
+			name:        "invalid insert_batch_size - negative",
+			connStr:     "./test.db?insert_batch_size=-1",
+			wantErr:     true,
+			errContains: "invalid insert_batch_size parameter",
+		},
+		{
+			name:        "invalid insert_batch_commit value",
+			connStr:     "./test.db?insert_batch_commit=maybe",
+			wantErr:     true,
+			errContains: "invalid insert_batch_commit parameter",
+		},
 		{
 			name:        "invalid hnsw_vec_cache_size - zero",
 			connStr:     "./test.db?hnsw_vec_cache_size=0",
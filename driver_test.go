@@ -80,3 +80,84 @@ func TestDriverPreparedStatementsAndTransactions(t *testing.T) {
 	require.NoError(t, db.QueryRowContext(context.Background(), `select count(*) from users;`).Scan(&count))
 	assert.Equal(t, 2, count)
 }
+
+func TestDriverColumnTypes(t *testing.T) {
+	t.Parallel()
+
+	tempFile, err := os.CreateTemp("", "minisql-driver-test")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = os.Remove(tempFile.Name())
+		_ = os.Remove(tempFile.Name() + "-wal")
+	})
+
+	db, err := sql.Open("minisql", tempFile.Name())
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, db.Close()) })
+
+	ctx := context.Background()
+	_, err = db.ExecContext(ctx, `create table "items" (
+		id      int8 primary key autoincrement,
+		name    varchar(50) not null,
+		price   double,
+		stock   int4 not null,
+		active  boolean,
+		created timestamp default now(),
+		tag     uuid
+	);`)
+	require.NoError(t, err)
+	_, err = db.ExecContext(ctx, `insert into items(name, price, stock, active) values('pen', 1.5, 3, true);`)
+	require.NoError(t, err)
+
+	type columnType struct {
+		Name     string
+		TypeName string
+		Nullable bool
+		ScanType string
+	}
+	columnTypes := func(query string) []columnType {
+		rows, err := db.QueryContext(ctx, query)
+		require.NoError(t, err)
+		defer rows.Close()
+
+		types, err := rows.ColumnTypes()
+		require.NoError(t, err)
+		result := make([]columnType, 0, len(types))
+		for _, ct := range types {
+			nullable, ok := ct.Nullable()
+			require.True(t, ok, ct.Name())
+			result = append(result, columnType{ct.Name(), ct.DatabaseTypeName(), nullable, ct.ScanType().String()})
+		}
+		return result
+	}
+
+	assert.Equal(t, []columnType{
+		{"id", "INT8", false, "int64"},
+		{"name", "VARCHAR", false, "string"},
+		{"price", "DOUBLE", true, "float64"},
+		{"stock", "INT4", false, "int64"},
+		{"active", "BOOLEAN", true, "bool"},
+		{"created", "TIMESTAMP", true, "time.Time"},
+		{"tag", "UUID", true, "string"},
+	}, columnTypes(`select * from items;`))
+
+	assert.Equal(t, []columnType{
+		{"stock", "INT4", false, "int64"},
+		{"price", "DOUBLE", true, "float64"},
+	}, columnTypes(`select stock, price from items where id = 1;`))
+
+	// Computed columns have no schema type, so their metadata is unknown.
+	rows, err := db.QueryContext(ctx, `select price * 2 as doubled from items;`)
+	require.NoError(t, err)
+	types, err := rows.ColumnTypes()
+	require.NoError(t, err)
+	require.NoError(t, rows.Close())
+	require.Len(t, types, 1)
+	assert.Equal(t, "", types[0].DatabaseTypeName())
+	_, ok := types[0].Nullable()
+	assert.False(t, ok)
+
+	var stock any
+	require.NoError(t, db.QueryRowContext(ctx, `select stock from items where id = 1;`).Scan(&stock))
+	assert.Equal(t, int64(3), stock)
+}
@@ -0,0 +1,40 @@
+package minisql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io"
+)
+
+// Dump writes SQL statements that recreate tables, or every user table when
+// none are given, to w. The output holds the CREATE TABLE and CREATE INDEX
+// statements followed by one INSERT per row, wrapped in BEGIN/COMMIT, so it
+// can be replayed into an empty database to migrate or restore it:
+//
+//	f, err := os.Create("backup.sql")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	defer f.Close()
+//	if err := minisql.Dump(ctx, db, f); err != nil {
+//	    log.Fatal(err)
+//	}
+//
+// Dump reads a consistent snapshot and must not be called from inside an
+// explicit user transaction.
+func Dump(ctx context.Context, db *sql.DB, w io.Writer, tables ...string) error {
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("minisql: Dump: acquire connection: %w", err)
+	}
+	defer conn.Close()
+
+	return conn.Raw(func(c any) error {
+		mc, ok := c.(*Conn)
+		if !ok {
+			return fmt.Errorf("minisql: Dump: unexpected connection type %T", c)
+		}
+		return mc.db.Dump(ctx, w, tables...)
+	})
+}
@@ -0,0 +1,76 @@
+package e2etests
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/RichardKnop/minisql"
+)
+
+// TestAffectedKeys verifies that UPDATE and DELETE report the keys of the
+// rows they modify through the root package.
+func (s *TestSuite) TestAffectedKeys() {
+	ctx := context.Background()
+
+	_, err := s.db.Exec(`create table "events" (id int8 primary key, kind varchar(20) not null)`)
+	s.Require().NoError(err)
+	_, err = s.db.Exec(`create table "log" (msg text)`)
+	s.Require().NoError(err)
+	for i := 1; i <= 10; i++ {
+		_, err = s.db.Exec(`insert into "events" (id, kind) values (?, ?)`, i, "open")
+		s.Require().NoError(err)
+		_, err = s.db.Exec(`insert into "log" (msg) values (?)`, fmt.Sprintf("m%d", i))
+		s.Require().NoError(err)
+	}
+
+	s.Run("ranged delete returns the deleted keys in order", func() {
+		keys, err := minisql.AffectedKeys(ctx, s.db, `delete from "events" where id between ? and ?`, 3, 6)
+		s.Require().NoError(err)
+		s.Equal([][]any{{int64(3)}, {int64(4)}, {int64(5)}, {int64(6)}}, keys)
+		s.countRowsInTable("events", 6)
+	})
+
+	s.Run("update returns the updated keys", func() {
+		keys, err := minisql.AffectedKeys(ctx, s.db, `update "events" set kind = 'closed' where id > 8`)
+		s.Require().NoError(err)
+		s.Equal([][]any{{int64(9)}, {int64(10)}}, keys)
+
+		keys, err = minisql.AffectedKeys(ctx, s.db, `update "events" set kind = 'closed' where id > 100`)
+		s.Require().NoError(err)
+		s.Empty(keys)
+	})
+
+	s.Run("tables without a primary key report row IDs", func() {
+		var rowIDs [][]any
+		rows, err := s.db.Query(`select rowid from "log" where msg in ('m1', 'm2') order by rowid`)
+		s.Require().NoError(err)
+		for rows.Next() {
+			var rowID int64
+			s.Require().NoError(rows.Scan(&rowID))
+			rowIDs = append(rowIDs, []any{rowID})
+		}
+		s.Require().NoError(rows.Err())
+		s.Require().NoError(rows.Close())
+		s.Require().Len(rowIDs, 2)
+
+		keys, err := minisql.AffectedKeys(ctx, s.db, `delete from "log" where msg in ('m1', 'm2')`)
+		s.Require().NoError(err)
+		s.Equal(rowIDs, keys)
+	})
+
+	s.Run("other statements are rejected", func() {
+		_, err := minisql.AffectedKeys(ctx, s.db, `select id from "events"`)
+		s.Require().ErrorContains(err, "must be an UPDATE or DELETE")
+	})
+
+	s.Run("builder statements report keys through Result", func() {
+		del, err := minisql.DeleteFrom("events").
+			Where(minisql.FieldIsLess(minisql.Field{Name: "id"}, minisql.OperandInteger, 3)).
+			ReturningKeys().
+			Build()
+		s.Require().NoError(err)
+		res, err := minisql.ExecStatement(ctx, s.db, del)
+		s.Require().NoError(err)
+		s.Equal([][]any{{int64(1)}, {int64(2)}}, res.(minisql.Result).AffectedKeys())
+	})
+}
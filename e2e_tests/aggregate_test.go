@@ -2,8 +2,11 @@ package e2etests
 
 import (
 	"context"
+	"database/sql"
 	"math"
 	"sort"
+	"strings"
+	"time"
 )
 
 func (s *TestSuite) TestAggregateWithoutGroupBy() {
@@ -285,6 +288,57 @@ func (s *TestSuite) TestAggregateMinMaxWithIndex() {
 	})
 }
 
+func (s *TestSuite) TestAggregateMinMaxWithoutIndex() {
+	_, err := s.db.Exec(`create table "readings" (
+	id       int8 primary key autoincrement,
+	label    varchar(50),
+	value    double,
+	taken_at timestamp,
+	meta     json
+);`)
+	s.Require().NoError(err)
+
+	s.Run("MAX on empty table without index returns NULL", func() {
+		var maxVal sql.NullFloat64
+		s.Require().NoError(s.db.QueryRow(`select MAX(value) from readings;`).Scan(&maxVal))
+		s.False(maxVal.Valid)
+	})
+
+	s.execQuery(`insert into readings(label, value, taken_at) values
+	('b', 2.5, '2024-03-01 00:00:00'),
+	('c', -1.25, '2024-01-01 00:00:00'),
+	(NULL, NULL, NULL),
+	('a', 7.75, '2024-02-01 00:00:00');`, 4)
+
+	s.Run("MIN and MAX fall back to a scan and keep the column kind", func() {
+		var (
+			minLabel, maxLabel string
+			minValue, maxValue float64
+			maxTakenAt         time.Time
+		)
+		err := s.db.QueryRow(`select MIN(label), MAX(label), MIN(value), MAX(value), MAX(taken_at) from readings;`).
+			Scan(&minLabel, &maxLabel, &minValue, &maxValue, &maxTakenAt)
+		s.Require().NoError(err)
+		s.Equal("a", minLabel)
+		s.Equal("c", maxLabel)
+		s.Equal(-1.25, minValue)
+		s.Equal(7.75, maxValue)
+		s.Equal(time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), maxTakenAt.UTC())
+	})
+
+	s.Run("MIN on unknown column is rejected", func() {
+		_, err := s.db.Query(`select MIN(nope) from readings;`)
+		s.Require().Error(err)
+		s.Contains(err.Error(), `unknown column "nope" referenced in MIN`)
+	})
+
+	s.Run("MAX on non-comparable column is rejected", func() {
+		_, err := s.db.Query(`select MAX(meta) from readings;`)
+		s.Require().Error(err)
+		s.Contains(err.Error(), `column "meta" must be comparable for MAX`)
+	})
+}
+
 func (s *TestSuite) TestAggregateAVGFractional() {
 	_, err := s.db.Exec(`create table "nums" (
 	id int8 primary key autoincrement,
@@ -307,6 +361,218 @@ func (s *TestSuite) TestAggregateAVGFractional() {
 	s.False(math.IsNaN(avg))
 }
 
+func (s *TestSuite) TestAggregateSumAvgPromotionAndNulls() {
+	_, err := s.db.Exec(`create table "scores" (
+	id    int8 primary key,
+	name  varchar(50),
+	age   int4,
+	score double
+);`)
+	s.Require().NoError(err)
+
+	// Two ages at the INT4 maximum: their sum only fits in INT8.
+	s.execQuery(`insert into scores(id, name, age, score) values
+(1, 'a', 2147483647, 1.5),
+(2, 'b', 2147483647, null),
+(3, 'c', null, 2.5);`, 3)
+
+	s.Run("SUM over INT4 promotes to INT8 and AVG skips NULLs", func() {
+		var (
+			sum int64
+			avg float64
+		)
+		s.Require().NoError(s.db.QueryRow(`select SUM(age), AVG(score) from scores;`).Scan(&sum, &avg))
+		s.Equal(int64(2*math.MaxInt32), sum)
+		s.InDelta(2.0, avg, 0.001)
+	})
+
+	s.Run("AVG over an integer column returns a double", func() {
+		var avg float64
+		s.Require().NoError(s.db.QueryRow(`select AVG(age) from scores;`).Scan(&avg))
+		s.InDelta(float64(math.MaxInt32), avg, 0.001)
+	})
+
+	s.Run("SUM over no rows is NULL", func() {
+		var sum *int64
+		s.Require().NoError(s.db.QueryRow(`select SUM(age) from scores where id > 10;`).Scan(&sum))
+		s.Nil(sum)
+	})
+
+	s.Run("non-numeric column is rejected", func() {
+		_, err := s.db.Query(`select SUM(name) from scores;`)
+		s.Require().Error(err)
+		s.Contains(err.Error(), `column "name" must be numeric for SUM`)
+
+		_, err = s.db.Query(`select AVG(name) from scores;`)
+		s.Require().Error(err)
+		s.Contains(err.Error(), `column "name" must be numeric for AVG`)
+	})
+
+	s.Run("non-aggregate column without GROUP BY is rejected", func() {
+		_, err := s.db.Query(`select name, SUM(age) from scores;`)
+		s.Require().Error(err)
+		s.Contains(err.Error(), `non-aggregate column "name" must appear in GROUP BY`)
+	})
+}
+
+func (s *TestSuite) TestAggregateCountColumn() {
+	_, err := s.db.Exec(`create table "contacts" (
+	id    int8 primary key autoincrement,
+	team  varchar(20) not null,
+	email text,
+	phone varchar(20)
+);`)
+	s.Require().NoError(err)
+
+	s.execQuery(`insert into contacts(team, email, phone) values
+('red', 'a@example.com', null),
+('red', null, null),
+('blue', 'b@example.com', '555-0100'),
+('blue', 'c@example.com', null),
+('blue', null, '555-0101');`, 5)
+
+	s.Run("COUNT(column) skips NULLs while COUNT(*) counts every row", func() {
+		var emails, phones, all int64
+		s.Require().NoError(s.db.QueryRow(`select COUNT(email), COUNT(phone), COUNT(*) from contacts;`).Scan(&emails, &phones, &all))
+		s.Equal(int64(3), emails)
+		s.Equal(int64(2), phones)
+		s.Equal(int64(5), all)
+	})
+
+	s.Run("COUNT(column) alone and with WHERE", func() {
+		var n int64
+		s.Require().NoError(s.db.QueryRow(`select COUNT(email) from contacts;`).Scan(&n))
+		s.Equal(int64(3), n)
+		s.Require().NoError(s.db.QueryRow(`select COUNT(email) from contacts where team = 'red';`).Scan(&n))
+		s.Equal(int64(1), n)
+		s.Require().NoError(s.db.QueryRow(`select COUNT(email) from contacts where email is null;`).Scan(&n))
+		s.Equal(int64(0), n)
+	})
+
+	s.Run("COUNT(column) per group", func() {
+		rows, err := s.db.Query(`select team, COUNT(email), COUNT(phone) from contacts group by team order by team;`)
+		s.Require().NoError(err)
+		defer rows.Close()
+
+		type teamCounts struct {
+			team           string
+			emails, phones int64
+		}
+		var got []teamCounts
+		for rows.Next() {
+			var tc teamCounts
+			s.Require().NoError(rows.Scan(&tc.team, &tc.emails, &tc.phones))
+			got = append(got, tc)
+		}
+		s.Require().NoError(rows.Err())
+		s.Equal([]teamCounts{{"blue", 2, 2}, {"red", 1, 0}}, got)
+	})
+
+	s.Run("HAVING on COUNT(column)", func() {
+		var team string
+		s.Require().NoError(s.db.QueryRow(`select team, COUNT(phone) from contacts group by team having COUNT(phone) > 0;`).Scan(&team, new(int64)))
+		s.Equal("blue", team)
+	})
+
+	s.Run("unknown column is rejected", func() {
+		_, err := s.db.Query(`select COUNT(fax) from contacts;`)
+		s.Require().Error(err)
+		s.Contains(err.Error(), `unknown column "fax" referenced in COUNT`)
+	})
+}
+
+func (s *TestSuite) TestAggregateCountDistinct() {
+	_, err := s.db.Exec(`create table "visits" (
+	id     int8 primary key autoincrement,
+	team   varchar(20) not null,
+	email  text,
+	score  int4,
+	vec    vector(2)
+);`)
+	s.Require().NoError(err)
+
+	longA := strings.Repeat("a", 5000)
+	longB := strings.Repeat("a", 4999) + "b"
+	s.execQuery(`insert into visits(team, email, score) values
+('red', 'a@example.com', 1),
+('red', 'a@example.com', 1),
+('red', null, 2),
+('blue', 'a@example.com', 3),
+('blue', 'b@example.com', 3),
+('blue', null, null);`, 6)
+	for _, email := range []string{longA, longA, longB} {
+		_, err := s.db.Exec(`insert into visits(team, email) values ('green', ?);`, email)
+		s.Require().NoError(err)
+	}
+
+	s.Run("COUNT(DISTINCT column) skips NULLs and duplicates", func() {
+		var emails, scores, all int64
+		s.Require().NoError(s.db.QueryRow(`select COUNT(DISTINCT email), COUNT(DISTINCT score), COUNT(*) from visits;`).Scan(&emails, &scores, &all))
+		s.Equal(int64(4), emails)
+		s.Equal(int64(3), scores)
+		s.Equal(int64(9), all)
+	})
+
+	s.Run("COUNT(DISTINCT column) with WHERE", func() {
+		var n int64
+		s.Require().NoError(s.db.QueryRow(`select count(distinct email) from visits where team = 'red';`).Scan(&n))
+		s.Equal(int64(1), n)
+	})
+
+	s.Run("COUNT(DISTINCT column) compares overflow text by content", func() {
+		var n int64
+		s.Require().NoError(s.db.QueryRow(`select COUNT(DISTINCT email) from visits where team = 'green';`).Scan(&n))
+		s.Equal(int64(2), n)
+	})
+
+	s.Run("COUNT(DISTINCT column) per group", func() {
+		rows, err := s.db.Query(`select team, COUNT(DISTINCT email), COUNT(email) from visits group by team order by team;`)
+		s.Require().NoError(err)
+		defer rows.Close()
+
+		type teamCounts struct {
+			team             string
+			distinct, emails int64
+		}
+		var got []teamCounts
+		for rows.Next() {
+			var tc teamCounts
+			s.Require().NoError(rows.Scan(&tc.team, &tc.distinct, &tc.emails))
+			got = append(got, tc)
+		}
+		s.Require().NoError(rows.Err())
+		s.Equal([]teamCounts{{"blue", 2, 2}, {"green", 2, 3}, {"red", 1, 2}}, got)
+	})
+
+	s.Run("HAVING on COUNT(DISTINCT column)", func() {
+		rows, err := s.db.Query(`select team, COUNT(DISTINCT email) from visits group by team having COUNT(DISTINCT email) > 1 order by team;`)
+		s.Require().NoError(err)
+		defer rows.Close()
+
+		var teams []string
+		for rows.Next() {
+			var team string
+			s.Require().NoError(rows.Scan(&team, new(int64)))
+			teams = append(teams, team)
+		}
+		s.Require().NoError(rows.Err())
+		s.Equal([]string{"blue", "green"}, teams)
+	})
+
+	s.Run("invalid arguments are rejected", func() {
+		for sql, msg := range map[string]string{
+			`select COUNT(DISTINCT *) from visits;`:           "COUNT(DISTINCT *) is not supported",
+			`select COUNT(DISTINCT email, team) from visits;`: "COUNT(DISTINCT) takes exactly one column",
+			`select COUNT(DISTINCT vec) from visits;`:         `column "vec" must be comparable for COUNT(DISTINCT)`,
+			`select COUNT(DISTINCT fax) from visits;`:         `unknown column "fax" referenced in COUNT`,
+		} {
+			_, err := s.db.Query(sql)
+			s.Require().Error(err, sql)
+			s.Contains(err.Error(), msg, sql)
+		}
+	})
+}
+
 func (s *TestSuite) TestGroupBy() {
 	_, err := s.db.Exec(createOrdersTableSQL)
 	s.Require().NoError(err)
@@ -633,6 +899,57 @@ func (s *TestSuite) TestHaving() {
 		s.Contains(err.Error(), "HAVING requires GROUP BY")
 	})
 
+	s.Run("HAVING with IN on aggregate", func() {
+		// SUM(total_paid) IN (30, 50): user_id=1 (30) and user_id=3 (50).
+		rows, err := s.db.QueryContext(context.Background(), `select user_id, SUM(total_paid) from orders GROUP BY user_id HAVING SUM(total_paid) IN (30, 50) ORDER BY user_id;`)
+		s.Require().NoError(err)
+		defer rows.Close()
+
+		var got []int64
+		for rows.Next() {
+			var userID, sum int64
+			s.Require().NoError(rows.Scan(&userID, &sum))
+			got = append(got, userID)
+		}
+		s.Require().NoError(rows.Err())
+		s.Equal([]int64{1, 3}, got)
+	})
+
+	s.Run("HAVING with NOT IN on COUNT", func() {
+		rows, err := s.db.QueryContext(context.Background(), `select user_id, COUNT(*) from orders GROUP BY user_id HAVING COUNT(*) NOT IN (2);`)
+		s.Require().NoError(err)
+		defer rows.Close()
+
+		s.Require().True(rows.Next())
+		var userID, count int64
+		s.Require().NoError(rows.Scan(&userID, &count))
+		s.Equal(int64(3), userID)
+		s.Equal(int64(1), count)
+		s.False(rows.Next())
+		s.Require().NoError(rows.Err())
+	})
+
+	s.Run("HAVING is applied before LIMIT and OFFSET", func() {
+		// HAVING keeps user_id=1 and user_id=2; OFFSET 1 skips user_id=1.
+		rows, err := s.db.QueryContext(context.Background(), `select user_id, COUNT(*) from orders GROUP BY user_id HAVING COUNT(*) = 2 ORDER BY user_id LIMIT 1 OFFSET 1;`)
+		s.Require().NoError(err)
+		defer rows.Close()
+
+		s.Require().True(rows.Next())
+		var userID, count int64
+		s.Require().NoError(rows.Scan(&userID, &count))
+		s.Equal(int64(2), userID)
+		s.Equal(int64(2), count)
+		s.False(rows.Next())
+		s.Require().NoError(rows.Err())
+	})
+
+	s.Run("HAVING on non-grouped column is rejected", func() {
+		_, err := s.db.Exec(`select user_id, COUNT(*) from orders GROUP BY user_id HAVING product_id = 1;`)
+		s.Require().Error(err)
+		s.Contains(err.Error(), `HAVING references "product_id" which is not a GROUP BY column or aggregate function`)
+	})
+
 	s.Run("HAVING with placeholder", func() {
 		// Only groups where SUM(total_paid) > 40: user_id=2 (70) and user_id=3 (50).
 		rows, err := s.db.QueryContext(context.Background(),
@@ -3,6 +3,8 @@ package e2etests
 import (
 	"context"
 	"database/sql"
+	"fmt"
+	"strings"
 
 	minisqlErrors "github.com/RichardKnop/minisql/pkg/errors"
 )
@@ -128,7 +130,7 @@ func (s *TestSuite) TestAlterTable_AddColumn_SchemaPersists() {
 	s.Equal(int32(7), score.Int32)
 }
 
-// TestAlterTable_DropColumn verifies that a column can be tombstoned and that
+// TestAlterTable_DropColumn verifies that a column can be dropped and that
 // subsequent queries no longer return it.
 func (s *TestSuite) TestAlterTable_DropColumn() {
 	ctx := context.Background()
@@ -163,7 +165,7 @@ func (s *TestSuite) TestAlterTable_DropColumn() {
 	s.Equal("alpha", name)
 }
 
-// TestAlterTable_DropColumn_SchemaPersists verifies the tombstone survives a reopen.
+// TestAlterTable_DropColumn_SchemaPersists verifies the drop survives a reopen.
 func (s *TestSuite) TestAlterTable_DropColumn_SchemaPersists() {
 	ctx := context.Background()
 
@@ -201,6 +203,142 @@ func (s *TestSuite) TestAlterTable_DropColumn_SchemaPersists() {
 	s.Require().Len(got, 2)
 }
 
+// TestAlterTable_DropColumn_RewritesRows verifies that dropping a column
+// removes it from existing rows, including rows that spilled text into
+// overflow pages and rows written before a later ADD COLUMN.
+func (s *TestSuite) TestAlterTable_DropColumn_RewritesRows() {
+	ctx := context.Background()
+
+	_, err := s.db.ExecContext(ctx, `create table "items" (
+		id int8 primary key autoincrement,
+		body text,
+		name varchar(255) not null,
+		qty int4
+	);`)
+	s.Require().NoError(err)
+
+	big := strings.Repeat("x", 10000)
+	for i := range 50 {
+		_, err = s.db.ExecContext(ctx, `insert into "items" (body, name, qty) values (?, ?, ?);`, big, fmt.Sprintf("item-%d", i), i)
+		s.Require().NoError(err)
+	}
+
+	_, err = s.db.ExecContext(ctx, `ALTER TABLE items ADD COLUMN tag varchar(20) default 'none';`)
+	s.Require().NoError(err)
+	s.execQuery(`update items set tag = 'odd' where qty = 1;`, 1)
+
+	_, err = s.db.ExecContext(ctx, `ALTER TABLE items DROP COLUMN body;`)
+	s.Require().NoError(err)
+
+	assertRows := func() {
+		rows, err := s.db.QueryContext(ctx, `select * from "items" where qty < 3;`)
+		s.Require().NoError(err)
+		defer rows.Close()
+
+		cols, err := rows.Columns()
+		s.Require().NoError(err)
+		s.Equal([]string{"id", "name", "qty", "tag"}, cols)
+
+		var tags []string
+		for rows.Next() {
+			var (
+				id   int64
+				name string
+				qty  int32
+				tag  string
+			)
+			s.Require().NoError(rows.Scan(&id, &name, &qty, &tag))
+			s.Equal(fmt.Sprintf("item-%d", qty), name)
+			tags = append(tags, tag)
+		}
+		s.Require().NoError(rows.Err())
+		s.Equal([]string{"none", "odd", "none"}, tags)
+
+		results := s.collectPragmaResults(`PRAGMA integrity_check;`)
+		s.Require().Len(results, 1)
+		s.Equal("ok", results[0].Code)
+	}
+
+	assertRows()
+
+	_, err = s.db.ExecContext(ctx, `insert into "items" (name, body) values ('z', 'gone');`)
+	s.Require().Error(err)
+	s.Contains(err.Error(), `unknown field "body"`)
+
+	s.db = s.reopenDB()
+	assertRows()
+	s.countRowsInTable("items", 50)
+}
+
+// TestAlterTable_DropColumn_DependentsFail verifies that columns still used by
+// indexes, foreign keys or generated columns cannot be dropped.
+func (s *TestSuite) TestAlterTable_DropColumn_DependentsFail() {
+	ctx := context.Background()
+
+	_, err := s.db.ExecContext(ctx, `create table "items" (
+		id int8 primary key autoincrement,
+		email varchar(255),
+		status varchar(20),
+		amount int8,
+		price double,
+		total double generated always as (price * 2) stored
+	);`)
+	s.Require().NoError(err)
+	_, err = s.db.ExecContext(ctx, `create index "idx_items_lower_email" on "items" (lower(email));`)
+	s.Require().NoError(err)
+	_, err = s.db.ExecContext(ctx, `create index "idx_items_active_amount" on "items" (amount) where status = 'active';`)
+	s.Require().NoError(err)
+
+	for _, column := range []string{"email", "status", "amount", "price"} {
+		_, err = s.db.ExecContext(ctx, fmt.Sprintf(`ALTER TABLE items DROP COLUMN %s;`, column))
+		s.Error(err, column)
+	}
+
+	// The generated column itself has no dependents and can be dropped.
+	_, err = s.db.ExecContext(ctx, `ALTER TABLE items DROP COLUMN total;`)
+	s.Require().NoError(err)
+}
+
+// TestAlterTable_DropColumn_Rollback verifies that rolling back a DROP COLUMN
+// restores the column in the schema as well as in the rows, so every column
+// reads back its original values.
+func (s *TestSuite) TestAlterTable_DropColumn_Rollback() {
+	ctx := context.Background()
+
+	_, err := s.db.ExecContext(ctx, `create table "t" (
+		id int8 primary key,
+		a int8,
+		b int8
+	);`)
+	s.Require().NoError(err)
+	s.execQuery(`insert into "t" (id, a, b) values (1, 100, 10), (2, 200, 20);`, 2)
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	s.Require().NoError(err)
+	_, err = tx.ExecContext(ctx, `ALTER TABLE t DROP COLUMN a;`)
+	s.Require().NoError(err)
+	s.Require().NoError(tx.Rollback())
+
+	assertRows := func() {
+		rows, err := s.db.QueryContext(ctx, `select id, a, b from "t" order by id;`)
+		s.Require().NoError(err)
+		defer rows.Close()
+
+		var got [][3]int64
+		for rows.Next() {
+			var row [3]int64
+			s.Require().NoError(rows.Scan(&row[0], &row[1], &row[2]))
+			got = append(got, row)
+		}
+		s.Require().NoError(rows.Err())
+		s.Equal([][3]int64{{1, 100, 10}, {2, 200, 20}}, got)
+	}
+	assertRows()
+
+	s.db = s.reopenDB()
+	assertRows()
+}
+
 // TestAlterTable_RenameColumn verifies that a column can be renamed.
 func (s *TestSuite) TestAlterTable_RenameColumn() {
 	ctx := context.Background()
@@ -226,6 +364,43 @@ func (s *TestSuite) TestAlterTable_RenameColumn() {
 	s.Equal("hello", fullName)
 }
 
+// TestAlterTable_RenameColumn_DependentsFail verifies that columns referred
+// to by name from generated columns, indexes or constraints cannot be
+// renamed, and that the table keeps accepting writes afterwards.
+func (s *TestSuite) TestAlterTable_RenameColumn_DependentsFail() {
+	ctx := context.Background()
+
+	_, err := s.db.ExecContext(ctx, `create table "items" (
+		id int8 primary key autoincrement,
+		email varchar(255),
+		qty int8 check (qty > 0),
+		price real,
+		total real generated always as (price * 2) stored
+	);`)
+	s.Require().NoError(err)
+	_, err = s.db.ExecContext(ctx, `create index "idx_items_lower_email" on "items" (lower(email));`)
+	s.Require().NoError(err)
+
+	for _, column := range []string{"id", "email", "qty", "price"} {
+		_, err = s.db.ExecContext(ctx, fmt.Sprintf(`ALTER TABLE items RENAME COLUMN %s TO renamed;`, column))
+		s.Error(err, column)
+	}
+
+	_, err = s.db.ExecContext(ctx, `insert into "items" (email, qty, price) values ('a@example.com', 1, 2.5);`)
+	s.Require().NoError(err)
+	var total float32
+	s.Require().NoError(s.db.QueryRowContext(ctx, `select total from "items" where lower(email) = 'a@example.com';`).Scan(&total))
+	s.Equal(float32(5), total)
+
+	// The generated column itself has no dependents and can be renamed.
+	_, err = s.db.ExecContext(ctx, `ALTER TABLE items RENAME COLUMN total TO doubled;`)
+	s.Require().NoError(err)
+	_, err = s.db.ExecContext(ctx, `update "items" set price = 3.5;`)
+	s.Require().NoError(err)
+	s.Require().NoError(s.db.QueryRowContext(ctx, `select doubled from "items";`).Scan(&total))
+	s.Equal(float32(7), total)
+}
+
 // TestAlterTable_RenameTo verifies that a table can be renamed.
 func (s *TestSuite) TestAlterTable_RenameTo() {
 	ctx := context.Background()
@@ -259,6 +434,163 @@ func (s *TestSuite) TestAlterTable_RenameTo() {
 	s.Equal("foo", name)
 }
 
+// TestAlterTable_RenameTo_TargetExistsFails verifies that a table cannot be
+// renamed over an existing table or to a system table name.
+func (s *TestSuite) TestAlterTable_RenameTo_TargetExistsFails() {
+	ctx := context.Background()
+
+	_, err := s.db.ExecContext(ctx, `create table "items" (id int8 primary key, name text);`)
+	s.Require().NoError(err)
+	_, err = s.db.ExecContext(ctx, `create table "products" (id int8 primary key, name text);`)
+	s.Require().NoError(err)
+
+	_, err = s.db.ExecContext(ctx, `ALTER TABLE items RENAME TO products;`)
+	s.Require().Error(err)
+	var existsErr minisqlErrors.ErrTableAlreadyExists
+	s.Require().ErrorAs(err, &existsErr)
+	s.Equal("products", existsErr.Name)
+
+	_, err = s.db.ExecContext(ctx, `ALTER TABLE items RENAME TO minisql_schema;`)
+	s.Require().Error(err)
+
+	s.countRowsInTable("items", 0)
+}
+
+// TestAlterTable_RenameTo_Rollback verifies that rolling back a transaction
+// restores the original table name, including its indexes and row count.
+func (s *TestSuite) TestAlterTable_RenameTo_Rollback() {
+	ctx := context.Background()
+
+	_, err := s.db.ExecContext(ctx, `create table "items" (
+		id int8 primary key autoincrement,
+		name varchar(255) not null
+	);`)
+	s.Require().NoError(err)
+	_, err = s.db.ExecContext(ctx, `create index "idx_items_name" on "items" (name);`)
+	s.Require().NoError(err)
+	s.execQuery(`insert into "items" (name) values ('foo'), ('bar');`, 2)
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	s.Require().NoError(err)
+	_, err = tx.ExecContext(ctx, `ALTER TABLE items RENAME TO products;`)
+	s.Require().NoError(err)
+	_, err = tx.ExecContext(ctx, `insert into "products" (name) values ('baz');`)
+	s.Require().NoError(err)
+	_, err = tx.ExecContext(ctx, `ALTER TABLE products RENAME TO goods;`)
+	s.Require().NoError(err)
+	s.Require().NoError(tx.Rollback())
+
+	_, err = s.db.ExecContext(ctx, `select * from "products";`)
+	s.Require().Error(err)
+	_, err = s.db.ExecContext(ctx, `select * from "goods";`)
+	s.Require().Error(err)
+
+	s.countRowsInTable("items", 2)
+
+	var id int64
+	s.Require().NoError(s.db.QueryRowContext(ctx, `select id from "items" where name = 'bar';`).Scan(&id))
+	s.Equal(int64(2), id)
+
+	s.db = s.reopenDB()
+	s.countRowsInTable("items", 2)
+}
+
+// TestAlterTable_RenameTo_SchemaPersists verifies that a committed rename,
+// including its index schema entries, survives a reopen.
+func (s *TestSuite) TestAlterTable_RenameTo_SchemaPersists() {
+	ctx := context.Background()
+
+	_, err := s.db.ExecContext(ctx, `create table "items" (
+		id int8 primary key autoincrement,
+		name varchar(255) not null unique,
+		qty int4
+	);`)
+	s.Require().NoError(err)
+	_, err = s.db.ExecContext(ctx, `create index "idx_items_qty" on "items" (qty);`)
+	s.Require().NoError(err)
+	s.execQuery(`insert into "items" (name, qty) values ('foo', 1), ('bar', 2);`, 2)
+
+	_, err = s.db.ExecContext(ctx, `ALTER TABLE items RENAME TO products;`)
+	s.Require().NoError(err)
+
+	s.db = s.reopenDB()
+
+	for _, schema := range s.scanSchemas() {
+		s.NotEqual("items", schema.Name)
+		s.NotEqual("items", schema.TableName())
+	}
+
+	s.countRowsInTable("products", 2)
+	var name string
+	s.Require().NoError(s.db.QueryRowContext(ctx, `select name from "products" where qty = 2;`).Scan(&name))
+	s.Equal("bar", name)
+
+	results := s.collectPragmaResults(`PRAGMA integrity_check;`)
+	s.Require().Len(results, 1)
+	s.Equal("ok", results[0].Code)
+}
+
+// TestAlterIndex_RenameTo verifies that a secondary index can be renamed, that
+// queries keep using it under the new name, also after reopening, and that
+// clashing, missing and primary key index names are rejected.
+func (s *TestSuite) TestAlterIndex_RenameTo() {
+	ctx := context.Background()
+
+	_, err := s.db.ExecContext(ctx, `create table "people" (
+		id int8 primary key autoincrement,
+		name varchar(255) not null,
+		email varchar(255)
+	);`)
+	s.Require().NoError(err)
+	_, err = s.db.ExecContext(ctx, `create index "idx_name" on "people" (name);`)
+	s.Require().NoError(err)
+	_, err = s.db.ExecContext(ctx, `create index "idx_email" on "people" (email);`)
+	s.Require().NoError(err)
+	_, err = s.db.ExecContext(ctx, `insert into "people" (name, email) values ('alice', 'a@x.io'), ('bob', 'b@x.io');`)
+	s.Require().NoError(err)
+
+	_, err = s.db.ExecContext(ctx, `ALTER INDEX idx_name RENAME TO idx_people_name;`)
+	s.Require().NoError(err)
+
+	assertUsesRenamedIndex := func() {
+		rows := s.collectExplain(`explain select * from "people" where name = 'bob';`)
+		s.Require().NotEmpty(rows)
+		s.Contains(rows[0].Detail, "index=idx_people_name")
+
+		var id int64
+		s.Require().NoError(s.db.QueryRowContext(ctx, `select id from "people" where name = 'bob';`).Scan(&id))
+		s.Equal(int64(2), id)
+	}
+	assertUsesRenamedIndex()
+
+	// Writes after the rename maintain the index.
+	_, err = s.db.ExecContext(ctx, `insert into "people" (name, email) values ('carol', 'c@x.io');`)
+	s.Require().NoError(err)
+	var id int64
+	s.Require().NoError(s.db.QueryRowContext(ctx, `select id from "people" where name = 'carol';`).Scan(&id))
+	s.Equal(int64(3), id)
+
+	s.db = s.reopenDB()
+	assertUsesRenamedIndex()
+
+	_, err = s.db.ExecContext(ctx, `ALTER INDEX idx_people_name RENAME TO idx_email;`)
+	s.Require().Error(err)
+	s.Contains(err.Error(), "index idx_email already exists")
+
+	_, err = s.db.ExecContext(ctx, `ALTER INDEX idx_name RENAME TO idx_other;`)
+	var noIdxErr minisqlErrors.ErrNoSuchIndex
+	s.Require().ErrorAs(err, &noIdxErr)
+	s.Equal("idx_name", noIdxErr.Name)
+
+	_, err = s.db.ExecContext(ctx, `ALTER INDEX pkey__people RENAME TO people_pkey;`)
+	s.Require().Error(err)
+	s.Contains(err.Error(), "primary key and unique index names are derived from the table definition")
+
+	// The index can be dropped under its new name.
+	_, err = s.db.ExecContext(ctx, `drop index "idx_people_name";`)
+	s.Require().NoError(err)
+}
+
 // TestAlterTable_AddColumn_DuplicateFails verifies that adding a column that
 // already exists returns an error.
 func (s *TestSuite) TestAlterTable_AddColumn_DuplicateFails() {
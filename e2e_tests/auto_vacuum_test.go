@@ -0,0 +1,87 @@
+package e2etests
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/RichardKnop/minisql"
+)
+
+func TestAutoVacuum_ConnectionString(t *testing.T) {
+	t.Parallel()
+
+	f, err := os.CreateTemp("", "minisql-e2e-autovacuum-*.db")
+	require.NoError(t, err)
+	path := f.Name()
+	f.Close()
+	defer func() {
+		os.Remove(path)
+		os.Remove(path + "-wal")
+	}()
+
+	db, err := sql.Open("minisql", path+"?auto_vacuum=0.3")
+	require.NoError(t, err)
+	db.SetMaxOpenConns(1)
+	db.SetMaxIdleConns(1)
+	defer db.Close()
+
+	ctx := context.Background()
+	_, err = db.Exec(`create table "items" (id int8 primary key, name varchar(255))`)
+	require.NoError(t, err)
+
+	insertRows := func(from, to int) {
+		tx, err := db.Begin()
+		require.NoError(t, err)
+		stmt, err := tx.Prepare(`insert into "items" (id, name) values (?, ?)`)
+		require.NoError(t, err)
+		for i := from; i <= to; i++ {
+			_, err := stmt.Exec(int64(i), fmt.Sprintf("%d-%s", i, strings.Repeat("x", 200)))
+			require.NoError(t, err)
+		}
+		require.NoError(t, stmt.Close())
+		require.NoError(t, tx.Commit())
+	}
+	countRows := func() int {
+		var n int
+		require.NoError(t, db.QueryRow(`select count(*) from "items"`).Scan(&n))
+		return n
+	}
+
+	insertRows(1, 1000)
+	m, err := minisql.ReadMetrics(ctx, db)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), m.AutoVacuums, "inserts alone must not trigger autovacuum")
+
+	// Auto-commit DELETE: compaction runs right after the statement commits.
+	_, err = db.Exec(`delete from "items" where id > 50`)
+	require.NoError(t, err)
+
+	m, err = minisql.ReadMetrics(ctx, db)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), m.AutoVacuums)
+	assert.Equal(t, 50, countRows())
+
+	// DELETE inside an explicit transaction: compaction waits for COMMIT.
+	insertRows(51, 1000)
+	tx, err := db.Begin()
+	require.NoError(t, err)
+	_, err = tx.Exec(`delete from "items" where id > 50`)
+	require.NoError(t, err)
+	require.NoError(t, tx.Commit())
+	m, err = minisql.ReadMetrics(ctx, db)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), m.AutoVacuums)
+	assert.Equal(t, 50, countRows())
+
+	// The compacted database stays writable.
+	_, err = db.Exec(`insert into "items" (id, name) values (1001, 'after')`)
+	require.NoError(t, err)
+	assert.Equal(t, 51, countRows())
+}
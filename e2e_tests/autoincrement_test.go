@@ -0,0 +1,78 @@
+package e2etests
+
+import (
+	"fmt"
+	"strings"
+)
+
+func (s *TestSuite) TestAutoincrement_NotReusedAfterDelete() {
+	_, err := s.db.Exec(`create table "events" (
+		id   int8 primary key autoincrement,
+		name varchar(100) not null
+	)`)
+	s.Require().NoError(err)
+
+	insertOne := func(name string) int64 {
+		res, err := s.db.Exec(`insert into "events" (name) values (?)`, name)
+		s.Require().NoError(err)
+		id, err := res.LastInsertId()
+		s.Require().NoError(err)
+		return id
+	}
+
+	s.Run("deleted newest row is not reused after reopen", func() {
+		s.Equal(int64(1), insertOne("a"))
+		s.Equal(int64(2), insertOne("b"))
+		s.Equal(int64(3), insertOne("c"))
+
+		_, err := s.db.Exec(`delete from "events" where id = 3`)
+		s.Require().NoError(err)
+
+		s.db = s.reopenDB()
+		s.Equal(int64(4), insertOne("d"))
+	})
+
+	s.Run("counter survives root splits and deleting every row", func() {
+		// Enough rows to turn the root leaf into an internal node.
+		tuples := make([]string, 0, 500)
+		for i := range 500 {
+			tuples = append(tuples, fmt.Sprintf("('%s')", strings.Repeat("x", 50+i%10)))
+		}
+		_, err := s.db.Exec(`insert into "events" (name) values ` + strings.Join(tuples, ", "))
+		s.Require().NoError(err)
+
+		_, err = s.db.Exec(`delete from "events"`)
+		s.Require().NoError(err)
+
+		s.db = s.reopenDB()
+		s.Equal(int64(505), insertOne("e"))
+	})
+
+	s.Run("explicit keys move the counter", func() {
+		_, err := s.db.Exec(`insert into "events" (id, name) values (1000, 'f')`)
+		s.Require().NoError(err)
+		_, err = s.db.Exec(`delete from "events" where id = 1000`)
+		s.Require().NoError(err)
+
+		s.db = s.reopenDB()
+		s.Equal(int64(1001), insertOne("g"))
+	})
+
+	s.Run("VACUUM keeps the counter", func() {
+		_, err := s.db.Exec(`delete from "events" where id = 1001`)
+		s.Require().NoError(err)
+		_, err = s.db.Exec(`VACUUM`)
+		s.Require().NoError(err)
+
+		s.db = s.reopenDB()
+		s.Equal(int64(1002), insertOne("h"))
+	})
+
+	s.Run("TRUNCATE TABLE restarts the counter", func() {
+		_, err := s.db.Exec(`truncate table "events"`)
+		s.Require().NoError(err)
+
+		s.db = s.reopenDB()
+		s.Equal(int64(1), insertOne("i"))
+	})
+}
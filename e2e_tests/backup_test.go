@@ -1,6 +1,7 @@
 package e2etests
 
 import (
+	"bytes"
 	"context"
 	"database/sql"
 	"fmt"
@@ -248,3 +249,25 @@ func TestBackup_IsIndependent(t *testing.T) {
 	assert.Equal(t, int64(1), minX)
 	assert.Equal(t, int64(3), maxX)
 }
+
+// TestBackupTo_Writer verifies that BackupTo streams a standalone database to
+// an arbitrary writer.
+func TestBackupTo_Writer(t *testing.T) {
+	ctx := context.Background()
+	src, _ := openBackupDB(t)
+	destPath := tempBackupPath(t)
+
+	_, err := src.ExecContext(ctx, `create table "data" (id int8 primary key autoincrement, x int8)`)
+	require.NoError(t, err)
+	_, err = src.ExecContext(ctx, `insert into "data" (x) values (1), (2), (3)`)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, minisql.BackupTo(ctx, src, &buf))
+	require.NoError(t, os.WriteFile(destPath, buf.Bytes(), 0o600))
+
+	dst := openReadOnly(t, destPath)
+	var count int64
+	require.NoError(t, dst.QueryRowContext(ctx, `select count(*) from "data"`).Scan(&count))
+	assert.Equal(t, int64(3), count)
+}
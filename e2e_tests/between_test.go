@@ -211,3 +211,100 @@ func (s *TestSuite) TestBetween_DeleteWithBetween() {
 
 	s.countRowsInTable("users", 5)
 }
+
+func (s *TestSuite) TestBetween_UsesIndexRangeScan() {
+	_, err := s.db.Exec(createProductsTableSQL)
+	s.Require().NoError(err)
+	_, err = s.db.Exec(`create index "products_price" on "products" (price)`)
+	s.Require().NoError(err)
+
+	for i := 1; i <= 10; i++ {
+		_, err := s.db.Exec(`insert into "products" (name, price) values (?, ?)`, fmt.Sprintf("Product %d", i), int64(i*10))
+		s.Require().NoError(err)
+	}
+
+	explain := s.collectExplain(`EXPLAIN SELECT name FROM products WHERE price BETWEEN 30 AND 50`)
+	s.Require().NotEmpty(explain)
+	s.Equal("index_range", explain[0].Operation)
+	s.Contains(explain[0].Detail, "index=products_price")
+	s.Contains(explain[0].Detail, "range=>= 30 and <= 50")
+
+	var count int
+	s.Require().NoError(s.db.QueryRow(`select count(*) from "products" WHERE price BETWEEN 30 AND 50`).Scan(&count))
+	s.Equal(3, count)
+	s.Require().NoError(s.db.QueryRow(`select count(*) from "products" WHERE price NOT BETWEEN 30 AND 50`).Scan(&count))
+	s.Equal(7, count)
+	s.Require().NoError(s.db.QueryRow(`select count(*) from "products" WHERE product_id BETWEEN 2 AND 4`).Scan(&count))
+	s.Equal(3, count)
+}
+
+func (s *TestSuite) TestBetween_BoundTypes() {
+	_, err := s.db.Exec(`create table "measurements" (id int8 primary key autoincrement, value double not null)`)
+	s.Require().NoError(err)
+	for _, v := range []float64{0.5, 1.5, 2.5, 3.5} {
+		_, err := s.db.Exec(`insert into "measurements" (value) values (?)`, v)
+		s.Require().NoError(err)
+	}
+
+	// Integral bounds are widened to match the DOUBLE column.
+	var count int
+	s.Require().NoError(s.db.QueryRow(`select count(*) from "measurements" WHERE value BETWEEN 1 AND 3`).Scan(&count))
+	s.Equal(2, count)
+
+	_, err = s.db.Query(`select id from "measurements" WHERE value BETWEEN 'a' AND 'b'`)
+	s.Require().Error(err)
+	s.Contains(err.Error(), `invalid BETWEEN bound: expects DOUBLE value for "value"`)
+}
+
+func (s *TestSuite) TestBetween_NullValue() {
+	_, err := s.db.Exec(`create table "readings" (id int8 primary key autoincrement, k int8, r int4)`)
+	s.Require().NoError(err)
+	for _, v := range []any{int64(10), int64(30), int64(50), nil} {
+		_, err := s.db.Exec(`insert into "readings" (k, r) values (?, ?)`, v, v)
+		s.Require().NoError(err)
+	}
+
+	// A NULL value matches neither BETWEEN nor NOT BETWEEN.
+	for _, col := range []string{"k", "r"} {
+		var count int
+		s.Require().NoError(s.db.QueryRow(fmt.Sprintf(`select count(*) from "readings" WHERE %s between 15 and 45`, col)).Scan(&count))
+		s.Equal(1, count, col)
+
+		s.Require().NoError(s.db.QueryRow(fmt.Sprintf(`select count(*) from "readings" WHERE %s not between 15 and 45`, col)).Scan(&count))
+		s.Equal(2, count, col)
+
+		s.Require().NoError(s.db.QueryRow(fmt.Sprintf(`select count(*) from "readings" WHERE %s between 45 and 15`, col)).Scan(&count))
+		s.Equal(0, count, col)
+
+		s.Require().NoError(s.db.QueryRow(fmt.Sprintf(`select count(*) from "readings" WHERE %s not between 45 and 15`, col)).Scan(&count))
+		s.Equal(3, count, col)
+	}
+
+	rows, err := s.db.Query(`select id from "readings" WHERE k not between 15 and 45`)
+	s.Require().NoError(err)
+	defer rows.Close()
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		s.Require().NoError(rows.Scan(&id))
+		ids = append(ids, id)
+	}
+	s.Require().NoError(rows.Err())
+	s.Equal([]int64{1, 3}, ids)
+
+	// The same holds when the range is answered from an index.
+	_, err = s.db.Exec(`create index "readings_k_idx" on "readings" (k)`)
+	s.Require().NoError(err)
+	var count int
+	s.Require().NoError(s.db.QueryRow(`select count(*) from "readings" WHERE k not between 15 and 45`).Scan(&count))
+	s.Equal(2, count)
+	s.Require().NoError(s.db.QueryRow(`select count(*) from "readings" WHERE k between 45 and 15`).Scan(&count))
+	s.Equal(0, count)
+
+	res, err := s.db.Exec(`delete from "readings" WHERE k not between 15 and 45`)
+	s.Require().NoError(err)
+	affected, err := res.RowsAffected()
+	s.Require().NoError(err)
+	s.Equal(int64(2), affected)
+	s.countRowsInTable("readings", 2)
+}
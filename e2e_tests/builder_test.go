@@ -0,0 +1,68 @@
+package e2etests
+
+import (
+	"context"
+
+	"github.com/RichardKnop/minisql"
+)
+
+// TestBuilder_RootPackage verifies that statements built with the root
+// package builders run through ExecStatement and QueryStatement.
+func (s *TestSuite) TestBuilder_RootPackage() {
+	ctx := context.Background()
+
+	_, err := s.db.Exec(`create table "people" (id int8 primary key autoincrement, email varchar(100), age int4)`)
+	s.Require().NoError(err)
+
+	insert, err := minisql.InsertInto("people").
+		Columns("email", "age").
+		Values("alice@example.com", 30).
+		Values("bob@example.com", 25).
+		Values("carol@example.com", nil).
+		Build()
+	s.Require().NoError(err)
+	res, err := minisql.ExecStatement(ctx, s.db, insert)
+	s.Require().NoError(err)
+	affected, err := res.RowsAffected()
+	s.Require().NoError(err)
+	s.Equal(int64(3), affected)
+	lastID, err := res.LastInsertId()
+	s.Require().NoError(err)
+	s.Equal(int64(3), lastID)
+
+	update, err := minisql.UpdateTable("people").
+		Set("age", 41).
+		Where(minisql.FieldIsEqual(minisql.Field{Name: "email"}, minisql.OperandQuotedString, "carol@example.com")).
+		Build()
+	s.Require().NoError(err)
+	res, err = minisql.ExecStatement(ctx, s.db, update)
+	s.Require().NoError(err)
+	affected, err = res.RowsAffected()
+	s.Require().NoError(err)
+	s.Equal(int64(1), affected)
+
+	del, err := minisql.DeleteFrom("people").
+		Where(minisql.FieldIsLess(minisql.Field{Name: "age"}, minisql.OperandInteger, 26)).
+		Build()
+	s.Require().NoError(err)
+	_, err = minisql.ExecStatement(ctx, s.db, del)
+	s.Require().NoError(err)
+
+	sel, err := minisql.SelectFrom("people").
+		Columns("id", "email", "age").
+		Where(minisql.FieldIsNotNull(minisql.Field{Name: "age"})).
+		OrderBy("id", minisql.Desc).
+		Build()
+	s.Require().NoError(err)
+	columns, rows, err := minisql.QueryStatement(ctx, s.db, sel)
+	s.Require().NoError(err)
+	s.Equal([]string{"id", "email", "age"}, columns)
+	s.Equal([][]any{
+		{int64(3), "carol@example.com", int64(41)},
+		{int64(1), "alice@example.com", int64(30)},
+	}, rows)
+
+	_, err = minisql.InsertInto("people").Build()
+	s.Require().ErrorIs(err, minisql.ErrBuilderNoColumns)
+	s.countRowsInTable("people", 2)
+}
@@ -195,3 +195,52 @@ func (s *TestSuite) TestCaseWhen_NestedInArithmetic() {
 	s.InDelta(80.0, prices[0], 1e-9)  // VIP: 100 * 0.8
 	s.InDelta(100.0, prices[1], 1e-9) // non-VIP: 100 * 1.0
 }
+
+func (s *TestSuite) TestCaseWhen_InOrderBy() {
+	_, err := s.db.Exec(`create table "people" (
+		id int8 primary key autoincrement,
+		name varchar(50) not null,
+		age int8 not null
+	)`)
+	s.Require().NoError(err)
+
+	_, err = s.db.Exec(`insert into "people" (name, age) values ('ann', 30), ('bob', 10), ('cid', 50)`)
+	s.Require().NoError(err)
+
+	// age is not selected, so the sort key has to read it from the table.
+	rows, err := s.db.Query(`
+		select name from "people"
+		order by CASE WHEN age >= 18 THEN 1 ELSE 0 END, name desc`)
+	s.Require().NoError(err)
+	defer rows.Close()
+
+	var names []string
+	for rows.Next() {
+		var n string
+		s.Require().NoError(rows.Scan(&n))
+		names = append(names, n)
+	}
+	s.Require().NoError(rows.Err())
+	s.Equal([]string{"bob", "cid", "ann"}, names)
+}
+
+func (s *TestSuite) TestCaseWhen_IncompatibleBranches() {
+	_, err := s.db.Exec(`create table "members" (
+		id int8 primary key autoincrement,
+		age int8 not null
+	)`)
+	s.Require().NoError(err)
+
+	_, err = s.db.Query(`select CASE WHEN age >= 18 THEN 'adult' ELSE 1 END from "members"`)
+	s.Require().Error(err)
+	s.Contains(err.Error(), "CASE branches have incompatible types")
+
+	_, err = s.db.Query(`select CASE WHEN age >= 18 THEN 'adult' ELSE age END from "members"`)
+	s.Require().Error(err)
+	s.Contains(err.Error(), "CASE branches have incompatible types")
+
+	// NULL fits any branch, and integers mix with floats.
+	rows, err := s.db.Query(`select CASE WHEN age >= 18 THEN 1 WHEN age > 5 THEN 2.5 ELSE NULL END from "members"`)
+	s.Require().NoError(err)
+	s.Require().NoError(rows.Close())
+}
@@ -1,5 +1,12 @@
 package e2etests
 
+import (
+	"database/sql"
+	"math"
+
+	"github.com/RichardKnop/minisql/internal/minisql"
+)
+
 // ── CAST expressions ─────────────────────────────────────────────────────────
 
 func (s *TestSuite) TestCast_FloatToInt8() {
@@ -121,3 +128,94 @@ func (s *TestSuite) TestCast_VarcharType() {
 	s.Require().NoError(rows.Err())
 	s.Equal([]string{"123"}, vals)
 }
+
+func (s *TestSuite) TestCast_IntegerOverflow() {
+	_, err := s.db.Exec(`create table "bounds" (
+		id  int8 primary key autoincrement,
+		big int8 not null
+	)`)
+	s.Require().NoError(err)
+
+	_, err = s.db.Exec(`insert into "bounds" (big) values (?)`, int64(math.MaxInt64))
+	s.Require().NoError(err)
+
+	s.Run("overflow fails by default", func() {
+		var v int64
+		err := s.db.QueryRow(`SELECT big + 1 FROM "bounds"`).Scan(&v)
+		s.Require().ErrorIs(err, minisql.ErrIntegerOverflow)
+
+		var n int32
+		err = s.db.QueryRow(`SELECT CAST(2147483648 AS int4) FROM "bounds"`).Scan(&n)
+		s.Require().ErrorIs(err, minisql.ErrIntegerOverflow)
+	})
+
+	s.Run("integer_overflow=wrap wraps around", func() {
+		s.Require().NoError(s.db.Close())
+		db, err := sql.Open("minisql", s.dbFile.Name()+"?integer_overflow=wrap")
+		s.Require().NoError(err)
+		db.SetMaxOpenConns(1)
+		s.db = db
+
+		var v int64
+		s.Require().NoError(s.db.QueryRow(`SELECT big + 1 FROM "bounds"`).Scan(&v))
+		s.Equal(int64(math.MinInt64), v)
+
+		var n int32
+		s.Require().NoError(s.db.QueryRow(`SELECT CAST(2147483648 AS int4) FROM "bounds"`).Scan(&n))
+		s.Equal(int32(math.MinInt32), n)
+	})
+}
+
+func (s *TestSuite) TestIntegerOverflow_WrapIntoInt4Column() {
+	_, err := s.db.Exec(`create table "counters" (
+		id   int8 primary key,
+		a    int4 not null,
+		next int4 generated always as (a + 1) stored
+	)`)
+	s.Require().NoError(err)
+	_, err = s.db.Exec(`create table "steps" (id int8 primary key, step int4 not null)`)
+	s.Require().NoError(err)
+	_, err = s.db.Exec(`insert into "counters" (id, a) values (1, 2147483646), (2, 2147483646)`)
+	s.Require().NoError(err)
+	_, err = s.db.Exec(`insert into "steps" (id, step) values (2, 1)`)
+	s.Require().NoError(err)
+
+	s.Run("overflow fails by default", func() {
+		_, err := s.db.Exec(`update "counters" set a = a + 1 where id = 1`)
+		s.Require().ErrorContains(err, "overflows INT4")
+	})
+
+	s.Run("integer_overflow=wrap wraps the stored value", func() {
+		s.Require().NoError(s.db.Close())
+		db, err := sql.Open("minisql", s.dbFile.Name()+"?integer_overflow=wrap")
+		s.Require().NoError(err)
+		db.SetMaxOpenConns(1)
+		s.db = db
+
+		// The generated column wraps first, then the updated column itself.
+		_, err = s.db.Exec(`update "counters" set a = a + 1 where id = 1`)
+		s.Require().NoError(err)
+		_, err = s.db.Exec(`update "counters" set a = a + 1 where id = 1`)
+		s.Require().NoError(err)
+		_, err = s.db.Exec(`update "counters" set a = a + 2 * s.step from "steps" s where "counters".id = s.id`)
+		s.Require().NoError(err)
+		_, err = s.db.Exec(`insert into "counters" (id, a) select id + 2, step + 2147483647 from "steps"`)
+		s.Require().NoError(err)
+
+		rows, err := s.db.Query(`select a, next from "counters" order by id`)
+		s.Require().NoError(err)
+		defer rows.Close()
+		var got [][2]int32
+		for rows.Next() {
+			var a, next int32
+			s.Require().NoError(rows.Scan(&a, &next))
+			got = append(got, [2]int32{a, next})
+		}
+		s.Require().NoError(rows.Err())
+		s.Equal([][2]int32{
+			{math.MinInt32, math.MinInt32 + 1},
+			{math.MinInt32, math.MinInt32 + 1},
+			{math.MinInt32, math.MinInt32 + 1},
+		}, got)
+	})
+}
@@ -0,0 +1,41 @@
+package e2etests
+
+func (s *TestSuite) TestComments() {
+	s.Run("Script with comments and empty statements", func() {
+		result, err := s.db.Exec(`-- create the table
+			create table "notes" (id int8 primary key, body varchar(64));
+			/* seed
+			   data */
+			insert into "notes" (id, body) values (1, '-- not a comment'), (2, '/* nor this */');;
+			-- trailing comment`)
+		s.Require().NoError(err)
+		rowsAffected, err := result.RowsAffected()
+		s.Require().NoError(err)
+		s.Equal(int64(2), rowsAffected)
+
+		var body string
+		err = s.db.QueryRow(`select body from "notes" where id = 1; -- first note`).Scan(&body)
+		s.Require().NoError(err)
+		s.Equal("-- not a comment", body)
+	})
+
+	s.Run("Exec with only comments", func() {
+		result, err := s.db.Exec("-- nothing to do\n; /* at all */")
+		s.Require().NoError(err)
+		rowsAffected, err := result.RowsAffected()
+		s.Require().NoError(err)
+		s.Zero(rowsAffected)
+	})
+
+	s.Run("Query with only comments", func() {
+		rows, err := s.db.Query(`-- nothing to select`)
+		s.Require().NoError(err)
+		defer rows.Close()
+
+		columns, err := rows.Columns()
+		s.Require().NoError(err)
+		s.Empty(columns)
+		s.False(rows.Next())
+		s.Require().NoError(rows.Err())
+	})
+}
@@ -80,6 +80,18 @@ func (s *TestSuite) TestCompositeIndex() {
 		s.Equal("Neil", users[3].LastName)
 	})
 
+	s.Run("Explain shows composite primary key values", func() {
+		explain := s.collectExplain(`EXPLAIN SELECT * FROM users WHERE first_name = 'Westley' AND last_name = 'Maud';`)
+		s.Require().Len(explain, 1)
+		s.Equal("index_point", explain[0].Operation)
+		s.Contains(explain[0].Detail, "keys=[(Westley, Maud)]")
+
+		explain = s.collectExplain(`EXPLAIN SELECT * FROM users WHERE first_name = 'Westley';`)
+		s.Require().Len(explain, 1)
+		s.Equal("index_range", explain[0].Operation)
+		s.Contains(explain[0].Detail, "range=>= (Westley)")
+	})
+
 	s.Run("Delete a user by composite primary key", func() {
 		s.execQuery(`delete from users where first_name = 'Ambrosine' and last_name = 'Deeann';`, 1)
 
@@ -100,6 +112,71 @@ func (s *TestSuite) TestCompositeIndex() {
 	})
 }
 
+func (s *TestSuite) TestCompositeSecondaryIndex_NullComponents() {
+	_, err := s.db.Exec(`create table "items" (
+		id    int8 primary key autoincrement,
+		a     int4,
+		b     varchar(20),
+		c     int4 not null
+	);`)
+	s.Require().NoError(err)
+	s.execQuery(`create index "idx_items_a_b" on "items" (a, b);`, 0)
+
+	s.execQuery(`insert into items(a, b, c) values
+(1, 'x', 1),
+(1, 'y', 2),
+(2, 'x', 3),
+(NULL, 'x', 4),
+(1, NULL, 5),
+(2, 'z', 6);`, 6)
+
+	collectIDs := func(query string) []int64 {
+		rows, err := s.db.QueryContext(context.Background(), query)
+		s.Require().NoError(err)
+		defer rows.Close()
+
+		var ids []int64
+		for rows.Next() {
+			var id int64
+			s.Require().NoError(rows.Scan(&id))
+			ids = append(ids, id)
+		}
+		s.Require().NoError(rows.Err())
+		return ids
+	}
+
+	s.Run("Full key match uses an index point scan", func() {
+		explain := s.collectExplain(`EXPLAIN SELECT id FROM items WHERE a = 1 AND b = 'y';`)
+		s.Require().Len(explain, 1)
+		s.Equal("index_point", explain[0].Operation)
+		s.Contains(explain[0].Detail, "index=idx_items_a_b")
+
+		s.Equal([]int64{2}, collectIDs(`SELECT id FROM items WHERE a = 1 AND b = 'y';`))
+	})
+
+	s.Run("Prefix match keeps rows with a NULL trailing component", func() {
+		explain := s.collectExplain(`EXPLAIN SELECT id FROM items WHERE a = 1;`)
+		s.Require().Len(explain, 1)
+		s.Equal("sequential", explain[0].Operation)
+
+		s.Equal([]int64{1, 2, 5}, collectIDs(`SELECT id FROM items WHERE a = 1;`))
+		s.Equal([]int64{5}, collectIDs(`SELECT id FROM items WHERE a = 1 AND c = 5;`))
+
+		var count int64
+		s.Require().NoError(s.db.QueryRow(`SELECT count(*) FROM items WHERE a = 1;`).Scan(&count))
+		s.Equal(int64(3), count)
+	})
+
+	s.Run("IS NULL on either component", func() {
+		s.Equal([]int64{4}, collectIDs(`SELECT id FROM items WHERE a IS NULL;`))
+		s.Equal([]int64{5}, collectIDs(`SELECT id FROM items WHERE b IS NULL;`))
+	})
+
+	s.Run("Non-prefix column is filtered sequentially", func() {
+		s.Equal([]int64{1, 3, 4}, collectIDs(`SELECT id FROM items WHERE b = 'x';`))
+	})
+}
+
 func (s *TestSuite) collectCompositeUsers(query string) []compositeUser {
 	rows, err := s.db.QueryContext(context.Background(), query)
 	s.Require().NoError(err)
@@ -126,6 +126,55 @@ func TestCoveringIndex_RangeScan(t *testing.T) {
 	assert.Equal(t, []int{5, 6, 7, 8, 9}, got)
 }
 
+// TestCoveringIndex_LikePrefix verifies that LIKE with a literal prefix is
+// served from the index leaves alone, with the pattern checked against the
+// key values.
+func TestCoveringIndex_LikePrefix(t *testing.T) {
+	t.Parallel()
+	db, cleanup := openCoveringDB(t)
+	defer cleanup()
+
+	_, err := db.Exec(`create table "users" (
+		id    int8 primary key autoincrement,
+		email varchar(100),
+		name  text
+	);`)
+	require.NoError(t, err)
+	_, err = db.Exec(`create index "idx_email" on "users" (email);`)
+	require.NoError(t, err)
+
+	for _, email := range []string{"alice@a.com", "adam@b.com", "a_b@c.com", "bob@a.com", "ab@d.com"} {
+		_, err = db.Exec(`insert into "users" (email, name) values (?, 'x');`, email)
+		require.NoError(t, err)
+	}
+
+	var operation, detail string
+	err = db.QueryRow(`explain select email from "users" where email like 'a%';`).
+		Scan(new(int64), &operation, &detail, new(sql.NullInt64), new(sql.NullInt64), new(sql.NullInt64))
+	require.NoError(t, err)
+	assert.Equal(t, "covering_index_range", operation)
+	assert.Contains(t, detail, "covering=true")
+
+	queryEmails := func(query string) []string {
+		rows, err := db.Query(query)
+		require.NoError(t, err)
+		defer rows.Close()
+		var got []string
+		for rows.Next() {
+			var email string
+			require.NoError(t, rows.Scan(&email))
+			got = append(got, email)
+		}
+		require.NoError(t, rows.Err())
+		return got
+	}
+
+	assert.Equal(t, []string{"a_b@c.com", "ab@d.com", "adam@b.com", "alice@a.com"}, queryEmails(`select email from "users" where email like 'a%';`))
+	assert.Equal(t, []string{"a_b@c.com"}, queryEmails(`select email from "users" where email like 'a\_%';`))
+	assert.Equal(t, []string{"adam@b.com"}, queryEmails(`select email from "users" where email like 'a%@b.com';`))
+	assert.Equal(t, []string{"alice@a.com", "bob@a.com"}, queryEmails(`select email from "users" where email like '%@a.com';`))
+}
+
 // TestCoveringIndex_CompositeIndex verifies a composite secondary index can
 // serve a covering scan for queries selecting both index columns.
 func TestCoveringIndex_CompositeIndex(t *testing.T) {
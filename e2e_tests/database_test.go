@@ -1,6 +1,11 @@
 package e2etests
 
 import (
+	"database/sql"
+	"fmt"
+	"os"
+	"strings"
+
 	_ "github.com/RichardKnop/minisql"
 	"github.com/RichardKnop/minisql/internal/minisql"
 	minisqlErrors "github.com/RichardKnop/minisql/pkg/errors"
@@ -165,6 +170,167 @@ func (s *TestSuite) TestCreateTable() {
 	})
 }
 
+func (s *TestSuite) TestCreateTable_IdentifierValidation() {
+	longName := strings.Repeat("t", minisql.DefaultMaxIdentifierLength+1)
+
+	s.Run("too long names are rejected", func() {
+		_, err := s.db.Exec(fmt.Sprintf(`create table "%s" (id int8 primary key);`, longName))
+		s.Require().ErrorIs(err, minisql.ErrInvalidIdentifier)
+
+		_, err = s.db.Exec(fmt.Sprintf(`create table "items" (%s int8 primary key);`, longName))
+		s.Require().ErrorIs(err, minisql.ErrInvalidIdentifier)
+
+		_, err = s.db.Exec(`create table "items" (id int8 primary key, price int8);`)
+		s.Require().NoError(err)
+		_, err = s.db.Exec(fmt.Sprintf(`create index "%s" on "items" (price);`, longName))
+		s.Require().ErrorIs(err, minisql.ErrInvalidIdentifier)
+	})
+
+	s.Run("disallowed characters are rejected, quoted or not", func() {
+		for _, stmt := range []string{
+			`create table "my-table" (id int8 primary key);`,
+			`create table my-table (id int8 primary key);`,
+			`create table "items2" ("unit price" int8);`,
+		} {
+			_, err := s.db.Exec(stmt)
+			s.Require().Error(err, stmt)
+		}
+	})
+
+	s.Run("the limit is configurable", func() {
+		s.Require().NoError(s.db.Close())
+		db, err := sql.Open("minisql", s.dbFile.Name()+"?max_identifier_length=100")
+		s.Require().NoError(err)
+		s.db = db
+
+		_, err = s.db.Exec(fmt.Sprintf(`create table "%s" (id int8 primary key);`, longName))
+		s.Require().NoError(err)
+
+		// The table keeps loading under the default limit.
+		s.db = s.reopenDB()
+		_, err = s.db.Exec(fmt.Sprintf(`insert into "%s" (id) values (1);`, longName))
+		s.Require().NoError(err)
+	})
+}
+
+func (s *TestSuite) TestCreateTable_MaximumRowSize() {
+	// A row must fit in the root leaf, which is smaller than other leaves
+	// because page 0 also holds the database header. 45 columns leave room
+	// for a 3912 byte row: id and ts, 7 × varchar(512), 35 × int8 and the
+	// last column, which is 4 bytes at the boundary and 8 bytes past it.
+	// A MINMAX column adds an 18 byte page hint to every leaf.
+	wideTable := func(name string, ints int, lastKind string, minMax bool) (string, string, []any) {
+		var columns, names, params []string
+		columns = append(columns, "id int8 primary key", "ts int8")
+		if minMax {
+			columns[1] += " minmax"
+		}
+		names = append(names, "id", "ts")
+		for i := range 7 {
+			columns = append(columns, fmt.Sprintf("v%d varchar(512)", i))
+			names = append(names, fmt.Sprintf("v%d", i))
+		}
+		for i := range ints {
+			columns = append(columns, fmt.Sprintf("n%d int8", i))
+			names = append(names, fmt.Sprintf("n%d", i))
+		}
+		columns = append(columns, "last "+lastKind)
+		names = append(names, "last")
+		for range names {
+			params = append(params, "?")
+		}
+
+		args := []any{int64(0), int64(1)}
+		for range 7 {
+			args = append(args, strings.Repeat("x", 512))
+		}
+		for i := range ints + 1 {
+			args = append(args, int64(i))
+		}
+
+		createSQL := fmt.Sprintf(`create table "%s" (%s);`, name, strings.Join(columns, ", "))
+		insertSQL := fmt.Sprintf(`insert into "%s" (%s) values (%s);`, name, strings.Join(names, ", "), strings.Join(params, ", "))
+		return createSQL, insertSQL, args
+	}
+
+	s.Run("a row at the limit is accepted and inserted", func() {
+		createSQL, insertSQL, args := wideTable("wide", 35, "int4", false)
+		_, err := s.db.Exec(createSQL)
+		s.Require().NoError(err)
+
+		for i := range 5 {
+			args[0] = int64(i + 1)
+			_, err := s.db.Exec(insertSQL, args...)
+			s.Require().NoError(err)
+		}
+		s.countRowsInTable("wide", 5)
+	})
+
+	s.Run("a row past the limit is rejected", func() {
+		createSQL, _, _ := wideTable("too_wide", 35, "int8", false)
+		_, err := s.db.Exec(createSQL)
+		s.Require().ErrorContains(err, "potential row size exceeds maximum allowed 3912")
+	})
+
+	s.Run("a row at the limit of a MINMAX table is accepted and inserted", func() {
+		createSQL, insertSQL, args := wideTable("wide_minmax", 33, "int4", true)
+		_, err := s.db.Exec(createSQL)
+		s.Require().NoError(err)
+
+		for i := range 5 {
+			args[0] = int64(i + 1)
+			_, err := s.db.Exec(insertSQL, args...)
+			s.Require().NoError(err)
+		}
+		s.countRowsInTable("wide_minmax", 5)
+	})
+
+	s.Run("a row past the limit of a MINMAX table is rejected", func() {
+		createSQL, _, _ := wideTable("too_wide_minmax", 33, "int8", true)
+		_, err := s.db.Exec(createSQL)
+		s.Require().ErrorContains(err, "potential row size exceeds maximum allowed 3896")
+
+		// The same columns fit without the page hint.
+		createSQL, _, _ = wideTable("too_wide_minmax", 33, "int8", false)
+		_, err = s.db.Exec(createSQL)
+		s.Require().NoError(err)
+	})
+}
+
+func (s *TestSuite) TestGrowChunkPages() {
+	const chunkPages = 64
+
+	s.Require().NoError(s.db.Close())
+	db, err := sql.Open("minisql", s.dbFile.Name()+fmt.Sprintf("?grow_chunk_pages=%d", chunkPages))
+	s.Require().NoError(err)
+	db.SetMaxOpenConns(1)
+	s.db = db
+
+	_, err = s.db.Exec(`create table notes (id int8 primary key autoincrement, body text);`)
+	s.Require().NoError(err)
+	body := strings.Repeat("x", 500)
+	for range 500 {
+		_, err := s.db.Exec(`insert into notes (body) values (?);`, body)
+		s.Require().NoError(err)
+	}
+
+	_, err = s.db.Exec(`PRAGMA wal_checkpoint`)
+	s.Require().NoError(err)
+	info, err := os.Stat(s.dbFile.Name())
+	s.Require().NoError(err)
+	s.Zero(info.Size()%(chunkPages*minisql.PageSize), "file grows in whole chunks")
+
+	// Closing trims the preallocated tail and the data reads back.
+	s.db = s.reopenDB()
+	info, err = os.Stat(s.dbFile.Name())
+	s.Require().NoError(err)
+	s.Zero(info.Size() % minisql.PageSize)
+
+	var count int
+	s.Require().NoError(s.db.QueryRow(`select count(*) from notes;`).Scan(&count))
+	s.Equal(500, count)
+}
+
 func (s *TestSuite) scanSchemas() []schema {
 	var schemas []schema
 	rows, err := s.db.Query(`select * from minisql_schema;`)
@@ -1,5 +1,10 @@
 package e2etests
 
+import (
+	"fmt"
+	"strings"
+)
+
 func (s *TestSuite) TestTruncateTable() {
 	_, err := s.db.Exec(`create table "trunc_users" (
 		id    int8 primary key autoincrement,
@@ -54,6 +59,135 @@ func (s *TestSuite) TestTruncateTable() {
 	})
 }
 
+// TestTruncateTable_LargeTable verifies that TRUNCATE TABLE frees every data,
+// index and overflow page of a multi-page table, restarts the autoincrement
+// counter and can be rolled back.
+func (s *TestSuite) TestTruncateTable_LargeTable() {
+	_, err := s.db.Exec(`create table "trunc_events" (
+		id    int8 primary key autoincrement,
+		kind  varchar(20) not null,
+		email varchar(50) unique,
+		body  text
+	)`)
+	s.Require().NoError(err)
+	_, err = s.db.Exec(`create index idx_trunc_events_kind on "trunc_events" (kind)`)
+	s.Require().NoError(err)
+
+	// A few distinct kinds spill their row IDs into index overflow pages and
+	// every tenth body is long enough to need text overflow pages.
+	insertEvents := func(n int) {
+		for i := 1; i <= n; i++ {
+			body := "short"
+			if i%10 == 0 {
+				body = strings.Repeat("x", 5000)
+			}
+			_, err := s.db.Exec(
+				`insert into "trunc_events" (kind, email, body) values (?, ?, ?)`,
+				fmt.Sprintf("kind-%d", i%5), fmt.Sprintf("user%d@example.com", i), body,
+			)
+			s.Require().NoError(err)
+		}
+	}
+
+	assertIntegrity := func() {
+		results := s.collectPragmaResults(`PRAGMA integrity_check;`)
+		s.Require().Len(results, 1)
+		s.Equal("ok", results[0].Code)
+	}
+
+	s.Run("truncate frees all pages", func() {
+		insertEvents(300)
+
+		res, err := s.db.Exec(`TRUNCATE TABLE "trunc_events"`)
+		s.Require().NoError(err)
+		n, err := res.RowsAffected()
+		s.Require().NoError(err)
+		s.Equal(int64(300), n)
+
+		s.countRowsInTable("trunc_events", 0)
+		var count int64
+		s.Require().NoError(s.db.QueryRow(`select count(*) from "trunc_events" where kind = 'kind-1'`).Scan(&count))
+		s.Equal(int64(0), count)
+		assertIntegrity()
+	})
+
+	s.Run("autoincrement restarts after truncate", func() {
+		insertEvents(3)
+
+		var ids []int64
+		rows, err := s.db.Query(`select id from "trunc_events" order by id`)
+		s.Require().NoError(err)
+		defer rows.Close()
+		for rows.Next() {
+			var id int64
+			s.Require().NoError(rows.Scan(&id))
+			ids = append(ids, id)
+		}
+		s.Require().NoError(rows.Err())
+		s.Equal([]int64{1, 2, 3}, ids)
+	})
+
+	s.Run("rollback restores rows and indexes", func() {
+		_, err := s.db.Exec(`TRUNCATE TABLE "trunc_events"`)
+		s.Require().NoError(err)
+		insertEvents(50)
+
+		tx, err := s.db.Begin()
+		s.Require().NoError(err)
+		_, err = tx.Exec(`TRUNCATE TABLE "trunc_events"`)
+		s.Require().NoError(err)
+		var count int64
+		s.Require().NoError(tx.QueryRow(`select count(*) from "trunc_events"`).Scan(&count))
+		s.Equal(int64(0), count)
+		// Reuses the first autoincrement key, which is also restored by the rollback.
+		_, err = tx.Exec(`insert into "trunc_events" (kind, email) values ('kind-0', 'tx@example.com')`)
+		s.Require().NoError(err)
+		s.Require().NoError(tx.Rollback())
+
+		s.countRowsInTable("trunc_events", 50)
+		s.Require().NoError(s.db.QueryRow(`select count(*) from "trunc_events" where kind = 'kind-1'`).Scan(&count))
+		s.Equal(int64(10), count)
+
+		// The next autoincrement key continues after the restored rows.
+		_, err = s.db.Exec(`insert into "trunc_events" (kind, email) values ('kind-0', 'next@example.com')`)
+		s.Require().NoError(err)
+		var id int64
+		s.Require().NoError(s.db.QueryRow(`select id from "trunc_events" where email = 'next@example.com'`).Scan(&id))
+		s.Equal(int64(51), id)
+		assertIntegrity()
+	})
+}
+
+// TestTruncateTable_ReferencedByForeignKey verifies that truncating a table
+// referenced by a foreign key applies the ON DELETE action to child rows.
+func (s *TestSuite) TestTruncateTable_ReferencedByForeignKey() {
+	_, err := s.db.Exec(`create table "trunc_users" (
+		id   int8 primary key autoincrement,
+		name varchar(100) not null
+	);`)
+	s.Require().NoError(err)
+	_, err = s.db.Exec(`create table "trunc_orders" (
+		id      int8 primary key autoincrement,
+		user_id int8 not null,
+		foreign key (user_id) references "trunc_users" (id) on delete cascade
+	);`)
+	s.Require().NoError(err)
+
+	_, err = s.db.Exec(`insert into "trunc_users" (name) values ('alice'), ('bob')`)
+	s.Require().NoError(err)
+	_, err = s.db.Exec(`insert into "trunc_orders" (user_id) values (1), (1), (2)`)
+	s.Require().NoError(err)
+
+	res, err := s.db.Exec(`TRUNCATE TABLE "trunc_users"`)
+	s.Require().NoError(err)
+	n, err := res.RowsAffected()
+	s.Require().NoError(err)
+	s.Equal(int64(2), n)
+
+	s.countRowsInTable("trunc_users", 0)
+	s.countRowsInTable("trunc_orders", 0)
+}
+
 func (s *TestSuite) TestDelete() {
 	_, err := s.db.Exec(`create table "del_users" (
 		id   int8 primary key autoincrement,
@@ -0,0 +1,81 @@
+package e2etests
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/RichardKnop/minisql"
+)
+
+func TestDump_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	openDB := func() *sql.DB {
+		f, err := os.CreateTemp("", "minisql-e2e-dump-*.db")
+		require.NoError(t, err)
+		path := f.Name()
+		f.Close()
+		t.Cleanup(func() {
+			os.Remove(path)
+			os.Remove(path + "-wal")
+		})
+		db, err := sql.Open("minisql", path)
+		require.NoError(t, err)
+		db.SetMaxOpenConns(1)
+		db.SetMaxIdleConns(1)
+		t.Cleanup(func() { db.Close() })
+		return db
+	}
+
+	ctx := context.Background()
+	src := openDB()
+	_, err := src.Exec(`create table "p" (id int8 primary key, r real, d double)`)
+	require.NoError(t, err)
+	// Whole-number floats must be dumped as REAL and DOUBLE literals, not as integers.
+	_, err = src.Exec(`insert into "p" (id, r, d) values (?, ?, ?), (?, ?, ?), (?, ?, ?)`,
+		1, float32(2), float64(2),
+		2, float32(-3), float64(1e21),
+		3, float32(0.5), float64(-0.25),
+	)
+	require.NoError(t, err)
+
+	var dump bytes.Buffer
+	require.NoError(t, minisql.Dump(ctx, src, &dump))
+
+	dst := openDB()
+	for _, stmt := range strings.Split(strings.TrimSpace(dump.String()), "\n") {
+		if stmt == "begin;" || stmt == "commit;" {
+			continue
+		}
+		_, err := dst.Exec(stmt)
+		require.NoError(t, err, stmt)
+	}
+
+	type row struct {
+		ID int64
+		R  float32
+		D  float64
+	}
+	readRows := func(db *sql.DB) []row {
+		rows, err := db.Query(`select id, r, d from "p" order by id`)
+		require.NoError(t, err)
+		defer rows.Close()
+		var got []row
+		for rows.Next() {
+			var r row
+			require.NoError(t, rows.Scan(&r.ID, &r.R, &r.D))
+			got = append(got, r)
+		}
+		require.NoError(t, rows.Err())
+		return got
+	}
+	assert.Equal(t, []row{{1, 2, 2}, {2, -3, 1e21}, {3, 0.5, -0.25}}, readRows(src))
+	assert.Equal(t, readRows(src), readRows(dst))
+}
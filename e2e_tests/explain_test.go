@@ -31,6 +31,46 @@ func (s *TestSuite) TestExplainSelect() {
 	s.False(rows[0].DurationUS.Valid)
 }
 
+func (s *TestSuite) TestExplainSelectEqualityWithResidualFilter() {
+	s.execQuery(createUsersTableSQL, 0)
+	s.execQuery(`insert into users("email", "name") values
+('alice@example.com', 'Alice'),
+('bob@example.com', 'Bob');`, 2)
+
+	s.Run("primary key equality seeks the row and filters the rest", func() {
+		rows := s.collectExplain(`EXPLAIN SELECT * FROM users WHERE id = 2 AND name = 'Bob';`)
+		s.Require().NotEmpty(rows)
+		s.Equal("index_point", rows[0].Operation)
+		s.Contains(rows[0].Detail, "index=pkey__users")
+		s.Contains(rows[0].Detail, "keys=[2]")
+		s.Contains(rows[0].Detail, "filters=1")
+
+		var name string
+		s.Require().NoError(s.db.QueryRow(`SELECT name FROM users WHERE id = 2 AND name = 'Bob';`).Scan(&name))
+		s.Equal("Bob", name)
+
+		var count int
+		s.Require().NoError(s.db.QueryRow(`SELECT count(*) FROM users WHERE id = 2 AND name = 'Alice';`).Scan(&count))
+		s.Equal(0, count)
+	})
+
+	s.Run("unique index equality seeks the row and filters the rest", func() {
+		rows := s.collectExplain(`EXPLAIN SELECT * FROM users WHERE email = 'alice@example.com' AND name = 'Alice';`)
+		s.Require().NotEmpty(rows)
+		s.Equal("index_point", rows[0].Operation)
+		s.Contains(rows[0].Detail, "columns=email")
+		s.Contains(rows[0].Detail, "filters=1")
+
+		var id int64
+		s.Require().NoError(s.db.QueryRow(`SELECT id FROM users WHERE email = 'alice@example.com' AND name = 'Alice';`).Scan(&id))
+		s.Equal(int64(1), id)
+
+		var count int
+		s.Require().NoError(s.db.QueryRow(`SELECT count(*) FROM users WHERE email = 'alice@example.com' AND name = 'Bob';`).Scan(&count))
+		s.Equal(0, count)
+	})
+}
+
 func (s *TestSuite) TestExplainAnalyzeSelect() {
 	s.execQuery(createUsersTableSQL, 0)
 	s.execQuery(`insert into users("email", "name") values
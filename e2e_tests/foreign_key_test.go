@@ -199,6 +199,30 @@ func (s *TestSuite) TestForeignKey_Delete_ParentReferencedRow_Blocked() {
 	s.Equal([]string{"user_id"}, fkErr.ChildColumns)
 }
 
+func (s *TestSuite) TestForeignKey_Delete_ParentReferencedByText_Blocked() {
+	s.createParentChildTables()
+
+	_, err := s.db.Exec(`create table "subscriptions" (
+		id    int8 primary key autoincrement,
+		email varchar(255) not null,
+		foreign key (email) references "users" (email)
+	);`)
+	s.Require().NoError(err)
+
+	_, err = s.db.Exec(`insert into "users" (email, name) values ('alice@example.com', 'Alice'), ('bob@example.com', 'Bob')`)
+	s.Require().NoError(err)
+	_, err = s.db.Exec(`insert into "subscriptions" (email) values ('alice@example.com')`)
+	s.Require().NoError(err)
+
+	_, err = s.db.Exec(`delete from "users" where id = 1`)
+	var fkErr minisqlErrors.ErrForeignKeyParentViolation
+	s.Require().ErrorAs(err, &fkErr)
+	s.Equal([]string{"email"}, fkErr.ParentColumns)
+
+	_, err = s.db.Exec(`delete from "users" where id = 2`)
+	s.Require().NoError(err)
+}
+
 func (s *TestSuite) TestForeignKey_Delete_ParentUnreferencedRow_OK() {
 	s.createParentChildTables()
 
@@ -301,6 +325,75 @@ func (s *TestSuite) TestForeignKey_DropTable_DropChildFirst_ThenParent_OK() {
 	s.Require().NoError(err)
 }
 
+func (s *TestSuite) TestForeignKey_DropTable_Cascade() {
+	s.createParentChildTables()
+	_, err := s.db.Exec(`create table "shipments" (
+		id       int8 primary key autoincrement,
+		order_id int8 not null,
+		foreign key (order_id) references "orders" (id)
+	);`)
+	s.Require().NoError(err)
+	_, err = s.db.Exec(`create table "audit" (id int8 primary key autoincrement, user_id int8 references "users" (id));`)
+	s.Require().NoError(err)
+	_, err = s.db.Exec(`create table "products" (id int8 primary key autoincrement, name varchar(100));`)
+	s.Require().NoError(err)
+	_, err = s.db.Exec(`insert into "users" (email, name) values ('alice@example.com', 'Alice')`)
+	s.Require().NoError(err)
+	_, err = s.db.Exec(`insert into "orders" (user_id, amount) values (1, 100)`)
+	s.Require().NoError(err)
+	_, err = s.db.Exec(`insert into "shipments" (order_id) values (1)`)
+	s.Require().NoError(err)
+
+	// Without CASCADE the error lists every directly referencing table.
+	for _, stmt := range []string{`drop table "users"`, `drop table "users" restrict`} {
+		_, err = s.db.Exec(stmt)
+		s.Require().ErrorIs(err, minisqlErrors.ErrDropTableReferencedByFK)
+		s.Contains(err.Error(), "users is referenced by audit, orders")
+	}
+
+	_, err = s.db.Exec(`drop table "users" cascade`)
+	s.Require().NoError(err)
+
+	assertSchema := func() {
+		rows, err := s.db.Query(`select name from minisql_schema where type = 1 and name != 'minisql_schema'`)
+		s.Require().NoError(err)
+		defer rows.Close()
+		var names []string
+		for rows.Next() {
+			var name string
+			s.Require().NoError(rows.Scan(&name))
+			names = append(names, name)
+		}
+		s.Require().NoError(rows.Err())
+		s.Equal([]string{"products"}, names)
+	}
+	assertSchema()
+	_, err = s.db.Exec(`select * from "orders"`)
+	s.Require().Error(err)
+
+	s.Require().NoError(s.db.Close())
+	s.db = s.reopenDB()
+	assertSchema()
+
+	// The dropped names are free again and no stale FK metadata lingers.
+	s.createParentChildTables()
+	_, err = s.db.Exec(`drop table "orders"`)
+	s.Require().NoError(err)
+	_, err = s.db.Exec(`drop table "users"`)
+	s.Require().NoError(err)
+}
+
+func (s *TestSuite) TestForeignKey_DropTable_SelfReferential_OK() {
+	_, err := s.db.Exec(`create table "categories" (
+		id        int8 primary key autoincrement,
+		parent_id int8 references "categories" (id)
+	);`)
+	s.Require().NoError(err)
+
+	_, err = s.db.Exec(`drop table "categories"`)
+	s.Require().NoError(err)
+}
+
 // ─────────────────────────────────────────────────────────────────────────────
 // DDL round-trip: create → close → reopen → FK still enforced
 // ─────────────────────────────────────────────────────────────────────────────
@@ -351,6 +444,17 @@ func (s *TestSuite) TestForeignKey_SelfReferential() {
 	s.Require().ErrorAs(err, &fkErr)
 }
 
+func (s *TestSuite) TestForeignKey_SelfReferential_BatchInsert_ParentFirst() {
+	_, err := s.db.Exec(`create table "nodes" (id int8 primary key, parent int8 references "nodes" (id));`)
+	s.Require().NoError(err)
+
+	// The parent row comes first in VALUES but has the larger key; rows must
+	// still be inserted in VALUES order for the child to find it.
+	_, err = s.db.Exec(`insert into "nodes" (id, parent) values (5, null), (3, 5);`)
+	s.Require().NoError(err)
+	s.countRowsInTable("nodes", 2)
+}
+
 // ─────────────────────────────────────────────────────────────────────────────
 // Batch INSERT with mixed valid/invalid rows
 // ─────────────────────────────────────────────────────────────────────────────
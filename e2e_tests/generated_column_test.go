@@ -0,0 +1,141 @@
+package e2etests
+
+func (s *TestSuite) TestGeneratedColumns() {
+	_, err := s.db.Exec(`create table "order_lines" (
+		id       int8 primary key autoincrement,
+		price    double not null,
+		quantity int8 not null,
+		total    double generated always as (price * quantity) stored,
+		label    varchar(50) generated always as (upper(sku)) stored,
+		sku      varchar(20)
+	);`)
+	s.Require().NoError(err)
+	_, err = s.db.Exec(`create index "idx_order_lines_total" on "order_lines" (total);`)
+	s.Require().NoError(err)
+
+	total := func(id int64) float64 {
+		var v float64
+		err := s.db.QueryRow(`select total from "order_lines" where id = ?;`, id).Scan(&v)
+		s.Require().NoError(err)
+		return v
+	}
+
+	s.Run("insert computes generated values", func() {
+		_, err := s.db.Exec(`insert into "order_lines" (price, quantity, sku) values (2.5, 4, 'abc');`)
+		s.Require().NoError(err)
+		s.Equal(10.0, total(1))
+
+		var label string
+		err = s.db.QueryRow(`select label from "order_lines" where id = 1;`).Scan(&label)
+		s.Require().NoError(err)
+		s.Equal("ABC", label)
+	})
+
+	s.Run("updating a source column recomputes the generated column", func() {
+		_, err := s.db.Exec(`update "order_lines" set price = 3.5 where id = 1;`)
+		s.Require().NoError(err)
+		s.Equal(14.0, total(1))
+
+		_, err = s.db.Exec(`update "order_lines" set quantity = quantity + 1 where id = 1;`)
+		s.Require().NoError(err)
+		s.Equal(17.5, total(1))
+	})
+
+	s.Run("index on generated column follows updates", func() {
+		var id int64
+		err := s.db.QueryRow(`select id from "order_lines" where total = 17.5;`).Scan(&id)
+		s.Require().NoError(err)
+		s.Equal(int64(1), id)
+	})
+
+	s.Run("direct writes are rejected", func() {
+		_, err := s.db.Exec(`insert into "order_lines" (price, quantity, total) values (1.5, 1, 5.5);`)
+		s.Require().Error(err)
+		s.Contains(err.Error(), `cannot insert a value into generated column "total"`)
+
+		_, err = s.db.Exec(`update "order_lines" set total = 1.5 where id = 1;`)
+		s.Require().Error(err)
+		s.Contains(err.Error(), `cannot update generated column "total"`)
+	})
+
+	s.Run("definition survives reopen", func() {
+		s.db = s.reopenDB()
+
+		_, err := s.db.Exec(`update "order_lines" set quantity = 2 where id = 1;`)
+		s.Require().NoError(err)
+		s.Equal(7.0, total(1))
+	})
+}
+
+func (s *TestSuite) TestGeneratedColumns_ReferenceEarlierGeneratedColumn() {
+	_, err := s.db.Exec(`create table "invoice_lines" (
+		id       int8 primary key autoincrement,
+		price    double not null,
+		quantity int8 not null,
+		net      double generated always as (price * quantity) stored,
+		gross    double generated always as (net * 1.5) stored
+	);`)
+	s.Require().NoError(err)
+
+	gross := func() float64 {
+		var v float64
+		err := s.db.QueryRow(`select gross from "invoice_lines" where id = 1;`).Scan(&v)
+		s.Require().NoError(err)
+		return v
+	}
+
+	_, err = s.db.Exec(`insert into "invoice_lines" (price, quantity) values (2.5, 4);`)
+	s.Require().NoError(err)
+	s.Equal(15.0, gross())
+
+	_, err = s.db.Exec(`update "invoice_lines" set quantity = 2 where id = 1;`)
+	s.Require().NoError(err)
+	s.Equal(7.5, gross())
+
+	s.db = s.reopenDB()
+	_, err = s.db.Exec(`update "invoice_lines" set price = 1.5 where id = 1;`)
+	s.Require().NoError(err)
+	s.Equal(4.5, gross())
+}
+
+func (s *TestSuite) TestGeneratedColumns_InvalidDefinition() {
+	testCases := []struct {
+		Name string
+		SQL  string
+		Err  string
+	}{
+		{
+			Name: "unknown column",
+			SQL:  `create table "t1" (a int8, b int8 generated always as (a + c) stored);`,
+			Err:  `references unknown column "c"`,
+		},
+		{
+			Name: "self reference",
+			SQL:  `create table "t2" (a int8, b int8 generated always as (a + b) stored);`,
+			Err:  `cannot reference itself`,
+		},
+		{
+			Name: "reference to a later generated column",
+			SQL:  `create table "t3" (a int8, b int8 generated always as (c + 1) stored, c int8 generated always as (a * 2) stored);`,
+			Err:  `cannot reference generated column "c" declared after it`,
+		},
+		{
+			Name: "circular reference",
+			SQL:  `create table "t5" (a int8 generated always as (b + 1) stored, b int8 generated always as (a + 1) stored);`,
+			Err:  `cannot reference generated column "b" declared after it`,
+		},
+		{
+			Name: "non-deterministic expression",
+			SQL:  `create table "t4" (a timestamp, b timestamp generated always as (now()) stored);`,
+			Err:  `must be deterministic`,
+		},
+	}
+
+	for _, aTestCase := range testCases {
+		s.Run(aTestCase.Name, func() {
+			_, err := s.db.Exec(aTestCase.SQL)
+			s.Require().Error(err)
+			s.Contains(err.Error(), aTestCase.Err)
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package e2etests
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+)
+
+func (s *TestSuite) TestInsert_BatchCommit() {
+	const numRows = 2000
+
+	s.Require().NoError(s.db.Close())
+	db, err := sql.Open("minisql", s.dbFile.Name()+"?insert_batch_size=250&insert_batch_commit=on")
+	s.Require().NoError(err)
+	db.SetMaxOpenConns(1)
+	s.db = db
+
+	_, err = s.db.Exec(`create table "events" (
+		id   int8 primary key autoincrement,
+		name varchar(100) not null
+	)`)
+	s.Require().NoError(err)
+
+	values := make([]string, 0, numRows)
+	for i := range numRows {
+		values = append(values, fmt.Sprintf("('event %d')", i))
+	}
+	res, err := s.db.Exec(`insert into "events" (name) values ` + strings.Join(values, ", "))
+	s.Require().NoError(err)
+
+	affected, err := res.RowsAffected()
+	s.Require().NoError(err)
+	s.Equal(int64(numRows), affected)
+	lastID, err := res.LastInsertId()
+	s.Require().NoError(err)
+	s.Equal(int64(numRows), lastID)
+
+	var count int64
+	s.Require().NoError(s.db.QueryRow(`select count(*) from "events"`).Scan(&count))
+	s.Equal(int64(numRows), count)
+
+	var name string
+	s.Require().NoError(s.db.QueryRow(`select name from "events" where id = ?`, numRows).Scan(&name))
+	s.Equal(fmt.Sprintf("event %d", numRows-1), name)
+}
@@ -0,0 +1,83 @@
+package e2etests
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"strings"
+
+	"github.com/RichardKnop/minisql/internal/minisql"
+)
+
+func (s *TestSuite) TestInsert_ShuffledPrimaryKeys() {
+	_, err := s.db.Exec(`create table "items" (
+		id   int8 primary key,
+		code varchar(100) not null unique,
+		qty  int4
+	)`)
+	s.Require().NoError(err)
+	_, err = s.db.Exec(`create index "idx_items_qty" on "items" (qty);`)
+	s.Require().NoError(err)
+
+	// Enough rows to split index and table leaves several times.
+	const n = 2000
+	ids := rand.New(rand.NewPCG(1, 2)).Perm(n)
+	tuples := make([]string, 0, n)
+	for _, id := range ids {
+		tuples = append(tuples, fmt.Sprintf("(%d, 'code-%d', %d)", id+1, id+1, (id+1)%10))
+	}
+
+	s.Run("rows are stored once each in key order", func() {
+		res, err := s.db.Exec(`insert into "items" (id, code, qty) values ` + strings.Join(tuples, ", "))
+		s.Require().NoError(err)
+
+		affected, err := res.RowsAffected()
+		s.Require().NoError(err)
+		s.Equal(int64(n), affected)
+
+		// LastInsertId reports the last row of the VALUES list, not the largest key.
+		lastID, err := res.LastInsertId()
+		s.Require().NoError(err)
+		s.Equal(int64(ids[n-1]+1), lastID)
+
+		rows, err := s.db.Query(`select id, code from "items"`)
+		s.Require().NoError(err)
+		defer rows.Close()
+		expected := int64(1)
+		for rows.Next() {
+			var (
+				id   int64
+				code string
+			)
+			s.Require().NoError(rows.Scan(&id, &code))
+			s.Equal(expected, id)
+			s.Equal(fmt.Sprintf("code-%d", id), code)
+			expected++
+		}
+		s.Require().NoError(rows.Err())
+		s.Equal(int64(n+1), expected)
+	})
+
+	s.Run("secondary indexes see every row", func() {
+		var count int64
+		s.Require().NoError(s.db.QueryRow(`select count(*) from "items" where qty = 3`).Scan(&count))
+		s.Equal(int64(n/10), count)
+
+		var id int64
+		s.Require().NoError(s.db.QueryRow(`select id from "items" where code = 'code-1234'`).Scan(&id))
+		s.Equal(int64(1234), id)
+	})
+
+	s.Run("duplicate key rolls back the whole statement", func() {
+		_, err := s.db.Exec(`insert into "items" (id, code, qty) values (5003, 'a', 1), (5001, 'b', 1), (1500, 'c', 1), (5002, 'd', 1)`)
+		s.Require().ErrorIs(err, minisql.ErrDuplicateKey)
+
+		var count int64
+		s.Require().NoError(s.db.QueryRow(`select count(*) from "items"`).Scan(&count))
+		s.Equal(int64(n), count)
+	})
+
+	s.Run("duplicate key within the VALUES list", func() {
+		_, err := s.db.Exec(`insert into "items" (id, code, qty) values (6002, 'e', 1), (6001, 'f', 1), (6002, 'g', 1)`)
+		s.Require().ErrorIs(err, minisql.ErrDuplicateKey)
+	})
+}
@@ -0,0 +1,47 @@
+package e2etests
+
+import (
+	"database/sql"
+)
+
+func (s *TestSuite) TestInsert_ReturningInputOrder() {
+	queryIDs := func(db *sql.DB, query string) []int64 {
+		rows, err := db.Query(query)
+		s.Require().NoError(err)
+		defer rows.Close()
+
+		var ids []int64
+		for rows.Next() {
+			var id int64
+			s.Require().NoError(rows.Scan(&id))
+			ids = append(ids, id)
+		}
+		s.Require().NoError(rows.Err())
+		return ids
+	}
+
+	_, err := s.db.Exec(`create table "items" (
+		id   int8 primary key,
+		name varchar(100) not null
+	)`)
+	s.Require().NoError(err)
+
+	s.Run("RETURNING follows VALUES order, not key order", func() {
+		ids := queryIDs(s.db, `insert into "items" (id, name) values (30, 'c'), (10, 'a'), (20, 'b') returning id`)
+		s.Equal([]int64{30, 10, 20}, ids)
+
+		// Storage (primary key) order differs from the input order.
+		s.Equal([]int64{10, 20, 30}, queryIDs(s.db, `select id from "items" order by id`))
+	})
+
+	s.Run("RETURNING follows VALUES order across insert batches", func() {
+		s.Require().NoError(s.db.Close())
+		db, err := sql.Open("minisql", s.dbFile.Name()+"?insert_batch_size=2&insert_batch_commit=on")
+		s.Require().NoError(err)
+		db.SetMaxOpenConns(1)
+		s.db = db
+
+		ids := queryIDs(s.db, `insert into "items" (id, name) values (60, 'f'), (40, 'd'), (70, 'g'), (50, 'e'), (45, 'x') returning id`)
+		s.Equal([]int64{60, 40, 70, 50, 45}, ids)
+	})
+}
@@ -258,6 +258,75 @@ func (s *TestSuite) TestInnerJoin() {
 		s.Require().NoError(rows.Err())
 		s.Equal(len(expectedByMultiple), i, "Expected %d rows, got %d", len(expectedByMultiple), i)
 	})
+
+	s.Run("SELECT with INNER JOIN and qualified wildcard", func() {
+		rows, err := s.db.Query(`
+			select
+				u.*,
+				o.amount
+			from users as u inner join orders as o on u.id = o.user_id
+			order by o.amount;
+		`)
+		s.Require().NoError(err)
+		defer rows.Close()
+
+		columns, err := rows.Columns()
+		s.Require().NoError(err)
+		s.Equal([]string{"u.id", "u.name", "u.age", "amount"}, columns)
+
+		type result struct {
+			userID   int64
+			username string
+			age      int64
+			amount   int64
+		}
+		var actualResults []result
+		for rows.Next() {
+			var aResult result
+			err := rows.Scan(&aResult.userID, &aResult.username, &aResult.age, &aResult.amount)
+			s.Require().NoError(err)
+			actualResults = append(actualResults, aResult)
+		}
+		s.Require().NoError(rows.Err())
+
+		s.Equal([]result{
+			{1, "Alice", 25, 100},
+			{2, "Bob", 30, 150},
+			{1, "Alice", 25, 200},
+		}, actualResults)
+	})
+
+	s.Run("SELECT with qualified wildcards of both tables", func() {
+		rows, err := s.db.Query(`
+			select u.*, o.*
+			from users as u inner join orders as o on u.id = o.user_id
+			where o.id = 3;
+		`)
+		s.Require().NoError(err)
+		defer rows.Close()
+
+		columns, err := rows.Columns()
+		s.Require().NoError(err)
+		s.Equal([]string{"u.id", "u.name", "u.age", "o.id", "o.user_id", "o.amount"}, columns)
+
+		s.Require().True(rows.Next())
+		var (
+			userID, age, orderID, orderUserID, amount int64
+			username                                  string
+		)
+		s.Require().NoError(rows.Scan(&userID, &username, &age, &orderID, &orderUserID, &amount))
+		s.Equal(int64(2), userID)
+		s.Equal("Bob", username)
+		s.Equal(int64(3), orderID)
+		s.Equal(int64(150), amount)
+		s.False(rows.Next())
+		s.Require().NoError(rows.Err())
+	})
+
+	s.Run("SELECT with qualified wildcard of unknown alias", func() {
+		_, err := s.db.Query(`select x.* from users as u inner join orders as o on u.id = o.user_id;`)
+		s.Require().ErrorContains(err, `unknown table alias "x" in x.*`)
+	})
 }
 
 func (s *TestSuite) TestInnerJoin_WithSecondaryIndex() {
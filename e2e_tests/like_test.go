@@ -2,6 +2,8 @@ package e2etests
 
 import (
 	"fmt"
+
+	"github.com/RichardKnop/minisql/internal/minisql"
 )
 
 func (s *TestSuite) TestLike_PrefixMatch() {
@@ -144,6 +146,23 @@ func (s *TestSuite) TestLike_NoMatch() {
 	s.Equal(0, count)
 }
 
+func (s *TestSuite) TestLike_RejectsNonTextOperands() {
+	_, err := s.db.Exec(createUsersTableSQL)
+	s.Require().NoError(err)
+
+	_, err = s.db.Query(`select name from "users" WHERE id LIKE '1%'`)
+	s.Require().Error(err)
+	s.Contains(err.Error(), `LIKE not supported for int8 column "id"`)
+
+	_, err = s.db.Query(`select name from "users" WHERE name NOT LIKE 5`)
+	s.Require().Error(err)
+	s.Contains(err.Error(), "NOT LIKE pattern must be a string")
+
+	_, err = s.db.Query(`select name from "users" WHERE name LIKE ?`, 5)
+	s.Require().Error(err)
+	s.Contains(err.Error(), "LIKE pattern must be a string")
+}
+
 func (s *TestSuite) TestNotLike_Basic() {
 	_, err := s.db.Exec(createUsersTableSQL)
 	s.Require().NoError(err)
@@ -321,3 +340,59 @@ func (s *TestSuite) TestLike_DeleteWithLike() {
 
 	s.countRowsInTable("users", 1)
 }
+
+func (s *TestSuite) TestLike_Escape() {
+	_, err := s.db.Exec(createUsersTableSQL)
+	s.Require().NoError(err)
+
+	stmt, err := s.db.Prepare(`insert into "users" (email, name) values (?, ?)`)
+	s.Require().NoError(err)
+
+	for i, name := range []string{"100%", "1000", "a_b", "axb", `a\b`} {
+		_, err := stmt.Exec(fmt.Sprintf("user%d@example.com", i), name)
+		s.Require().NoError(err)
+	}
+
+	queryNames := func(query string, args ...any) []string {
+		rows, err := s.db.Query(query, args...)
+		s.Require().NoError(err)
+		defer rows.Close()
+
+		var names []string
+		for rows.Next() {
+			var name string
+			s.Require().NoError(rows.Scan(&name))
+			names = append(names, name)
+		}
+		s.Require().NoError(rows.Err())
+		return names
+	}
+
+	s.Run("unescaped wildcards", func() {
+		s.ElementsMatch([]string{"100%", "1000"}, queryNames(`select name from "users" where name LIKE '100_'`))
+		s.ElementsMatch([]string{"a_b", "axb", `a\b`}, queryNames(`select name from "users" where name LIKE 'a_b'`))
+	})
+
+	s.Run("default backslash escape", func() {
+		s.Equal([]string{"100%"}, queryNames(`select name from "users" where name LIKE '100\%'`))
+		s.Equal([]string{"a_b"}, queryNames(`select name from "users" where name LIKE 'a\_b'`))
+		s.Equal([]string{"100%"}, queryNames(`select name from "users" where name LIKE ?`, `%\%`))
+	})
+
+	s.Run("explicit backslash escape", func() {
+		s.Equal([]string{"100%"}, queryNames(`select name from "users" where name LIKE '100\%' ESCAPE '\'`))
+	})
+
+	s.Run("custom escape character", func() {
+		s.Equal([]string{"100%"}, queryNames(`select name from "users" where name LIKE '100!%' ESCAPE '!'`))
+		s.Equal([]string{"a_b"}, queryNames(`select name from "users" where name LIKE 'a!_b' ESCAPE '!'`))
+		s.Equal([]string{`a\b`}, queryNames(`select name from "users" where name LIKE 'a\b' ESCAPE '!'`))
+		s.ElementsMatch([]string{"1000", "axb", `a\b`}, queryNames(`select name from "users" where name NOT LIKE '%!%%' ESCAPE '!' and name NOT LIKE 'a!_b' ESCAPE '!'`))
+	})
+
+	s.Run("invalid escape", func() {
+		_, err := s.db.Query(`select name from "users" where name LIKE 'a%' ESCAPE 'ab'`)
+		s.Require().Error(err)
+		s.ErrorIs(err, minisql.ErrInvalidLikeEscape)
+	})
+}
@@ -0,0 +1,138 @@
+package e2etests
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/RichardKnop/minisql"
+)
+
+func openMemoryDB(t *testing.T, params string) *sql.DB {
+	t.Helper()
+
+	db, err := sql.Open("minisql", minisql.MemoryPath+params)
+	require.NoError(t, err)
+	db.SetMaxOpenConns(1)
+	t.Cleanup(func() { _ = db.Close() })
+	return db
+}
+
+func TestMemoryDatabase(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	// A tiny page cache makes the inserts below evict pages to the memory file.
+	db := openMemoryDB(t, "?max_cached_pages=16")
+
+	_, err := db.ExecContext(ctx, `create table users (
+		id int8 primary key autoincrement,
+		email varchar(255) unique,
+		name varchar(255)
+	);`)
+	require.NoError(t, err)
+	_, err = db.ExecContext(ctx, `create index idx_users_name on users (name);`)
+	require.NoError(t, err)
+
+	_, err = db.ExecContext(ctx, `insert into users (email, name) values ('alice@example.com', 'Alice'), ('bob@example.com', 'Bob');`)
+	require.NoError(t, err)
+
+	t.Run("Rolled back transaction leaves no trace", func(t *testing.T) {
+		tx, err := db.BeginTx(ctx, nil)
+		require.NoError(t, err)
+		_, err = tx.ExecContext(ctx, `insert into users (email, name) values ('carol@example.com', 'Carol');`)
+		require.NoError(t, err)
+		require.NoError(t, tx.Rollback())
+
+		var count int
+		require.NoError(t, db.QueryRowContext(ctx, `select count(*) from users;`).Scan(&count))
+		assert.Equal(t, 2, count)
+	})
+
+	t.Run("Committed transaction is visible", func(t *testing.T) {
+		tx, err := db.BeginTx(ctx, nil)
+		require.NoError(t, err)
+		_, err = tx.ExecContext(ctx, `update users set name = 'Robert' where email = 'bob@example.com';`)
+		require.NoError(t, err)
+		_, err = tx.ExecContext(ctx, `delete from users where email = 'alice@example.com';`)
+		require.NoError(t, err)
+		require.NoError(t, tx.Commit())
+
+		var name string
+		require.NoError(t, db.QueryRowContext(ctx, `select name from users where name = 'Robert';`).Scan(&name))
+		assert.Equal(t, "Robert", name)
+
+		var count int
+		require.NoError(t, db.QueryRowContext(ctx, `select count(*) from users;`).Scan(&count))
+		assert.Equal(t, 1, count)
+	})
+
+	t.Run("Many rows spill past the page cache", func(t *testing.T) {
+		tx, err := db.BeginTx(ctx, nil)
+		require.NoError(t, err)
+		stmt, err := tx.PrepareContext(ctx, `insert into users (email, name) values (?, ?);`)
+		require.NoError(t, err)
+		for i := range 5000 {
+			_, err = stmt.ExecContext(ctx, fmt.Sprintf("user%d@example.com", i), "User")
+			require.NoError(t, err)
+		}
+		require.NoError(t, stmt.Close())
+		require.NoError(t, tx.Commit())
+
+		var count int
+		require.NoError(t, db.QueryRowContext(ctx, `select count(*) from users where name = 'User';`).Scan(&count))
+		assert.Equal(t, 5000, count)
+	})
+
+	t.Run("VACUUM and backups are not supported", func(t *testing.T) {
+		_, err := db.ExecContext(ctx, `VACUUM`)
+		require.ErrorIs(t, err, minisql.ErrInMemoryDatabase)
+
+		err = minisql.BackupTo(ctx, db, io.Discard)
+		require.ErrorIs(t, err, minisql.ErrInMemoryDatabase)
+	})
+}
+
+func TestMemoryDatabase_AutoVacuum(t *testing.T) {
+	t.Parallel()
+
+	// Autovacuum would fail after every commit past the threshold, so the
+	// connection string is rejected up front.
+	db, err := sql.Open("minisql", minisql.MemoryPath+"?auto_vacuum=0.1")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+
+	err = db.PingContext(context.Background())
+	require.ErrorIs(t, err, minisql.ErrInMemoryDatabase)
+
+	// A zero ratio disables autovacuum and is accepted.
+	db = openMemoryDB(t, "?auto_vacuum=0")
+	_, err = db.ExecContext(context.Background(), `create table t (id int8 primary key);`)
+	require.NoError(t, err)
+}
+
+func TestMemoryDatabase_PrivateAndEphemeral(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	first := openMemoryDB(t, "")
+	second := openMemoryDB(t, "")
+
+	_, err := first.ExecContext(ctx, `create table items (id int8 primary key);`)
+	require.NoError(t, err)
+
+	// The second database is independent of the first one.
+	_, err = second.ExecContext(ctx, `select * from items;`)
+	require.Error(t, err)
+
+	// Closing drops the contents, a new in-memory database starts empty.
+	require.NoError(t, first.Close())
+	third := openMemoryDB(t, "")
+	_, err = third.ExecContext(ctx, `create table items (id int8 primary key);`)
+	require.NoError(t, err)
+}
@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"fmt"
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -83,6 +84,53 @@ func TestReadMetrics_Basic(t *testing.T) {
 	assert.Zero(t, m.SortSpillRuns, "no disk spill expected for 50 rows")
 }
 
+// TestReadMetrics_StatementCache verifies that repeated queries skip the
+// parser and that DDL invalidates the cached statements.
+func TestReadMetrics_StatementCache(t *testing.T) {
+	ctx := context.Background()
+	db := openMetricsDB(t)
+
+	_, err := db.ExecContext(ctx, `create table "pairs" (id int8 primary key autoincrement, a int4, b int4)`)
+	require.NoError(t, err)
+
+	const query = `insert into "pairs" (a, b) values (?, ?)`
+	insertPrepared := func(a, b int) {
+		stmt, err := db.PrepareContext(ctx, query)
+		require.NoError(t, err)
+		defer stmt.Close()
+		_, err = stmt.ExecContext(ctx, a, b)
+		require.NoError(t, err)
+	}
+
+	before, err := minisql.ReadMetrics(ctx, db)
+	require.NoError(t, err)
+	for i := range 5 {
+		insertPrepared(i, i*10)
+	}
+	m, err := minisql.ReadMetrics(ctx, db)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), m.StmtCacheMisses-before.StmtCacheMisses)
+	assert.Equal(t, int64(4), m.StmtCacheHits-before.StmtCacheHits)
+
+	// Dropping and re-adding a moves it behind b. The cached INSERT must be
+	// re-prepared against the new column order.
+	_, err = db.ExecContext(ctx, `alter table "pairs" drop column a`)
+	require.NoError(t, err)
+	_, err = db.ExecContext(ctx, `alter table "pairs" add column a int4`)
+	require.NoError(t, err)
+
+	before = m
+	insertPrepared(7, 70)
+	m, err = minisql.ReadMetrics(ctx, db)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), m.StmtCacheMisses-before.StmtCacheMisses, "both ALTERs and the INSERT are parsed")
+
+	var a, b int64
+	require.NoError(t, db.QueryRowContext(ctx, `select a, b from "pairs" where b = 70`).Scan(&a, &b))
+	assert.Equal(t, int64(7), a)
+	assert.Equal(t, int64(70), b)
+}
+
 // TestReadMetrics_SpillCounters verifies that SortSpillRuns and SortSpillBytes
 // are incremented when an ORDER BY query exceeds sort_mem_limit.
 func TestReadMetrics_SpillCounters(t *testing.T) {
@@ -150,3 +198,130 @@ func TestReadMetrics_WALCheckpoint(t *testing.T) {
 		"WALCheckpoints should increase after explicit checkpoint")
 	assert.GreaterOrEqual(t, after.QueriesTotal, before.QueriesTotal+1)
 }
+
+// TestReadMetrics_PageCacheEviction verifies that a page cache much smaller
+// than the database evicts pages without losing data and that the hit ratio
+// reflects the cache activity.
+func TestReadMetrics_PageCacheEviction(t *testing.T) {
+	ctx := context.Background()
+	f, err := os.CreateTemp("", "minisql_metrics_*.db")
+	require.NoError(t, err)
+	dbPath := f.Name()
+	f.Close()
+	t.Cleanup(func() {
+		os.Remove(dbPath)
+		os.Remove(dbPath + "-wal")
+	})
+	db, err := sql.Open("minisql", dbPath+"?max_cached_pages=8")
+	require.NoError(t, err)
+	db.SetMaxOpenConns(1)
+	db.SetMaxIdleConns(1)
+	t.Cleanup(func() { db.Close() })
+
+	m, err := minisql.ReadMetrics(ctx, db)
+	require.NoError(t, err)
+	assert.Equal(t, int64(8), m.PageCacheCapacity)
+
+	_, err = db.ExecContext(ctx, `create table "docs" (id int8 primary key, body varchar(1000))`)
+	require.NoError(t, err)
+	const rowCount = 200
+	for i := range rowCount {
+		_, err = db.ExecContext(ctx, `insert into "docs" (id, body) values (?, ?)`, i, fmt.Sprintf("%04d-%0800d", i, i))
+		require.NoError(t, err)
+	}
+
+	for range 2 {
+		rows, err := db.QueryContext(ctx, `select id, body from "docs"`)
+		require.NoError(t, err)
+		n := 0
+		for rows.Next() {
+			var (
+				id   int64
+				body string
+			)
+			require.NoError(t, rows.Scan(&id, &body))
+			assert.Equal(t, fmt.Sprintf("%04d-%0800d", id, id), body)
+			n++
+		}
+		require.NoError(t, rows.Err())
+		require.NoError(t, rows.Close())
+		assert.Equal(t, rowCount, n)
+	}
+
+	var body string
+	require.NoError(t, db.QueryRowContext(ctx, `select body from "docs" where id = ?`, 123).Scan(&body))
+	assert.Equal(t, fmt.Sprintf("%04d-%0800d", 123, 123), body)
+
+	m, err = minisql.ReadMetrics(ctx, db)
+	require.NoError(t, err)
+	assert.Positive(t, m.PageCacheEvictions, "a table larger than the cache must evict pages")
+	assert.LessOrEqual(t, m.PageCacheSize, m.PageCacheCapacity)
+	ratio := m.PageCacheHitRatio()
+	assert.Greater(t, ratio, 0.0)
+	assert.Less(t, ratio, 1.0)
+	assert.InDelta(t, float64(m.PageCacheHits)/float64(m.PageCacheHits+m.PageCacheMisses), ratio, 1e-9)
+
+	assert.Zero(t, minisql.Metrics{}.PageCacheHitRatio(), "no requests yet")
+}
+
+// TestReadMetrics_LimitStopsScan verifies through the page cache counters that
+// an unordered LIMIT stops reading the table once enough rows are produced,
+// while an ORDER BY on an unindexed column still has to read every row.
+func TestReadMetrics_LimitStopsScan(t *testing.T) {
+	ctx := context.Background()
+	db := openMetricsDB(t)
+
+	_, err := db.ExecContext(ctx, `create table "logs" (id int8 primary key, level int4 not null, msg varchar(200) not null)`)
+	require.NoError(t, err)
+	// About 30 rows per leaf, so the table spans well over a hundred pages.
+	tuples := make([]string, 0, 5000)
+	for i := range 5000 {
+		tuples = append(tuples, fmt.Sprintf("(%d, %d, '%s')", i+1, i%5, strings.Repeat("m", 100)))
+	}
+	_, err = db.ExecContext(ctx, `insert into "logs" (id, level, msg) values `+strings.Join(tuples, ", "))
+	require.NoError(t, err)
+
+	// pagesRequested drains query and returns how many page requests it made.
+	pagesRequested := func(query string) int64 {
+		before, err := minisql.ReadMetrics(ctx, db)
+		require.NoError(t, err)
+		rows, err := db.QueryContext(ctx, query)
+		require.NoError(t, err)
+		n := 0
+		for rows.Next() {
+			n++
+		}
+		require.NoError(t, rows.Err())
+		require.NoError(t, rows.Close())
+		require.Positive(t, n)
+		after, err := minisql.ReadMetrics(ctx, db)
+		require.NoError(t, err)
+		return after.PageCacheHits + after.PageCacheMisses - before.PageCacheHits - before.PageCacheMisses
+	}
+
+	full := pagesRequested(`select * from "logs"`)
+	require.Greater(t, full, int64(100))
+
+	testCases := []struct {
+		Name     string
+		Query    string
+		MaxPages int64
+	}{
+		{"LIMIT", `select * from "logs" limit 10`, 5},
+		{"LIMIT with OFFSET", `select * from "logs" limit 10 offset 100`, 8},
+		{"LIMIT with a filter matching every row", `select * from "logs" where level >= 0 limit 10`, 5},
+		{"LIMIT with ORDER BY on the primary key", `select * from "logs" order by id limit 10`, 30},
+		{"rowid keyset pagination", `select rowid, msg from "logs" where rowid > 2500 order by rowid limit 10`, 8},
+		{"rowid seek", `select rowid, msg from "logs" where rowid = 4000`, 5},
+		{"rowid descending", `select rowid, msg from "logs" order by rowid desc limit 10`, 8},
+	}
+	for _, aTestCase := range testCases {
+		t.Run(aTestCase.Name, func(t *testing.T) {
+			assert.LessOrEqual(t, pagesRequested(aTestCase.Query), aTestCase.MaxPages)
+		})
+	}
+
+	t.Run("LIMIT with ORDER BY on an unindexed column reads every row", func(t *testing.T) {
+		assert.GreaterOrEqual(t, pagesRequested(`select * from "logs" order by msg limit 10`), full)
+	})
+}
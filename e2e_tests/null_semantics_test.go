@@ -1,6 +1,9 @@
 package e2etests
 
-import "database/sql"
+import (
+	"database/sql"
+	"time"
+)
 
 // TestNullSemantics_GroupByNullFormsOwnGroup verifies that NULL values in a
 // GROUP BY key form their own group (standard SQL behaviour).
@@ -24,7 +27,7 @@ func (s *TestSuite) TestNullSemantics_GroupByNullFormsOwnGroup() {
 	_, err = s.db.Exec(`insert into "scores" (val) values (88)`)
 	s.Require().NoError(err)
 
-	rows, err := s.db.Query(`select category, count(*), sum(val) from "scores" group by category order by category`)
+	rows, err := s.db.Query(`select category, count(*), sum(val) from "scores" group by category order by category nulls first`)
 	s.Require().NoError(err)
 	defer rows.Close()
 
@@ -41,7 +44,7 @@ func (s *TestSuite) TestNullSemantics_GroupByNullFormsOwnGroup() {
 	}
 	s.Require().NoError(rows.Err())
 
-	// NULL group sorts first (nulls first in our ORDER BY); then A, then B.
+	// NULL group sorts first (NULLS FIRST); then A, then B.
 	s.Require().Len(groups, 3)
 
 	nullGroup := groups[0]
@@ -60,6 +63,56 @@ func (s *TestSuite) TestNullSemantics_GroupByNullFormsOwnGroup() {
 	s.Equal(int64(5), bGroup.sum)
 }
 
+// TestNullSemantics_GroupByTimestampUsesFullValue verifies that GROUP BY on a
+// TIMESTAMP column keys on the full microsecond value, and that NULL timestamps
+// still form their own group.
+func (s *TestSuite) TestNullSemantics_GroupByTimestampUsesFullValue() {
+	_, err := s.db.Exec(`create table "events" (
+		id      int8 primary key autoincrement,
+		seen_at timestamp,
+		val     int8 not null
+	)`)
+	s.Require().NoError(err)
+
+	for _, ts := range []string{
+		"2024-06-15 12:34:56.000001",
+		"2024-06-15 12:34:56.000001",
+		"2024-06-15 12:34:56.000002",
+		"2024-06-15 12:34:56",
+	} {
+		_, err = s.db.Exec(`insert into "events" (seen_at, val) values (?, 1)`, ts)
+		s.Require().NoError(err)
+	}
+	_, err = s.db.Exec(`insert into "events" (val) values (1)`)
+	s.Require().NoError(err)
+
+	rows, err := s.db.Query(`select seen_at, count(*) from "events" group by seen_at order by seen_at nulls first`)
+	s.Require().NoError(err)
+	defer rows.Close()
+
+	type groupRow struct {
+		seenAt sql.NullTime
+		count  int64
+	}
+	var groups []groupRow
+	for rows.Next() {
+		var r groupRow
+		s.Require().NoError(rows.Scan(&r.seenAt, &r.count))
+		groups = append(groups, r)
+	}
+	s.Require().NoError(rows.Err())
+
+	s.Require().Len(groups, 4)
+	s.False(groups[0].seenAt.Valid)
+	s.Equal(int64(1), groups[0].count)
+	s.Equal(time.Date(2024, 6, 15, 12, 34, 56, 0, time.UTC), groups[1].seenAt.Time)
+	s.Equal(int64(1), groups[1].count)
+	s.Equal(time.Date(2024, 6, 15, 12, 34, 56, 1000, time.UTC), groups[2].seenAt.Time)
+	s.Equal(int64(2), groups[2].count)
+	s.Equal(time.Date(2024, 6, 15, 12, 34, 56, 2000, time.UTC), groups[3].seenAt.Time)
+	s.Equal(int64(1), groups[3].count)
+}
+
 // TestNullSemantics_IsNullVsEqualsNull verifies that IS NULL matches NULL rows
 // while = NULL never matches (SQL three-value logic).
 func (s *TestSuite) TestNullSemantics_IsNullVsEqualsNull() {
@@ -139,8 +192,8 @@ func (s *TestSuite) TestNullSemantics_NullableColumnAggregate() {
 	s.Require().NoError(s.db.QueryRow(`select max(val) from "readings"`).Scan(&maxVal))
 
 	s.Equal(int64(5), countStar)
-	s.Equal(int64(60), sumVal)      // 10+20+30, NULLs excluded
-	s.InDelta(20.0, avgVal, 0.001)  // 60/3
+	s.Equal(int64(60), sumVal)     // 10+20+30, NULLs excluded
+	s.InDelta(20.0, avgVal, 0.001) // 60/3
 	s.Equal(int64(10), minVal)
 	s.Equal(int64(30), maxVal)
 }
@@ -167,3 +220,114 @@ func (s *TestSuite) TestNullSemantics_UpdateSetsColumnToNull() {
 	s.Require().NoError(s.db.QueryRow(`select score from "users" where id = ?`, id).Scan(&score))
 	s.False(score.Valid, "score should be NULL after update")
 }
+
+// TestNullSemantics_BooleanFilters verifies three-valued logic for BOOLEAN
+// columns: NULL matches neither TRUE nor FALSE, so negating a comparison with
+// != never brings NULL rows back.
+func (s *TestSuite) TestNullSemantics_BooleanFilters() {
+	_, err := s.db.Exec(`create table "accounts" (
+		id       int8 primary key,
+		verified boolean
+	)`)
+	s.Require().NoError(err)
+	_, err = s.db.Exec(`insert into "accounts" (id, verified) values (1, true), (2, false), (3, null), (4, true)`)
+	s.Require().NoError(err)
+
+	ids := func(query string, args ...any) []int64 {
+		rows, err := s.db.Query(query, args...)
+		s.Require().NoError(err, query)
+		defer rows.Close()
+		var ids []int64
+		for rows.Next() {
+			var id int64
+			s.Require().NoError(rows.Scan(&id))
+			ids = append(ids, id)
+		}
+		s.Require().NoError(rows.Err())
+		return ids
+	}
+
+	s.Equal([]int64{1, 4}, ids(`select id from "accounts" where verified = true order by id`))
+	s.Equal([]int64{2}, ids(`select id from "accounts" where verified = false order by id`))
+	s.Equal([]int64{1, 4}, ids(`select id from "accounts" where verified = ? order by id`, true))
+	s.Equal([]int64{3}, ids(`select id from "accounts" where verified is null order by id`))
+	s.Equal([]int64{1, 2, 4}, ids(`select id from "accounts" where verified is not null order by id`))
+
+	// Negation excludes NULL rows.
+	s.Equal([]int64{2}, ids(`select id from "accounts" where verified != true order by id`))
+	s.Equal([]int64{1, 4}, ids(`select id from "accounts" where verified != false order by id`))
+	s.Equal([]int64{2, 3}, ids(`select id from "accounts" where verified != true or verified is null order by id`))
+
+	// UPDATE and DELETE filter the same way.
+	s.execQuery(`update "accounts" set verified = true where verified != true`, 1)
+	s.execQuery(`delete from "accounts" where verified = false`, 0)
+	s.Equal([]int64{3}, ids(`select id from "accounts" where verified is null order by id`))
+
+	// Only TRUE, FALSE and NULL are valid right-hand sides, with = or !=.
+	for _, query := range []string{
+		`select id from "accounts" where verified = 1`,
+		`select id from "accounts" where verified = 'yes'`,
+		`select id from "accounts" where verified > false`,
+	} {
+		_, err := s.db.Query(query)
+		s.Require().Error(err, query)
+		s.Contains(err.Error(), `boolean column "verified"`, query)
+	}
+	_, err = s.db.Query(`select id from "accounts" where verified = ?`, 1)
+	s.Require().Error(err)
+}
+
+// TestNullSemantics_IsNullInConditionGroups verifies IS NULL / IS NOT NULL
+// inside AND/OR groups, that comparing with an empty scalar subquery (NULL)
+// matches nothing, and that NULL checks on NOT NULL columns are rejected.
+func (s *TestSuite) TestNullSemantics_IsNullInConditionGroups() {
+	_, err := s.db.Exec(`create table "people" (
+		id    int8 primary key autoincrement,
+		name  varchar(50) not null,
+		age   int8,
+		email text
+	)`)
+	s.Require().NoError(err)
+	s.execQuery(`insert into "people" (name, age, email) values
+('Ann', 30, 'ann@example.com'),
+('Bob', null, 'bob@example.com'),
+('Cid', 40, null),
+('Dee', null, null)`, 4)
+
+	names := func(query string) []string {
+		rows, err := s.db.Query(query)
+		s.Require().NoError(err, query)
+		defer rows.Close()
+		var names []string
+		for rows.Next() {
+			var name string
+			s.Require().NoError(rows.Scan(&name))
+			names = append(names, name)
+		}
+		s.Require().NoError(rows.Err())
+		return names
+	}
+
+	s.Equal([]string{"Bob", "Dee"}, names(`select name from "people" where age is null order by name`))
+	s.Equal([]string{"Ann", "Cid"}, names(`select name from "people" where age is not null order by name`))
+	s.Equal([]string{"Dee"}, names(`select name from "people" where age is null and email is null`))
+	s.Equal([]string{"Ann", "Bob", "Dee"}, names(`select name from "people" where age is null or email is not null order by name`))
+	s.Equal([]string{"Bob", "Cid"}, names(`select name from "people" where (age is null and email is not null) or (age > 35 and email is null) order by name`))
+
+	// A scalar subquery with no rows is NULL, and age = NULL is never true.
+	s.Empty(names(`select name from "people" where age = (select age from "people" where name = 'Zed')`))
+
+	s.execQuery(`update "people" set age = 0 where age is null and name = 'Bob'`, 1)
+	s.execQuery(`delete from "people" where age is null`, 1)
+	s.Equal([]string{"Ann", "Bob", "Cid"}, names(`select name from "people" order by name`))
+
+	for query, msg := range map[string]string{
+		`select name from "people" where name is null`:       `column "name" is NOT NULL, IS NULL is always false`,
+		`select name from "people" where id is not null`:     `column "id" is NOT NULL, IS NOT NULL is always true`,
+		`delete from "people" where age > 1 or name is null`: `column "name" is NOT NULL, IS NULL is always false`,
+	} {
+		_, err := s.db.Exec(query)
+		s.Require().Error(err, query)
+		s.Contains(err.Error(), msg, query)
+	}
+}
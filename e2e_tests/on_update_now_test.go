@@ -0,0 +1,72 @@
+package e2etests
+
+import (
+	"context"
+	"time"
+)
+
+func (s *TestSuite) TestOnUpdateNow() {
+	ctx := context.Background()
+
+	_, err := s.db.ExecContext(ctx, `create table "docs" (
+		id      int8 primary key autoincrement,
+		title   varchar(100) not null unique,
+		created timestamp default now(),
+		updated timestamp not null default now() on update now()
+	);`)
+	s.Require().NoError(err)
+
+	s.execQuery(`insert into docs(title) values('first'), ('second');`, 2)
+
+	// Pin both timestamps to a known point in the past. An explicit assignment
+	// takes precedence over ON UPDATE NOW().
+	pinned := time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+	s.execQuery(`update docs set created = '2000-01-01 00:00:00.000000', updated = '2000-01-01 00:00:00.000000';`, 2)
+
+	timestamps := func(id int64) (time.Time, time.Time) {
+		var created, updated time.Time
+		s.Require().NoError(s.db.QueryRowContext(ctx, `select created, updated from docs where id = ?;`, id).Scan(&created, &updated))
+		return created, updated
+	}
+
+	created, updated := timestamps(1)
+	s.True(created.Equal(pinned))
+	s.True(updated.Equal(pinned))
+
+	s.Run("UPDATE refreshes only the ON UPDATE NOW() column of matched rows", func() {
+		s.execQuery(`update docs set title = 'first edited' where id = 1;`, 1)
+
+		created, updated := timestamps(1)
+		s.True(created.Equal(pinned))
+		s.True(updated.After(pinned))
+
+		created, updated = timestamps(2)
+		s.True(created.Equal(pinned))
+		s.True(updated.Equal(pinned))
+	})
+
+	s.Run("ON CONFLICT DO UPDATE refreshes the column", func() {
+		s.execQuery(`insert into docs(title) values('second') ON CONFLICT DO UPDATE SET created = '2001-01-01 00:00:00.000000';`, 1)
+
+		_, updated := timestamps(2)
+		s.True(updated.After(pinned))
+	})
+
+	s.Run("Clause survives a reopen", func() {
+		s.execQuery(`update docs set updated = '2000-01-01 00:00:00.000000';`, 2)
+		s.db = s.reopenDB()
+
+		s.execQuery(`update docs set title = 'second edited' where id = 2;`, 1)
+
+		_, updated := timestamps(1)
+		s.True(updated.Equal(pinned))
+		_, updated = timestamps(2)
+		s.True(updated.After(pinned))
+	})
+
+	s.Run("Clause is rejected on non-TIMESTAMP columns", func() {
+		_, err := s.db.ExecContext(ctx, `create table "bad" (n int4 on update now());`)
+		s.Require().Error(err)
+		s.Contains(err.Error(), "ON UPDATE NOW() is only valid for TIMESTAMP columns")
+	})
+}
@@ -1,6 +1,7 @@
 package e2etests
 
 import (
+	"fmt"
 	"sort"
 )
 
@@ -114,3 +115,92 @@ func (s *TestSuite) TestORIndexUnion() {
 		s.Equal([]int{1, 2, 3}, ids)
 	})
 }
+
+// TestOR_MixedIndexedAndUnindexedGroups covers OR queries where only some of
+// the groups can use an index. The unindexed group forces a full table scan,
+// so the result must be the exact union of matching rows: nothing missed from
+// the unindexed branch and nothing returned twice by overlapping branches.
+func (s *TestSuite) TestOR_MixedIndexedAndUnindexedGroups() {
+	_, err := s.db.Exec(`create table "accounts" (
+		id     int8 primary key,
+		email  varchar(50) unique,
+		status varchar(20),
+		score  int8 not null
+	);`)
+	s.Require().NoError(err)
+	_, err = s.db.Exec(`create index idx_accounts_status on "accounts" (status);`)
+	s.Require().NoError(err)
+
+	// Every fifth row has a NULL email, every seventh a NULL status.
+	for i := 1; i <= 40; i++ {
+		var email, status any
+		if i%5 != 0 {
+			email = fmt.Sprintf("user%d@example.com", i)
+		}
+		if i%7 != 0 {
+			status = []string{"open", "closed", "pending"}[i%3]
+		}
+		_, err = s.db.Exec(`insert into "accounts" (id, email, status, score) values (?, ?, ?, ?);`, i, email, status, i%4)
+		s.Require().NoError(err)
+	}
+
+	queryIDs := func(query string) []int {
+		rows, err := s.db.Query(query)
+		s.Require().NoError(err)
+		defer rows.Close()
+
+		var ids []int
+		for rows.Next() {
+			var id int
+			s.Require().NoError(rows.Scan(&id))
+			ids = append(ids, id)
+		}
+		s.Require().NoError(rows.Err())
+		sort.Ints(ids)
+		return ids
+	}
+
+	s.Run("primary key OR unindexed column", func() {
+		// Row 3 matches both branches and must be returned once.
+		ids := queryIDs(`select id from "accounts" where id = 3 or score = 3;`)
+		s.Equal([]int{3, 7, 11, 15, 19, 23, 27, 31, 35, 39}, ids)
+	})
+
+	s.Run("unique index OR IS NULL on the same column", func() {
+		ids := queryIDs(`select id from "accounts" where email = 'user1@example.com' or email is null;`)
+		s.Equal([]int{1, 5, 10, 15, 20, 25, 30, 35, 40}, ids)
+	})
+
+	s.Run("secondary index OR IS NULL on another column", func() {
+		ids := queryIDs(`select id from "accounts" where status = 'pending' and score = 1 or email is null;`)
+		s.Equal([]int{5, 10, 15, 17, 20, 25, 29, 30, 35, 40}, ids)
+	})
+
+	s.Run("range scan OR unindexed column", func() {
+		ids := queryIDs(`select id from "accounts" where id > 37 or status is null;`)
+		s.Equal([]int{7, 14, 21, 28, 35, 38, 39, 40}, ids)
+	})
+
+	s.Run("count matches row union", func() {
+		var count int
+		err := s.db.QueryRow(`select count(*) from "accounts" where id = 3 or score = 3;`).Scan(&count)
+		s.Require().NoError(err)
+		s.Equal(10, count)
+	})
+
+	s.Run("delete with mixed OR removes every matching row", func() {
+		res, err := s.db.Exec(`delete from "accounts" where id = 1 or email is null;`)
+		s.Require().NoError(err)
+		affected, err := res.RowsAffected()
+		s.Require().NoError(err)
+		s.Equal(int64(9), affected)
+
+		var count int
+		err = s.db.QueryRow(`select count(*) from "accounts" where email is null;`).Scan(&count)
+		s.Require().NoError(err)
+		s.Equal(0, count)
+		err = s.db.QueryRow(`select count(*) from "accounts";`).Scan(&count)
+		s.Require().NoError(err)
+		s.Equal(31, count)
+	})
+}
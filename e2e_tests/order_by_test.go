@@ -173,8 +173,8 @@ func (s *TestSuite) TestOrderByMultiColumn() {
 }
 
 // TestOrderByCompositeIndex verifies that the query planner uses a composite index to
-// satisfy a multi-column ORDER BY clause when the index columns match exactly (same
-// columns, same order, uniform direction) so that no in-memory sort is needed.
+// satisfy a multi-column ORDER BY clause when the ORDER BY columns are the index's
+// leading columns (same order, uniform direction) so that no in-memory sort is needed.
 func (s *TestSuite) TestOrderByCompositeIndex() {
 	_, err := s.db.Exec(`create table "events" (
 		id    int8 primary key autoincrement,
@@ -304,3 +304,193 @@ func (s *TestSuite) TestOrderByCompositeIndex() {
 		s.Equal("Charlie", events[2].Name)
 	})
 }
+
+func (s *TestSuite) TestOrderByIndexedColumn() {
+	_, err := s.db.Exec(`create table "accounts" (
+		id     int8 primary key autoincrement,
+		handle varchar(255) not null unique,
+		email  varchar(255) unique
+	);`)
+	s.Require().NoError(err)
+
+	s.execQuery(`insert into accounts(handle, email) values
+('carol', 'carol@example.com'),
+('alice', NULL),
+('bob', 'bob@example.com');`, 3)
+
+	collectIDs := func(query string) []int64 {
+		rows, err := s.db.QueryContext(context.Background(), query)
+		s.Require().NoError(err)
+		defer rows.Close()
+
+		var ids []int64
+		for rows.Next() {
+			var id int64
+			s.Require().NoError(rows.Scan(&id))
+			ids = append(ids, id)
+		}
+		s.Require().NoError(rows.Err())
+		return ids
+	}
+
+	s.Run("NOT NULL column walks the index in order", func() {
+		explain := s.collectExplain(`EXPLAIN SELECT * FROM accounts ORDER BY handle DESC;`)
+		s.Require().Len(explain, 1)
+		s.Equal("index_all", explain[0].Operation)
+		s.Contains(explain[0].Detail, "columns=handle")
+
+		s.Equal([]int64{2, 3, 1}, collectIDs(`SELECT id FROM accounts ORDER BY handle;`))
+		s.Equal([]int64{1, 3, 2}, collectIDs(`SELECT id FROM accounts ORDER BY handle DESC;`))
+	})
+
+	s.Run("nullable column keeps NULL rows by sorting in memory", func() {
+		explain := s.collectExplain(`EXPLAIN SELECT * FROM accounts ORDER BY email;`)
+		s.Require().Len(explain, 2)
+		s.Equal("sort", explain[1].Operation)
+
+		s.Equal([]int64{3, 1, 2}, collectIDs(`SELECT id FROM accounts ORDER BY email;`))
+		s.Equal([]int64{2, 1, 3}, collectIDs(`SELECT id FROM accounts ORDER BY email DESC;`))
+	})
+}
+
+func (s *TestSuite) TestOrderByIndexPrefix() {
+	_, err := s.db.Exec(`create table "members" (
+		id       int8 primary key,
+		verified boolean not null,
+		age      int4 not null,
+		city     varchar(50),
+		name     varchar(50) not null
+	);`)
+	s.Require().NoError(err)
+	_, err = s.db.Exec(`create index "idx_verified_age_name" on "members" (verified, age, name);`)
+	s.Require().NoError(err)
+	_, err = s.db.Exec(`create index "idx_age_name_city" on "members" (age, name, city);`)
+	s.Require().NoError(err)
+
+	_, err = s.db.Exec(`insert into members (id, verified, age, city, name) values
+		(1, false, 30, 'Oslo', 'Ann'),
+		(2, true, 25, null, 'Ben'),
+		(3, true, 40, 'Rome', 'Cid'),
+		(4, false, 25, 'Oslo', 'Dan'),
+		(5, true, 25, 'Rome', 'Eva');`)
+	s.Require().NoError(err)
+
+	collectIDs := func(query string) []int64 {
+		rows, err := s.db.Query(query)
+		s.Require().NoError(err)
+		defer rows.Close()
+		var ids []int64
+		for rows.Next() {
+			var id int64
+			s.Require().NoError(rows.Scan(&id))
+			ids = append(ids, id)
+		}
+		s.Require().NoError(rows.Err())
+		return ids
+	}
+
+	s.Run("mixed directions sort by each key in turn", func() {
+		s.Equal([]int64{2, 5, 3, 4, 1}, collectIDs(`select id from members order by verified desc, age asc, id asc;`))
+		s.Equal([]int64{3, 5, 2, 1, 4}, collectIDs(`select id from members order by verified desc, age desc, id desc;`))
+	})
+
+	s.Run("leading columns of a composite index walk the index", func() {
+		explain := s.collectExplain(`EXPLAIN SELECT id FROM members ORDER BY verified, age;`)
+		s.Require().Len(explain, 1)
+		s.Equal("index_all", explain[0].Operation)
+		s.Contains(explain[0].Detail, "idx_verified_age_name")
+
+		s.Equal([]int64{4, 1, 2, 5, 3}, collectIDs(`select id from members order by verified, age;`))
+		s.Equal([]int64{3, 5, 2, 1, 4}, collectIDs(`select id from members order by verified desc, age desc;`))
+	})
+
+	s.Run("nullable trailing index column sorts in memory", func() {
+		explain := s.collectExplain(`EXPLAIN SELECT id FROM members ORDER BY age, name;`)
+		s.Require().Len(explain, 2)
+		s.Equal("sort", explain[1].Operation)
+
+		s.Equal([]int64{2, 4, 5, 1, 3}, collectIDs(`select id from members order by age, name;`))
+	})
+
+	s.Run("duplicate ORDER BY column is rejected", func() {
+		_, err := s.db.Query(`select id from members order by age, name, age desc;`)
+		s.Require().Error(err)
+		s.ErrorContains(err, `duplicate field "age" in ORDER BY clause`)
+	})
+}
+
+func (s *TestSuite) TestOrderByNulls() {
+	_, err := s.db.Exec(`create table "players" (
+		id    int8 primary key,
+		team  varchar(20),
+		score int4
+	);`)
+	s.Require().NoError(err)
+
+	s.execQuery(`insert into players(id, team, score) values
+(1, 'red', 30),
+(2, 'blue', NULL),
+(3, 'red', 10),
+(4, NULL, NULL),
+(5, 'blue', 20);`, 5)
+
+	collectIDs := func(query string) []int64 {
+		rows, err := s.db.QueryContext(context.Background(), query)
+		s.Require().NoError(err)
+		defer rows.Close()
+
+		var ids []int64
+		for rows.Next() {
+			var id int64
+			s.Require().NoError(rows.Scan(&id))
+			ids = append(ids, id)
+		}
+		s.Require().NoError(rows.Err())
+		return ids
+	}
+
+	s.Run("NULLs sort last for ASC and first for DESC by default", func() {
+		s.Equal([]int64{3, 5, 1, 2, 4}, collectIDs(`SELECT id FROM players ORDER BY score, id;`))
+		s.Equal([]int64{2, 4, 1, 5, 3}, collectIDs(`SELECT id FROM players ORDER BY score DESC, id;`))
+	})
+
+	s.Run("NULLS FIRST and NULLS LAST override the default", func() {
+		s.Equal([]int64{2, 4, 3, 5, 1}, collectIDs(`SELECT id FROM players ORDER BY score ASC NULLS FIRST, id;`))
+		s.Equal([]int64{1, 5, 3, 2, 4}, collectIDs(`SELECT id FROM players ORDER BY score DESC NULLS LAST, id;`))
+	})
+
+	s.Run("each ORDER BY column has its own NULL placement", func() {
+		s.Equal([]int64{4, 5, 2, 1, 3}, collectIDs(`SELECT id FROM players ORDER BY team NULLS FIRST, score DESC NULLS LAST;`))
+	})
+
+	s.Run("top-N with LIMIT", func() {
+		s.Equal([]int64{2, 4}, collectIDs(`SELECT id FROM players ORDER BY score NULLS FIRST, id LIMIT 2;`))
+		s.Equal([]int64{1, 5}, collectIDs(`SELECT id FROM players ORDER BY score DESC NULLS LAST, id LIMIT 2;`))
+	})
+
+	s.Run("window function ORDER BY", func() {
+		rows, err := s.db.Query(`SELECT id, ROW_NUMBER() OVER (ORDER BY score DESC NULLS LAST) AS rn FROM players ORDER BY id;`)
+		s.Require().NoError(err)
+		defer rows.Close()
+
+		rankByID := map[int64]int64{}
+		for rows.Next() {
+			var id, rn int64
+			s.Require().NoError(rows.Scan(&id, &rn))
+			rankByID[id] = rn
+		}
+		s.Require().NoError(rows.Err())
+		s.Equal(int64(1), rankByID[1])
+		s.Equal(int64(2), rankByID[5])
+		s.Equal(int64(3), rankByID[3])
+		s.Greater(rankByID[2], int64(3))
+		s.Greater(rankByID[4], int64(3))
+	})
+
+	s.Run("EXPLAIN shows explicit NULL placement", func() {
+		explain := s.collectExplain(`EXPLAIN SELECT * FROM players ORDER BY score DESC NULLS LAST;`)
+		s.Require().Len(explain, 2)
+		s.Equal("sort", explain[1].Operation)
+		s.Contains(explain[1].Detail, "order_by=score DESC NULLS LAST")
+	})
+}
@@ -189,6 +189,77 @@ func (s *TestSuite) TestLeftJoin_WithIndex() {
 	}
 }
 
+func (s *TestSuite) TestLeftJoin_WhereOnRightTable() {
+	_, err := s.db.Exec(`create table "users" (
+		id int8 primary key,
+		name varchar(50)
+	);`)
+	s.Require().NoError(err)
+
+	_, err = s.db.Exec(`create table "orders" (
+		id int8 primary key,
+		user_id int8,
+		amount int8
+	);`)
+	s.Require().NoError(err)
+
+	_, err = s.db.Exec(`insert into users("id", "name") values(1, 'Alice'), (2, 'Bob'), (3, 'Charlie');`)
+	s.Require().NoError(err)
+	_, err = s.db.Exec(`insert into orders("id", "user_id", "amount") values(1, 1, 100), (2, 1, 200), (3, 3, 150);`)
+	s.Require().NoError(err)
+
+	names := func(query string) []string {
+		rows, err := s.db.Query(query)
+		s.Require().NoError(err)
+		defer rows.Close()
+
+		var got []string
+		for rows.Next() {
+			var name string
+			s.Require().NoError(rows.Scan(&name))
+			got = append(got, name)
+		}
+		s.Require().NoError(rows.Err())
+		return got
+	}
+
+	// Run every case against the hash join and then the index lookup path.
+	for _, withIndex := range []bool{false, true} {
+		if withIndex {
+			_, err = s.db.Exec(`create index "idx_user_id" on "orders" (user_id);`)
+			s.Require().NoError(err)
+		}
+
+		s.Run("IS NULL on the right table finds users without orders", func() {
+			s.Equal([]string{"Bob"}, names(`
+				select u.name
+				from users as u
+				left join orders as o on u.id = o.user_id
+				where o.id is null;
+			`))
+		})
+
+		s.Run("condition on the right table drops NULL-extended rows", func() {
+			s.Equal([]string{"Alice"}, names(`
+				select u.name
+				from users as u
+				left outer join orders as o on u.id = o.user_id
+				where o.amount > 150;
+			`))
+		})
+
+		s.Run("OR across both tables", func() {
+			s.Equal([]string{"Bob", "Charlie"}, names(`
+				select u.name
+				from users as u
+				left join orders as o on u.id = o.user_id
+				where u.name = 'Bob' or o.amount = 150
+				order by u.name;
+			`))
+		})
+	}
+}
+
 func (s *TestSuite) TestFullOuterJoin() {
 	_, err := s.db.Exec(`create table "users" (
 		id int8 primary key,
@@ -226,7 +297,7 @@ func (s *TestSuite) TestFullOuterJoin() {
 			select u.id, u.name, o.id, o.amount
 			from users as u
 			full outer join orders as o on u.id = o.user_id
-			order by u.id, o.id;
+			order by u.id nulls first, o.id;
 		`)
 		s.Require().NoError(err)
 		defer rows.Close()
@@ -248,8 +319,7 @@ func (s *TestSuite) TestFullOuterJoin() {
 		int64p := func(v int64) *int64 { return &v }
 		strp := func(v string) *string { return &v }
 
-		// NULL sorts before any non-NULL value (NULLS FIRST), so the right-only
-		// row (u.id = NULL) appears first when ordering by u.id.
+		// NULLS FIRST puts the right-only row (u.id = NULL) first.
 		want := []result{
 			// order 4: right-only (user_id=99 doesn't exist) — NULL u.id sorts first
 			{nil, nil, int64p(4), int64p(300)},
@@ -290,7 +360,7 @@ func (s *TestSuite) TestFullOuterJoin() {
 			select u.id, o.id
 			from users as u
 			full join orders as o on u.id = o.user_id
-			order by u.id, o.id;
+			order by u.id nulls first, o.id;
 		`)
 		s.Require().NoError(err)
 		defer rows.Close()
@@ -308,7 +378,7 @@ func (s *TestSuite) TestFullOuterJoin() {
 		s.Require().NoError(rows.Err())
 
 		int64p := func(v int64) *int64 { return &v }
-		// NULL u.id sorts first (NULLS FIRST behaviour).
+		// NULL u.id sorts first (NULLS FIRST).
 		want := []result{
 			{nil, int64p(4)},
 			{int64p(1), int64p(1)},
@@ -0,0 +1,98 @@
+package e2etests
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+func (s *TestSuite) TestPageHints() {
+	_, err := s.db.Exec(`create table "readings" (
+		id          int8 primary key autoincrement,
+		recorded_at timestamp not null minmax,
+		value       double,
+		note        varchar(255)
+	);`)
+	s.Require().NoError(err)
+
+	start := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	const numRows = 600
+	tx, err := s.db.Begin()
+	s.Require().NoError(err)
+	stmt, err := tx.Prepare(`insert into "readings" (recorded_at, value, note) values (?, ?, ?);`)
+	s.Require().NoError(err)
+	for i := range numRows {
+		_, err := stmt.Exec(start.Add(time.Duration(i)*time.Minute), float64(i%10)+0.5, strings.Repeat("n", 100))
+		s.Require().NoError(err)
+	}
+	s.Require().NoError(stmt.Close())
+	s.Require().NoError(tx.Commit())
+
+	count := func(query string, args ...any) int64 {
+		var n int64
+		err := s.db.QueryRow(query, args...).Scan(&n)
+		s.Require().NoError(err)
+		return n
+	}
+	ts := func(minute int) string {
+		return start.Add(time.Duration(minute) * time.Minute).Format("2006-01-02 15:04:05")
+	}
+
+	s.Run("range predicates return the same rows as without hints", func() {
+		s.Equal(int64(61), count(fmt.Sprintf(`select count(*) from "readings" where recorded_at between '%s' and '%s';`, ts(120), ts(180))))
+		s.Equal(int64(10), count(fmt.Sprintf(`select count(*) from "readings" where recorded_at >= '%s';`, ts(numRows-10))))
+		s.Equal(int64(5), count(fmt.Sprintf(`select count(*) from "readings" where recorded_at < '%s';`, ts(5))))
+		s.Equal(int64(1), count(fmt.Sprintf(`select count(*) from "readings" where recorded_at = '%s';`, ts(300))))
+		s.Equal(int64(0), count(fmt.Sprintf(`select count(*) from "readings" where recorded_at > '%s';`, ts(numRows))))
+		s.Equal(int64(7), count(fmt.Sprintf(`select count(*) from "readings" where recorded_at < '%s' or recorded_at > '%s';`, ts(2), ts(numRows-6))))
+		s.Equal(int64(6), count(fmt.Sprintf(`select count(*) from "readings" where recorded_at between '%s' and '%s' and value = 3.5;`, ts(0), ts(59))))
+	})
+
+	s.Run("aggregates and GROUP BY over a hinted range", func() {
+		var total float64
+		err := s.db.QueryRow(fmt.Sprintf(`select sum(value) from "readings" where recorded_at >= '%s' and recorded_at < '%s';`, ts(200), ts(220))).Scan(&total)
+		s.Require().NoError(err)
+		s.Equal(100.0, total)
+
+		rows, err := s.db.Query(fmt.Sprintf(`select value, count(*) from "readings" where recorded_at < '%s' group by value;`, ts(20)))
+		s.Require().NoError(err)
+		defer rows.Close()
+		groups := 0
+		for rows.Next() {
+			var value float64
+			var n int64
+			s.Require().NoError(rows.Scan(&value, &n))
+			s.Equal(int64(2), n)
+			groups += 1
+		}
+		s.Require().NoError(rows.Err())
+		s.Equal(10, groups)
+	})
+
+	s.Run("updates move rows between ranges", func() {
+		_, err := s.db.Exec(fmt.Sprintf(`update "readings" set recorded_at = '%s' where id = 1;`, ts(10_000)))
+		s.Require().NoError(err)
+		s.Equal(int64(1), count(fmt.Sprintf(`select count(*) from "readings" where recorded_at > '%s';`, ts(numRows))))
+		s.Equal(int64(4), count(fmt.Sprintf(`select count(*) from "readings" where recorded_at < '%s';`, ts(5))))
+	})
+
+	s.Run("uncommitted changes are visible inside the transaction", func() {
+		tx, err := s.db.Begin()
+		s.Require().NoError(err)
+		_, err = tx.Exec(fmt.Sprintf(`insert into "readings" (recorded_at) values ('%s');`, ts(-60)))
+		s.Require().NoError(err)
+		var n int64
+		err = tx.QueryRow(fmt.Sprintf(`select count(*) from "readings" where recorded_at < '%s';`, ts(0))).Scan(&n)
+		s.Require().NoError(err)
+		s.Equal(int64(1), n)
+		s.Require().NoError(tx.Rollback())
+
+		s.Equal(int64(0), count(fmt.Sprintf(`select count(*) from "readings" where recorded_at < '%s';`, ts(0))))
+	})
+
+	s.Run("MINMAX is rejected on unsupported columns", func() {
+		_, err := s.db.Exec(`create table "bad_hint" (id int8 primary key, note text minmax);`)
+		s.Require().Error(err)
+		s.Contains(err.Error(), `MINMAX column "note" must be a numeric or timestamp column`)
+	})
+}
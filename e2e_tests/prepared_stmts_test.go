@@ -1,7 +1,10 @@
 package e2etests
 
 import (
+	"database/sql"
 	"time"
+
+	"github.com/RichardKnop/minisql/internal/minisql"
 )
 
 func (s *TestSuite) TestPreparedStmts() {
@@ -156,3 +159,103 @@ func (s *TestSuite) TestPreparedStmts() {
 		s.Equal(time.Date(2024, 1, 2, 12, 0, 0, 0, time.UTC), user.Created)
 	})
 }
+
+func (s *TestSuite) TestPreparedStmts_NamedPlaceholders() {
+	_, err := s.db.Exec(createUsersTableSQL)
+	s.Require().NoError(err)
+
+	s.Run("Insert user", func() {
+		_, err := s.db.Exec(
+			`insert into users("email", "name", "created") values(:email, :name, :created)`,
+			sql.Named("name", "Danny Mason"),
+			sql.Named("email", "Danny_Mason2966@xqj6f.tech"),
+			sql.Named("created", "2024-01-01 12:00:00"),
+		)
+		s.Require().NoError(err)
+	})
+
+	s.Run("Select user with a repeated name", func() {
+		stmt, err := s.db.Prepare(`select * from users where id = :id and (name = :name or email = :name);`)
+		s.Require().NoError(err)
+
+		aUser := user{}
+		err = stmt.QueryRow(sql.Named("name", "Danny Mason"), sql.Named("id", int64(1))).
+			Scan(&aUser.ID, &aUser.Email, &aUser.Name, &aUser.Created)
+		s.Require().NoError(err)
+		s.Equal(int64(1), aUser.ID)
+		s.Equal("Danny Mason", aUser.Name.String)
+	})
+
+	s.Run("Update user", func() {
+		stmt, err := s.db.Prepare(`update users set name = :name where id = :id;`)
+		s.Require().NoError(err)
+
+		result, err := stmt.Exec(sql.Named("id", int64(1)), sql.Named("name", "New Name"))
+		s.Require().NoError(err)
+		rowsAffected, err := result.RowsAffected()
+		s.Require().NoError(err)
+		s.Equal(int64(1), rowsAffected)
+
+		user := s.collectUser(`select * from users where id = 1;`)
+		s.Equal("New Name", user.Name.String)
+	})
+
+	s.Run("Missing name", func() {
+		_, err := s.db.Exec(`update users set name = :name where id = :id;`, sql.Named("name", "x"))
+		s.Require().ErrorContains(err, "missing value for placeholder :id")
+	})
+
+	s.Run("Positional arguments for named placeholders", func() {
+		_, err := s.db.Exec(`update users set name = :name where id = :id;`, "x", int64(1))
+		s.Require().NoError(err)
+	})
+
+	s.Run("Mixing positional and named placeholders", func() {
+		_, err := s.db.Prepare(`select * from users where id = :id and name = ?;`)
+		s.Require().ErrorIs(err, minisql.ErrMixedPlaceholders)
+	})
+}
+
+func (s *TestSuite) TestPreparedStmts_QuotedStringsAreNotPlaceholders() {
+	_, err := s.db.Exec(`create table "t" (id int8 primary key, name varchar(32));`)
+	s.Require().NoError(err)
+
+	s.Run("Insert", func() {
+		_, err := s.db.Exec(`insert into t (id, name) values (1, '12:30'), (2, ':30'), (3, '?');`)
+		s.Require().NoError(err)
+		s.countRowsInTable("t", 3)
+	})
+
+	s.Run("Insert with a placeholder", func() {
+		_, err := s.db.Exec(`insert into t (id, name) values (?, ':x');`, int64(4))
+		s.Require().NoError(err)
+	})
+
+	s.Run("Update", func() {
+		result, err := s.db.Exec(`update t set name = ':y' where name = ':x';`)
+		s.Require().NoError(err)
+		rowsAffected, err := result.RowsAffected()
+		s.Require().NoError(err)
+		s.Equal(int64(1), rowsAffected)
+	})
+
+	s.Run("Where", func() {
+		var id int64
+		err := s.db.QueryRow(`select id from t where name = ':30';`).Scan(&id)
+		s.Require().NoError(err)
+		s.Equal(int64(2), id)
+
+		err = s.db.QueryRow(`select id from t where name = ':y' and id = ?;`, int64(4)).Scan(&id)
+		s.Require().NoError(err)
+		s.Equal(int64(4), id)
+
+		err = s.db.QueryRow(`select id from t where name = '?' and id = :id;`, sql.Named("id", int64(3))).Scan(&id)
+		s.Require().NoError(err)
+		s.Equal(int64(3), id)
+
+		var count int64
+		err = s.db.QueryRow(`select count(*) from t where name in ('12:30', ':30');`).Scan(&count)
+		s.Require().NoError(err)
+		s.Equal(int64(2), count)
+	})
+}
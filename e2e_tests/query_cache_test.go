@@ -0,0 +1,136 @@
+package e2etests
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/RichardKnop/minisql"
+)
+
+func TestQueryCache_ConnectionString(t *testing.T) {
+	t.Parallel()
+
+	f, err := os.CreateTemp("", "minisql-e2e-querycache-*.db")
+	require.NoError(t, err)
+	path := f.Name()
+	f.Close()
+	defer func() {
+		os.Remove(path)
+		os.Remove(path + "-wal")
+	}()
+
+	db, err := sql.Open("minisql", path+"?query_cache=16")
+	require.NoError(t, err)
+	db.SetMaxOpenConns(1)
+	db.SetMaxIdleConns(1)
+	defer db.Close()
+
+	ctx := context.Background()
+	_, err = db.Exec(`create table "notes" (id int8 primary key, body text)`)
+	require.NoError(t, err)
+	longBody := strings.Repeat("y", 5000) // stored on overflow pages
+	_, err = db.Exec(`insert into "notes" (id, body) values (1, 'short'), (2, ?)`, longBody)
+	require.NoError(t, err)
+
+	selectBodies := func(query string, args ...any) []string {
+		rows, err := db.Query(query, args...)
+		require.NoError(t, err)
+		defer rows.Close()
+		var bodies []string
+		for rows.Next() {
+			var body string
+			require.NoError(t, rows.Scan(&body))
+			bodies = append(bodies, body)
+		}
+		require.NoError(t, rows.Err())
+		return bodies
+	}
+	cacheCounters := func() (int64, int64) {
+		m, err := minisql.ReadMetrics(ctx, db)
+		require.NoError(t, err)
+		return m.QueryCacheHits, m.QueryCacheMisses
+	}
+
+	const query = `select body from "notes" where id >= ?`
+	assert.Equal(t, []string{"short", longBody}, selectBodies(query, 1))
+	// Whitespace differences do not matter.
+	assert.Equal(t, []string{"short", longBody}, selectBodies("select body  from \"notes\"\n where id >= ?", 1))
+	hits, misses := cacheCounters()
+	assert.Equal(t, int64(1), hits)
+	assert.Equal(t, int64(1), misses)
+
+	// An update to the table invalidates the entry and the query runs again.
+	_, err = db.Exec(`update "notes" set body = 'edited' where id = 1`)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"edited", longBody}, selectBodies(query, 1))
+	hits, misses = cacheCounters()
+	assert.Equal(t, int64(1), hits)
+	assert.Equal(t, int64(2), misses)
+
+	// A rolled-back write leaves the cached result valid.
+	tx, err := db.Begin()
+	require.NoError(t, err)
+	_, err = tx.Exec(`delete from "notes" where id = 2`)
+	require.NoError(t, err)
+	require.NoError(t, tx.Rollback())
+	assert.Equal(t, []string{"edited", longBody}, selectBodies(query, 1))
+	hits, _ = cacheCounters()
+	assert.Equal(t, int64(2), hits)
+
+	// Queries calling NOW() are never cached.
+	_, misses = cacheCounters()
+	rows, err := db.Query(`select now() from "notes" where id = 1`)
+	require.NoError(t, err)
+	for rows.Next() {
+	}
+	require.NoError(t, rows.Err())
+	require.NoError(t, rows.Close())
+	hits2, misses2 := cacheCounters()
+	assert.Equal(t, hits, hits2)
+	assert.Equal(t, misses, misses2)
+
+	// Nor are queries calling other volatile functions such as GEN_RANDOM_UUID().
+	uuids := map[string]struct{}{}
+	for range 3 {
+		uuids[selectBodies(`select gen_random_uuid() from "notes" where id = 1`)[0]] = struct{}{}
+	}
+	assert.Len(t, uuids, 3)
+	hits3, misses3 := cacheCounters()
+	assert.Equal(t, hits, hits3)
+	assert.Equal(t, misses, misses3)
+
+	// Registered functions are cached only when declared deterministic.
+	var calls int64
+	require.NoError(t, minisql.RegisterFunction(ctx, db, "counter", minisql.ScalarFunction{
+		Returns: minisql.Int8,
+		Call: func(args []any) (any, error) {
+			calls++
+			return calls, nil
+		},
+	}))
+	require.NoError(t, minisql.RegisterFunction(ctx, db, "shout", minisql.ScalarFunction{
+		Args:          []minisql.ColumnKind{minisql.Text},
+		Returns:       minisql.Text,
+		Deterministic: true,
+		Call: func(args []any) (any, error) {
+			return strings.ToUpper(args[0].(string)), nil
+		},
+	}))
+	for i := range 3 {
+		var n int64
+		require.NoError(t, db.QueryRow(`select counter() from "notes" where id = 1`).Scan(&n))
+		assert.Equal(t, int64(i+1), n)
+	}
+	for range 2 {
+		assert.Equal(t, []string{"EDITED"}, selectBodies(`select shout(body) from "notes" where id = 1`))
+	}
+	hits4, misses4 := cacheCounters()
+	assert.Equal(t, hits+1, hits4)
+	assert.Equal(t, misses+1, misses4)
+}
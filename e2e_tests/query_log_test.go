@@ -0,0 +1,125 @@
+package e2etests
+
+import (
+	"bufio"
+	"database/sql"
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/RichardKnop/minisql/internal/minisql"
+)
+
+func openQueryLogDB(t *testing.T, params string) (*sql.DB, string) {
+	t.Helper()
+
+	f, err := os.CreateTemp("", "minisql-e2e-querylog-*.db")
+	require.NoError(t, err)
+	path := f.Name()
+	f.Close()
+	logPath := path + ".log"
+	t.Cleanup(func() {
+		os.Remove(path)
+		os.Remove(path + "-wal")
+		os.Remove(logPath)
+	})
+
+	db, err := sql.Open("minisql", path+"?query_log="+logPath+params)
+	require.NoError(t, err)
+	db.SetMaxOpenConns(1)
+	db.SetMaxIdleConns(1)
+	t.Cleanup(func() { db.Close() })
+
+	return db, logPath
+}
+
+func readQueryLog(t *testing.T, logPath string) []minisql.QueryLogEntry {
+	t.Helper()
+
+	f, err := os.Open(logPath)
+	require.NoError(t, err)
+	defer f.Close()
+
+	var entries []minisql.QueryLogEntry
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var entry minisql.QueryLogEntry
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &entry))
+		entries = append(entries, entry)
+	}
+	require.NoError(t, scanner.Err())
+	return entries
+}
+
+func TestQueryLog_ConnectionString(t *testing.T) {
+	t.Parallel()
+
+	db, logPath := openQueryLogDB(t, "")
+
+	_, err := db.Exec(`create table "items" (id int8 primary key, name varchar(100))`)
+	require.NoError(t, err)
+	_, err = db.Exec(`insert into "items" (id, name) values (?, ?)`, int64(1), "alpha")
+	require.NoError(t, err)
+	_, err = db.Exec(`update "items" set name = 'beta' where id = 1`)
+	require.NoError(t, err)
+
+	// INSERT … SELECT executes its source SELECT internally; only the
+	// top-level statement must be logged.
+	_, err = db.Exec(`insert into "items" (id, name) select id + 10, name from "items"`)
+	require.NoError(t, err)
+
+	var name string
+	require.NoError(t, db.QueryRow(`select name from "items" where id = ?`, int64(1)).Scan(&name))
+	assert.Equal(t, "beta", name)
+
+	_, err = db.Exec(`insert into "items" (id, name) values (1, 'dup')`)
+	require.Error(t, err)
+
+	entries := readQueryLog(t, logPath)
+	require.Len(t, entries, 6)
+
+	kinds := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		kinds = append(kinds, entry.Kind)
+		assert.Equal(t, "conn-", entry.Client[:5])
+		assert.False(t, entry.Time.IsZero())
+		assert.NotEmpty(t, entry.Duration)
+	}
+	assert.Equal(t, []string{"CREATE TABLE", "INSERT", "UPDATE", "INSERT", "SELECT", "INSERT"}, kinds)
+
+	assert.Equal(t, `insert into "items" (id, name) values (?, ?)`, entries[1].SQL)
+	assert.Equal(t, []any{float64(1), "alpha"}, entries[1].Args)
+	assert.Equal(t, 1, entries[1].RowsAffected)
+	assert.Equal(t, 1, entries[2].RowsAffected)
+	assert.Equal(t, 1, entries[3].RowsAffected)
+	assert.Equal(t, []any{float64(1)}, entries[4].Args)
+	assert.Empty(t, entries[4].Error)
+	assert.NotEmpty(t, entries[5].Error)
+}
+
+func TestQueryLog_Redaction(t *testing.T) {
+	t.Parallel()
+
+	db, logPath := openQueryLogDB(t, "&query_log_redact=on")
+
+	_, err := db.Exec(`create table "secrets" (id int8 primary key, token varchar(100))`)
+	require.NoError(t, err)
+
+	stmt, err := db.Prepare(`insert into "secrets" (id, token) values (?, ?)`)
+	require.NoError(t, err)
+	defer stmt.Close()
+	_, err = stmt.Exec(int64(1), "s3cr3t")
+	require.NoError(t, err)
+
+	entries := readQueryLog(t, logPath)
+	require.Len(t, entries, 2)
+	assert.Equal(t, "INSERT", entries[1].Kind)
+	assert.Equal(t, []any{"<redacted>", "<redacted>"}, entries[1].Args)
+
+	raw, err := os.ReadFile(logPath)
+	require.NoError(t, err)
+	assert.NotContains(t, string(raw), "s3cr3t")
+}
@@ -0,0 +1,92 @@
+package e2etests
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/RichardKnop/minisql"
+	internal "github.com/RichardKnop/minisql/internal/minisql"
+)
+
+// TestRecover_CorruptedSchemaTable wipes the schema table on page 0 and
+// verifies Recover makes the surviving table data listable and readable.
+func TestRecover_CorruptedSchemaTable(t *testing.T) {
+	ctx := context.Background()
+	db, dbPath := openBackupDB(t)
+
+	_, err := db.ExecContext(ctx, `create table "items" (id int8 primary key autoincrement, name varchar(255) not null, price double)`)
+	require.NoError(t, err)
+
+	const rowCount = 300
+	tx, err := db.BeginTx(ctx, nil)
+	require.NoError(t, err)
+	for i := 0; i < rowCount; i++ {
+		var price any
+		if i%10 != 0 {
+			price = float64(i) + 0.5
+		}
+		_, err = tx.ExecContext(ctx, `insert into "items" (name, price) values (?, ?)`, fmt.Sprintf("item_%04d", i), price)
+		require.NoError(t, err)
+	}
+	require.NoError(t, tx.Commit())
+	require.NoError(t, db.Close())
+
+	// Overwrite the schema table root but keep the database header.
+	f, err := os.OpenFile(dbPath, os.O_RDWR, 0o600)
+	require.NoError(t, err)
+	garbage := make([]byte, internal.PageSize-internal.RootPageConfigSize)
+	for i := range garbage {
+		garbage[i] = 0xAB
+	}
+	_, err = f.WriteAt(garbage, internal.RootPageConfigSize)
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	broken, err := sql.Open("minisql", dbPath)
+	require.NoError(t, err)
+	_, err = broken.ExecContext(ctx, `select * from "items"`)
+	require.Error(t, err)
+	require.NoError(t, broken.Close())
+
+	report, err := minisql.Recover(ctx, dbPath)
+	require.NoError(t, err)
+	require.Len(t, report.Tables, 1)
+	recovered := report.Tables[0]
+	assert.Equal(t, rowCount, recovered.Rows)
+	assert.Empty(t, report.Skipped)
+	assert.False(t, report.HeaderReset)
+
+	db = openReadOnly(t, dbPath)
+
+	var ddl string
+	require.NoError(t, db.QueryRowContext(ctx, `select sql from minisql_schema where name = ?`, recovered.Name).Scan(&ddl))
+	assert.Equal(t, fmt.Sprintf(`create table "%s" (c1 int8, c2 text, c3 double);`, recovered.Name), ddl)
+
+	rows, err := db.QueryContext(ctx, fmt.Sprintf(`select c1, c2, c3 from "%s" order by c1`, recovered.Name))
+	require.NoError(t, err)
+	defer rows.Close()
+	var count int
+	for rows.Next() {
+		var (
+			id    int64
+			name  string
+			price sql.NullFloat64
+		)
+		require.NoError(t, rows.Scan(&id, &name, &price))
+		i := int(id) - 1
+		assert.Equal(t, fmt.Sprintf("item_%04d", i), name)
+		assert.Equal(t, i%10 != 0, price.Valid)
+		if price.Valid {
+			assert.Equal(t, float64(i)+0.5, price.Float64)
+		}
+		count++
+	}
+	require.NoError(t, rows.Err())
+	assert.Equal(t, rowCount, count)
+}
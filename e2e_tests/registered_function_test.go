@@ -0,0 +1,89 @@
+package e2etests
+
+import (
+	"context"
+
+	"github.com/RichardKnop/minisql"
+)
+
+func (s *TestSuite) TestRegisteredFunction() {
+	err := minisql.RegisterFunction(context.Background(), s.db, "double", minisql.ScalarFunction{
+		Args:    []minisql.ColumnKind{minisql.Int8},
+		Returns: minisql.Int8,
+		Call: func(args []any) (any, error) {
+			return args[0].(int64) * 2, nil
+		},
+	})
+	s.Require().NoError(err)
+
+	_, err = s.db.Exec(`create table "numbers" (
+		id int8 primary key,
+		n  int8
+	)`)
+	s.Require().NoError(err)
+	_, err = s.db.Exec(`insert into "numbers" (id, n) values (1, 1), (2, 2), (3, 3), (4, null)`)
+	s.Require().NoError(err)
+
+	s.Run("projection", func() {
+		rows, err := s.db.Query(`select id, double(n) as doubled from "numbers" order by id`)
+		s.Require().NoError(err)
+		defer rows.Close()
+
+		var got []*int64
+		for rows.Next() {
+			var (
+				id      int64
+				doubled *int64
+			)
+			s.Require().NoError(rows.Scan(&id, &doubled))
+			got = append(got, doubled)
+		}
+		s.Require().NoError(rows.Err())
+		s.Require().Len(got, 4)
+		s.Equal(int64(2), *got[0])
+		s.Equal(int64(4), *got[1])
+		s.Equal(int64(6), *got[2])
+		s.Nil(got[3], "NULL argument yields NULL")
+	})
+
+	s.Run("filter", func() {
+		rows, err := s.db.Query(`select id from "numbers" where double(n) > 3 order by id`)
+		s.Require().NoError(err)
+		defer rows.Close()
+
+		var ids []int64
+		for rows.Next() {
+			var id int64
+			s.Require().NoError(rows.Scan(&id))
+			ids = append(ids, id)
+		}
+		s.Require().NoError(rows.Err())
+		s.Equal([]int64{2, 3}, ids)
+	})
+
+	s.Run("unknown function fails at prepare time", func() {
+		_, err := s.db.Prepare(`select triple(n) from "numbers"`)
+		s.Require().ErrorIs(err, minisql.ErrUnknownFunction)
+	})
+
+	s.Run("wrong argument count fails at prepare time", func() {
+		_, err := s.db.Prepare(`select double(n, id) from "numbers"`)
+		s.Require().Error(err)
+		s.Contains(err.Error(), "function DOUBLE takes 1 arguments, got 2")
+	})
+
+	s.Run("wrong argument type fails", func() {
+		_, err := s.db.Query(`select double('x') from "numbers"`)
+		s.Require().Error(err)
+		s.Contains(err.Error(), "DOUBLE: argument 1 must be int8")
+	})
+
+	s.Run("registering twice fails", func() {
+		err := minisql.RegisterFunction(context.Background(), s.db, "DOUBLE", minisql.ScalarFunction{
+			Args:    []minisql.ColumnKind{minisql.Int8},
+			Returns: minisql.Int8,
+			Call:    func(args []any) (any, error) { return nil, nil },
+		})
+		s.Require().ErrorIs(err, minisql.ErrFunctionExists)
+	})
+}
@@ -289,6 +289,25 @@ func (s *TestSuite) TestReturning() {
 		s.Require().NoError(rows.Err())
 	})
 
+	s.Run("RETURNING_unknown_column_modifies_nothing", func() {
+		var before int64
+		s.Require().NoError(s.db.QueryRow(`select count(*) from users`).Scan(&before))
+
+		_, err := s.db.Exec(`update users set score = ? returning nope`, int64(-1))
+		s.Require().Error(err)
+		s.Contains(err.Error(), `unknown field "nope" in RETURNING`)
+
+		_, err = s.db.Exec(`delete from users returning id, nope`)
+		s.Require().Error(err)
+		s.Contains(err.Error(), `unknown field "nope" in RETURNING`)
+
+		var after, negative int64
+		s.Require().NoError(s.db.QueryRow(`select count(*) from users`).Scan(&after))
+		s.Equal(before, after)
+		s.Require().NoError(s.db.QueryRow(`select count(*) from users where score = -1`).Scan(&negative))
+		s.Zero(negative)
+	})
+
 	s.Run("INSERT_ON_CONFLICT_DO_UPDATE_RETURNING_non_conflict", func() {
 		// Fresh insert with DO UPDATE — row is inserted normally, returned as if INSERT.
 		rows, err := s.db.Query(
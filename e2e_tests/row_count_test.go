@@ -0,0 +1,52 @@
+package e2etests
+
+import (
+	"context"
+
+	"github.com/RichardKnop/minisql"
+	minisqlErrors "github.com/RichardKnop/minisql/pkg/errors"
+)
+
+// TestRowCount verifies that RowCount tracks inserts, deletes and TRUNCATE,
+// ignores rolled back changes and is recomputed when the database reopens.
+func (s *TestSuite) TestRowCount() {
+	ctx := context.Background()
+
+	_, err := s.db.Exec(`create table "counted" (id int8 primary key autoincrement, name varchar(50))`)
+	s.Require().NoError(err)
+
+	rowCount := func() int64 {
+		n, err := minisql.RowCount(ctx, s.db, "counted")
+		s.Require().NoError(err)
+		return n
+	}
+
+	s.Equal(int64(0), rowCount())
+
+	for range 25 {
+		_, err = s.db.Exec(`insert into "counted" (name) values ('x')`)
+		s.Require().NoError(err)
+	}
+	s.Equal(int64(25), rowCount())
+
+	_, err = s.db.Exec(`delete from "counted" where id <= 5`)
+	s.Require().NoError(err)
+	s.Equal(int64(20), rowCount())
+
+	tx, err := s.db.Begin()
+	s.Require().NoError(err)
+	_, err = tx.Exec(`delete from "counted" where id <= 10`)
+	s.Require().NoError(err)
+	s.Require().NoError(tx.Rollback())
+	s.Equal(int64(20), rowCount())
+
+	s.db = s.reopenDB()
+	s.Equal(int64(20), rowCount())
+
+	_, err = s.db.Exec(`TRUNCATE TABLE "counted"`)
+	s.Require().NoError(err)
+	s.Equal(int64(0), rowCount())
+
+	_, err = minisql.RowCount(ctx, s.db, "no_such_table")
+	s.Require().ErrorAs(err, &minisqlErrors.ErrNoSuchTable{})
+}
@@ -0,0 +1,78 @@
+package e2etests
+
+func (s *TestSuite) TestSelect_RowID() {
+	_, err := s.db.Exec(`create table "rowid_users" (
+		id    int8 primary key autoincrement,
+		email varchar(255) not null
+	)`)
+	s.Require().NoError(err)
+	for _, email := range []string{"alice@example.com", "bob@example.com", "carol@example.com"} {
+		_, err := s.db.Exec(`insert into "rowid_users" (email) values (?)`, email)
+		s.Require().NoError(err)
+	}
+
+	type rowIDEmail struct {
+		RowID int64
+		Email string
+	}
+	query := func(query string, args ...any) []rowIDEmail {
+		rows, err := s.db.Query(query, args...)
+		s.Require().NoError(err)
+		defer rows.Close()
+		var out []rowIDEmail
+		for rows.Next() {
+			var r rowIDEmail
+			s.Require().NoError(rows.Scan(&r.RowID, &r.Email))
+			out = append(out, r)
+		}
+		s.Require().NoError(rows.Err())
+		return out
+	}
+
+	all := query(`select rowid, email from "rowid_users"`)
+	s.Require().Len(all, 3)
+	for i, r := range all {
+		if i > 0 {
+			s.Greater(r.RowID, all[i-1].RowID, "row IDs ascend in insertion order")
+		}
+	}
+	s.Equal("alice@example.com", all[0].Email)
+
+	s.Run("filter by rowid seeks to the row", func() {
+		s.Equal([]rowIDEmail{all[1]}, query(`select rowid, email from "rowid_users" where rowid = ?`, all[1].RowID))
+		s.Empty(query(`select rowid, email from "rowid_users" where rowid = 999`))
+		s.Empty(query(`select rowid, email from "rowid_users" where rowid = ? and email = 'alice@example.com'`, all[1].RowID))
+	})
+
+	s.Run("range and ordering for pagination", func() {
+		s.Equal([]rowIDEmail{all[2], all[1]},
+			query(`select rowid, email from "rowid_users" where rowid > ? order by rowid desc limit 2`, all[0].RowID))
+		s.Equal([]rowIDEmail{all[1], all[2]},
+			query(`select rowid, email from "rowid_users" where rowid >= ? order by rowid limit 2`, all[1].RowID))
+		s.Equal([]rowIDEmail{all[0]},
+			query(`select rowid, email from "rowid_users" where rowid < ?`, all[1].RowID))
+		s.Empty(query(`select rowid, email from "rowid_users" where rowid > ? and rowid < ?`, all[1].RowID, all[2].RowID))
+
+		var count int64
+		s.Require().NoError(s.db.QueryRow(`select count(*) from "rowid_users" where rowid > ?`, all[0].RowID).Scan(&count))
+		s.Equal(int64(2), count)
+	})
+
+	s.Run("select star omits the pseudo-column", func() {
+		rows, err := s.db.Query(`select * from "rowid_users" where rowid = ?`, all[0].RowID)
+		s.Require().NoError(err)
+		defer rows.Close()
+		columns, err := rows.Columns()
+		s.Require().NoError(err)
+		s.Equal([]string{"id", "email"}, columns)
+	})
+
+	s.Run("a real rowid column shadows the pseudo-column", func() {
+		_, err := s.db.Exec(`create table "rowid_shadow" (rowid int8, email varchar(255))`)
+		s.Require().NoError(err)
+		_, err = s.db.Exec(`insert into "rowid_shadow" (rowid, email) values (42, 'dave@example.com')`)
+		s.Require().NoError(err)
+		s.Equal([]rowIDEmail{{RowID: 42, Email: "dave@example.com"}},
+			query(`select rowid, email from "rowid_shadow" where rowid = 42`))
+	})
+}
@@ -0,0 +1,88 @@
+package e2etests
+
+import (
+	"database/sql"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/RichardKnop/minisql/internal/minisql"
+)
+
+func (s *TestSuite) TestSafeMode_Pragma() {
+	_, err := s.db.Exec(`create table "safe_users" (id int8 primary key, name varchar(100))`)
+	s.Require().NoError(err)
+	_, err = s.db.Exec(`insert into "safe_users" (id, name) values (1, 'a'), (2, 'b'), (3, 'c')`)
+	s.Require().NoError(err)
+
+	s.Run("normal mode allows unconditional update", func() {
+		s.execQuery(`update "safe_users" set name = 'x'`, 3)
+	})
+
+	s.Run("safe mode rejects unconditional update and delete", func() {
+		_, err := s.db.Exec(`PRAGMA safe_mode = on`)
+		s.Require().NoError(err)
+
+		_, err = s.db.Exec(`update "safe_users" set name = 'y'`)
+		s.Require().ErrorIs(err, minisql.ErrSafeModeNoWhere)
+
+		_, err = s.db.Exec(`delete from "safe_users"`)
+		s.Require().ErrorIs(err, minisql.ErrSafeModeNoWhere)
+
+		s.countRowsInTable("safe_users", 3)
+	})
+
+	s.Run("safe mode allows conditional delete", func() {
+		s.execQuery(`delete from "safe_users" where id = 1`, 1)
+	})
+
+	s.Run("safe mode accepts explicit always-true predicate", func() {
+		s.execQuery(`update "safe_users" set name = 'z' where 1 = 1`, 2)
+	})
+
+	s.Run("pragma switches safe mode off again", func() {
+		_, err := s.db.Exec(`PRAGMA safe_mode = off`)
+		s.Require().NoError(err)
+
+		s.execQuery(`delete from "safe_users"`, 2)
+	})
+}
+
+func TestSafeMode_ConnectionString(t *testing.T) {
+	t.Parallel()
+
+	f, err := os.CreateTemp("", "minisql-e2e-safe-*.db")
+	require.NoError(t, err)
+	path := f.Name()
+	f.Close()
+	defer func() {
+		os.Remove(path)
+		os.Remove(path + "-wal")
+	}()
+
+	db, err := sql.Open("minisql", path+"?safe_mode=on")
+	require.NoError(t, err)
+	db.SetMaxOpenConns(1)
+	db.SetMaxIdleConns(1)
+	defer db.Close()
+
+	_, err = db.Exec(`create table "items" (id int8 primary key, name varchar(100))`)
+	require.NoError(t, err)
+	_, err = db.Exec(`insert into "items" (id, name) values (1, 'a'), (2, 'b')`)
+	require.NoError(t, err)
+
+	_, err = db.Exec(`delete from "items"`)
+	assert.ErrorIs(t, err, minisql.ErrSafeModeNoWhere)
+
+	_, err = db.Exec(`delete from "items" where id = 2`)
+	require.NoError(t, err)
+
+	// TRUNCATE TABLE is an explicit request to remove every row.
+	result, err := db.Exec(`truncate table "items"`)
+	require.NoError(t, err)
+	n, err := result.RowsAffected()
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), n)
+}
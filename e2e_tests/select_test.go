@@ -99,6 +99,21 @@ values('Johnathan Walker', 'Johnathan_Walker250@ptr6k.page', '2024-01-02 15:30:2
 		s.Equal(int64(104), users[1].ID)
 	})
 
+	s.Run("Fetch first rows only", func() {
+		for _, pair := range [][2]string{
+			{`select * from users fetch first 1 row only;`, `select * from users limit 1;`},
+			{`select * from users offset 9 rows;`, `select * from users offset 9;`},
+			{`select * from users order by id offset 4 rows fetch next 2 rows only;`, `select * from users order by id limit 2 offset 4;`},
+		} {
+			s.Equal(s.collectUsers(pair[1]), s.collectUsers(pair[0]), pair[0])
+		}
+
+		users := s.collectUsers(`select * from users order by id offset 4 rows fetch first 2 rows only;`)
+		s.Require().Len(users, 2)
+		s.Equal(int64(103), users[0].ID)
+		s.Equal(int64(104), users[1].ID)
+	})
+
 	s.Run("Where conditions on primary key", func() {
 		users := s.collectUsers(`select * from users where id = 107;`)
 		s.Require().Len(users, 1)
@@ -167,6 +182,16 @@ values('Johnathan Walker', 'Johnathan_Walker250@ptr6k.page', '2024-01-02 15:30:2
 		}
 	})
 
+	s.Run("Order by primary key descending with limit", func() {
+		users := s.collectUsers(`select * from users order by id desc limit 3;`)
+		s.Require().Len(users, 3)
+
+		expectedIDs := []int64{108, 107, 106}
+		for i := range expectedIDs {
+			s.Equal(expectedIDs[i], users[i].ID)
+		}
+	})
+
 	var (
 		twentiethCentury = time.Date(1999, 7, 19, 22, 11, 56, 112456*1000, time.UTC).Format("2006-01-02 15:04:05")
 		aMinuteAgo       = time.Now().Add(-1 * time.Minute).UTC().Format("2006-01-02 15:04:05")
@@ -279,3 +279,50 @@ func (s *TestSuite) TestSemiJoinIneligible() {
 		s.Require().Len(names, 1)
 	})
 }
+
+// TestSemiJoinCombinedConditions checks IN/NOT IN subqueries combined with
+// other WHERE conditions: AND groups are still rewritten to semi-joins, while
+// an IN condition under OR is evaluated against the materialised set.
+func (s *TestSuite) TestSemiJoinCombinedConditions() {
+	_, err := s.db.Exec(`create table "sjc_users" (id int8 primary key, verified boolean not null)`)
+	s.Require().NoError(err)
+	_, err = s.db.Exec(`create table "sjc_orders" (id int8 primary key, user_id int8 not null)`)
+	s.Require().NoError(err)
+	_, err = s.db.Exec(`insert into "sjc_users" (id, verified) values (1, true), (2, false)`)
+	s.Require().NoError(err)
+	_, err = s.db.Exec(`insert into "sjc_orders" (id, user_id) values (1, 1), (2, 2), (3, 1)`)
+	s.Require().NoError(err)
+
+	orderIDs := func(query string) []int64 {
+		rows, err := s.db.Query(query)
+		s.Require().NoError(err)
+		defer rows.Close()
+		var ids []int64
+		for rows.Next() {
+			var id int64
+			s.Require().NoError(rows.Scan(&id))
+			ids = append(ids, id)
+		}
+		s.Require().NoError(rows.Err())
+		return ids
+	}
+
+	s.Run("in_and_outer_condition", func() {
+		s.ElementsMatch([]int64{3}, orderIDs(
+			`select id from "sjc_orders" where id > 1 and user_id in (select id from "sjc_users" where verified = true)`))
+	})
+
+	s.Run("not_in_with_inner_where", func() {
+		s.ElementsMatch([]int64{2}, orderIDs(
+			`select id from "sjc_orders" where user_id not in (select id from "sjc_users" where verified = true)`))
+		s.ElementsMatch([]int64{2}, orderIDs(
+			`select id from "sjc_orders" where id > 1 and user_id not in (select id from "sjc_users" where verified = true)`))
+	})
+
+	s.Run("in_under_or", func() {
+		s.ElementsMatch([]int64{1, 2, 3}, orderIDs(
+			`select id from "sjc_orders" where id = 2 or user_id in (select id from "sjc_users" where verified = true)`))
+		s.ElementsMatch([]int64{2}, orderIDs(
+			`select id from "sjc_orders" where id = 2 or user_id in (select id from "sjc_users" where id > 5)`))
+	})
+}
@@ -1,6 +1,7 @@
 package e2etests
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"os"
@@ -10,7 +11,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
-	_ "github.com/RichardKnop/minisql"
+	"github.com/RichardKnop/minisql"
 )
 
 // openSpillDB opens a temporary MiniSQL database with sort_mem_limit set to
@@ -358,3 +359,67 @@ func TestOrderBy_DiskSpill_Join_Offset(t *testing.T) {
 			"row %d: value %d should be <= %d", i, results[i-1].value, results[i].value)
 	}
 }
+
+// TestOrderBy_TextOverflow verifies ORDER BY and equality WHERE on a TEXT
+// column whose values live in overflow pages. The values share a prefix longer
+// than one overflow page, so comparisons are only correct when the full value
+// is assembled. The sort must also account for the assembled text when
+// deciding to spill, otherwise it would hold every value in memory.
+func TestOrderBy_TextOverflow(t *testing.T) {
+	db := openSpillDB(t, 32768)
+	ctx := context.Background()
+
+	_, err := db.Exec(`create table "docs" (
+		id   int8 primary key,
+		body text
+	)`)
+	require.NoError(t, err)
+
+	// TEXT columns cannot be indexed; ORDER BY and WHERE fall back to scans.
+	_, err = db.Exec(`create index "docs_body" on "docs" (body)`)
+	require.Error(t, err)
+
+	const rowCount = 40
+	prefix := strings.Repeat("x", 6000)
+	for i := 1; i <= rowCount; i++ {
+		// Suffixes are a permutation of 0..rowCount-1 unrelated to id order.
+		body := fmt.Sprintf("%s%03d", prefix, (i*7)%rowCount)
+		_, err = db.Exec(`insert into "docs" (id, body) values (?, ?)`, int64(i), body)
+		require.NoError(t, err)
+	}
+
+	before, err := minisql.ReadMetrics(ctx, db)
+	require.NoError(t, err)
+
+	suffixes := func(query string) []string {
+		rows, err := db.Query(query)
+		require.NoError(t, err)
+		defer rows.Close()
+		var got []string
+		for rows.Next() {
+			var body string
+			require.NoError(t, rows.Scan(&body))
+			require.True(t, strings.HasPrefix(body, prefix))
+			got = append(got, body[len(prefix):])
+		}
+		require.NoError(t, rows.Err())
+		return got
+	}
+
+	asc := suffixes(`select body from "docs" order by body asc`)
+	require.Len(t, asc, rowCount)
+	for i, suffix := range asc {
+		assert.Equal(t, fmt.Sprintf("%03d", i), suffix)
+	}
+
+	after, err := minisql.ReadMetrics(ctx, db)
+	require.NoError(t, err)
+	assert.Greater(t, after.SortSpillRuns, before.SortSpillRuns, "~240 KB of assembled text should exceed the 32 KB sort limit")
+
+	desc := suffixes(`select body from "docs" order by body desc limit 3`)
+	assert.Equal(t, []string{"039", "038", "037"}, desc)
+
+	var id int64
+	require.NoError(t, db.QueryRow(`select id from "docs" where body = ?`, prefix+"007").Scan(&id))
+	assert.Equal(t, int64(1), id)
+}
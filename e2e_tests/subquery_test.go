@@ -1,5 +1,16 @@
 package e2etests
 
+import (
+	"database/sql"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/RichardKnop/minisql"
+)
+
 func (s *TestSuite) TestSubquery() {
 	// Schema: users and orders tables.
 	_, err := s.db.Exec(`create table "users" (
@@ -165,3 +176,48 @@ func (s *TestSuite) TestSubquery() {
 		s.Equal(int64(2), affected)
 	})
 }
+
+func (s *TestSuite) TestSubquery_INTypeMismatch() {
+	_, err := s.db.Exec(`create table "users" (id int8 primary key, name varchar(100), verified boolean)`)
+	s.Require().NoError(err)
+	_, err = s.db.Exec(`create table "orders" (id int8 primary key, user_id int8, note text)`)
+	s.Require().NoError(err)
+
+	for _, query := range []string{
+		`select id from "orders" where user_id in (select name from "users")`,
+		`select id from "orders" where user_id not in (select verified from "users")`,
+		`select id from "orders" where note in (select distinct id from "users")`,
+		`select id from "orders" as o where o.user_id in (select u.name from "users" as u)`,
+		`delete from "orders" where user_id in (select name from "users")`,
+	} {
+		_, err := s.db.Exec(query)
+		s.Require().Error(err, query)
+		s.Contains(err.Error(), "is not comparable with", query)
+	}
+
+	// Columns of different widths or text types still compare.
+	_, err = s.db.Exec(`select id from "orders" where note in (select name from "users")`)
+	s.Require().NoError(err)
+}
+
+func TestSubquery_MaxSubqueryRows(t *testing.T) {
+	t.Parallel()
+
+	db, err := sql.Open("minisql", filepath.Join(t.TempDir(), "subquery.db")+"?max_subquery_rows=2")
+	require.NoError(t, err)
+	defer db.Close()
+
+	_, err = db.Exec(`create table "users" (id int8 primary key, name varchar(100))`)
+	require.NoError(t, err)
+	_, err = db.Exec(`insert into "users" (id, name) values (1, 'Alice'), (2, 'Bob'), (3, 'Alice')`)
+	require.NoError(t, err)
+
+	// Two distinct names fit; duplicates do not count against the limit.
+	var n int
+	require.NoError(t, db.QueryRow(
+		`select count(*) from "users" where name in (select distinct name from "users")`).Scan(&n))
+	assert.Equal(t, 3, n)
+
+	_, err = db.Exec(`select id from "users" where id in (select distinct id from "users")`)
+	assert.ErrorIs(t, err, minisql.ErrSubqueryTooLarge)
+}
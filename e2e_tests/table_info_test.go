@@ -0,0 +1,51 @@
+package e2etests
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/RichardKnop/minisql"
+)
+
+// TestTables verifies the per-table overview: column and row counts, pages
+// of the table and its indexes, and the index names.
+func (s *TestSuite) TestTables() {
+	ctx := context.Background()
+
+	_, err := s.db.Exec(`create table "users" (id int8 primary key autoincrement, email varchar(255) unique, name varchar(255))`)
+	s.Require().NoError(err)
+	_, err = s.db.Exec(`create index "idx_users_name" on "users" (name)`)
+	s.Require().NoError(err)
+	_, err = s.db.Exec(`create table "notes" (body text)`)
+	s.Require().NoError(err)
+
+	tables, err := minisql.Tables(ctx, s.db)
+	s.Require().NoError(err)
+	s.Require().Len(tables, 2)
+	s.Equal("notes", tables[0].Name)
+	s.Equal(1, tables[0].Columns)
+	s.Equal(int64(0), tables[0].Rows)
+	s.Equal(1, tables[0].Pages)
+	s.Empty(tables[0].Indexes)
+
+	users := tables[1]
+	s.Equal("users", users.Name)
+	s.Equal(3, users.Columns)
+	s.Equal(int64(0), users.Rows)
+	// Empty table and index roots: table, primary key, unique and secondary index.
+	s.Equal(4, users.Pages)
+	s.Contains(users.Indexes, "idx_users_name")
+	s.Len(users.Indexes, 3)
+
+	for i := range 500 {
+		_, err = s.db.Exec(`insert into "users" (email, name) values (?, ?)`,
+			fmt.Sprintf("user%d@example.com", i), strings.Repeat("x", 100))
+		s.Require().NoError(err)
+	}
+
+	tables, err = minisql.Tables(ctx, s.db)
+	s.Require().NoError(err)
+	s.Equal(int64(500), tables[1].Rows)
+	s.Greater(tables[1].Pages, users.Pages)
+}
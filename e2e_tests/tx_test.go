@@ -1,5 +1,12 @@
 package e2etests
 
+import (
+	"context"
+	"database/sql"
+
+	"github.com/RichardKnop/minisql"
+)
+
 // TestTransaction_DML_ReadsOwnWrites verifies that an explicit transaction can
 // read its own uncommitted DML changes (INSERT, UPDATE, DELETE) and that those
 // changes are either persisted after COMMIT or fully reversed after ROLLBACK.
@@ -204,3 +211,217 @@ func (s *TestSuite) TestTransaction() {
 		s.Equal(1, count)
 	})
 }
+
+// TestTransaction_SelectForUpdate verifies that SELECT … FOR UPDATE works both
+// inside an explicit transaction and in auto-commit mode. Concurrent writers
+// are covered by the engine tests: the suite uses a single connection.
+func (s *TestSuite) TestTransaction_SelectForUpdate() {
+	_, err := s.db.Exec(createUsersTableSQL)
+	s.Require().NoError(err)
+	_, err = s.db.Exec(`insert into "users" ("email", "name") values (?, ?);`, "alice@example.com", "Alice")
+	s.Require().NoError(err)
+
+	s.Run("lock, modify and commit", func() {
+		tx, err := s.db.Begin()
+		s.Require().NoError(err)
+
+		var name string
+		err = tx.QueryRow(`select "name" from "users" where "email" = ? for update;`, "alice@example.com").Scan(&name)
+		s.Require().NoError(err)
+		s.Equal("Alice", name)
+
+		_, err = tx.Exec(`update "users" set "name" = ? where "email" = ?;`, "Alicia", "alice@example.com")
+		s.Require().NoError(err)
+		s.Require().NoError(tx.Commit())
+
+		err = s.db.QueryRow(`select "name" from "users" where "email" = ?;`, "alice@example.com").Scan(&name)
+		s.Require().NoError(err)
+		s.Equal("Alicia", name)
+	})
+
+	s.Run("auto-commit FOR UPDATE returns rows", func() {
+		var count int
+		rows, err := s.db.Query(`select "email" from "users" for update;`)
+		s.Require().NoError(err)
+		for rows.Next() {
+			count += 1
+		}
+		s.Require().NoError(rows.Err())
+		s.Require().NoError(rows.Close())
+		s.Equal(1, count)
+	})
+
+	s.Run("FOR UPDATE with aggregate is rejected", func() {
+		_, err := s.db.Query(`select count(*) from "users" for update;`)
+		s.Require().Error(err)
+		s.Contains(err.Error(), "FOR UPDATE cannot be used")
+	})
+}
+
+// TestTransaction_ReadOnly verifies that a transaction begun with
+// sql.TxOptions{ReadOnly: true} can read but rejects every write.
+func (s *TestSuite) TestTransaction_ReadOnly() {
+	_, err := s.db.Exec(createUsersTableSQL)
+	s.Require().NoError(err)
+	_, err = s.db.Exec(`insert into "users" ("email", "name") values (?, ?);`, "alice@example.com", "Alice")
+	s.Require().NoError(err)
+
+	s.Run("reads work and writes are rejected", func() {
+		tx, err := s.db.BeginTx(context.Background(), &sql.TxOptions{ReadOnly: true})
+		s.Require().NoError(err)
+
+		var count int
+		s.Require().NoError(tx.QueryRow(`select count(*) from "users";`).Scan(&count))
+		s.Equal(1, count)
+
+		_, err = tx.Exec(`insert into "users" ("email", "name") values (?, ?);`, "bob@example.com", "Bob")
+		s.Require().ErrorIs(err, minisql.ErrReadOnlyTransaction)
+		_, err = tx.Exec(`update "users" set "name" = ?;`, "Bob")
+		s.Require().ErrorIs(err, minisql.ErrReadOnlyTransaction)
+		_, err = tx.Exec(`drop table "users";`)
+		s.Require().ErrorIs(err, minisql.ErrReadOnlyTransaction)
+
+		s.Require().NoError(tx.QueryRow(`select count(*) from "users";`).Scan(&count))
+		s.Equal(1, count)
+		s.Require().NoError(tx.Commit())
+	})
+
+	s.Run("writes work again after the read-only transaction", func() {
+		_, err := s.db.Exec(`insert into "users" ("email", "name") values (?, ?);`, "bob@example.com", "Bob")
+		s.Require().NoError(err)
+
+		var count int
+		s.Require().NoError(s.db.QueryRow(`select count(*) from "users";`).Scan(&count))
+		s.Equal(2, count)
+	})
+}
+
+// TestTransaction_RollbackDeleteRestoresOverflowIndexEntries verifies that a
+// rolled back DELETE leaves non-unique index entries intact, including row IDs
+// that spilled into index overflow pages.
+func (s *TestSuite) TestTransaction_RollbackDeleteRestoresOverflowIndexEntries() {
+	_, err := s.db.Exec(`create table "events" (id int8 primary key, kind int8 not null);`)
+	s.Require().NoError(err)
+	_, err = s.db.Exec(`create index idx_events_kind on "events" (kind);`)
+	s.Require().NoError(err)
+
+	// 17 distinct kinds with 17 or 18 rows each, enough to spill every key's
+	// row IDs into overflow pages.
+	for i := 1; i <= 300; i++ {
+		_, err = s.db.Exec(`insert into "events" (id, kind) values (?, ?);`, i, i%17)
+		s.Require().NoError(err)
+	}
+
+	countKind := func(kind int) (indexed, scanned int) {
+		// kind + 0 cannot use idx_events_kind, so it counts via a table scan.
+		err := s.db.QueryRow(`select count(*) from "events" where kind = ?;`, kind).Scan(&indexed)
+		s.Require().NoError(err)
+		err = s.db.QueryRow(`select count(*) from "events" where kind + 0 = ?;`, kind).Scan(&scanned)
+		s.Require().NoError(err)
+		return indexed, scanned
+	}
+
+	// Deleting a single row keeps the overflow chain for kind 5 alive, the
+	// removal only shifts row IDs within the overflow pages.
+	tx, err := s.db.Begin()
+	s.Require().NoError(err)
+	_, err = tx.Exec(`delete from "events" where id = 5;`)
+	s.Require().NoError(err)
+	s.Require().NoError(tx.Rollback())
+
+	indexed, scanned := countKind(5)
+	s.Equal(18, scanned)
+	s.Equal(18, indexed)
+
+	_, err = s.db.Exec(`delete from "events" where id in (5, 22, 39, 56);`)
+	s.Require().NoError(err)
+
+	indexed, scanned = countKind(5)
+	s.Equal(14, scanned)
+	s.Equal(14, indexed)
+}
+
+// TestTransaction_SQLStatements verifies that BEGIN, COMMIT and ROLLBACK sent
+// as separate statements keep a transaction open on the connection between
+// calls.
+func (s *TestSuite) TestTransaction_SQLStatements() {
+	_, err := s.db.Exec(createUsersTableSQL)
+	s.Require().NoError(err)
+
+	s.Run("COMMIT persists every statement since BEGIN", func() {
+		_, err := s.db.Exec(`begin transaction;`)
+		s.Require().NoError(err)
+		_, err = s.db.Exec(`insert into "users" ("email", "name") values (?, ?);`, "alice@example.com", "Alice")
+		s.Require().NoError(err)
+		_, err = s.db.Exec(`insert into "users" ("email", "name") values (?, ?);`, "bob@example.com", "Bob")
+		s.Require().NoError(err)
+		s.countRowsInTable(`"users"`, 2)
+
+		_, err = s.db.Exec(`commit;`)
+		s.Require().NoError(err)
+		s.countRowsInTable(`"users"`, 2)
+	})
+
+	s.Run("ROLLBACK discards every statement since BEGIN", func() {
+		_, err := s.db.Exec(`begin;`)
+		s.Require().NoError(err)
+		_, err = s.db.Exec(`insert into "users" ("email", "name") values (?, ?);`, "carol@example.com", "Carol")
+		s.Require().NoError(err)
+		_, err = s.db.Exec(`delete from "users" where "name" = ?;`, "Alice")
+		s.Require().NoError(err)
+		s.countRowsInTable(`"users"`, 2)
+
+		_, err = s.db.Exec(`rollback;`)
+		s.Require().NoError(err)
+
+		var names []string
+		rows, err := s.db.Query(`select "name" from "users" order by "name";`)
+		s.Require().NoError(err)
+		defer rows.Close()
+		for rows.Next() {
+			var name string
+			s.Require().NoError(rows.Scan(&name))
+			names = append(names, name)
+		}
+		s.Require().NoError(rows.Err())
+		s.Equal([]string{"Alice", "Bob"}, names)
+	})
+
+	s.Run("BEGIN, work and COMMIT in a single Exec", func() {
+		_, err := s.db.Exec(`begin; delete from "users" where "name" = 'Bob'; commit;`)
+		s.Require().NoError(err)
+		s.countRowsInTable(`"users"`, 1)
+	})
+
+	s.Run("misuse is rejected", func() {
+		_, err := s.db.Exec(`commit;`)
+		s.Require().ErrorContains(err, "no transaction in progress")
+		_, err = s.db.Exec(`rollback;`)
+		s.Require().ErrorContains(err, "no transaction in progress")
+
+		_, err = s.db.Exec(`begin;`)
+		s.Require().NoError(err)
+		_, err = s.db.Exec(`begin;`)
+		s.Require().ErrorContains(err, "transaction already in progress")
+		_, err = s.db.Begin()
+		s.Require().ErrorContains(err, "transaction already in progress")
+		_, err = s.db.Exec(`rollback;`)
+		s.Require().NoError(err)
+
+		tx, err := s.db.Begin()
+		s.Require().NoError(err)
+		_, err = tx.Exec(`commit;`)
+		s.Require().ErrorContains(err, "transaction was started with BeginTx")
+		s.Require().NoError(tx.Rollback())
+	})
+
+	s.Run("closing the connection rolls back an open transaction", func() {
+		_, err := s.db.Exec(`begin;`)
+		s.Require().NoError(err)
+		_, err = s.db.Exec(`insert into "users" ("email", "name") values (?, ?);`, "dave@example.com", "Dave")
+		s.Require().NoError(err)
+
+		s.db = s.reopenDB()
+		s.countRowsInTable(`"users"`, 1)
+	})
+}
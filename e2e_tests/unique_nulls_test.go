@@ -0,0 +1,80 @@
+package e2etests
+
+import (
+	"github.com/RichardKnop/minisql/internal/minisql"
+	minisqlErrors "github.com/RichardKnop/minisql/pkg/errors"
+)
+
+func (s *TestSuite) TestUniqueIndex_NullsDistinct() {
+	_, err := s.db.Exec(`create table "accounts" (id int8 primary key autoincrement, email varchar(100) unique, phone varchar(20));`)
+	s.Require().NoError(err)
+
+	s.Run("multiple NULLs are allowed by default", func() {
+		for range 3 {
+			_, err := s.db.Exec(`insert into "accounts" (phone) values ('555');`)
+			s.Require().NoError(err)
+		}
+		var count int
+		err := s.db.QueryRow(`select count(*) from "accounts" where email is null;`).Scan(&count)
+		s.Require().NoError(err)
+		s.Equal(3, count)
+	})
+
+	s.Run("non-NULL duplicates are still rejected", func() {
+		_, err := s.db.Exec(`insert into "accounts" (email) values ('a@example.com');`)
+		s.Require().NoError(err)
+		_, err = s.db.Exec(`insert into "accounts" (email) values ('a@example.com');`)
+		s.Require().ErrorIs(err, minisql.ErrDuplicateKey)
+	})
+}
+
+func (s *TestSuite) TestUniqueIndex_NullsNotDistinct() {
+	_, err := s.db.Exec(`create table "devices" (
+		id     int8 primary key autoincrement,
+		serial varchar(50) unique nulls not distinct,
+		vendor varchar(50),
+		model  varchar(50),
+		unique nulls not distinct (vendor, model)
+	);`)
+	s.Require().NoError(err)
+
+	s.Run("second NULL is rejected", func() {
+		_, err := s.db.Exec(`insert into "devices" (vendor, model) values ('acme', 'x1');`)
+		s.Require().NoError(err)
+
+		_, err = s.db.Exec(`insert into "devices" (vendor, model) values ('acme', 'x2');`)
+		s.Require().ErrorIs(err, minisql.ErrDuplicateKey)
+		var uvErr minisqlErrors.ErrUniqueViolation
+		s.Require().ErrorAs(err, &uvErr)
+		s.Equal([]string{"serial"}, uvErr.Columns)
+	})
+
+	s.Run("composite key compares NULLs as equal", func() {
+		_, err := s.db.Exec(`insert into "devices" (serial, vendor) values ('s-1', 'acme');`)
+		s.Require().NoError(err)
+
+		// Same vendor and NULL model as the previous row.
+		_, err = s.db.Exec(`insert into "devices" (serial, vendor) values ('s-2', 'acme');`)
+		s.Require().ErrorIs(err, minisql.ErrDuplicateKey)
+
+		// A different non-NULL part makes the key distinct.
+		_, err = s.db.Exec(`insert into "devices" (serial, vendor) values ('s-3', 'globex');`)
+		s.Require().NoError(err)
+	})
+
+	s.Run("update to NULL is rejected when a NULL exists", func() {
+		_, err := s.db.Exec(`update "devices" set serial = null where serial = 's-1';`)
+		s.Require().ErrorIs(err, minisql.ErrDuplicateKey)
+
+		// Updating the row that already holds the NULL is not a conflict with itself.
+		_, err = s.db.Exec(`update "devices" set model = 'x9' where serial is null;`)
+		s.Require().NoError(err)
+	})
+
+	s.Run("definition survives reopen", func() {
+		s.db = s.reopenDB()
+
+		_, err := s.db.Exec(`insert into "devices" (vendor, model) values ('initech', 'y1');`)
+		s.Require().ErrorIs(err, minisql.ErrDuplicateKey)
+	})
+}
@@ -263,3 +263,82 @@ func (s *TestSuite) TestUpdateFrom() {
 		s.Equal(int64(3000), got[3].salary)
 	})
 }
+
+func (s *TestSuite) TestUpdateFromValues() {
+	_, err := s.db.Exec(`create table "bulk_users" (
+		id    int8 primary key,
+		email varchar(255),
+		score int8
+	);`)
+	s.Require().NoError(err)
+	_, err = s.db.Exec(`insert into "bulk_users" (id, email, score) values
+		(1, 'one@old.com', 10), (2, 'two@old.com', 20), (3, 'three@old.com', 30), (4, 'four@old.com', 40)`)
+	s.Require().NoError(err)
+
+	type bulkUser struct {
+		id    int64
+		email string
+		score int64
+	}
+	collect := func() []bulkUser {
+		rows, err := s.db.Query(`select id, email, score from "bulk_users" order by id`)
+		s.Require().NoError(err)
+		defer rows.Close()
+		var got []bulkUser
+		for rows.Next() {
+			var u bulkUser
+			s.Require().NoError(rows.Scan(&u.id, &u.email, &u.score))
+			got = append(got, u)
+		}
+		s.Require().NoError(rows.Err())
+		return got
+	}
+
+	s.Run("primary key join updates three rows to distinct values", func() {
+		s.execQuery(`update bulk_users
+			set email = v.email
+			from (values (1, 'a@new.com'), (2, 'b@new.com'), (3, 'c@new.com')) as v(id, email)
+			where bulk_users.id = v.id`, 3)
+
+		s.Equal([]bulkUser{
+			{1, "a@new.com", 10},
+			{2, "b@new.com", 20},
+			{3, "c@new.com", 30},
+			{4, "four@old.com", 40},
+		}, collect())
+	})
+
+	s.Run("multiple columns and expressions over values", func() {
+		s.execQuery(`update bulk_users u
+			set email = v.email, score = u.score + v.bonus
+			from (values (2, 'b2@new.com', 5), (4, 'd@new.com', 7)) v(id, email, bonus)
+			where u.id = v.id`, 2)
+
+		s.Equal([]bulkUser{
+			{1, "a@new.com", 10},
+			{2, "b2@new.com", 25},
+			{3, "c@new.com", 30},
+			{4, "d@new.com", 47},
+		}, collect())
+	})
+
+	s.Run("rows without a matching key are left unchanged", func() {
+		s.execQuery(`update bulk_users
+			set score = v.score
+			from (values (99, 1), (1, 11)) as v(id, score)
+			where bulk_users.id = v.id`, 1)
+
+		got := collect()
+		s.Equal(int64(11), got[0].score)
+		s.Equal(int64(25), got[1].score)
+	})
+
+	s.Run("target row matching two values rows fails", func() {
+		_, err := s.db.Exec(`update bulk_users
+			set score = v.score
+			from (values (1, 100), (1, 200)) as v(id, score)
+			where bulk_users.id = v.id`)
+		s.Require().Error(err)
+		s.Contains(err.Error(), "matched more than one FROM row")
+	})
+}
@@ -173,3 +173,36 @@ func (s *TestSuite) TestVacuum_RepeatedVacuums() {
 
 	s.countRowsInTable("users", 5)
 }
+
+func (s *TestSuite) TestVacuum_ReportsReclaimedPages() {
+	_, err := s.db.Exec(createUsersTableSQL)
+	s.Require().NoError(err)
+
+	stmt, err := s.db.Prepare(`insert into "users" (email, name) values (?, ?)`)
+	s.Require().NoError(err)
+
+	users := gen.Users(500)
+	for _, u := range users {
+		_, err := stmt.Exec(u.Email, u.Name)
+		s.Require().NoError(err)
+	}
+	s.Require().NoError(stmt.Close())
+
+	_, err = s.db.Exec(`delete from "users"`)
+	s.Require().NoError(err)
+
+	result, err := s.db.ExecContext(context.Background(), `VACUUM`)
+	s.Require().NoError(err)
+	reclaimed, err := result.RowsAffected()
+	s.Require().NoError(err)
+	s.Positive(reclaimed)
+
+	// A compacted file has nothing left to reclaim.
+	result, err = s.db.ExecContext(context.Background(), `VACUUM`)
+	s.Require().NoError(err)
+	reclaimed, err = result.RowsAffected()
+	s.Require().NoError(err)
+	s.Zero(reclaimed)
+
+	s.countRowsInTable("users", 0)
+}
@@ -0,0 +1,121 @@
+package e2etests
+
+import (
+	"database/sql"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/RichardKnop/minisql"
+)
+
+// zigzagCodec stores INT8-like values as zig-zag varints.
+type zigzagCodec struct{}
+
+func (zigzagCodec) Size(value any) uint64 {
+	n, _ := value.(int64)
+	var buf [binary.MaxVarintLen64]byte
+	return uint64(binary.PutVarint(buf[:], n))
+}
+
+func (zigzagCodec) Append(dst []byte, value any) ([]byte, error) {
+	n, ok := value.(int64)
+	if !ok {
+		return nil, fmt.Errorf("expects an integer, got %T", value)
+	}
+	return binary.AppendVarint(dst, n), nil
+}
+
+func (zigzagCodec) Unmarshal(buf []byte) (any, error) {
+	n, size := binary.Varint(buf)
+	if size != len(buf) {
+		return nil, errors.New("invalid varint")
+	}
+	return n, nil
+}
+
+func TestValueCodec_CustomColumnType(t *testing.T) {
+	t.Parallel()
+
+	_, err := minisql.RegisterValueCodec("zigzag", zigzagCodec{})
+	require.NoError(t, err)
+
+	f, err := os.CreateTemp("", "minisql-e2e-codec-*.db")
+	require.NoError(t, err)
+	path := f.Name()
+	f.Close()
+	defer func() {
+		os.Remove(path)
+		os.Remove(path + "-wal")
+	}()
+	open := func() *sql.DB {
+		db, err := sql.Open("minisql", path)
+		require.NoError(t, err)
+		db.SetMaxOpenConns(1)
+		db.SetMaxIdleConns(1)
+		return db
+	}
+
+	db := open()
+	_, err = db.Exec(`create table "events" (id int8 primary key, delta zigzag not null)`)
+	require.NoError(t, err)
+	_, err = db.Exec(`insert into "events" (id, delta) values (1, 5), (2, -300)`)
+	require.NoError(t, err)
+	_, err = db.Exec(`insert into "events" (id, delta) values (?, ?)`, 3, int64(1)<<40)
+	require.NoError(t, err)
+	_, err = db.Exec(`alter table "events" add column previous zigzag`)
+	require.NoError(t, err)
+	_, err = db.Exec(`update "events" set previous = 4 where id = 1`)
+	require.NoError(t, err)
+
+	_, err = db.Exec(`insert into "events" (id, delta) values (4, 'text')`)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "expects an integer")
+	_, err = db.Exec(`create index "idx_events_delta" on "events" (delta)`)
+	require.Error(t, err)
+	// Custom values cannot be compared.
+	var id int64
+	err = db.QueryRow(`select id from "events" where delta = 5`).Scan(&id)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "zigzag")
+
+	type event struct {
+		ID       int64
+		Delta    int64
+		Previous sql.NullInt64
+	}
+	readEvents := func(db *sql.DB) []event {
+		rows, err := db.Query(`select id, delta, previous from "events" order by id`)
+		require.NoError(t, err)
+		defer rows.Close()
+		var events []event
+		for rows.Next() {
+			var e event
+			require.NoError(t, rows.Scan(&e.ID, &e.Delta, &e.Previous))
+			events = append(events, e)
+		}
+		require.NoError(t, rows.Err())
+		return events
+	}
+	expected := []event{
+		{1, 5, sql.NullInt64{Int64: 4, Valid: true}},
+		{2, -300, sql.NullInt64{}},
+		{3, 1 << 40, sql.NullInt64{}},
+	}
+	assert.Equal(t, expected, readEvents(db))
+
+	// The schema names the type, so the table reads back after a reopen.
+	require.NoError(t, db.Close())
+	db = open()
+	defer db.Close()
+	assert.Equal(t, expected, readEvents(db))
+
+	var ddl string
+	require.NoError(t, db.QueryRow(`select sql from minisql_schema where name = 'events'`).Scan(&ddl))
+	assert.Contains(t, ddl, "delta zigzag not null")
+}
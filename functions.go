@@ -0,0 +1,66 @@
+package minisql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/RichardKnop/minisql/internal/minisql"
+)
+
+// ScalarFunction is a SQL function implemented in Go; see RegisterFunction.
+type ScalarFunction = minisql.ScalarFunction
+
+var (
+	// ErrUnknownFunction is returned when a statement calls a function that is
+	// neither built in nor registered.
+	ErrUnknownFunction = minisql.ErrUnknownFunction
+	// ErrFunctionExists is returned when registering a function under the
+	// name of a built-in or already registered function.
+	ErrFunctionExists = minisql.ErrFunctionExists
+)
+
+// ColumnKind identifies a SQL data type.
+type ColumnKind = minisql.ColumnKind
+
+// Column kinds used to declare the signature of a ScalarFunction.
+const (
+	Boolean = minisql.Boolean
+	Int4    = minisql.Int4
+	Int8    = minisql.Int8
+	Real    = minisql.Real
+	Double  = minisql.Double
+	Varchar = minisql.Varchar
+	Text    = minisql.Text
+)
+
+// RegisterFunction makes fn callable from SQL as name(args...) on the
+// database behind db, which must have been opened with
+// sql.Open("minisql", dsn). Functions belong to the open database file, so
+// register them right after opening it and do not let the pool close the
+// connection (ConnMaxLifetime, ConnMaxIdleTime).
+//
+// Example:
+//
+//	err := minisql.RegisterFunction(ctx, db, "double", minisql.ScalarFunction{
+//		Args:    []minisql.ColumnKind{minisql.Int8},
+//		Returns: minisql.Int8,
+//		Call: func(args []any) (any, error) {
+//			return args[0].(int64) * 2, nil
+//		},
+//	})
+func RegisterFunction(ctx context.Context, db *sql.DB, name string, fn ScalarFunction) error {
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("minisql: RegisterFunction: acquire connection: %w", err)
+	}
+	defer conn.Close()
+
+	return conn.Raw(func(c any) error {
+		mc, ok := c.(*Conn)
+		if !ok {
+			return fmt.Errorf("minisql: RegisterFunction: unexpected connection type %T", c)
+		}
+		return mc.db.RegisterFunction(name, fn)
+	})
+}
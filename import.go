@@ -0,0 +1,130 @@
+package minisql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io"
+
+	"github.com/RichardKnop/minisql/internal/minisql"
+)
+
+// ImportOption configures ImportNDJSON and ImportCSV.
+type ImportOption = minisql.ImportOption
+
+// ErrImportUnknownColumn is returned by ImportNDJSON when a JSON object has a
+// key that is not a column of the table, and by ImportCSV when a header names
+// one, unless WithLenientImport is set.
+var ErrImportUnknownColumn = minisql.ErrImportUnknownColumn
+
+// WithLenientImport makes ImportNDJSON ignore object keys, and ImportCSV
+// header names, that do not match a column of the table.
+func WithLenientImport() ImportOption {
+	return minisql.WithLenientImport()
+}
+
+// WithImportBatchSize sets how many rows ImportNDJSON and ImportCSV insert per
+// statement (default 500).
+func WithImportBatchSize(n int) ImportOption {
+	return minisql.WithImportBatchSize(n)
+}
+
+// WithCSVDelimiter sets the field delimiter ImportCSV splits records on
+// (default ',').
+func WithCSVDelimiter(delimiter rune) ImportOption {
+	return minisql.WithCSVDelimiter(delimiter)
+}
+
+// WithCSVEmptyAsNull makes ImportCSV store empty fields as NULL.
+func WithCSVEmptyAsNull() ImportOption {
+	return minisql.WithCSVEmptyAsNull()
+}
+
+// WithCSVSkipHeader makes ImportCSV discard the first record and map fields
+// to the table's columns by position.
+func WithCSVSkipHeader() ImportOption {
+	return minisql.WithCSVSkipHeader()
+}
+
+// WithCSVNoHeader tells ImportCSV the input has no header record; fields map
+// to the table's columns by position.
+func WithCSVNoHeader() ImportOption {
+	return minisql.WithCSVNoHeader()
+}
+
+// WithContinueOnError makes ImportCSV skip rows that cannot be converted or
+// inserted, calling onError (when not nil) for each one, instead of failing
+// the whole import. Rows are then committed in several transactions.
+func WithContinueOnError(onError func(line int, err error)) ImportOption {
+	return minisql.WithContinueOnError(onError)
+}
+
+// ImportNDJSON inserts newline-delimited JSON read from r into table and
+// returns the number of rows inserted. Each line is a JSON object whose keys
+// name columns:
+//
+//	f, err := os.Open("events.ndjson")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	defer f.Close()
+//	n, err := minisql.ImportNDJSON(ctx, db, "events", f)
+//
+// Columns missing from an object get their default value or NULL. All rows
+// are inserted in one transaction, so a bad line imports nothing; the error
+// names the offending line.
+//
+// ImportNDJSON must not be called from inside an explicit user transaction.
+func ImportNDJSON(ctx context.Context, db *sql.DB, table string, r io.Reader, opts ...ImportOption) (int64, error) {
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("minisql: ImportNDJSON: acquire connection: %w", err)
+	}
+	defer conn.Close()
+
+	var imported int64
+	err = conn.Raw(func(c any) error {
+		mc, ok := c.(*Conn)
+		if !ok {
+			return fmt.Errorf("minisql: ImportNDJSON: unexpected connection type %T", c)
+		}
+		imported, err = mc.db.ImportNDJSON(ctx, table, r, opts...)
+		return err
+	})
+	return imported, err
+}
+
+// ImportCSV inserts CSV records read from r into table and returns the number
+// of rows inserted. By default the first record is a header naming the columns
+// the fields belong to:
+//
+//	f, err := os.Open("users.csv")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	defer f.Close()
+//	n, err := minisql.ImportCSV(ctx, db, "users", f, minisql.WithCSVEmptyAsNull())
+//
+// Fields are converted to the kinds of their columns. All rows are inserted in
+// one transaction, so a bad record imports nothing and the error names its
+// line, unless WithContinueOnError is set.
+//
+// ImportCSV must not be called from inside an explicit user transaction.
+func ImportCSV(ctx context.Context, db *sql.DB, table string, r io.Reader, opts ...ImportOption) (int64, error) {
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("minisql: ImportCSV: acquire connection: %w", err)
+	}
+	defer conn.Close()
+
+	var imported int64
+	err = conn.Raw(func(c any) error {
+		mc, ok := c.(*Conn)
+		if !ok {
+			return fmt.Errorf("minisql: ImportCSV: unexpected connection type %T", c)
+		}
+		imported, err = mc.db.ImportCSV(ctx, table, r, opts...)
+		return err
+	})
+	return imported, err
+}
@@ -0,0 +1,116 @@
+package minisql
+
+import (
+	"context"
+	"fmt"
+
+	minisqlErrors "github.com/RichardKnop/minisql/pkg/errors"
+)
+
+// executeAlterIndex renames a secondary index. The index keeps its root page:
+// only its schema entry, its DDL, the table's in-memory index map and its
+// planner statistics are updated to the new name.
+//
+// Primary key and unique index names are derived from the table definition
+// when the schema is loaded, so they cannot be renamed.
+func (d *Database) executeAlterIndex(ctx context.Context, stmt Statement) error {
+	oldName, newName := stmt.IndexName, stmt.NewIndexName
+
+	schema, exists, err := d.checkSchemaExists(ctx, SchemaSecondaryIndex, oldName)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		for _, schemaType := range []SchemaType{SchemaPrimaryKey, SchemaUniqueIndex} {
+			if _, exists, err := d.checkSchemaExists(ctx, schemaType, oldName); err != nil {
+				return err
+			} else if exists {
+				return fmt.Errorf("cannot rename index %s: primary key and unique index names are derived from the table definition", oldName)
+			}
+		}
+		return minisqlErrors.ErrNoSuchIndex{Name: oldName}
+	}
+	for _, schemaType := range []SchemaType{SchemaPrimaryKey, SchemaUniqueIndex, SchemaSecondaryIndex} {
+		if _, exists, err := d.checkSchemaExists(ctx, schemaType, newName); err != nil {
+			return err
+		} else if exists {
+			return fmt.Errorf("index %s already exists", newName)
+		}
+	}
+
+	stmts, err := d.parser.Parse(ctx, schema.DDL)
+	if err != nil {
+		return err
+	}
+	if len(stmts) != 1 {
+		return fmt.Errorf("expected one statement when loading index, got %d", len(stmts))
+	}
+	createStmt := stmts[0]
+	createStmt.IndexName = newName
+
+	if err := d.deleteSchema(ctx, SchemaSecondaryIndex, oldName); err != nil {
+		return err
+	}
+	if err := d.insertSchema(ctx, Schema{
+		Type:      SchemaSecondaryIndex,
+		Name:      newName,
+		TableName: schema.TableName,
+		DDL:       createStmt.DDL(),
+		RootPage:  schema.RootPage,
+	}); err != nil {
+		return err
+	}
+	if err := d.renameIndexStats(ctx, schema.TableName, oldName, newName); err != nil {
+		return err
+	}
+
+	// An index created earlier in this transaction is only added to the table
+	// at commit, so rename the pending change instead.
+	tx := MustTxFromContext(ctx)
+	if created, ok := tx.DDLChanges.CreateIndexes[schema.TableName]; ok && created.Name == oldName {
+		created.Name = newName
+		tx.DDLChanges.CreateIndexes[schema.TableName] = created
+		return nil
+	}
+
+	table, ok := d.tables[schema.TableName]
+	if !ok {
+		return nil
+	}
+	if secondaryIndex, ok := table.SecondaryIndexes[oldName]; ok {
+		table.RemoveSecondaryIndex(oldName)
+		secondaryIndex.Name = newName
+		table.SetSecondaryIndex(secondaryIndex)
+	}
+	return nil
+}
+
+// renameIndexStats moves the planner statistics collected by ANALYZE for an
+// index to its new name, both in memory and in the statistics table.
+func (d *Database) renameIndexStats(ctx context.Context, tableName, oldName, newName string) error {
+	if table, ok := d.tables[tableName]; ok {
+		if stats, ok := table.indexStats[oldName]; ok {
+			delete(table.indexStats, oldName)
+			table.indexStats[newName] = stats
+		}
+	}
+
+	statsTable, ok := d.tables[StatsTableName]
+	if !ok {
+		return nil
+	}
+	_, err := statsTable.Update(ctx, Statement{
+		Kind:      Update,
+		TableName: StatsTableName,
+		Updates: map[string]OptionalValue{
+			"idx": {Value: NewTextPointer([]byte(newName)), Valid: true},
+		},
+		Conditions: OneOrMore{
+			{
+				FieldIsEqual(Field{Name: "tbl"}, OperandQuotedString, NewTextPointer([]byte(tableName))),
+				FieldIsEqual(Field{Name: "idx"}, OperandQuotedString, NewTextPointer([]byte(oldName))),
+			},
+		},
+	})
+	return err
+}
@@ -3,7 +3,9 @@ package minisql
 import (
 	"context"
 	"fmt"
+	"slices"
 
+	"github.com/RichardKnop/minisql/pkg/bitwise"
 	minisqlErrors "github.com/RichardKnop/minisql/pkg/errors"
 )
 
@@ -56,15 +58,18 @@ func (d *Database) alterTableAddColumn(ctx context.Context, stmt Statement) erro
 		return fmt.Errorf("table %q already has %d columns (max %d)", stmt.TableName, live, MaxColumns)
 	}
 
-	table.Columns = append(table.Columns, newCol)
-	table.columnCache[newCol.Name] = len(table.Columns) - 1
+	tx := MustTxFromContext(ctx)
+	tx.DDLChanges = tx.DDLChanges.AlteredColumns(table, table.Columns)
+	table.setColumns(append(slices.Clone(table.Columns), newCol))
 
 	return d.updateTableSchema(ctx, table)
 }
 
-// alterTableDropColumn marks a column as Deleted=true (tombstone). Old cells still
-// carry the column's bytes; TypeCode tells the decoder how to skip them. New cells
-// written after the DROP write TypeCodeNull (0 bytes) for the dropped slot.
+// alterTableDropColumn removes a column from the table. Every leaf cell is
+// rewritten without the column's slot before the schema entry is replaced, so
+// the column disappears from Table.Columns and the persisted DDL. The rewrite
+// goes through the transaction pager, so a crash mid-alter is recovered from
+// the journal like any other write.
 func (d *Database) alterTableDropColumn(ctx context.Context, stmt Statement) error {
 	table := d.tables[stmt.TableName]
 
@@ -81,37 +86,147 @@ func (d *Database) alterTableDropColumn(ctx context.Context, stmt Statement) err
 
 	col := table.Columns[colIdx]
 
-	for _, pkCol := range table.PrimaryKey.Columns {
-		if pkCol.Name == col.Name {
-			return fmt.Errorf("cannot drop primary key column %q", col.Name)
-		}
+	if dependency := table.columnDependency(col.Name); dependency != "" {
+		return fmt.Errorf("cannot drop column %q: %s", col.Name, dependency)
 	}
-	for _, si := range table.SecondaryIndexes {
-		for _, siCol := range si.Columns {
-			if siCol.Name == col.Name {
-				return fmt.Errorf("cannot drop column %q: referenced by index %q", col.Name, si.Name)
-			}
-		}
+
+	if err := table.rewriteLeavesWithoutColumn(ctx, colIdx); err != nil {
+		return fmt.Errorf("drop column %q: %w", col.Name, err)
 	}
-	for _, ui := range table.UniqueIndexes {
-		for _, uiCol := range ui.Columns {
-			if uiCol.Name == col.Name {
-				return fmt.Errorf("cannot drop column %q: referenced by unique index %q", col.Name, ui.Name)
+
+	oldColumns := table.Columns
+	table.setColumns(slices.Delete(slices.Clone(oldColumns), colIdx, colIdx+1))
+	if err := d.updateTableSchema(ctx, table); err != nil {
+		table.setColumns(oldColumns)
+		return err
+	}
+	tx := MustTxFromContext(ctx)
+	tx.DDLChanges = tx.DDLChanges.AlteredColumns(table, oldColumns)
+	return nil
+}
+
+// rewriteLeavesWithoutColumn re-serialises every leaf cell of the table
+// without the column at colIdx: its value bytes and TypeCode are removed, the
+// NullBitmask bits above it shift down and ColumnCount shrinks by one. Cells
+// only shrink, so pages are rewritten in place and none are split, merged or
+// freed; overflow chains owned by the dropped values go back to the free list.
+func (t *Table) rewriteLeavesWithoutColumn(ctx context.Context, colIdx int) error {
+	cursor, err := t.SeekFirst(ctx)
+	if err != nil {
+		return err
+	}
+
+	col := t.Columns[colIdx]
+	// Leaves carry the MINMAX column ordinal, which moves when an earlier
+	// column is removed; the min/max summary is recomputed on commit.
+	hint := newPageHint(slices.Delete(slices.Clone(t.Columns), colIdx, colIdx+1))
+
+	for pageIdx := cursor.PageIdx; ; {
+		page, err := t.pager.ModifyPage(ctx, pageIdx)
+		if err != nil {
+			return fmt.Errorf("rewrite leaf %d: %w", pageIdx, err)
+		}
+		leaf := page.LeafNode
+
+		for cellIdx := range leaf.Header.Cells {
+			cell := leaf.Cells[cellIdx]
+			if colIdx >= int(cell.ColumnCount) {
+				// The row predates the column (lazy ADD COLUMN), nothing to remove.
+				continue
+			}
+
+			var offset, size int
+			if !bitwise.IsSet(cell.NullBitmask, colIdx) {
+				view := NewRowView(t.Columns, cell)
+				if col.MayUseOverflowText() || col.MayUseOverflowVector() {
+					value, err := view.ValueAt(colIdx)
+					if err != nil {
+						return fmt.Errorf("rewrite leaf %d: %w", pageIdx, err)
+					}
+					row := Row{Columns: []Column{col}, Values: []OptionalValue{value}, Key: cell.Key}
+					if err := t.freeOverflowPages(ctx, row); err != nil {
+						return fmt.Errorf("rewrite leaf %d: %w", pageIdx, err)
+					}
+				}
+				if offset, err = view.offsetOf(colIdx); err != nil {
+					return fmt.Errorf("rewrite leaf %d: %w", pageIdx, err)
+				}
+				valueSize, err := encodedValueSize(TypeCode(cell.TypeCodes[colIdx]), cell.Value, uint64(offset))
+				if err != nil {
+					return fmt.Errorf("rewrite leaf %d: %w", pageIdx, err)
+				}
+				size = int(valueSize)
 			}
+
+			leaf.PrepareModifyCell(cellIdx)
+			modified := &leaf.Cells[cellIdx]
+			modified.Value = append(modified.Value[:offset], modified.Value[offset+size:]...)
+			modified.TypeCodes = slices.Delete(slices.Clone(modified.TypeCodes), colIdx, colIdx+1)
+			modified.NullBitmask = removeBit(modified.NullBitmask, colIdx)
+			modified.ColumnCount -= 1
 		}
+		leaf.Header.Hint = hint
+
+		if leaf.Header.NextLeaf == 0 {
+			return nil
+		}
+		pageIdx = leaf.Header.NextLeaf
 	}
-	if table.referencedColumns[col.Name] {
-		return fmt.Errorf("cannot drop column %q: referenced by a foreign key constraint", col.Name)
-	}
+}
 
-	table.Columns[colIdx].Deleted = true
-	delete(table.columnCache, col.Name)
+// removeBit drops bit k from n, shifting the higher bits down by one.
+func removeBit(n uint64, k int) uint64 {
+	low := n & (1<<k - 1)
+	high := n >> (k + 1) << k
+	return low | high
+}
 
-	return d.updateTableSchema(ctx, table)
+// columnDependency describes the first index, foreign key, CHECK constraint
+// of another column or generated column that refers to the named column, or
+// returns "" when nothing does. Dependents refer to columns by name, so such
+// a column can be neither dropped nor renamed.
+func (t *Table) columnDependency(name string) string {
+	for _, pkCol := range t.PrimaryKey.Columns {
+		if pkCol.Name == name {
+			return "part of the primary key"
+		}
+	}
+	for _, si := range t.SecondaryIndexes {
+		if si.referencesColumn(name) {
+			return fmt.Sprintf("referenced by index %q", si.Name)
+		}
+	}
+	for _, ui := range t.UniqueIndexes {
+		if ui.referencesColumn(name) {
+			return fmt.Sprintf("referenced by unique index %q", ui.Name)
+		}
+	}
+	if t.referencedColumns[name] {
+		return "referenced by a foreign key constraint"
+	}
+	for _, fk := range t.ForeignKeys {
+		if slices.Contains(fk.Columns, name) {
+			return "used by a foreign key constraint"
+		}
+	}
+	for _, other := range t.Columns {
+		if other.Deleted || other.Name == name {
+			continue
+		}
+		if other.CheckCond != nil && slices.Contains(other.CheckCond.Columns(), name) {
+			return fmt.Sprintf("referenced by the CHECK constraint of column %q", other.Name)
+		}
+		if other.IsGenerated() && slices.Contains(exprSourceColumns(other.GeneratedExpr), name) {
+			return fmt.Sprintf("referenced by generated column %q", other.Name)
+		}
+	}
+	return ""
 }
 
 // alterTableRenameColumn renames a column in the schema. The B+ tree is untouched;
-// only the schema DDL and in-memory column cache are updated.
+// only the schema DDL and in-memory column cache are updated. Columns that
+// other schema objects refer to by name, including a column's own CHECK
+// constraint, cannot be renamed.
 func (d *Database) alterTableRenameColumn(ctx context.Context, stmt Statement) error {
 	table := d.tables[stmt.TableName]
 
@@ -130,56 +245,77 @@ func (d *Database) alterTableRenameColumn(ctx context.Context, stmt Statement) e
 			return fmt.Errorf("column %q already exists in table %q", stmt.NewColumnName, stmt.TableName)
 		}
 	}
+	if dependency := table.columnDependency(stmt.AlterColumnName); dependency != "" {
+		return fmt.Errorf("cannot rename column %q: %s", stmt.AlterColumnName, dependency)
+	}
+	if table.Columns[colIdx].CheckCond != nil {
+		return fmt.Errorf("cannot rename column %q: referenced by its CHECK constraint", stmt.AlterColumnName)
+	}
 
-	oldName := table.Columns[colIdx].Name
-	table.Columns[colIdx].Name = stmt.NewColumnName
-	delete(table.columnCache, oldName)
-	table.columnCache[stmt.NewColumnName] = colIdx
+	columns := slices.Clone(table.Columns)
+	columns[colIdx].Name = stmt.NewColumnName
+	tx := MustTxFromContext(ctx)
+	tx.DDLChanges = tx.DDLChanges.AlteredColumns(table, table.Columns)
+	table.setColumns(columns)
 
 	return d.updateTableSchema(ctx, table)
 }
 
 // alterTableRenameTo renames the table. It updates the table schema entry and all
 // associated index schema entries (PK, unique, secondary) so they reference the new
-// name. The in-memory d.tables map and Table.Name are updated accordingly.
+// name. The in-memory d.tables map and Table.Name are updated accordingly and the
+// rename is recorded in the transaction's DDLChanges so rollback can revert it.
 func (d *Database) alterTableRenameTo(ctx context.Context, stmt Statement) error {
 	oldName := stmt.TableName
 	newName := stmt.NewTableName
 
+	if isSystemTable(newName) {
+		return fmt.Errorf("cannot rename table %q to system table name %q", oldName, newName)
+	}
 	if _, newExists, err := d.checkSchemaExists(ctx, SchemaTable, newName); err != nil {
 		return err
 	} else if newExists {
-		return fmt.Errorf("table %q already exists", newName)
+		return minisqlErrors.ErrTableAlreadyExists{Name: newName}
 	}
 
-	table := d.tables[oldName]
+	// The table may have been created earlier in this transaction, in which
+	// case it is only reachable through the transaction's DDLChanges.
+	table, ok := d.lockedProvider.GetTable(ctx, oldName)
+	if !ok {
+		return minisqlErrors.ErrNoSuchTable{Name: oldName}
+	}
 
-	// Rename the table in-memory first so tableStatementFromTable picks up the new name.
+	// Build the DDL under the new name without touching the in-memory table
+	// until every schema entry has been rewritten.
 	table.Name = newName
+	tableDDL := tableStatementFromTable(table).DDL()
+	table.Name = oldName
 
 	// Update the table schema entry.
 	if err := d.deleteSchema(ctx, SchemaTable, oldName); err != nil {
-		table.Name = oldName
 		return err
 	}
 	if err := d.insertSchema(ctx, Schema{
 		Type:     SchemaTable,
 		Name:     newName,
 		RootPage: table.rootPageIdx,
-		DDL:      tableStatementFromTable(table).DDL(),
+		DDL:      tableDDL,
 	}); err != nil {
-		table.Name = oldName
 		return err
 	}
 
-	// Update primary key schema entry.
+	// Primary key and unique index names are derived from the table name, so
+	// their schema entries move to the new derived names.
+	indexRenames := make(map[string]string, len(table.UniqueIndexes)+1)
 	if table.HasPrimaryKey() {
+		newIndexName := PrimaryKeyName(newName)
+		indexRenames[table.PrimaryKey.Name] = newIndexName
 		if err := d.deleteSchema(ctx, SchemaPrimaryKey, table.PrimaryKey.Name); err != nil {
 			return err
 		}
 		if err := d.insertSchema(ctx, Schema{
 			Type:      SchemaPrimaryKey,
-			Name:      table.PrimaryKey.Name,
+			Name:      newIndexName,
 			TableName: newName,
 			RootPage:  table.PrimaryKey.Index.GetRootPageIdx(),
 		}); err != nil {
@@ -189,12 +325,14 @@ func (d *Database) alterTableRenameTo(ctx context.Context, stmt Statement) error
 
 	// Update unique index schema entries.
 	for _, ui := range table.UniqueIndexes {
+		newIndexName := uniqueIndexNameForTable(newName, ui)
+		indexRenames[ui.Name] = newIndexName
 		if err := d.deleteSchema(ctx, SchemaUniqueIndex, ui.Name); err != nil {
 			return err
 		}
 		if err := d.insertSchema(ctx, Schema{
 			Type:      SchemaUniqueIndex,
-			Name:      ui.Name,
+			Name:      newIndexName,
 			TableName: newName,
 			RootPage:  ui.Index.GetRootPageIdx(),
 		}); err != nil {
@@ -219,10 +357,113 @@ func (d *Database) alterTableRenameTo(ctx context.Context, stmt Statement) error
 		}
 	}
 
-	// Update in-memory tables map.
+	if err := d.renameTableStats(ctx, oldName, newName, indexRenames); err != nil {
+		return err
+	}
+
+	d.renameTable(table, oldName, newName)
+
+	tx := MustTxFromContext(ctx)
+	if delta := tx.RowCountDelta(oldName); delta != 0 {
+		tx.AddRowCountDelta(oldName, -delta)
+		tx.AddRowCountDelta(newName, delta)
+	}
+	tx.DDLChanges = tx.DDLChanges.RenamedTable(oldName, newName)
+
+	return nil
+}
+
+// renameTableStats moves the planner statistics collected by ANALYZE for a
+// table to its new name, renaming index entries listed in indexRenames.
+func (d *Database) renameTableStats(ctx context.Context, oldName, newName string, indexRenames map[string]string) error {
+	statsTable, ok := d.tables[StatsTableName]
+	if !ok {
+		return nil
+	}
+	if _, err := statsTable.Update(ctx, Statement{
+		Kind:      Update,
+		TableName: StatsTableName,
+		Updates: map[string]OptionalValue{
+			"tbl": {Value: NewTextPointer([]byte(newName)), Valid: true},
+		},
+		Conditions: OneOrMore{
+			{
+				FieldIsEqual(Field{Name: "tbl"}, OperandQuotedString, NewTextPointer([]byte(oldName))),
+			},
+		},
+	}); err != nil {
+		return err
+	}
+	for oldIndexName, newIndexName := range indexRenames {
+		if _, err := statsTable.Update(ctx, Statement{
+			Kind:      Update,
+			TableName: StatsTableName,
+			Updates: map[string]OptionalValue{
+				"idx": {Value: NewTextPointer([]byte(newIndexName)), Valid: true},
+			},
+			Conditions: OneOrMore{
+				{
+					FieldIsEqual(Field{Name: "tbl"}, OperandQuotedString, NewTextPointer([]byte(newName))),
+					FieldIsEqual(Field{Name: "idx"}, OperandQuotedString, NewTextPointer([]byte(oldIndexName))),
+				},
+			},
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// uniqueIndexNameForTable returns the derived name of a unique index once its
+// table is called tableName.
+func uniqueIndexNameForTable(tableName string, ui UniqueIndex) string {
+	names := make([]string, 0, len(ui.Columns))
+	for _, col := range ui.Columns {
+		names = append(names, col.Name)
+	}
+	return UniqueIndexName(tableName, names...)
+}
+
+// renameTable moves a table to a new name in the in-memory schema: its derived
+// primary key and unique index names, the tables map, the cached row count and
+// the foreign key bookkeeping. A table created by the current transaction is
+// not in the tables map yet and only has its names updated. The caller must
+// hold dbLock.
+func (d *Database) renameTable(table *Table, oldName, newName string) {
+	table.Name = newName
+	if table.HasPrimaryKey() {
+		newIndexName := PrimaryKeyName(newName)
+		renameIndexStatsKey(table, table.PrimaryKey.Name, newIndexName)
+		table.PrimaryKey.Name = newIndexName
+	}
+	if len(table.UniqueIndexes) > 0 {
+		uniqueIndexes := make(map[string]UniqueIndex, len(table.UniqueIndexes))
+		for _, ui := range table.UniqueIndexes {
+			newIndexName := uniqueIndexNameForTable(newName, ui)
+			renameIndexStatsKey(table, ui.Name, newIndexName)
+			ui.Name = newIndexName
+			uniqueIndexes[newIndexName] = ui
+		}
+		table.UniqueIndexes = uniqueIndexes
+	}
+
+	if committed, ok := d.tables[oldName]; !ok || committed != table {
+		return
+	}
 	delete(d.tables, oldName)
 	d.tables[newName] = table
 
+	// Move the cached row count to the new name.
+	if table.getRowCount != nil {
+		d.rowCountsMu.Lock()
+		if count, ok := d.rowCounts[oldName]; ok {
+			d.rowCounts[newName] = count
+			delete(d.rowCounts, oldName)
+		}
+		d.rowCountsMu.Unlock()
+		table.getRowCount = d.rowCountGetter(newName)
+	}
+
 	// Update FK references: if other tables reference this table, update the map key.
 	if inbounds, ok := d.referencedBy[oldName]; ok {
 		d.referencedBy[newName] = inbounds
@@ -236,8 +477,15 @@ func (d *Database) alterTableRenameTo(ctx context.Context, stmt Statement) error
 			}
 		}
 	}
+}
 
-	return nil
+// renameIndexStatsKey moves the in-memory planner statistics of an index to
+// its new name.
+func renameIndexStatsKey(table *Table, oldName, newName string) {
+	if stats, ok := table.indexStats[oldName]; ok {
+		delete(table.indexStats, oldName)
+		table.indexStats[newName] = stats
+	}
 }
 
 // updateTableSchema rebuilds the DDL for a table and replaces its schema entry.
@@ -0,0 +1,31 @@
+package minisql
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRemoveBit(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		Name     string
+		Bitmask  uint64
+		Bit      int
+		Expected uint64
+	}{
+		{"Empty bitmask", 0, 3, 0},
+		{"Remove lowest set bit", 0b1011, 0, 0b101},
+		{"Remove unset bit shifts higher bits down", 0b1001, 1, 0b101},
+		{"Remove highest set bit", 0b1001, 3, 0b001},
+		{"Remove bit above all set bits", 0b0111, 5, 0b0111},
+		{"Remove bit 63", 1<<63 | 1, 63, 1},
+	}
+
+	for _, aTestCase := range testCases {
+		t.Run(aTestCase.Name, func(t *testing.T) {
+			assert.Equal(t, aTestCase.Expected, removeBit(aTestCase.Bitmask, aTestCase.Bit))
+		})
+	}
+}
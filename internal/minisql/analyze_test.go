@@ -131,6 +131,52 @@ func TestDatabase_Analyze(t *testing.T) {
 	// Non-unique secondary index collects MCV.
 	assert.NotEmpty(t, createdStats.MCV, "non-unique secondary index should have MCV entries")
 
+	// Renaming the index carries its statistics over to the new name.
+	mockParser.On("Parse", mock.Anything, mock.Anything).Return([]Statement{createIndexStmt}, nil).Once()
+	err = aDatabase.txManager.ExecuteInTransaction(ctx, func(ctx context.Context) error {
+		_, err := aDatabase.ExecuteStatement(ctx, Statement{Kind: AlterIndex, IndexName: "idx_created", NewIndexName: "idx_created_at"})
+		return err
+	})
+	require.NoError(t, err)
+
+	stats, err = aDatabase.listStats(ctx, "")
+	require.NoError(t, err)
+	indexNames := make([]string, 0, len(stats))
+	for _, s := range stats {
+		indexNames = append(indexNames, s.IndexName)
+	}
+	assert.ElementsMatch(t, []string{"", "pkey__test_table", "key__test_table__email", "idx_created_at"}, indexNames)
+	assert.Equal(t, createdStats, aDatabase.tables[testTableName].indexStats["idx_created_at"])
+	assert.NotContains(t, aDatabase.tables[testTableName].indexStats, "idx_created")
+	assert.Contains(t, aDatabase.tables[testTableName].SecondaryIndexes, "idx_created_at")
+
+	// Renaming the table moves its statistics, including those of the primary
+	// key and unique index whose names are derived from the table name.
+	err = aDatabase.txManager.ExecuteInTransaction(ctx, func(ctx context.Context) error {
+		_, err := aDatabase.ExecuteStatement(ctx, Statement{
+			Kind:             AlterTable,
+			TableName:        testTableName,
+			AlterTableAction: AlterTableRenameTo,
+			NewTableName:     "renamed_table",
+		})
+		return err
+	})
+	require.NoError(t, err)
+
+	stats, err = aDatabase.listStats(ctx, "")
+	require.NoError(t, err)
+	indexNames = indexNames[:0]
+	for _, s := range stats {
+		assert.Equal(t, "renamed_table", s.TableName)
+		indexNames = append(indexNames, s.IndexName)
+	}
+	assert.ElementsMatch(t, []string{"", "pkey__renamed_table", "key__renamed_table__email", "idx_created_at"}, indexNames)
+	renamedTable := aDatabase.tables["renamed_table"]
+	require.NotNil(t, renamedTable)
+	assert.Equal(t, pkStats, renamedTable.indexStats["pkey__renamed_table"])
+	assert.Contains(t, renamedTable.UniqueIndexes, "key__renamed_table__email")
+	assert.Equal(t, "pkey__renamed_table", renamedTable.PrimaryKey.Name)
+
 	mock.AssertExpectationsForObjects(t, mockParser)
 }
 
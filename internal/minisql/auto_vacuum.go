@@ -0,0 +1,110 @@
+package minisql
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+)
+
+// freePageRatio returns the fraction of database pages that sit on the free
+// list, or 0 for an empty database.
+func (d *Database) freePageRatio(ctx context.Context) float64 {
+	totalPages := d.saver.TotalPages()
+	if totalPages == 0 {
+		return 0
+	}
+	header := d.factory.ForTable(mainTableColumns).GetHeader(ctx)
+	return float64(header.FreePageCount) / float64(totalPages)
+}
+
+// checkAutoVacuum is registered as the transaction manager's after-commit
+// callback when autovacuum is enabled.  It only flags the database; the
+// VACUUM cannot run here because the committing transaction is still in
+// scope of its caller.  The header is read in a read-only transaction so the
+// free-page count comes from a committed snapshot rather than racing the
+// next writer.
+func (d *Database) checkAutoVacuum() {
+	var ratio float64
+	err := d.txManager.ExecuteReadOnlyTransaction(context.Background(), func(ctx context.Context) error {
+		ratio = d.freePageRatio(ctx)
+		return nil
+	})
+	if err == nil && ratio >= d.autoVacuumThreshold {
+		d.autoVacuumPending.Store(true)
+	}
+}
+
+// AutoVacuumAfterCommit runs a VACUUM scheduled by autovacuum once a statement
+// or transaction has committed. A failure is logged rather than returned
+// because the statement that triggered it has already committed.
+func (d *Database) AutoVacuumAfterCommit(ctx context.Context) {
+	if !d.AutoVacuumPending() {
+		return
+	}
+	if _, err := d.RunPendingAutoVacuum(ctx); err != nil {
+		d.logger.Warn("autovacuum failed", zap.Error(err))
+	}
+}
+
+// AutoVacuumPending reports whether a commit has pushed the free-page ratio
+// past the WithAutoVacuum threshold and a VACUUM is waiting to run.
+func (d *Database) AutoVacuumPending() bool {
+	return d.autoVacuumPending.Load()
+}
+
+// RunPendingAutoVacuum runs a VACUUM scheduled by autovacuum, reporting
+// whether it did.  VACUUM replaces the database file and transaction manager,
+// so it is deferred while ctx carries a transaction or any other transaction
+// (an explicit BEGIN, a streaming read) is still open; the pending flag is
+// kept and the next call after a later commit retries.  The driver calls this
+// after every auto-commit statement and explicit COMMIT.
+//
+// The VACUUM runs in a write transaction that is only started when no other
+// transaction is open, atomically with that check, and keeps other writers
+// out until the compacted file is in place.
+func (d *Database) RunPendingAutoVacuum(ctx context.Context) (bool, error) {
+	if !d.autoVacuumPending.Load() || TxFromContext(ctx) != nil {
+		return false, nil
+	}
+	txManager := d.txManager
+	tx, err := txManager.beginTransaction(ctx, false, true)
+	if err != nil {
+		// Another transaction is open; retry after a later commit.
+		return false, nil
+	}
+	vacuumCtx := WithTransaction(ctx, tx)
+
+	if !d.autoVacuumPending.CompareAndSwap(true, false) {
+		txManager.RollbackTransaction(ctx, tx)
+		return false, nil
+	}
+
+	// Pages may have been reused by inserts since the flag was set.
+	ratio := d.freePageRatio(vacuumCtx)
+	if ratio < d.autoVacuumThreshold {
+		txManager.RollbackTransaction(ctx, tx)
+		return false, nil
+	}
+
+	if _, err := d.Vacuum(vacuumCtx); err != nil {
+		txManager.RollbackTransaction(ctx, tx)
+		return false, err
+	}
+	// The transaction belongs to the replaced transaction manager and made no
+	// changes; committing it only releases the writer slot, as it does for a
+	// VACUUM statement.
+	if err := txManager.CommitTransaction(ctx, tx); err != nil {
+		return false, err
+	}
+	// VACUUM's own internal commits run the after-commit check against the
+	// pre-compaction file; the compacted file needs no further vacuum.
+	d.autoVacuumPending.Store(false)
+	d.metrics.autoVacuums.Add(1)
+	if ce := d.logger.Check(zap.InfoLevel, "autovacuum completed"); ce != nil {
+		ce.Write(
+			zap.Float64("free_page_ratio", ratio),
+			zap.Float64("threshold", d.autoVacuumThreshold),
+		)
+	}
+	return true, nil
+}
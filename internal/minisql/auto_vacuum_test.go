@@ -0,0 +1,100 @@
+package minisql
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithAutoVacuum(t *testing.T) {
+	t.Parallel()
+
+	for _, threshold := range []float64{-0.5, 0, 1.5} {
+		db := &Database{}
+		WithAutoVacuum(threshold)(db)
+		assert.Zero(t, db.autoVacuumThreshold, "threshold %v must be ignored", threshold)
+	}
+
+	db := &Database{}
+	WithAutoVacuum(0.25)(db)
+	assert.Equal(t, 0.25, db.autoVacuumThreshold)
+}
+
+func TestAutoVacuum_HeavyDeleteTriggersCompaction(t *testing.T) {
+	t.Parallel()
+	const (
+		tableName = "items"
+		numRows   = 1000
+		keepRows  = 50
+	)
+	createStmt := vacuumCreateStmt(tableName)
+
+	mockParser := new(MockParser)
+	mockParser.On("Parse", mock.Anything, createStmt.DDL()).Return([]Statement{createStmt}, nil)
+
+	db, _ := newVacuumTestDB(t, mockParser, WithAutoVacuum(0.3))
+	ctx := context.Background()
+
+	execInTx(t, db, func(ctx context.Context) {
+		_, err := db.ExecuteStatement(ctx, createStmt)
+		require.NoError(t, err)
+	})
+	execInTx(t, db, func(ctx context.Context) {
+		tbl := db.tables[tableName]
+		for i := int64(1); i <= numRows; i++ {
+			_, err := tbl.Insert(ctx, Statement{
+				Kind:   Insert,
+				Fields: fieldsFromColumns(tbl.Columns...),
+				Inserts: [][]OptionalValue{{
+					{Value: i, Valid: true},
+					{Value: NewTextPointer([]byte(fmt.Sprintf("%d-%s", i, strings.Repeat("x", 90)))), Valid: true},
+				}},
+			})
+			require.NoError(t, err)
+		}
+	})
+	assert.False(t, db.AutoVacuumPending(), "inserts alone must not schedule a vacuum")
+
+	execInTx(t, db, func(ctx context.Context) {
+		_, err := db.ExecuteStatement(ctx, Statement{
+			Kind:       Delete,
+			TableName:  tableName,
+			Conditions: OneOrMore{{FieldIsGreater(Field{Name: "id"}, OperandInteger, int64(keepRows))}},
+		})
+		require.NoError(t, err)
+	})
+
+	before := db.freePageRatio(ctx)
+	require.GreaterOrEqual(t, before, 0.3)
+	require.True(t, db.AutoVacuumPending())
+
+	// Deferred while another transaction is open.
+	readTx := db.txManager.BeginReadOnlyTransaction(ctx)
+	ran, err := db.RunPendingAutoVacuum(ctx)
+	require.NoError(t, err)
+	assert.False(t, ran)
+	assert.True(t, db.AutoVacuumPending())
+	require.NoError(t, db.txManager.CommitTransaction(ctx, readTx))
+
+	ran, err = db.RunPendingAutoVacuum(ctx)
+	require.NoError(t, err)
+	assert.True(t, ran)
+	assert.False(t, db.AutoVacuumPending())
+	assert.Less(t, db.freePageRatio(ctx), before)
+	assert.Less(t, db.freePageRatio(ctx), 0.3)
+	assert.Equal(t, int64(1), db.ReadEngineMetrics().AutoVacuums)
+	assert.Equal(t, keepRows, countRowsInDB(t, db, tableName))
+
+	// Nothing pending: a further call is a no-op.
+	ran, err = db.RunPendingAutoVacuum(ctx)
+	require.NoError(t, err)
+	assert.False(t, ran)
+
+	// The after-commit check survives the Reopen performed by VACUUM.
+	assert.NotNil(t, db.txManager.afterCommitFn)
+}
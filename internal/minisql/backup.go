@@ -3,10 +3,52 @@ package minisql
 import (
 	"context"
 	"fmt"
+	"io"
 	"os"
 )
 
-// Backup writes a consistent, point-in-time copy of the database to destPath.
+// Backup writes a consistent, point-in-time copy of the database to destPath
+// using BackupTo, then syncs the file.  A partially written destination is
+// removed when the backup fails.
+//
+// The destination is a standalone database file that can be opened directly
+// with sql.Open("minisql", destPath).  It carries no WAL file.  If the source
+// is encrypted the backup is encrypted with the same key.
+//
+// Backup must not be called from inside an explicit user transaction.
+func (d *Database) Backup(ctx context.Context, destPath string) (retErr error) {
+	if d.InMemory() {
+		return fmt.Errorf("backup: %w", ErrInMemoryDatabase)
+	}
+	if d.walDBFile == nil || d.walIndex == nil {
+		return fmt.Errorf("backup: database is not in WAL mode")
+	}
+
+	destFile, err := os.OpenFile(destPath, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0o600)
+	if err != nil {
+		return fmt.Errorf("backup: create destination: %w", err)
+	}
+	defer func() {
+		if cerr := destFile.Close(); cerr != nil && retErr == nil {
+			retErr = fmt.Errorf("backup: close destination: %w", cerr)
+		}
+		if retErr != nil {
+			os.Remove(destPath)
+		}
+	}()
+
+	if err := d.BackupTo(ctx, destFile); err != nil {
+		return err
+	}
+	if err := destFile.Sync(); err != nil {
+		return fmt.Errorf("backup: sync destination: %w", err)
+	}
+
+	return nil
+}
+
+// BackupTo streams a consistent, point-in-time copy of the database to w,
+// page by page in file order.
 //
 // The algorithm mirrors SQLite's WAL-mode online backup:
 //
@@ -30,14 +72,18 @@ import (
 //     Because checkpoints are blocked by step 1, the DB file cannot be
 //     modified by post-snapshot transactions during this phase.
 //
-//  5. Release the read-only snapshot, sync, and close the destination.
+//  5. Release the read-only snapshot.
 //
-// The destination is a standalone database file that can be opened directly
-// with sql.Open("minisql", destPath).  It carries no WAL file.  If the source
-// is encrypted the backup is encrypted with the same key.
+// The bytes written to w form a standalone database file: written to disk
+// they can be opened with NewPager or sql.Open.  If the source is encrypted
+// the copy is encrypted with the same key.  Transactions committed after the
+// snapshot in step 2 are not included.
 //
-// Backup must not be called from inside an explicit user transaction.
-func (d *Database) Backup(_ context.Context, destPath string) (retErr error) {
+// BackupTo must not be called from inside an explicit user transaction.
+func (d *Database) BackupTo(_ context.Context, w io.Writer) error {
+	if d.InMemory() {
+		return fmt.Errorf("backup: %w", ErrInMemoryDatabase)
+	}
 	if d.walDBFile == nil || d.walIndex == nil {
 		return fmt.Errorf("backup: database is not in WAL mode")
 	}
@@ -45,7 +91,7 @@ func (d *Database) Backup(_ context.Context, destPath string) (retErr error) {
 	// --- PHASE 1: Begin a read-only snapshot to block checkpoints. ---
 	// A checkpoint that starts after this point will see an active snapshot
 	// reader and return ErrCheckpointBlockedByReaders without modifying the DB
-	// file.  This guarantees that all ReadAt calls in phase 4 see DB file pages
+	// file.  This guarantees that all ReadAt calls in phase 3 see DB file pages
 	// at their pre-snapshot versions, even if writers commit and trigger
 	// auto-checkpoint attempts during the copy.
 	snapCtx := context.Background()
@@ -73,48 +119,33 @@ func (d *Database) Backup(_ context.Context, destPath string) (retErr error) {
 		return fmt.Errorf("backup: database is empty")
 	}
 
-	// --- PHASE 3: Open destination file. ---
-	destFile, err := os.OpenFile(destPath, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0o600)
-	if err != nil {
-		return fmt.Errorf("backup: create destination: %w", err)
-	}
-	defer func() {
-		if cerr := destFile.Close(); cerr != nil && retErr == nil {
-			retErr = fmt.Errorf("backup: close destination: %w", cerr)
-		}
-	}()
-
 	// backupHook is nil in production; tests inject concurrent operations here
 	// to verify the checkpoint-blocking invariant.
 	if d.backupHook != nil {
 		d.backupHook()
 	}
 
-	// --- PHASE 4: Copy pages (writers unblocked, checkpoints blocked). ---
+	// --- PHASE 3: Copy pages (writers unblocked, checkpoints blocked). ---
 	// WAL snapshot bytes take priority.  Pages absent from the snapshot are read
 	// from the DB file — safe because no checkpoint can flush post-snapshot WAL
-	// frames to the DB file while the snapshot transaction is active.
+	// frames to the DB file while the snapshot transaction is active.  Pages
+	// are written in order, so w need not support seeking.
 	readBuf := make([]byte, PageSize)
 	for idx := PageIndex(0); idx < PageIndex(totalPages); idx++ {
-		offset := int64(idx) * int64(PageSize)
 		if data, ok := walMap[idx]; ok {
-			if _, err = destFile.WriteAt(data, offset); err != nil {
+			if _, err := w.Write(data); err != nil {
 				return fmt.Errorf("backup: write WAL page %d: %w", idx, err)
 			}
-		} else {
-			if _, err = d.walDBFile.ReadAt(readBuf, offset); err != nil {
-				return fmt.Errorf("backup: read DB page %d: %w", idx, err)
-			}
-			if _, err = destFile.WriteAt(readBuf, offset); err != nil {
-				return fmt.Errorf("backup: write DB page %d: %w", idx, err)
-			}
+			continue
+		}
+		if _, err := d.walDBFile.ReadAt(readBuf, int64(idx)*int64(PageSize)); err != nil {
+			return fmt.Errorf("backup: read DB page %d: %w", idx, err)
+		}
+		if _, err := w.Write(readBuf); err != nil {
+			return fmt.Errorf("backup: write DB page %d: %w", idx, err)
 		}
 	}
 
-	// --- PHASE 5: Sync destination and release snapshot (deferred above). ---
-	if err = destFile.Sync(); err != nil {
-		return fmt.Errorf("backup: sync destination: %w", err)
-	}
-
+	// --- PHASE 4: Release the snapshot (deferred above). ---
 	return nil
 }
@@ -1,6 +1,7 @@
 package minisql
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"os"
@@ -213,3 +214,53 @@ func TestBackup_CheckpointBlockedDuringCopy(t *testing.T) {
 	assert.Equal(t, 15, backupCount,
 		"backup should contain exactly 15 rows (10 checkpointed + 5 from WAL snapshot), not the 5 post-snapshot rows written during the hook")
 }
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) { return 0, errors.New("disk full") }
+
+func TestBackupTo_StreamsStandaloneFile(t *testing.T) {
+	ctx := context.Background()
+	db, dbPath := newBackupTestDB(t)
+
+	createStmt := Statement{
+		Kind:      CreateTable,
+		TableName: "items",
+		Columns:   append([]Column{}, backupColumns...),
+	}
+	err := db.txManager.ExecuteInTransaction(ctx, func(txCtx context.Context) error {
+		_, err := db.ExecuteStatement(txCtx, createStmt)
+		return err
+	})
+	require.NoError(t, err)
+	tbl := db.tables["items"]
+	insertBackupRows(t, db, tbl, 10, 1)
+	require.NoError(t, db.Checkpoint(ctx))
+	insertBackupRows(t, db, tbl, 5, 100)
+
+	var buf bytes.Buffer
+	require.NoError(t, db.BackupTo(ctx, &buf))
+	assert.Equal(t, int(db.saver.TotalPages())*PageSize, buf.Len())
+
+	// A failing writer aborts the copy and still releases the snapshot.
+	require.ErrorContains(t, db.BackupTo(ctx, failingWriter{}), "disk full")
+	assert.False(t, db.txManager.hasActiveTransactions())
+
+	destPath := dbPath + ".stream"
+	t.Cleanup(func() { os.Remove(destPath) })
+	require.NoError(t, os.WriteFile(destPath, buf.Bytes(), 0o600))
+
+	backupParser := new(MockParser)
+	backupParser.On("Parse", mock.Anything, createStmt.DDL()).Return([]Statement{createStmt}, nil)
+	backupFile, err := os.OpenFile(destPath, os.O_RDWR, 0o600)
+	require.NoError(t, err)
+	backupPager, err := NewPager(backupFile, PageSize, PageCacheSize)
+	require.NoError(t, err)
+	backupDB, err := NewDatabase(ctx, testLogger, destPath, backupParser, backupPager, backupPager, nil)
+	require.NoError(t, err)
+	defer func() { require.NoError(t, backupDB.Close()) }()
+
+	backupTbl, ok := backupDB.tables["items"]
+	require.True(t, ok, "backup database must have the 'items' table")
+	assert.Equal(t, 15, countBackupRows(t, backupDB, backupTbl))
+}
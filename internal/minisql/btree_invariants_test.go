@@ -157,6 +157,11 @@ func assertTableBTreeInvariants(t *testing.T, pager *pagerImpl, table *Table) {
 		page := pager.pages[leafIdx]
 		require.NotNil(t, page)
 		require.NotNil(t, page.LeafNode)
+		if i == 0 {
+			assert.Equal(t, 0, int(page.LeafNode.Header.PrevLeaf), "first leaf should terminate the reverse chain")
+		} else {
+			assert.Equal(t, state.leaves[i-1], page.LeafNode.Header.PrevLeaf, "reverse leaf chain must follow in-order traversal")
+		}
 		if i == len(state.leaves)-1 {
 			assert.Equal(t, 0, int(page.LeafNode.Header.NextLeaf), "last leaf should terminate the chain")
 			continue
@@ -0,0 +1,368 @@
+package minisql
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Errors returned by the statement builders from Build.
+var (
+	ErrBuilderNoTable     = errors.New("builder: table name is required")
+	ErrBuilderNoColumns   = errors.New("builder: at least one column is required")
+	ErrBuilderNoValues    = errors.New("builder: at least one row of values is required")
+	ErrBuilderValueCount  = errors.New("builder: number of values does not match number of columns")
+	ErrBuilderNoUpdates   = errors.New("builder: at least one SET column is required")
+	ErrBuilderInvalidSize = errors.New("builder: LIMIT and OFFSET must not be negative")
+)
+
+// The builders below construct Statement values without going through the
+// SQL parser. They produce the same Statement the parser would produce for
+// the equivalent SQL, so the result can be passed straight to
+// Database.ExecuteStatement. The constructor names differ from the SQL verbs
+// because Insert, Select, Update and Delete are already StatementKind values.
+//
+// Values are converted the way the parser represents literals: strings and
+// byte slices become TextPointer, every Go integer type becomes int64,
+// float32 becomes float64 and nil becomes a NULL OptionalValue. Any other
+// value (Placeholder, TextPointer, Function, ...) is stored unchanged. The
+// same conversion applies to the values of Where and OrWhere conditions.
+
+// InsertBuilder builds an INSERT statement.
+type InsertBuilder struct {
+	stmt Statement
+	err  error
+}
+
+// InsertInto starts an INSERT INTO table statement.
+func InsertInto(table string) *InsertBuilder {
+	return &InsertBuilder{stmt: Statement{Kind: Insert, TableName: table}}
+}
+
+// Columns sets the target columns, in the order values are given.
+func (b *InsertBuilder) Columns(names ...string) *InsertBuilder {
+	b.stmt.Fields = builderFields(names)
+	return b
+}
+
+// Values appends one row of values. The number of values must match the
+// number of columns.
+func (b *InsertBuilder) Values(values ...any) *InsertBuilder {
+	if b.err != nil {
+		return b
+	}
+	row, err := builderValues(values)
+	if err != nil {
+		b.err = err
+		return b
+	}
+	b.stmt.Inserts = append(b.stmt.Inserts, row)
+	return b
+}
+
+// Build returns the INSERT statement or the first error encountered.
+func (b *InsertBuilder) Build() (Statement, error) {
+	if b.err != nil {
+		return Statement{}, b.err
+	}
+	if b.stmt.TableName == "" {
+		return Statement{}, ErrBuilderNoTable
+	}
+	if len(b.stmt.Fields) == 0 {
+		return Statement{}, ErrBuilderNoColumns
+	}
+	if len(b.stmt.Inserts) == 0 {
+		return Statement{}, ErrBuilderNoValues
+	}
+	for i, row := range b.stmt.Inserts {
+		if len(row) != len(b.stmt.Fields) {
+			return Statement{}, fmt.Errorf("%w: row %d has %d values, expected %d", ErrBuilderValueCount, i, len(row), len(b.stmt.Fields))
+		}
+	}
+	return b.stmt, nil
+}
+
+// SelectBuilder builds a SELECT statement.
+type SelectBuilder struct {
+	stmt Statement
+	err  error
+}
+
+// SelectFrom starts a SELECT … FROM table statement. Without a call to
+// Columns every column is selected.
+func SelectFrom(table string) *SelectBuilder {
+	return &SelectBuilder{stmt: Statement{Kind: Select, TableName: table}}
+}
+
+// Columns sets the projected columns.
+func (b *SelectBuilder) Columns(names ...string) *SelectBuilder {
+	b.stmt.Fields = builderFields(names)
+	return b
+}
+
+// Where adds conditions joined with AND to the current condition group.
+func (b *SelectBuilder) Where(conditions ...Condition) *SelectBuilder {
+	b.stmt.Conditions = builderWhere(b.stmt.Conditions, conditions)
+	return b
+}
+
+// OrWhere starts a new condition group joined to the previous ones with OR.
+func (b *SelectBuilder) OrWhere(conditions ...Condition) *SelectBuilder {
+	b.stmt.Conditions = builderOrWhere(b.stmt.Conditions, conditions)
+	return b
+}
+
+// OrderBy appends a sort key.
+func (b *SelectBuilder) OrderBy(column string, direction Direction) *SelectBuilder {
+	b.stmt.OrderBy = append(b.stmt.OrderBy, OrderBy{Field: Field{Name: column}, Direction: direction})
+	return b
+}
+
+// Limit sets the maximum number of rows returned.
+func (b *SelectBuilder) Limit(n int64) *SelectBuilder {
+	if n < 0 && b.err == nil {
+		b.err = ErrBuilderInvalidSize
+	}
+	b.stmt.Limit = OptionalValue{Value: n, Valid: true}
+	return b
+}
+
+// Offset sets the number of rows skipped before the first returned row.
+func (b *SelectBuilder) Offset(n int64) *SelectBuilder {
+	if n < 0 && b.err == nil {
+		b.err = ErrBuilderInvalidSize
+	}
+	b.stmt.Offset = OptionalValue{Value: n, Valid: true}
+	return b
+}
+
+// Build returns the SELECT statement or the first error encountered.
+func (b *SelectBuilder) Build() (Statement, error) {
+	if b.err != nil {
+		return Statement{}, b.err
+	}
+	if b.stmt.TableName == "" {
+		return Statement{}, ErrBuilderNoTable
+	}
+	stmt := b.stmt
+	if len(stmt.Fields) == 0 {
+		stmt.Fields = []Field{{Name: "*"}}
+	}
+	return stmt, nil
+}
+
+// UpdateBuilder builds an UPDATE statement.
+type UpdateBuilder struct {
+	stmt Statement
+	err  error
+}
+
+// UpdateTable starts an UPDATE table statement.
+func UpdateTable(table string) *UpdateBuilder {
+	return &UpdateBuilder{stmt: Statement{Kind: Update, TableName: table}}
+}
+
+// Set assigns value to column. Setting the same column twice keeps the last
+// value.
+func (b *UpdateBuilder) Set(column string, value any) *UpdateBuilder {
+	if b.err != nil {
+		return b
+	}
+	val, err := builderValue(value)
+	if err != nil {
+		b.err = err
+		return b
+	}
+	if b.stmt.Updates == nil {
+		b.stmt.Updates = make(map[string]OptionalValue)
+	}
+	if _, ok := b.stmt.Updates[column]; !ok {
+		b.stmt.Fields = append(b.stmt.Fields, Field{Name: column})
+	}
+	b.stmt.Updates[column] = val
+	return b
+}
+
+// Where adds conditions joined with AND to the current condition group.
+func (b *UpdateBuilder) Where(conditions ...Condition) *UpdateBuilder {
+	b.stmt.Conditions = builderWhere(b.stmt.Conditions, conditions)
+	return b
+}
+
+// OrWhere starts a new condition group joined to the previous ones with OR.
+func (b *UpdateBuilder) OrWhere(conditions ...Condition) *UpdateBuilder {
+	b.stmt.Conditions = builderOrWhere(b.stmt.Conditions, conditions)
+	return b
+}
+
+// ReturningKeys asks the UPDATE to report the key of every modified row in
+// StatementResult.AffectedKeys.
+func (b *UpdateBuilder) ReturningKeys() *UpdateBuilder {
+	b.stmt.ReturnAffectedKeys = true
+	return b
+}
+
+// Build returns the UPDATE statement or the first error encountered.
+func (b *UpdateBuilder) Build() (Statement, error) {
+	if b.err != nil {
+		return Statement{}, b.err
+	}
+	if b.stmt.TableName == "" {
+		return Statement{}, ErrBuilderNoTable
+	}
+	if len(b.stmt.Updates) == 0 {
+		return Statement{}, ErrBuilderNoUpdates
+	}
+	return b.stmt, nil
+}
+
+// DeleteBuilder builds a DELETE statement.
+type DeleteBuilder struct {
+	stmt Statement
+}
+
+// DeleteFrom starts a DELETE FROM table statement.
+func DeleteFrom(table string) *DeleteBuilder {
+	return &DeleteBuilder{stmt: Statement{Kind: Delete, TableName: table}}
+}
+
+// Where adds conditions joined with AND to the current condition group.
+func (b *DeleteBuilder) Where(conditions ...Condition) *DeleteBuilder {
+	b.stmt.Conditions = builderWhere(b.stmt.Conditions, conditions)
+	return b
+}
+
+// OrWhere starts a new condition group joined to the previous ones with OR.
+func (b *DeleteBuilder) OrWhere(conditions ...Condition) *DeleteBuilder {
+	b.stmt.Conditions = builderOrWhere(b.stmt.Conditions, conditions)
+	return b
+}
+
+// ReturningKeys asks the DELETE to report the key of every removed row in
+// StatementResult.AffectedKeys.
+func (b *DeleteBuilder) ReturningKeys() *DeleteBuilder {
+	b.stmt.ReturnAffectedKeys = true
+	return b
+}
+
+// Build returns the DELETE statement.
+func (b *DeleteBuilder) Build() (Statement, error) {
+	if b.stmt.TableName == "" {
+		return Statement{}, ErrBuilderNoTable
+	}
+	return b.stmt, nil
+}
+
+func builderFields(names []string) []Field {
+	fields := make([]Field, 0, len(names))
+	for _, name := range names {
+		fields = append(fields, Field{Name: name})
+	}
+	return fields
+}
+
+func builderWhere(existing OneOrMore, conditions []Condition) OneOrMore {
+	if len(conditions) == 0 {
+		return existing
+	}
+	if len(existing) == 0 {
+		return OneOrMore{builderConditions(conditions)}
+	}
+	last := len(existing) - 1
+	existing[last] = append(existing[last], builderConditions(conditions)...)
+	return existing
+}
+
+func builderOrWhere(existing OneOrMore, conditions []Condition) OneOrMore {
+	if len(conditions) == 0 {
+		return existing
+	}
+	return append(existing, builderConditions(conditions))
+}
+
+// builderConditions copies conditions, converting the literal values on the
+// right-hand side like builderValue so that, for example, a Go string
+// compares as a quoted string.
+func builderConditions(conditions []Condition) Conditions {
+	converted := make(Conditions, 0, len(conditions))
+	for _, cond := range conditions {
+		switch cond.Operand2.Type {
+		case OperandQuotedString, OperandInteger, OperandFloat:
+			cond.Operand2.Value = builderOperandValue(cond.Operand2.Value)
+		case OperandList:
+			if values, ok := cond.Operand2.Value.([]any); ok {
+				list := make([]any, len(values))
+				for i, value := range values {
+					list[i] = builderOperandValue(value)
+				}
+				cond.Operand2.Value = list
+			}
+		}
+		converted = append(converted, cond)
+	}
+	return converted
+}
+
+// builderOperandValue converts a condition value with builderValue. A value
+// builderValue rejects is kept and fails validation when the statement runs.
+func builderOperandValue(value any) any {
+	val, err := builderValue(value)
+	if err != nil || !val.Valid {
+		return value
+	}
+	return val.Value
+}
+
+func builderValues(values []any) ([]OptionalValue, error) {
+	row := make([]OptionalValue, 0, len(values))
+	for _, value := range values {
+		val, err := builderValue(value)
+		if err != nil {
+			return nil, err
+		}
+		row = append(row, val)
+	}
+	return row, nil
+}
+
+// builderValue converts a Go value into the OptionalValue form produced by
+// the parser for the equivalent literal.
+func builderValue(value any) (OptionalValue, error) {
+	switch v := value.(type) {
+	case nil:
+		return OptionalValue{}, nil
+	case string:
+		return OptionalValue{Value: NewTextPointer([]byte(v)), Valid: true}, nil
+	case []byte:
+		return OptionalValue{Value: NewTextPointer(v), Valid: true}, nil
+	case int:
+		return OptionalValue{Value: int64(v), Valid: true}, nil
+	case int8:
+		return OptionalValue{Value: int64(v), Valid: true}, nil
+	case int16:
+		return OptionalValue{Value: int64(v), Valid: true}, nil
+	case int32:
+		return OptionalValue{Value: int64(v), Valid: true}, nil
+	case int64:
+		return OptionalValue{Value: v, Valid: true}, nil
+	case uint8:
+		return OptionalValue{Value: int64(v), Valid: true}, nil
+	case uint16:
+		return OptionalValue{Value: int64(v), Valid: true}, nil
+	case uint32:
+		return OptionalValue{Value: int64(v), Valid: true}, nil
+	case uint:
+		if uint64(v) > 1<<63-1 {
+			return OptionalValue{}, fmt.Errorf("builder: value %d overflows int64", v)
+		}
+		return OptionalValue{Value: int64(v), Valid: true}, nil
+	case uint64:
+		if v > 1<<63-1 {
+			return OptionalValue{}, fmt.Errorf("builder: value %d overflows int64", v)
+		}
+		return OptionalValue{Value: int64(v), Valid: true}, nil
+	case float32:
+		return OptionalValue{Value: float64(v), Valid: true}, nil
+	default:
+		return OptionalValue{Value: value, Valid: true}, nil
+	}
+}
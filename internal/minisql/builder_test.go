@@ -0,0 +1,212 @@
+package minisql
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInsertBuilder(t *testing.T) {
+	t.Parallel()
+
+	t.Run("multiple rows", func(t *testing.T) {
+		stmt, err := InsertInto("users").
+			Columns("id", "email", "age").
+			Values(1, "a@example.com", nil).
+			Values(int32(2), []byte("b@example.com"), 3.5).
+			Build()
+		require.NoError(t, err)
+
+		assert.Equal(t, Statement{
+			Kind:      Insert,
+			TableName: "users",
+			Fields:    []Field{{Name: "id"}, {Name: "email"}, {Name: "age"}},
+			Inserts: [][]OptionalValue{
+				{
+					{Value: int64(1), Valid: true},
+					{Value: NewTextPointer([]byte("a@example.com")), Valid: true},
+					{},
+				},
+				{
+					{Value: int64(2), Valid: true},
+					{Value: NewTextPointer([]byte("b@example.com")), Valid: true},
+					{Value: 3.5, Valid: true},
+				},
+			},
+		}, stmt)
+	})
+
+	t.Run("placeholders", func(t *testing.T) {
+		stmt, err := InsertInto("users").Columns("id").Values(Placeholder{}).Build()
+		require.NoError(t, err)
+		assert.Equal(t, [][]OptionalValue{{{Value: Placeholder{}, Valid: true}}}, stmt.Inserts)
+		assert.Equal(t, 1, stmt.NumPlaceholders())
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		_, err := InsertInto("").Columns("id").Values(1).Build()
+		assert.ErrorIs(t, err, ErrBuilderNoTable)
+
+		_, err = InsertInto("users").Values(1).Build()
+		assert.ErrorIs(t, err, ErrBuilderNoColumns)
+
+		_, err = InsertInto("users").Columns("id").Build()
+		assert.ErrorIs(t, err, ErrBuilderNoValues)
+
+		_, err = InsertInto("users").Columns("id", "email").Values(1, "a").Values(2).Build()
+		assert.ErrorIs(t, err, ErrBuilderValueCount)
+
+		_, err = InsertInto("users").Columns("id").Values(uint64(1 << 63)).Build()
+		assert.ErrorContains(t, err, "overflows int64")
+	})
+}
+
+func TestSelectBuilder(t *testing.T) {
+	t.Parallel()
+
+	stmt, err := SelectFrom("users").
+		Columns("id", "email").
+		Where(FieldIsGreater(Field{Name: "id"}, OperandInteger, int64(10))).
+		Where(FieldIsNotNull(Field{Name: "email"})).
+		OrWhere(FieldIsEqual(Field{Name: "id"}, OperandInteger, int64(1))).
+		OrderBy("id", Desc).
+		Limit(5).
+		Offset(2).
+		Build()
+	require.NoError(t, err)
+
+	assert.Equal(t, Statement{
+		Kind:      Select,
+		TableName: "users",
+		Fields:    []Field{{Name: "id"}, {Name: "email"}},
+		Conditions: OneOrMore{
+			{
+				FieldIsGreater(Field{Name: "id"}, OperandInteger, int64(10)),
+				FieldIsNotNull(Field{Name: "email"}),
+			},
+			{
+				FieldIsEqual(Field{Name: "id"}, OperandInteger, int64(1)),
+			},
+		},
+		OrderBy: []OrderBy{{Field: Field{Name: "id"}, Direction: Desc}},
+		Limit:   OptionalValue{Value: int64(5), Valid: true},
+		Offset:  OptionalValue{Value: int64(2), Valid: true},
+	}, stmt)
+
+	stmt, err = SelectFrom("users").Build()
+	require.NoError(t, err)
+	assert.True(t, stmt.IsSelectAll())
+
+	_, err = SelectFrom("users").Limit(-1).Build()
+	assert.ErrorIs(t, err, ErrBuilderInvalidSize)
+
+	stmt, err = SelectFrom("users").
+		Where(FieldIsEqual(Field{Name: "email"}, OperandQuotedString, "a@example.com")).
+		OrWhere(FieldIsBetween(Field{Name: "id"}, 1, uint8(5))).
+		Build()
+	require.NoError(t, err)
+	assert.Equal(t, OneOrMore{
+		{FieldIsEqual(Field{Name: "email"}, OperandQuotedString, NewTextPointer([]byte("a@example.com")))},
+		{FieldIsBetween(Field{Name: "id"}, int64(1), int64(5))},
+	}, stmt.Conditions)
+}
+
+func TestUpdateBuilder(t *testing.T) {
+	t.Parallel()
+
+	stmt, err := UpdateTable("users").
+		Set("email", "new@example.com").
+		Set("age", nil).
+		Set("email", "newer@example.com").
+		Where(FieldIsEqual(Field{Name: "id"}, OperandInteger, int64(1))).
+		Build()
+	require.NoError(t, err)
+
+	assert.Equal(t, Statement{
+		Kind:      Update,
+		TableName: "users",
+		Fields:    []Field{{Name: "email"}, {Name: "age"}},
+		Updates: map[string]OptionalValue{
+			"email": {Value: NewTextPointer([]byte("newer@example.com")), Valid: true},
+			"age":   {},
+		},
+		Conditions: OneOrMore{{FieldIsEqual(Field{Name: "id"}, OperandInteger, int64(1))}},
+	}, stmt)
+
+	_, err = UpdateTable("users").Build()
+	assert.ErrorIs(t, err, ErrBuilderNoUpdates)
+}
+
+func TestDeleteBuilder(t *testing.T) {
+	t.Parallel()
+
+	stmt, err := DeleteFrom("users").Where(FieldIsNull(Field{Name: "email"})).Build()
+	require.NoError(t, err)
+	assert.Equal(t, Statement{
+		Kind:       Delete,
+		TableName:  "users",
+		Conditions: OneOrMore{{FieldIsNull(Field{Name: "email"})}},
+	}, stmt)
+
+	stmt, err = DeleteFrom("users").Build()
+	require.NoError(t, err)
+	assert.Equal(t, Statement{Kind: Delete, TableName: "users"}, stmt)
+}
+
+func TestBuilder_Execute(t *testing.T) {
+	t.Parallel()
+	const tableName = "items"
+	createStmt := vacuumCreateStmt(tableName)
+
+	mockParser := new(MockParser)
+	mockParser.On("Parse", mock.Anything, createStmt.DDL()).Return([]Statement{createStmt}, nil)
+
+	db, _ := newVacuumTestDB(t, mockParser)
+	execInTx(t, db, func(ctx context.Context) {
+		_, err := db.ExecuteStatement(ctx, createStmt)
+		require.NoError(t, err)
+	})
+
+	insert, err := InsertInto(tableName).Columns("id", "name").Values(1, "a").Values(2, "b").Values(3, nil).Build()
+	require.NoError(t, err)
+	execInTx(t, db, func(ctx context.Context) {
+		result, err := db.ExecuteStatement(ctx, insert)
+		require.NoError(t, err)
+		assert.Equal(t, 3, result.RowsAffected)
+	})
+
+	update, err := UpdateTable(tableName).Set("name", "c").Where(FieldIsNull(Field{Name: "name"})).Build()
+	require.NoError(t, err)
+	execInTx(t, db, func(ctx context.Context) {
+		result, err := db.ExecuteStatement(ctx, update)
+		require.NoError(t, err)
+		assert.Equal(t, 1, result.RowsAffected)
+	})
+
+	del, err := DeleteFrom(tableName).Where(FieldIsEqual(Field{Name: "id"}, OperandInteger, int64(1))).Build()
+	require.NoError(t, err)
+	execInTx(t, db, func(ctx context.Context) {
+		result, err := db.ExecuteStatement(ctx, del)
+		require.NoError(t, err)
+		assert.Equal(t, 1, result.RowsAffected)
+	})
+
+	sel, err := SelectFrom(tableName).Columns("id", "name").OrderBy("id", Desc).Build()
+	require.NoError(t, err)
+	var got []string
+	execInTx(t, db, func(ctx context.Context) {
+		result, err := db.ExecuteStatement(ctx, sel)
+		require.NoError(t, err)
+		for result.Rows.Next(ctx) {
+			row := result.Rows.Row()
+			name, ok := row.GetValue("name")
+			require.True(t, ok)
+			got = append(got, name.Value.(TextPointer).String())
+		}
+		require.NoError(t, result.Rows.Err())
+	})
+	assert.Equal(t, []string{"c", "b"}, got)
+}
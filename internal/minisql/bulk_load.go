@@ -0,0 +1,371 @@
+package minisql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.uber.org/zap"
+)
+
+// BulkLoad inserts rows received from the channel until it is closed and
+// returns the number of rows inserted. Each row holds one value per table
+// column, in table column order, with values already of the column types
+// (text as TextPointer, timestamps as TimestampMicros, and so on). Unlike
+// Insert, rows are not type-checked and defaults are not applied, so callers
+// must only feed trusted, fully populated rows. Index constraints, CHECK
+// constraints and foreign keys are still enforced.
+//
+// When the table is empty and rows arrive in ascending primary key order,
+// the row B+ tree is built bottom-up: leaves are packed full one after
+// another and the internal levels are written once every row is in, instead
+// of descending the tree and splitting pages for every row. As soon as a key
+// arrives out of order, the tree built so far is finished and the remaining
+// rows go through Insert. Tables that already hold rows always use Insert.
+//
+// BulkLoad must run inside a write transaction. If it returns an error the
+// caller should cancel ctx so the producer stops sending, and roll back.
+func (t *Table) BulkLoad(ctx context.Context, rows <-chan []OptionalValue) (int64, error) {
+	rootPage, err := t.pager.ReadPage(ctx, t.GetRootPageIdx())
+	if err != nil {
+		return 0, fmt.Errorf("bulk load: %w", err)
+	}
+	if rootPage.LeafNode == nil || rootPage.LeafNode.Header.Cells > 0 {
+		return t.bulkInsert(ctx, rows, 0)
+	}
+
+	loader := &bulkLoader{
+		table:  t,
+		cursor: &Cursor{Table: t},
+		leaf:   t.newBulkLeaf(),
+	}
+	// An empty table hands out row IDs from zero, as SeekNextRowID does.
+	var rowID RowID
+	for {
+		values, ok, err := receiveBulkRow(ctx, rows)
+		if err != nil {
+			return 0, err
+		}
+		if !ok {
+			break
+		}
+		inOrder, err := loader.add(ctx, values, rowID)
+		if err != nil {
+			return 0, fmt.Errorf("bulk load row %d: %w", loader.rows+1, err)
+		}
+		rowID += 1
+		if !inOrder {
+			if ce := t.logger.Check(zap.DebugLevel, "bulk load keys out of order, falling back to insert"); ce != nil {
+				ce.Write(zap.String("table", t.Name), zap.Int64("rows_loaded", loader.rows))
+			}
+			if err := loader.finish(ctx); err != nil {
+				return 0, err
+			}
+			return t.bulkInsert(ctx, rows, loader.rows)
+		}
+	}
+
+	if err := loader.finish(ctx); err != nil {
+		return 0, err
+	}
+	return loader.rows, nil
+}
+
+// bulkInsert inserts every row left in the channel through Insert. loaded
+// is the number of rows BulkLoad already wrote and is included in the
+// returned count.
+func (t *Table) bulkInsert(ctx context.Context, rows <-chan []OptionalValue, loaded int64) (int64, error) {
+	stmt := Statement{
+		Kind:    Insert,
+		Fields:  fieldsFromColumns(t.Columns...),
+		Inserts: make([][]OptionalValue, 1),
+	}
+	for {
+		values, ok, err := receiveBulkRow(ctx, rows)
+		if err != nil {
+			return 0, err
+		}
+		if !ok {
+			return loaded, nil
+		}
+		if len(values) != len(t.Columns) {
+			return 0, fmt.Errorf("bulk load row %d: expected %d values, got %d", loaded+1, len(t.Columns), len(values))
+		}
+		stmt.Inserts[0] = values
+		if _, err := t.Insert(ctx, stmt); err != nil {
+			return 0, fmt.Errorf("bulk load row %d: %w", loaded+1, err)
+		}
+		loaded += 1
+	}
+}
+
+// receiveBulkRow returns the next row from the channel, false once the
+// channel is closed, or the context error if ctx is done first.
+func receiveBulkRow(ctx context.Context, rows <-chan []OptionalValue) ([]OptionalValue, bool, error) {
+	select {
+	case <-ctx.Done():
+		return nil, false, ctx.Err()
+	case values, ok := <-rows:
+		return values, ok, nil
+	}
+}
+
+// bulkLeafRef is a finished node of a bulk-loaded tree together with the
+// greatest row ID below it, which becomes its separator key in the parent.
+type bulkLeafRef struct {
+	pageIdx PageIndex
+	maxKey  RowID
+}
+
+// bulkLoader packs rows into leaves for BulkLoad. The leaf being filled is
+// kept in memory and only gets a page once it is full, so a load that fits
+// into a single leaf ends up in the root page without allocating anything.
+type bulkLoader struct {
+	table  *Table
+	cursor *Cursor
+	leaf   *LeafNode
+	leaves []bulkLeafRef
+	// lastKey is the primary key of the previous row, nil before the first.
+	lastKey any
+	rows    int64
+}
+
+func (t *Table) newBulkLeaf() *LeafNode {
+	leaf := NewLeafNode()
+	leaf.Header.Hint = newPageHint(t.Columns)
+	return leaf
+}
+
+// add maintains the indexes for a row, checks its constraints and appends it
+// to the current leaf. It reports false when the row's primary key is not
+// greater than the previous row's; the row itself is still stored.
+func (l *bulkLoader) add(ctx context.Context, values []OptionalValue, rowID RowID) (bool, error) {
+	t := l.table
+	if len(values) != len(t.Columns) {
+		return false, fmt.Errorf("expected %d values, got %d", len(t.Columns), len(values))
+	}
+
+	row := NewRowWithValues(t.Columns, values)
+	inOrder := true
+	if t.HasPrimaryKey() {
+		keyParts, ok := row.GetValuesForColumns(t.PrimaryKey.Columns)
+		if !ok {
+			return false, fmt.Errorf("failed to get value for primary key %s", t.PrimaryKey.Name)
+		}
+		insertedPrimaryKey, err := t.insertPrimaryKey(ctx, keyParts, rowID)
+		if err != nil {
+			return false, err
+		}
+		if len(t.PrimaryKey.Columns) == 1 {
+			// Store the autoincremented primary key value with the row.
+			_, pkIdx := row.GetColumn(t.PrimaryKey.Columns[0].Name)
+			values[pkIdx] = OptionalValue{Value: insertedPrimaryKey, Valid: true}
+		}
+		if l.lastKey != nil && compareAny(insertedPrimaryKey, l.lastKey) <= 0 {
+			inOrder = false
+		}
+		l.lastKey = insertedPrimaryKey
+	}
+
+	for _, uniqueIndex := range t.UniqueIndexes {
+		keyParts, ok := row.GetValuesForColumns(uniqueIndex.Columns)
+		if !ok {
+			return false, fmt.Errorf("failed to get value for unique index %s", uniqueIndex.Name)
+		}
+		if err := t.insertUniqueIndexKey(ctx, uniqueIndex, keyParts, rowID); err != nil {
+			return false, err
+		}
+	}
+
+	for _, secondaryIndex := range t.SecondaryIndexes {
+		var keyParts []OptionalValue
+		if secondaryIndex.Expression == nil {
+			var ok bool
+			keyParts, ok = row.GetValuesForColumns(secondaryIndex.Columns)
+			if !ok {
+				return false, fmt.Errorf("failed to get value for secondary index %s", secondaryIndex.Name)
+			}
+		}
+		if err := t.insertSecondaryIndexKey(ctx, secondaryIndex, keyParts, rowID, row); err != nil {
+			return false, err
+		}
+	}
+
+	// Normalise JSON columns to compact form before writing.
+	for i, col := range t.Columns {
+		if col.Kind != JSON || !values[i].Valid {
+			continue
+		}
+		tp, ok := values[i].Value.(TextPointer)
+		if !ok {
+			continue
+		}
+		normalised, err := normaliseJSON(tp.String())
+		if err != nil {
+			return false, fmt.Errorf("column %q: %w", col.Name, err)
+		}
+		values[i].Value = NewTextPointer([]byte(normalised))
+	}
+
+	if err := validateCheckConstraints(t.Columns, row); err != nil {
+		return false, err
+	}
+	if t.checkChildFK != nil {
+		if err := t.checkChildFK(ctx, row); err != nil {
+			return false, err
+		}
+	}
+
+	if !l.leaf.HasSpaceForRow(row) && l.leaf.Header.Cells > 0 {
+		if err := l.flushLeaf(ctx); err != nil {
+			return false, err
+		}
+	}
+	if err := l.cursor.saveToCell(ctx, l.leaf, l.leaf.Header.Cells, rowID, row); err != nil {
+		return false, err
+	}
+	l.leaf.Header.Cells += 1
+	l.rows += 1
+
+	return inOrder, nil
+}
+
+// flushLeaf writes the full in-memory leaf to a new page, links it after the
+// previously written leaf and starts a new one.
+func (l *bulkLoader) flushLeaf(ctx context.Context) error {
+	pager := l.table.pager
+	page, err := pager.GetFreePage(ctx)
+	if err != nil {
+		return fmt.Errorf("bulk load: get leaf page: %w", err)
+	}
+	page.LeafNode = l.leaf
+
+	if n := len(l.leaves); n > 0 {
+		prevPage, err := pager.ModifyPage(ctx, l.leaves[n-1].pageIdx)
+		if err != nil {
+			return fmt.Errorf("bulk load: get previous leaf: %w", err)
+		}
+		prevPage.LeafNode.Header.NextLeaf = page.Index
+		page.LeafNode.Header.PrevLeaf = prevPage.Index
+	}
+
+	l.leaves = append(l.leaves, bulkLeafRef{
+		pageIdx: page.Index,
+		maxKey:  l.leaf.Cells[l.leaf.Header.Cells-1].Key,
+	})
+	l.leaf = l.table.newBulkLeaf()
+	return nil
+}
+
+// finish writes the last leaf and builds the internal levels above the
+// leaves, from the bottom up, ending in the table's root page. It also
+// records the row count and the autoincrement counter.
+func (l *bulkLoader) finish(ctx context.Context) error {
+	t := l.table
+
+	rootPage, err := t.pager.ModifyPage(ctx, t.GetRootPageIdx())
+	if err != nil {
+		return fmt.Errorf("bulk load: get root page: %w", err)
+	}
+
+	// The root page reserves space for the table configuration, so the last
+	// leaf only stays in it when it is the only leaf and still fits.
+	rootLeaf := rootPage.LeafNode
+	if len(l.leaves) == 0 && l.leaf.TakenSpace() <= rootLeaf.MaxSpace() {
+		rootLeaf.Cells = l.leaf.Cells
+		rootLeaf.Header.Cells = l.leaf.Header.Cells
+	} else {
+		if l.leaf.Header.Cells > 0 {
+			if err := l.flushLeaf(ctx); err != nil {
+				return err
+			}
+		}
+		if err := l.buildInternalLevels(ctx, rootPage); err != nil {
+			return err
+		}
+	}
+
+	// Force the next insert to find the rightmost leaf of the new tree.
+	t.rightmostTablePage.Store(-1)
+
+	if t.PrimaryKey.Autoincrement && l.rows > 0 {
+		if err := t.saveNextAutoincrement(ctx); err != nil {
+			return err
+		}
+	}
+	if t.getRowCount != nil && l.rows > 0 {
+		if tx := TxFromContext(ctx); tx != nil {
+			tx.AddRowCountDelta(t.Name, l.rows)
+		}
+	}
+	return nil
+}
+
+// buildInternalLevels groups the written leaves under new internal nodes, then
+// groups those, until the remaining nodes fit under the root page, which is
+// turned into an internal node in place. Nodes of a level share the children
+// evenly.
+func (l *bulkLoader) buildInternalLevels(ctx context.Context, rootPage *Page) error {
+	t := l.table
+	if len(l.leaves) < 2 {
+		return errors.New("bulk load: internal levels need at least two leaves")
+	}
+
+	maxChildren := int(t.maximumICells) + 1
+	maxRootChildren := t.maxICells(t.GetRootPageIdx()) + 1
+	if t.maximumICells == InternalNodeMaxCells {
+		// The root page reserves space for the table configuration.
+		maxRootChildren = RootInternalNodeMaxCells + 1
+	}
+
+	level := l.leaves
+	for len(level) > maxRootChildren {
+		// The root holds fewer children than other internal nodes, so a
+		// level too wide for the root but not for one node is split in two.
+		groups := max(2, (len(level)+maxChildren-1)/maxChildren)
+		next := make([]bulkLeafRef, 0, groups)
+		start := 0
+		for g := range groups {
+			end := start + (len(level)-start)/(groups-g)
+			page, err := t.pager.GetFreePage(ctx)
+			if err != nil {
+				return fmt.Errorf("bulk load: get internal page: %w", err)
+			}
+			page.InternalNode = NewInternalNode()
+			if err := l.linkChildren(ctx, page, level[start:end]); err != nil {
+				return err
+			}
+			next = append(next, bulkLeafRef{pageIdx: page.Index, maxKey: level[end-1].maxKey})
+			start = end
+		}
+		level = next
+	}
+
+	rootNode := NewInternalNode()
+	rootNode.Header.IsRoot = true
+	rootNode.Header.NextAutoincrement = rootPage.nextAutoincrement()
+	rootPage.LeafNode = nil
+	rootPage.InternalNode = rootNode
+	return l.linkChildren(ctx, rootPage, level)
+}
+
+// linkChildren points the internal node of page at children, using each
+// child's max key as its separator, and sets page as their parent.
+func (l *bulkLoader) linkChildren(ctx context.Context, page *Page, children []bulkLeafRef) error {
+	node := page.InternalNode
+	node.Header.KeysNum = uint32(len(children) - 1)
+	for i, child := range children {
+		if err := node.SetChild(uint32(i), child.pageIdx); err != nil {
+			return err
+		}
+		if i < len(children)-1 {
+			node.ICells[i].Key = child.maxKey
+		}
+		childPage, err := l.table.pager.ModifyPage(ctx, child.pageIdx)
+		if err != nil {
+			return fmt.Errorf("bulk load: get child page: %w", err)
+		}
+		childPage.setParent(page.Index)
+	}
+	return nil
+}
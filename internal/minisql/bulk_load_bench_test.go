@@ -0,0 +1,78 @@
+package minisql
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+const bulkLoadBenchRows = 10000
+
+func newBulkLoadBenchTable(b *testing.B) (*TransactionManager, *Table) {
+	b.Helper()
+	file, err := os.CreateTemp("", "bench_bulk_load_*.db")
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.Cleanup(func() {
+		file.Close()
+		os.Remove(file.Name())
+	})
+
+	pager, err := NewPager(file, PageSize, 100000)
+	if err != nil {
+		b.Fatal(err)
+	}
+	var (
+		tablePager = pager.ForTable(testColumns)
+		txManager  = NewTransactionManager(zap.NewNop(), file.Name(), mockPagerFactory(tablePager), pager, nil)
+		txPager    = NewTransactionalPager(tablePager, txManager, testTableName, "")
+	)
+	return txManager, NewTable(zap.NewNop(), txPager, txManager, testTableName, testColumns, 0, nil)
+}
+
+// BenchmarkTable_InsertSorted loads rows into an empty table with a single
+// multi-row INSERT.
+func BenchmarkTable_InsertSorted(b *testing.B) {
+	rows := gen.Rows(bulkLoadBenchRows)
+	for b.Loop() {
+		b.StopTimer()
+		txManager, table := newBulkLoadBenchTable(b)
+		stmt := Statement{
+			Kind:    Insert,
+			Fields:  fieldsFromColumns(testColumns...),
+			Inserts: make([][]OptionalValue, 0, len(rows)),
+		}
+		for _, row := range rows {
+			stmt.Inserts = append(stmt.Inserts, row.Clone().Values)
+		}
+		b.StartTimer()
+
+		if err := txManager.ExecuteInTransaction(context.Background(), func(ctx context.Context) error {
+			_, err := table.Insert(ctx, stmt)
+			return err
+		}); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkTable_BulkLoad loads the same rows with BulkLoad.
+func BenchmarkTable_BulkLoad(b *testing.B) {
+	rows := gen.Rows(bulkLoadBenchRows)
+	for b.Loop() {
+		b.StopTimer()
+		txManager, table := newBulkLoadBenchTable(b)
+		ch := bulkRows(rows)
+		b.StartTimer()
+
+		if err := txManager.ExecuteInTransaction(context.Background(), func(ctx context.Context) error {
+			_, err := table.BulkLoad(ctx, ch)
+			return err
+		}); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,173 @@
+package minisql
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestTable_BulkLoad(t *testing.T) {
+	newTable := func(t *testing.T) (*pagerImpl, *TransactionManager, *Table) {
+		var (
+			pager, dbFile = initTest(t)
+			tablePager    = pager.ForTable(testMediumColumns)
+			txManager     = NewTransactionManager(zap.NewNop(), dbFile.Name(), mockPagerFactory(tablePager), pager, nil)
+			txPager       = NewTransactionalPager(tablePager, txManager, testTableName, "")
+			table         = NewTable(testLogger, txPager, txManager, testTableName, testMediumColumns, 0, nil)
+		)
+		table.maximumICells = 5
+		return pager, txManager, table
+	}
+
+	t.Run("empty table builds the tree bottom-up", func(t *testing.T) {
+		var (
+			ctx                     = context.Background()
+			pager, txManager, table = newTable(t)
+			rows                    = gen.MediumRows(100)
+		)
+
+		loaded := mustBulkLoad(ctx, t, table, txManager, rows)
+		assert.Equal(t, int64(len(rows)), loaded)
+
+		assertTableBTreeInvariants(t, pager, table)
+		checkRows(ctx, t, table, rows)
+
+		// Normal inserts continue after the last bulk loaded row.
+		more := gen.MediumRows(1)
+		more[0].Key = RowID(len(rows))
+		mustInsert(ctx, t, table, txManager, Statement{
+			Kind:    Insert,
+			Fields:  fieldsFromColumns(testMediumColumns...),
+			Inserts: [][]OptionalValue{more[0].Values},
+		})
+		assertTableBTreeInvariants(t, pager, table)
+		checkRows(ctx, t, table, append(rows, more[0]))
+	})
+
+	t.Run("rows fitting the root leaf stay in the root", func(t *testing.T) {
+		var (
+			ctx                     = context.Background()
+			pager, txManager, table = newTable(t)
+			rows                    = gen.MediumRows(2)
+		)
+
+		mustBulkLoad(ctx, t, table, txManager, rows)
+
+		assertTableBTreeInvariants(t, pager, table)
+		assert.Equal(t, 1, int(pager.TotalPages()))
+		checkRows(ctx, t, table, rows)
+	})
+
+	t.Run("non-empty table falls back to insert", func(t *testing.T) {
+		var (
+			ctx                     = context.Background()
+			pager, txManager, table = newTable(t)
+			rows                    = gen.MediumRows(40)
+		)
+		mustInsert(ctx, t, table, txManager, Statement{
+			Kind:    Insert,
+			Fields:  fieldsFromColumns(testMediumColumns...),
+			Inserts: [][]OptionalValue{rows[0].Values},
+		})
+
+		loaded := mustBulkLoad(ctx, t, table, txManager, rows[1:])
+		assert.Equal(t, int64(len(rows)-1), loaded)
+
+		assertTableBTreeInvariants(t, pager, table)
+		checkRows(ctx, t, table, rows)
+	})
+}
+
+func TestTable_BulkLoad_PrimaryKeyOutOfOrder(t *testing.T) {
+	var (
+		pager, dbFile = initTest(t)
+		ctx           = context.Background()
+		tablePager    = pager.ForTable(testColumns[0:2])
+		txManager     = NewTransactionManager(zap.NewNop(), dbFile.Name(), mockPagerFactory(tablePager), pager, nil)
+		txPager       = NewTransactionalPager(tablePager, txManager, testTableName, "")
+		rows          = gen.RowsWithPrimaryKey(300)
+		table         *Table
+	)
+
+	err := txManager.ExecuteInTransaction(ctx, func(ctx context.Context) error {
+		freePage, err := txPager.GetFreePage(ctx)
+		if err != nil {
+			return err
+		}
+		freePage.LeafNode = NewLeafNode()
+		freePage.LeafNode.Header.IsRoot = true
+		table = NewTable(
+			testLogger,
+			txPager,
+			txManager,
+			testTableName,
+			testColumns[0:2],
+			freePage.Index,
+			nil,
+			WithPrimaryKey(NewPrimaryKey("foo", testColumns[0:1], false)),
+		)
+		return nil
+	})
+	require.NoError(t, err)
+	table.maximumICells = 5
+
+	idxPager, err := pager.ForIndex(table.PrimaryKey.Columns, true)
+	require.NoError(t, err)
+	txIndexPager := NewTransactionalPager(idxPager, table.txManager, testTableName, table.PrimaryKey.Name)
+	err = txManager.ExecuteInTransaction(ctx, func(ctx context.Context) error {
+		freePage, err := txIndexPager.GetFreePage(ctx)
+		if err != nil {
+			return err
+		}
+		table.PrimaryKey.Index, err = table.createBTreeIndex(txIndexPager, freePage, table.PrimaryKey.Columns, table.PrimaryKey.Name, true)
+		return err
+	})
+	require.NoError(t, err)
+
+	// Swap two keys in the middle: rows up to the swap are bulk loaded, the
+	// rest go through Insert. Row IDs follow the order the rows arrive in.
+	rows[200].Values, rows[201].Values = rows[201].Values, rows[200].Values
+	expectedKeys := make([]int64, 0, len(rows))
+	for _, row := range rows {
+		expectedKeys = append(expectedKeys, row.Values[0].Value.(int64))
+	}
+
+	loaded := mustBulkLoad(ctx, t, table, txManager, rows)
+	assert.Equal(t, int64(len(rows)), loaded)
+
+	assertTableBTreeInvariants(t, pager, table)
+	checkRowsWithPrimaryKey(ctx, t, table, rows)
+	checkIndexKeys(ctx, t, table.PrimaryKey.Index, expectedKeys)
+
+	t.Run("duplicate primary key", func(t *testing.T) {
+		err := txManager.ExecuteInTransaction(ctx, func(ctx context.Context) error {
+			_, err := table.BulkLoad(ctx, bulkRows(rows[:1]))
+			return err
+		})
+		require.ErrorIs(t, err, ErrDuplicateKey)
+	})
+}
+
+func bulkRows(rows []Row) <-chan []OptionalValue {
+	ch := make(chan []OptionalValue, len(rows))
+	for _, row := range rows {
+		ch <- row.Clone().Values
+	}
+	close(ch)
+	return ch
+}
+
+func mustBulkLoad(ctx context.Context, t *testing.T, table *Table, txManager *TransactionManager, rows []Row) int64 {
+	t.Helper()
+	var loaded int64
+	err := txManager.ExecuteInTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		loaded, err = table.BulkLoad(ctx, bulkRows(rows))
+		return err
+	})
+	require.NoError(t, err)
+	return loaded
+}
@@ -15,7 +15,7 @@ type ErrCheckConstraintViolation = minisqlErrors.ErrCheckConstraintViolation
 // first failure, nil if all constraints pass.
 func validateCheckConstraints(columns []Column, row Row) error {
 	for _, col := range columns {
-		if col.CheckCond == nil {
+		if col.CheckCond == nil || col.Deleted {
 			continue
 		}
 		dnf := col.CheckCond.ToDNF()
@@ -0,0 +1,107 @@
+package minisql
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// checkpointTicker is the subset of time.Ticker used by the checkpoint
+// scheduler, so tests can drive it with a fake.
+type checkpointTicker interface {
+	C() <-chan time.Time
+	Stop()
+}
+
+type timeTicker struct {
+	*time.Ticker
+}
+
+func (t timeTicker) C() <-chan time.Time {
+	return t.Ticker.C
+}
+
+func newTimeTicker(d time.Duration) checkpointTicker {
+	return timeTicker{Ticker: time.NewTicker(d)}
+}
+
+// checkpointScheduler periodically checkpoints the WAL so that the amount of
+// committed-but-uncheckpointed data, and with it crash-recovery time, stays
+// bounded even when the frame-count threshold is rarely reached.
+type checkpointScheduler struct {
+	ticker checkpointTicker
+	stop   chan struct{}
+	done   chan struct{}
+}
+
+// startCheckpointScheduler starts the background checkpoint loop when a
+// checkpoint interval is configured and the database runs in WAL mode.
+func (d *Database) startCheckpointScheduler() {
+	if d.checkpointInterval <= 0 || d.wal == nil {
+		return
+	}
+	newTicker := d.newCheckpointTicker
+	if newTicker == nil {
+		newTicker = newTimeTicker
+	}
+	s := &checkpointScheduler{
+		ticker: newTicker(d.checkpointInterval),
+		stop:   make(chan struct{}),
+		done:   make(chan struct{}),
+	}
+	d.checkpointScheduler = s
+	go d.runCheckpointScheduler(s)
+}
+
+func (d *Database) runCheckpointScheduler(s *checkpointScheduler) {
+	defer close(s.done)
+	defer s.ticker.Stop()
+	for {
+		select {
+		case <-s.stop:
+			return
+		case <-s.ticker.C():
+			d.runScheduledCheckpoint()
+		}
+	}
+}
+
+// stopCheckpointScheduler stops the background loop and waits for an
+// in-flight checkpoint to finish. It is safe to call when no scheduler runs.
+func (d *Database) stopCheckpointScheduler() {
+	s := d.checkpointScheduler
+	if s == nil {
+		return
+	}
+	d.checkpointScheduler = nil
+	close(s.stop)
+	<-s.done
+}
+
+// runScheduledCheckpoint checkpoints the WAL if it holds any frames.
+//
+// The checkpoint runs at a transaction boundary: CheckpointWAL holds the WAL
+// write lock, so it waits for an in-progress commit to finish and no commit
+// can start until it is done, while uncommitted transactions never touch the
+// WAL. The read lock on dbLock keeps VACUUM from swapping the WAL out from
+// under the checkpoint. When snapshot readers are active the checkpoint is
+// skipped and retried on the next tick.
+func (d *Database) runScheduledCheckpoint() {
+	d.dbLock.RLock()
+	defer d.dbLock.RUnlock()
+
+	if d.wal == nil || d.txManager.walFrameCount() == 0 {
+		return
+	}
+	if err := d.Checkpoint(context.Background()); err != nil {
+		if errors.Is(err, ErrCheckpointBlockedByReaders) {
+			if ce := d.logger.Check(zap.DebugLevel, "scheduled checkpoint deferred: active snapshot readers"); ce != nil {
+				ce.Write()
+			}
+			return
+		}
+		d.logger.Warn("scheduled WAL checkpoint failed", zap.Error(err))
+	}
+}
@@ -0,0 +1,108 @@
+package minisql
+
+import (
+	"context"
+	"os"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeCheckpointTicker is a checkpointTicker driven by the test.
+type fakeCheckpointTicker struct {
+	c       chan time.Time
+	stopped atomic.Bool
+}
+
+func (f *fakeCheckpointTicker) C() <-chan time.Time { return f.c }
+
+func (f *fakeCheckpointTicker) Stop() { f.stopped.Store(true) }
+
+func TestDatabase_CheckpointScheduler(t *testing.T) {
+	t.Parallel()
+
+	dbFile, err := os.CreateTemp("", "checkpoint_scheduler_test_*.db")
+	require.NoError(t, err)
+	dbPath := dbFile.Name()
+	t.Cleanup(func() {
+		os.Remove(dbPath)
+		os.Remove(dbPath + "-wal")
+	})
+
+	walIndex := NewWALIndex()
+	wal, _, err := OpenWALAndRebuildIndex(dbPath, PageSize, walIndex)
+	require.NoError(t, err)
+
+	pager, err := NewPager(dbFile, PageSize, PageCacheSize)
+	require.NoError(t, err)
+
+	var (
+		ticker   = &fakeCheckpointTicker{c: make(chan time.Time)}
+		interval time.Duration
+	)
+	db, err := NewDatabase(
+		context.Background(), testLogger, dbPath, nil, pager, pager,
+		&WALConfig{
+			WAL:                 wal,
+			Index:               walIndex,
+			DBFile:              dbFile,
+			CheckpointThreshold: 10000, // high threshold: no auto-checkpoint
+		},
+		WithCheckpointInterval(time.Minute),
+		func(d *Database) {
+			d.newCheckpointTicker = func(d time.Duration) checkpointTicker {
+				interval = d
+				return ticker
+			}
+		},
+	)
+	require.NoError(t, err)
+	require.NotNil(t, db.checkpointScheduler)
+	assert.Equal(t, time.Minute, interval)
+
+	err = db.txManager.ExecuteInTransaction(context.Background(), func(txCtx context.Context) error {
+		_, err := db.ExecuteStatement(txCtx, Statement{
+			Kind:      CreateTable,
+			TableName: "items",
+			Columns:   append([]Column{}, backupColumns...),
+		})
+		return err
+	})
+	require.NoError(t, err)
+	tbl, ok := db.tables["items"]
+	require.True(t, ok)
+	insertBackupRows(t, db, tbl, 50, 1)
+
+	framesBefore := db.txManager.walFrameCount()
+	require.Greater(t, framesBefore, int64(0))
+
+	// The first tick checkpoints the WAL; the unbuffered send returns once the
+	// scheduler has received it, so wait for the checkpoint to complete.
+	ticker.c <- time.Now()
+	require.Eventually(t, func() bool {
+		return db.txManager.walFrameCount() == 0
+	}, 5*time.Second, time.Millisecond)
+	assert.Equal(t, 50, countBackupRows(t, db, tbl))
+
+	// Close stops the scheduler and waits for its goroutine to exit.
+	done := db.checkpointScheduler.done
+	require.NoError(t, db.Close())
+	assert.Nil(t, db.checkpointScheduler)
+	assert.True(t, ticker.stopped.Load())
+	select {
+	case <-done:
+	default:
+		t.Fatal("checkpoint scheduler still running after Close")
+	}
+}
+
+func TestDatabase_CheckpointScheduler_Disabled(t *testing.T) {
+	t.Parallel()
+
+	// Without a WAL the scheduler is never started, even with an interval.
+	db, _ := newVacuumTestDB(t, nil, WithCheckpointInterval(time.Millisecond))
+	assert.Nil(t, db.checkpointScheduler)
+}
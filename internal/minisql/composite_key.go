@@ -1,5 +1,7 @@
 package minisql
 
+import "fmt"
+
 // CompositeKey holds the column definitions and values for a multi-column index key.
 type CompositeKey struct {
 	Columns []Column
@@ -42,6 +44,19 @@ func (ck CompositeKey) Size() uint64 {
 	return uint64(size)
 }
 
+// String formats the key values as a tuple, e.g. (1, 2), for EXPLAIN output.
+func (ck CompositeKey) String() string {
+	b := make([]byte, 0, 16)
+	b = append(b, '(')
+	for i, v := range ck.Values {
+		if i > 0 {
+			b = append(b, ", "...)
+		}
+		b = fmt.Appendf(b, "%v", v)
+	}
+	return string(append(b, ')'))
+}
+
 // Prefix returns a CompositeKey containing only the first n columns' comparison bytes.
 func (ck CompositeKey) Prefix(columns int) CompositeKey {
 	if columns > len(ck.Columns) {
@@ -187,3 +187,15 @@ func TestCompositeKey_Prefix_AllTypes(t *testing.T) {
 	p5 := ck.Prefix(5) // + Timestamp: 8 bytes
 	assert.Equal(t, ck.Comparison, p5.Comparison)
 }
+
+func TestCompositeKey_String(t *testing.T) {
+	t.Parallel()
+
+	columns := []Column{
+		{Kind: Int8, Name: "tenant_id", Size: 8},
+		{Kind: Varchar, Name: "code", Size: 16},
+	}
+
+	assert.Equal(t, "(1, ab)", NewCompositeKey(columns, int64(1), "ab").String())
+	assert.Equal(t, "(1)", NewCompositeKey(columns[:1], int64(1)).String())
+}
@@ -34,6 +34,10 @@ const (
 	Between
 	// NotBetween -> "NOT BETWEEN ... AND ..."
 	NotBetween
+	// IsNull -> "IS NULL"
+	IsNull
+	// IsNotNull -> "IS NOT NULL"
+	IsNotNull
 )
 
 func (o Operator) String() string {
@@ -62,6 +66,10 @@ func (o Operator) String() string {
 		return "BETWEEN"
 	case NotBetween:
 		return "NOT BETWEEN"
+	case IsNull:
+		return "IS NULL"
+	case IsNotNull:
+		return "IS NOT NULL"
 	default:
 		return "Unknown"
 	}
@@ -233,7 +241,7 @@ func FieldIsNull(field Field) Condition {
 			Type:  OperandField,
 			Value: field,
 		},
-		Operator: Eq,
+		Operator: IsNull,
 		Operand2: Operand{
 			Type: OperandNull,
 		},
@@ -247,7 +255,7 @@ func FieldIsNotNull(field Field) Condition {
 			Type:  OperandField,
 			Value: field,
 		},
-		Operator: Ne,
+		Operator: IsNotNull,
 		Operand2: Operand{
 			Type: OperandNull,
 		},
@@ -374,6 +382,20 @@ func FieldIsLessOrEqual(field Field, operandType OperandType, value any) Conditi
 	}
 }
 
+// compareNull evaluates a value against a NULL operand. IS NULL and IS NOT
+// NULL test the value's validity; any other comparison with NULL is unknown
+// and therefore never matches.
+func compareNull(valid bool, operator Operator) bool {
+	switch operator {
+	case IsNull:
+		return !valid
+	case IsNotNull:
+		return valid
+	default:
+		return false
+	}
+}
+
 func compareBoolean(v1, v2 bool, operator Operator) (bool, error) {
 	switch operator {
 	case Eq:
@@ -392,6 +414,20 @@ func compareBoolean(v1, v2 bool, operator Operator) (bool, error) {
 	return false, fmt.Errorf("unknown operator '%s'", operator)
 }
 
+// compareBooleanValues compares two values that should both be booleans,
+// returning an error instead of panicking when either is of another type.
+func compareBooleanValues(v1, v2 any, operator Operator) (bool, error) {
+	b1, ok := v1.(bool)
+	if !ok {
+		return false, fmt.Errorf("cannot compare %T with a boolean", v1)
+	}
+	b2, ok := v2.(bool)
+	if !ok {
+		return false, fmt.Errorf("cannot compare a boolean with %T", v2)
+	}
+	return compareBoolean(b1, b2, operator)
+}
+
 func compareInt4(v1, v2 int64, operator Operator) (bool, error) {
 	// Validate that both values are within the INT4 range before comparing.
 	if v1 < math.MinInt32 || v1 > math.MaxInt32 {
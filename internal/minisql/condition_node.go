@@ -45,11 +45,8 @@ func (n *ConditionNode) String() string {
 		} else {
 			op1 = fmt.Sprintf("%v", l.Operand1.Value)
 		}
-		if l.Operand2.Type == OperandNull {
-			if l.Operator == Eq {
-				return op1 + " IS NULL"
-			}
-			return op1 + " IS NOT NULL"
+		if l.Operator == IsNull || l.Operator == IsNotNull {
+			return op1 + " " + l.Operator.String()
 		}
 		var op2 string
 		if l.Operand2.Type == OperandField {
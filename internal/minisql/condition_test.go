@@ -1787,6 +1787,19 @@ func TestCompareBoolean_UnknownOperator(t *testing.T) {
 	require.Error(t, err)
 }
 
+func TestCompareBooleanValues(t *testing.T) {
+	t.Parallel()
+
+	got, err := compareBooleanValues(true, true, Eq)
+	require.NoError(t, err)
+	assert.True(t, got)
+
+	_, err = compareBooleanValues(true, int64(1), Eq)
+	assert.EqualError(t, err, "cannot compare a boolean with int64")
+	_, err = compareBooleanValues(NewTextPointer([]byte("true")), false, Ne)
+	assert.EqualError(t, err, "cannot compare minisql.TextPointer with a boolean")
+}
+
 func TestIsBetweenInt4_ErrorPaths(t *testing.T) {
 	t.Parallel()
 
@@ -31,17 +31,24 @@ func (p *QueryPlan) markCoveringIndexes(stmt Statement) {
 //   - No WHERE condition involves a NULL check (IS NULL / IS NOT NULL), because
 //     rows with NULL keys may not be present in the index.
 //
-// SELECT COUNT(*) with no column references is always eligible when an index
-// scan has already been chosen — the executor only needs to count entries.
+// SELECT COUNT(*) only needs the row count, so it is eligible whenever its
+// WHERE conditions can be checked against the index key alone.
 func coveringIndexEligible(stmt Statement, indexColumns []Column) bool {
 	// SELECT * is never covered (all table columns needed).
 	if stmt.IsSelectAll() {
 		return false
 	}
 
-	// SELECT COUNT(*) — only needs row count, no column values.
+	// Build a set of index column names for O(1) lookup.
+	covered := make(map[string]struct{}, len(indexColumns))
+	for _, c := range indexColumns {
+		covered[c.Name] = struct{}{}
+	}
+
+	// SELECT COUNT(*) — no column values needed, but residual filters still
+	// have to be evaluated against the index row.
 	if stmt.IsSelectCountAll() {
-		return true
+		return conditionsCoveredByIndex(stmt.Conditions, covered)
 	}
 
 	// If no specific columns are listed and this isn't a recognised aggregate form,
@@ -50,12 +57,6 @@ func coveringIndexEligible(stmt Statement, indexColumns []Column) bool {
 		return false
 	}
 
-	// Build a set of index column names for O(1) lookup.
-	covered := make(map[string]struct{}, len(indexColumns))
-	for _, c := range indexColumns {
-		covered[c.Name] = struct{}{}
-	}
-
 	// SELECT fields (resolve expression source columns, not output aliases).
 	for _, f := range exprSourceFields(stmt.Fields) {
 		if _, ok := covered[f.Name]; !ok {
@@ -87,8 +88,13 @@ func coveringIndexEligible(stmt Statement, indexColumns []Column) bool {
 		}
 	}
 
-	// WHERE conditions: check for NULL checks and unreferenced columns.
-	for _, group := range stmt.Conditions {
+	return conditionsCoveredByIndex(stmt.Conditions, covered)
+}
+
+// conditionsCoveredByIndex reports whether every WHERE condition can be
+// evaluated from the index columns in covered alone.
+func conditionsCoveredByIndex(conditions OneOrMore, covered map[string]struct{}) bool {
+	for _, group := range conditions {
 		for _, cond := range group {
 			// IS NULL / IS NOT NULL — index may not contain NULL-keyed entries.
 			if cond.Operand2.Type == OperandNull {
@@ -122,8 +128,8 @@ func coveringIndexEligible(stmt Statement, indexColumns []Column) bool {
 func rowFromIndexKey(key any, indexColumns []Column, rowID RowID) Row {
 	if ck, ok := key.(CompositeKey); ok {
 		vals := make([]OptionalValue, len(ck.Columns))
-		for i := range ck.Columns {
-			vals[i] = OptionalValue{Value: ck.Values[i], Valid: true}
+		for i, col := range ck.Columns {
+			vals[i] = OptionalValue{Value: rowValueFromIndexKey(col, ck.Values[i]), Valid: true}
 		}
 		row := NewRowWithValues(ck.Columns, vals)
 		row.Key = rowID
@@ -132,8 +138,17 @@ func rowFromIndexKey(key any, indexColumns []Column, rowID RowID) Row {
 
 	// Single-column index.
 	col := indexColumns[0]
-	vals := []OptionalValue{{Value: key, Valid: true}}
+	vals := []OptionalValue{{Value: rowValueFromIndexKey(col, key), Valid: true}}
 	row := NewRowWithValues([]Column{col}, vals)
 	row.Key = rowID
 	return row
 }
+
+// rowValueFromIndexKey converts an index key value to its row representation.
+// Indexes keep VARCHAR keys as plain strings while rows hold a TextPointer.
+func rowValueFromIndexKey(col Column, value any) any {
+	if s, ok := value.(string); ok && (col.Kind == Varchar || col.Kind == Text) {
+		return NewTextPointer([]byte(s))
+	}
+	return value
+}
@@ -37,11 +37,34 @@ func TestCoveringIndexEligible(t *testing.T) {
 			want:         false,
 		},
 		{
-			name:         "SELECT COUNT(*) is always eligible",
+			name:         "SELECT COUNT(*) without WHERE is eligible",
 			stmt:         Statement{Kind: Select, Fields: []Field{{Name: "COUNT(*)"}}},
 			indexColumns: emailCol,
 			want:         true,
 		},
+		{
+			name: "SELECT COUNT(*) with WHERE on indexed column - eligible",
+			stmt: Statement{
+				Kind:       Select,
+				Fields:     []Field{{Name: "COUNT(*)"}},
+				Conditions: OneOrMore{{FieldIsEqual(Field{Name: "id"}, OperandInteger, int64(1))}},
+			},
+			indexColumns: idCol,
+			want:         true,
+		},
+		{
+			name: "SELECT COUNT(*) with residual WHERE on non-indexed column - not eligible",
+			stmt: Statement{
+				Kind:   Select,
+				Fields: []Field{{Name: "COUNT(*)"}},
+				Conditions: OneOrMore{{
+					FieldIsEqual(Field{Name: "id"}, OperandInteger, int64(1)),
+					FieldIsEqual(Field{Name: "name"}, OperandQuotedString, NewTextPointer([]byte("Bob"))),
+				}},
+			},
+			indexColumns: idCol,
+			want:         false,
+		},
 		{
 			name:         "SELECT indexed column - eligible",
 			stmt:         Statement{Kind: Select, Fields: []Field{{Name: "email"}}},
@@ -188,9 +211,16 @@ func TestRowFromIndexKey(t *testing.T) {
 		require.Len(t, row.Values, 2)
 		assert.Equal(t, int64(5), row.Values[0].Value)
 		assert.True(t, row.Values[0].Valid)
-		assert.Equal(t, "a@b.com", row.Values[1].Value)
+		assert.Equal(t, NewTextPointer([]byte("a@b.com")), row.Values[1].Value)
 		assert.True(t, row.Values[1].Valid)
 	})
+
+	t.Run("single-column varchar key", func(t *testing.T) {
+		t.Parallel()
+		row := rowFromIndexKey("a@b.com", []Column{emailCol}, RowID(1))
+		require.Len(t, row.Values, 1)
+		assert.Equal(t, NewTextPointer([]byte("a@b.com")), row.Values[0].Value)
+	})
 }
 
 func TestProjectCoveringIndexKey(t *testing.T) {
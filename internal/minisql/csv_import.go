@@ -0,0 +1,329 @@
+package minisql
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	minisqlErrors "github.com/RichardKnop/minisql/pkg/errors"
+)
+
+// csvHeaderMode says how ImportCSV treats the first record of its input.
+type csvHeaderMode int
+
+const (
+	// csvHeaderNames reads column names from the first record.
+	csvHeaderNames csvHeaderMode = iota
+	// csvHeaderSkip discards the first record and maps fields by position.
+	csvHeaderSkip
+	// csvHeaderNone treats every record as data and maps fields by position.
+	csvHeaderNone
+)
+
+// WithCSVDelimiter sets the field delimiter ImportCSV splits records on
+// (default ','). Invalid delimiters such as '"' or '\n' fail the import.
+func WithCSVDelimiter(delimiter rune) ImportOption {
+	return func(c *importConfig) {
+		c.csvDelimiter = delimiter
+	}
+}
+
+// WithCSVEmptyAsNull makes ImportCSV store empty fields as NULL. Without it
+// an empty field is an empty string in text columns and an error in columns
+// of other kinds.
+func WithCSVEmptyAsNull() ImportOption {
+	return func(c *importConfig) {
+		c.csvEmptyAsNull = true
+	}
+}
+
+// WithCSVSkipHeader makes ImportCSV discard the first record instead of
+// reading column names from it. Fields map to the table's columns in order.
+func WithCSVSkipHeader() ImportOption {
+	return func(c *importConfig) {
+		c.csvHeader = csvHeaderSkip
+	}
+}
+
+// WithCSVNoHeader tells ImportCSV the input has no header record. Every
+// record is data and fields map to the table's columns in order.
+func WithCSVNoHeader() ImportOption {
+	return func(c *importConfig) {
+		c.csvHeader = csvHeaderNone
+	}
+}
+
+// WithContinueOnError makes ImportCSV skip rows that cannot be converted or
+// inserted instead of failing the import. onError, when not nil, is called
+// with the line number and error of every skipped row. Batches are then
+// committed in separate transactions, and a batch that fails is retried one
+// row per transaction, so rows imported before a failure are kept.
+func WithContinueOnError(onError func(line int, err error)) ImportOption {
+	return func(c *importConfig) {
+		c.continueOnError = true
+		c.onError = onError
+	}
+}
+
+// csvBatchRow is a converted record along with the line it started on.
+type csvBatchRow struct {
+	line   int
+	values []OptionalValue
+}
+
+// ImportCSV inserts the CSV records read from r into tableName and returns the
+// number of rows inserted. By default the first record names the columns the
+// fields belong to; WithCSVSkipHeader and WithCSVNoHeader map fields to the
+// table's columns by position instead. Fields are converted to the column
+// kinds and go through the same validation as an INSERT. Columns without a
+// field get their default value, or NULL.
+//
+// Rows are inserted in batches within a single transaction, so either every
+// record is imported or, on the first error, none is. When called with a
+// transaction in ctx the rows become part of that transaction instead.
+// WithContinueOnError skips bad rows instead and cannot be used with a
+// transaction in ctx.
+func (d *Database) ImportCSV(ctx context.Context, tableName string, r io.Reader, opts ...ImportOption) (int64, error) {
+	config := importConfig{batchSize: DefaultImportBatchSize, csvDelimiter: ','}
+	for _, opt := range opts {
+		opt(&config)
+	}
+	if config.continueOnError && TxFromContext(ctx) != nil {
+		return 0, errors.New("import: continue on error cannot be used inside a transaction")
+	}
+
+	table, ok := d.GetTable(ctx, tableName)
+	if !ok {
+		return 0, minisqlErrors.ErrNoSuchTable{Name: tableName}
+	}
+
+	reader := csv.NewReader(r)
+	reader.Comma = config.csvDelimiter
+	reader.FieldsPerRecord = -1
+
+	columns, err := csvImportColumns(table, reader, config)
+	if err != nil {
+		return 0, err
+	}
+	var fields []Field
+	for _, col := range columns {
+		if col.Name != "" {
+			fields = append(fields, Field{Name: col.Name})
+		}
+	}
+	insert := func(ctx context.Context, batch []csvBatchRow) (int64, error) {
+		inserts := make([][]OptionalValue, 0, len(batch))
+		for _, row := range batch {
+			inserts = append(inserts, row.values)
+		}
+		result, err := d.ExecuteStatement(ctx, Statement{
+			Kind:      Insert,
+			TableName: tableName,
+			Fields:    fields,
+			Inserts:   inserts,
+		})
+		return int64(result.RowsAffected), err
+	}
+
+	var imported int64
+	if !config.continueOnError {
+		err := d.txManager.ExecuteInTransaction(ctx, func(ctx context.Context) error {
+			return readCSVBatches(reader, columns, config, func(batch []csvBatchRow) error {
+				n, err := insert(ctx, batch)
+				if err != nil {
+					return fmt.Errorf("batch starting at line %d: %w", batch[0].line, err)
+				}
+				imported += n
+				return nil
+			})
+		})
+		if err != nil {
+			return 0, err
+		}
+		return imported, nil
+	}
+
+	err = readCSVBatches(reader, columns, config, func(batch []csvBatchRow) error {
+		var n int64
+		err := d.txManager.ExecuteInTransaction(ctx, func(ctx context.Context) error {
+			var err error
+			n, err = insert(ctx, batch)
+			return err
+		})
+		if err == nil {
+			imported += n
+			return nil
+		}
+		// Retry the failed batch one row at a time to find the bad rows.
+		for _, row := range batch {
+			err := d.txManager.ExecuteInTransaction(ctx, func(ctx context.Context) error {
+				var err error
+				n, err = insert(ctx, []csvBatchRow{row})
+				return err
+			})
+			if err != nil {
+				config.reportError(row.line, fmt.Errorf("line %d: %w", row.line, err))
+				continue
+			}
+			imported += n
+		}
+		return nil
+	})
+	return imported, err
+}
+
+// csvImportColumns returns the column each field of a record belongs to,
+// reading the header record when the input has one. Header names that are
+// not columns fail the import unless lenient mode is on, in which case their
+// fields are ignored; those positions hold a zero Column.
+func csvImportColumns(table *Table, reader *csv.Reader, config importConfig) ([]Column, error) {
+	var positional []Column
+	for _, col := range table.Columns {
+		if !col.Deleted {
+			positional = append(positional, col)
+		}
+	}
+
+	switch config.csvHeader {
+	case csvHeaderNone:
+		return positional, nil
+	case csvHeaderSkip:
+		if _, err := reader.Read(); err != nil && err != io.EOF {
+			return nil, fmt.Errorf("header: %w", err)
+		}
+		return positional, nil
+	}
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, errors.New("header: empty input")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("header: %w", err)
+	}
+	columns := make([]Column, len(header))
+	seen := make(map[string]struct{}, len(header))
+	for i, name := range header {
+		name = strings.TrimSpace(name)
+		if i == 0 {
+			// Spreadsheet exports often start with a UTF-8 byte order mark.
+			name = strings.TrimPrefix(name, "\uFEFF")
+		}
+		col, ok := table.ColumnByName(name)
+		if !ok || col.Deleted {
+			if !config.lenient {
+				return nil, fmt.Errorf("header: %w %q for table %s", ErrImportUnknownColumn, name, table.Name)
+			}
+			continue
+		}
+		if _, ok := seen[name]; ok {
+			return nil, fmt.Errorf("header: duplicate column %q", name)
+		}
+		seen[name] = struct{}{}
+		columns[i] = col
+	}
+	return columns, nil
+}
+
+// readCSVBatches converts the records read from reader and passes them to
+// flush in batches of at most config.batchSize rows. Records that cannot be
+// converted fail the import, or are reported and skipped when continuing on
+// errors.
+func readCSVBatches(reader *csv.Reader, columns []Column, config importConfig, flush func([]csvBatchRow) error) error {
+	var batch []csvBatchRow
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		var (
+			line int
+			row  []OptionalValue
+		)
+		if parseErr, ok := err.(*csv.ParseError); ok {
+			// Errors from the CSV reader already name their line.
+			line = parseErr.StartLine
+		} else if err == nil {
+			line, _ = reader.FieldPos(0)
+			row, err = csvRow(record, columns, config.csvEmptyAsNull)
+			if err != nil {
+				err = fmt.Errorf("line %d: %w", line, err)
+			}
+		}
+		if err != nil {
+			if !config.continueOnError {
+				return err
+			}
+			config.reportError(line, err)
+			continue
+		}
+		batch = append(batch, csvBatchRow{line: line, values: row})
+		if len(batch) >= config.batchSize {
+			if err := flush(batch); err != nil {
+				return err
+			}
+			batch = nil
+		}
+	}
+	if len(batch) == 0 {
+		return nil
+	}
+	return flush(batch)
+}
+
+// csvRow converts the fields of one record to the values of columns, leaving
+// out fields whose header did not name a column.
+func csvRow(record []string, columns []Column, emptyAsNull bool) ([]OptionalValue, error) {
+	if len(record) != len(columns) {
+		return nil, fmt.Errorf("expected %d fields, got %d", len(columns), len(record))
+	}
+	row := make([]OptionalValue, 0, len(columns))
+	for i, field := range record {
+		if columns[i].Name == "" {
+			continue
+		}
+		value, err := csvValue(columns[i], field, emptyAsNull)
+		if err != nil {
+			return nil, err
+		}
+		row = append(row, value)
+	}
+	return row, nil
+}
+
+// csvValue converts a CSV field to the representation the parser would
+// produce for the equivalent SQL literal in a column of col's kind.
+func csvValue(col Column, field string, emptyAsNull bool) (OptionalValue, error) {
+	if field == "" && emptyAsNull {
+		return OptionalValue{}, nil
+	}
+
+	switch col.Kind {
+	case Boolean:
+		b, err := strconv.ParseBool(strings.TrimSpace(field))
+		if err != nil {
+			return OptionalValue{}, fmt.Errorf("column %q: expected a boolean, got %q", col.Name, field)
+		}
+		return OptionalValue{Value: b, Valid: true}, nil
+	case Int4, Int8:
+		n, err := strconv.ParseInt(strings.TrimSpace(field), 10, 64)
+		if err != nil {
+			return OptionalValue{}, fmt.Errorf("column %q: expected an integer, got %q", col.Name, field)
+		}
+		return OptionalValue{Value: n, Valid: true}, nil
+	case Real, Double:
+		f, err := strconv.ParseFloat(strings.TrimSpace(field), 64)
+		if err != nil {
+			return OptionalValue{}, fmt.Errorf("column %q: expected a number, got %q", col.Name, field)
+		}
+		return OptionalValue{Value: f, Valid: true}, nil
+	default:
+		// Text, VARCHAR, JSON, VECTOR, TIMESTAMP and UUID fields hold the
+		// same text as the SQL literal would.
+		return OptionalValue{Value: NewTextPointer([]byte(field)), Valid: true}, nil
+	}
+}
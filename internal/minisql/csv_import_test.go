@@ -0,0 +1,148 @@
+package minisql
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDatabase_ImportCSV(t *testing.T) {
+	t.Parallel()
+
+	t.Run("header maps fields to columns", func(t *testing.T) {
+		t.Parallel()
+		db := newImportTestDB(t)
+
+		input := strings.Join([]string{
+			"\uFEFFname, id ,score,active",
+			"first,1,1.5,true",
+			`"second, quoted",2,7,false`,
+			"third,3,,",
+		}, "\n")
+		n, err := db.ImportCSV(context.Background(), "events", strings.NewReader(input), WithCSVEmptyAsNull(), WithImportBatchSize(2))
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), n)
+
+		expected := []map[string]any{
+			{"id": int64(1), "name": "first", "score": 1.5, "status": "new", "active": true},
+			{"id": int64(2), "name": "second, quoted", "score": float64(7), "status": "new", "active": false},
+			{"id": int64(3), "name": "third", "score": nil, "status": "new", "active": nil},
+		}
+		assert.ElementsMatch(t, expected, importedRows(t, db))
+	})
+
+	t.Run("delimiter and no header map fields by position", func(t *testing.T) {
+		t.Parallel()
+		db := newImportTestDB(t)
+
+		input := "1\tfirst\t2.5\tdone\ttrue\n2\tsecond\t3\tnew\tfalse\n"
+		n, err := db.ImportCSV(context.Background(), "events", strings.NewReader(input), WithCSVDelimiter('\t'), WithCSVNoHeader())
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), n)
+
+		expected := []map[string]any{
+			{"id": int64(1), "name": "first", "score": 2.5, "status": "done", "active": true},
+			{"id": int64(2), "name": "second", "score": float64(3), "status": "new", "active": false},
+		}
+		assert.ElementsMatch(t, expected, importedRows(t, db))
+	})
+
+	t.Run("skip header discards the first record", func(t *testing.T) {
+		t.Parallel()
+		db := newImportTestDB(t)
+
+		input := "whatever;header;this;is;ignored\n1;first;1;done;true\n"
+		n, err := db.ImportCSV(context.Background(), "events", strings.NewReader(input), WithCSVDelimiter(';'), WithCSVSkipHeader())
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), n)
+		assert.Len(t, importedRows(t, db), 1)
+	})
+
+	t.Run("bad value fails the whole import and reports its line", func(t *testing.T) {
+		t.Parallel()
+		db := newImportTestDB(t)
+
+		input := "id,name\n1,first\ntwo,second\n3,third\n"
+		n, err := db.ImportCSV(context.Background(), "events", strings.NewReader(input))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `line 3: column "id": expected an integer, got "two"`)
+		assert.Equal(t, int64(0), n)
+		assert.Empty(t, importedRows(t, db))
+	})
+
+	t.Run("empty field in a numeric column without empty as null fails", func(t *testing.T) {
+		t.Parallel()
+		db := newImportTestDB(t)
+
+		_, err := db.ImportCSV(context.Background(), "events", strings.NewReader("id,name,score\n1,first,\n"))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `line 2: column "score": expected a number`)
+	})
+
+	t.Run("continue on error skips bad rows", func(t *testing.T) {
+		t.Parallel()
+		db := newImportTestDB(t)
+
+		input := strings.Join([]string{
+			"id,name",
+			"1,first",
+			"two,second",
+			"3",
+			"4,fourth",
+			"5,this name is far too long to fit in a varchar fifty column",
+			"6,sixth",
+		}, "\n")
+		var skipped []int
+		n, err := db.ImportCSV(context.Background(), "events", strings.NewReader(input), WithImportBatchSize(2), WithContinueOnError(func(line int, err error) {
+			skipped = append(skipped, line)
+		}))
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), n)
+		assert.Equal(t, []int{3, 4, 6}, skipped)
+
+		var ids []int64
+		for _, row := range importedRows(t, db) {
+			ids = append(ids, row["id"].(int64))
+		}
+		assert.ElementsMatch(t, []int64{1, 4, 6}, ids)
+	})
+
+	t.Run("continue on error is rejected inside a transaction", func(t *testing.T) {
+		t.Parallel()
+		db := newImportTestDB(t)
+
+		execInTx(t, db, func(ctx context.Context) {
+			_, err := db.ImportCSV(ctx, "events", strings.NewReader("id,name\n1,first\n"), WithContinueOnError(nil))
+			require.Error(t, err)
+		})
+	})
+
+	t.Run("unknown and duplicate header columns", func(t *testing.T) {
+		t.Parallel()
+		db := newImportTestDB(t)
+
+		_, err := db.ImportCSV(context.Background(), "events", strings.NewReader("id,name,source\n1,first,api\n"))
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, ErrImportUnknownColumn))
+
+		n, err := db.ImportCSV(context.Background(), "events", strings.NewReader("id,name,source\n1,first,api\n"), WithLenientImport())
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), n)
+
+		_, err = db.ImportCSV(context.Background(), "events", strings.NewReader("id,name,id\n1,first,1\n"))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `duplicate column "id"`)
+	})
+
+	t.Run("unknown table", func(t *testing.T) {
+		t.Parallel()
+		db := newImportTestDB(t)
+
+		_, err := db.ImportCSV(context.Background(), "missing", strings.NewReader("id\n1\n"))
+		require.Error(t, err)
+	})
+}
@@ -79,9 +79,18 @@ func (c *Cursor) LeafNodeSplitInsert(ctx context.Context, key RowID, row Row) er
 
 	newPage.LeafNode = NewLeafNode()
 	newPage.LeafNode.Header.Parent = splitPage.LeafNode.Header.Parent
+	newPage.LeafNode.Header.Hint = newPageHint(c.Table.Columns)
 
 	newPage.LeafNode.Header.NextLeaf = splitPage.LeafNode.Header.NextLeaf
+	newPage.LeafNode.Header.PrevLeaf = splitPage.Index
 	splitPage.LeafNode.Header.NextLeaf = newPage.Index
+	if nextLeaf := newPage.LeafNode.Header.NextLeaf; nextLeaf != 0 {
+		nextPage, err := pager.ModifyPage(ctx, nextLeaf)
+		if err != nil {
+			return fmt.Errorf("get next leaf page: %w", err)
+		}
+		nextPage.LeafNode.Header.PrevLeaf = newPage.Index
+	}
 
 	// Keep the rightmost-page hint current: if the new page is the last leaf
 	// (NextLeaf == 0), it becomes the new rightmost page for future inserts.
@@ -281,6 +290,10 @@ func (c *Cursor) update(ctx context.Context, stmt Statement, row Row) (bool, err
 		if _, isSub := value.Value.(*Statement); isSub {
 			return false, fmt.Errorf("internal error: unresolved correlated subquery for column %q in cursor.update", name)
 		}
+		col, idx := row.GetColumn(name)
+		if idx < 0 {
+			return false, fmt.Errorf("column '%s' not found", name)
+		}
 		// Evaluate arithmetic expressions against the current row before applying.
 		if expr, ok := value.Value.(*Expr); ok {
 			result, err := expr.Eval(row)
@@ -290,13 +303,9 @@ func (c *Cursor) update(ctx context.Context, stmt Statement, row Row) (bool, err
 			if result == nil {
 				value = OptionalValue{Valid: false}
 			} else {
-				value = OptionalValue{Value: result, Valid: true}
+				value = OptionalValue{Value: expr.wrapInt4(col.Kind, result), Valid: true}
 			}
 		}
-		col, idx := row.GetColumn(name)
-		if idx < 0 {
-			return false, fmt.Errorf("column '%s' not found", name)
-		}
 		// Normalise JSON values to compact form before writing.
 		if col.Kind == JSON && value.Valid {
 			if tp, ok := value.Value.(TextPointer); ok {
@@ -319,6 +328,14 @@ func (c *Cursor) update(ctx context.Context, stmt Statement, row Row) (bool, err
 		return false, nil
 	}
 
+	if c.Table.hasGeneratedColumns {
+		var err error
+		row, stmt.Updates, err = applyGeneratedColumns(row, stmt.Updates, changedValues)
+		if err != nil {
+			return false, err
+		}
+	}
+
 	if err := validateCheckConstraints(c.Table.Columns, row); err != nil {
 		return false, err
 	}
@@ -356,7 +373,7 @@ func (c *Cursor) update(ctx context.Context, stmt Statement, row Row) (bool, err
 	// we need to delete the old row and re-insert the new row. This will likely cause
 	// a split, but that's better than trying to move rows around in the page. Since we
 	// use internal row IDs as keys, we will reinsert to the same page.
-	if row.Size() > page.LeafNode.AvailableSpace()+oldRow.Size() {
+	if cellSize(row) > page.LeafNode.AvailableSpace()+page.LeafNode.Cells[c.CellIdx].Size() {
 		// Delete the row
 		if err := c.delete(ctx, oldRow); err != nil {
 			return false, fmt.Errorf("update delete old row: %w", err)
@@ -532,6 +549,10 @@ func (c *Cursor) update(ctx context.Context, stmt Statement, row Row) (bool, err
 
 	cell.NullBitmask = row.NullBitmask()
 	cell.Value = rowBuf
+	// A row written before ADD COLUMN now carries every column, so the cell
+	// must describe the current schema rather than the one it was written with.
+	cell.TypeCodes = c.Table.cachedTypeCodes
+	cell.ColumnCount = uint8(len(row.Columns))
 
 	return true, nil
 }
@@ -624,6 +645,9 @@ func (c *Cursor) deleteUniqueIndexKeys(ctx context.Context, row Row) error {
 		if !ok {
 			return fmt.Errorf("unique index key %s not found in row", uniqueIndex.Name)
 		}
+		if !indexValue.Valid {
+			continue // NULL — was never indexed
+		}
 
 		castedValue, err := castKeyValue(uniqueIndex.Columns[0], indexValue.Value)
 		if err != nil {
@@ -733,6 +757,9 @@ func (c *Cursor) deleteSecondaryIndexKeys(ctx context.Context, row Row) error {
 		if !ok {
 			return fmt.Errorf("unique index key %s not found in row", secondaryIndex.Name)
 		}
+		if !indexValue.Valid {
+			continue // NULL — was never indexed
+		}
 
 		castedValue, err := castKeyValue(secondaryIndex.Columns[0], indexValue.Value)
 		if err != nil {
@@ -6,9 +6,11 @@ import (
 	"errors"
 	"fmt"
 	"math"
+	"slices"
 	"sort"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"go.uber.org/zap"
@@ -52,7 +54,9 @@ type Database struct {
 	saver          PageSaver
 	lockedProvider TableProvider
 	stmtCache      LRUCache[string]
+	parseCache     LRUCache[string] // SQL text → []Statement for PrepareStatements
 	planCache      LRUCache[string]
+	queryCache     LRUCache[string] // nil unless WithQueryCache is set
 	tables         map[string]*Table
 	txManager      *TransactionManager
 	metrics        *engineMetrics
@@ -72,6 +76,30 @@ type Database struct {
 	// foreignKeysEnabled controls whether FK constraints are enforced.
 	// Default true; toggled by PRAGMA foreign_keys = on|off.
 	foreignKeysEnabled bool
+	// maxIdentifierLength limits table, column and index names at CREATE time.
+	maxIdentifierLength int
+	// maxSubqueryRows limits the distinct values an IN subquery materialises.
+	maxSubqueryRows int
+	// queryTimeout is the default QueryLimits.MaxDuration for statements whose
+	// context sets none.  0 disables it.
+	queryTimeout time.Duration
+	// safeMode rejects UPDATE and DELETE statements without a WHERE clause.
+	// Default false; set by WithSafeMode or PRAGMA safe_mode = on|off.
+	safeMode bool
+	// integerOverflow selects whether integer arithmetic and casts fail or
+	// wrap around on overflow.  Default IntegerOverflowError.
+	integerOverflow IntegerOverflow
+	// queryLog records every top-level statement when configured via
+	// WithQueryLog or WithZapQueryLog; nil disables it.
+	queryLog *queryLog
+	// queryLogRedactArgs replaces bound argument values in query log entries.
+	queryLogRedactArgs bool
+	// autoVacuumThreshold is the free-page ratio at or above which a commit
+	// schedules an automatic VACUUM.  0 disables autovacuum.
+	autoVacuumThreshold float64
+	// autoVacuumPending is set by checkAutoVacuum after a commit crosses
+	// autoVacuumThreshold and cleared when RunPendingAutoVacuum compacts.
+	autoVacuumPending atomic.Bool
 	// encryptionKey holds the caller-supplied raw key material used to derive
 	// the AES-256-CTR page cipher.  nil when encryption is disabled.
 	encryptionKey []byte
@@ -81,10 +109,32 @@ type Database struct {
 	// hnswVecCacheSize is the maximum number of vector entries per HNSW index LRU
 	// cache.  Defaults to defaultHNSWVecCacheSize.
 	hnswVecCacheSize int
+	// checkpointInterval is the period of the background WAL checkpoint
+	// scheduler.  0 disables the scheduler.
+	checkpointInterval time.Duration
+	// newCheckpointTicker creates the scheduler's ticker.  Nil in production
+	// (a time.Ticker is used); set by tests to drive the scheduler manually.
+	newCheckpointTicker func(time.Duration) checkpointTicker
+	// checkpointScheduler is the running background checkpoint loop, nil when
+	// the scheduler is disabled or stopped.
+	checkpointScheduler *checkpointScheduler
 	// backupHook is called by Backup after the WAL snapshot is taken and
 	// walWriteMu is released, just before the page-copy loop begins.
 	// Nil in production; set by tests to inject concurrent operations.
 	backupHook func()
+	// insertBatchSize splits multi-row INSERTs into batches of at most this
+	// many rows (0 = never split); see WithInsertBatchSize.
+	insertBatchSize int
+	// insertBatchCommit commits each batch of an auto-commit INSERT in its
+	// own transaction; see WithInsertBatchCommit.
+	insertBatchCommit bool
+	// insertBatchHook is called with the enclosing transaction after each
+	// INSERT batch is written.  Nil in production; set by tests.
+	insertBatchHook func(*Transaction)
+	// functions holds the SQL functions registered from Go, keyed by
+	// upper-cased name; see RegisterFunction.
+	functions   map[string]*ScalarFunction
+	functionsMu sync.RWMutex
 }
 
 type clock func() Time
@@ -94,20 +144,23 @@ type clock func() Time
 // that do not require WAL (commits fall back to writing directly to the pager).
 func NewDatabase(ctx context.Context, logger *zap.Logger, dbFilePath string, parser Parser, factory PagerFactory, saver PageSaver, walCfg *WALConfig, opts ...DatabaseOption) (*Database, error) {
 	db := &Database{
-		dbFilePath:         dbFilePath,
-		parser:             parser,
-		factory:            factory,
-		saver:              saver,
-		tables:             make(map[string]*Table),
-		rowCounts:          make(map[string]int64),
-		referencedBy:       make(map[string][]inboundFK),
-		foreignKeysEnabled: true,
-		sortMemLimit:       defaultSortMemLimit,
-		hnswVecCacheSize:   defaultHNSWVecCacheSize,
-		dbLock:             new(sync.RWMutex),
-		stmtCache:          lrucache.New[string](defaultMaxCachedStatements),
-		planCache:          lrucache.New[string](defaultMaxCachedPlans),
-		logger:             logger,
+		dbFilePath:          dbFilePath,
+		parser:              parser,
+		factory:             factory,
+		saver:               saver,
+		tables:              make(map[string]*Table),
+		rowCounts:           make(map[string]int64),
+		referencedBy:        make(map[string][]inboundFK),
+		foreignKeysEnabled:  true,
+		sortMemLimit:        defaultSortMemLimit,
+		maxIdentifierLength: DefaultMaxIdentifierLength,
+		maxSubqueryRows:     DefaultMaxSubqueryRows,
+		hnswVecCacheSize:    defaultHNSWVecCacheSize,
+		dbLock:              new(sync.RWMutex),
+		stmtCache:           lrucache.New[string](defaultMaxCachedStatements),
+		parseCache:          lrucache.New[string](defaultMaxCachedStatements),
+		planCache:           lrucache.New[string](defaultMaxCachedPlans),
+		logger:              logger,
 		clock: func() Time {
 			now := time.Now().UTC()
 			return Time{
@@ -157,6 +210,9 @@ func NewDatabase(ctx context.Context, logger *zap.Logger, dbFilePath string, par
 	for _, opt := range opts {
 		opt(db)
 	}
+	if db.autoVacuumThreshold > 0 {
+		db.txManager.SetAfterCommitFunc(db.checkAutoVacuum)
+	}
 
 	if err := db.setupEncryption(ctx); err != nil {
 		return nil, fmt.Errorf("setup encryption: %w", err)
@@ -168,6 +224,8 @@ func NewDatabase(ctx context.Context, logger *zap.Logger, dbFilePath string, par
 		return nil, err
 	}
 
+	db.startCheckpointScheduler()
+
 	return db, nil
 }
 
@@ -232,8 +290,10 @@ func (p *lockedTableProvider) GetTable(ctx context.Context, name string) (*Table
 func (d *Database) PrepareStatement(ctx context.Context, query string) (Statement, error) {
 	// Check cache first
 	if stmt, ok := d.stmtCache.Get(query); ok {
+		d.recordStatementCache(true)
 		return stmt.(Statement), nil
 	}
+	d.recordStatementCache(false)
 
 	// Parse the statement
 	statements, err := d.parser.Parse(ctx, query)
@@ -252,6 +312,10 @@ func (d *Database) PrepareStatement(ctx context.Context, query string) (Statemen
 	stmt := statements[0]
 	stmt.CacheKey = query
 
+	if err := d.resolveFunctions(&stmt); err != nil {
+		return Statement{}, err
+	}
+
 	// Pre-allocate the insert column-order cache so all clones share one object.
 	// INSERT … SELECT cannot use the cache: column layout comes from the runtime
 	// SELECT result, not from the static statement structure.
@@ -271,6 +335,8 @@ func (d *Database) PrepareStatement(ctx context.Context, query string) (Statemen
 
 // Close flushes and closes the underlying page storage.
 func (d *Database) Close() error {
+	d.stopCheckpointScheduler()
+
 	// Passive checkpoint on close (mirrors SQLite behaviour): if there are
 	// committed WAL frames that have not yet been written to the DB file, flush
 	// them now so the DB file is a complete snapshot.  This limits WAL growth
@@ -346,6 +412,7 @@ func (d *Database) Reopen(ctx context.Context, factory PagerFactory, saver PageS
 	var (
 		checkpointThreshold = d.txManager.checkpointThreshold
 		checkpointFn        = d.txManager.checkpointFn
+		afterCommitFn       = d.txManager.afterCommitFn
 	)
 	d.txManager = NewTransactionManager(d.logger, d.dbFilePath, d.pagerFactory, saver, d)
 	d.txManager.SetRowCountApplier(d.applyRowCountDeltas)
@@ -354,6 +421,13 @@ func (d *Database) Reopen(ctx context.Context, factory PagerFactory, saver PageS
 	// is wired in after Reopen (e.g. in vacuumWithKey which sets up WAL after init).
 	d.txManager.checkpointThreshold = checkpointThreshold
 	d.txManager.SetCheckpointFunc(checkpointFn)
+	d.txManager.SetAfterCommitFunc(afterCommitFn)
+	if d.queryCache != nil {
+		// Commit sequences restart with the new manager, so entries stamped
+		// with the old manager's snapshots can no longer be validated.
+		d.queryCache.Purge()
+		d.txManager.TrackTableWrites()
+	}
 	if d.wal != nil {
 		d.txManager.wal = d.wal
 		d.txManager.walIndex = d.walIndex
@@ -454,29 +528,31 @@ func (d *Database) rowCountGetter(tableName string) func() int64 {
 // stores the result in d.rowCounts[tableName].  It also wires up the O(1)
 // getter on the table so future COUNT(*) calls bypass the walk entirely.
 func (d *Database) initTableRowCount(ctx context.Context, tableName string, table *Table) {
-	// getRowCount is nil at this point, so countAllLeafWalk falls back to the
-	// leaf walk — exactly what we want for the initial population.
-	result, err := table.countAllLeafWalk(ctx)
+	// getRowCount is nil at this point, so RowCount falls back to the leaf
+	// walk — exactly what we want for the initial population.
+	count, err := table.RowCount(ctx)
 	if err != nil {
 		d.logger.Warn("failed to initialise row count; COUNT(*) will fall back to leaf walk",
 			zap.String("table", tableName), zap.Error(err))
 		return
 	}
-	var count int64
-	if result.Rows.Next(ctx) {
-		row := result.Rows.Row()
-		if len(row.Values) > 0 {
-			if n, ok := row.Values[0].Value.(int64); ok {
-				count = n
-			}
-		}
-	}
 	d.rowCountsMu.Lock()
 	d.rowCounts[tableName] = count
 	d.rowCountsMu.Unlock()
 	table.getRowCount = d.rowCountGetter(tableName)
 }
 
+// RowCount returns the number of rows in the named table. Inside a write
+// transaction the count includes the transaction's own uncommitted inserts
+// and deletes.
+func (d *Database) RowCount(ctx context.Context, name string) (int64, error) {
+	table, ok := d.GetTable(ctx, name)
+	if !ok {
+		return 0, minisqlErrors.ErrNoSuchTable{Name: name}
+	}
+	return table.RowCount(ctx)
+}
+
 // SaveDDLChanges applies committed DDL changes (table/index creates and drops) to the in-memory schema.
 func (d *Database) SaveDDLChanges(ctx context.Context, changes DDLChanges) {
 	if !changes.HasChanges() {
@@ -509,6 +585,47 @@ func (d *Database) SaveDDLChanges(ctx context.Context, changes DDLChanges) {
 	}
 }
 
+// DiscardDDLChanges reverts the in-memory effects of DDL that a rolled-back
+// transaction applied eagerly. Table renames and column changes are undone in
+// reverse order so that chained renames (a → b → c) unwind back to the
+// original name and a table ends up with the columns it had before the
+// transaction's first ALTER TABLE.
+func (d *Database) DiscardDDLChanges(ctx context.Context, changes DDLChanges) {
+	if len(changes.RenameTables) == 0 && len(changes.TruncateTables) == 0 && len(changes.AlterColumns) == 0 {
+		return
+	}
+
+	d.dbLock.Lock()
+	defer d.dbLock.Unlock()
+
+	// TRUNCATE TABLE reset the autoincrement counter, which may now be lower
+	// than the keys restored by the rollback. Let the next insert reseed it
+	// from the primary key index.
+	for _, tableName := range changes.TruncateTables {
+		if table, ok := d.tables[tableName]; ok {
+			table.lastAutoincrementKey.Store(-1)
+		}
+	}
+	if len(changes.RenameTables) == 0 && len(changes.AlterColumns) == 0 {
+		return
+	}
+	for _, change := range slices.Backward(changes.AlterColumns) {
+		change.Table.setColumns(change.Columns)
+	}
+	for _, rename := range slices.Backward(changes.RenameTables) {
+		// Tables created by the rolled-back transaction are not in the tables
+		// map and are discarded along with it.
+		if table, ok := d.tables[rename.NewName]; ok {
+			d.renameTable(table, rename.NewName, rename.OldName)
+		}
+	}
+	d.planCache.Purge()
+	d.purgeStatementCaches()
+	if d.queryCache != nil {
+		d.queryCache.Purge()
+	}
+}
+
 // ListTableNames lists names of all tables in the database
 func (d *Database) ListTableNames(ctx context.Context) []string {
 	d.dbLock.RLock()
@@ -521,13 +638,54 @@ func (d *Database) ListTableNames(ctx context.Context) []string {
 	return tables
 }
 
-// PrepareStatements parses SQL into a slice of Statement struct
+// PrepareStatements parses SQL into a slice of Statement struct. Parsed
+// statements are cached by SQL text without surrounding whitespace, so
+// repeating a query skips the parser; the cache is purged whenever DDL
+// changes the schema. Whitespace inside the text is kept as is, since it may
+// be part of a string literal.
 func (d *Database) PrepareStatements(ctx context.Context, sql string) ([]Statement, error) {
+	key := strings.TrimSpace(sql)
+	if stmts, ok := d.parseCache.Get(key); ok {
+		d.recordStatementCache(true)
+		return cloneStatements(stmts.([]Statement)), nil
+	}
+	d.recordStatementCache(false)
+
 	stmts, err := d.parser.Parse(ctx, sql)
 	if err != nil {
 		return nil, err
 	}
-	return stmts, nil
+	d.parseCache.Put(key, stmts, true)
+	return cloneStatements(stmts), nil
+}
+
+// cloneStatements returns copies of cached statements that are safe to bind
+// and execute, since execution may modify a statement's values in place.
+func cloneStatements(stmts []Statement) []Statement {
+	cloned := make([]Statement, len(stmts))
+	for i, stmt := range stmts {
+		cloned[i] = stmt.Clone()
+	}
+	return cloned
+}
+
+// recordStatementCache counts a statement cache lookup in the engine metrics.
+func (d *Database) recordStatementCache(hit bool) {
+	if d.metrics == nil {
+		return
+	}
+	if hit {
+		d.metrics.stmtCacheHits.Add(1)
+	} else {
+		d.metrics.stmtCacheMisses.Add(1)
+	}
+}
+
+// purgeStatementCaches drops every cached parsed statement. Prepared INSERTs
+// cache the table's column order, so they must not outlive a schema change.
+func (d *Database) purgeStatementCaches() {
+	d.stmtCache.Purge()
+	d.parseCache.Purge()
 }
 
 // GetTransactionManager returns the transaction manager for this database
@@ -550,8 +708,58 @@ func (d *Database) GetFileName() string {
 	return d.dbFilePath
 }
 
-// ExecuteStatement executes a single statement and returns the result
+// InMemory reports whether the database was opened at MemoryPath and so has
+// no file on disk.
+func (d *Database) InMemory() bool {
+	return d.dbFilePath == MemoryPath
+}
+
+// ExecuteStatement executes a single statement and returns the result.
+// When a query log is configured, the top-level statement is recorded after
+// it completes; statements it executes internally are not logged separately.
+// When a query cache is configured, a top-level SELECT may be answered from
+// it; see WithQueryCache.
+// Limits attached to ctx with WithQueryLimits apply to the top-level
+// statement and everything it executes internally.
 func (d *Database) ExecuteStatement(ctx context.Context, stmt Statement) (StatementResult, error) {
+	if (d.queryLog == nil && d.queryCache == nil) || ctx.Value(ctxKeyNestedStatement{}) != nil {
+		return d.executeStatementWithLimits(ctx, stmt)
+	}
+
+	start := time.Now()
+	result, err := d.executeCachedStatement(context.WithValue(ctx, ctxKeyNestedStatement{}, true), stmt)
+	if d.queryLog != nil {
+		d.logQuery(ctx, stmt, start, result, err)
+	}
+	return result, err
+}
+
+// executeSelectForUpdate runs a SELECT … FOR UPDATE inside the caller's write
+// transaction. The engine allows a single active writer, so holding the write
+// transaction already blocks every concurrent modification until it commits or
+// rolls back; the rows are read eagerly so that they are not fetched lazily
+// after an auto-commit transaction has ended.
+func (d *Database) executeSelectForUpdate(ctx context.Context, stmt Statement) (StatementResult, error) {
+	if err := stmt.validateForUpdate(); err != nil {
+		return StatementResult{}, err
+	}
+	stmt.ForUpdate = false
+	result, err := d.executeStatement(ctx, stmt)
+	if err != nil {
+		return StatementResult{}, err
+	}
+	rows, err := materializeResultRows(ctx, result)
+	if err != nil {
+		return StatementResult{}, err
+	}
+	return StatementResult{
+		Columns: result.Columns,
+		Rows:    NewSliceIterator(rows),
+		rawRows: rows,
+	}, nil
+}
+
+func (d *Database) executeStatement(ctx context.Context, stmt Statement) (StatementResult, error) {
 	tx := TxFromContext(ctx)
 	if tx == nil {
 		return StatementResult{}, errors.New("statement must be executed from within a transaction")
@@ -560,19 +768,47 @@ func (d *Database) ExecuteStatement(ctx context.Context, stmt Statement) (Statem
 	if !stmt.ReadOnly() && isSystemTable(stmt.TableName) {
 		return StatementResult{}, fmt.Errorf("cannot write to system table %s", stmt.TableName)
 	}
+	if tx.ReadOnly && !stmt.ReadOnly() {
+		return StatementResult{}, fmt.Errorf("%w: %s", ErrReadOnlyTransaction, stmt.Kind)
+	}
+	if err := d.resolveFunctions(&stmt); err != nil {
+		return StatementResult{}, err
+	}
+	if d.integerOverflow == IntegerOverflowWrap {
+		setStatementIntegerOverflowWrap(&stmt)
+		d.dbLock.RLock()
+		if table, ok := d.tables[stmt.TableName]; ok {
+			setTableIntegerOverflowWrap(table)
+		}
+		d.dbLock.RUnlock()
+	}
+	if stmt.ForUpdate {
+		if tx.ReadOnly {
+			return StatementResult{}, ErrForUpdateReadOnly
+		}
+		return d.executeSelectForUpdate(ctx, stmt)
+	}
 
 	switch stmt.Kind {
 	case Vacuum:
 		// VACUUM manages its own locking and creates a fresh transaction
 		// manager on completion, so it must not go through the normal DDL
-		// path (which would deadlock by re-acquiring dbLock).
-		return StatementResult{}, d.Vacuum(ctx)
+		// path (which would deadlock by re-acquiring dbLock). The number of
+		// reclaimed pages is reported as the affected count.
+		reclaimed, err := d.Vacuum(ctx)
+		return StatementResult{RowsAffected: reclaimed}, err
 	case Pragma:
 		return d.executePragmaStatement(ctx, stmt)
 	case Explain:
 		return d.executeExplain(ctx, stmt)
-	case CreateTable, DropTable, CreateIndex, DropIndex, AlterTable:
+	case CreateTable, DropTable, CreateIndex, DropIndex, AlterTable, AlterIndex:
 		return d.executeDDLStatement(ctx, stmt)
+	case TruncateTable:
+		table, ok := d.GetTable(ctx, stmt.TableName)
+		if !ok {
+			return StatementResult{}, minisqlErrors.ErrNoSuchTable{Name: stmt.TableName}
+		}
+		return d.executeTableStatement(ctx, table, stmt)
 	case Insert, Select, Update, Delete:
 		// WITH … SELECT — CTE statement. Route before resolveSubqueries because
 		// the outer WHERE may reference CTE names that only become resolvable
@@ -581,6 +817,10 @@ func (d *Database) ExecuteStatement(ctx context.Context, stmt Statement) (Statem
 			return d.executeCTESelect(ctx, stmt)
 		}
 
+		if err := d.checkINSubqueryTypes(ctx, stmt); err != nil {
+			return StatementResult{}, err
+		}
+
 		// Convert eligible IN/NOT IN (subquery) conditions to semi-joins before
 		// resolveSubqueries so that the join planner can use early termination
 		// and avoid full materialisation of the inner result set.
@@ -588,6 +828,15 @@ func (d *Database) ExecuteStatement(ctx context.Context, stmt Statement) (Statem
 			stmt = liftINSubqueriesToSemiJoins(stmt)
 		}
 
+		// Safe mode: flag unconditional UPDATE/DELETE so Validate rejects them.
+		// The flag is captured before constant folding so that an explicit
+		// always-true predicate (WHERE 1=1) still counts as a WHERE clause.
+		if stmt.Kind == Update || stmt.Kind == Delete {
+			d.dbLock.RLock()
+			stmt.safeMode = d.safeMode && len(stmt.Conditions) == 0
+			d.dbLock.RUnlock()
+		}
+
 		// Pre-evaluate any non-correlated scalar subqueries in the WHERE clause.
 		if len(stmt.Conditions) > 0 {
 			resolved, err := d.resolveSubqueries(ctx, stmt.Conditions)
@@ -625,7 +874,7 @@ func (d *Database) ExecuteStatement(ctx context.Context, stmt Statement) (Statem
 		// occur if materialisation happened inside executeTableStatement (which
 		// holds the exclusive write lock) and the FROM source is a subquery
 		// (which calls GetTable → RLock).
-		if stmt.Kind == Update && (stmt.UpdateFromTable != "" || stmt.UpdateFromSubquery != nil) {
+		if stmt.IsUpdateFrom() {
 			fromRows, err := d.materialiseFromSource(ctx, stmt)
 			if err != nil {
 				return StatementResult{}, err
@@ -658,6 +907,18 @@ func (d *Database) ExecuteStatement(ctx context.Context, stmt Statement) (Statem
 			return StatementResult{}, minisqlErrors.ErrNoSuchTable{Name: stmt.TableName}
 		}
 
+		if stmt.Kind == Select {
+			var err error
+			stmt, err = stmt.expandQualifiedWildcards(ctx, d)
+			if err != nil {
+				return StatementResult{}, err
+			}
+		}
+
+		if selectsRowID(stmt, table) {
+			return d.selectWithRowID(ctx, table, stmt)
+		}
+
 		return d.executeTableStatement(ctx, table, stmt)
 	}
 	return StatementResult{}, errUnrecognizedStatementType
@@ -1190,6 +1451,9 @@ func (d *Database) executeDDLStatement(ctx context.Context, stmt Statement) (Sta
 		}
 	}
 
+	if stmt.Kind == CreateTable || stmt.Kind == CreateIndex || stmt.Kind == AlterIndex {
+		stmt.maxIdentifierLength = d.maxIdentifierLength
+	}
 	if err := stmt.Validate(table); err != nil {
 		return StatementResult{}, err
 	}
@@ -1203,7 +1467,7 @@ func (d *Database) executeDDLStatement(ctx context.Context, stmt Statement) (Sta
 	case CreateTable:
 		_, execErr = d.createTable(ctx, stmt)
 	case DropTable:
-		execErr = d.dropTable(ctx, stmt.TableName)
+		execErr = d.dropTable(ctx, stmt.TableName, stmt.Cascade)
 	case CreateIndex:
 		execErr = d.createIndex(ctx, stmt, table)
 	case DropIndex:
@@ -1212,6 +1476,8 @@ func (d *Database) executeDDLStatement(ctx context.Context, stmt Statement) (Sta
 		execErr = d.Analyze(ctx, stmt.TableName)
 	case AlterTable:
 		execErr = d.executeAlterTable(ctx, stmt)
+	case AlterIndex:
+		execErr = d.executeAlterIndex(ctx, stmt)
 	default:
 		return StatementResult{}, fmt.Errorf("unrecognized DDL statement type: %v", stmt.Kind)
 	}
@@ -1220,6 +1486,10 @@ func (d *Database) executeDDLStatement(ctx context.Context, stmt Statement) (Sta
 	// CreateTable is excluded: no existing plan targets a brand-new table.
 	if execErr == nil && stmt.Kind != CreateTable {
 		d.planCache.Purge()
+		d.purgeStatementCaches()
+	}
+	if execErr == nil && d.queryCache != nil {
+		d.queryCache.Purge()
 	}
 
 	return StatementResult{}, execErr
@@ -1247,16 +1517,18 @@ func (d *Database) executeTableStatement(ctx context.Context, table *Table, stmt
 
 	switch stmt.Kind {
 	case Insert:
-		return table.Insert(ctx, stmt)
+		return d.insertInBatches(ctx, table, stmt)
 	case Select:
 		return table.Select(ctx, stmt)
 	case Update:
-		if stmt.UpdateFromTable != "" || stmt.UpdateFromSubquery != nil {
+		if stmt.IsUpdateFrom() {
 			return d.executeUpdateFrom(ctx, stmt)
 		}
 		return table.Update(ctx, stmt)
 	case Delete:
 		return table.Delete(ctx, stmt)
+	case TruncateTable:
+		return d.truncateTable(ctx, table)
 	}
 
 	return StatementResult{}, fmt.Errorf("unrecognized table statement type: %v", stmt.Kind)
@@ -1284,6 +1556,9 @@ func (d *Database) materialiseInsertSelect(ctx context.Context, stmt Statement)
 		}
 	}
 
+	table, wrapInt4 := d.GetTable(ctx, stmt.TableName)
+	wrapInt4 = wrapInt4 && d.integerOverflow == IntegerOverflowWrap
+
 	inserts := make([][]OptionalValue, 0, len(rows))
 	for _, row := range rows {
 		if len(row.Values) != nInsertFields {
@@ -1294,6 +1569,9 @@ func (d *Database) materialiseInsertSelect(ctx context.Context, stmt Statement)
 		}
 		insertRow := make([]OptionalValue, nInsertFields)
 		copy(insertRow, row.Values)
+		if wrapInt4 {
+			wrapInsertSelectInt4(table, stmt, insertRow)
+		}
 		inserts = append(inserts, insertRow)
 	}
 	stmt.Inserts = inserts
@@ -1479,6 +1757,7 @@ func (d *Database) createTable(ctx context.Context, stmt Statement) (*Table, err
 	}
 	freePage.LeafNode = NewLeafNode()
 	freePage.LeafNode.Header.IsRoot = true
+	freePage.LeafNode.Header.Hint = newPageHint(stmt.Columns)
 
 	// Validate FK targets before creating the table.
 	for _, fk := range stmt.ForeignKeys {
@@ -1592,6 +1871,10 @@ func (d *Database) createTable(ctx context.Context, stmt Statement) (*Table, err
 	}
 
 	tx.DDLChanges = tx.DDLChanges.CreatedTable(createdTable)
+	// Wire the row-count getter now so rows inserted by the creating
+	// transaction are recorded as deltas; the count itself is tracked from
+	// commit onwards (SaveDDLChanges).
+	createdTable.getRowCount = d.rowCountGetter(createdTable.Name)
 
 	// Register any FK constraints in the referencedBy map and wire up callbacks.
 	for _, fk := range createdTable.ForeignKeys {
@@ -1616,10 +1899,10 @@ func (d *Database) createTable(ctx context.Context, stmt Statement) (*Table, err
 	return createdTable, nil
 }
 
-// dropTable drops a table and all its data
-func (d *Database) dropTable(ctx context.Context, name string) error {
-	tx := MustTxFromContext(ctx)
-
+// dropTable drops a table and all its data. A table referenced by another
+// table's foreign key can only be dropped with cascade, which drops every
+// table that depends on it, directly or transitively, as well.
+func (d *Database) dropTable(ctx context.Context, name string, cascade bool) error {
 	// Table could only exist within this transaction so create it from the system table
 	_, exists, err := d.checkSchemaExists(ctx, SchemaTable, name)
 	if err != nil {
@@ -1628,22 +1911,72 @@ func (d *Database) dropTable(ctx context.Context, name string) error {
 	if !exists {
 		return minisqlErrors.ErrNoSuchTable{Name: name}
 	}
-	tableToDelete := d.tables[name]
-
-	d.logger.Sugar().With("name", tableToDelete.Name).Debug("dropping table")
 
-	// Refuse drop if another table's FK references this table.
 	if d.foreignKeysEnabled {
-		if inbounds := d.referencedBy[name]; len(inbounds) > 0 {
-			return fmt.Errorf("%w: referenced by %s.%v",
-				minisqlErrors.ErrDropTableReferencedByFK, inbounds[0].ChildTable, inbounds[0].FK.Columns)
+		if referencing := d.referencingTables(name); len(referencing) > 0 {
+			if !cascade {
+				return fmt.Errorf("%w: %s is referenced by %s (use DROP TABLE … CASCADE to drop them too)",
+					minisqlErrors.ErrDropTableReferencedByFK, name, strings.Join(referencing, ", "))
+			}
+			for _, dependent := range d.dependentTables(name) {
+				if err := d.dropTableData(ctx, dependent); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return d.dropTableData(ctx, name)
+}
+
+// referencingTables returns the sorted names of other tables with a foreign
+// key targeting name. Self-references do not count.
+func (d *Database) referencingTables(name string) []string {
+	var names []string
+	for _, inbound := range d.referencedBy[name] {
+		if inbound.ChildTable != name && !slices.Contains(names, inbound.ChildTable) {
+			names = append(names, inbound.ChildTable)
 		}
 	}
+	slices.Sort(names)
+	return names
+}
+
+// dependentTables returns every table that references name through a chain
+// of foreign keys, excluding name itself, in breadth-first order.
+func (d *Database) dependentTables(name string) []string {
+	var (
+		seen       = map[string]bool{name: true}
+		dependents []string
+	)
+	for queue := []string{name}; len(queue) > 0; queue = queue[1:] {
+		for _, child := range d.referencingTables(queue[0]) {
+			if seen[child] {
+				continue
+			}
+			seen[child] = true
+			dependents = append(dependents, child)
+			queue = append(queue, child)
+		}
+	}
+	return dependents
+}
+
+// dropTableData removes the schema entries of a table and its indexes, frees
+// all their pages and removes the table from the foreign key graph. Callers
+// check incoming foreign keys first.
+func (d *Database) dropTableData(ctx context.Context, name string) error {
+	tx := MustTxFromContext(ctx)
+	tableToDelete := d.tables[name]
+
+	d.logger.Sugar().With("name", tableToDelete.Name).Debug("dropping table")
 
-	// Remove outgoing FKs from the referencedBy map.
+	// Remove outgoing FKs from the referencedBy map, and any inbound entries
+	// left behind by the table itself or by dependents dropped with it.
 	for _, fk := range tableToDelete.ForeignKeys {
 		d.removeFromReferencedBy(fk.TargetTable, name)
 	}
+	delete(d.referencedBy, name)
 
 	if err := d.deleteSchema(ctx, SchemaTable, tableToDelete.Name); err != nil {
 		return err
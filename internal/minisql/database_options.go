@@ -1,6 +1,9 @@
 package minisql
 
 import (
+	"io"
+	"time"
+
 	"github.com/RichardKnop/minisql/pkg/lrucache"
 )
 
@@ -25,11 +28,13 @@ const (
 	DefaultHNSWVecCacheSize = defaultHNSWVecCacheSize
 )
 
-// WithMaxCachedStatements configures the maximum number of prepared statements to keep in the LRU cache.
+// WithMaxCachedStatements configures the maximum number of parsed statements to keep in the LRU
+// caches used by PrepareStatement and PrepareStatements.
 func WithMaxCachedStatements(maxStatements int) DatabaseOption {
 	return func(d *Database) {
 		if maxStatements > 0 {
 			d.stmtCache = lrucache.New[string](maxStatements)
+			d.parseCache = lrucache.New[string](maxStatements)
 		}
 	}
 }
@@ -45,6 +50,22 @@ func WithMaxCachedPlans(maxPlans int) DatabaseOption {
 	}
 }
 
+// WithQueryCache enables a result cache for up to size read-only SELECT
+// results of at most MaxCachedResultRows rows each. Entries are keyed by the
+// whitespace-normalised SQL text and the bound arguments, and are invalidated
+// as soon as a write to any table the query reads commits, so a hit never
+// returns stale rows. Only statements issued through the driver are cached;
+// queries inside an explicit transaction, with subqueries, CTEs or UNION, or
+// calling NOW() always run.
+func WithQueryCache(size int) DatabaseOption {
+	return func(d *Database) {
+		if size > 0 {
+			d.queryCache = lrucache.New[string](size)
+			d.txManager.TrackTableWrites()
+		}
+	}
+}
+
 // WithParallelScanEnabled turns on concurrent leaf-page scanning for all user tables.
 func WithParallelScanEnabled() DatabaseOption {
 	return func(d *Database) {
@@ -52,6 +73,140 @@ func WithParallelScanEnabled() DatabaseOption {
 	}
 }
 
+// WithIntegerOverflow selects what happens when INT4/INT8 arithmetic or a
+// CAST to an integer type overflows: IntegerOverflowError (the default) fails
+// the statement with ErrIntegerOverflow, IntegerOverflowWrap wraps the value
+// around using two's complement.
+func WithIntegerOverflow(policy IntegerOverflow) DatabaseOption {
+	return func(d *Database) {
+		d.integerOverflow = policy
+	}
+}
+
+// WithInsertBatchSize makes INSERT … VALUES statements with more than size
+// rows insert them size rows at a time. The batches run in the statement's
+// transaction and commit together, so the statement stays all-or-nothing;
+// see WithInsertBatchCommit to commit them separately.
+func WithInsertBatchSize(size int) DatabaseOption {
+	return func(d *Database) {
+		if size > 0 {
+			d.insertBatchSize = size
+		}
+	}
+}
+
+// WithInsertBatchCommit commits each batch of an auto-commit INSERT, split by
+// WithInsertBatchSize, in its own transaction. This bounds the pages held by
+// a single transaction and the WAL it writes at commit, at the cost of
+// atomicity: a failing batch does not undo the batches committed before it.
+// INSERTs inside an explicit transaction always commit together.
+func WithInsertBatchCommit() DatabaseOption {
+	return func(d *Database) {
+		d.insertBatchCommit = true
+	}
+}
+
+// WithSafeMode makes UPDATE and DELETE statements without a WHERE clause fail
+// with ErrSafeModeNoWhere instead of touching every row in the table. Use an
+// explicit predicate such as WHERE 1=1, or PRAGMA safe_mode = off, to override.
+func WithSafeMode(enabled bool) DatabaseOption {
+	return func(d *Database) {
+		d.safeMode = enabled
+	}
+}
+
+// WithMaxIdentifierLength sets the maximum length, in characters, of table,
+// column and index names accepted by CREATE TABLE and CREATE INDEX. The
+// default is DefaultMaxIdentifierLength; values outside 1..MaxInlineVarchar
+// are ignored.
+func WithMaxIdentifierLength(n int) DatabaseOption {
+	return func(d *Database) {
+		if n > 0 && n <= MaxInlineVarchar {
+			d.maxIdentifierLength = n
+		}
+	}
+}
+
+// WithMaxSubqueryRows sets the maximum number of distinct values an
+// IN (SELECT ...) subquery may materialise before the statement fails with
+// ErrSubqueryTooLarge. The default is DefaultMaxSubqueryRows; n <= 0 is
+// ignored. Subqueries executed as semi-joins are not materialised and are not
+// limited.
+func WithMaxSubqueryRows(n int) DatabaseOption {
+	return func(d *Database) {
+		if n > 0 {
+			d.maxSubqueryRows = n
+		}
+	}
+}
+
+// WithQueryTimeout bounds the execution time of every top-level statement
+// whose context does not set QueryLimits.MaxDuration: once d has elapsed the
+// statement is cancelled and fails with ErrQueryTimeLimitExceeded. d <= 0
+// leaves statements unbounded.
+func WithQueryTimeout(d time.Duration) DatabaseOption {
+	return func(db *Database) {
+		if d > 0 {
+			db.queryTimeout = d
+		}
+	}
+}
+
+// WithQueryLog records every top-level statement — SQL text, bound arguments,
+// client, rows affected and duration — to w as one JSON object per line,
+// regardless of how long it took. Writes are serialised, so w does not need to
+// be safe for concurrent use. A nil writer is a no-op.
+func WithQueryLog(w io.Writer) DatabaseOption {
+	return func(d *Database) {
+		if w != nil {
+			d.queryLog = &queryLog{w: w}
+		}
+	}
+}
+
+// WithZapQueryLog records every top-level statement through the Database's
+// zap logger at INFO level instead of a dedicated writer.
+func WithZapQueryLog() DatabaseOption {
+	return func(d *Database) {
+		d.queryLog = &queryLog{logger: d.logger}
+	}
+}
+
+// WithQueryLogRedaction replaces bound argument values in query log entries
+// with a "<redacted>" placeholder, for workloads that bind sensitive data.
+func WithQueryLogRedaction(enabled bool) DatabaseOption {
+	return func(d *Database) {
+		d.queryLogRedactArgs = enabled
+	}
+}
+
+// WithAutoVacuum enables automatic compaction: after each write commit the
+// free-page ratio (free-list pages / total pages) is checked, and once it
+// reaches threshold a VACUUM is scheduled.  The VACUUM itself runs on the
+// next RunPendingAutoVacuum call made outside any transaction.  threshold
+// must be in (0, 1]; other values leave autovacuum disabled.
+func WithAutoVacuum(threshold float64) DatabaseOption {
+	return func(d *Database) {
+		if threshold > 0 && threshold <= 1 {
+			d.autoVacuumThreshold = threshold
+		}
+	}
+}
+
+// WithCheckpointInterval starts a background scheduler that checkpoints the
+// WAL every d, bounding how much committed data crash recovery has to replay
+// when commits rarely reach the frame-count threshold.  Checkpoints run
+// between commits and are skipped while snapshot readers are active.  The
+// scheduler stops when the database is closed.  It has no effect without a
+// WAL; d <= 0 leaves the scheduler disabled.
+func WithCheckpointInterval(d time.Duration) DatabaseOption {
+	return func(db *Database) {
+		if d > 0 {
+			db.checkpointInterval = d
+		}
+	}
+}
+
 // WithSortMemLimit sets the maximum bytes of row data accumulated in memory before
 // spilling to a temp file during an ORDER BY sort. 0 disables external sort.
 // The default is 4 MiB.
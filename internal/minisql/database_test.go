@@ -2,6 +2,8 @@ package minisql
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -9,6 +11,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	minisqlErrors "github.com/RichardKnop/minisql/pkg/errors"
+	"github.com/RichardKnop/minisql/pkg/lrucache"
 )
 
 func TestNewDatabase(t *testing.T) {
@@ -641,21 +644,44 @@ func TestDatabase_Accessors(t *testing.T) {
 func TestDatabase_PrepareStatements(t *testing.T) {
 	t.Parallel()
 
-	// PrepareStatements just delegates to the parser — test with a standalone Database
-	// that has a real parser wired in (drivers do this; unit tests use nil parser).
-	db := &Database{
-		parser: testParser{},
-	}
-	ctx := context.Background()
+	// Test with a standalone Database that has a parser wired in (drivers do
+	// this; unit tests use nil parser).
+	var (
+		ctx        = context.Background()
+		mockParser = new(MockParser)
+		db         = &Database{
+			parser:     mockParser,
+			stmtCache:  lrucache.New[string](100),
+			parseCache: lrucache.New[string](100),
+			metrics:    &engineMetrics{},
+		}
+	)
+	mockParser.On("Parse", ctx, `select 1`).Return([]Statement{{Kind: Select}}, nil).Once()
+
 	stmts, err := db.PrepareStatements(ctx, `select 1`)
 	require.NoError(t, err)
-	require.NotNil(t, stmts)
-}
+	require.Len(t, stmts, 1)
+	assert.Equal(t, Select, stmts[0].Kind)
 
-type testParser struct{}
+	// The second call is served from the cache and returns a fresh copy.
+	stmts[0].TableName = "changed"
+	stmts, err = db.PrepareStatements(ctx, `  select 1`)
+	require.NoError(t, err)
+	require.Len(t, stmts, 1)
+	assert.Equal(t, Select, stmts[0].Kind)
+	assert.Empty(t, stmts[0].TableName)
+	mockParser.AssertExpectations(t)
+
+	// Schema changes drop the cached statements.
+	db.purgeStatementCaches()
+	mockParser.On("Parse", ctx, `select 1`).Return([]Statement{{Kind: Select}}, nil).Once()
+	_, err = db.PrepareStatements(ctx, `select 1`)
+	require.NoError(t, err)
+	mockParser.AssertExpectations(t)
 
-func (testParser) Parse(_ context.Context, sql string) ([]Statement, error) {
-	return []Statement{{Kind: Select}}, nil
+	snapshot := db.ReadEngineMetrics()
+	assert.Equal(t, int64(1), snapshot.StmtCacheHits)
+	assert.Equal(t, int64(2), snapshot.StmtCacheMisses)
 }
 
 func TestDatabase_WireFKCallbacks_ChildOnly(t *testing.T) {
@@ -705,3 +731,185 @@ func collectMainSchemas(ctx context.Context, t *testing.T, aDatabase *Database)
 	require.NoError(t, schemaResults.Rows.Err())
 	return schemas
 }
+
+func TestDatabase_SelectForUpdate(t *testing.T) {
+	t.Parallel()
+	const tableName = "items"
+	createStmt := vacuumCreateStmt(tableName)
+
+	mockParser := new(MockParser)
+	mockParser.On("Parse", mock.Anything, createStmt.DDL()).Return([]Statement{createStmt}, nil)
+
+	db, _ := newVacuumTestDB(t, mockParser)
+	execInTx(t, db, func(ctx context.Context) {
+		_, err := db.ExecuteStatement(ctx, createStmt)
+		require.NoError(t, err)
+	})
+	insertStmt, err := InsertInto(tableName).Columns("id", "name").Values(1, "foo").Build()
+	require.NoError(t, err)
+	execInTx(t, db, func(ctx context.Context) {
+		_, err := db.ExecuteStatement(ctx, insertStmt)
+		require.NoError(t, err)
+	})
+
+	selectStmt, err := SelectFrom(tableName).Build()
+	require.NoError(t, err)
+	selectStmt.ForUpdate = true
+	updateStmt, err := UpdateTable(tableName).Set("name", "bar").Build()
+	require.NoError(t, err)
+
+	t.Run("concurrent modify fails until commit", func(t *testing.T) {
+		ctx := context.Background()
+		tx, err := db.txManager.BeginTransaction(ctx)
+		require.NoError(t, err)
+		txCtx := WithTransaction(ctx, tx)
+
+		result, err := db.ExecuteStatement(txCtx, selectStmt)
+		require.NoError(t, err)
+		rows, err := materializeResultRows(txCtx, result)
+		require.NoError(t, err)
+		require.Len(t, rows, 1)
+
+		// The locking transaction holds the writer slot, so a concurrent
+		// modification is rejected rather than interleaved.
+		err = db.txManager.ExecuteInTransaction(ctx, func(ctx context.Context) error {
+			_, err := db.ExecuteStatement(ctx, updateStmt)
+			return err
+		})
+		require.ErrorIs(t, err, ErrConcurrentWriter)
+
+		require.NoError(t, db.txManager.CommitTransaction(txCtx, tx))
+
+		err = db.txManager.ExecuteInTransaction(ctx, func(ctx context.Context) error {
+			_, err := db.ExecuteStatement(ctx, updateStmt)
+			return err
+		})
+		require.NoError(t, err)
+	})
+
+	t.Run("read-only transaction", func(t *testing.T) {
+		err := db.txManager.ExecuteReadOnlyTransaction(context.Background(), func(ctx context.Context) error {
+			_, err := db.ExecuteStatement(ctx, selectStmt)
+			return err
+		})
+		require.ErrorIs(t, err, ErrForUpdateReadOnly)
+	})
+}
+
+func TestDatabase_RowCountCache(t *testing.T) {
+	t.Parallel()
+
+	const tableName = "items"
+	createStmt := vacuumCreateStmt(tableName)
+
+	mockParser := new(MockParser)
+	mockParser.On("Parse", mock.Anything, createStmt.DDL()).Return([]Statement{createStmt}, nil)
+
+	db, _ := newVacuumTestDB(t, mockParser)
+	errRollback := errors.New("rollback")
+
+	countStar := func(ctx context.Context, t *testing.T, name string) int64 {
+		result, err := db.ExecuteStatement(ctx, Statement{
+			Kind:      Select,
+			TableName: name,
+			Fields:    []Field{{Name: "COUNT(*)"}},
+		})
+		require.NoError(t, err)
+		rows, err := materializeResultRows(ctx, result)
+		require.NoError(t, err)
+		require.Len(t, rows, 1)
+		return rows[0].Values[0].Value.(int64)
+	}
+	// assertCount checks the cached COUNT(*) against a full table scan.
+	assertCount := func(t *testing.T, name string, expected int) {
+		t.Helper()
+		err := db.txManager.ExecuteReadOnlyTransaction(context.Background(), func(ctx context.Context) error {
+			assert.Equal(t, int64(expected), countStar(ctx, t, name))
+			return nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, expected, countRowsInDB(t, db, name))
+	}
+	insert := func(ctx context.Context, t *testing.T, name string, ids ...int) {
+		builder := InsertInto(name).Columns("id", "name")
+		for _, id := range ids {
+			builder.Values(id, fmt.Sprintf("name-%d", id))
+		}
+		stmt, err := builder.Build()
+		require.NoError(t, err)
+		_, err = db.ExecuteStatement(ctx, stmt)
+		require.NoError(t, err)
+	}
+	deleteRows := func(ctx context.Context, t *testing.T, name string, where ...Condition) {
+		builder := DeleteFrom(name)
+		if len(where) > 0 {
+			builder.Where(where[0])
+		}
+		stmt, err := builder.Build()
+		require.NoError(t, err)
+		_, err = db.ExecuteStatement(ctx, stmt)
+		require.NoError(t, err)
+	}
+
+	// Rows inserted by the transaction that creates the table are counted.
+	execInTx(t, db, func(ctx context.Context) {
+		_, err := db.ExecuteStatement(ctx, createStmt)
+		require.NoError(t, err)
+		created := TxFromContext(ctx).DDLChanges.CreateTables[0]
+		for id := range 3 {
+			_, err := created.Insert(ctx, Statement{
+				Kind:   Insert,
+				Fields: fieldsFromColumns(created.Columns...),
+				Inserts: [][]OptionalValue{{
+					{Value: int64(id + 1), Valid: true},
+					{Value: NewTextPointer([]byte(fmt.Sprintf("name-%d", id+1))), Valid: true},
+				}},
+			})
+			require.NoError(t, err)
+		}
+	})
+	assertCount(t, tableName, 3)
+
+	// A rolled-back insert leaves the count unchanged, while the transaction
+	// itself sees its own rows.
+	err := db.txManager.ExecuteInTransaction(context.Background(), func(ctx context.Context) error {
+		insert(ctx, t, tableName, 4, 5)
+		assert.Equal(t, int64(5), countStar(ctx, t, tableName))
+		return errRollback
+	})
+	require.ErrorIs(t, err, errRollback)
+	assertCount(t, tableName, 3)
+
+	execInTx(t, db, func(ctx context.Context) {
+		deleteRows(ctx, t, tableName, FieldIsEqual(Field{Name: "id"}, OperandInteger, int64(1)))
+		assert.Equal(t, int64(2), countStar(ctx, t, tableName))
+	})
+	assertCount(t, tableName, 2)
+
+	err = db.txManager.ExecuteInTransaction(context.Background(), func(ctx context.Context) error {
+		deleteRows(ctx, t, tableName)
+		insert(ctx, t, tableName, 10)
+		assert.Equal(t, int64(1), countStar(ctx, t, tableName))
+		return errRollback
+	})
+	require.ErrorIs(t, err, errRollback)
+	assertCount(t, tableName, 2)
+
+	execInTx(t, db, func(ctx context.Context) {
+		insert(ctx, t, tableName, 6, 7)
+	})
+	assertCount(t, tableName, 4)
+
+	// The count follows the table through a rename.
+	execInTx(t, db, func(ctx context.Context) {
+		insert(ctx, t, tableName, 8)
+		_, err := db.ExecuteStatement(ctx, Statement{
+			Kind:             AlterTable,
+			AlterTableAction: AlterTableRenameTo,
+			TableName:        tableName,
+			NewTableName:     "things",
+		})
+		require.NoError(t, err)
+	})
+	assertCount(t, "things", 5)
+}
@@ -47,6 +47,9 @@ func (t *Table) Delete(ctx context.Context, stmt Statement) (StatementResult, er
 	}
 
 	for _, row := range rows {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
 		if t.checkParentFK != nil {
 			if err := t.checkParentFK(ctx, row); err != nil {
 				return result, err
@@ -75,6 +78,9 @@ func (t *Table) Delete(ctx context.Context, stmt Statement) (StatementResult, er
 		}
 
 		result.RowsAffected += 1
+		if stmt.ReturnAffectedKeys {
+			result.AffectedKeys = append(result.AffectedKeys, t.affectedKey(row))
+		}
 	}
 
 	if ce := t.logger.Check(zap.DebugLevel, "deleted rows"); ce != nil {
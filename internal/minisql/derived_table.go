@@ -52,6 +52,22 @@ func newVirtualTable(logger *zap.Logger, name string, columns []Column, rows []R
 	if rows == nil {
 		rows = []Row{}
 	}
+	t := newEmptyVirtualTable(logger, name, columns)
+	t.virtualRows = rows
+	return t
+}
+
+// newStreamingVirtualTable creates an in-memory *Table whose rows are produced
+// by scan each time the table is read, so they are never all held in memory.
+func newStreamingVirtualTable(logger *zap.Logger, name string, columns []Column, scan func(ctx context.Context, out func(Row) error) error) *Table {
+	t := newEmptyVirtualTable(logger, name, columns)
+	t.virtualScan = scan
+	return t
+}
+
+// newEmptyVirtualTable creates the scaffolding shared by virtual tables, with
+// no rows attached yet.
+func newEmptyVirtualTable(logger *zap.Logger, name string, columns []Column) *Table {
 	cache := make(map[string]int, len(columns))
 	for i, col := range columns {
 		cache[col.Name] = i
@@ -60,7 +76,6 @@ func newVirtualTable(logger *zap.Logger, name string, columns []Column, rows []R
 		Name:                 name,
 		Columns:              columns,
 		columnCache:          cache,
-		virtualRows:          rows,
 		UniqueIndexes:        make(map[string]UniqueIndex),
 		SecondaryIndexes:     make(map[string]SecondaryIndex),
 		columnIndexInfoCache: make(map[string]IndexInfo),
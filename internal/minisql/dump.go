@@ -0,0 +1,217 @@
+package minisql
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+
+	minisqlErrors "github.com/RichardKnop/minisql/pkg/errors"
+)
+
+// Dump writes SQL that recreates tableNames, or every user table when none
+// are given, to w: the CREATE TABLE and CREATE INDEX statements stored in the
+// schema, then one INSERT per row between BEGIN and COMMIT. Tables are
+// ordered so that foreign key parents come before their children, which lets
+// the output be replayed into an empty database as is.
+//
+// The DDL comes before BEGIN because a table created inside an explicit
+// transaction cannot be written to until that transaction commits. Generated
+// columns are left out of the INSERT statements since they are recomputed on
+// replay. The dump reads a single snapshot of the database.
+func (d *Database) Dump(ctx context.Context, w io.Writer, tableNames ...string) error {
+	bw := bufio.NewWriter(w)
+	err := d.txManager.ExecuteReadOnlyTransaction(ctx, func(ctx context.Context) error {
+		schemas, err := d.listSchemas(ctx)
+		if err != nil {
+			return err
+		}
+		tables, err := d.dumpTables(ctx, schemas, tableNames)
+		if err != nil {
+			return err
+		}
+
+		ddl := make(map[string]string)
+		indexDDL := make(map[string][]string)
+		for _, schema := range schemas {
+			switch schema.Type {
+			case SchemaTable:
+				ddl[schema.Name] = schema.DDL
+			case SchemaSecondaryIndex:
+				indexDDL[schema.TableName] = append(indexDDL[schema.TableName], schema.DDL)
+			}
+		}
+
+		for _, table := range tables {
+			fmt.Fprintln(bw, dumpStatement(ddl[table.Name]))
+			indexes := indexDDL[table.Name]
+			slices.Sort(indexes)
+			for _, index := range indexes {
+				fmt.Fprintln(bw, dumpStatement(index))
+			}
+		}
+		fmt.Fprintln(bw, "begin;")
+		for _, table := range tables {
+			if err := dumpRows(ctx, bw, table); err != nil {
+				return fmt.Errorf("dump %s: %w", table.Name, err)
+			}
+		}
+		fmt.Fprintln(bw, "commit;")
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	return bw.Flush()
+}
+
+// dumpTables returns the tables to dump, parents before children. When
+// tableNames is empty every user table is dumped.
+func (d *Database) dumpTables(ctx context.Context, schemas []Schema, tableNames []string) ([]*Table, error) {
+	if len(tableNames) == 0 {
+		for _, schema := range schemas {
+			if schema.Type == SchemaTable && !isSystemTable(schema.Name) {
+				tableNames = append(tableNames, schema.Name)
+			}
+		}
+	}
+
+	byName := make(map[string]*Table, len(tableNames))
+	for _, name := range tableNames {
+		table, ok := d.GetTable(ctx, name)
+		if !ok || isSystemTable(name) {
+			return nil, minisqlErrors.ErrNoSuchTable{Name: name}
+		}
+		byName[name] = table
+	}
+	names := make([]string, 0, len(byName))
+	for name := range byName {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	// Depth-first walk over foreign keys. A cycle is broken wherever it is
+	// first entered, in which case replaying needs foreign keys turned off.
+	var (
+		ordered = make([]*Table, 0, len(names))
+		visited = make(map[string]bool, len(names))
+		visit   func(name string)
+	)
+	visit = func(name string) {
+		if visited[name] {
+			return
+		}
+		visited[name] = true
+		table := byName[name]
+		for _, fk := range table.ForeignKeys {
+			if _, ok := byName[fk.TargetTable]; ok {
+				visit(fk.TargetTable)
+			}
+		}
+		ordered = append(ordered, table)
+	}
+	for _, name := range names {
+		visit(name)
+	}
+	return ordered, nil
+}
+
+// dumpRows writes one INSERT statement per row of table.
+func dumpRows(ctx context.Context, w io.Writer, table *Table) error {
+	var (
+		columns []Column
+		names   []string
+	)
+	for _, col := range table.Columns {
+		if col.Deleted || col.IsGenerated() {
+			continue
+		}
+		columns = append(columns, col)
+		names = append(names, `"`+col.Name+`"`)
+	}
+	if len(columns) == 0 {
+		return nil
+	}
+	prefix := fmt.Sprintf(`insert into "%s" (%s) values (`, table.Name, strings.Join(names, ", "))
+
+	result, err := table.Select(ctx, Statement{
+		Kind:   Select,
+		Fields: fieldsFromColumns(columns...),
+	})
+	if err != nil {
+		return err
+	}
+	var sb strings.Builder
+	for result.Rows.Next(ctx) {
+		row := result.Rows.Row()
+		sb.Reset()
+		sb.WriteString(prefix)
+		for i, col := range columns {
+			if i > 0 {
+				sb.WriteString(", ")
+			}
+			value, ok := row.GetValue(col.Name)
+			if !ok {
+				return fmt.Errorf("missing value for column %q", col.Name)
+			}
+			sb.WriteString(sqlLiteral(value))
+		}
+		sb.WriteString(");")
+		if _, err := fmt.Fprintln(w, sb.String()); err != nil {
+			return err
+		}
+	}
+	return result.Rows.Err()
+}
+
+// dumpStatement normalises a stored DDL statement to end in one semicolon.
+func dumpStatement(ddl string) string {
+	return strings.TrimRight(strings.TrimSpace(ddl), ";") + ";"
+}
+
+// sqlLiteral formats value as a SQL literal the parser reads back to the same
+// value in a column of the kind it came from.
+func sqlLiteral(value OptionalValue) string {
+	if !value.Valid {
+		return "null"
+	}
+	switch v := value.Value.(type) {
+	case bool:
+		return strconv.FormatBool(v)
+	case int32:
+		return strconv.FormatInt(int64(v), 10)
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case float32:
+		return floatLiteral(strconv.FormatFloat(float64(v), 'f', -1, 32))
+	case float64:
+		return floatLiteral(strconv.FormatFloat(v, 'f', -1, 64))
+	case TextPointer:
+		return quoteSQLString(v.String())
+	case TimestampMicros:
+		return quoteSQLString(FromMicroseconds(int64(v)).String())
+	case UUIDValue:
+		return quoteSQLString(v.String())
+	case VectorPointer:
+		return quoteSQLString(FormatVector(v))
+	default:
+		return quoteSQLString(fmt.Sprintf("%v", v))
+	}
+}
+
+// floatLiteral appends ".0" to a whole number so that the parser reads it back
+// as a REAL or DOUBLE rather than an integer.
+func floatLiteral(s string) string {
+	if strings.Contains(s, ".") {
+		return s
+	}
+	return s + ".0"
+}
+
+// quoteSQLString wraps s in single quotes, doubling any embedded quotes.
+func quoteSQLString(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
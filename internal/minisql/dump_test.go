@@ -0,0 +1,40 @@
+package minisql
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSQLLiteral(t *testing.T) {
+	t.Parallel()
+
+	uuid, err := ParseUUID("550e8400-e29b-41d4-a716-446655440000")
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name     string
+		value    OptionalValue
+		expected string
+	}{
+		{"null", OptionalValue{}, "null"},
+		{"boolean", OptionalValue{Value: true, Valid: true}, "true"},
+		{"int4", OptionalValue{Value: int32(-7), Valid: true}, "-7"},
+		{"int8", OptionalValue{Value: int64(1 << 40), Valid: true}, "1099511627776"},
+		{"real", OptionalValue{Value: float32(0.1), Valid: true}, "0.1"},
+		{"whole real", OptionalValue{Value: float32(-3), Valid: true}, "-3.0"},
+		{"whole double", OptionalValue{Value: float64(2), Valid: true}, "2.0"},
+		{"double without exponent", OptionalValue{Value: 1e21, Valid: true}, "1000000000000000000000.0"},
+		{"text with quotes", OptionalValue{Value: NewTextPointer([]byte("it's")), Valid: true}, "'it''s'"},
+		{"timestamp", OptionalValue{Value: MustParseTimestampMicros("2024-01-02 03:04:05.5"), Valid: true}, "'2024-01-02 03:04:05.500000'"},
+		{"uuid", OptionalValue{Value: uuid, Valid: true}, "'550e8400-e29b-41d4-a716-446655440000'"},
+		{"vector", OptionalValue{Value: VectorPointer{Data: []float32{0.5, -1}}, Valid: true}, "'[0.5, -1]'"},
+	}
+
+	for _, aTestCase := range testCases {
+		t.Run(aTestCase.name, func(t *testing.T) {
+			assert.Equal(t, aTestCase.expected, sqlLiteral(aTestCase.value))
+		})
+	}
+}
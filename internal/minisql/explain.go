@@ -611,6 +611,11 @@ func joinDetail(plan QueryPlan, join JoinPlan) []byte {
 		b = append(b, '=')
 		b = append(b, join.InnerJoinColumn...)
 	}
+	// WHERE conditions on the combined row are checked after the last join.
+	if last := len(plan.Joins) - 1; len(plan.JoinFilters) > 0 && last >= 0 && join.RightScanIndex == plan.Joins[last].RightScanIndex {
+		b = append(b, " filters="...)
+		b = strconv.AppendInt(b, int64(conditionCount(plan.JoinFilters)), 10)
+	}
 	return b
 }
 
@@ -646,6 +651,10 @@ func orderByDetail(orderBy []OrderBy) []byte {
 		} else {
 			b = append(b, " ASC"...)
 		}
+		if order.Nulls != NullsDefault {
+			b = append(b, ' ')
+			b = append(b, order.Nulls.String()...)
+		}
 	}
 	return b
 }
@@ -20,6 +20,7 @@ const (
 	ArithDiv                          // /
 	JSONArrow                         // -> (returns JSON fragment)
 	JSONArrowArrow                    // ->> (returns SQL scalar)
+	ArithConcat                       // || (text concatenation)
 )
 
 func (op ArithOp) String() string {
@@ -36,6 +37,8 @@ func (op ArithOp) String() string {
 		return "->"
 	case JSONArrowArrow:
 		return "->>"
+	case ArithConcat:
+		return "||"
 	default:
 		return "?"
 	}
@@ -53,7 +56,7 @@ type CaseWhen struct {
 // Exactly one interpretation is active (checked in priority order):
 //   - WindowFunc != nil:        a window function call (ROW_NUMBER, SUM OVER, etc.)
 //   - CaseClauses != nil:      a CASE WHEN expression
-//   - FuncName != "":          a built-in function call (Args holds the arguments)
+//   - FuncName != "":          a built-in or registered function call (Args holds the arguments)
 //   - CastExpr != nil:         a CAST(expr AS type) expression
 //   - IsNull:                  an explicit NULL literal
 //   - Column != "":            a column reference (read value from the row)
@@ -74,6 +77,12 @@ type Expr struct {
 	CastTargetType ColumnKind
 	Op             ArithOp
 	IsNull         bool
+	// wrapIntegerOverflow makes integer arithmetic and casts wrap around
+	// instead of failing with ErrIntegerOverflow (see WithIntegerOverflow).
+	wrapIntegerOverflow bool
+	// function is the implementation of a call to a registered function,
+	// bound when the statement is prepared (see Database.RegisterFunction).
+	function *ScalarFunction
 }
 
 // cloneExpr returns a deep copy of an Expr tree so that BindArguments can
@@ -266,6 +275,9 @@ func windowFuncString(wf *WindowFunc) string {
 	case WindowAvg:
 		return "AVG(...) OVER (...)"
 	case WindowCount:
+		if wf.Arg != nil {
+			return "COUNT(...) OVER (...)"
+		}
 		return "COUNT(*) OVER (...)"
 	case WindowMin:
 		return "MIN(...) OVER (...)"
@@ -297,6 +309,9 @@ func (e *Expr) Eval(row Row) (any, error) {
 
 	// Function call
 	if e.FuncName != "" {
+		if e.function != nil {
+			return e.evalRegisteredFunc(row)
+		}
 		return e.evalFunc(row)
 	}
 
@@ -403,6 +418,19 @@ func (e *Expr) Eval(row Row) (any, error) {
 		return e.evalJSONOp(leftVal, rightVal)
 	}
 
+	// Concatenation converts both operands to text, as CAST(x AS TEXT) does.
+	if e.Op == ArithConcat {
+		left, err := castToTextPointer(leftVal)
+		if err != nil {
+			return nil, fmt.Errorf("left operand of ||: %w", err)
+		}
+		right, err := castToTextPointer(rightVal)
+		if err != nil {
+			return nil, fmt.Errorf("right operand of ||: %w", err)
+		}
+		return NewTextPointer(append(append([]byte{}, left.Data...), right.Data...)), nil
+	}
+
 	lf, err := toFloat64(leftVal)
 	if err != nil {
 		return nil, fmt.Errorf("left operand of %s: %w", e.Op, err)
@@ -417,21 +445,18 @@ func (e *Expr) Eval(row Row) (any, error) {
 	ri, rightIsInt := toInt64(rightVal)
 
 	switch e.Op {
-	case ArithAdd:
-		if leftIsInt && rightIsInt {
-			return li + ri, nil
-		}
-		return lf + rf, nil
-	case ArithSub:
+	case ArithAdd, ArithSub, ArithMul:
 		if leftIsInt && rightIsInt {
-			return li - ri, nil
+			return e.evalIntArith(li, ri)
 		}
-		return lf - rf, nil
-	case ArithMul:
-		if leftIsInt && rightIsInt {
-			return li * ri, nil
+		switch e.Op {
+		case ArithAdd:
+			return lf + rf, nil
+		case ArithSub:
+			return lf - rf, nil
+		default:
+			return lf * rf, nil
 		}
-		return lf * rf, nil
 	case ArithDiv:
 		if rf == 0 {
 			return nil, fmt.Errorf("division by zero")
@@ -464,8 +489,8 @@ func (e *Expr) evalCast(row Row) (any, error) {
 			return nil, err
 		}
 		if e.CastTargetType == Int4 {
-			if n > math.MaxInt32 || n < math.MinInt32 {
-				return nil, fmt.Errorf("CAST: value %d overflows INT4", n)
+			if (n > math.MaxInt32 || n < math.MinInt32) && !e.wrapIntegerOverflow {
+				return nil, fmt.Errorf("%w: CAST: value %d overflows INT4", ErrIntegerOverflow, n)
 			}
 			return int32(n), nil
 		}
@@ -786,6 +811,110 @@ func (e *Expr) evalCase(row Row) (any, error) {
 	return nil, nil
 }
 
+// caseBranchClass groups the result kinds of CASE branches into families
+// that can share a result column.
+type caseBranchClass int
+
+const (
+	caseBranchUnknown caseBranchClass = iota
+	caseBranchNumeric
+	caseBranchText
+	caseBranchBoolean
+	caseBranchTimestamp
+)
+
+func (c caseBranchClass) String() string {
+	switch c {
+	case caseBranchNumeric:
+		return "numeric"
+	case caseBranchText:
+		return "text"
+	case caseBranchBoolean:
+		return "boolean"
+	case caseBranchTimestamp:
+		return "timestamp"
+	default:
+		return "unknown"
+	}
+}
+
+// checkCaseBranches reports an error when the THEN and ELSE branches of a
+// CASE expression yield incompatible types, such as 'adult' and 1. Only
+// branches whose type is known before execution are compared: literals,
+// columns of table, and || concatenations. NULL fits any branch, and a text
+// literal may stand in for a timestamp.
+func (e *Expr) checkCaseBranches(table *Table) error {
+	if e.CaseClauses == nil {
+		return nil
+	}
+	branches := make([]*Expr, 0, len(e.CaseClauses)+1)
+	for _, cl := range e.CaseClauses {
+		branches = append(branches, cl.Then)
+	}
+	if e.CaseElse != nil {
+		branches = append(branches, e.CaseElse)
+	}
+
+	var (
+		first      caseBranchClass
+		firstLabel string
+	)
+	for _, branch := range branches {
+		class := caseBranchClassOf(branch, table)
+		if class == caseBranchUnknown {
+			continue
+		}
+		if first == caseBranchUnknown {
+			first, firstLabel = class, branch.String()
+			continue
+		}
+		if class == first {
+			continue
+		}
+		if (class == caseBranchText && first == caseBranchTimestamp) ||
+			(class == caseBranchTimestamp && first == caseBranchText) {
+			continue
+		}
+		return fmt.Errorf("CASE branches have incompatible types: %s (%s) and %s (%s)",
+			firstLabel, first, branch.String(), class)
+	}
+	return nil
+}
+
+func caseBranchClassOf(e *Expr, table *Table) caseBranchClass {
+	switch {
+	case e == nil || e.IsNull:
+		return caseBranchUnknown
+	case e.Op == ArithConcat:
+		return caseBranchText
+	case e.Literal != nil:
+		switch e.Literal.(type) {
+		case int64, float64:
+			return caseBranchNumeric
+		case bool:
+			return caseBranchBoolean
+		case TextPointer:
+			return caseBranchText
+		}
+	case e.Column != "" && table != nil:
+		col, ok := table.ColumnByName(e.Column)
+		if !ok {
+			return caseBranchUnknown
+		}
+		switch col.Kind {
+		case Int4, Int8, Real, Double:
+			return caseBranchNumeric
+		case Varchar, Text:
+			return caseBranchText
+		case Boolean:
+			return caseBranchBoolean
+		case Timestamp:
+			return caseBranchTimestamp
+		}
+	}
+	return caseBranchUnknown
+}
+
 // evalFunc evaluates a built-in function call against the given row.
 func (e *Expr) evalFunc(row Row) (any, error) {
 	switch e.FuncName {
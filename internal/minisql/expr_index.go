@@ -56,6 +56,9 @@ func inferExprResultKind(expr *Expr, tableCols []Column) ColumnKind {
 	if expr.CastExpr != nil {
 		return expr.CastTargetType
 	}
+	if expr.function != nil {
+		return expr.function.Returns
+	}
 	if expr.FuncName != "" {
 		switch expr.FuncName {
 		case "LOWER", "UPPER", "TRIM", "LTRIM", "RTRIM", "SUBSTR", "REPLACE", "CONCAT":
@@ -90,6 +93,9 @@ func inferExprResultKind(expr *Expr, tableCols []Column) ColumnKind {
 	if expr.Op == JSONArrowArrow || expr.Op == JSONArrow {
 		return Text
 	}
+	if expr.Op == ArithConcat {
+		return Varchar
+	}
 	if expr.Left != nil && expr.Op != 0 {
 		leftKind := inferExprResultKind(expr.Left, tableCols)
 		rightKind := inferExprResultKind(expr.Right, tableCols)
@@ -125,13 +131,23 @@ func isIntegerKind(k ColumnKind) bool {
 	return k == Int8 || k == Int4
 }
 
+// volatileFunctions lists the built-in functions that can return a different
+// result for the same arguments: the current time, random UUIDs and salted
+// password hashes.
+var volatileFunctions = map[string]struct{}{
+	"NOW": {}, "GEN_RANDOM_UUID": {}, "ARGON2ID_HASH": {}, "BCRYPT_HASH": {},
+}
+
 // isImmutableExpr reports whether the expression contains only deterministic sub-expressions.
-// NOW() is the only non-deterministic function currently implemented.
+// Calls to registered functions count only when registered as Deterministic.
 func isImmutableExpr(expr *Expr) bool {
 	if expr == nil {
 		return true
 	}
-	if expr.FuncName == "NOW" {
+	if _, ok := volatileFunctions[expr.FuncName]; ok {
+		return false
+	}
+	if expr.function != nil && !expr.function.Deterministic {
 		return false
 	}
 	for _, arg := range expr.Args {
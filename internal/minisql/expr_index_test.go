@@ -323,6 +323,27 @@ func TestIsImmutableExpr(t *testing.T) {
 		assert.False(t, isImmutableExpr(&Expr{FuncName: "NOW"}))
 	})
 
+	t.Run("GEN_RANDOM_UUID() is not immutable", func(t *testing.T) {
+		t.Parallel()
+		assert.False(t, isImmutableExpr(&Expr{FuncName: "GEN_RANDOM_UUID"}))
+	})
+
+	t.Run("BCRYPT_HASH() is not immutable", func(t *testing.T) {
+		t.Parallel()
+		assert.False(t, isImmutableExpr(&Expr{FuncName: "BCRYPT_HASH", Args: []*Expr{{Column: "password"}}}))
+	})
+
+	t.Run("registered function is not immutable", func(t *testing.T) {
+		t.Parallel()
+		assert.False(t, isImmutableExpr(&Expr{FuncName: "COUNTER", function: &ScalarFunction{}}))
+	})
+
+	t.Run("deterministic registered function is immutable", func(t *testing.T) {
+		t.Parallel()
+		expr := &Expr{FuncName: "SHOUT", Args: []*Expr{{Column: "name"}}, function: &ScalarFunction{Deterministic: true}}
+		assert.True(t, isImmutableExpr(expr))
+	})
+
 	t.Run("LOWER(col) is immutable", func(t *testing.T) {
 		t.Parallel()
 		expr := &Expr{FuncName: "LOWER", Args: []*Expr{{Column: "name"}}}
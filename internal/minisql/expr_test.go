@@ -189,6 +189,31 @@ func TestExpr_Eval_DivisionByZero(t *testing.T) {
 	assert.ErrorContains(t, err, "division by zero")
 }
 
+func TestExpr_Eval_Concat(t *testing.T) {
+	t.Parallel()
+
+	row := NewRowWithValues(
+		[]Column{{Name: "name", Kind: Varchar}, {Name: "n", Kind: Int8}},
+		[]OptionalValue{{Value: NewTextPointer([]byte("bob")), Valid: true}, {Valid: false}},
+	)
+
+	concat := &Expr{
+		Left:  &Expr{Column: "name"},
+		Right: &Expr{Literal: NewTextPointer([]byte("-"))},
+		Op:    ArithConcat,
+	}
+	assert.Equal(t, "name || -", concat.String())
+
+	res, err := (&Expr{Left: concat, Right: &Expr{Literal: int64(7)}, Op: ArithConcat}).Eval(row)
+	require.NoError(t, err)
+	require.IsType(t, TextPointer{}, res)
+	assert.Equal(t, "bob-7", res.(TextPointer).String())
+
+	res, err = (&Expr{Left: &Expr{Column: "name"}, Right: &Expr{Column: "n"}, Op: ArithConcat}).Eval(row)
+	require.NoError(t, err)
+	assert.Nil(t, res, "NULL operand should produce NULL result")
+}
+
 func TestExpr_Eval_Nested(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,157 @@
+package minisql
+
+// walkExpr calls visit for every node of an expression tree, including the
+// expressions inside searched CASE conditions. It stops at the first error.
+func walkExpr(e *Expr, visit func(*Expr) error) error {
+	if e == nil {
+		return nil
+	}
+	if err := visit(e); err != nil {
+		return err
+	}
+	for _, child := range []*Expr{e.Left, e.Right, e.CastExpr, e.CaseInput, e.CaseElse} {
+		if err := walkExpr(child, visit); err != nil {
+			return err
+		}
+	}
+	for _, arg := range e.Args {
+		if err := walkExpr(arg, visit); err != nil {
+			return err
+		}
+	}
+	for _, cl := range e.CaseClauses {
+		if err := walkConditionNodeExprs(cl.Cond, visit); err != nil {
+			return err
+		}
+		if err := walkExpr(cl.When, visit); err != nil {
+			return err
+		}
+		if err := walkExpr(cl.Then, visit); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func walkConditionNodeExprs(node *ConditionNode, visit func(*Expr) error) error {
+	if node == nil {
+		return nil
+	}
+	if node.Leaf != nil {
+		return walkConditionsExprs(Conditions{*node.Leaf}, visit)
+	}
+	if err := walkConditionNodeExprs(node.Left, visit); err != nil {
+		return err
+	}
+	return walkConditionNodeExprs(node.Right, visit)
+}
+
+// walkConditionsExprs walks the expression operands of a WHERE, HAVING or
+// JOIN condition list, descending into subquery operands.
+func walkConditionsExprs(conditions Conditions, visit func(*Expr) error) error {
+	for _, cond := range conditions {
+		for _, operand := range []Operand{cond.Operand1, cond.Operand2} {
+			switch v := operand.Value.(type) {
+			case *Expr:
+				if err := walkExpr(v, visit); err != nil {
+					return err
+				}
+			case *Statement:
+				if err := walkStatementExprs(v, visit); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func walkFieldsExprs(fields []Field, visit func(*Expr) error) error {
+	for _, field := range fields {
+		if err := walkExpr(field.Expr, visit); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func walkJoinsExprs(joins []Join, visit func(*Expr) error) error {
+	for _, join := range joins {
+		if err := walkConditionsExprs(join.Conditions, visit); err != nil {
+			return err
+		}
+		if err := walkJoinsExprs(join.Joins, visit); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// walkStatementExprs calls visit for every expression node reachable from a
+// statement, including subqueries, CTE bodies and UNION branches. It stops at
+// the first error.
+func walkStatementExprs(stmt *Statement, visit func(*Expr) error) error {
+	if stmt == nil {
+		return nil
+	}
+	for _, fields := range [][]Field{stmt.Fields, stmt.GroupBy, stmt.ReturningFields} {
+		if err := walkFieldsExprs(fields, visit); err != nil {
+			return err
+		}
+	}
+	for _, orderBy := range stmt.OrderBy {
+		if err := walkExpr(orderBy.Field.Expr, visit); err != nil {
+			return err
+		}
+	}
+	for _, groups := range []OneOrMore{stmt.Conditions, stmt.Having} {
+		for _, group := range groups {
+			if err := walkConditionsExprs(group, visit); err != nil {
+				return err
+			}
+		}
+	}
+	if err := walkJoinsExprs(stmt.Joins, visit); err != nil {
+		return err
+	}
+	for _, values := range stmt.Inserts {
+		for _, value := range values {
+			if expr, ok := value.Value.(*Expr); ok {
+				if err := walkExpr(expr, visit); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	for _, value := range stmt.Updates {
+		if expr, ok := value.Value.(*Expr); ok {
+			if err := walkExpr(expr, visit); err != nil {
+				return err
+			}
+		}
+	}
+	for _, col := range stmt.Columns {
+		if err := walkExpr(col.GeneratedExpr, visit); err != nil {
+			return err
+		}
+	}
+	if err := walkExpr(stmt.IndexExpression, visit); err != nil {
+		return err
+	}
+	for _, sub := range []*Statement{stmt.ExplainStatement, stmt.FromSubquery, stmt.UpdateFromSubquery, stmt.InsertSelectStmt} {
+		if err := walkStatementExprs(sub, visit); err != nil {
+			return err
+		}
+	}
+	for i := range stmt.CTEs {
+		if err := walkStatementExprs(stmt.CTEs[i].Body, visit); err != nil {
+			return err
+		}
+	}
+	for i := range stmt.Unions {
+		if err := walkStatementExprs(&stmt.Unions[i].Stmt, visit); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -161,25 +161,12 @@ func foldCondition(cond Condition) (fc Condition, condFalse, condTrue bool, err
 // column. Returns (result, canEval): canEval is false when the comparison
 // cannot be performed (e.g. unsupported type), in which case result is meaningless.
 func evalConstCond(cond Condition) (result, canEval bool) {
-	if cond.Operand1.Type == OperandNull {
-		switch cond.Operator {
-		case Eq:
-			return cond.Operand2.Type == OperandNull, true
-		case Ne:
-			return cond.Operand2.Type != OperandNull, true
-		default:
-			return false, false
-		}
+	switch cond.Operator {
+	case IsNull, IsNotNull:
+		return compareNull(cond.Operand1.Type != OperandNull, cond.Operator), true
 	}
-	if cond.Operand2.Type == OperandNull {
-		switch cond.Operator {
-		case Eq:
-			return false, true
-		case Ne:
-			return true, true
-		default:
-			return false, false
-		}
+	if cond.Operand1.Type == OperandNull || cond.Operand2.Type == OperandNull {
+		return false, true
 	}
 	ok, err := compareScalarToOperand(cond.Operand1.Value, cond.Operand2, cond.Operator)
 	if err != nil {
@@ -263,6 +263,17 @@ func TestFoldConditions_ColumnExprNotFolded(t *testing.T) {
 func TestEvalConstCond(t *testing.T) {
 	t.Parallel()
 
+	t.Run("NULL IS NULL", func(t *testing.T) {
+		cond := Condition{
+			Operand1: Operand{Type: OperandNull},
+			Operator: IsNull,
+			Operand2: Operand{Type: OperandNull},
+		}
+		result, canEval := evalConstCond(cond)
+		assert.True(t, canEval)
+		assert.True(t, result)
+	})
+
 	t.Run("NULL = NULL", func(t *testing.T) {
 		cond := Condition{
 			Operand1: Operand{Type: OperandNull},
@@ -271,6 +282,17 @@ func TestEvalConstCond(t *testing.T) {
 		}
 		result, canEval := evalConstCond(cond)
 		assert.True(t, canEval)
+		assert.False(t, result)
+	})
+
+	t.Run("non-null IS NOT NULL", func(t *testing.T) {
+		cond := Condition{
+			Operand1: Operand{Type: OperandInteger, Value: int64(5)},
+			Operator: IsNotNull,
+			Operand2: Operand{Type: OperandNull},
+		}
+		result, canEval := evalConstCond(cond)
+		assert.True(t, canEval)
 		assert.True(t, result)
 	})
 
@@ -293,17 +315,17 @@ func TestEvalConstCond(t *testing.T) {
 		}
 		result, canEval := evalConstCond(cond)
 		assert.True(t, canEval)
-		assert.True(t, result)
+		assert.False(t, result)
 	})
 
-	t.Run("NULL > non-null (unsupported for null)", func(t *testing.T) {
+	t.Run("NULL > non-null", func(t *testing.T) {
 		cond := Condition{
 			Operand1: Operand{Type: OperandNull},
 			Operator: Gt,
 			Operand2: Operand{Type: OperandInteger, Value: int64(1)},
 		}
 		result, canEval := evalConstCond(cond)
-		assert.False(t, canEval)
+		assert.True(t, canEval)
 		assert.False(t, result)
 	})
 
@@ -326,17 +348,17 @@ func TestEvalConstCond(t *testing.T) {
 		}
 		result, canEval := evalConstCond(cond)
 		assert.True(t, canEval)
-		assert.True(t, result)
+		assert.False(t, result)
 	})
 
-	t.Run("non-null > NULL (unsupported for null)", func(t *testing.T) {
+	t.Run("non-null > NULL", func(t *testing.T) {
 		cond := Condition{
 			Operand1: Operand{Type: OperandInteger, Value: int64(5)},
 			Operator: Gt,
 			Operand2: Operand{Type: OperandNull},
 		}
 		result, canEval := evalConstCond(cond)
-		assert.False(t, canEval)
+		assert.True(t, canEval)
 		assert.False(t, result)
 	})
 
@@ -443,8 +443,15 @@ func fkExtractValues(tableCols []Column, targetColNames []string, row Row) ([]an
 	return values, anyNonNull
 }
 
-// fkValuesEqual compares two FK values for equality, handling int32/int64 cross-type.
+// fkValuesEqual compares two FK values for equality, handling int32/int64
+// cross-type and text held as a TextPointer, which is not comparable with ==.
 func fkValuesEqual(a, b any) bool {
+	if tp, ok := a.(TextPointer); ok {
+		a = tp.String()
+	}
+	if tp, ok := b.(TextPointer); ok {
+		b = tp.String()
+	}
 	if a == b {
 		return true
 	}
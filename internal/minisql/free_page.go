@@ -1,9 +1,16 @@
 package minisql
 
 import (
+	"context"
+	"errors"
 	"fmt"
 )
 
+// ErrBrokenFreeList is returned by FreePages when the free list does not form
+// a valid chain: it leaves the database, loops, links a page that is not a
+// free page, or its length does not match the header's free page count.
+var ErrBrokenFreeList = errors.New("broken free list")
+
 // FreePage reuses the existing page structure for tracking free (unused) pages.
 type FreePage struct {
 	NextFreePage PageIndex // Points to next free page, 0 if last
@@ -40,3 +47,46 @@ func (n *FreePage) Unmarshal(buf []byte) error {
 	n.NextFreePage = PageIndex(unmarshalUint32(buf, i))
 	return nil
 }
+
+// FreePages walks the free list from the header's FirstFreePage along the
+// NextFreePage pointers and returns the pages in list order, i.e. the order in
+// which they will be reused. If the chain is broken, the pages walked so far
+// are returned together with an error wrapping ErrBrokenFreeList.
+func FreePages(ctx context.Context, pager Pager) ([]PageIndex, error) {
+	var (
+		dbHeader   = pager.GetHeader(ctx)
+		totalPages = pager.TotalPages()
+		freePages  = make([]PageIndex, 0, dbHeader.FreePageCount)
+		visited    = make(map[PageIndex]struct{}, dbHeader.FreePageCount)
+	)
+	for current := dbHeader.FirstFreePage; current != 0; {
+		if uint32(current) >= totalPages {
+			return freePages, fmt.Errorf("%w: page %d is outside the database (%d pages)", ErrBrokenFreeList, current, totalPages)
+		}
+		if _, ok := visited[current]; ok {
+			return freePages, fmt.Errorf("%w: cycle at page %d", ErrBrokenFreeList, current)
+		}
+		visited[current] = struct{}{}
+
+		page, err := pager.GetPage(ctx, current)
+		if err != nil {
+			return freePages, fmt.Errorf("free list page %d: %w", current, err)
+		}
+		if page.FreePage == nil {
+			return freePages, fmt.Errorf("%w: page %d is not a free page", ErrBrokenFreeList, current)
+		}
+		freePages = append(freePages, current)
+		current = page.FreePage.NextFreePage
+	}
+
+	if uint32(len(freePages)) != dbHeader.FreePageCount {
+		return freePages, fmt.Errorf("%w: header counts %d free pages, list has %d", ErrBrokenFreeList, dbHeader.FreePageCount, len(freePages))
+	}
+	return freePages, nil
+}
+
+// FreePages returns the database's free list in order and verifies that it
+// is a well-formed chain. See the package-level FreePages.
+func (d *Database) FreePages(ctx context.Context) ([]PageIndex, error) {
+	return FreePages(ctx, d.factory.ForTable(mainTableColumns))
+}
@@ -0,0 +1,136 @@
+package minisql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFreePages(t *testing.T) {
+	t.Parallel()
+
+	t.Run("fragmented database", func(t *testing.T) {
+		t.Parallel()
+		const tableName = "items"
+		createStmt := vacuumCreateStmt(tableName)
+
+		mockParser := new(MockParser)
+		mockParser.On("Parse", mock.Anything, createStmt.DDL()).Return([]Statement{createStmt}, nil)
+
+		db, _ := newVacuumTestDB(t, mockParser)
+		ctx := context.Background()
+
+		execInTx(t, db, func(ctx context.Context) {
+			_, err := db.ExecuteStatement(ctx, createStmt)
+			require.NoError(t, err)
+		})
+		freePages, err := db.FreePages(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, freePages)
+
+		execInTx(t, db, func(ctx context.Context) {
+			for i := int64(1); i <= 500; i++ {
+				_, err := db.tables[tableName].Insert(ctx, Statement{
+					Kind:   Insert,
+					Fields: fieldsFromColumns(vacuumColumns...),
+					Inserts: [][]OptionalValue{{
+						{Value: i, Valid: true},
+						{Value: NewTextPointer([]byte(fmt.Sprintf("%d-%s", i, strings.Repeat("x", 90)))), Valid: true},
+					}},
+				})
+				require.NoError(t, err)
+			}
+		})
+		execInTx(t, db, func(ctx context.Context) {
+			_, err := db.ExecuteStatement(ctx, Statement{
+				Kind:       Delete,
+				TableName:  tableName,
+				Conditions: OneOrMore{{FieldIsGreater(Field{Name: "id"}, OperandInteger, int64(20))}},
+			})
+			require.NoError(t, err)
+		})
+
+		freePages, err = db.FreePages(ctx)
+		require.NoError(t, err)
+		header := db.factory.ForTable(mainTableColumns).GetHeader(ctx)
+		require.NotEmpty(t, freePages)
+		assert.Len(t, freePages, int(header.FreePageCount))
+		assert.Equal(t, header.FirstFreePage, freePages[0])
+	})
+
+	freePage := func(idx, next PageIndex) *Page {
+		return &Page{Index: idx, FreePage: &FreePage{NextFreePage: next}}
+	}
+	testCases := []struct {
+		name     string
+		header   DatabaseHeader
+		pages    []*Page
+		expected []PageIndex
+		err      string
+	}{
+		{
+			name:     "valid chain",
+			header:   DatabaseHeader{FirstFreePage: 3, FreePageCount: 2},
+			pages:    []*Page{freePage(3, 1), freePage(1, 0)},
+			expected: []PageIndex{3, 1},
+		},
+		{
+			name:     "cycle",
+			header:   DatabaseHeader{FirstFreePage: 3, FreePageCount: 2},
+			pages:    []*Page{freePage(3, 1), freePage(1, 3)},
+			expected: []PageIndex{3, 1},
+			err:      "cycle at page 3",
+		},
+		{
+			name:     "page outside the database",
+			header:   DatabaseHeader{FirstFreePage: 3, FreePageCount: 2},
+			pages:    []*Page{freePage(3, 9)},
+			expected: []PageIndex{3},
+			err:      "page 9 is outside the database",
+		},
+		{
+			name:     "linked page is not free",
+			header:   DatabaseHeader{FirstFreePage: 3, FreePageCount: 2},
+			pages:    []*Page{freePage(3, 2), {Index: 2, LeafNode: NewLeafNode()}},
+			expected: []PageIndex{3},
+			err:      "page 2 is not a free page",
+		},
+		{
+			name:     "count mismatch",
+			header:   DatabaseHeader{FirstFreePage: 3, FreePageCount: 3},
+			pages:    []*Page{freePage(3, 1), freePage(1, 0)},
+			expected: []PageIndex{3, 1},
+			err:      "header counts 3 free pages, list has 2",
+		},
+	}
+
+	for _, aTestCase := range testCases {
+		t.Run(aTestCase.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+
+			pagerMock := new(MockPager)
+			pagerMock.On("GetHeader", ctx).Return(aTestCase.header)
+			pagerMock.On("TotalPages").Return(uint32(5))
+			for _, page := range aTestCase.pages {
+				pagerMock.On("GetPage", ctx, page.Index).Return(page, nil)
+			}
+
+			freePages, err := FreePages(ctx, pagerMock)
+			assert.Equal(t, aTestCase.expected, freePages)
+			if aTestCase.err == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.True(t, errors.Is(err, ErrBrokenFreeList))
+			assert.Contains(t, err.Error(), aTestCase.err)
+		})
+	}
+}
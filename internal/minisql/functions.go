@@ -0,0 +1,219 @@
+package minisql
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ScalarFunction is a SQL function implemented in Go. Once registered with
+// Database.RegisterFunction it can be called like a built-in function from
+// SELECT fields, WHERE and HAVING conditions, ORDER BY, INSERT values and
+// UPDATE assignments.
+type ScalarFunction struct {
+	// Args declares the kind of each argument; calls must pass exactly
+	// len(Args) arguments.
+	Args []ColumnKind
+	// Returns declares the kind of the result.
+	Returns ColumnKind
+	// Call computes the result. Arguments arrive as Go values matching the
+	// declared kinds: bool for BOOLEAN, int64 for INT4 and INT8, float64 for
+	// REAL and DOUBLE, and string for VARCHAR and TEXT. Call must return a
+	// value of the same Go type for Returns, or nil for NULL. It is never
+	// called with a NULL argument: the call then yields NULL.
+	Call func(args []any) (any, error)
+	// Deterministic declares that Call always returns the same result for the
+	// same arguments. Only SELECTs whose functions are all deterministic are
+	// served from the query cache.
+	Deterministic bool
+}
+
+var (
+	// ErrUnknownFunction is returned when a statement calls a function that is
+	// neither built in nor registered.
+	ErrUnknownFunction = errors.New("unknown function")
+	// ErrFunctionExists is returned when registering a function under the
+	// name of a built-in or already registered function.
+	ErrFunctionExists = errors.New("function already exists")
+)
+
+// builtinFunctions lists the names the SQL dialect reserves for built-in
+// scalar, aggregate and window functions.
+var builtinFunctions = map[string]struct{}{
+	"COALESCE": {}, "NULLIF": {},
+	"UPPER": {}, "LOWER": {}, "TRIM": {}, "LTRIM": {}, "RTRIM": {},
+	"LENGTH": {}, "SUBSTR": {}, "REPLACE": {}, "CONCAT": {}, "NATURAL_SORT": {},
+	"ABS": {}, "FLOOR": {}, "CEIL": {}, "ROUND": {}, "MOD": {},
+	"GEN_RANDOM_UUID": {},
+	"ARGON2ID_HASH":   {}, "ARGON2ID_VERIFY": {}, "BCRYPT_HASH": {}, "BCRYPT_VERIFY": {},
+	"NOW": {}, "DATE_TRUNC": {}, "EXTRACT": {}, "DATE_PART": {}, "TO_TIMESTAMP": {},
+	"MATCH": {}, "TS_RANK": {},
+	"JSON_VALID": {}, "JSON_TYPE": {}, "JSON_ARRAY_LENGTH": {}, "JSON_EXTRACT": {}, "JSON_CONTAINS": {},
+	"VEC_L2": {}, "VEC_COSINE": {},
+	"CAST": {}, "COUNT": {}, "SUM": {}, "AVG": {}, "MIN": {}, "MAX": {},
+	"ROW_NUMBER": {}, "RANK": {}, "DENSE_RANK": {}, "NTILE": {}, "LAG": {}, "LEAD": {},
+	"FIRST_VALUE": {}, "LAST_VALUE": {}, "NTH_VALUE": {},
+}
+
+// RegisterFunction makes fn callable from SQL as name(args...). Names are
+// case-insensitive and must be valid unquoted identifiers that do not clash
+// with a built-in or previously registered function. Statements calling a
+// function are checked against its declared argument count when they are
+// prepared; argument and result types are checked on every call.
+func (d *Database) RegisterFunction(name string, fn ScalarFunction) error {
+	if !isValidFunctionName(name) {
+		return fmt.Errorf("invalid function name %q", name)
+	}
+	if fn.Call == nil {
+		return fmt.Errorf("function %s: Call must not be nil", name)
+	}
+	for i, kind := range fn.Args {
+		if !isFunctionValueKind(kind) {
+			return fmt.Errorf("function %s: unsupported kind %s for argument %d", name, kind, i+1)
+		}
+	}
+	if !isFunctionValueKind(fn.Returns) {
+		return fmt.Errorf("function %s: unsupported return kind %s", name, fn.Returns)
+	}
+
+	upperName := strings.ToUpper(name)
+	if _, ok := builtinFunctions[upperName]; ok {
+		return fmt.Errorf("%w: %s is a built-in function", ErrFunctionExists, upperName)
+	}
+
+	d.functionsMu.Lock()
+	defer d.functionsMu.Unlock()
+	if _, ok := d.functions[upperName]; ok {
+		return fmt.Errorf("%w: %s", ErrFunctionExists, upperName)
+	}
+	if d.functions == nil {
+		d.functions = make(map[string]*ScalarFunction)
+	}
+	fn.Args = append([]ColumnKind(nil), fn.Args...)
+	d.functions[upperName] = &fn
+	return nil
+}
+
+func isValidFunctionName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for i, c := range name {
+		switch {
+		case c == '_', 'a' <= c && c <= 'z', 'A' <= c && c <= 'Z':
+		case '0' <= c && c <= '9' && i > 0:
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+func isFunctionValueKind(kind ColumnKind) bool {
+	switch kind {
+	case Boolean, Int4, Int8, Real, Double, Varchar, Text:
+		return true
+	default:
+		return false
+	}
+}
+
+// resolveFunctions binds every call to a registered function in stmt to its
+// implementation, failing on unknown names and wrong argument counts.
+// Nodes already bound, e.g. in a cached prepared statement, are left alone.
+func (d *Database) resolveFunctions(stmt *Statement) error {
+	return walkStatementExprs(stmt, func(e *Expr) error {
+		if e.FuncName == "" || e.WindowFunc != nil || e.function != nil {
+			return nil
+		}
+		if _, ok := builtinFunctions[e.FuncName]; ok {
+			return nil
+		}
+		switch stmt.Kind {
+		case CreateTable, CreateIndex, AlterTable:
+			// The schema is loaded before any function can be registered.
+			return fmt.Errorf("function %s: registered functions cannot be used in schema definitions", e.FuncName)
+		}
+
+		d.functionsMu.RLock()
+		fn, ok := d.functions[e.FuncName]
+		d.functionsMu.RUnlock()
+		if !ok {
+			return fmt.Errorf("%w %s", ErrUnknownFunction, e.FuncName)
+		}
+		if len(e.Args) != len(fn.Args) {
+			return fmt.Errorf("function %s takes %d arguments, got %d", e.FuncName, len(fn.Args), len(e.Args))
+		}
+		e.function = fn
+		return nil
+	})
+}
+
+// evalRegisteredFunc evaluates a call to a registered function.
+func (e *Expr) evalRegisteredFunc(row Row) (any, error) {
+	fn := e.function
+	args := make([]any, len(e.Args))
+	for i, arg := range e.Args {
+		value, err := arg.Eval(row)
+		if err != nil {
+			return nil, err
+		}
+		if value == nil {
+			return nil, nil
+		}
+		converted, ok := toFunctionValue(fn.Args[i], value)
+		if !ok {
+			return nil, fmt.Errorf("%s: argument %d must be %s, got %T", e.FuncName, i+1, fn.Args[i], value)
+		}
+		args[i] = converted
+	}
+
+	result, err := fn.Call(args)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", e.FuncName, err)
+	}
+	if result == nil {
+		return nil, nil
+	}
+	value, ok := toFunctionValue(fn.Returns, result)
+	if !ok {
+		return nil, fmt.Errorf("%s: returned %T, want %s", e.FuncName, result, fn.Returns)
+	}
+	if s, ok := value.(string); ok {
+		return NewTextPointer([]byte(s)), nil
+	}
+	return value, nil
+}
+
+// toFunctionValue converts an engine value to the Go type documented on
+// ScalarFunction for kind.
+func toFunctionValue(kind ColumnKind, value any) (any, bool) {
+	switch kind {
+	case Boolean:
+		v, ok := value.(bool)
+		return v, ok
+	case Int4, Int8:
+		switch v := value.(type) {
+		case int64:
+			return v, true
+		case int32:
+			return int64(v), true
+		case int:
+			return int64(v), true
+		}
+	case Real, Double:
+		switch v := value.(type) {
+		case float64:
+			return v, true
+		case float32:
+			return float64(v), true
+		case int64:
+			return float64(v), true
+		}
+	case Varchar, Text:
+		if s, ok := toStringVal(value); ok {
+			return s, true
+		}
+	}
+	return nil, false
+}
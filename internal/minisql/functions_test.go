@@ -0,0 +1,75 @@
+package minisql
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDatabase_RegisterFunction(t *testing.T) {
+	t.Parallel()
+
+	greet := ScalarFunction{
+		Args:    []ColumnKind{Varchar},
+		Returns: Varchar,
+		Call: func(args []any) (any, error) {
+			return "hello " + args[0].(string), nil
+		},
+	}
+
+	t.Run("validates the registration", func(t *testing.T) {
+		db := &Database{}
+
+		require.NoError(t, db.RegisterFunction("greet", greet))
+		require.ErrorIs(t, db.RegisterFunction("GREET", greet), ErrFunctionExists)
+		require.ErrorIs(t, db.RegisterFunction("lower", greet), ErrFunctionExists)
+		assert.EqualError(t, db.RegisterFunction("1greet", greet), `invalid function name "1greet"`)
+		assert.EqualError(t, db.RegisterFunction("nocall", ScalarFunction{Returns: Int8}), "function nocall: Call must not be nil")
+
+		badKind := greet
+		badKind.Args = []ColumnKind{Vector}
+		assert.EqualError(t, db.RegisterFunction("vec", badKind), "function vec: unsupported kind vector for argument 1")
+	})
+
+	t.Run("resolves and evaluates calls", func(t *testing.T) {
+		db := &Database{}
+		require.NoError(t, db.RegisterFunction("greet", greet))
+
+		expr := &Expr{FuncName: "GREET", Args: []*Expr{{Column: "name"}}}
+		stmt := Statement{
+			Kind:      Select,
+			TableName: "users",
+			Fields:    []Field{{Name: expr.String(), Expr: expr}},
+		}
+		require.NoError(t, db.resolveFunctions(&stmt))
+
+		row := NewRowWithValues(
+			[]Column{{Name: "name", Kind: Varchar, Size: 10}},
+			[]OptionalValue{{Value: NewTextPointer([]byte("world")), Valid: true}},
+		)
+		value, err := expr.Eval(row)
+		require.NoError(t, err)
+		assert.Equal(t, NewTextPointer([]byte("hello world")), value)
+
+		row.Values[0] = OptionalValue{}
+		value, err = expr.Eval(row)
+		require.NoError(t, err)
+		assert.Nil(t, value)
+	})
+
+	t.Run("rejects unknown functions and wrong argument counts", func(t *testing.T) {
+		db := &Database{}
+		require.NoError(t, db.RegisterFunction("greet", greet))
+
+		stmt := Statement{
+			Kind:       Select,
+			TableName:  "users",
+			Conditions: OneOrMore{{{Operand1: Operand{Type: OperandExpr, Value: &Expr{FuncName: "SHOUT", Args: []*Expr{{Column: "name"}}}}}}},
+		}
+		require.ErrorIs(t, db.resolveFunctions(&stmt), ErrUnknownFunction)
+
+		stmt.Conditions[0][0].Operand1.Value = &Expr{FuncName: "GREET"}
+		assert.EqualError(t, db.resolveFunctions(&stmt), "function GREET takes 1 arguments, got 0")
+	})
+}
@@ -0,0 +1,174 @@
+package minisql
+
+import (
+	"fmt"
+	"maps"
+)
+
+// validateGeneratedColumns checks the GENERATED ALWAYS AS expressions of a
+// CREATE TABLE statement: each expression must be deterministic and may
+// reference only other columns of the same table. Generated columns are
+// evaluated left to right by column position, so a generation expression may
+// read an earlier generated column but not a later one; this also rules out
+// circular references.
+func validateGeneratedColumns(columns []Column) error {
+	positions := make(map[string]int, len(columns))
+	for i, col := range columns {
+		positions[col.Name] = i
+	}
+	for pos, col := range columns {
+		if !col.IsGenerated() {
+			continue
+		}
+		if col.DefaultValue.Valid || col.DefaultValueNow || col.DefaultValueGenRandUUID {
+			return fmt.Errorf("generated column %q cannot have a DEFAULT value", col.Name)
+		}
+		if !isImmutableExpr(col.GeneratedExpr) || hasWindowFunc(col.GeneratedExpr) {
+			return fmt.Errorf("generation expression for column %q must be deterministic", col.Name)
+		}
+		for _, name := range exprSourceColumns(col.GeneratedExpr) {
+			refPos, ok := positions[name]
+			if !ok {
+				return fmt.Errorf("generation expression for column %q references unknown column %q", col.Name, name)
+			}
+			ref := columns[refPos]
+			switch {
+			case name == col.Name:
+				return fmt.Errorf("generation expression for column %q cannot reference itself", col.Name)
+			case ref.IsGenerated() && refPos > pos:
+				return fmt.Errorf("generation expression for column %q cannot reference generated column %q declared after it", col.Name, name)
+			case ref.Deleted:
+				return fmt.Errorf("generation expression for column %q references dropped column %q", col.Name, name)
+			}
+		}
+	}
+	return nil
+}
+
+func hasWindowFunc(expr *Expr) bool {
+	if expr == nil {
+		return false
+	}
+	if expr.WindowFunc != nil {
+		return true
+	}
+	if hasWindowFunc(expr.Left) || hasWindowFunc(expr.Right) || hasWindowFunc(expr.CastExpr) ||
+		hasWindowFunc(expr.CaseInput) || hasWindowFunc(expr.CaseElse) {
+		return true
+	}
+	for _, arg := range expr.Args {
+		if hasWindowFunc(arg) {
+			return true
+		}
+	}
+	for _, cw := range expr.CaseClauses {
+		if hasWindowFunc(cw.When) || hasWindowFunc(cw.Then) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasGeneratedColumns reports whether any of columns is a generated column.
+func hasGeneratedColumns(columns []Column) bool {
+	for _, col := range columns {
+		if col.IsGenerated() {
+			return true
+		}
+	}
+	return false
+}
+
+// generatedColumnDependsOn reports whether the generation expression of any
+// column in columns references the column called name.
+func generatedColumnDependsOn(columns []Column, name string) bool {
+	for _, col := range columns {
+		if !col.IsGenerated() {
+			continue
+		}
+		for _, ref := range exprSourceColumns(col.GeneratedExpr) {
+			if ref == name {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// evalGeneratedColumn computes the value of a generated column for row and
+// converts it to the column's type.
+func evalGeneratedColumn(col Column, row Row) (OptionalValue, error) {
+	result, err := col.GeneratedExpr.Eval(row)
+	if err != nil {
+		return OptionalValue{}, fmt.Errorf("generated column %q: %w", col.Name, err)
+	}
+	if result == nil {
+		if !col.Nullable {
+			return OptionalValue{}, fmt.Errorf("generated column %q: expression yields NULL for NOT NULL column", col.Name)
+		}
+		return OptionalValue{}, nil
+	}
+	result = col.GeneratedExpr.wrapInt4(col.Kind, result)
+	switch col.Kind {
+	case Real, Double:
+		if n, ok := result.(int64); ok {
+			result = float64(n)
+		}
+	case Varchar, Text:
+		if str, ok := result.(string); ok {
+			result = NewTextPointer([]byte(str))
+		}
+	}
+	value := OptionalValue{Value: result, Valid: true}
+	if err := isValueValidForColumn(col, value); err != nil {
+		return OptionalValue{}, fmt.Errorf("generated column %q: %w", col.Name, err)
+	}
+	return value, nil
+}
+
+// fillGeneratedColumns computes every generated column of row in place, left
+// to right, so each expression sees the generated columns before it. The
+// row's values must be in column order.
+func fillGeneratedColumns(row Row) error {
+	for i, col := range row.Columns {
+		if !col.IsGenerated() || col.Deleted {
+			continue
+		}
+		value, err := evalGeneratedColumn(col, row)
+		if err != nil {
+			return err
+		}
+		row.Values[i] = value
+	}
+	return nil
+}
+
+// applyGeneratedColumns recomputes the generated columns of an updated row in
+// column order, like fillGeneratedColumns.
+// Changed columns are recorded in changedValues and their new values are
+// added to a copy of updates, so that index maintenance which reads updated
+// key values from the statement sees the recomputed values too.
+func applyGeneratedColumns(row Row, updates map[string]OptionalValue, changedValues map[string]Column) (Row, map[string]OptionalValue, error) {
+	var cloned bool
+	for _, col := range row.Columns {
+		if !col.IsGenerated() || col.Deleted {
+			continue
+		}
+		value, err := evalGeneratedColumn(col, row)
+		if err != nil {
+			return row, updates, err
+		}
+		var changed bool
+		row, changed = row.SetValue(col.Name, value)
+		if !changed {
+			continue
+		}
+		changedValues[col.Name] = col
+		if !cloned {
+			updates = maps.Clone(updates)
+			cloned = true
+		}
+		updates[col.Name] = value
+	}
+	return row, updates, nil
+}
@@ -93,7 +93,7 @@ func buildHashBuckets(ctx context.Context, plan QueryPlan, provider TableProvide
 
 		// Virtual tables (CTEs, derived tables) have no B+tree pager; only
 		// physical sequential scans can use the RowView path.
-		useRowViewPath := innerTable.virtualRows == nil &&
+		useRowViewPath := !innerTable.isVirtual() &&
 			innerScan.Type == ScanTypeSequential &&
 			rowViewFilterSupports(innerTable.Columns, innerScan.Filters)
 
@@ -4,18 +4,44 @@ import (
 	"fmt"
 )
 
+// Bits of the second header byte. Only the root bit is shared by all pages;
+// the remaining bits are owned by the concrete node header.
+const (
+	headerFlagRoot          byte = 1 << 0
+	headerFlagPageHint      byte = 1 << 1 // leaf only: a PageHint follows the leaf header
+	headerFlagAutoincrement byte = 1 << 2 // root only: the next autoincrement value follows the base header
+	headerFlagPrevLeaf      byte = 1 << 3 // leaf only: a PrevLeaf pointer follows NextLeaf
+)
+
+// autoincrementSize is the serialised size of Header.NextAutoincrement.
+const autoincrementSize = 8
+
 // Header is the common 6-byte prefix shared by every leaf and internal B+ tree
 // page. It records the page type (leaf vs internal), whether this page is the
 // B+ tree root, and the parent page index.
+//
+// The root page of a table with an autoincrement primary key also stores the
+// next autoincrement value, flagged by a bit in the root byte. It lives in the
+// space reserved for the root page config, so it takes no room from cells.
 type Header struct {
-	IsInternal bool
-	IsRoot     bool
-	Parent     PageIndex
+	IsInternal        bool
+	IsRoot            bool
+	Parent            PageIndex
+	NextAutoincrement int64 // 0 = not stored; only serialised on the root page
 }
 
-// Size returns the fixed serialised byte length of a Header (6 bytes: type + root flag + parent).
+// Size returns the serialised byte length of a Header (6 bytes: type + root
+// flag + parent, plus 8 when the root stores the next autoincrement value).
 func (h *Header) Size() uint64 {
-	return 1 + 1 + 4
+	return 1 + 1 + 4 + h.autoincrementSize()
+}
+
+// autoincrementSize returns the number of bytes taken by NextAutoincrement.
+func (h *Header) autoincrementSize() uint64 {
+	if h.IsRoot && h.NextAutoincrement > 0 {
+		return autoincrementSize
+	}
+	return 0
 }
 
 // Marshal writes the header fields into buf starting at offset 0.
@@ -29,10 +55,13 @@ func (h *Header) Marshal(buf []byte) {
 	i += 1
 
 	if h.IsRoot {
-		buf[i] = 1
+		buf[i] = headerFlagRoot
 	} else {
 		buf[i] = 0
 	}
+	if h.autoincrementSize() > 0 {
+		buf[i] |= headerFlagAutoincrement
+	}
 	i += 1
 
 	buf[i] = byte(h.Parent >> 0)
@@ -40,6 +69,10 @@ func (h *Header) Marshal(buf []byte) {
 	buf[i+2] = byte(h.Parent >> 16)
 	buf[i+3] = byte(h.Parent >> 24)
 	i += 4
+
+	if h.autoincrementSize() > 0 {
+		marshalUint64(buf, uint64(h.NextAutoincrement), i)
+	}
 }
 
 // Unmarshal reads the header fields from buf and returns the number of bytes consumed.
@@ -51,12 +84,20 @@ func (h *Header) Unmarshal(buf []byte) (uint64, error) {
 		return 0, fmt.Errorf("unrecognised page type byte %d", buf[0])
 	}
 	h.IsInternal = buf[0] == PageTypeInternal
-	h.IsRoot = buf[1] == 1
+	h.IsRoot = buf[1]&headerFlagRoot != 0
 	h.Parent = PageIndex(0 |
 		(uint32(buf[0+2]) << 0) |
 		(uint32(buf[1+2]) << 8) |
 		(uint32(buf[2+2]) << 16) |
 		(uint32(buf[3+2]) << 24))
 
+	h.NextAutoincrement = 0
+	if buf[1]&headerFlagAutoincrement != 0 {
+		if uint64(len(buf)) < 6+autoincrementSize {
+			return 0, fmt.Errorf("header unmarshal: buffer too short for next autoincrement (%d < %d)", len(buf), 6+autoincrementSize)
+		}
+		h.NextAutoincrement = int64(unmarshalUint64(buf, 6))
+	}
+
 	return h.Size(), nil
 }
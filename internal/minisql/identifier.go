@@ -0,0 +1,65 @@
+package minisql
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"unicode/utf8"
+)
+
+// DefaultMaxIdentifierLength is the default maximum length, in characters,
+// of table, column and index names. Use WithMaxIdentifierLength to change it.
+const DefaultMaxIdentifierLength = 64
+
+// ErrInvalidIdentifier is returned when a table, column or index name is too
+// long or contains characters outside the safe identifier set.
+var ErrInvalidIdentifier = errors.New("invalid identifier")
+
+// identifierPattern is the set of names that survive being embedded in
+// generated index names and in the DDL persisted to the schema table. Quoted
+// identifiers use the same set: quoting only distinguishes names from
+// keywords.
+var identifierPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// validateIdentifier checks that name is a safe identifier of at most
+// maxLength characters. what describes the name in errors, e.g. "table".
+func validateIdentifier(what, name string, maxLength int) error {
+	if n := utf8.RuneCountInString(name); n > maxLength {
+		return fmt.Errorf("%w: %s name %q is %d characters long, maximum is %d", ErrInvalidIdentifier, what, name, n, maxLength)
+	}
+	if !identifierPattern.MatchString(name) {
+		return fmt.Errorf("%w: %s name %q must start with a letter or underscore and contain only letters, digits and underscores", ErrInvalidIdentifier, what, name)
+	}
+	return nil
+}
+
+// validateIdentifiers checks the names a CREATE TABLE introduces, including
+// the index names derived from them, which must fit the schema table's name
+// column. Nothing is checked when no limit is set on the statement.
+func (s Statement) validateIdentifiers() error {
+	if s.maxIdentifierLength == 0 {
+		return nil
+	}
+	if err := validateIdentifier("table", s.TableName, s.maxIdentifierLength); err != nil {
+		return err
+	}
+	for _, col := range s.Columns {
+		if err := validateIdentifier("column", col.Name, s.maxIdentifierLength); err != nil {
+			return err
+		}
+	}
+
+	derived := make([]string, 0, 1+len(s.UniqueIndexes))
+	if s.PrimaryKey.Name != "" {
+		derived = append(derived, s.PrimaryKey.Name)
+	}
+	for _, uniqueIndex := range s.UniqueIndexes {
+		derived = append(derived, uniqueIndex.Name)
+	}
+	for _, name := range derived {
+		if n := utf8.RuneCountInString(name); n > MaxInlineVarchar {
+			return fmt.Errorf("%w: generated index name %q is %d characters long, maximum is %d", ErrInvalidIdentifier, name, n, MaxInlineVarchar)
+		}
+	}
+	return nil
+}
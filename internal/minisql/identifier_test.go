@@ -0,0 +1,80 @@
+package minisql
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatement_ValidateIdentifiers(t *testing.T) {
+	t.Parallel()
+
+	createTable := func(tableName string, columns ...string) Statement {
+		stmt := Statement{Kind: CreateTable, TableName: tableName, maxIdentifierLength: DefaultMaxIdentifierLength}
+		for _, name := range columns {
+			stmt.Columns = append(stmt.Columns, Column{Kind: Int8, Size: 8, Name: name})
+		}
+		return stmt
+	}
+	longName := strings.Repeat("a", DefaultMaxIdentifierLength+1)
+
+	testCases := []struct {
+		name string
+		stmt Statement
+		err  string
+	}{
+		{"valid names", createTable("_users2", "id", "Last_Name"), ""},
+		{"name at the limit", createTable(longName[1:], longName[1:]), ""},
+		{"table name too long", createTable(longName, "id"), `table name "` + longName + `" is 65 characters long, maximum is 64`},
+		{"column name too long", createTable("users", longName), `column name "` + longName + `" is 65 characters long, maximum is 64`},
+		{"table name with a dash", createTable("my-table", "id"), `table name "my-table" must start with a letter or underscore`},
+		{"column name with a space", createTable("users", "first name"), `column name "first name" must start with a letter or underscore`},
+		{"column name starting with a digit", createTable("users", "1st"), `column name "1st" must start with a letter or underscore`},
+		{"non-ASCII table name", createTable("użytkownicy", "id"), `table name "użytkownicy" must start with a letter or underscore`},
+		{"limit not set", Statement{Kind: CreateTable, TableName: longName, Columns: []Column{{Kind: Int8, Size: 8, Name: "a-b"}}}, ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			err := tc.stmt.validateCreateTable()
+			if tc.err == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorIs(t, err, ErrInvalidIdentifier)
+			assert.Contains(t, err.Error(), tc.err)
+		})
+	}
+
+	t.Run("generated index names must fit the schema table", func(t *testing.T) {
+		t.Parallel()
+		stmt := createTable("users", "id")
+		stmt.maxIdentifierLength = MaxInlineVarchar
+		stmt.UniqueIndexes = []UniqueIndex{{IndexInfo: IndexInfo{Name: UniqueIndexName("users", strings.Repeat("b", MaxInlineVarchar))}}}
+		err := stmt.validateCreateTable()
+		require.ErrorIs(t, err, ErrInvalidIdentifier)
+		assert.Contains(t, err.Error(), "generated index name")
+	})
+
+	t.Run("index name", func(t *testing.T) {
+		t.Parallel()
+		stmt := Statement{
+			Kind:                CreateIndex,
+			TableName:           "users",
+			IndexName:           "idx-users",
+			Columns:             []Column{{Kind: Int8, Size: 8, Name: "id"}},
+			maxIdentifierLength: DefaultMaxIdentifierLength,
+		}
+		err := stmt.validateCreateIndex(nil)
+		require.ErrorIs(t, err, ErrInvalidIdentifier)
+		assert.Contains(t, err.Error(), `index name "idx-users"`)
+
+		stmt.IndexName = longName
+		err = stmt.validateCreateIndex(nil)
+		require.ErrorIs(t, err, ErrInvalidIdentifier)
+		assert.Contains(t, err.Error(), "is 65 characters long")
+	})
+}
@@ -131,24 +131,14 @@ func (ui *Index[T]) Insert(ctx context.Context, keyAny any, rowID RowID) error {
 		return nil
 	}
 
-	if ui.unique {
-		// In case of unique index, we cannot insert duplicate keys (read-only check).
-		_, ok, err := ui.Seek(ctx, rootPage, key)
-		if err != nil {
-			return fmt.Errorf("seek key: %w", err)
-		}
-		if ok {
-			return ErrDuplicateKey
-		}
-	}
-
-	// Root is not full — insertNotFull will upgrade only the pages it actually writes.
+	// Fast path: when root has room and we hold a rightmost-leaf hint, skip the
+	// tree traversal for strictly-increasing (autoincrement) keys.
+	// The root-has-space guard is essential: a full root triggers a proactive split
+	// in the normal path that restructures the tree; the fast path cannot replicate
+	// that, so it must only fire when no root split is required.
+	// A key above the last key of the rightmost leaf is above every key in the
+	// tree, so the fast path also skips the unique index duplicate check.
 	if ui.hasSpaceForKey(rootNode, key) {
-		// Fast path: when root has room and we hold a rightmost-leaf hint, skip the
-		// tree traversal for strictly-increasing (autoincrement) keys.
-		// The root-has-space guard is essential: a full root triggers a proactive split
-		// in the normal path that restructures the tree; the fast path cannot replicate
-		// that, so it must only fire when no root split is required.
 		if cached := ui.rightmostLeaf.Load(); cached >= 0 {
 			inserted, err := ui.tryInsertIntoRightmostLeaf(ctx, PageIndex(cached), key, rowID)
 			if err != nil {
@@ -160,7 +150,21 @@ func (ui *Index[T]) Insert(ctx context.Context, keyAny any, rowID RowID) error {
 			// Miss (leaf full, stale, or key out-of-order): invalidate and fall through.
 			ui.rightmostLeaf.Store(-1)
 		}
+	}
+
+	if ui.unique {
+		// In case of unique index, we cannot insert duplicate keys (read-only check).
+		_, ok, err := ui.Seek(ctx, rootPage, key)
+		if err != nil {
+			return fmt.Errorf("seek key: %w", err)
+		}
+		if ok {
+			return ErrDuplicateKey
+		}
+	}
 
+	// Root is not full — insertNotFull will upgrade only the pages it actually writes.
+	if ui.hasSpaceForKey(rootNode, key) {
 		leafIdx, isRightmost, err := ui.insertNotFull(ctx, ui.GetRootPageIdx(), key, rowID)
 		if err != nil {
 			return err
@@ -1078,19 +1078,8 @@ func TestIndex_Delete_Varchar(t *testing.T) {
 }
 
 func assertFreePages(t *testing.T, pager Pager, expectedFreePages []PageIndex) {
-	dbHeader := pager.GetHeader(context.Background())
-
-	assert.Equal(t, len(expectedFreePages), int(dbHeader.FreePageCount))
-
-	actualFreePages := []PageIndex{}
-	currentFreePageID := dbHeader.FirstFreePage
-
-	for currentFreePageID != 0 {
-		actualFreePages = append(actualFreePages, currentFreePageID)
-		currentFreePage, err := pager.GetPage(context.Background(), currentFreePageID)
-		require.NoError(t, err)
-		currentFreePageID = currentFreePage.FreePage.NextFreePage
-	}
+	actualFreePages, err := FreePages(context.Background(), pager)
+	require.NoError(t, err)
 
 	if expectedFreePages == nil {
 		expectedFreePages = []PageIndex{}
@@ -222,6 +222,7 @@ func (c *IndexCell[T]) Unmarshal(columns []Column, buf []byte) (uint64, error) {
 		if err != nil {
 			return 0, err
 		}
+		c.Key = any(compositeKey).(T)
 		i += ci
 	case UUIDValue:
 		var uv UUIDValue
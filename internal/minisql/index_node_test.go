@@ -173,3 +173,43 @@ func TestIndexNode_Varchar_Marshal(t *testing.T) {
 		(varcharLengthPrefixSize + 7) + 4 + rowIDsLengthPrefixSize + 2*8 + 4) // cell 2: "bar qux", 2 rowIDs
 	assert.Equal(t, expectedSize, int(recreatedNode.Size()))
 }
+
+func TestIndexNode_Composite_Unique_Marshal(t *testing.T) {
+	t.Parallel()
+
+	columns := []Column{
+		{Kind: Varchar, Size: 50, Name: "vendor"},
+		{Kind: Int8, Size: 8, Name: "model"},
+	}
+	node := NewIndexNode[CompositeKey](true)
+
+	node.Header = IndexNodeHeader{
+		IsRoot:     true,
+		IsLeaf:     true,
+		Parent:     3,
+		Keys:       2,
+		RightChild: 4,
+	}
+
+	node.Cells[0].Key = NewCompositeKey(columns, "acme", int64(1))
+	node.Cells[0].UniqueRowID = 125
+	node.Cells[0].Child = 7
+	node.Cells[1].Key = NewCompositeKey(columns, "globex", int64(2))
+	node.Cells[1].UniqueRowID = 126
+	node.Cells[1].Child = 8
+	node.freeBytes = node.MaxSpace() - node.TakenSpace()
+
+	buf := make([]byte, node.Size())
+	err := node.Marshal(buf)
+	require.NoError(t, err)
+
+	recreatedNode := NewIndexNode[CompositeKey](true)
+	_, err = recreatedNode.Unmarshal(columns, buf)
+	require.NoError(t, err)
+
+	assert.Equal(t, node, recreatedNode)
+
+	for idx := 0; idx < len(node.Cells); idx++ {
+		assert.Equal(t, node.Cells[idx], recreatedNode.Cells[idx])
+	}
+}
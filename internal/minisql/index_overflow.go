@@ -193,10 +193,12 @@ func removeRowID[T IndexKey](ctx context.Context, pager TxPager, node *IndexNode
 		if lastPage != nil {
 			previousPage = lastPage
 		}
+		// Overflow pages in the chain may be modified below, so they must be
+		// in the write set for the change to be rolled back with the transaction.
 		var err error
-		lastPage, err = pager.ReadPage(ctx, overflowIdx)
+		lastPage, err = pager.ModifyPage(ctx, overflowIdx)
 		if err != nil {
-			return fmt.Errorf("read index overflow page %d: %w", overflowIdx, err)
+			return fmt.Errorf("modify index overflow page %d: %w", overflowIdx, err)
 		}
 		if foundPage == nil {
 			// Look for the row ID to remove and keep track of the page where we found it
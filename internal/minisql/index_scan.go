@@ -19,6 +19,9 @@ func (ui *Index[T]) ScanAll(ctx context.Context, reverse bool, callback indexSca
 // Structure: child[0] key[0] child[1] key[1] ... child[n-1] key[n-1] child[n]
 // where child[i] is stored in Cells[i].Child and child[n] is RightChild
 func (ui *Index[T]) scanAscending(ctx context.Context, pageIdx PageIndex, callback indexScanner) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
 	page, err := ui.pager.ReadPage(ctx, pageIdx)
 	if err != nil {
 		return err
@@ -96,6 +99,9 @@ func (ui *Index[T]) scanAscending(ctx context.Context, pageIdx PageIndex, callba
 
 // scanDescending performs reverse in-order traversal (descending order)
 func (ui *Index[T]) scanDescending(ctx context.Context, pageIdx PageIndex, callback indexScanner) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
 	page, err := ui.pager.ReadPage(ctx, pageIdx)
 	if err != nil {
 		return err
@@ -223,6 +229,9 @@ func (ui *Index[T]) scanRangeFrom(
 	rangeCondition RangeCondition,
 	callback indexScanner,
 ) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
 	page, err := ui.pager.ReadPage(ctx, pageIdx)
 	if err != nil {
 		return err
@@ -297,6 +306,9 @@ func (ui *Index[T]) scanRangeFrom(
 		reachedRoot  = false
 	)
 	for !reachedRoot {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
 		parentPage, err := ui.pager.ReadPage(ctx, parentIdx)
 		if err != nil {
 			return fmt.Errorf("read parent page: %w", err)
@@ -367,6 +379,9 @@ func (ui *Index[T]) scanRangeFrom(
 
 // scanRangeRecursive traverses the tree in-order, applying range checks at each step.
 func (ui *Index[T]) scanRangeRecursive(ctx context.Context, pageIdx PageIndex, rangeCondition RangeCondition, callback indexScanner) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
 	page, err := ui.pager.ReadPage(ctx, pageIdx)
 	if err != nil {
 		return err
@@ -436,6 +451,9 @@ func (ui *Index[T]) scanRangeRecursive(ctx context.Context, pageIdx PageIndex, r
 
 // scanRangeRecursiveReverse traverses the tree in reverse in-order, applying range checks at each step.
 func (ui *Index[T]) scanRangeRecursiveReverse(ctx context.Context, pageIdx PageIndex, rangeCondition RangeCondition, callback indexScanner) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
 	page, err := ui.pager.ReadPage(ctx, pageIdx)
 	if err != nil {
 		return err
@@ -77,6 +77,24 @@ func TestIndex_ScanAll(t *testing.T) {
 		assert.Equal(t, []int64{21, 20, 19, 18, 17, 16, 15, 14, 13, 12, 11, 10, 9, 8, 7, 6, 5, 4, 3, 2, 1}, scannedKeys)
 		assert.Equal(t, []RowID{121, 120, 119, 118, 117, 116, 115, 114, 113, 112, 111, 110, 109, 108, 107, 106, 105, 104, 103, 102, 101}, scannedRowIDs)
 	})
+
+	t.Run("cancelling the context stops the scan at the next page", func(t *testing.T) {
+		for _, reverse := range []bool{false, true} {
+			scanCtx, cancel := context.WithCancel(ctx)
+			var scannedKeys []int64
+			err := idx.ScanAll(scanCtx, reverse, func(key any, rowID RowID) error {
+				scannedKeys = append(scannedKeys, key.(int64))
+				if len(scannedKeys) == 3 {
+					cancel()
+				}
+				return nil
+			})
+			cancel()
+			require.ErrorIs(t, err, context.Canceled)
+			// Keys left on the current leaf and its parent may still be visited.
+			assert.Less(t, len(scannedKeys), 6)
+		}
+	})
 }
 
 func TestIndex_ScanAll_NonUnique(t *testing.T) {
@@ -0,0 +1,30 @@
+package minisql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// ErrIndexInconsistent is returned by Table.VerifyIndexes when an index does
+// not match the rows of its table.
+var ErrIndexInconsistent = errors.New("index inconsistent with table data")
+
+// VerifyIndexes cross-checks the table's B-tree indexes against its rows.
+// Every index entry must point to an existing row whose indexed value equals
+// the entry's key, and every row with a non-NULL indexed value (satisfying
+// the predicate of a partial index) must have a matching entry. Inverted
+// indexes are checked term by term; HNSW indexes are approximate and skipped.
+//
+// The returned error wraps ErrIndexInconsistent and describes the first
+// problem found, followed by the number of further problems.
+func (t *Table) VerifyIndexes(ctx context.Context) error {
+	report := checkTableIndexConsistency(ctx, IntegrityReport{}, t)
+	if report.Ok() {
+		return nil
+	}
+	if len(report.Issues) == 1 {
+		return fmt.Errorf("%w: %s", ErrIndexInconsistent, report.Issues[0].Message)
+	}
+	return fmt.Errorf("%w: %s (and %d more)", ErrIndexInconsistent, report.Issues[0].Message, len(report.Issues)-1)
+}
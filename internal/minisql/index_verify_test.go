@@ -0,0 +1,94 @@
+package minisql
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTable_VerifyIndexes(t *testing.T) {
+	t.Parallel()
+
+	newIndexedTable := func(t *testing.T) (*Database, *Table) {
+		pager, dbFile := initTest(t)
+		mockParser := new(MockParser)
+		db, err := NewDatabase(context.Background(), testLogger, dbFile.Name(), mockParser, pager, pager, nil)
+		require.NoError(t, err)
+
+		createTableStmt := Statement{
+			Kind:      CreateTable,
+			TableName: testTableName,
+			Columns:   append([]Column{}, testColumns[:2]...),
+			UniqueIndexes: []UniqueIndex{
+				{
+					IndexInfo: IndexInfo{
+						Name:    UniqueIndexName(testTableName, "id"),
+						Columns: testColumns[0:1],
+					},
+				},
+			},
+		}
+		createIndexStmt := Statement{
+			Kind:      CreateIndex,
+			TableName: testTableName,
+			IndexName: "idx_email",
+			Columns:   testColumns[1:2],
+		}
+		mockParser.EXPECT().Parse(mock.Anything, createTableStmt.DDL()).Return([]Statement{createTableStmt}, nil).Once()
+
+		insertStmt := Statement{
+			Kind:      Insert,
+			TableName: testTableName,
+			Fields:    []Field{{Name: "id"}, {Name: "email"}},
+			Inserts: [][]OptionalValue{
+				{{Value: int64(1), Valid: true}, {Value: NewTextPointer([]byte("alice@example.com")), Valid: true}},
+				{{Value: int64(2), Valid: true}, {Value: NewTextPointer([]byte("bob@example.com")), Valid: true}},
+				{{Value: int64(3), Valid: true}, {Valid: false}},
+			},
+		}
+		err = db.txManager.ExecuteInTransaction(context.Background(), func(ctx context.Context) error {
+			if _, err := db.ExecuteStatement(ctx, createTableStmt); err != nil {
+				return err
+			}
+			_, err := db.ExecuteStatement(ctx, createIndexStmt)
+			return err
+		})
+		require.NoError(t, err)
+
+		err = db.txManager.ExecuteInTransaction(context.Background(), func(ctx context.Context) error {
+			_, err := db.tables[testTableName].Insert(ctx, insertStmt)
+			return err
+		})
+		require.NoError(t, err)
+
+		return db, db.tables[testTableName]
+	}
+
+	t.Run("healthy table passes", func(t *testing.T) {
+		db, table := newIndexedTable(t)
+
+		err := db.txManager.ExecuteInTransaction(context.Background(), func(ctx context.Context) error {
+			return table.VerifyIndexes(ctx)
+		})
+		require.NoError(t, err)
+	})
+
+	t.Run("missing index entry fails", func(t *testing.T) {
+		db, table := newIndexedTable(t)
+
+		secondaryIndex := table.SecondaryIndexes["idx_email"]
+		err := db.txManager.ExecuteInTransaction(context.Background(), func(ctx context.Context) error {
+			return secondaryIndex.Index.Delete(ctx, "bob@example.com", 1)
+		})
+		require.NoError(t, err)
+
+		err = db.txManager.ExecuteInTransaction(context.Background(), func(ctx context.Context) error {
+			return table.VerifyIndexes(ctx)
+		})
+		require.ErrorIs(t, err, ErrIndexInconsistent)
+		assert.EqualError(t, err, "index inconsistent with table data: secondary index idx_email on table "+testTableName+" is missing row 1 for key string:bob@example.com")
+	})
+}
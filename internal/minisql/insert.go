@@ -1,9 +1,11 @@
 package minisql
 
 import (
+	"cmp"
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 
 	"go.uber.org/zap"
 )
@@ -43,7 +45,20 @@ func (t *Table) Insert(ctx context.Context, stmt Statement) (StatementResult, er
 	if len(stmt.Inserts) > 1 {
 		rowValues = make([]OptionalValue, len(t.Columns))
 	}
-	for insertIdx, values := range stmt.Inserts {
+	// Rows with explicit, out-of-order integer primary keys are inserted in
+	// key order so that every primary key lands in the rightmost index leaf
+	// instead of descending from the root. RETURNING rows are placed back at
+	// their VALUES position.
+	order := t.insertKeyOrder(stmt)
+	if order != nil && len(stmt.ReturningFields) > 0 {
+		returningRows = make([]Row, len(stmt.Inserts))
+	}
+	for n := range stmt.Inserts {
+		insertIdx := n
+		if order != nil {
+			insertIdx = order[n]
+		}
+		values := stmt.Inserts[insertIdx]
 		switch stmt.ConflictAction {
 		case ConflictActionDoNothing:
 			conflict, err := t.hasInsertConflict(ctx, stmt, insertIdx)
@@ -186,12 +201,18 @@ func (t *Table) Insert(ctx context.Context, stmt Statement) (StatementResult, er
 
 		// Collect the row for RETURNING before it is inserted (values are already
 		// final at this point, including any autoincrement PK that was resolved above).
+		// Rows are collected in VALUES order, never in key order, so callers can
+		// correlate generated keys with the tuples they supplied.
 		if len(stmt.ReturningFields) > 0 {
 			projected, err := projectReturning(row, stmt.ReturningFields)
 			if err != nil {
 				return StatementResult{}, err
 			}
-			returningRows = append(returningRows, projected)
+			if order != nil {
+				returningRows[insertIdx] = projected
+			} else {
+				returningRows = append(returningRows, projected)
+			}
 		}
 
 		page, err := t.pager.ModifyPage(ctx, cursor.PageIdx)
@@ -228,7 +249,7 @@ func (t *Table) Insert(ctx context.Context, stmt Statement) (StatementResult, er
 		// Track the PK value of this newly inserted row for LastInsertId.
 		// Works for single-column int8 PKs (autoincrement and explicit).
 		// For composite or non-int8 PKs, lastInsertID stays 0.
-		if t.HasPrimaryKey() && len(t.PrimaryKey.Columns) == 1 {
+		if t.HasPrimaryKey() && len(t.PrimaryKey.Columns) == 1 && (order == nil || insertIdx == len(stmt.Inserts)-1) {
 			if pkIdx := stmt.ColumnIdx(t.PrimaryKey.Columns[0].Name); pkIdx >= 0 && pkIdx < len(values) {
 				if v := values[pkIdx]; v.Valid {
 					if id, ok := v.Value.(int64); ok {
@@ -238,7 +259,7 @@ func (t *Table) Insert(ctx context.Context, stmt Statement) (StatementResult, er
 			}
 		}
 
-		if insertIdx == len(stmt.Inserts)-1 {
+		if n == len(stmt.Inserts)-1 {
 			break
 		}
 
@@ -267,6 +288,12 @@ func (t *Table) Insert(ctx context.Context, stmt Statement) (StatementResult, er
 		}
 	}
 
+	if t.PrimaryKey.Autoincrement && newRowsInserted > 0 {
+		if err := t.saveNextAutoincrement(ctx); err != nil {
+			return StatementResult{}, err
+		}
+	}
+
 	// Update the in-memory row-count cache (only for tables that have a getter,
 	// i.e. user tables managed by the Database — system tables are excluded).
 	if t.getRowCount != nil && newRowsInserted > 0 {
@@ -283,6 +310,53 @@ func (t *Table) Insert(ctx context.Context, stmt Statement) (StatementResult, er
 	return result, nil
 }
 
+// insertKeyOrder returns the positions of stmt.Inserts sorted by primary key,
+// or nil when the rows should be inserted in VALUES order: the table has no
+// single-column integer primary key, a key is left to autoincrement, the keys
+// are already ascending, or ON CONFLICT or a self-referencing foreign key makes
+// the outcome depend on row order.
+func (t *Table) insertKeyOrder(stmt Statement) []int {
+	if len(stmt.Inserts) < 2 || stmt.ConflictAction != ConflictActionNone ||
+		!t.HasPrimaryKey() || len(t.PrimaryKey.Columns) != 1 {
+		return nil
+	}
+	for _, fk := range t.ForeignKeys {
+		if fk.TargetTable == t.Name {
+			return nil
+		}
+	}
+	pkIdx := stmt.ColumnIdx(t.PrimaryKey.Columns[0].Name)
+	if pkIdx < 0 {
+		return nil
+	}
+	keys := make([]int64, len(stmt.Inserts))
+	sorted := true
+	for i, values := range stmt.Inserts {
+		if pkIdx >= len(values) || !values[pkIdx].Valid {
+			return nil
+		}
+		key, ok := values[pkIdx].Value.(int64)
+		if !ok {
+			return nil
+		}
+		keys[i] = key
+		if i > 0 && key < keys[i-1] {
+			sorted = false
+		}
+	}
+	if sorted {
+		return nil
+	}
+	order := make([]int, len(stmt.Inserts))
+	for i := range order {
+		order[i] = i
+	}
+	slices.SortStableFunc(order, func(a, b int) int {
+		return cmp.Compare(keys[a], keys[b])
+	})
+	return order
+}
+
 // hasInsertConflict returns true if inserting the row at insertIdx would violate
 // a primary key or unique index constraint.
 func (t *Table) hasInsertConflict(ctx context.Context, stmt Statement, insertIdx int) (bool, error) {
@@ -0,0 +1,115 @@
+package minisql
+
+import (
+	"context"
+)
+
+// insertBatches splits the VALUES rows of stmt into statements of at most
+// size rows each. Arguments of a prepared INSERT that are still waiting to be
+// bound are split along with the rows that consume them. It returns nil when
+// the statement does not need splitting.
+func insertBatches(stmt Statement, size int) []Statement {
+	if size <= 0 || stmt.Kind != Insert || len(stmt.Inserts) <= size {
+		return nil
+	}
+	batches := make([]Statement, 0, (len(stmt.Inserts)+size-1)/size)
+	boundArgs := stmt.boundArgs
+	for start := 0; start < len(stmt.Inserts); start += size {
+		batch := stmt
+		batch.Inserts = stmt.Inserts[start:min(start+size, len(stmt.Inserts))]
+		if boundArgs != nil {
+			n := min(batch.NumPlaceholders(), len(boundArgs))
+			batch.boundArgs, boundArgs = boundArgs[:n], boundArgs[n:]
+		}
+		batches = append(batches, batch)
+	}
+	return batches
+}
+
+// mergeInsertResult folds the result of one insert batch into the result of
+// the whole statement. Batches must be merged in order so that RETURNING rows
+// keep following the order of the VALUES tuples.
+func mergeInsertResult(ctx context.Context, total *StatementResult, returning *[]Row, batch StatementResult) error {
+	total.RowsAffected += batch.RowsAffected
+	if batch.LastInsertID != 0 {
+		total.LastInsertID = batch.LastInsertID
+	}
+	if len(batch.Columns) == 0 {
+		return nil
+	}
+	total.Columns = batch.Columns
+	rows, err := materializeResultRows(ctx, batch)
+	if err != nil {
+		return err
+	}
+	*returning = append(*returning, rows...)
+	total.Rows = NewSliceIterator(*returning)
+	return nil
+}
+
+// insertInBatches inserts the rows of a multi-row INSERT at most
+// insertBatchSize rows at a time. Every batch runs in the caller's
+// transaction, so the statement stays all-or-nothing.
+func (d *Database) insertInBatches(ctx context.Context, table *Table, stmt Statement) (StatementResult, error) {
+	batches := insertBatches(stmt, d.insertBatchSize)
+	if batches == nil {
+		return table.Insert(ctx, stmt)
+	}
+
+	var (
+		total     StatementResult
+		returning []Row
+	)
+	for _, batch := range batches {
+		result, err := table.Insert(ctx, batch)
+		if err != nil {
+			return StatementResult{}, err
+		}
+		if err := mergeInsertResult(ctx, &total, &returning, result); err != nil {
+			return StatementResult{}, err
+		}
+		if d.insertBatchHook != nil {
+			d.insertBatchHook(MustTxFromContext(ctx))
+		}
+	}
+	return total, nil
+}
+
+// CommitsInsertInBatches reports whether ExecuteInsertInBatches would commit
+// stmt in more than one transaction: WithInsertBatchCommit is enabled and stmt
+// is an INSERT … VALUES with more rows than the configured batch size.
+func (d *Database) CommitsInsertInBatches(stmt Statement) bool {
+	return d.insertBatchCommit && stmt.InsertSelectStmt == nil && insertBatches(stmt, d.insertBatchSize) != nil
+}
+
+// ExecuteInsertInBatches runs a large INSERT … VALUES outside any explicit
+// transaction, committing each batch of rows in its own transaction so that
+// the pages modified by a single transaction stay bounded. Batches committed
+// before a failing one are kept; the returned error reports the failure.
+func (d *Database) ExecuteInsertInBatches(ctx context.Context, stmt Statement) (StatementResult, error) {
+	batches := insertBatches(stmt, d.insertBatchSize)
+	if batches == nil {
+		batches = []Statement{stmt}
+	}
+
+	var (
+		total     StatementResult
+		returning []Row
+	)
+	for _, batch := range batches {
+		err := d.txManager.ExecuteInTransaction(ctx, func(txCtx context.Context) error {
+			result, err := d.ExecuteStatement(txCtx, batch)
+			if err != nil {
+				return err
+			}
+			if d.insertBatchHook != nil {
+				d.insertBatchHook(MustTxFromContext(txCtx))
+			}
+			return mergeInsertResult(txCtx, &total, &returning, result)
+		})
+		if err != nil {
+			return total, err
+		}
+	}
+	return total, nil
+}
@@ -0,0 +1,158 @@
+package minisql
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDatabase_InsertBatches(t *testing.T) {
+	t.Parallel()
+
+	const (
+		tableName = "items"
+		numRows   = 5000
+		batchSize = 500
+	)
+
+	newInsertStmt := func(nullAt int) Statement {
+		stmt := Statement{
+			Kind:      Insert,
+			TableName: tableName,
+			Fields:    fieldsFromColumns(vacuumColumns...),
+			Inserts:   make([][]OptionalValue, 0, numRows),
+		}
+		for i := range numRows {
+			stmt.Inserts = append(stmt.Inserts, []OptionalValue{
+				{Value: int64(i), Valid: i != nullAt},
+				{Value: NewTextPointer(fmt.Appendf(nil, "item %d", i)), Valid: true},
+			})
+		}
+		return stmt
+	}
+
+	// newDB creates the table and records, for every INSERT batch, the
+	// ID of the transaction it ran in and the most pages any transaction had
+	// modified.
+	newDB := func(t *testing.T, opts ...DatabaseOption) (*Database, *[]TransactionID, *int) {
+		createStmt := vacuumCreateStmt(tableName)
+		mockParser := new(MockParser)
+		mockParser.On("Parse", mock.Anything, createStmt.DDL()).Return([]Statement{createStmt}, nil)
+
+		db, _ := newVacuumTestDB(t, mockParser, opts...)
+		execInTx(t, db, func(ctx context.Context) {
+			_, err := db.ExecuteStatement(ctx, createStmt)
+			require.NoError(t, err)
+		})
+
+		var (
+			txs         []TransactionID
+			maxWriteSet int
+		)
+		db.insertBatchHook = func(tx *Transaction) {
+			txs = append(txs, tx.ID)
+			maxWriteSet = max(maxWriteSet, len(tx.WriteSet))
+		}
+		return db, &txs, &maxWriteSet
+	}
+
+	var atomicMaxWriteSet int
+
+	t.Run("batches commit together", func(t *testing.T) {
+		db, txs, maxWriteSet := newDB(t, WithInsertBatchSize(batchSize))
+		assert.False(t, db.CommitsInsertInBatches(newInsertStmt(-1)))
+
+		execInTx(t, db, func(ctx context.Context) {
+			result, err := db.ExecuteStatement(ctx, newInsertStmt(-1))
+			require.NoError(t, err)
+			assert.Equal(t, numRows, result.RowsAffected)
+		})
+		require.Len(t, *txs, numRows/batchSize)
+		for _, txID := range *txs {
+			assert.Equal(t, (*txs)[0], txID)
+		}
+		assert.Equal(t, numRows, countRowsInDB(t, db, tableName))
+		atomicMaxWriteSet = *maxWriteSet
+	})
+
+	t.Run("failing batch rolls back the whole statement", func(t *testing.T) {
+		db, _, _ := newDB(t, WithInsertBatchSize(batchSize))
+
+		err := db.txManager.ExecuteInTransaction(context.Background(), func(ctx context.Context) error {
+			_, err := db.ExecuteStatement(ctx, newInsertStmt(numRows-1))
+			return err
+		})
+		require.Error(t, err)
+		assert.Equal(t, 0, countRowsInDB(t, db, tableName))
+	})
+
+	t.Run("batch commit bounds each transaction", func(t *testing.T) {
+		db, txs, maxWriteSet := newDB(t, WithInsertBatchSize(batchSize), WithInsertBatchCommit())
+		stmt := newInsertStmt(-1)
+		require.True(t, db.CommitsInsertInBatches(stmt))
+
+		result, err := db.ExecuteInsertInBatches(context.Background(), stmt)
+		require.NoError(t, err)
+		assert.Equal(t, numRows, result.RowsAffected)
+		assert.Equal(t, numRows, countRowsInDB(t, db, tableName))
+
+		require.Len(t, *txs, numRows/batchSize)
+		seen := map[TransactionID]bool{}
+		for _, txID := range *txs {
+			assert.False(t, seen[txID], "every batch commits in its own transaction")
+			seen[txID] = true
+		}
+		require.NotZero(t, atomicMaxWriteSet)
+		assert.Less(t, *maxWriteSet*4, atomicMaxWriteSet,
+			"a batch transaction holds far fewer pages than the whole insert")
+	})
+
+	t.Run("batch commit splits pending prepared arguments", func(t *testing.T) {
+		db, txs, _ := newDB(t, WithInsertBatchSize(batchSize), WithInsertBatchCommit())
+		prepared := Statement{
+			Kind:        Insert,
+			TableName:   tableName,
+			Fields:      fieldsFromColumns(vacuumColumns...),
+			Inserts:     [][]OptionalValue{{{Value: Placeholder{}, Valid: true}, {Value: Placeholder{}, Valid: true}}},
+			insertCache: &insertPrepCache{},
+		}
+		rows := make([][]any, 0, numRows)
+		for i := range numRows {
+			rows = append(rows, []any{int64(i), NewTextPointer(fmt.Appendf(nil, "item %d", i))})
+		}
+		stmt, ok, err := prepared.BindMany(rows)
+		require.NoError(t, err)
+		require.True(t, ok)
+		require.True(t, db.CommitsInsertInBatches(stmt))
+
+		result, err := db.ExecuteInsertInBatches(context.Background(), stmt)
+		require.NoError(t, err)
+		assert.Equal(t, numRows, result.RowsAffected)
+		require.Len(t, *txs, numRows/batchSize)
+
+		execInTx(t, db, func(ctx context.Context) {
+			result, err := db.ExecuteStatement(ctx, Statement{
+				Kind:       Select,
+				TableName:  tableName,
+				Fields:     fieldsFromColumns(vacuumColumns...),
+				Conditions: OneOrMore{{FieldIsEqual(Field{Name: vacuumColumns[0].Name}, OperandInteger, int64(numRows-1))}},
+			})
+			require.NoError(t, err)
+			require.True(t, result.Rows.Next(ctx))
+			assert.Equal(t, fmt.Sprintf("item %d", numRows-1), result.Rows.Row().Values[1].Value.(TextPointer).String())
+		})
+	})
+
+	t.Run("batch commit keeps earlier batches on failure", func(t *testing.T) {
+		db, _, _ := newDB(t, WithInsertBatchSize(batchSize), WithInsertBatchCommit())
+
+		result, err := db.ExecuteInsertInBatches(context.Background(), newInsertStmt(3*batchSize+1))
+		require.Error(t, err)
+		assert.Equal(t, 3*batchSize, result.RowsAffected)
+		assert.Equal(t, 3*batchSize, countRowsInDB(t, db, tableName))
+	})
+}
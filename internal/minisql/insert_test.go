@@ -354,3 +354,87 @@ func TestTable_Insert_Overflow(t *testing.T) {
 		assert.Equal(t, 100, int(pager.pages[3].OverflowPage.Header.DataSize))
 	})
 }
+
+func TestTable_insertKeyOrder(t *testing.T) {
+	t.Parallel()
+
+	table := &Table{
+		Columns:    testColumns,
+		PrimaryKey: NewPrimaryKey(PrimaryKeyName(testTableName), testColumns[0:1], false),
+	}
+	row := func(id any) []OptionalValue {
+		if id == nil {
+			return []OptionalValue{{}, {}, {}}
+		}
+		return []OptionalValue{{Value: id, Valid: true}, {}, {}}
+	}
+	stmt := func(action ConflictAction, rows ...[]OptionalValue) Statement {
+		return Statement{
+			Kind:           Insert,
+			Columns:        testColumns[0:3],
+			Inserts:        rows,
+			ConflictAction: action,
+		}
+	}
+
+	testCases := []struct {
+		Name     string
+		Table    *Table
+		Stmt     Statement
+		Expected []int
+	}{
+		{
+			Name:     "Out of order keys are sorted",
+			Table:    table,
+			Stmt:     stmt(ConflictActionNone, row(int64(30)), row(int64(10)), row(int64(20))),
+			Expected: []int{1, 2, 0},
+		},
+		{
+			Name:     "Equal keys keep VALUES order",
+			Table:    table,
+			Stmt:     stmt(ConflictActionNone, row(int64(2)), row(int64(1)), row(int64(2))),
+			Expected: []int{1, 0, 2},
+		},
+		{
+			Name:  "Ascending keys are inserted as they are",
+			Table: table,
+			Stmt:  stmt(ConflictActionNone, row(int64(1)), row(int64(2)), row(int64(3))),
+		},
+		{
+			Name:  "Single row",
+			Table: table,
+			Stmt:  stmt(ConflictActionNone, row(int64(1))),
+		},
+		{
+			Name:  "Missing key",
+			Table: table,
+			Stmt:  stmt(ConflictActionNone, row(int64(2)), row(nil)),
+		},
+		{
+			Name:  "ON CONFLICT",
+			Table: table,
+			Stmt:  stmt(ConflictActionDoNothing, row(int64(2)), row(int64(1))),
+		},
+		{
+			Name:  "No primary key",
+			Table: &Table{Columns: testColumns},
+			Stmt:  stmt(ConflictActionNone, row(int64(2)), row(int64(1))),
+		},
+		{
+			Name: "Self-referencing foreign key",
+			Table: &Table{
+				Name:        testTableName,
+				Columns:     testColumns,
+				PrimaryKey:  table.PrimaryKey,
+				ForeignKeys: []ForeignKey{{Columns: []string{testColumns[1].Name}, TargetTable: testTableName, TargetColumns: []string{testColumns[0].Name}}},
+			},
+			Stmt: stmt(ConflictActionNone, row(int64(2)), row(int64(1))),
+		},
+	}
+
+	for _, aTestCase := range testCases {
+		t.Run(aTestCase.Name, func(t *testing.T) {
+			assert.Equal(t, aTestCase.Expected, aTestCase.Table.insertKeyOrder(aTestCase.Stmt))
+		})
+	}
+}
@@ -0,0 +1,118 @@
+package minisql
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// IntegerOverflow selects what happens when INT4/INT8 arithmetic or a CAST
+// to an integer type produces a value outside the target range.
+type IntegerOverflow int
+
+const (
+	// IntegerOverflowError fails the statement with ErrIntegerOverflow. This
+	// is the default.
+	IntegerOverflowError IntegerOverflow = iota
+	// IntegerOverflowWrap wraps the result around using two's complement, as
+	// C and Go integer arithmetic does.
+	IntegerOverflowWrap
+)
+
+func (o IntegerOverflow) String() string {
+	switch o {
+	case IntegerOverflowError:
+		return "error"
+	case IntegerOverflowWrap:
+		return "wrap"
+	default:
+		return "unknown"
+	}
+}
+
+// ErrIntegerOverflow is returned when integer arithmetic or a CAST overflows
+// under the IntegerOverflowError policy.
+var ErrIntegerOverflow = errors.New("integer overflow")
+
+// evalIntArith applies an integer +, - or * operator, detecting int64
+// overflow unless the expression was marked to wrap around.
+func (e *Expr) evalIntArith(a, b int64) (int64, error) {
+	var (
+		result   int64
+		overflow bool
+	)
+	switch e.Op {
+	case ArithAdd:
+		result = a + b
+		overflow = (a >= 0) == (b >= 0) && (result >= 0) != (a >= 0)
+	case ArithSub:
+		result = a - b
+		overflow = (a >= 0) != (b >= 0) && (result >= 0) != (a >= 0)
+	case ArithMul:
+		result = a * b
+		overflow = a != 0 && (result/a != b || (a == -1 && b == math.MinInt64))
+	default:
+		return 0, fmt.Errorf("unknown integer operator %s", e.Op)
+	}
+	if overflow && !e.wrapIntegerOverflow {
+		return 0, fmt.Errorf("%w: %d %s %d overflows INT8", ErrIntegerOverflow, a, e.Op, b)
+	}
+	return result, nil
+}
+
+// setIntegerOverflowWrap marks every node of an expression tree to wrap
+// integer overflow instead of failing.
+func setIntegerOverflowWrap(e *Expr) {
+	_ = walkExpr(e, markIntegerOverflowWrap)
+}
+
+func markIntegerOverflowWrap(e *Expr) error {
+	e.wrapIntegerOverflow = true
+	return nil
+}
+
+// setStatementIntegerOverflowWrap marks every expression reachable from a
+// statement, including subqueries, CTE bodies and UNION branches, so that
+// the evaluators wrap integer overflow instead of failing.
+func setStatementIntegerOverflowWrap(stmt *Statement) {
+	_ = walkStatementExprs(stmt, markIntegerOverflowWrap)
+}
+
+// setTableIntegerOverflowWrap marks the generated column expressions of a
+// table, which are evaluated on every INSERT and UPDATE.
+func setTableIntegerOverflowWrap(table *Table) {
+	for _, col := range table.Columns {
+		setIntegerOverflowWrap(col.GeneratedExpr)
+	}
+}
+
+// wrapInt4 narrows the integer result of e into the INT4 range when e wraps
+// integer overflow and the result is stored into a column of the given kind,
+// so the store wraps around like CAST(... AS INT4) instead of failing.
+func (e *Expr) wrapInt4(kind ColumnKind, result any) any {
+	if kind != Int4 || !e.wrapIntegerOverflow {
+		return result
+	}
+	if n, ok := result.(int64); ok {
+		return int32(n)
+	}
+	return result
+}
+
+// wrapInsertSelectInt4 applies wrapInt4 to the values of an INSERT INTO …
+// SELECT row that come from SELECT expressions and are stored into INT4
+// columns of table.
+func wrapInsertSelectInt4(table *Table, stmt Statement, values []OptionalValue) {
+	selectFields := stmt.InsertSelectStmt.Fields
+	if len(selectFields) != len(values) {
+		return // a * in the SELECT list; expressions cannot be matched to values
+	}
+	for i, field := range selectFields {
+		if field.Expr == nil || !values[i].Valid || i >= len(stmt.Fields) {
+			continue
+		}
+		if col, ok := table.ColumnByName(stmt.Fields[i].Name); ok {
+			values[i].Value = field.Expr.wrapInt4(col.Kind, values[i].Value)
+		}
+	}
+}
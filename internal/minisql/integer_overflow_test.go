@@ -0,0 +1,110 @@
+package minisql
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExpr_IntegerOverflow_Addition(t *testing.T) {
+	t.Parallel()
+
+	newExpr := func() *Expr {
+		return &Expr{
+			Left:  &Expr{Literal: int64(math.MaxInt64)},
+			Right: &Expr{Literal: int64(1)},
+			Op:    ArithAdd,
+		}
+	}
+
+	t.Run("error policy", func(t *testing.T) {
+		_, err := newExpr().Eval(Row{})
+		require.ErrorIs(t, err, ErrIntegerOverflow)
+	})
+
+	t.Run("wrap policy", func(t *testing.T) {
+		expr := newExpr()
+		setIntegerOverflowWrap(expr)
+		val, err := expr.Eval(Row{})
+		require.NoError(t, err)
+		assert.Equal(t, int64(math.MinInt64), val)
+	})
+
+	t.Run("multiplication", func(t *testing.T) {
+		expr := &Expr{
+			Left:  &Expr{Literal: int64(math.MinInt64)},
+			Right: &Expr{Literal: int64(-1)},
+			Op:    ArithMul,
+		}
+		_, err := expr.Eval(Row{})
+		require.ErrorIs(t, err, ErrIntegerOverflow)
+
+		expr.Right = &Expr{Literal: int64(1)}
+		val, err := expr.Eval(Row{})
+		require.NoError(t, err)
+		assert.Equal(t, int64(math.MinInt64), val)
+	})
+}
+
+func TestExpr_IntegerOverflow_Cast(t *testing.T) {
+	t.Parallel()
+
+	newExpr := func() *Expr {
+		return &Expr{
+			CastExpr:       &Expr{Literal: int64(2147483648)},
+			CastTargetType: Int4,
+		}
+	}
+
+	t.Run("error policy", func(t *testing.T) {
+		_, err := newExpr().Eval(Row{})
+		require.ErrorIs(t, err, ErrIntegerOverflow)
+	})
+
+	t.Run("wrap policy", func(t *testing.T) {
+		expr := newExpr()
+		setIntegerOverflowWrap(expr)
+		val, err := expr.Eval(Row{})
+		require.NoError(t, err)
+		assert.Equal(t, int32(math.MinInt32), val)
+	})
+}
+
+func TestSetStatementIntegerOverflowWrap(t *testing.T) {
+	t.Parallel()
+
+	field := &Expr{Left: &Expr{Column: "a"}, Right: &Expr{Literal: int64(1)}, Op: ArithAdd}
+	where := &Expr{CastExpr: &Expr{Column: "b"}, CastTargetType: Int4}
+	cteField := &Expr{Left: &Expr{Column: "c"}, Right: &Expr{Literal: int64(2)}, Op: ArithMul}
+	stmt := Statement{
+		Kind:   Select,
+		Fields: []Field{{Expr: field}},
+		Conditions: OneOrMore{
+			{{Operand1: Operand{Type: OperandExpr, Value: where}, Operator: Eq, Operand2: Operand{Type: OperandInteger, Value: int64(1)}}},
+		},
+		CTEs: []CTE{{Name: "t", Body: &Statement{Kind: Select, Fields: []Field{{Expr: cteField}}}}},
+	}
+
+	setStatementIntegerOverflowWrap(&stmt)
+
+	assert.True(t, field.wrapIntegerOverflow)
+	assert.True(t, field.Left.wrapIntegerOverflow)
+	assert.True(t, where.wrapIntegerOverflow)
+	assert.True(t, where.CastExpr.wrapIntegerOverflow)
+	assert.True(t, cteField.wrapIntegerOverflow)
+}
+
+func TestExpr_WrapInt4(t *testing.T) {
+	t.Parallel()
+
+	expr := &Expr{Left: &Expr{Column: "a"}, Right: &Expr{Literal: int64(1)}, Op: ArithAdd}
+	assert.Equal(t, int64(2147483648), expr.wrapInt4(Int4, int64(2147483648)), "error policy keeps the result")
+
+	setIntegerOverflowWrap(expr)
+	assert.Equal(t, int32(math.MinInt32), expr.wrapInt4(Int4, int64(2147483648)))
+	assert.Equal(t, int32(5), expr.wrapInt4(Int4, int64(5)))
+	assert.Equal(t, int64(2147483648), expr.wrapInt4(Int8, int64(2147483648)))
+	assert.Equal(t, 1.5, expr.wrapInt4(Int4, 1.5))
+}
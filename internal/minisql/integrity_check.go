@@ -3,9 +3,12 @@ package minisql
 import (
 	"context"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"maps"
 	"slices"
+
+	minisqlErrors "github.com/RichardKnop/minisql/pkg/errors"
 )
 
 // IntegrityIssue represents a single integrity problem discovered by a check.
@@ -34,8 +37,9 @@ func (r IntegrityReport) Ok() bool {
 // IntegrityCheck performs a deeper structural walk of the database file.
 //
 // In addition to QuickCheck, it traverses reachable table, index, and overflow
-// pages from schema roots, reports orphan pages, and flags pages that appear in
-// both live structures and the free list.
+// pages from schema roots, verifies key ordering and parent pointers, reports
+// orphan pages and pages failing their checksum, and flags pages that appear
+// in both live structures and the free list.
 func (d *Database) IntegrityCheck(ctx context.Context) (IntegrityReport, error) {
 	report, freePages, tables, err := d.quickCheckState(ctx)
 	if err != nil {
@@ -48,31 +52,8 @@ func (d *Database) IntegrityCheck(ctx context.Context) (IntegrityReport, error)
 	livePages := make(map[PageIndex]string, len(tables)*4+1)
 
 	for _, table := range tables {
-		report = d.walkTablePages(ctx, report, table, table.GetRootPageIdx(), livePages)
-
-		if table.HasPrimaryKey() && table.PrimaryKey.Index != nil {
-			report = d.walkIndexPages(ctx, report, table.Name, table.PrimaryKey.Name, table.PrimaryKey.Columns, true, table.PrimaryKey.Index.GetRootPageIdx(), livePages)
-		}
-		for _, index := range table.UniqueIndexes {
-			if index.Index == nil {
-				continue
-			}
-			report = d.walkIndexPages(ctx, report, table.Name, index.Name, index.Columns, true, index.Index.GetRootPageIdx(), livePages)
-		}
-		for _, index := range table.SecondaryIndexes {
-			if secondaryIndexUsesDedicatedInvertedStorage(index.Method) && index.InvertedIndex != nil {
-				report = d.walkInvertedIndexPages(ctx, report, table.Name, index.Name, index.InvertedIndex.GetRootPageIdx(), livePages)
-				continue
-			}
-			if secondaryIndexUsesDedicatedHNSWStorage(index.Method) && index.HNSWIndex != nil {
-				report = d.walkHNSWIndexPages(ctx, report, table.Name, index.Name, index.HNSWIndex.rootPageIdx, livePages)
-				continue
-			}
-			if index.Index == nil {
-				continue
-			}
-			report = d.walkIndexPages(ctx, report, table.Name, index.Name, secondaryIndexStorageColumns(index), false, index.Index.GetRootPageIdx(), livePages)
-		}
+		report = d.walkTableObjectPages(ctx, report, table, livePages)
+		report = d.checkTableTreeOrder(ctx, report, table)
 	}
 
 	for pageIdx, owner := range livePages {
@@ -101,12 +82,45 @@ func (d *Database) IntegrityCheck(ctx context.Context) (IntegrityReport, error)
 	}
 
 	for _, table := range tables {
-		report = d.checkTableIndexConsistency(ctx, report, table)
+		report = checkTableIndexConsistency(ctx, report, table)
 	}
 
 	return report, nil
 }
 
+// walkTableObjectPages marks every page reachable from table: its B+ tree,
+// overflow pages, and the pages of its primary key, unique and secondary
+// indexes.
+func (d *Database) walkTableObjectPages(ctx context.Context, report IntegrityReport, table *Table, livePages map[PageIndex]string) IntegrityReport {
+	report = d.walkTablePages(ctx, report, table, table.GetRootPageIdx(), livePages)
+
+	if table.HasPrimaryKey() && table.PrimaryKey.Index != nil {
+		report = d.walkIndexPages(ctx, report, table.Name, table.PrimaryKey.Name, table.PrimaryKey.Columns, true, table.PrimaryKey.Index.GetRootPageIdx(), livePages)
+	}
+	for _, index := range table.UniqueIndexes {
+		if index.Index == nil {
+			continue
+		}
+		report = d.walkIndexPages(ctx, report, table.Name, index.Name, index.Columns, true, index.Index.GetRootPageIdx(), livePages)
+	}
+	for _, index := range table.SecondaryIndexes {
+		if secondaryIndexUsesDedicatedInvertedStorage(index.Method) && index.InvertedIndex != nil {
+			report = d.walkInvertedIndexPages(ctx, report, table.Name, index.Name, index.InvertedIndex.GetRootPageIdx(), livePages)
+			continue
+		}
+		if secondaryIndexUsesDedicatedHNSWStorage(index.Method) && index.HNSWIndex != nil {
+			report = d.walkHNSWIndexPages(ctx, report, table.Name, index.Name, index.HNSWIndex.rootPageIdx, livePages)
+			continue
+		}
+		if index.Index == nil {
+			continue
+		}
+		report = d.walkIndexPages(ctx, report, table.Name, index.Name, secondaryIndexStorageColumns(index), false, index.Index.GetRootPageIdx(), livePages)
+	}
+
+	return report
+}
+
 // QuickCheck performs a cheap structural health check of the open database.
 //
 // It validates header-linked free-list metadata and the decodability/shape of
@@ -262,7 +276,7 @@ func (d *Database) checkFreeList(ctx context.Context, report IntegrityReport, pa
 		page, err := pager.GetPage(ctx, current)
 		if err != nil {
 			report.Issues = append(report.Issues, IntegrityIssue{
-				Code:    "free_list_page_decode_failed",
+				Code:    pageReadIssueCode(err, "free_list_page_decode_failed"),
 				Message: fmt.Sprintf("failed to decode free-list page %d: %v", current, err),
 				Page:    pageIndexPtr(current),
 			})
@@ -308,7 +322,7 @@ func (d *Database) checkTableRoot(ctx context.Context, report IntegrityReport, t
 	page, err := d.factory.ForTable(table.Columns).GetPage(ctx, pageIdx)
 	if err != nil {
 		report.Issues = append(report.Issues, IntegrityIssue{
-			Code:    "table_root_decode_failed",
+			Code:    pageReadIssueCode(err, "table_root_decode_failed"),
 			Message: fmt.Sprintf("failed to decode root page for table %s: %v", table.Name, err),
 			Page:    pageIndexPtr(pageIdx),
 			Object:  table.Name,
@@ -352,7 +366,7 @@ func (d *Database) checkIndexRoot(ctx context.Context, report IntegrityReport, t
 	page, err := idxPager.GetPage(ctx, pageIdx)
 	if err != nil {
 		report.Issues = append(report.Issues, IntegrityIssue{
-			Code:    "index_root_decode_failed",
+			Code:    pageReadIssueCode(err, "index_root_decode_failed"),
 			Message: fmt.Sprintf("failed to decode root page for index %s on table %s: %v", indexName, tableName, err),
 			Page:    pageIndexPtr(pageIdx),
 			Object:  indexName,
@@ -387,7 +401,7 @@ func (d *Database) checkInvertedIndexRoot(ctx context.Context, report IntegrityR
 	page, err := d.factory.ForInvertedIndex().GetPage(ctx, pageIdx)
 	if err != nil {
 		report.Issues = append(report.Issues, IntegrityIssue{
-			Code:    "index_root_decode_failed",
+			Code:    pageReadIssueCode(err, "index_root_decode_failed"),
 			Message: fmt.Sprintf("failed to decode root page for index %s on table %s: %v", indexName, tableName, err),
 			Page:    pageIndexPtr(pageIdx),
 			Object:  indexName,
@@ -421,7 +435,7 @@ func (d *Database) checkHNSWIndexRoot(ctx context.Context, report IntegrityRepor
 	page, err := d.factory.ForHNSWIndex().GetPage(ctx, pageIdx)
 	if err != nil {
 		report.Issues = append(report.Issues, IntegrityIssue{
-			Code:    "index_root_decode_failed",
+			Code:    pageReadIssueCode(err, "index_root_decode_failed"),
 			Message: fmt.Sprintf("failed to decode root page for index %s on table %s: %v", indexName, tableName, err),
 			Page:    pageIndexPtr(pageIdx),
 			Object:  indexName,
@@ -458,7 +472,7 @@ func (d *Database) walkHNSWIndexPages(ctx context.Context, report IntegrityRepor
 	metaPage, err := pager.GetPage(ctx, root)
 	if err != nil {
 		report.Issues = append(report.Issues, IntegrityIssue{
-			Code:    "index_page_decode_failed",
+			Code:    pageReadIssueCode(err, "index_page_decode_failed"),
 			Message: fmt.Sprintf("failed to decode meta page %d for %s: %v", root, objectName, err),
 			Page:    pageIndexPtr(root),
 			Object:  indexName,
@@ -503,7 +517,7 @@ func (d *Database) walkHNSWIndexPages(ctx context.Context, report IntegrityRepor
 		page, err := pager.GetPage(ctx, dataPageIdx)
 		if err != nil {
 			report.Issues = append(report.Issues, IntegrityIssue{
-				Code:    "index_page_decode_failed",
+				Code:    pageReadIssueCode(err, "index_page_decode_failed"),
 				Message: fmt.Sprintf("failed to decode HNSW data page %d for %s: %v", dataPageIdx, objectName, err),
 				Page:    pageIndexPtr(dataPageIdx),
 				Object:  indexName,
@@ -559,7 +573,7 @@ func (d *Database) walkTablePages(ctx context.Context, report IntegrityReport, t
 		page, err := pager.GetPage(ctx, pageIdx)
 		if err != nil {
 			report.Issues = append(report.Issues, IntegrityIssue{
-				Code:    "table_page_decode_failed",
+				Code:    pageReadIssueCode(err, "table_page_decode_failed"),
 				Message: fmt.Sprintf("failed to decode page %d for %s: %v", pageIdx, objectName, err),
 				Page:    pageIndexPtr(pageIdx),
 				Object:  objectName,
@@ -571,6 +585,18 @@ func (d *Database) walkTablePages(ctx context.Context, report IntegrityReport, t
 		case page.LeafNode != nil:
 			report = d.checkTableLeafPage(ctx, report, table, page, fields, livePages)
 			if nextLeaf := page.LeafNode.Header.NextLeaf; nextLeaf != 0 {
+				if nextLeaf < PageIndex(report.TotalPages) {
+					nextPage, err := pager.GetPage(ctx, nextLeaf)
+					// Legacy leaves do not store PrevLeaf.
+					if err == nil && nextPage.LeafNode != nil && !nextPage.LeafNode.Header.LegacyLinks && nextPage.LeafNode.Header.PrevLeaf != pageIdx {
+						report.Issues = append(report.Issues, IntegrityIssue{
+							Code:    "table_leaf_prev_link_mismatch",
+							Message: fmt.Sprintf("%s leaf page %d has PrevLeaf %d, expected %d", objectName, nextLeaf, nextPage.LeafNode.Header.PrevLeaf, pageIdx),
+							Page:    pageIndexPtr(nextLeaf),
+							Object:  objectName,
+						})
+					}
+				}
 				stack = append(stack, nextLeaf)
 			}
 		case page.InternalNode != nil:
@@ -600,13 +626,103 @@ func (d *Database) walkTablePages(ctx context.Context, report IntegrityReport, t
 	return report
 }
 
+// checkTableTreeOrder walks the table B+ tree from root, verifying that each
+// page points back to the internal node it hangs off and that row keys are
+// strictly increasing within a page and fall inside the range the parent's
+// separator keys assign to it: child i holds keys in (key[i-1], key[i]] and
+// the right child holds keys above the last separator. Pages that cannot be
+// read or sit outside the file are skipped, walkTablePages reports them.
+func (d *Database) checkTableTreeOrder(ctx context.Context, report IntegrityReport, table *Table) IntegrityReport {
+	pager := d.factory.ForTable(table.Columns)
+	objectName := fmt.Sprintf("table %s", table.Name)
+	visited := make(map[PageIndex]struct{})
+	return d.checkTableSubtreeOrder(ctx, report, pager, objectName, table.GetRootPageIdx(), 0, nil, nil, visited)
+}
+
+func (d *Database) checkTableSubtreeOrder(ctx context.Context, report IntegrityReport, pager Pager, objectName string, pageIdx, parentIdx PageIndex, lower, upper *RowID, visited map[PageIndex]struct{}) IntegrityReport {
+	if pageIdx >= PageIndex(report.TotalPages) {
+		return report
+	}
+	if _, seen := visited[pageIdx]; seen {
+		return report
+	}
+	visited[pageIdx] = struct{}{}
+
+	page, err := pager.GetPage(ctx, pageIdx)
+	if err != nil {
+		return report
+	}
+
+	var (
+		header *Header
+		keys   []RowID
+	)
+	switch {
+	case page.LeafNode != nil:
+		header = &page.LeafNode.Header.Header
+		keys = page.LeafNode.Keys()
+	case page.InternalNode != nil:
+		header = &page.InternalNode.Header.Header
+		keys = page.InternalNode.Keys()
+	default:
+		return report
+	}
+
+	if header.Parent != parentIdx {
+		report.Issues = append(report.Issues, IntegrityIssue{
+			Code:    "table_parent_mismatch",
+			Message: fmt.Sprintf("%s page %d has parent %d, expected %d", objectName, pageIdx, header.Parent, parentIdx),
+			Page:    pageIndexPtr(pageIdx),
+			Object:  objectName,
+		})
+	}
+
+	for i, key := range keys {
+		if i > 0 && keys[i-1] >= key {
+			report.Issues = append(report.Issues, IntegrityIssue{
+				Code:    "table_keys_out_of_order",
+				Message: fmt.Sprintf("%s page %d has key %d after key %d", objectName, pageIdx, key, keys[i-1]),
+				Page:    pageIndexPtr(pageIdx),
+				Object:  objectName,
+			})
+			break
+		}
+	}
+	for _, key := range keys {
+		if (lower != nil && key <= *lower) || (upper != nil && key > *upper) {
+			report.Issues = append(report.Issues, IntegrityIssue{
+				Code:    "table_key_out_of_range",
+				Message: fmt.Sprintf("%s page %d has key %d outside the range of its parent's separator keys", objectName, pageIdx, key),
+				Page:    pageIndexPtr(pageIdx),
+				Object:  objectName,
+			})
+			break
+		}
+	}
+
+	if page.InternalNode == nil {
+		return report
+	}
+
+	childLower := lower
+	for i := range keys {
+		report = d.checkTableSubtreeOrder(ctx, report, pager, objectName, page.InternalNode.ICells[i].Child, pageIdx, childLower, &keys[i], visited)
+		childLower = &keys[i]
+	}
+	if rightChild := page.InternalNode.Header.RightChild; rightChild != RightChildNotSet {
+		report = d.checkTableSubtreeOrder(ctx, report, pager, objectName, rightChild, pageIdx, childLower, upper, visited)
+	}
+
+	return report
+}
+
 func (d *Database) checkTableLeafPage(ctx context.Context, report IntegrityReport, table *Table, page *Page, fields []Field, livePages map[PageIndex]string) IntegrityReport {
 	selectedMask := selectedColumnsMask(table.Columns, fields)
 	for _, cell := range page.LeafNode.Cells[:page.LeafNode.Header.Cells] {
 		row, err := NewRowView(table.Columns, cell).MaterializeWithOverflow(ctx, table.pager, selectedMask)
 		if err != nil {
 			report.Issues = append(report.Issues, IntegrityIssue{
-				Code:    "table_row_decode_failed",
+				Code:    pageReadIssueCode(err, "table_row_decode_failed"),
 				Message: fmt.Sprintf("failed to decode row on table %s leaf page %d: %v", table.Name, page.Index, err),
 				Page:    pageIndexPtr(page.Index),
 				Object:  table.Name,
@@ -667,7 +783,7 @@ func (d *Database) walkOverflowPages(report IntegrityReport, objectName string,
 		page, err := pager.GetPage(context.Background(), current)
 		if err != nil {
 			report.Issues = append(report.Issues, IntegrityIssue{
-				Code:    "overflow_page_decode_failed",
+				Code:    pageReadIssueCode(err, "overflow_page_decode_failed"),
 				Message: fmt.Sprintf("failed to decode overflow page %d for %s: %v", current, objectName, err),
 				Page:    pageIndexPtr(current),
 				Object:  objectName,
@@ -700,6 +816,7 @@ func (d *Database) walkIndexPages(ctx context.Context, report IntegrityReport, t
 		return report
 	}
 	visited := make(map[PageIndex]struct{})
+	parents := map[PageIndex]PageIndex{root: 0}
 	stack := []PageIndex{root}
 	objectName := fmt.Sprintf("index %s on table %s", indexName, tableName)
 
@@ -725,7 +842,7 @@ func (d *Database) walkIndexPages(ctx context.Context, report IntegrityReport, t
 		page, err := pager.GetPage(ctx, pageIdx)
 		if err != nil {
 			report.Issues = append(report.Issues, IntegrityIssue{
-				Code:    "index_page_decode_failed",
+				Code:    pageReadIssueCode(err, "index_page_decode_failed"),
 				Message: fmt.Sprintf("failed to decode page %d for %s: %v", pageIdx, objectName, err),
 				Page:    pageIndexPtr(pageIdx),
 				Object:  indexName,
@@ -742,7 +859,12 @@ func (d *Database) walkIndexPages(ctx context.Context, report IntegrityReport, t
 			continue
 		}
 
+		report = checkIndexNodeOrder(report, objectName, indexName, pageIdx, parents[pageIdx], page.IndexNode)
+
 		children := indexNodeChildren(page.IndexNode)
+		for _, child := range children {
+			parents[child] = pageIdx
+		}
 		stack = append(stack, children...)
 		report = d.walkIndexOverflowPages(ctx, report, pager, objectName, page.IndexNode, livePages)
 	}
@@ -778,7 +900,7 @@ func (d *Database) walkInvertedIndexPages(ctx context.Context, report IntegrityR
 		page, err := pager.GetPage(ctx, pageIdx)
 		if err != nil {
 			report.Issues = append(report.Issues, IntegrityIssue{
-				Code:    "index_page_decode_failed",
+				Code:    pageReadIssueCode(err, "index_page_decode_failed"),
 				Message: fmt.Sprintf("failed to decode page %d for %s: %v", pageIdx, objectName, err),
 				Page:    pageIndexPtr(pageIdx),
 				Object:  indexName,
@@ -907,7 +1029,7 @@ func walkIndexOverflowChain(ctx context.Context, report IntegrityReport, pager P
 		page, err := pager.GetPage(ctx, current)
 		if err != nil {
 			report.Issues = append(report.Issues, IntegrityIssue{
-				Code:    "index_overflow_decode_failed",
+				Code:    pageReadIssueCode(err, "index_overflow_decode_failed"),
 				Message: fmt.Sprintf("failed to decode index-overflow page %d for %s: %v", current, objectName, err),
 				Page:    pageIndexPtr(current),
 				Object:  objectName,
@@ -929,6 +1051,15 @@ func walkIndexOverflowChain(ctx context.Context, report IntegrityReport, pager P
 	return report
 }
 
+// pageReadIssueCode returns code for a page that failed to read, or
+// page_checksum_mismatch when the page failed its checksum.
+func pageReadIssueCode(err error, code string) string {
+	if errors.Is(err, minisqlErrors.ErrPageChecksumMismatch) {
+		return "page_checksum_mismatch"
+	}
+	return code
+}
+
 func markLivePage(report IntegrityReport, livePages map[PageIndex]string, pageIdx PageIndex, owner string) IntegrityReport {
 	if existing, seen := livePages[pageIdx]; seen {
 		if existing != owner {
@@ -946,6 +1077,54 @@ func markLivePage(report IntegrityReport, livePages map[PageIndex]string, pageId
 	return report
 }
 
+// checkIndexNodeOrder verifies that an index node points back to its parent
+// and that its keys are strictly increasing.
+func checkIndexNodeOrder(report IntegrityReport, objectName, indexName string, pageIdx, parentIdx PageIndex, node any) IntegrityReport {
+	switch n := node.(type) {
+	case *IndexNode[int8]:
+		return checkIndexNodeOrderTyped(report, objectName, indexName, pageIdx, parentIdx, n)
+	case *IndexNode[int32]:
+		return checkIndexNodeOrderTyped(report, objectName, indexName, pageIdx, parentIdx, n)
+	case *IndexNode[int64]:
+		return checkIndexNodeOrderTyped(report, objectName, indexName, pageIdx, parentIdx, n)
+	case *IndexNode[float32]:
+		return checkIndexNodeOrderTyped(report, objectName, indexName, pageIdx, parentIdx, n)
+	case *IndexNode[float64]:
+		return checkIndexNodeOrderTyped(report, objectName, indexName, pageIdx, parentIdx, n)
+	case *IndexNode[string]:
+		return checkIndexNodeOrderTyped(report, objectName, indexName, pageIdx, parentIdx, n)
+	case *IndexNode[CompositeKey]:
+		return checkIndexNodeOrderTyped(report, objectName, indexName, pageIdx, parentIdx, n)
+	case *IndexNode[UUIDValue]:
+		return checkIndexNodeOrderTyped(report, objectName, indexName, pageIdx, parentIdx, n)
+	default:
+		return report
+	}
+}
+
+func checkIndexNodeOrderTyped[T IndexKey](report IntegrityReport, objectName, indexName string, pageIdx, parentIdx PageIndex, node *IndexNode[T]) IntegrityReport {
+	if node.Header.Parent != parentIdx {
+		report.Issues = append(report.Issues, IntegrityIssue{
+			Code:    "index_parent_mismatch",
+			Message: fmt.Sprintf("%s page %d has parent %d, expected %d", objectName, pageIdx, node.Header.Parent, parentIdx),
+			Page:    pageIndexPtr(pageIdx),
+			Object:  indexName,
+		})
+	}
+	for i := 1; i < int(node.Header.Keys); i++ {
+		if compare(node.Cells[i-1].Key, node.Cells[i].Key) >= 0 {
+			report.Issues = append(report.Issues, IntegrityIssue{
+				Code:    "index_keys_out_of_order",
+				Message: fmt.Sprintf("%s page %d has key %v after key %v", objectName, pageIdx, node.Cells[i].Key, node.Cells[i-1].Key),
+				Page:    pageIndexPtr(pageIdx),
+				Object:  indexName,
+			})
+			break
+		}
+	}
+	return report
+}
+
 func indexNodeChildren(node any) []PageIndex {
 	switch n := node.(type) {
 	case *IndexNode[int8]:
@@ -969,7 +1148,7 @@ func indexNodeChildren(node any) []PageIndex {
 	}
 }
 
-func (d *Database) checkTableIndexConsistency(ctx context.Context, report IntegrityReport, table *Table) IntegrityReport {
+func checkTableIndexConsistency(ctx context.Context, report IntegrityReport, table *Table) IntegrityReport {
 	if table.HasPrimaryKey() && table.PrimaryKey.Index != nil {
 		report = checkIndexConsistency(ctx, report, table, indexConsistencyTarget{
 			name:    table.PrimaryKey.Name,
@@ -207,6 +207,140 @@ func TestDatabase_IntegrityCheck(t *testing.T) {
 		assert.Contains(t, issueCodes(report), "table_page_out_of_range")
 	})
 
+	t.Run("leaf prev link mismatch is reported", func(t *testing.T) {
+		pager, dbFile := initTest(t)
+		db, err := NewDatabase(context.Background(), testLogger, dbFile.Name(), nil, pager, pager, nil)
+		require.NoError(t, err)
+
+		rootPageIdx := PageIndex(1)
+		addQuickCheckTestTable(db, pager, "users", rootPageIdx)
+		for len(pager.pages) <= 3 {
+			pager.pages = append(pager.pages, nil)
+		}
+		pager.pages[rootPageIdx] = &Page{
+			Index: rootPageIdx,
+			InternalNode: &InternalNode{
+				Header: InternalNodeHeader{
+					Header:     Header{IsInternal: true, IsRoot: true},
+					KeysNum:    1,
+					RightChild: 3,
+				},
+				ICells: [InternalNodeMaxCells]ICell{
+					{Child: 2},
+				},
+			},
+		}
+		pager.pages[2] = &Page{
+			Index: 2,
+			LeafNode: &LeafNode{
+				Header: LeafNodeHeader{Header: Header{Parent: rootPageIdx}, NextLeaf: 3},
+			},
+		}
+		pager.pages[3] = &Page{
+			Index: 3,
+			LeafNode: &LeafNode{
+				Header: LeafNodeHeader{Header: Header{Parent: rootPageIdx}, PrevLeaf: 0},
+			},
+		}
+		pager.totalPages = 4
+
+		report, err := db.IntegrityCheck(context.Background())
+		require.NoError(t, err)
+		assert.False(t, report.Ok())
+		assert.Contains(t, issueCodes(report), "table_leaf_prev_link_mismatch")
+	})
+
+	t.Run("table parent pointers and key order are checked", func(t *testing.T) {
+		pager, dbFile := initTest(t)
+		db, err := NewDatabase(context.Background(), testLogger, dbFile.Name(), nil, pager, pager, nil)
+		require.NoError(t, err)
+
+		rootPageIdx := PageIndex(1)
+		addQuickCheckTestTable(db, pager, "users", rootPageIdx)
+		for len(pager.pages) <= 3 {
+			pager.pages = append(pager.pages, nil)
+		}
+		pager.pages[rootPageIdx] = &Page{
+			Index: rootPageIdx,
+			InternalNode: &InternalNode{
+				Header: InternalNodeHeader{
+					Header:     Header{IsInternal: true, IsRoot: true},
+					KeysNum:    1,
+					RightChild: 3,
+				},
+				ICells: [InternalNodeMaxCells]ICell{
+					{Key: 5, Child: 2},
+				},
+			},
+		}
+		// Left leaf holds a key above its separator, right leaf points to
+		// the wrong parent and stores its keys out of order.
+		pager.pages[2] = &Page{
+			Index: 2,
+			LeafNode: &LeafNode{
+				Header: LeafNodeHeader{Header: Header{Parent: rootPageIdx}, Cells: 2, NextLeaf: 3},
+				Cells:  []Cell{{Key: 1}, {Key: 7}},
+			},
+		}
+		pager.pages[3] = &Page{
+			Index: 3,
+			LeafNode: &LeafNode{
+				Header: LeafNodeHeader{Header: Header{Parent: 2}, Cells: 2, PrevLeaf: 2},
+				Cells:  []Cell{{Key: 9}, {Key: 8}},
+			},
+		}
+		pager.totalPages = 4
+
+		report, err := db.IntegrityCheck(context.Background())
+		require.NoError(t, err)
+		assert.False(t, report.Ok())
+		assert.Contains(t, issueCodes(report), "table_key_out_of_range")
+		assert.Contains(t, issueCodes(report), "table_parent_mismatch")
+		assert.Contains(t, issueCodes(report), "table_keys_out_of_order")
+	})
+
+	t.Run("page checksum mismatch is reported", func(t *testing.T) {
+		pager, dbFile := initTest(t)
+		db, err := NewDatabase(context.Background(), testLogger, dbFile.Name(), nil, pager, pager, nil)
+		require.NoError(t, err)
+
+		rootPageIdx := PageIndex(1)
+		addQuickCheckTestTable(db, pager, "users", rootPageIdx)
+		ctx := context.Background()
+		require.NoError(t, pager.Flush(ctx, rootPageIdx))
+
+		_, err = dbFile.WriteAt([]byte{0xFF}, int64(rootPageIdx)*PageSize+50)
+		require.NoError(t, err)
+		pager.InvalidatePage(rootPageIdx)
+
+		report, err := db.IntegrityCheck(ctx)
+		require.NoError(t, err)
+		assert.False(t, report.Ok())
+		assert.Contains(t, issueCodes(report), "page_checksum_mismatch")
+	})
+
+	t.Run("index parent pointers and key order are checked", func(t *testing.T) {
+		pager, dbFile := initTest(t)
+		db, err := NewDatabase(context.Background(), testLogger, dbFile.Name(), nil, pager, pager, nil)
+		require.NoError(t, err)
+
+		indexRootPageIdx := PageIndex(2)
+		addQuickCheckTestTableWithSecondaryIndex(db, pager, testTableName, 1, "test_table_email_idx", indexRootPageIdx)
+		indexNode := pager.pages[indexRootPageIdx].IndexNode.(*IndexNode[string])
+		indexNode.Header.Parent = 1
+		indexNode.Header.Keys = 2
+		indexNode.Cells = []IndexCell[string]{
+			{Key: "bob@example.com", RowIDs: []RowID{1}, InlineRowIDs: 1},
+			{Key: "alice@example.com", RowIDs: []RowID{2}, InlineRowIDs: 1},
+		}
+
+		report, err := db.IntegrityCheck(context.Background())
+		require.NoError(t, err)
+		assert.False(t, report.Ok())
+		assert.Contains(t, issueCodes(report), "index_parent_mismatch")
+		assert.Contains(t, issueCodes(report), "index_keys_out_of_order")
+	})
+
 	t.Run("index pages are traversed", func(t *testing.T) {
 		pager, dbFile := initTest(t)
 		db, err := NewDatabase(context.Background(), testLogger, dbFile.Name(), nil, pager, pager, nil)
@@ -7,17 +7,39 @@ import (
 )
 
 // LeafNodeHeader is the on-disk header for a leaf B+ tree node. It extends
-// the base Header with the number of cells currently stored and the page index
-// of the next leaf (used for linked-list traversal in range scans).
+// the base Header with the number of cells currently stored and the page
+// indexes of the next and previous leaves (used for linked-list traversal in
+// forward and reverse scans). Zero means there is no sibling in that direction.
+//
+// PrevLeaf is flagged by a bit in the base header's root byte. Leaves written
+// before it existed have no such bit: they are read with LegacyLinks set and
+// keep their shorter header when rewritten, since a full page has no room to
+// grow, so their previous leaf is found through the parent instead.
+//
+// Leaves of a table with a MINMAX column also carry a PageHint, flagged by a
+// bit in the base header's root byte so pages without one keep the original
+// layout.
 type LeafNodeHeader struct {
 	Header
-	Cells    uint32
-	NextLeaf PageIndex
+	Hint        PageHint
+	Cells       uint32
+	NextLeaf    PageIndex
+	PrevLeaf    PageIndex
+	LegacyLinks bool
 }
 
-// Size returns the serialised byte size of LeafNodeHeader (base Header + 8 bytes).
+// Size returns the serialised byte size of LeafNodeHeader (base Header + 12
+// bytes, or 8 for a legacy leaf without PrevLeaf, plus the page hint when
+// enabled).
 func (h *LeafNodeHeader) Size() uint64 {
-	return h.Header.Size() + 8
+	size := h.Header.Size() + 12
+	if h.LegacyLinks {
+		size -= 4
+	}
+	if h.Hint.Enabled {
+		size += pageHintSize
+	}
+	return size
 }
 
 // Marshal serialises the header into buf in little-endian byte order.
@@ -25,18 +47,33 @@ func (h *LeafNodeHeader) Marshal(buf []byte) {
 	i := uint64(0)
 
 	h.Header.Marshal(buf[i:])
+	if h.Hint.Enabled {
+		buf[1] |= headerFlagPageHint
+	}
+	if !h.LegacyLinks {
+		buf[1] |= headerFlagPrevLeaf
+	}
 	i += h.Header.Size()
 
 	marshalUint32(buf, h.Cells, i)
 	i += 4
 	marshalUint32(buf, uint32(h.NextLeaf), i)
 	i += 4
+	if !h.LegacyLinks {
+		marshalUint32(buf, uint32(h.PrevLeaf), i)
+		i += 4
+	}
+
+	if h.Hint.Enabled {
+		h.Hint.marshal(buf[i:])
+	}
 }
 
 // Unmarshal deserialises the header from buf and returns the number of bytes consumed.
 func (h *LeafNodeHeader) Unmarshal(buf []byte) (uint64, error) {
-	if uint64(len(buf)) < h.Size() {
-		return 0, fmt.Errorf("leaf node header unmarshal: buffer too short (%d < %d)", len(buf), h.Size())
+	// The receiver's hint may be stale, so only the fixed part is checked here.
+	if size := h.Header.Size() + 8; uint64(len(buf)) < size {
+		return 0, fmt.Errorf("leaf node header unmarshal: buffer too short (%d < %d)", len(buf), size)
 	}
 	i := uint64(0)
 
@@ -49,6 +86,25 @@ func (h *LeafNodeHeader) Unmarshal(buf []byte) (uint64, error) {
 	h.Cells = unmarshalUint32(buf, i)
 	i += 4
 	h.NextLeaf = PageIndex(unmarshalUint32(buf, i))
+	i += 4
+
+	h.PrevLeaf = 0
+	h.LegacyLinks = buf[1]&headerFlagPrevLeaf == 0
+	if !h.LegacyLinks {
+		if uint64(len(buf)) < i+4 {
+			return 0, fmt.Errorf("leaf node header unmarshal: buffer too short for prev leaf (%d < %d)", len(buf), i+4)
+		}
+		h.PrevLeaf = PageIndex(unmarshalUint32(buf, i))
+		i += 4
+	}
+
+	h.Hint = PageHint{}
+	if buf[1]&headerFlagPageHint != 0 {
+		if uint64(len(buf)) < i+pageHintSize {
+			return 0, fmt.Errorf("leaf node header unmarshal: buffer too short for page hint (%d < %d)", len(buf), i+pageHintSize)
+		}
+		h.Hint.unmarshal(buf[i:])
+	}
 
 	return h.Size(), nil
 }
@@ -132,23 +188,14 @@ func (c *Cell) Unmarshal(buf []byte) (uint64, error) {
 		if bitwise.IsSet(c.NullBitmask, i) {
 			continue
 		}
-		tc := TypeCode(c.TypeCodes[i])
-		sz := typeCodeFixedSize(tc)
-		if sz >= 0 {
-			totalSize += uint64(sz)
-			scanOffset += uint64(sz)
-		} else {
-			// TypeCodeText: inline → 4-byte length + data; overflow → 4-byte
-			// length+flag + 4-byte page index.  Use TextPointer.Size() so the
-			// overflow flag (bit 31 of the uint32 length) is handled correctly.
-			if scanOffset+4 > uint64(len(buf)) {
-				return 0, fmt.Errorf("cell unmarshal: text column %d offset %d exceeds buffer length %d", i, scanOffset, len(buf))
-			}
-			rawLen := unmarshalUint32(buf, scanOffset)
-			textSize := TextPointer{Length: rawLen}.Size()
-			totalSize += textSize
-			scanOffset += textSize
+		// Variable-length values carry a 4-byte length prefix; for text the
+		// overflow flag (bit 31 of the prefix) is handled by encodedValueSize.
+		sz, err := encodedValueSize(TypeCode(c.TypeCodes[i]), buf, scanOffset)
+		if err != nil {
+			return 0, fmt.Errorf("cell unmarshal: column %d: %w", i, err)
 		}
+		totalSize += sz
+		scanOffset += sz
 	}
 
 	// Pass 2: copy value bytes into owned memory.
@@ -166,8 +213,8 @@ func (c *Cell) Unmarshal(buf []byte) (uint64, error) {
 }
 
 // LeafNode is a leaf page in the B+ tree. It stores an ordered sequence of
-// Cells (one per row) and a NextLeaf pointer that links sibling leaves for
-// efficient range scans.
+// Cells (one per row) plus NextLeaf and PrevLeaf pointers that link sibling
+// leaves for efficient forward and reverse scans.
 type LeafNode struct {
 	Cells  []Cell
 	Header LeafNodeHeader
@@ -430,9 +477,10 @@ func (n *LeafNode) Keys() []RowID {
 // page, accounting for the larger root-page header when IsRoot is set and the
 // 4-byte CRC32 checksum reserved at the end of every page.
 func (n *LeafNode) MaxSpace() uint64 {
-	maxSpace := PageSize - headerSize() - pageChecksumSize
+	maxSpace := PageSize - n.Header.Size() - pageChecksumSize
 	if n.Header.IsRoot {
-		maxSpace = PageSize - rootHeaderSize() - pageChecksumSize
+		// The next autoincrement value is stored in the reserved root space.
+		maxSpace -= RootPageConfigSize - n.Header.autoincrementSize()
 	}
 	return maxSpace
 }
@@ -452,10 +500,16 @@ func (n *LeafNode) AvailableSpace() uint64 {
 }
 
 // HasSpaceForRow reports whether the node has enough free space to store the
-// given row.  The cell overhead is: 8B NullBitmask + 8B Key + 1B ColumnCount
-// + 1B per column (TypeCodes) + value bytes.
+// given row.
 func (n *LeafNode) HasSpaceForRow(row Row) bool {
-	return row.Size()+8+8+1+uint64(len(row.Columns)) <= n.AvailableSpace()
+	return cellSize(row) <= n.AvailableSpace()
+}
+
+// cellSize returns the size of the leaf cell holding row. The cell overhead
+// is: 8B NullBitmask + 8B Key + 1B ColumnCount + 1B per column (TypeCodes)
+// + value bytes.
+func cellSize(row Row) uint64 {
+	return row.Size() + 8 + 8 + 1 + uint64(len(row.Columns))
 }
 
 // AtLeastHalfFull reports whether the node is at least half full by space,
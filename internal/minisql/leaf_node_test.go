@@ -22,6 +22,7 @@ func TestLeafNode_Marshal(t *testing.T) {
 		},
 		Cells:    2,
 		NextLeaf: 4,
+		PrevLeaf: 2,
 	}
 	node.Cells = append(node.Cells, Cell{
 		Key:         1,
@@ -51,6 +52,78 @@ func TestLeafNode_Marshal(t *testing.T) {
 	}
 }
 
+func TestLeafNode_Marshal_LegacyLinks(t *testing.T) {
+	t.Parallel()
+
+	node := NewLeafNode()
+	node.Header = LeafNodeHeader{
+		Header:   Header{Parent: 3},
+		Cells:    1,
+		NextLeaf: 4,
+	}
+	node.Cells = append(node.Cells, Cell{
+		Key:         1,
+		Value:       prefixWithLength([]byte("abc")),
+		TypeCodes:   []byte{byte(TypeCodeText)},
+		ColumnCount: 1,
+	})
+	linkedSize := node.Size()
+
+	// A leaf written before PrevLeaf existed has no flag and a 4 byte shorter
+	// header, and keeps that layout when it is rewritten.
+	node.Header.LegacyLinks = true
+	assert.Equal(t, linkedSize-4, node.Size())
+
+	buf := make([]byte, PageSize)
+	require.NoError(t, node.Marshal(buf))
+	assert.Zero(t, buf[1]&headerFlagPrevLeaf)
+
+	recreatedNode := NewLeafNode()
+	_, err := recreatedNode.Unmarshal(buf)
+	require.NoError(t, err)
+	assert.True(t, recreatedNode.Header.LegacyLinks)
+	assert.Equal(t, PageIndex(4), recreatedNode.Header.NextLeaf)
+	assert.Equal(t, PageIndex(0), recreatedNode.Header.PrevLeaf)
+	assert.Equal(t, node.Cells[0].Value, recreatedNode.Cells[0].Value)
+}
+
+func TestLeafNode_Marshal_NextAutoincrement(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Root page stores the counter", func(t *testing.T) {
+		node := NewLeafNode()
+		node.Header.IsRoot = true
+		maxSpace := node.MaxSpace()
+		node.Header.NextAutoincrement = 42
+
+		// The counter lives in the reserved root space.
+		assert.Equal(t, maxSpace, node.MaxSpace())
+
+		buf := make([]byte, PageSize)
+		require.NoError(t, node.Marshal(buf))
+		assert.NotZero(t, buf[1]&headerFlagAutoincrement)
+
+		recreatedNode := NewLeafNode()
+		_, err := recreatedNode.Unmarshal(buf)
+		require.NoError(t, err)
+		assert.Equal(t, node, recreatedNode)
+	})
+
+	t.Run("Non-root page does not", func(t *testing.T) {
+		node := NewLeafNode()
+		node.Header.NextAutoincrement = 42
+
+		buf := make([]byte, PageSize)
+		require.NoError(t, node.Marshal(buf))
+		assert.Zero(t, buf[1]&headerFlagAutoincrement)
+
+		recreatedNode := NewLeafNode()
+		_, err := recreatedNode.Unmarshal(buf)
+		require.NoError(t, err)
+		assert.Equal(t, int64(0), recreatedNode.Header.NextAutoincrement)
+	})
+}
+
 func prefixWithLength(data []byte) []byte {
 	lengthPrefix := marshalUint32(make([]byte, 4), uint32(len(data)), 0)
 	return append(lengthPrefix, data...)
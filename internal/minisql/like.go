@@ -1,9 +1,29 @@
 package minisql
 
+import (
+	"errors"
+	"strings"
+	"unicode/utf8"
+)
+
+// likeDefaultEscape is the escape character honoured by every LIKE pattern.
+// A backslash before '%', '_' or another backslash makes it a literal.
+const likeDefaultEscape = '\\'
+
+// ErrInvalidLikeEscape is returned when the ESCAPE clause of a LIKE condition
+// is not exactly one character.
+var ErrInvalidLikeEscape = errors.New("LIKE ESCAPE must be a single character")
+
 // likeMatch reports whether str matches the SQL LIKE pattern.
 // '%' matches any sequence of zero or more characters.
 // '_' matches exactly one character.
+// '\' makes the following character a literal; a trailing '\' is itself literal.
 // Matching is case-sensitive and byte-level (consistent with compareText).
+//
+// A leading literal run is matched anchored at the start of str, so patterns
+// such as 'abc%' fail on the first mismatching byte. After a '%', a wildcard-free
+// remainder is matched as a suffix and a literal next byte is located with
+// IndexByte instead of retrying the match at every position.
 func likeMatch(pattern, str string) bool {
 	for pattern != "" {
 		switch pattern[0] {
@@ -15,6 +35,23 @@ func likeMatch(pattern, str string) bool {
 			if pattern == "" {
 				return true // trailing '%' matches anything
 			}
+			if isLikeLiteral(pattern) {
+				return strings.HasSuffix(str, pattern)
+			}
+			if c := pattern[0]; c != '_' && c != likeDefaultEscape {
+				// Only positions starting with the next literal byte can match.
+				for i := strings.IndexByte(str, c); i >= 0; {
+					if likeMatch(pattern, str[i:]) {
+						return true
+					}
+					next := strings.IndexByte(str[i+1:], c)
+					if next < 0 {
+						break
+					}
+					i += next + 1
+				}
+				return false
+			}
 			// Try to match the remaining pattern at every position in str.
 			for i := 0; i <= len(str); i++ {
 				if likeMatch(pattern, str[i:]) {
@@ -28,6 +65,11 @@ func likeMatch(pattern, str string) bool {
 			}
 			pattern = pattern[1:]
 			str = str[1:]
+		case likeDefaultEscape:
+			if len(pattern) > 1 {
+				pattern = pattern[1:] // escaped character is compared literally
+			}
+			fallthrough
 		default:
 			if str == "" || pattern[0] != str[0] {
 				return false
@@ -38,3 +80,62 @@ func likeMatch(pattern, str string) bool {
 	}
 	return str == ""
 }
+
+// isLikeLiteral reports whether pattern contains no wildcards or escapes and
+// therefore only matches itself.
+func isLikeLiteral(pattern string) bool {
+	return strings.IndexAny(pattern, "%_\\") < 0
+}
+
+// likePrefix returns the literal text every string matching pattern starts
+// with: the characters before the first '%' or '_', with escapes removed.
+func likePrefix(pattern string) string {
+	var b strings.Builder
+	for i := 0; i < len(pattern); i++ {
+		switch c := pattern[i]; c {
+		case '%', '_':
+			return b.String()
+		case likeDefaultEscape:
+			if i+1 < len(pattern) {
+				i++ // escaped character is literal
+			}
+			b.WriteByte(pattern[i])
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return b.String()
+}
+
+// LikePatternWithEscape rewrites pattern written for LIKE … ESCAPE 'escape'
+// into the equivalent pattern using the default backslash escape, so that
+// likeMatch needs no knowledge of the ESCAPE clause.
+func LikePatternWithEscape(pattern, escape string) (string, error) {
+	esc, size := utf8.DecodeRuneInString(escape)
+	if size == 0 || size != len(escape) {
+		return "", ErrInvalidLikeEscape
+	}
+	if esc == likeDefaultEscape {
+		return pattern, nil
+	}
+
+	var b strings.Builder
+	b.Grow(len(pattern) + 2)
+	for i := 0; i < len(pattern); {
+		r, n := utf8.DecodeRuneInString(pattern[i:])
+		i += n
+		switch {
+		case r == esc && i < len(pattern):
+			next, m := utf8.DecodeRuneInString(pattern[i:])
+			i += m
+			b.WriteByte(likeDefaultEscape)
+			b.WriteRune(next)
+		case r == likeDefaultEscape:
+			// A backslash is an ordinary character under a custom escape.
+			b.WriteString(`\\`)
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String(), nil
+}
@@ -59,6 +59,28 @@ func TestLikeMatch(t *testing.T) {
 
 		// Patterns longer than string
 		{"pattern longer than string", "toolong", "too", false},
+
+		// Backslash escape
+		{"escaped percent matches literal", `100\%`, "100%", true},
+		{"escaped percent is not a wildcard", `100\%`, "1000", false},
+		{"escaped underscore matches literal", `a\_b`, "a_b", true},
+		{"escaped underscore is not a wildcard", `a\_b`, "axb", false},
+		{"escaped backslash", `a\\b`, `a\b`, true},
+		{"escaped literal char", `\a`, "a", true},
+		{"trailing backslash is literal", `a\`, `a\`, true},
+		{"escape inside percent pattern", `%\%%`, "50% off", true},
+
+		// Literal remainder after percent is matched as a suffix
+		{"email suffix", "%@example.com", "alice@example.com", true},
+		{"email suffix no match", "%@example.com", "alice@example.org", false},
+		{"suffix longer than string", "%@example.com", ".com", false},
+		{"prefix and suffix overlap", "ab%ba", "aba", false},
+
+		// Literal after percent followed by more wildcards
+		{"repeated literal candidates", "%ab_d", "abxabcd", true},
+		{"repeated literal candidates no match", "%ab_d", "abxabc", false},
+		{"literal candidate at end", "%a%", "bbba", true},
+		{"literal candidate missing", "%a%", "bbb", false},
 	}
 
 	for _, tt := range tests {
@@ -107,3 +129,68 @@ func TestCompareText_Like(t *testing.T) {
 		})
 	}
 }
+
+func TestLikePrefix(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		pattern string
+		want    string
+	}{
+		{"abc%", "abc"},
+		{"abc", "abc"},
+		{"ab_d%", "ab"},
+		{"%abc", ""},
+		{`a\%b%`, "a%b"},
+		{`a\\b%`, `a\b`},
+		{`ab\`, `ab\`},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pattern, func(t *testing.T) {
+			assert.Equal(t, tt.want, likePrefix(tt.pattern))
+		})
+	}
+}
+
+func TestLikePatternWithEscape(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		pattern string
+		escape  string
+		want    string
+		wantErr error
+	}{
+		{"backslash is unchanged", `100\%`, `\`, `100\%`, nil},
+		{"custom escape before percent", "100!%", "!", `100\%`, nil},
+		{"custom escape before underscore", "a!_b", "!", `a\_b`, nil},
+		{"escaped escape character", "a!!b", "!", `a\!b`, nil},
+		{"backslash is literal under custom escape", `a\b`, "!", `a\\b`, nil},
+		{"trailing escape character is literal", "a!", "!", "a!", nil},
+		{"multi-byte escape character", "5§%", "§", `5\%`, nil},
+		{"empty escape", "a%", "", "", ErrInvalidLikeEscape},
+		{"multi-character escape", "a%", "ab", "", ErrInvalidLikeEscape},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := LikePatternWithEscape(tt.pattern, tt.escape)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	t.Run("rewritten pattern matches literal percent", func(t *testing.T) {
+		pattern, err := LikePatternWithEscape("%!%", "!")
+		require.NoError(t, err)
+		assert.True(t, likeMatch(pattern, "50%"))
+		assert.False(t, likeMatch(pattern, "500"))
+	})
+}
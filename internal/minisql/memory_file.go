@@ -0,0 +1,153 @@
+package minisql
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+)
+
+// MemoryPath is the database path that selects an ephemeral in-memory
+// database instead of a file on disk.
+const MemoryPath = ":memory:"
+
+// ErrInMemoryDatabase is returned by operations that need a database file on
+// disk, such as VACUUM and backups, when the database lives in memory.
+var ErrInMemoryDatabase = errors.New("not supported for an in-memory database")
+
+// memoryFile is a DBFile backed by a growable byte slice. Writes past the end
+// extend the slice, reads past the end return io.EOF just like *os.File, and
+// Close drops the contents.
+type memoryFile struct {
+	mu     sync.RWMutex
+	data   []byte
+	offset int64
+	closed bool
+}
+
+func newMemoryFile() *memoryFile {
+	return &memoryFile{}
+}
+
+// NewMemoryPager returns a pager whose pages live in memory only. It supports
+// everything a file-backed pager does, and its contents are lost on Close.
+func NewMemoryPager(pageSize, maxCachedPages int, opts ...PagerOption) (*pagerImpl, error) {
+	return NewPager(newMemoryFile(), pageSize, maxCachedPages, opts...)
+}
+
+func (f *memoryFile) ReadAt(b []byte, off int64) (int, error) {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	if f.closed {
+		return 0, os.ErrClosed
+	}
+	if off < 0 {
+		return 0, fmt.Errorf("read at negative offset %d", off)
+	}
+	if off >= int64(len(f.data)) {
+		return 0, io.EOF
+	}
+	n := copy(b, f.data[off:])
+	if n < len(b) {
+		return n, io.EOF
+	}
+	return n, nil
+}
+
+func (f *memoryFile) WriteAt(b []byte, off int64) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.closed {
+		return 0, os.ErrClosed
+	}
+	if off < 0 {
+		return 0, fmt.Errorf("write at negative offset %d", off)
+	}
+	if end := off + int64(len(b)); end > int64(len(f.data)) {
+		if end > int64(cap(f.data)) {
+			grown := make([]byte, end, max(end, 2*int64(cap(f.data))))
+			copy(grown, f.data)
+			f.data = grown
+		} else {
+			f.data = f.data[:end]
+		}
+	}
+	return copy(f.data[off:], b), nil
+}
+
+func (f *memoryFile) Read(b []byte) (int, error) {
+	f.mu.Lock()
+	offset := f.offset
+	f.mu.Unlock()
+
+	n, err := f.ReadAt(b, offset)
+
+	f.mu.Lock()
+	f.offset += int64(n)
+	f.mu.Unlock()
+	return n, err
+}
+
+func (f *memoryFile) Seek(offset int64, whence int) (int64, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.closed {
+		return 0, os.ErrClosed
+	}
+	switch whence {
+	case io.SeekStart:
+	case io.SeekCurrent:
+		offset += f.offset
+	case io.SeekEnd:
+		offset += int64(len(f.data))
+	default:
+		return 0, fmt.Errorf("invalid seek whence %d", whence)
+	}
+	if offset < 0 {
+		return 0, fmt.Errorf("seek to negative offset %d", offset)
+	}
+	f.offset = offset
+	return offset, nil
+}
+
+// Truncate changes the size of the file, so chunked growth can trim its
+// preallocated tail.
+func (f *memoryFile) Truncate(size int64) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.closed {
+		return os.ErrClosed
+	}
+	if size < 0 {
+		return fmt.Errorf("truncate to negative size %d", size)
+	}
+	if size <= int64(len(f.data)) {
+		f.data = f.data[:size]
+		return nil
+	}
+	f.data = append(f.data, make([]byte, size-int64(len(f.data)))...)
+	return nil
+}
+
+// Sync is a no-op: there is nothing durable to flush to.
+func (f *memoryFile) Sync() error {
+	return nil
+}
+
+// Close releases the contents of the file.
+func (f *memoryFile) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.closed {
+		return os.ErrClosed
+	}
+	f.data = nil
+	f.closed = true
+	return nil
+}
@@ -0,0 +1,80 @@
+package minisql
+
+import (
+	"io"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryFile(t *testing.T) {
+	t.Parallel()
+
+	f := newMemoryFile()
+
+	size, err := f.Seek(0, io.SeekEnd)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), size)
+
+	// Writing past the end zero-fills the gap.
+	n, err := f.WriteAt([]byte("page"), 8)
+	require.NoError(t, err)
+	assert.Equal(t, 4, n)
+
+	size, err = f.Seek(0, io.SeekEnd)
+	require.NoError(t, err)
+	assert.Equal(t, int64(12), size)
+
+	buf := make([]byte, 12)
+	n, err = f.ReadAt(buf, 0)
+	require.NoError(t, err)
+	assert.Equal(t, 12, n)
+	assert.Equal(t, append(make([]byte, 8), "page"...), buf)
+
+	// A read that runs off the end reports io.EOF like *os.File.
+	n, err = f.ReadAt(buf, 10)
+	assert.Equal(t, 2, n)
+	assert.ErrorIs(t, err, io.EOF)
+	_, err = f.ReadAt(buf, 12)
+	assert.ErrorIs(t, err, io.EOF)
+
+	_, err = f.Seek(8, io.SeekStart)
+	require.NoError(t, err)
+	n, err = f.Read(buf[:4])
+	require.NoError(t, err)
+	assert.Equal(t, "page", string(buf[:n]))
+
+	require.NoError(t, f.Truncate(4))
+	size, err = f.Seek(0, io.SeekEnd)
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), size)
+
+	require.NoError(t, f.Sync())
+	require.NoError(t, f.Close())
+	_, err = f.ReadAt(buf, 0)
+	assert.ErrorIs(t, err, os.ErrClosed)
+	assert.ErrorIs(t, f.Close(), os.ErrClosed)
+}
+
+func TestNewMemoryPager(t *testing.T) {
+	t.Parallel()
+
+	pager, err := NewMemoryPager(PageSize, 10)
+	require.NoError(t, err)
+	assert.Equal(t, uint32(0), pager.TotalPages())
+
+	columns := testColumns[:1]
+	tablePager := pager.ForTable(columns)
+	page, err := tablePager.GetPage(t.Context(), 0)
+	require.NoError(t, err)
+	require.NotNil(t, page.LeafNode)
+	require.NoError(t, pager.Flush(t.Context(), 0))
+
+	size, err := pager.File().Seek(0, io.SeekEnd)
+	require.NoError(t, err)
+	assert.Equal(t, int64(PageSize), size)
+
+	require.NoError(t, pager.Close())
+}
@@ -18,9 +18,14 @@ type EngineSnapshot struct {
 	TxRollbacks        int64
 	QueriesTotal       int64
 	QueriesSlow        int64
+	QueryCacheHits     int64
+	QueryCacheMisses   int64
+	StmtCacheHits      int64
+	StmtCacheMisses    int64
 	SortsInMemory      int64
 	SortSpillRuns      int64
 	SortSpillBytes     int64
+	AutoVacuums        int64
 }
 
 // engineMetrics holds all engine-level performance counters and gauges.
@@ -47,10 +52,21 @@ type engineMetrics struct {
 	queriesTotal atomic.Int64
 	queriesSlow  atomic.Int64
 
+	// Query cache
+	queryCacheHits   atomic.Int64 // SELECTs answered from the query cache
+	queryCacheMisses atomic.Int64 // cacheable SELECTs that had to run
+
+	// Statement cache
+	stmtCacheHits   atomic.Int64 // queries whose parsed statements were cached
+	stmtCacheMisses atomic.Int64 // queries that had to be parsed
+
 	// Sort
 	sortsInMemory  atomic.Int64 // ORDER BY completed without spilling to disk
 	sortSpillRuns  atomic.Int64 // cumulative run files written to disk
 	sortSpillBytes atomic.Int64 // cumulative bytes written to run files
+
+	// Maintenance
+	autoVacuums atomic.Int64 // VACUUM runs triggered by the free-page ratio
 }
 
 func (m *engineMetrics) recordQuery(slow bool) {
@@ -74,8 +90,13 @@ func (m *engineMetrics) snapshot() EngineSnapshot {
 		TxRollbacks:        m.txRollbacks.Load(),
 		QueriesTotal:       m.queriesTotal.Load(),
 		QueriesSlow:        m.queriesSlow.Load(),
+		QueryCacheHits:     m.queryCacheHits.Load(),
+		QueryCacheMisses:   m.queryCacheMisses.Load(),
+		StmtCacheHits:      m.stmtCacheHits.Load(),
+		StmtCacheMisses:    m.stmtCacheMisses.Load(),
 		SortsInMemory:      m.sortsInMemory.Load(),
 		SortSpillRuns:      m.sortSpillRuns.Load(),
 		SortSpillBytes:     m.sortSpillBytes.Load(),
+		AutoVacuums:        m.autoVacuums.Load(),
 	}
 }
@@ -113,7 +113,7 @@ var (
 		int((PageSize-uint32(RootPageConfigSize)-
 			uint32(pageChecksumSize)- // 4-byte CRC32 checksum at end of page
 			7-                        // base header
-			8-                        // leaf header
+			12-                       // leaf header
 			5*8-                      // 5 keys
 			5*8-                      // 5 null bitmasks
 			5*1-                      // 5 ColumnCount bytes (self-describing cell format)
@@ -166,7 +166,7 @@ var (
 		int((PageSize - uint32(RootPageConfigSize) -
 			uint32(pageChecksumSize) - // 4-byte CRC32 checksum at end of page
 			7 -                        // base header
-			8 -                        // leaf header
+			12 -                       // leaf header
 			8 -                        // 1 key
 			8 -                        // 1 null bitmask
 			1 -                        // 1 ColumnCount byte (self-describing cell format)
@@ -0,0 +1,221 @@
+package minisql
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+
+	minisqlErrors "github.com/RichardKnop/minisql/pkg/errors"
+)
+
+// DefaultImportBatchSize is the number of rows ImportNDJSON and ImportCSV
+// insert per INSERT statement.
+const DefaultImportBatchSize = 500
+
+// ErrImportUnknownColumn is returned by ImportNDJSON when a JSON object has a
+// key that is not a column of the table, and by ImportCSV when a header names
+// one, and lenient mode is off.
+var ErrImportUnknownColumn = errors.New("unknown column")
+
+type importConfig struct {
+	lenient   bool
+	batchSize int
+
+	// CSV only.
+	csvDelimiter    rune
+	csvEmptyAsNull  bool
+	csvHeader       csvHeaderMode
+	continueOnError bool
+	onError         func(line int, err error)
+}
+
+// reportError passes the error of a skipped row to the WithContinueOnError
+// callback.
+func (c importConfig) reportError(line int, err error) {
+	if c.onError != nil {
+		c.onError(line, err)
+	}
+}
+
+// ImportOption configures ImportNDJSON and ImportCSV.
+type ImportOption func(*importConfig)
+
+// WithLenientImport makes ImportNDJSON ignore object keys, and ImportCSV the
+// fields of header names, that do not match a column instead of failing the
+// import.
+func WithLenientImport() ImportOption {
+	return func(c *importConfig) {
+		c.lenient = true
+	}
+}
+
+// WithImportBatchSize sets how many rows ImportNDJSON and ImportCSV insert per
+// INSERT statement. Values below 1 are ignored.
+func WithImportBatchSize(n int) ImportOption {
+	return func(c *importConfig) {
+		if n > 0 {
+			c.batchSize = n
+		}
+	}
+}
+
+// ImportNDJSON inserts one row per line of newline-delimited JSON read from
+// r into tableName and returns the number of rows inserted. Each line must be
+// a JSON object whose keys name columns; values are converted to the column
+// kinds and go through the same validation as an INSERT. Columns missing from
+// an object get their default value, or NULL. Blank lines are skipped.
+//
+// Rows are inserted in batches within a single transaction, so either every
+// line is imported or, on the first error, none is. When called with a
+// transaction in ctx the rows become part of that transaction instead.
+func (d *Database) ImportNDJSON(ctx context.Context, tableName string, r io.Reader, opts ...ImportOption) (int64, error) {
+	config := importConfig{batchSize: DefaultImportBatchSize}
+	for _, opt := range opts {
+		opt(&config)
+	}
+
+	var imported int64
+	err := d.txManager.ExecuteInTransaction(ctx, func(ctx context.Context) error {
+		table, ok := d.GetTable(ctx, tableName)
+		if !ok {
+			return minisqlErrors.ErrNoSuchTable{Name: tableName}
+		}
+
+		var (
+			reader    = bufio.NewReader(r)
+			batch     [][]OptionalValue
+			columns   []string // columns set by every row of batch
+			firstLine int      // line number of the first row in batch
+			lineNo    int
+		)
+		flush := func() error {
+			if len(batch) == 0 {
+				return nil
+			}
+			result, err := d.ExecuteStatement(ctx, Statement{
+				Kind:      Insert,
+				TableName: tableName,
+				Fields:    builderFields(columns),
+				Inserts:   batch,
+			})
+			if err != nil {
+				return fmt.Errorf("batch starting at line %d: %w", firstLine, err)
+			}
+			imported += int64(result.RowsAffected)
+			batch = nil
+			return nil
+		}
+
+		for {
+			line, readErr := reader.ReadBytes('\n')
+			if readErr != nil && readErr != io.EOF {
+				return readErr
+			}
+			lineNo++
+			if line = bytes.TrimSpace(line); len(line) > 0 {
+				rowColumns, row, err := ndjsonRow(table, line, config.lenient)
+				if err != nil {
+					return fmt.Errorf("line %d: %w", lineNo, err)
+				}
+				// A batch shares one column list, so a row setting a different
+				// set of columns starts a new batch.
+				if !slices.Equal(rowColumns, columns) || len(batch) >= config.batchSize {
+					if err := flush(); err != nil {
+						return err
+					}
+					columns = rowColumns
+				}
+				if len(batch) == 0 {
+					firstLine = lineNo
+				}
+				batch = append(batch, row)
+			}
+			if readErr == io.EOF {
+				return flush()
+			}
+		}
+	})
+	if err != nil {
+		return 0, err
+	}
+	return imported, nil
+}
+
+// ndjsonRow decodes one JSON object into the names of the columns it sets,
+// in table column order, and their values.
+func ndjsonRow(table *Table, line []byte, lenient bool) ([]string, []OptionalValue, error) {
+	var object map[string]json.RawMessage
+	if err := json.Unmarshal(line, &object); err != nil {
+		return nil, nil, err
+	}
+
+	for key := range object {
+		if _, ok := table.ColumnByName(key); !ok && !lenient {
+			return nil, nil, fmt.Errorf("%w %q for table %s", ErrImportUnknownColumn, key, table.Name)
+		}
+	}
+
+	var (
+		columns = make([]string, 0, len(object))
+		row     = make([]OptionalValue, 0, len(object))
+	)
+	for _, col := range table.Columns {
+		raw, ok := object[col.Name]
+		if !ok || col.Deleted {
+			continue
+		}
+		value, err := ndjsonValue(col, raw)
+		if err != nil {
+			return nil, nil, err
+		}
+		columns = append(columns, col.Name)
+		row = append(row, value)
+	}
+	return columns, row, nil
+}
+
+// ndjsonValue converts a JSON value to the representation the parser would
+// produce for the equivalent SQL literal in a column of col's kind.
+func ndjsonValue(col Column, raw json.RawMessage) (OptionalValue, error) {
+	if string(raw) == "null" {
+		return OptionalValue{}, nil
+	}
+
+	switch col.Kind {
+	case Boolean:
+		var b bool
+		if err := json.Unmarshal(raw, &b); err != nil {
+			return OptionalValue{}, fmt.Errorf("column %q: expected a boolean, got %s", col.Name, raw)
+		}
+		return OptionalValue{Value: b, Valid: true}, nil
+	case Int4, Int8:
+		n, err := strconv.ParseInt(string(raw), 10, 64)
+		if err != nil {
+			return OptionalValue{}, fmt.Errorf("column %q: expected an integer, got %s", col.Name, raw)
+		}
+		return OptionalValue{Value: n, Valid: true}, nil
+	case Real, Double:
+		var f float64
+		if err := json.Unmarshal(raw, &f); err != nil {
+			return OptionalValue{}, fmt.Errorf("column %q: expected a number, got %s", col.Name, raw)
+		}
+		return OptionalValue{Value: f, Valid: true}, nil
+	case JSON, Vector:
+		// JSON documents are stored as text; vectors use the same bracketed
+		// syntax as a JSON array of numbers.
+		return OptionalValue{Value: NewTextPointer(bytes.Clone(raw)), Valid: true}, nil
+	default:
+		// Text, VARCHAR, TIMESTAMP and UUID values are JSON strings.
+		var s string
+		if err := json.Unmarshal(raw, &s); err != nil {
+			return OptionalValue{}, fmt.Errorf("column %q: expected a string, got %s", col.Name, raw)
+		}
+		return OptionalValue{Value: NewTextPointer([]byte(s)), Valid: true}, nil
+	}
+}
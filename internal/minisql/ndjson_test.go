@@ -0,0 +1,151 @@
+package minisql
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newImportTestDB(t *testing.T) *Database {
+	t.Helper()
+
+	createStmt := Statement{
+		Kind:      CreateTable,
+		TableName: "events",
+		Columns: []Column{
+			{Kind: Int8, Size: 8, Name: "id"},
+			{Kind: Varchar, Size: 50, Name: "name"},
+			{Kind: Double, Size: 8, Name: "score", Nullable: true},
+			{
+				Kind:         Varchar,
+				Size:         20,
+				Name:         "status",
+				Nullable:     true,
+				DefaultValue: OptionalValue{Value: NewTextPointer([]byte("new")), Valid: true},
+			},
+			{Kind: Boolean, Size: 1, Name: "active", Nullable: true},
+		},
+	}
+	mockParser := new(MockParser)
+	mockParser.On("Parse", mock.Anything, createStmt.DDL()).Return([]Statement{createStmt}, nil)
+
+	db, _ := newVacuumTestDB(t, mockParser)
+	execInTx(t, db, func(ctx context.Context) {
+		_, err := db.ExecuteStatement(ctx, createStmt)
+		require.NoError(t, err)
+	})
+	return db
+}
+
+// importedRows returns every row of the events table as a map of column name
+// to value, with text values converted to strings.
+func importedRows(t *testing.T, db *Database) []map[string]any {
+	t.Helper()
+	tbl := db.tables["events"]
+
+	var rows []map[string]any
+	err := db.txManager.ExecuteInTransaction(context.Background(), func(ctx context.Context) error {
+		result, err := tbl.Select(ctx, Statement{
+			Kind:   Select,
+			Fields: fieldsFromColumns(tbl.Columns...),
+		})
+		if err != nil {
+			return err
+		}
+		for result.Rows.Next(ctx) {
+			row := result.Rows.Row()
+			values := make(map[string]any, len(tbl.Columns))
+			for _, col := range tbl.Columns {
+				value, ok := row.GetValue(col.Name)
+				require.True(t, ok)
+				if !value.Valid {
+					values[col.Name] = nil
+					continue
+				}
+				if tp, ok := value.Value.(TextPointer); ok {
+					values[col.Name] = tp.String()
+					continue
+				}
+				values[col.Name] = value.Value
+			}
+			rows = append(rows, values)
+		}
+		return result.Rows.Err()
+	})
+	require.NoError(t, err)
+	return rows
+}
+
+func TestDatabase_ImportNDJSON(t *testing.T) {
+	t.Parallel()
+
+	input := strings.Join([]string{
+		`{"id": 1, "name": "first", "score": 1.5, "status": "done", "active": true}`,
+		`{"id": 2, "name": "second"}`,
+		``,
+		`{"id": 3, "name": "third", "score": null, "active": false, "source": "api"}`,
+		`{"name": "fourth", "id": 4, "score": 7}`,
+	}, "\n")
+
+	t.Run("unknown column fails the whole import", func(t *testing.T) {
+		t.Parallel()
+		db := newImportTestDB(t)
+
+		n, err := db.ImportNDJSON(context.Background(), "events", strings.NewReader(input))
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, ErrImportUnknownColumn))
+		assert.Contains(t, err.Error(), "line 4")
+		assert.Equal(t, int64(0), n)
+		assert.Empty(t, importedRows(t, db))
+	})
+
+	t.Run("lenient import skips unknown columns", func(t *testing.T) {
+		t.Parallel()
+		db := newImportTestDB(t)
+
+		n, err := db.ImportNDJSON(context.Background(), "events", strings.NewReader(input), WithLenientImport(), WithImportBatchSize(2))
+		require.NoError(t, err)
+		assert.Equal(t, int64(4), n)
+
+		expected := []map[string]any{
+			{"id": int64(1), "name": "first", "score": 1.5, "status": "done", "active": true},
+			{"id": int64(2), "name": "second", "score": nil, "status": "new", "active": nil},
+			{"id": int64(3), "name": "third", "score": nil, "status": "new", "active": false},
+			{"id": int64(4), "name": "fourth", "score": float64(7), "status": "new", "active": nil},
+		}
+		assert.ElementsMatch(t, expected, importedRows(t, db))
+	})
+
+	t.Run("invalid value reports its line", func(t *testing.T) {
+		t.Parallel()
+		db := newImportTestDB(t)
+
+		input := "{\"id\": 1, \"name\": \"ok\"}\n{\"id\": \"two\", \"name\": \"bad\"}\n"
+		_, err := db.ImportNDJSON(context.Background(), "events", strings.NewReader(input))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `line 2: column "id": expected an integer`)
+		assert.Empty(t, importedRows(t, db))
+	})
+
+	t.Run("missing required column fails", func(t *testing.T) {
+		t.Parallel()
+		db := newImportTestDB(t)
+
+		_, err := db.ImportNDJSON(context.Background(), "events", strings.NewReader(`{"id": 1}`))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "batch starting at line 1")
+	})
+
+	t.Run("unknown table", func(t *testing.T) {
+		t.Parallel()
+		db := newImportTestDB(t)
+
+		_, err := db.ImportNDJSON(context.Background(), "missing", strings.NewReader(input))
+		require.Error(t, err)
+	})
+}
@@ -10,8 +10,8 @@ const (
 
 	// UsablePageSize returns the usable size of a page after accounting for headers
 	// and the trailing 4-byte CRC32 checksum.
-	// Page size minus base + internal/leaf header, minus key and null bitmask, minus checksum.
-	UsablePageSize = PageSize - 7 - 8 - 8 - 8 - pageChecksumSize
+	// Page size minus base + leaf header, minus key and null bitmask, minus checksum.
+	UsablePageSize = PageSize - 7 - 12 - 8 - 8 - pageChecksumSize
 )
 
 // PageIndex is a 0-based index that identifies a page within a database file.
@@ -84,11 +84,7 @@ func (p *Page) Clone() *Page {
 }
 
 func headerSize() uint64 {
-	return 6 + 8 // base header + leaf/internal header
-}
-
-func rootHeaderSize() uint64 {
-	return 6 + 8 + RootPageConfigSize // base header + leaf/internal header + root page config
+	return 6 + 12 // base header + leaf header
 }
 
 func (p *Page) setParent(parentIdx PageIndex) {
@@ -98,3 +94,23 @@ func (p *Page) setParent(parentIdx PageIndex) {
 		p.InternalNode.Header.Parent = parentIdx
 	}
 }
+
+// nextAutoincrement returns the next autoincrement value stored in a table
+// root page, or 0 when none is stored.
+func (p *Page) nextAutoincrement() int64 {
+	if p.LeafNode != nil {
+		return p.LeafNode.Header.NextAutoincrement
+	} else if p.InternalNode != nil {
+		return p.InternalNode.Header.NextAutoincrement
+	}
+	return 0
+}
+
+// setNextAutoincrement stores the next autoincrement value in a table root page.
+func (p *Page) setNextAutoincrement(next int64) {
+	if p.LeafNode != nil {
+		p.LeafNode.Header.NextAutoincrement = next
+	} else if p.InternalNode != nil {
+		p.InternalNode.Header.NextAutoincrement = next
+	}
+}
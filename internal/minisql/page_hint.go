@@ -0,0 +1,389 @@
+package minisql
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/RichardKnop/minisql/pkg/bitwise"
+)
+
+// PageHint is an optional min/max summary of one column over the rows stored
+// on a leaf page (a zone map). Tables opt in for a single numeric or timestamp
+// column with the MINMAX column option; sequential scans then skip leaf pages
+// whose range cannot satisfy a range predicate on that column. This pays off
+// when the column is correlated with insertion order, e.g. timestamps on an
+// append-only table.
+//
+// The hint is recomputed from the page's cells when the transaction that
+// modified the page commits, so every committed page version carries an exact
+// summary of its own cells. Pages in the current transaction's write set may
+// carry a stale hint and are never skipped.
+type PageHint struct {
+	Min uint64 // int64 or float64 bits, depending on the column type
+	Max uint64
+	// Column is the ordinal of the hinted column.
+	Column uint8
+	// Enabled marks leaves of a table with a MINMAX column. Only those pages
+	// serialise the hint.
+	Enabled bool
+	// HasValues is false when the page holds no non-NULL value for the
+	// column, in which case Min and Max are meaningless.
+	HasValues bool
+	// Unbounded is set when the page mixes integer and floating point values
+	// for the column, which can only happen for a corrupt page; such a page
+	// is never skipped.
+	Unbounded bool
+}
+
+const (
+	// pageHintSize is the serialised size of a PageHint:
+	// [1B column][1B flags][8B min][8B max].
+	pageHintSize = 1 + 1 + 8 + 8
+
+	pageHintHasValues byte = 1 << 0
+	pageHintUnbounded byte = 1 << 1
+)
+
+// newPageHint returns the hint to install on new leaves of a table, or a
+// disabled hint when the table has no MINMAX column.
+func newPageHint(columns []Column) PageHint {
+	for i, col := range columns {
+		if col.MinMax && !col.Deleted {
+			return PageHint{Column: uint8(i), Enabled: true}
+		}
+	}
+	return PageHint{}
+}
+
+func (h *PageHint) marshal(buf []byte) {
+	buf[0] = h.Column
+	var flags byte
+	if h.HasValues {
+		flags |= pageHintHasValues
+	}
+	if h.Unbounded {
+		flags |= pageHintUnbounded
+	}
+	buf[1] = flags
+	marshalUint64(buf, h.Min, 2)
+	marshalUint64(buf, h.Max, 10)
+}
+
+func (h *PageHint) unmarshal(buf []byte) {
+	h.Enabled = true
+	h.Column = buf[0]
+	h.HasValues = buf[1]&pageHintHasValues != 0
+	h.Unbounded = buf[1]&pageHintUnbounded != 0
+	h.Min = unmarshalUint64(buf, 2)
+	h.Max = unmarshalUint64(buf, 10)
+}
+
+// refreshPageHint recomputes the page hint from the node's cells. It is a
+// no-op for leaves without a hint.
+func (n *LeafNode) refreshPageHint() {
+	hint := &n.Header.Hint
+	if !hint.Enabled {
+		return
+	}
+	*hint = PageHint{Column: hint.Column, Enabled: true}
+
+	var (
+		minInt, maxInt     int64
+		minFloat, maxFloat float64
+		hasInt, hasFloat   bool
+	)
+	for idx := range n.Cells[:n.Header.Cells] {
+		intValue, floatValue, isFloat, ok := n.Cells[idx].hintValue(int(hint.Column))
+		switch {
+		case !ok:
+			continue
+		case isFloat:
+			if !hasFloat || floatValue < minFloat {
+				minFloat = floatValue
+			}
+			if !hasFloat || floatValue > maxFloat {
+				maxFloat = floatValue
+			}
+			hasFloat = true
+		default:
+			if !hasInt || intValue < minInt {
+				minInt = intValue
+			}
+			if !hasInt || intValue > maxInt {
+				maxInt = intValue
+			}
+			hasInt = true
+		}
+	}
+
+	switch {
+	case hasInt && hasFloat:
+		hint.HasValues = true
+		hint.Unbounded = true
+	case hasInt:
+		hint.HasValues = true
+		hint.Min, hint.Max = uint64(minInt), uint64(maxInt)
+	case hasFloat:
+		hint.HasValues = true
+		hint.Min, hint.Max = math.Float64bits(minFloat), math.Float64bits(maxFloat)
+	}
+}
+
+// hintValue decodes the value of column col from the cell for page hint
+// purposes. Integer and timestamp columns are returned as int64, floating
+// point columns as float64. ok is false for NULL, NaN, unsupported types and
+// rows written before the column existed.
+func (c *Cell) hintValue(col int) (intValue int64, floatValue float64, isFloat bool, ok bool) {
+	if col >= int(c.ColumnCount) || bitwise.IsSet(c.NullBitmask, col) {
+		return 0, 0, false, false
+	}
+	offset := uint64(0)
+	for i := range col {
+		if bitwise.IsSet(c.NullBitmask, i) {
+			continue
+		}
+		size, err := encodedValueSize(TypeCode(c.TypeCodes[i]), c.Value, offset)
+		if err != nil {
+			return 0, 0, false, false
+		}
+		offset += size
+	}
+	switch TypeCode(c.TypeCodes[col]) {
+	case TypeCodeInt4:
+		return int64(unmarshalInt32(c.Value, offset)), 0, false, true
+	case TypeCodeInt8, TypeCodeTimestamp:
+		return unmarshalInt64(c.Value, offset), 0, false, true
+	case TypeCodeReal:
+		floatValue = float64(unmarshalFloat32(c.Value, offset))
+	case TypeCodeDouble:
+		floatValue = unmarshalFloat64(c.Value, offset)
+	default:
+		return 0, 0, false, false
+	}
+	if math.IsNaN(floatValue) {
+		return 0, 0, false, false
+	}
+	return 0, floatValue, true, true
+}
+
+// refreshPageHints recomputes the hints of all leaf pages modified by tx. It
+// runs at commit, before the pages are serialised.
+func refreshPageHints(tx *Transaction) {
+	tx.ForEachWrite(func(_ PageIndex, info WriteInfo) {
+		if info.Page != nil && info.LeafNode != nil {
+			info.LeafNode.refreshPageHint()
+		}
+	})
+}
+
+// isPageHintColumnKind reports whether a column of kind k can carry MINMAX
+// page hints.
+func isPageHintColumnKind(k ColumnKind) bool {
+	switch k {
+	case Int4, Int8, Real, Double, Timestamp:
+		return true
+	default:
+		return false
+	}
+}
+
+// validatePageHintColumns checks the MINMAX columns of a CREATE TABLE
+// statement: at most one per table, of a numeric or timestamp type, and not
+// the primary key (which already has an index for range scans).
+func (s Statement) validatePageHintColumns() error {
+	var minMaxColumn string
+	for _, col := range s.Columns {
+		if !col.MinMax {
+			continue
+		}
+		if minMaxColumn != "" {
+			return fmt.Errorf("only one MINMAX column is allowed per table, got %q and %q", minMaxColumn, col.Name)
+		}
+		minMaxColumn = col.Name
+		if !isPageHintColumnKind(col.Kind) {
+			return fmt.Errorf("MINMAX column %q must be a numeric or timestamp column, got %s", col.Name, col.Kind)
+		}
+		for _, pkCol := range s.PrimaryKey.Columns {
+			if pkCol.Name == col.Name {
+				return fmt.Errorf("MINMAX cannot be used on primary key column %q", col.Name)
+			}
+		}
+	}
+	return nil
+}
+
+// pageHintBound is a single range predicate on the hinted column. literals
+// are already converted to the hint encoding (see pageHintLiteral).
+type pageHintBound struct {
+	operator Operator
+	literals []any
+}
+
+// compilePageHintFilter returns a function reporting whether a leaf page may
+// contain rows matching filters, judged by its page hint alone. It returns nil
+// when the table has no MINMAX column or the filters do not constrain it.
+//
+// Filters are in disjunctive normal form: a page can be skipped only when
+// every OR group contains a condition on the hinted column that no value in
+// the page's [min, max] range satisfies. NULLs never satisfy a range
+// predicate, so a page without non-NULL values is skipped too.
+func (t *Table) compilePageHintFilter(filters OneOrMore) func(context.Context, *Page) bool {
+	tableHint := newPageHint(t.Columns)
+	if !tableHint.Enabled || len(filters) == 0 {
+		return nil
+	}
+	col := t.Columns[tableHint.Column]
+
+	groups := make([][]pageHintBound, 0, len(filters))
+	for _, group := range filters {
+		var bounds []pageHintBound
+		for _, cond := range group {
+			if bound, ok := pageHintBoundFor(col, cond); ok {
+				bounds = append(bounds, bound)
+			}
+		}
+		if len(bounds) == 0 {
+			// This group does not constrain the column: every page may match.
+			return nil
+		}
+		groups = append(groups, bounds)
+	}
+
+	return func(ctx context.Context, page *Page) bool {
+		if page.LeafNode == nil {
+			return true
+		}
+		hint := page.LeafNode.Header.Hint
+		if !hint.Enabled || hint.Unbounded || hint.Column != tableHint.Column {
+			return true
+		}
+		if tx := TxFromContext(ctx); tx != nil && !tx.ReadOnly {
+			if _, modified := tx.GetModifiedPage(page.Index); modified {
+				return true
+			}
+		}
+		if !hint.HasValues {
+			return false
+		}
+		for _, bounds := range groups {
+			if pageHintGroupMayMatch(col.Kind, hint, bounds) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// pageHintBoundFor extracts a range predicate on col from cond.
+func pageHintBoundFor(col Column, cond Condition) (pageHintBound, bool) {
+	if cond.Operand1.Type != OperandField {
+		return pageHintBound{}, false
+	}
+	field, ok := cond.Operand1.Value.(Field)
+	if !ok || field.Name != col.Name {
+		return pageHintBound{}, false
+	}
+	switch cond.Operator {
+	case Eq, Gt, Gte, Lt, Lte:
+		if literal, ok := pageHintLiteral(col.Kind, cond.Operand2.Value); ok {
+			return pageHintBound{operator: cond.Operator, literals: []any{literal}}, true
+		}
+	case Between:
+		list, ok := cond.Operand2.Value.([]any)
+		if !ok || len(list) != 2 {
+			return pageHintBound{}, false
+		}
+		literals := make([]any, len(list))
+		for i, value := range list {
+			if literals[i], ok = pageHintLiteral(col.Kind, value); !ok {
+				return pageHintBound{}, false
+			}
+		}
+		return pageHintBound{operator: Between, literals: literals}, true
+	}
+	return pageHintBound{}, false
+}
+
+// pageHintLiteral converts a literal compared against a column of kind k to
+// the hint encoding: int64 for integer and timestamp columns, float64 for
+// floating point columns.
+func pageHintLiteral(k ColumnKind, value any) (any, bool) {
+	switch k {
+	case Int4, Int8:
+		v, ok := value.(int64)
+		return v, ok
+	case Timestamp:
+		v, ok := value.(TimestampMicros)
+		return int64(v), ok
+	case Real:
+		// REAL comparisons happen in float32 precision.
+		v, ok := value.(float64)
+		return float64(float32(v)), ok && !math.IsNaN(v)
+	case Double:
+		v, ok := value.(float64)
+		return v, ok && !math.IsNaN(v)
+	default:
+		return nil, false
+	}
+}
+
+// pageHintGroupMayMatch reports whether some value in the hint's range can
+// satisfy all bounds of one AND group.
+func pageHintGroupMayMatch(k ColumnKind, hint PageHint, bounds []pageHintBound) bool {
+	for _, bound := range bounds {
+		var mayMatch bool
+		switch k {
+		case Real, Double:
+			mayMatch = rangeMayMatch(math.Float64frombits(hint.Min), math.Float64frombits(hint.Max), bound.operator, bound.literals)
+		default:
+			mayMatch = rangeMayMatch(int64(hint.Min), int64(hint.Max), bound.operator, bound.literals)
+		}
+		if !mayMatch {
+			return false
+		}
+	}
+	return true
+}
+
+func rangeMayMatch[T int64 | float64](lo, hi T, operator Operator, literals []any) bool {
+	v := literals[0].(T)
+	switch operator {
+	case Eq:
+		return lo <= v && v <= hi
+	case Gt:
+		return hi > v
+	case Gte:
+		return hi >= v
+	case Lt:
+		return lo < v
+	case Lte:
+		return lo <= v
+	case Between:
+		return hi >= v && lo <= literals[1].(T)
+	default:
+		return true
+	}
+}
+
+// readScanPage reads the leaf page at cursor for a sequential scan, skipping
+// forward over pages that pageFilter rules out (see compilePageHintFilter).
+// The cursor must point at the first cell of a page. When every remaining page
+// is skipped, cursor.EndOfTable is set and the last page read is returned.
+func (t *Table) readScanPage(ctx context.Context, cursor *Cursor, pageFilter func(context.Context, *Page) bool) (*Page, error) {
+	for {
+		page, err := t.pager.ReadPage(ctx, cursor.PageIdx)
+		if err != nil {
+			return nil, err
+		}
+		if pageFilter == nil || pageFilter(ctx, page) {
+			return page, nil
+		}
+		if page.LeafNode.Header.NextLeaf == 0 {
+			cursor.EndOfTable = true
+			return page, nil
+		}
+		cursor.PageIdx = page.LeafNode.Header.NextLeaf
+		cursor.CellIdx = 0
+	}
+}
@@ -0,0 +1,275 @@
+package minisql
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPageHint_MarshalUnmarshal(t *testing.T) {
+	t.Parallel()
+
+	header := LeafNodeHeader{
+		Header: Header{IsRoot: true, Parent: 3},
+		Cells:  2,
+		Hint: PageHint{
+			Min:       uint64(1000),
+			Max:       uint64(2000),
+			Column:    1,
+			Enabled:   true,
+			HasValues: true,
+		},
+	}
+	buf := make([]byte, header.Size())
+	header.Marshal(buf)
+
+	var actual LeafNodeHeader
+	_, err := actual.Unmarshal(buf)
+	require.NoError(t, err)
+	assert.Equal(t, header, actual)
+
+	// A header without a hint keeps its original size and decodes with a
+	// disabled hint even when the previous value had one.
+	header.Hint = PageHint{}
+	buf = make([]byte, header.Size())
+	header.Marshal(buf)
+	_, err = actual.Unmarshal(buf)
+	require.NoError(t, err)
+	assert.Equal(t, header, actual)
+}
+
+func TestStatement_ValidatePageHintColumns(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		Name    string
+		Columns []Column
+		PK      []Column
+		Err     string
+	}{
+		{
+			"timestamp column",
+			[]Column{{Kind: Int8, Name: "id"}, {Kind: Timestamp, Name: "ts", MinMax: true}},
+			nil,
+			"",
+		},
+		{
+			"two MINMAX columns",
+			[]Column{{Kind: Int8, Name: "a", MinMax: true}, {Kind: Double, Name: "b", MinMax: true}},
+			nil,
+			`only one MINMAX column is allowed per table, got "a" and "b"`,
+		},
+		{
+			"text column",
+			[]Column{{Kind: Text, Name: "a", MinMax: true}},
+			nil,
+			`MINMAX column "a" must be a numeric or timestamp column`,
+		},
+		{
+			"primary key column",
+			[]Column{{Kind: Int8, Name: "id", MinMax: true}},
+			[]Column{{Kind: Int8, Name: "id"}},
+			`MINMAX cannot be used on primary key column "id"`,
+		},
+	}
+
+	for _, aTestCase := range testCases {
+		t.Run(aTestCase.Name, func(t *testing.T) {
+			stmt := Statement{Kind: CreateTable, TableName: "t", Columns: aTestCase.Columns}
+			stmt.PrimaryKey.Columns = aTestCase.PK
+			err := stmt.validatePageHintColumns()
+			if aTestCase.Err == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), aTestCase.Err)
+		})
+	}
+}
+
+func TestTable_PageHintScan(t *testing.T) {
+	t.Parallel()
+
+	const (
+		tableName = "events"
+		numRows   = 2000
+	)
+	createStmt := Statement{
+		Kind:      CreateTable,
+		TableName: tableName,
+		Columns: []Column{
+			{Kind: Int8, Size: 8, Name: "id"},
+			{Kind: Int8, Size: 8, Name: "ts", Nullable: true, MinMax: true},
+			{Kind: Varchar, Size: 100, Name: "payload", Nullable: true},
+		},
+	}
+
+	mockParser := new(MockParser)
+	mockParser.On("Parse", mock.Anything, createStmt.DDL()).Return([]Statement{createStmt}, nil)
+
+	db, dbFile := newVacuumTestDB(t, mockParser)
+	errRollback := errors.New("rollback")
+	tsField := Field{Name: "ts"}
+
+	insert := func(ctx context.Context, t *testing.T, from, to int) {
+		builder := InsertInto(tableName).Columns("id", "ts", "payload")
+		for id := from; id < to; id++ {
+			builder.Values(id, id*10, strings.Repeat("x", 40))
+		}
+		stmt, err := builder.Build()
+		require.NoError(t, err)
+		_, err = db.ExecuteStatement(ctx, stmt)
+		require.NoError(t, err)
+	}
+	selectIDs := func(ctx context.Context, t *testing.T, conditions ...Condition) []int64 {
+		stmt, err := SelectFrom(tableName).Columns("id").Where(conditions...).Build()
+		require.NoError(t, err)
+		result, err := db.ExecuteStatement(ctx, stmt)
+		require.NoError(t, err)
+		rows, err := materializeResultRows(ctx, result)
+		require.NoError(t, err)
+		ids := make([]int64, 0, len(rows))
+		for _, row := range rows {
+			ids = append(ids, row.Values[0].Value.(int64))
+		}
+		return ids
+	}
+	// leafPages returns the number of leaf pages and how many of them the
+	// page hint filter for conditions lets through.
+	leafPages := func(t *testing.T, conditions ...Condition) (int, int) {
+		tbl := db.tables[tableName]
+		pageFilter := tbl.compilePageHintFilter(OneOrMore{conditions})
+		require.NotNil(t, pageFilter)
+
+		var total, scanned int
+		err := db.txManager.ExecuteReadOnlyTransaction(context.Background(), func(ctx context.Context) error {
+			cursor, err := tbl.SeekFirst(ctx)
+			if err != nil {
+				return err
+			}
+			for pageIdx := cursor.PageIdx; ; {
+				page, err := tbl.pager.ReadPage(ctx, pageIdx)
+				if err != nil {
+					return err
+				}
+				assert.True(t, page.LeafNode.Header.Hint.Enabled)
+				total += 1
+				if pageFilter(ctx, page) {
+					scanned += 1
+				}
+				if page.LeafNode.Header.NextLeaf == 0 {
+					return nil
+				}
+				pageIdx = page.LeafNode.Header.NextLeaf
+			}
+		})
+		require.NoError(t, err)
+		return total, scanned
+	}
+	expectedIDs := func(from, to int) []int64 {
+		ids := make([]int64, 0, to-from)
+		for id := from; id < to; id++ {
+			ids = append(ids, int64(id))
+		}
+		return ids
+	}
+
+	execInTx(t, db, func(ctx context.Context) {
+		_, err := db.ExecuteStatement(ctx, createStmt)
+		require.NoError(t, err)
+	})
+	execInTx(t, db, func(ctx context.Context) {
+		insert(ctx, t, 0, numRows)
+	})
+
+	t.Run("range query skips most pages", func(t *testing.T) {
+		total, scanned := leafPages(t, FieldIsBetween(tsField, int64(10_000), int64(10_500)))
+		assert.Greater(t, total, 20)
+		assert.LessOrEqual(t, scanned, 2)
+
+		err := db.txManager.ExecuteReadOnlyTransaction(context.Background(), func(ctx context.Context) error {
+			assert.Equal(t, expectedIDs(1000, 1051), selectIDs(ctx, t, FieldIsBetween(tsField, int64(10_000), int64(10_500))))
+			assert.Equal(t, expectedIDs(1990, numRows), selectIDs(ctx, t, FieldIsGreaterOrEqual(tsField, OperandInteger, int64(19_900))))
+			assert.Equal(t, expectedIDs(0, 3), selectIDs(ctx, t, FieldIsLess(tsField, OperandInteger, int64(30))))
+			assert.Empty(t, selectIDs(ctx, t, FieldIsGreater(tsField, OperandInteger, int64(numRows*10))))
+			return nil
+		})
+		require.NoError(t, err)
+	})
+
+	t.Run("uncommitted writes are visible to their own transaction", func(t *testing.T) {
+		err := db.txManager.ExecuteInTransaction(context.Background(), func(ctx context.Context) error {
+			stmt, err := InsertInto(tableName).Columns("id", "ts").Values(numRows, -5).Build()
+			require.NoError(t, err)
+			_, err = db.ExecuteStatement(ctx, stmt)
+			require.NoError(t, err)
+			assert.Equal(t, []int64{numRows}, selectIDs(ctx, t, FieldIsLess(tsField, OperandInteger, int64(0))))
+			return errRollback
+		})
+		require.ErrorIs(t, err, errRollback)
+
+		_, scanned := leafPages(t, FieldIsLess(tsField, OperandInteger, int64(0)))
+		assert.Equal(t, 0, scanned)
+	})
+
+	t.Run("delete shrinks the hint", func(t *testing.T) {
+		execInTx(t, db, func(ctx context.Context) {
+			stmt, err := DeleteFrom(tableName).Where(FieldIsLess(tsField, OperandInteger, int64(100))).Build()
+			require.NoError(t, err)
+			_, err = db.ExecuteStatement(ctx, stmt)
+			require.NoError(t, err)
+		})
+
+		_, scanned := leafPages(t, FieldIsLess(tsField, OperandInteger, int64(100)))
+		assert.Equal(t, 0, scanned)
+		err := db.txManager.ExecuteReadOnlyTransaction(context.Background(), func(ctx context.Context) error {
+			assert.Equal(t, expectedIDs(10, 20), selectIDs(ctx, t, FieldIsLess(tsField, OperandInteger, int64(200))))
+			return nil
+		})
+		require.NoError(t, err)
+	})
+
+	t.Run("NULLs never match a range predicate", func(t *testing.T) {
+		execInTx(t, db, func(ctx context.Context) {
+			stmt, err := InsertInto(tableName).Columns("id", "ts").Values(numRows+1, nil).Build()
+			require.NoError(t, err)
+			_, err = db.ExecuteStatement(ctx, stmt)
+			require.NoError(t, err)
+		})
+		err := db.txManager.ExecuteReadOnlyTransaction(context.Background(), func(ctx context.Context) error {
+			assert.Equal(t, expectedIDs(1995, numRows), selectIDs(ctx, t, FieldIsGreater(tsField, OperandInteger, int64(19_940))))
+			return nil
+		})
+		require.NoError(t, err)
+	})
+
+	t.Run("hints survive reopening the database", func(t *testing.T) {
+		require.NoError(t, db.Close())
+
+		f, err := os.OpenFile(dbFile, os.O_RDWR, 0o600)
+		require.NoError(t, err)
+		pager, err := NewPager(f, PageSize, PageCacheSize)
+		require.NoError(t, err)
+		db, err = NewDatabase(context.Background(), testLogger, dbFile, mockParser, pager, pager, nil)
+		require.NoError(t, err)
+		defer func() { require.NoError(t, db.Close()) }()
+
+		assert.True(t, db.tables[tableName].Columns[1].MinMax)
+		total, scanned := leafPages(t, FieldIsBetween(tsField, int64(10_000), int64(10_500)))
+		assert.Greater(t, total, 20)
+		assert.LessOrEqual(t, scanned, 2)
+		err = db.txManager.ExecuteReadOnlyTransaction(context.Background(), func(ctx context.Context) error {
+			assert.Equal(t, expectedIDs(1000, 1051), selectIDs(ctx, t, FieldIsBetween(tsField, int64(10_000), int64(10_500))))
+			assert.Equal(t, expectedIDs(10, 20), selectIDs(ctx, t, FieldIsLess(tsField, OperandInteger, int64(200))))
+			return nil
+		})
+		require.NoError(t, err)
+	})
+}
@@ -51,11 +51,14 @@ type pagerImpl struct {
 	// and does a single fsync. Used by the VACUUM temp DB to collapse O(commits)
 	// pwrite+fsync calls into one sequential write + one fsync.
 	noIntermediateSync bool
+	// growChunkPages is the number of pages the file is extended by when a
+	// write lands past its end; see WithGrowChunkPages.
+	growChunkPages int
 }
 
 // NewPager opens the database file and initialises the pager.
 // maxCachedPages sets the maximum number of pages to keep in cache (0 = use default).
-func NewPager(file DBFile, pageSize, maxCachedPages int) (*pagerImpl, error) {
+func NewPager(file DBFile, pageSize, maxCachedPages int, opts ...PagerOption) (*pagerImpl, error) {
 	if maxCachedPages <= 0 {
 		maxCachedPages = PageCacheSize
 	}
@@ -73,17 +76,30 @@ func NewPager(file DBFile, pageSize, maxCachedPages int) (*pagerImpl, error) {
 		},
 	}
 
+	for _, opt := range opts {
+		opt(pager)
+	}
+
 	fileSize, err := pager.file.Seek(0, io.SeekEnd)
 	if err != nil {
 		return nil, err
 	}
-	pager.fileSize = fileSize
 
 	// Basic check to verify file size is a multiple of page size (4096B)
 	if fileSize%int64(pageSize) != 0 {
 		return nil, fmt.Errorf("db file size is not divisible by page size: %d", fileSize)
 	}
 
+	// Ignore zero pages preallocated by chunked growth that were never used.
+	fileSize, err = usedFileSize(pager.file, fileSize, pageSize)
+	if err != nil {
+		return nil, err
+	}
+	pager.fileSize = fileSize
+	if pager.growChunkPages > 1 {
+		pager.file = newChunkedFile(file, fileSize, pager.growChunkPages*pageSize)
+	}
+
 	totalPages := fileSize / int64(pageSize)
 	pager.totalPages = uint32(totalPages)
 
@@ -105,6 +121,18 @@ func NewPager(file DBFile, pageSize, maxCachedPages int) (*pagerImpl, error) {
 	return pager, nil
 }
 
+// File returns the database file the pager writes to. When chunked growth is
+// enabled this is a wrapper that preallocates space, and every other writer of
+// the database file, such as WAL checkpoints, must go through it too.
+func (p *pagerImpl) File() DBFile {
+	return p.file
+}
+
+// GrowChunkPages returns the chunk size set by WithGrowChunkPages.
+func (p *pagerImpl) GrowChunkPages() int {
+	return p.growChunkPages
+}
+
 // SetMetrics wires an engineMetrics counter store into the pager so that
 // cache hits, misses, evictions, and current size are tracked automatically.
 func (p *pagerImpl) SetMetrics(m *engineMetrics) {
@@ -144,3 +144,33 @@ func BenchmarkSequentialScan(b *testing.B) {
 		})
 	}
 }
+
+// BenchmarkPagerBulkLoad measures flushing newly appended pages one by one
+// with and without chunked file growth, reporting file extensions per run.
+func BenchmarkPagerBulkLoad(b *testing.B) {
+	const numPages = 1000
+
+	for _, chunkPages := range []int{0, 256} {
+		b.Run(fmt.Sprintf("chunk_%d", chunkPages), func(b *testing.B) {
+			var extends int
+			for b.Loop() {
+				f, err := os.CreateTemp("", "bench_*.db")
+				if err != nil {
+					b.Fatal(err)
+				}
+				file := &extendCountingFile{File: f}
+				pager, err := NewPager(file, PageSize, numPages, WithGrowChunkPages(chunkPages))
+				if err != nil {
+					b.Fatal(err)
+				}
+				bulkLoadPages(b, pager, numPages)
+				if err := pager.Close(); err != nil {
+					b.Fatal(err)
+				}
+				extends += file.extends
+				os.Remove(f.Name())
+			}
+			b.ReportMetric(float64(extends)/float64(b.N), "extends/op")
+		})
+	}
+}
@@ -0,0 +1,111 @@
+package minisql
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"sync"
+)
+
+// PagerOption configures a pager created by NewPager.
+type PagerOption func(*pagerImpl)
+
+// WithGrowChunkPages makes the pager extend the database file n pages at a
+// time whenever a write lands past the end of the file, instead of one page
+// per write. Fewer, larger extensions mean fewer syscalls and less filesystem
+// fragmentation under bulk inserts. The unused tail is trimmed on Close; n < 2
+// disables chunked growth.
+func WithGrowChunkPages(n int) PagerOption {
+	return func(p *pagerImpl) {
+		p.growChunkPages = n
+	}
+}
+
+// truncater is implemented by files whose size can be changed, e.g. *os.File.
+type truncater interface {
+	Truncate(size int64) error
+}
+
+// chunkedFile wraps a DBFile and zero-fills the file in whole chunks ahead of
+// writes past the current end, so the filesystem allocates space in large
+// extents. It keeps track of the logical size (the end of the last written
+// byte) so the preallocated tail can be cut off on Close.
+type chunkedFile struct {
+	DBFile
+	zeros     []byte
+	mu        sync.Mutex
+	allocated int64 // physical file size
+	logical   int64 // end of the furthest write
+	extends   int   // number of times the file was grown
+}
+
+func newChunkedFile(file DBFile, size int64, chunkSize int) *chunkedFile {
+	return &chunkedFile{
+		DBFile:    file,
+		zeros:     make([]byte, chunkSize),
+		allocated: size,
+		logical:   size,
+	}
+}
+
+// WriteAt grows the file to the next chunk boundary at or after off+len(b)
+// before writing.
+func (f *chunkedFile) WriteAt(b []byte, off int64) (int, error) {
+	end := off + int64(len(b))
+
+	f.mu.Lock()
+	if end > f.allocated {
+		chunk := int64(len(f.zeros))
+		newSize := (end + chunk - 1) / chunk * chunk
+		if _, err := f.DBFile.WriteAt(f.zeros[:newSize-f.allocated], f.allocated); err != nil {
+			f.mu.Unlock()
+			return 0, fmt.Errorf("grow database file to %d bytes: %w", newSize, err)
+		}
+		f.allocated = newSize
+		f.extends += 1
+	}
+	if end > f.logical {
+		f.logical = end
+	}
+	f.mu.Unlock()
+
+	return f.DBFile.WriteAt(b, off)
+}
+
+// Sync flushes the underlying file.
+func (f *chunkedFile) Sync() error {
+	return fastSync(f.DBFile)
+}
+
+// Close trims the preallocated tail, so the file size is a whole number of
+// written pages again, and closes the underlying file.
+func (f *chunkedFile) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if t, ok := f.DBFile.(truncater); ok && f.allocated > f.logical {
+		if err := t.Truncate(f.logical); err != nil {
+			return fmt.Errorf("trim preallocated database file tail: %w", err)
+		}
+		f.allocated = f.logical
+	}
+	return f.DBFile.Close()
+}
+
+// usedFileSize returns the size of file without trailing all-zero pages. A
+// page the pager has written always carries a non-zero checksum, so zero pages
+// at the end can only be space preallocated by chunked growth that was not
+// trimmed, e.g. after a crash. Page 0 is never treated as unused.
+func usedFileSize(file io.ReaderAt, fileSize int64, pageSize int) (int64, error) {
+	buf := make([]byte, pageSize)
+	for fileSize > int64(pageSize) {
+		if _, err := file.ReadAt(buf, fileSize-int64(pageSize)); err != nil {
+			return 0, err
+		}
+		if slices.ContainsFunc(buf, func(b byte) bool { return b != 0 }) {
+			break
+		}
+		fileSize -= int64(pageSize)
+	}
+	return fileSize, nil
+}
@@ -0,0 +1,93 @@
+package minisql
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// extendCountingFile counts writes that extend the file past its current end.
+type extendCountingFile struct {
+	*os.File
+	size    int64
+	extends int
+}
+
+func (f *extendCountingFile) WriteAt(b []byte, off int64) (int, error) {
+	if end := off + int64(len(b)); end > f.size {
+		f.size = end
+		f.extends += 1
+	}
+	return f.File.WriteAt(b, off)
+}
+
+// bulkLoadPages appends numPages blank leaf pages to pager and flushes them one
+// at a time, the way a commit appends new pages during a bulk insert.
+func bulkLoadPages(t testing.TB, pager *pagerImpl, numPages int) {
+	ctx := context.Background()
+	for i := range numPages {
+		leaf := NewLeafNode()
+		leaf.Header.IsRoot = i == 0
+		pager.pages = append(pager.pages, &Page{Index: PageIndex(i), LeafNode: leaf})
+		pager.totalPages += 1
+		require.NoError(t, pager.Flush(ctx, PageIndex(i)))
+	}
+}
+
+func TestPager_GrowChunkPages(t *testing.T) {
+	t.Parallel()
+
+	const numPages = 100
+
+	newFile := func(t *testing.T) *extendCountingFile {
+		f, err := os.CreateTemp("", testDBName)
+		require.NoError(t, err)
+		t.Cleanup(func() { os.Remove(f.Name()) })
+		return &extendCountingFile{File: f}
+	}
+
+	t.Run("one page at a time by default", func(t *testing.T) {
+		t.Parallel()
+		file := newFile(t)
+		pager, err := NewPager(file, PageSize, 1000)
+		require.NoError(t, err)
+
+		bulkLoadPages(t, pager, numPages)
+		require.NoError(t, pager.Close())
+		// Page 0 is written as the header followed by the rest of the page.
+		assert.Equal(t, numPages+1, file.extends)
+	})
+
+	t.Run("chunked growth", func(t *testing.T) {
+		t.Parallel()
+		file := newFile(t)
+		pager, err := NewPager(file, PageSize, 1000, WithGrowChunkPages(16))
+		require.NoError(t, err)
+
+		bulkLoadPages(t, pager, numPages)
+		assert.Equal(t, 7, file.extends, "100 pages fit in 7 chunks of 16")
+		assert.Equal(t, uint32(numPages), pager.TotalPages())
+
+		info, err := os.Stat(file.Name())
+		require.NoError(t, err)
+		assert.Equal(t, int64(112*PageSize), info.Size(), "file is preallocated to the chunk boundary")
+
+		// Opening the file while the tail is still preallocated, as after a
+		// crash, ignores the unused zero pages.
+		crashed, err := os.Open(file.Name())
+		require.NoError(t, err)
+		defer crashed.Close()
+		reopened, err := NewPager(crashed, PageSize, 1000)
+		require.NoError(t, err)
+		assert.Equal(t, uint32(numPages), reopened.TotalPages())
+
+		// Close trims the tail.
+		require.NoError(t, pager.Close())
+		info, err = os.Stat(file.Name())
+		require.NoError(t, err)
+		assert.Equal(t, int64(numPages*PageSize), info.Size())
+	})
+}
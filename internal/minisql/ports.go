@@ -99,6 +99,9 @@ type PageSaver interface {
 type DDLSaver interface {
 	// SaveDDLChanges atomically applies schema changes to the on-disk header.
 	SaveDDLChanges(ctx context.Context, changes DDLChanges)
+	// DiscardDDLChanges reverts schema changes that a rolled-back transaction
+	// applied to the in-memory schema eagerly (table renames).
+	DiscardDDLChanges(ctx context.Context, changes DDLChanges)
 }
 
 // TxPager is the read-write page accessor used inside a transaction. It extends
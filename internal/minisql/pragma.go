@@ -51,6 +51,8 @@ func (d *Database) executePragmaStatement(ctx context.Context, stmt Statement) (
 		return d.executeForeignKeysPragma(stmt)
 	case "sort_mem_limit":
 		return d.executeSortMemLimitPragma(stmt)
+	case "safe_mode":
+		return d.executeSafeModePragma(stmt)
 	default:
 		return StatementResult{}, fmt.Errorf("%w: %s", errUnknownPragma, stmt.PragmaName)
 	}
@@ -105,6 +107,30 @@ func (d *Database) executeForeignKeysPragma(stmt Statement) (StatementResult, er
 	return boolPragmaResult(foreignKeysResultColumns, enabled), nil
 }
 
+var safeModeResultColumns = []Column{
+	{Kind: Int4, Size: 4, Name: "safe_mode"},
+}
+
+func (d *Database) executeSafeModePragma(stmt Statement) (StatementResult, error) {
+	if stmt.PragmaValue == "" {
+		d.dbLock.RLock()
+		enabled := d.safeMode
+		d.dbLock.RUnlock()
+		return boolPragmaResult(safeModeResultColumns, enabled), nil
+	}
+
+	enabled, err := parseBoolPragma(stmt.PragmaValue)
+	if err != nil {
+		return StatementResult{}, err
+	}
+
+	d.dbLock.Lock()
+	d.safeMode = enabled
+	d.dbLock.Unlock()
+
+	return boolPragmaResult(safeModeResultColumns, enabled), nil
+}
+
 func (d *Database) executeParallelScanPragma(stmt Statement) (StatementResult, error) {
 	if stmt.PragmaValue == "" {
 		// Read: return current state.
@@ -0,0 +1,523 @@
+package minisql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"time"
+	"unsafe"
+)
+
+// Rows is a cursor over the result of Database.Query. It mirrors the
+// database/sql Rows API: call Next to advance, Scan to copy the current row
+// into Go values, and Err after the loop. Rows must be closed, either by
+// iterating to the end or by calling Close, to release the read transaction
+// that backs a streaming SELECT.
+type Rows struct {
+	ctx       context.Context
+	result    StatementResult
+	columns   []Column
+	values    []OptionalValue
+	txManager *TransactionManager
+	tx        *Transaction
+	err       error
+	hasRow    bool
+	closed    bool
+}
+
+// Query executes a statement that returns rows, typically a SELECT, and
+// returns a cursor over its results. args are bound to ? placeholders in
+// order; see Exec for the supported argument types. Outside an explicit
+// transaction a SELECT runs in its own read-only transaction that stays open
+// until the returned Rows is closed; any other statement runs in an
+// auto-commit write transaction. When ctx carries a transaction, the
+// statement runs inside it.
+func (d *Database) Query(ctx context.Context, query string, args ...any) (*Rows, error) {
+	d.RecordQuery(false)
+	ctx = d.queryAPIContext(ctx, query, args)
+
+	stmts, err := d.PrepareStatements(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse query: %w", err)
+	}
+	if len(stmts) == 0 {
+		// Only comments and empty statements: an empty result.
+		return newRows(ctx, StatementResult{Rows: NewSliceIterator(nil)}, nil, nil), nil
+	}
+	if len(stmts) > 1 {
+		return nil, fmt.Errorf("multiple statements not supported")
+	}
+
+	stmt, err := bindQueryArgs(stmts[0], args)
+	if err != nil {
+		return nil, err
+	}
+
+	if TxFromContext(ctx) != nil || stmt.ForUpdate || (stmt.Kind != Select && stmt.Kind != Explain) {
+		result, err := d.ExecuteAutoCommit(ctx, stmt)
+		if err != nil {
+			return nil, err
+		}
+		return newRows(ctx, result, nil, nil), nil
+	}
+
+	tx := d.txManager.BeginReadOnlyTransaction(ctx)
+	txCtx := WithTransaction(ctx, tx)
+
+	result, err := d.ExecuteStatement(txCtx, stmt)
+	if err != nil {
+		d.txManager.RollbackTransaction(txCtx, tx)
+		d.txManager.ReleaseReadOnlyTransaction(tx)
+		return nil, err
+	}
+
+	if len(result.RowViewFieldIndexes) > 0 {
+		// Row views are read lazily from pages, so the read transaction stays
+		// open until the rows are closed.
+		return newRows(txCtx, result, d.txManager, tx), nil
+	}
+
+	if err := d.txManager.CommitTransaction(txCtx, tx); err != nil {
+		d.txManager.RollbackTransaction(txCtx, tx)
+		d.txManager.ReleaseReadOnlyTransaction(tx)
+		return nil, err
+	}
+	d.txManager.ReleaseReadOnlyTransaction(tx)
+
+	return newRows(ctx, result, nil, nil), nil
+}
+
+// Exec executes one or more statements that don't return rows and returns the
+// total number of rows affected. args are bound to ? placeholders in order
+// and may be nil, any Go integer or float type, bool, string, []byte,
+// time.Time, []float32 (for VECTOR columns) or an io.Reader streaming a large
+// text value. When ctx carries a transaction, the statements run inside it;
+// otherwise each statement commits on its own.
+func (d *Database) Exec(ctx context.Context, query string, args ...any) (int64, error) {
+	d.RecordQuery(false)
+	ctx = d.queryAPIContext(ctx, query, args)
+
+	stmts, err := d.PrepareStatements(ctx, query)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse query: %w", err)
+	}
+
+	var rowsAffected int64
+	for _, stmt := range stmts {
+		stmt, err = bindQueryArgs(stmt, args)
+		if err != nil {
+			return rowsAffected, err
+		}
+		result, err := d.ExecuteAutoCommit(ctx, stmt)
+		if err != nil {
+			return rowsAffected, err
+		}
+		rowsAffected += int64(result.RowsAffected)
+	}
+
+	return rowsAffected, nil
+}
+
+// ExecuteAutoCommit runs stmt in the transaction carried by ctx, or in a new
+// transaction that commits when the statement succeeds. SELECT and EXPLAIN
+// use a read-only transaction so that per-page read tracking is skipped;
+// SELECT … FOR UPDATE needs the write transaction to lock out concurrent
+// writers. A large INSERT commits batch by batch when insert batch commits
+// are enabled. A VACUUM scheduled by autovacuum runs after the commit.
+func (d *Database) ExecuteAutoCommit(ctx context.Context, stmt Statement) (StatementResult, error) {
+	if TxFromContext(ctx) != nil {
+		return d.ExecuteStatement(ctx, stmt)
+	}
+
+	var result StatementResult
+	txFn := func(txCtx context.Context) error {
+		var err error
+		result, err = d.ExecuteStatement(txCtx, stmt)
+		return err
+	}
+
+	var err error
+	if (stmt.Kind == Select || stmt.Kind == Explain) && !stmt.ForUpdate {
+		return result, d.txManager.ExecuteReadOnlyTransaction(ctx, txFn)
+	} else if d.CommitsInsertInBatches(stmt) {
+		result, err = d.ExecuteInsertInBatches(ctx, stmt)
+	} else {
+		err = d.txManager.ExecuteInTransaction(ctx, txFn)
+	}
+	if err != nil {
+		return result, err
+	}
+
+	d.AutoVacuumAfterCommit(ctx)
+	return result, nil
+}
+
+// queryAPIContext attaches the SQL text and arguments for the query log and
+// query cache, mirroring what the database/sql driver does per connection.
+func (d *Database) queryAPIContext(ctx context.Context, query string, args []any) context.Context {
+	if !d.QueryLogEnabled() && !d.QueryCacheEnabled() {
+		return ctx
+	}
+	return WithQueryLogInfo(ctx, QueryLogInfo{SQL: query, Args: args})
+}
+
+func bindQueryArgs(stmt Statement, args []any) (Statement, error) {
+	if len(args) == 0 {
+		return stmt, nil
+	}
+	values := make([]any, len(args))
+	for i, arg := range args {
+		value, err := ToStatementArg(arg)
+		if err != nil {
+			return Statement{}, err
+		}
+		values[i] = value
+	}
+	return stmt.BindArguments(values...)
+}
+
+// ToStatementArg converts a Go value to the representation BindArguments
+// expects for a placeholder.
+func ToStatementArg(arg any) (any, error) {
+	switch v := arg.(type) {
+	case nil:
+		return nil, nil
+	case int64, float64, bool, TextPointer, TimestampMicros, UUIDValue, VectorPointer, ReaderValue:
+		return v, nil
+	case int:
+		return int64(v), nil
+	case int8:
+		return int64(v), nil
+	case int16:
+		return int64(v), nil
+	case int32:
+		return int64(v), nil
+	case uint8:
+		return int64(v), nil
+	case uint16:
+		return int64(v), nil
+	case uint32:
+		return int64(v), nil
+	case float32:
+		return float64(v), nil
+	case string:
+		// Reuse the string's backing bytes without copying. Strings are
+		// immutable and bound values are only read, so the TextPointer can
+		// share them for as long as the statement needs the value.
+		b := unsafe.Slice(unsafe.StringData(v), len(v))
+		return NewTextPointer(b), nil
+	case []byte:
+		return NewTextPointer(append([]byte(nil), v...)), nil
+	case []float32:
+		return VectorPointer{Dims: uint32(len(v)), Data: v}, nil
+	case io.Reader:
+		return ReaderValue{R: v}, nil
+	case time.Time:
+		t := Time{
+			Year:         int32(v.Year()),
+			Month:        int8(v.Month()),
+			Day:          int8(v.Day()),
+			Hour:         int8(v.Hour()),
+			Minutes:      int8(v.Minute()),
+			Seconds:      int8(v.Second()),
+			Microseconds: int32(v.Nanosecond() / 1000),
+		}
+		return TimestampMicros(t.TotalMicroseconds()), nil
+	default:
+		return nil, fmt.Errorf("unsupported argument type: %T", arg)
+	}
+}
+
+func newRows(ctx context.Context, result StatementResult, txManager *TransactionManager, tx *Transaction) *Rows {
+	return &Rows{
+		ctx:       ctx,
+		result:    result,
+		columns:   result.Columns,
+		txManager: txManager,
+		tx:        tx,
+	}
+}
+
+// Columns returns the names of the result columns.
+func (r *Rows) Columns() []string {
+	names := make([]string, len(r.columns))
+	for i, col := range r.columns {
+		names[i] = col.Name
+	}
+	return names
+}
+
+// ColumnTypes returns the result columns, including their kinds.
+func (r *Rows) ColumnTypes() []Column {
+	return r.columns
+}
+
+// Next advances to the next row. It returns false when there are no more rows
+// or an error occurred; check Err to tell the two apart. Rows is closed
+// automatically once Next returns false.
+func (r *Rows) Next() bool {
+	if r.closed {
+		return false
+	}
+	r.hasRow = false
+
+	if len(r.result.RowViewFieldIndexes) > 0 {
+		if !r.result.RowViews.Next(r.ctx) {
+			r.finish(r.result.RowViews.Err())
+			return false
+		}
+		row, err := projectRowView(r.ctx, r.result.RowViewPager, r.result.RowViews.RowView(), r.result.RowViewFieldIndexes, r.columns)
+		if err != nil {
+			r.finish(err)
+			return false
+		}
+		r.values = row.Values
+	} else {
+		if !r.result.Rows.Next(r.ctx) {
+			r.finish(r.result.Rows.Err())
+			return false
+		}
+		r.values = r.result.Rows.Row().Values
+	}
+
+	r.hasRow = true
+	return true
+}
+
+// Scan copies the columns of the current row into the values pointed at by
+// dest, which must have one entry per column. Supported destinations are
+// *any, *string, *[]byte, *bool, *int, *int32, *int64, *float32, *float64,
+// *time.Time, *[]float32, *UUIDValue and any sql.Scanner such as
+// sql.NullString. NULL can only be scanned into *any or an sql.Scanner.
+func (r *Rows) Scan(dest ...any) error {
+	if !r.hasRow {
+		return errors.New("scan called without a successful call to Next")
+	}
+	if len(dest) != len(r.values) {
+		return fmt.Errorf("expected %d destination arguments in Scan, not %d", len(r.values), len(dest))
+	}
+	for i, d := range dest {
+		if err := scanValue(d, r.values[i]); err != nil {
+			return fmt.Errorf("scan column %d (%s): %w", i, r.columns[i].Name, err)
+		}
+	}
+	return nil
+}
+
+// Err returns the error, if any, encountered during iteration.
+func (r *Rows) Err() error {
+	return r.err
+}
+
+// Close stops the iteration and releases the read transaction backing the
+// rows. It is safe to call Close more than once.
+func (r *Rows) Close() error {
+	if r.closed {
+		return nil
+	}
+	r.finish(nil)
+	return r.err
+}
+
+// finish closes the underlying iterators and ends the read transaction,
+// committing it unless iteration failed. The first error is kept in r.err.
+func (r *Rows) finish(err error) {
+	r.closed = true
+	r.hasRow = false
+
+	if closeErr := r.result.RowViews.Close(); err == nil {
+		err = closeErr
+	}
+	if closeErr := r.result.Rows.Close(); err == nil {
+		err = closeErr
+	}
+
+	if r.tx != nil {
+		tx := r.tx
+		r.tx = nil
+		if err != nil {
+			r.txManager.RollbackTransaction(r.ctx, tx)
+		} else if commitErr := r.txManager.CommitTransaction(r.ctx, tx); commitErr != nil {
+			r.txManager.RollbackTransaction(r.ctx, tx)
+			err = commitErr
+		}
+		r.txManager.ReleaseReadOnlyTransaction(tx)
+	}
+
+	if r.err == nil {
+		r.err = err
+	}
+}
+
+// goValue converts a stored value to its natural Go type: integers become
+// int64, floats float64, text string, timestamps time.Time, UUIDs UUIDValue
+// and vectors []float32.
+func goValue(value any) any {
+	switch v := value.(type) {
+	case int32:
+		return int64(v)
+	case float32:
+		return float64(v)
+	case TextPointer:
+		return string(v.Data)
+	case TimestampMicros:
+		return FromMicroseconds(int64(v)).GoTime()
+	case VectorPointer:
+		return append([]float32(nil), v.Data...)
+	default:
+		return v
+	}
+}
+
+// scanValue stores value in dest, converting it to the destination type.
+func scanValue(dest any, value OptionalValue) error {
+	if scanner, ok := dest.(sql.Scanner); ok {
+		if !value.Valid {
+			return scanner.Scan(nil)
+		}
+		src := goValue(value.Value)
+		switch v := src.(type) {
+		case UUIDValue:
+			src = v.String()
+		case []float32:
+			src = FormatVector(VectorPointer{Dims: uint32(len(v)), Data: v})
+		}
+		return scanner.Scan(src)
+	}
+
+	if !value.Valid {
+		if d, ok := dest.(*any); ok {
+			*d = nil
+			return nil
+		}
+		return fmt.Errorf("cannot scan NULL into %T", dest)
+	}
+
+	src := goValue(value.Value)
+	switch d := dest.(type) {
+	case *any:
+		*d = src
+		return nil
+	case *string:
+		s, ok := stringValue(src)
+		if !ok {
+			break
+		}
+		*d = s
+		return nil
+	case *[]byte:
+		s, ok := stringValue(src)
+		if !ok {
+			break
+		}
+		*d = []byte(s)
+		return nil
+	case *bool:
+		b, ok := src.(bool)
+		if !ok {
+			break
+		}
+		*d = b
+		return nil
+	case *int64:
+		i, ok := src.(int64)
+		if !ok {
+			break
+		}
+		*d = i
+		return nil
+	case *int:
+		i, ok := src.(int64)
+		if !ok {
+			break
+		}
+		*d = int(i)
+		return nil
+	case *int32:
+		i, ok := src.(int64)
+		if !ok {
+			break
+		}
+		if i < math.MinInt32 || i > math.MaxInt32 {
+			return fmt.Errorf("value %d overflows int32", i)
+		}
+		*d = int32(i)
+		return nil
+	case *float64:
+		switch v := src.(type) {
+		case float64:
+			*d = v
+			return nil
+		case int64:
+			*d = float64(v)
+			return nil
+		}
+	case *float32:
+		switch v := src.(type) {
+		case float64:
+			*d = float32(v)
+			return nil
+		case int64:
+			*d = float32(v)
+			return nil
+		}
+	case *time.Time:
+		t, ok := src.(time.Time)
+		if !ok {
+			break
+		}
+		*d = t
+		return nil
+	case *[]float32:
+		v, ok := src.([]float32)
+		if !ok {
+			break
+		}
+		*d = v
+		return nil
+	case *UUIDValue:
+		switch v := src.(type) {
+		case UUIDValue:
+			*d = v
+			return nil
+		case string:
+			u, err := ParseUUID(v)
+			if err != nil {
+				return err
+			}
+			*d = u
+			return nil
+		}
+	default:
+		return fmt.Errorf("unsupported destination type %T", dest)
+	}
+
+	return fmt.Errorf("cannot scan %T into %T", src, dest)
+}
+
+// stringValue formats src as text. Timestamps use RFC 3339 and vectors the
+// [x, y, ...] literal form.
+func stringValue(src any) (string, bool) {
+	switch v := src.(type) {
+	case string:
+		return v, true
+	case int64:
+		return strconv.FormatInt(v, 10), true
+	case float64:
+		return strconv.FormatFloat(v, 'g', -1, 64), true
+	case bool:
+		return strconv.FormatBool(v), true
+	case time.Time:
+		return v.Format(time.RFC3339Nano), true
+	case UUIDValue:
+		return v.String(), true
+	case []float32:
+		return FormatVector(VectorPointer{Dims: uint32(len(v)), Data: v}), true
+	default:
+		return "", false
+	}
+}
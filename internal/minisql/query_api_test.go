@@ -0,0 +1,251 @@
+package minisql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	queryAPIInsertSQL = "INSERT INTO events (id, name, score, status, active) VALUES (?, ?, ?, ?, ?);"
+	queryAPISelectSQL = "SELECT id, name, score, status, active FROM events;"
+	queryAPINameSQL   = "SELECT name FROM events WHERE id = ?;"
+)
+
+func newQueryAPITestDB(t *testing.T) *Database {
+	t.Helper()
+
+	db := newImportTestDB(t)
+	mockParser := db.parser.(*MockParser)
+
+	insertStmt, err := InsertInto("events").
+		Columns("id", "name", "score", "status", "active").
+		Values(Placeholder{}, Placeholder{}, Placeholder{}, Placeholder{}, Placeholder{}).
+		Build()
+	require.NoError(t, err)
+	selectStmt, err := SelectFrom("events").Columns("id", "name", "score", "status", "active").Build()
+	require.NoError(t, err)
+	nameStmt, err := SelectFrom("events").
+		Columns("name").
+		Where(FieldIsEqual(Field{Name: "id"}, OperandPlaceholder, Placeholder{})).
+		Build()
+	require.NoError(t, err)
+
+	mockParser.On("Parse", mock.Anything, queryAPIInsertSQL).Return([]Statement{insertStmt}, nil)
+	mockParser.On("Parse", mock.Anything, queryAPISelectSQL).Return([]Statement{selectStmt}, nil)
+	mockParser.On("Parse", mock.Anything, queryAPINameSQL).Return([]Statement{nameStmt}, nil)
+
+	return db
+}
+
+func TestDatabase_ExecAndQuery(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	db := newQueryAPITestDB(t)
+
+	n, err := db.Exec(ctx, queryAPIInsertSQL, 1, "alpha", 1.5, nil, true)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), n)
+	n, err = db.Exec(ctx, queryAPIInsertSQL, int64(2), []byte("beta"), float32(2.5), "done", false)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), n)
+
+	_, err = db.Exec(ctx, queryAPIInsertSQL, 3, struct{}{}, nil, nil, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unsupported argument type: struct {}")
+
+	t.Run("scan typed rows", func(t *testing.T) {
+		rows, err := db.Query(ctx, queryAPISelectSQL)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"id", "name", "score", "status", "active"}, rows.Columns())
+
+		type event struct {
+			id     int64
+			name   string
+			score  sql.NullFloat64
+			status sql.NullString
+			active any
+		}
+		var events []event
+		for rows.Next() {
+			var e event
+			require.NoError(t, rows.Scan(&e.id, &e.name, &e.score, &e.status, &e.active))
+			events = append(events, e)
+		}
+		require.NoError(t, rows.Err())
+		require.NoError(t, rows.Close())
+
+		assert.Equal(t, []event{
+			{id: 1, name: "alpha", score: sql.NullFloat64{Float64: 1.5, Valid: true}, active: true},
+			{id: 2, name: "beta", score: sql.NullFloat64{Float64: 2.5, Valid: true}, status: sql.NullString{String: "done", Valid: true}, active: false},
+		}, events)
+	})
+
+	t.Run("bind arguments", func(t *testing.T) {
+		rows, err := db.Query(ctx, queryAPINameSQL, 2)
+		require.NoError(t, err)
+		defer rows.Close()
+
+		require.True(t, rows.Next())
+		var name []byte
+		require.NoError(t, rows.Scan(&name))
+		assert.Equal(t, []byte("beta"), name)
+		assert.False(t, rows.Next())
+		require.NoError(t, rows.Err())
+	})
+
+	t.Run("scan errors", func(t *testing.T) {
+		rows, err := db.Query(ctx, queryAPISelectSQL)
+		require.NoError(t, err)
+		defer rows.Close()
+
+		var (
+			id     int64
+			name   string
+			score  float64
+			status string
+			active bool
+		)
+		require.Error(t, rows.Scan(&id, &name, &score, &status, &active), "Scan before Next")
+
+		require.True(t, rows.Next())
+		err = rows.Scan(&id, &name)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "expected 5 destination arguments in Scan, not 2")
+
+		err = rows.Scan(&id, &name, &score, &status, &active)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "scan column 3 (status): cannot scan NULL into *string")
+	})
+
+	t.Run("close before the end of the rows", func(t *testing.T) {
+		rows, err := db.Query(ctx, queryAPISelectSQL)
+		require.NoError(t, err)
+		require.True(t, rows.Next())
+		require.NoError(t, rows.Close())
+		require.NoError(t, rows.Close())
+		assert.False(t, rows.Next())
+
+		// The read transaction was released, so writes still go through.
+		n, err := db.Exec(ctx, queryAPIInsertSQL, 3, "gamma", nil, nil, nil)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), n)
+	})
+
+	t.Run("statements join the transaction in the context", func(t *testing.T) {
+		errRollback := errors.New("rollback")
+		err := db.txManager.ExecuteInTransaction(ctx, func(txCtx context.Context) error {
+			n, err := db.Exec(txCtx, queryAPIInsertSQL, 4, "delta", nil, nil, nil)
+			require.NoError(t, err)
+			assert.Equal(t, int64(1), n)
+
+			rows, err := db.Query(txCtx, queryAPINameSQL, 4)
+			require.NoError(t, err)
+			defer rows.Close()
+			require.True(t, rows.Next())
+			var name string
+			require.NoError(t, rows.Scan(&name))
+			assert.Equal(t, "delta", name)
+			return errRollback
+		})
+		require.ErrorIs(t, err, errRollback)
+
+		rows, err := db.Query(ctx, queryAPINameSQL, 4)
+		require.NoError(t, err)
+		defer rows.Close()
+		assert.False(t, rows.Next())
+		require.NoError(t, rows.Err())
+	})
+}
+
+func TestScanValue(t *testing.T) {
+	t.Parallel()
+
+	ts := time.Date(2024, 3, 15, 10, 30, 0, 123000, time.UTC)
+	tsValue := TimestampMicros(Time{Year: 2024, Month: 3, Day: 15, Hour: 10, Minutes: 30, Microseconds: 123}.TotalMicroseconds())
+	uuid, err := ParseUUID("550e8400-e29b-41d4-a716-446655440000")
+	require.NoError(t, err)
+	vector := VectorPointer{Dims: 3, Data: []float32{1, 2.5, -3}}
+
+	t.Run("int4 into int64", func(t *testing.T) {
+		var dest int64
+		require.NoError(t, scanValue(&dest, OptionalValue{Value: int32(-7), Valid: true}))
+		assert.Equal(t, int64(-7), dest)
+	})
+
+	t.Run("int8 into int32 overflows", func(t *testing.T) {
+		var dest int32
+		require.ErrorContains(t, scanValue(&dest, OptionalValue{Value: int64(1) << 40, Valid: true}), "overflows int32")
+	})
+
+	t.Run("real into float64 and int into float32", func(t *testing.T) {
+		var f64 float64
+		require.NoError(t, scanValue(&f64, OptionalValue{Value: float32(0.5), Valid: true}))
+		assert.Equal(t, 0.5, f64)
+		var f32 float32
+		require.NoError(t, scanValue(&f32, OptionalValue{Value: int64(3), Valid: true}))
+		assert.Equal(t, float32(3), f32)
+	})
+
+	t.Run("timestamp", func(t *testing.T) {
+		var dest time.Time
+		require.NoError(t, scanValue(&dest, OptionalValue{Value: tsValue, Valid: true}))
+		assert.True(t, ts.Equal(dest), dest)
+
+		var anyDest any
+		require.NoError(t, scanValue(&anyDest, OptionalValue{Value: tsValue, Valid: true}))
+		assert.IsType(t, time.Time{}, anyDest)
+	})
+
+	t.Run("uuid", func(t *testing.T) {
+		var s string
+		require.NoError(t, scanValue(&s, OptionalValue{Value: uuid, Valid: true}))
+		assert.Equal(t, "550e8400-e29b-41d4-a716-446655440000", s)
+		var u UUIDValue
+		require.NoError(t, scanValue(&u, OptionalValue{Value: uuid, Valid: true}))
+		assert.Equal(t, uuid, u)
+		var ns sql.NullString
+		require.NoError(t, scanValue(&ns, OptionalValue{Value: uuid, Valid: true}))
+		assert.Equal(t, sql.NullString{String: s, Valid: true}, ns)
+	})
+
+	t.Run("vector", func(t *testing.T) {
+		var floats []float32
+		require.NoError(t, scanValue(&floats, OptionalValue{Value: vector, Valid: true}))
+		assert.Equal(t, []float32{1, 2.5, -3}, floats)
+		floats[0] = 100
+		assert.Equal(t, float32(1), vector.Data[0], "scanned vector must be a copy")
+
+		var s string
+		require.NoError(t, scanValue(&s, OptionalValue{Value: vector, Valid: true}))
+		assert.Equal(t, FormatVector(vector), s)
+	})
+
+	t.Run("null", func(t *testing.T) {
+		anyDest := any("previous")
+		require.NoError(t, scanValue(&anyDest, OptionalValue{}))
+		assert.Nil(t, anyDest)
+
+		var ni sql.NullInt64
+		require.NoError(t, scanValue(&ni, OptionalValue{}))
+		assert.False(t, ni.Valid)
+
+		var i int64
+		require.ErrorContains(t, scanValue(&i, OptionalValue{}), "cannot scan NULL into *int64")
+	})
+
+	t.Run("type mismatch", func(t *testing.T) {
+		var b bool
+		require.ErrorContains(t, scanValue(&b, OptionalValue{Value: NewTextPointer([]byte("yes")), Valid: true}), "cannot scan string into *bool")
+
+		var u uint64
+		require.ErrorContains(t, scanValue(&u, OptionalValue{Value: int64(1), Valid: true}), "unsupported destination type *uint64")
+	})
+}
@@ -0,0 +1,270 @@
+package minisql
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
+)
+
+// MaxCachedResultRows is the largest result set, in rows, the query cache
+// keeps. Bigger results are streamed as usual and never cached.
+const MaxCachedResultRows = 1000
+
+// cachedResult is a query cache entry: the materialised result of a SELECT
+// together with the tables it read and the snapshot it was computed from.
+type cachedResult struct {
+	columns  []Column
+	rows     []Row
+	tables   []string
+	snapshot uint64
+}
+
+// QueryCacheEnabled reports whether a query cache is configured. The driver
+// uses it to decide whether to attach the SQL text and arguments to ctx.
+func (d *Database) QueryCacheEnabled() bool {
+	return d.queryCache != nil
+}
+
+// executeCachedStatement serves a read-only SELECT from the query cache when
+// an entry for the same SQL text and arguments exists and none of the tables
+// it read has been written since, and otherwise executes the statement and
+// caches small results. Every other statement is executed directly.
+func (d *Database) executeCachedStatement(ctx context.Context, stmt Statement) (StatementResult, error) {
+	key, tables, ok := d.queryCacheKey(ctx, stmt)
+	if !ok {
+		return d.executeStatementWithLimits(ctx, stmt)
+	}
+	tx := TxFromContext(ctx)
+
+	if value, ok := d.queryCache.Get(key); ok {
+		entry := value.(*cachedResult)
+		if d.txManager.TableWriteSeq(entry.tables...) <= min(entry.snapshot, tx.SnapshotSeq) {
+			d.metrics.queryCacheHits.Add(1)
+			return StatementResult{
+				Columns: entry.columns,
+				Rows:    NewSliceIterator(entry.rows),
+			}, nil
+		}
+		d.queryCache.Delete(key)
+	}
+	d.metrics.queryCacheMisses.Add(1)
+
+	result, err := d.executeStatementWithLimits(ctx, stmt)
+	if err != nil {
+		return result, err
+	}
+	rows, complete, err := bufferResultRows(ctx, &result, MaxCachedResultRows)
+	if err != nil || !complete {
+		return result, err
+	}
+
+	// A table written after this transaction's snapshot means the result is
+	// already stale for newer readers.
+	if d.txManager.TableWriteSeq(tables...) <= tx.SnapshotSeq {
+		cached := make([]Row, len(rows))
+		for i, row := range rows {
+			cached[i] = cloneCachedRow(row)
+		}
+		d.queryCache.Put(key, &cachedResult{
+			columns:  result.Columns,
+			rows:     cached,
+			tables:   tables,
+			snapshot: tx.SnapshotSeq,
+		}, true)
+	}
+	return StatementResult{
+		Columns: result.Columns,
+		Rows:    NewSliceIterator(rows),
+		rawRows: rows,
+	}, nil
+}
+
+// queryCacheKey returns the cache key for stmt and the tables it reads. ok is
+// false when the statement must not be cached: it is not a plain SELECT run
+// in a read-only transaction, reads something other than stored tables, calls
+// a non-deterministic function, or was issued without its SQL text.
+func (d *Database) queryCacheKey(ctx context.Context, stmt Statement) (string, []string, bool) {
+	if d.queryCache == nil || stmt.Kind != Select || stmt.ForUpdate {
+		return "", nil, false
+	}
+	if tx := TxFromContext(ctx); tx == nil || !tx.ReadOnly {
+		return "", nil, false
+	}
+	info := queryLogInfoFromContext(ctx)
+	if info.SQL == "" {
+		return "", nil, false
+	}
+	// Bind registered functions so that queryCacheTables can tell whether
+	// they are deterministic; a failure is reported when the statement runs.
+	if err := d.resolveFunctions(&stmt); err != nil {
+		return "", nil, false
+	}
+	tables, ok := stmt.queryCacheTables()
+	if !ok {
+		return "", nil, false
+	}
+
+	var key strings.Builder
+	key.WriteString(strings.Join(strings.Fields(info.SQL), " "))
+	for _, arg := range info.Args {
+		switch v := arg.(type) {
+		case io.Reader, ReaderValue:
+			return "", nil, false
+		case []byte:
+			fmt.Fprintf(&key, "\x00%T:%q", v, v)
+		case time.Time:
+			fmt.Fprintf(&key, "\x00%T:%s", v, v.Format(time.RFC3339Nano))
+		default:
+			fmt.Fprintf(&key, "\x00%T:%v", v, v)
+		}
+	}
+	return key.String(), tables, true
+}
+
+// queryCacheTables returns the tables a cacheable SELECT reads, including the
+// schema table so that DDL invalidates it. ok is false for statements whose
+// result depends on more than the contents of those tables.
+func (s Statement) queryCacheTables() ([]string, bool) {
+	if len(s.CTEs) > 0 || s.FromSubquery != nil || len(s.Unions) > 0 || s.TableName == "" {
+		return nil, false
+	}
+	for _, field := range s.Fields {
+		if !isImmutableExpr(field.Expr) {
+			return nil, false
+		}
+	}
+	for _, group := range slices.Concat(s.Conditions, s.Having) {
+		for _, cond := range group {
+			for _, operand := range []Operand{cond.Operand1, cond.Operand2} {
+				switch operand.Type {
+				case OperandSubquery:
+					return nil, false
+				case OperandExpr:
+					if expr, ok := operand.Value.(*Expr); ok && !isImmutableExpr(expr) {
+						return nil, false
+					}
+				}
+			}
+		}
+	}
+
+	tables := []string{SchemaTableName, s.TableName}
+	var addJoins func(joins []Join)
+	addJoins = func(joins []Join) {
+		for _, join := range joins {
+			tables = append(tables, join.TableName)
+			addJoins(join.Joins)
+		}
+	}
+	addJoins(s.Joins)
+	return tables, true
+}
+
+// bufferResultRows reads up to limit rows of result into memory. complete is
+// true when that was the whole result; result is then fully consumed and its
+// rows are returned. When the result is larger, result is rewritten to yield
+// the buffered rows followed by the rest of the original stream, so the caller
+// can return it unchanged.
+func bufferResultRows(ctx context.Context, result *StatementResult, limit int) ([]Row, bool, error) {
+	if result.rawRows != nil && len(result.rawRows) <= limit {
+		return result.rawRows, true, nil
+	}
+	if len(result.RowViewFieldIndexes) > 0 {
+		return bufferResultRowViews(ctx, result, limit)
+	}
+	if result.Rows.rowFunc == nil {
+		return nil, true, nil
+	}
+
+	var rows []Row
+	for len(rows) <= limit && result.Rows.Next(ctx) {
+		rows = append(rows, result.Rows.Row())
+	}
+	if err := result.Rows.Err(); err != nil {
+		return nil, false, err
+	}
+	if len(rows) <= limit {
+		_ = result.Rows.Close()
+		return rows, true, nil
+	}
+
+	rest := result.Rows
+	result.rawRows = nil
+	result.Rows = newIteratorWithClose(func(ctx context.Context) (Row, error) {
+		if len(rows) > 0 {
+			row := rows[0]
+			rows = rows[1:]
+			return row, nil
+		}
+		if rest.Next(ctx) {
+			return rest.Row(), nil
+		}
+		if err := rest.Err(); err != nil {
+			return Row{}, err
+		}
+		return Row{}, ErrNoMoreRows
+	}, rest.Close)
+	return nil, false, nil
+}
+
+// bufferResultRowViews is bufferResultRows for streaming row-view results. A
+// complete result is projected into rows while the transaction is still open;
+// a larger one keeps streaming row views.
+func bufferResultRowViews(ctx context.Context, result *StatementResult, limit int) ([]Row, bool, error) {
+	var views []RowView
+	for len(views) <= limit && result.RowViews.Next(ctx) {
+		views = append(views, result.RowViews.RowView())
+	}
+	if err := result.RowViews.Err(); err != nil {
+		return nil, false, err
+	}
+
+	if len(views) <= limit {
+		defer func() {
+			_ = result.RowViews.Close()
+			_ = result.Rows.Close()
+		}()
+		rows := make([]Row, 0, len(views))
+		for _, view := range views {
+			row, err := projectRowView(ctx, result.RowViewPager, view, result.RowViewFieldIndexes, result.Columns)
+			if err != nil {
+				return nil, false, err
+			}
+			rows = append(rows, row)
+		}
+		return rows, true, nil
+	}
+
+	rest := result.RowViews
+	result.RowViews = newRowViewIteratorWithClose(func(ctx context.Context) (RowView, error) {
+		if len(views) > 0 {
+			view := views[0]
+			views = views[1:]
+			return view, nil
+		}
+		if rest.Next(ctx) {
+			return rest.RowView(), nil
+		}
+		if err := rest.Err(); err != nil {
+			return RowView{}, err
+		}
+		return RowView{}, ErrNoMoreRows
+	}, rest.Close)
+	return nil, false, nil
+}
+
+// cloneCachedRow copies row, including the bytes of text values, so a cached
+// result does not share memory with pages or with rows handed to callers.
+func cloneCachedRow(row Row) Row {
+	row = row.Clone()
+	for i, value := range row.Values {
+		if tp, ok := value.Value.(TextPointer); ok {
+			tp.Data = append([]byte(nil), tp.Data...)
+			row.Values[i].Value = tp
+		}
+	}
+	return row
+}
@@ -0,0 +1,137 @@
+package minisql
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDatabase_QueryCache(t *testing.T) {
+	t.Parallel()
+
+	const (
+		tableName = "items"
+		selectSQL = "select * from items where id >= ?"
+	)
+	createStmt := vacuumCreateStmt(tableName)
+	mockParser := new(MockParser)
+	mockParser.On("Parse", mock.Anything, createStmt.DDL()).Return([]Statement{createStmt}, nil)
+
+	db, _ := newVacuumTestDB(t, mockParser, WithQueryCache(10))
+	execInTx(t, db, func(ctx context.Context) {
+		_, err := db.ExecuteStatement(ctx, createStmt)
+		require.NoError(t, err)
+	})
+	insertRowInDB(t, db, tableName, 1, "one")
+	insertRowInDB(t, db, tableName, 2, "two")
+
+	selectIDs := func(ctx context.Context, minID int64) []int64 {
+		t.Helper()
+		stmt, err := Statement{
+			Kind:       Select,
+			TableName:  tableName,
+			Fields:     fieldsFromColumns(vacuumColumns...),
+			Conditions: OneOrMore{{FieldIsGreaterOrEqual(Field{Name: "id"}, OperandPlaceholder, nil)}},
+		}.BindArguments(minID)
+		require.NoError(t, err)
+		ctx = WithQueryLogInfo(ctx, QueryLogInfo{SQL: selectSQL, Args: []any{minID}})
+
+		var ids []int64
+		err = db.txManager.ExecuteReadOnlyTransaction(ctx, func(ctx context.Context) error {
+			result, err := db.ExecuteStatement(ctx, stmt)
+			if err != nil {
+				return err
+			}
+			rows, err := materializeResultRows(ctx, result)
+			if err != nil {
+				return err
+			}
+			for _, row := range rows {
+				id, ok := row.GetValue("id")
+				require.True(t, ok)
+				ids = append(ids, id.Value.(int64))
+			}
+			return nil
+		})
+		require.NoError(t, err)
+		return ids
+	}
+	cacheCounters := func() (int64, int64) {
+		m := db.ReadEngineMetrics()
+		return m.QueryCacheHits, m.QueryCacheMisses
+	}
+	ctx := context.Background()
+
+	assert.Equal(t, []int64{1, 2}, selectIDs(ctx, 1))
+	hits, misses := cacheCounters()
+	assert.Equal(t, int64(0), hits)
+	assert.Equal(t, int64(1), misses)
+
+	// Same SQL and arguments: served from the cache.
+	assert.Equal(t, []int64{1, 2}, selectIDs(ctx, 1))
+	hits, misses = cacheCounters()
+	assert.Equal(t, int64(1), hits)
+	assert.Equal(t, int64(1), misses)
+
+	// Different arguments are a different entry.
+	assert.Equal(t, []int64{2}, selectIDs(ctx, 2))
+	hits, misses = cacheCounters()
+	assert.Equal(t, int64(1), hits)
+	assert.Equal(t, int64(2), misses)
+
+	// A committed insert invalidates every entry for the table.
+	insertRowInDB(t, db, tableName, 3, "three")
+	assert.Equal(t, []int64{1, 2, 3}, selectIDs(ctx, 1))
+	hits, misses = cacheCounters()
+	assert.Equal(t, int64(1), hits)
+	assert.Equal(t, int64(3), misses)
+
+	assert.Equal(t, []int64{1, 2, 3}, selectIDs(ctx, 1))
+	hits, _ = cacheCounters()
+	assert.Equal(t, int64(2), hits)
+
+	t.Run("write transactions bypass the cache", func(t *testing.T) {
+		_, before := cacheCounters()
+		execInTx(t, db, func(ctx context.Context) {
+			result, err := db.ExecuteStatement(WithQueryLogInfo(ctx, QueryLogInfo{SQL: "select * from items"}), Statement{
+				Kind:      Select,
+				TableName: tableName,
+				Fields:    fieldsFromColumns(vacuumColumns...),
+			})
+			require.NoError(t, err)
+			_, err = materializeResultRows(ctx, result)
+			require.NoError(t, err)
+		})
+		_, after := cacheCounters()
+		assert.Equal(t, before, after)
+	})
+}
+
+func TestStatement_QueryCacheTables(t *testing.T) {
+	t.Parallel()
+
+	stmt := Statement{Kind: Select, TableName: "orders", Fields: []Field{{Name: "*"}}}
+	stmt.Joins = []Join{{TableName: "customers", Joins: []Join{{TableName: "regions"}}}}
+	tables, ok := stmt.queryCacheTables()
+	require.True(t, ok)
+	assert.Equal(t, []string{SchemaTableName, "orders", "customers", "regions"}, tables)
+
+	now := Statement{Kind: Select, TableName: "orders", Fields: []Field{{Name: "now()", Expr: &Expr{FuncName: "NOW"}}}}
+	_, ok = now.queryCacheTables()
+	assert.False(t, ok)
+
+	uuid := Statement{Kind: Select, TableName: "orders", Fields: []Field{{Name: "gen_random_uuid()", Expr: &Expr{FuncName: "GEN_RANDOM_UUID"}}}}
+	_, ok = uuid.queryCacheTables()
+	assert.False(t, ok)
+
+	subquery := Statement{
+		Kind:       Select,
+		TableName:  "orders",
+		Conditions: OneOrMore{{{Operand1: Operand{Type: OperandField, Value: "id"}, Operator: Eq, Operand2: Operand{Type: OperandSubquery, Value: &Statement{Kind: Select, TableName: "customers"}}}}},
+	}
+	_, ok = subquery.queryCacheTables()
+	assert.False(t, ok)
+}
@@ -0,0 +1,206 @@
+package minisql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+)
+
+// Errors returned when a statement exceeds a limit set with WithQueryLimits.
+var (
+	ErrQueryRowLimitExceeded    = errors.New("query row limit exceeded")
+	ErrQueryTimeLimitExceeded   = errors.New("query time limit exceeded")
+	ErrQueryMemoryLimitExceeded = errors.New("query memory limit exceeded")
+)
+
+// QueryLimits caps the resources a single statement may consume. A zero
+// field means no limit.
+//
+// MaxRows bounds the number of rows returned to the caller. MaxDuration
+// bounds the wall-clock time from the start of execution until the last row
+// is read. MaxMemory bounds the approximate number of bytes of rows held in
+// memory by sorts and materialised result sets; rows spilled to disk by an
+// external sort are not counted.
+type QueryLimits struct {
+	MaxRows     int64
+	MaxDuration time.Duration
+	MaxMemory   int64
+}
+
+func (l QueryLimits) isZero() bool {
+	return l.MaxRows <= 0 && l.MaxDuration <= 0 && l.MaxMemory <= 0
+}
+
+// ctxKeyQueryLimits is the context key for caller-supplied QueryLimits.
+type ctxKeyQueryLimits struct{}
+
+// ctxKeyQueryBudget is the context key for the queryBudget of the statement
+// being executed. Nested statements (CTE bodies, subqueries) share the budget
+// of the top-level statement.
+type ctxKeyQueryBudget struct{}
+
+// WithQueryLimits returns a context that applies limits to every statement
+// executed with it.
+func WithQueryLimits(ctx context.Context, limits QueryLimits) context.Context {
+	return context.WithValue(ctx, ctxKeyQueryLimits{}, limits)
+}
+
+// QueryLimitsFromContext returns the limits attached with WithQueryLimits.
+func QueryLimitsFromContext(ctx context.Context) (QueryLimits, bool) {
+	limits, ok := ctx.Value(ctxKeyQueryLimits{}).(QueryLimits)
+	return limits, ok
+}
+
+// queryBudget tracks resource usage of one top-level statement against its
+// QueryLimits. All methods are safe to call on a nil budget, which is what
+// executor paths see when no limits are set.
+type queryBudget struct {
+	limits   QueryLimits
+	deadline time.Time
+	memory   atomic.Int64
+}
+
+// start stores the budget in ctx and, when MaxDuration is set, derives a
+// context that expires at the deadline.
+func (b *queryBudget) start(ctx context.Context) (context.Context, context.CancelFunc) {
+	ctx = context.WithValue(ctx, ctxKeyQueryBudget{}, b)
+	if b.limits.MaxDuration <= 0 {
+		return ctx, func() {}
+	}
+	b.deadline = time.Now().Add(b.limits.MaxDuration)
+	return context.WithDeadline(ctx, b.deadline)
+}
+
+func queryBudgetFromContext(ctx context.Context) *queryBudget {
+	budget, _ := ctx.Value(ctxKeyQueryBudget{}).(*queryBudget)
+	return budget
+}
+
+// growMemory charges n bytes against the memory limit.
+func (b *queryBudget) growMemory(n int64) error {
+	if b == nil || b.limits.MaxMemory <= 0 {
+		return nil
+	}
+	if used := b.memory.Add(n); used > b.limits.MaxMemory {
+		return fmt.Errorf("%w: %d bytes used, limit is %d", ErrQueryMemoryLimitExceeded, used, b.limits.MaxMemory)
+	}
+	return nil
+}
+
+// releaseMemory returns n bytes previously charged with growMemory, e.g.
+// after a sorted run has been spilled to disk.
+func (b *queryBudget) releaseMemory(n int64) {
+	if b == nil || b.limits.MaxMemory <= 0 {
+		return
+	}
+	b.memory.Add(-n)
+}
+
+func (b *queryBudget) checkDeadline() error {
+	if b == nil || b.deadline.IsZero() || time.Now().Before(b.deadline) {
+		return nil
+	}
+	return fmt.Errorf("%w: limit is %s", ErrQueryTimeLimitExceeded, b.limits.MaxDuration)
+}
+
+// wrapErr reports a context deadline hit while executing under MaxDuration
+// as ErrQueryTimeLimitExceeded.
+func (b *queryBudget) wrapErr(err error) error {
+	if b == nil || b.deadline.IsZero() || err == nil || errors.Is(err, ErrQueryTimeLimitExceeded) {
+		return err
+	}
+	if errors.Is(err, context.DeadlineExceeded) && !time.Now().Before(b.deadline) {
+		return fmt.Errorf("%w: %w", ErrQueryTimeLimitExceeded, err)
+	}
+	return err
+}
+
+// executeStatementWithLimits runs stmt under the QueryLimits attached to ctx,
+// if any, with MaxDuration defaulting to the database's query timeout.
+// MaxDuration is enforced through a context deadline while the statement
+// executes and checked again on every row read from the result; MaxRows is
+// enforced as rows are read; MaxMemory is charged by the sort and
+// materialisation paths through the budget stored in the context.
+func (d *Database) executeStatementWithLimits(ctx context.Context, stmt Statement) (StatementResult, error) {
+	limits, _ := QueryLimitsFromContext(ctx)
+	if limits.MaxDuration <= 0 {
+		limits.MaxDuration = d.queryTimeout
+	}
+	if limits.isZero() || queryBudgetFromContext(ctx) != nil {
+		return d.executeStatement(ctx, stmt)
+	}
+
+	budget := &queryBudget{limits: limits}
+	ctx, cancel := budget.start(ctx)
+
+	result, err := d.executeStatement(ctx, stmt)
+	if err != nil {
+		cancel()
+		return result, budget.wrapErr(err)
+	}
+
+	if result.Rows.rowFunc == nil && result.RowViews.rowFunc == nil {
+		cancel()
+		return result, nil
+	}
+
+	// Rows are counted through the iterators, so the raw slice shortcut
+	// used by materializeResultRows must not bypass them.
+	result.rawRows = nil
+	var returned int64
+	if result.Rows.rowFunc != nil {
+		next := result.Rows.rowFunc
+		result.Rows.rowFunc = func(ctx context.Context) (Row, error) {
+			if err := budget.checkDeadline(); err != nil {
+				cancel()
+				return Row{}, err
+			}
+			row, err := next(ctx)
+			if err != nil {
+				cancel()
+				return Row{}, budget.wrapErr(err)
+			}
+			returned += 1
+			if limits.MaxRows > 0 && returned > limits.MaxRows {
+				cancel()
+				return Row{}, fmt.Errorf("%w: limit is %d", ErrQueryRowLimitExceeded, limits.MaxRows)
+			}
+			return row, nil
+		}
+		result.Rows.closeFunc = queryLimitsCloseFunc(result.Rows.closeFunc, cancel)
+	}
+	if result.RowViews.rowFunc != nil {
+		next := result.RowViews.rowFunc
+		result.RowViews.rowFunc = func(ctx context.Context) (RowView, error) {
+			if err := budget.checkDeadline(); err != nil {
+				cancel()
+				return RowView{}, err
+			}
+			view, err := next(ctx)
+			if err != nil {
+				cancel()
+				return RowView{}, budget.wrapErr(err)
+			}
+			returned += 1
+			if limits.MaxRows > 0 && returned > limits.MaxRows {
+				cancel()
+				return RowView{}, fmt.Errorf("%w: limit is %d", ErrQueryRowLimitExceeded, limits.MaxRows)
+			}
+			return view, nil
+		}
+		result.RowViews.closeFunc = queryLimitsCloseFunc(result.RowViews.closeFunc, cancel)
+	}
+	return result, nil
+}
+
+func queryLimitsCloseFunc(closeFunc func() error, cancel context.CancelFunc) func() error {
+	return func() error {
+		cancel()
+		if closeFunc != nil {
+			return closeFunc()
+		}
+		return nil
+	}
+}
@@ -0,0 +1,127 @@
+package minisql
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDatabase_QueryLimits(t *testing.T) {
+	t.Parallel()
+	const (
+		tableName = "items"
+		numRows   = 200
+	)
+	createStmt := vacuumCreateStmt(tableName)
+
+	mockParser := new(MockParser)
+	mockParser.On("Parse", mock.Anything, createStmt.DDL()).Return([]Statement{createStmt}, nil)
+
+	db, _ := newVacuumTestDB(t, mockParser)
+	execInTx(t, db, func(ctx context.Context) {
+		_, err := db.ExecuteStatement(ctx, createStmt)
+		require.NoError(t, err)
+	})
+
+	insert := InsertInto(tableName).Columns("id", "name")
+	for i := range numRows {
+		insert.Values(i+1, fmt.Sprintf("name-%03d", numRows-i))
+	}
+	insertStmt, err := insert.Build()
+	require.NoError(t, err)
+	execInTx(t, db, func(ctx context.Context) {
+		_, err := db.ExecuteStatement(ctx, insertStmt)
+		require.NoError(t, err)
+	})
+
+	scanStmt, err := SelectFrom(tableName).Build()
+	require.NoError(t, err)
+	sortStmt, err := SelectFrom(tableName).OrderBy("name", Asc).Build()
+	require.NoError(t, err)
+
+	// runQuery executes stmt under limits and drains the result, returning
+	// the number of rows read and the first error from either step.
+	runQuery := func(t *testing.T, stmt Statement, limits QueryLimits) (int, error) {
+		var (
+			count int
+			err   error
+		)
+		execInTx(t, db, func(ctx context.Context) {
+			ctx = WithQueryLimits(ctx, limits)
+			var result StatementResult
+			result, err = db.ExecuteStatement(ctx, stmt)
+			if err != nil {
+				return
+			}
+			var rows []Row
+			rows, err = materializeResultRows(ctx, result)
+			count = len(rows)
+		})
+		return count, err
+	}
+
+	testCases := []struct {
+		Name     string
+		Stmt     Statement
+		Tight    QueryLimits
+		Err      error
+		Generous QueryLimits
+	}{
+		{
+			Name:     "max rows",
+			Stmt:     scanStmt,
+			Tight:    QueryLimits{MaxRows: 10},
+			Err:      ErrQueryRowLimitExceeded,
+			Generous: QueryLimits{MaxRows: numRows},
+		},
+		{
+			Name:     "max memory with sort",
+			Stmt:     sortStmt,
+			Tight:    QueryLimits{MaxMemory: 1024},
+			Err:      ErrQueryMemoryLimitExceeded,
+			Generous: QueryLimits{MaxMemory: 16 << 20},
+		},
+		{
+			Name:     "max duration",
+			Stmt:     sortStmt,
+			Tight:    QueryLimits{MaxDuration: time.Nanosecond},
+			Err:      ErrQueryTimeLimitExceeded,
+			Generous: QueryLimits{MaxDuration: time.Minute},
+		},
+	}
+
+	for _, aTestCase := range testCases {
+		t.Run(aTestCase.Name, func(t *testing.T) {
+			_, err := runQuery(t, aTestCase.Stmt, aTestCase.Tight)
+			assert.ErrorIs(t, err, aTestCase.Err)
+
+			count, err := runQuery(t, aTestCase.Stmt, aTestCase.Generous)
+			require.NoError(t, err)
+			assert.Equal(t, numRows, count)
+		})
+	}
+
+	t.Run("no limits", func(t *testing.T) {
+		count, err := runQuery(t, scanStmt, QueryLimits{})
+		require.NoError(t, err)
+		assert.Equal(t, numRows, count)
+	})
+
+	t.Run("database query timeout", func(t *testing.T) {
+		WithQueryTimeout(time.Nanosecond)(db)
+		defer func() { db.queryTimeout = 0 }()
+
+		_, err := runQuery(t, sortStmt, QueryLimits{})
+		assert.ErrorIs(t, err, ErrQueryTimeLimitExceeded)
+
+		// A MaxDuration on the context takes precedence.
+		count, err := runQuery(t, sortStmt, QueryLimits{MaxDuration: time.Minute})
+		require.NoError(t, err)
+		assert.Equal(t, numRows, count)
+	})
+}
@@ -0,0 +1,154 @@
+package minisql
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// redactedArg replaces every bound argument value in the query log when
+// redaction is enabled. The number of arguments is preserved.
+const redactedArg = "<redacted>"
+
+// ctxKeyQueryLogInfo is the context key for the caller-supplied QueryLogInfo.
+type ctxKeyQueryLogInfo struct{}
+
+// ctxKeyNestedStatement marks statements executed on behalf of an outer
+// statement (CTE bodies, subqueries, INSERT … SELECT sources) so that only
+// the top-level statement is written to the query log or looked up in the
+// query cache.
+type ctxKeyNestedStatement struct{}
+
+// QueryLogInfo carries the caller-side details of a statement that the engine
+// cannot recover on its own: the original SQL text, the bound argument values
+// and an identifier for the client that issued it.
+type QueryLogInfo struct {
+	SQL    string
+	Args   []any
+	Client string
+}
+
+// WithQueryLogInfo returns a context carrying info for the query log. The
+// driver attaches it before calling ExecuteStatement; it is ignored when the
+// query log is disabled.
+func WithQueryLogInfo(ctx context.Context, info QueryLogInfo) context.Context {
+	return context.WithValue(ctx, ctxKeyQueryLogInfo{}, info)
+}
+
+func queryLogInfoFromContext(ctx context.Context) QueryLogInfo {
+	info, _ := ctx.Value(ctxKeyQueryLogInfo{}).(QueryLogInfo)
+	return info
+}
+
+// QueryLogEntry is a single record written to the query log, one JSON object
+// per line when the log is written to an io.Writer.
+type QueryLogEntry struct {
+	Time         time.Time `json:"time"`
+	Client       string    `json:"client,omitempty"`
+	Kind         string    `json:"kind"`
+	SQL          string    `json:"sql,omitempty"`
+	Args         []any     `json:"args,omitempty"`
+	RowsAffected int       `json:"rows_affected"`
+	Duration     string    `json:"duration"`
+	Error        string    `json:"error,omitempty"`
+}
+
+// queryLog records every top-level statement executed by the Database,
+// independent of its duration. Entries go to w as JSON lines, or to the
+// zap logger at INFO level when w is nil.
+type queryLog struct {
+	mu     sync.Mutex
+	w      io.Writer
+	enc    *json.Encoder
+	logger *zap.Logger
+}
+
+func (q *queryLog) record(entry QueryLogEntry) error {
+	if q.w == nil {
+		if q.logger == nil {
+			return nil
+		}
+		fields := []zap.Field{
+			zap.Time("time", entry.Time),
+			zap.String("client", entry.Client),
+			zap.String("kind", entry.Kind),
+			zap.String("sql", entry.SQL),
+			zap.Any("args", entry.Args),
+			zap.Int("rows_affected", entry.RowsAffected),
+			zap.String("duration", entry.Duration),
+		}
+		if entry.Error != "" {
+			fields = append(fields, zap.String("error", entry.Error))
+		}
+		q.logger.Info("query", fields...)
+		return nil
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.enc == nil {
+		q.enc = json.NewEncoder(q.w)
+	}
+	return q.enc.Encode(entry)
+}
+
+// queryLogArgs converts bound argument values into JSON-friendly form,
+// replacing each with redactedArg when redaction is enabled.
+func queryLogArgs(args []any, redact bool) []any {
+	if len(args) == 0 {
+		return nil
+	}
+	out := make([]any, len(args))
+	for i, arg := range args {
+		if redact {
+			out[i] = redactedArg
+			continue
+		}
+		switch v := arg.(type) {
+		case TextPointer:
+			out[i] = v.String()
+		case []byte:
+			out[i] = string(v)
+		case io.Reader, ReaderValue:
+			out[i] = "<stream>"
+		default:
+			out[i] = v
+		}
+	}
+	return out
+}
+
+// QueryLogEnabled reports whether a query log is configured. The driver uses
+// it to skip building QueryLogInfo when nothing would be recorded.
+func (d *Database) QueryLogEnabled() bool {
+	return d.queryLog != nil
+}
+
+// logQuery writes a query log entry for a completed top-level statement.
+// A failure to write the log never fails the statement itself.
+func (d *Database) logQuery(ctx context.Context, stmt Statement, start time.Time, result StatementResult, err error) {
+	info := queryLogInfoFromContext(ctx)
+	sql := info.SQL
+	if sql == "" {
+		sql = stmt.CacheKey
+	}
+	entry := QueryLogEntry{
+		Time:         start.UTC(),
+		Client:       info.Client,
+		Kind:         stmt.Kind.String(),
+		SQL:          sql,
+		Args:         queryLogArgs(info.Args, d.queryLogRedactArgs),
+		RowsAffected: result.RowsAffected,
+		Duration:     time.Since(start).String(),
+	}
+	if err != nil {
+		entry.Error = err.Error()
+	}
+	if logErr := d.queryLog.record(entry); logErr != nil && d.logger != nil {
+		d.logger.Warn("failed to write query log entry", zap.Error(logErr))
+	}
+}
@@ -0,0 +1,83 @@
+package minisql
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestQueryLogArgs(t *testing.T) {
+	t.Parallel()
+
+	args := []any{int64(1), "alice", []byte("raw"), NewTextPointer([]byte("text")), strings.NewReader("stream"), nil}
+
+	assert.Nil(t, queryLogArgs(nil, false))
+	assert.Equal(t, []any{int64(1), "alice", "raw", "text", "<stream>", nil}, queryLogArgs(args, false))
+	assert.Equal(t, []any{redactedArg, redactedArg, redactedArg, redactedArg, redactedArg, redactedArg}, queryLogArgs(args, true))
+}
+
+func TestQueryLog_Record(t *testing.T) {
+	t.Parallel()
+
+	entry := QueryLogEntry{
+		Time:         time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		Client:       "conn-1",
+		Kind:         "INSERT",
+		SQL:          "insert into t (a) values (?)",
+		Args:         []any{int64(7)},
+		RowsAffected: 1,
+		Duration:     "1ms",
+	}
+
+	t.Run("writer", func(t *testing.T) {
+		var buf bytes.Buffer
+		q := &queryLog{w: &buf}
+		require.NoError(t, q.record(entry))
+		require.NoError(t, q.record(entry))
+
+		lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+		require.Len(t, lines, 2)
+
+		var decoded map[string]any
+		require.NoError(t, json.Unmarshal([]byte(lines[0]), &decoded))
+		assert.Equal(t, "conn-1", decoded["client"])
+		assert.Equal(t, "INSERT", decoded["kind"])
+		assert.Equal(t, "insert into t (a) values (?)", decoded["sql"])
+		assert.Equal(t, []any{float64(7)}, decoded["args"])
+		assert.Equal(t, float64(1), decoded["rows_affected"])
+		assert.NotContains(t, decoded, "error")
+	})
+
+	t.Run("zap logger", func(t *testing.T) {
+		core, logs := observer.New(zap.InfoLevel)
+		db := &Database{logger: zap.New(core)}
+		WithZapQueryLog()(db)
+		require.NoError(t, db.queryLog.record(entry))
+
+		require.Equal(t, 1, logs.Len())
+		logged := logs.All()[0]
+		assert.Equal(t, "query", logged.Message)
+		assert.Equal(t, "insert into t (a) values (?)", logged.ContextMap()["sql"])
+		assert.Equal(t, int64(1), logged.ContextMap()["rows_affected"])
+	})
+}
+
+func TestWithQueryLog(t *testing.T) {
+	t.Parallel()
+
+	db := &Database{}
+	WithQueryLog(nil)(db)
+	assert.False(t, db.QueryLogEnabled())
+
+	WithQueryLog(&bytes.Buffer{})(db)
+	WithQueryLogRedaction(true)(db)
+	assert.True(t, db.QueryLogEnabled())
+	assert.True(t, db.queryLogRedactArgs)
+}
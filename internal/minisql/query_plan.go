@@ -127,6 +127,11 @@ type QueryPlan struct {
 	Scans []Scan
 	Joins []JoinPlan // JOIN operations to perform
 
+	// JoinFilters holds the WHERE conditions of a JOIN query that cannot be
+	// pushed into a single table scan (see splitJoinFilters). They are checked
+	// against each combined row.
+	JoinFilters OneOrMore
+
 	// Ordering
 	OrderBy      []OrderBy
 	SortInMemory bool
@@ -754,20 +759,7 @@ func (t *Table) tryFullTextIndexScan(filters OneOrMore) (Scan, bool) {
 		return Scan{}, false
 	}
 
-	var (
-		matchedIndex SecondaryIndex
-		foundIndex   = false
-	)
-	for _, idx := range t.SecondaryIndexes {
-		if idx.Method != IndexMethodFullText || len(idx.Columns) != 1 {
-			continue
-		}
-		if idx.Columns[0].Name == columnName {
-			matchedIndex = idx
-			foundIndex = true
-			break
-		}
-	}
+	matchedIndex, foundIndex := t.singleColumnIndex(columnName, IndexMethodFullText)
 	if !foundIndex || !partialIndexImplied(matchedIndex.WhereCond, filters[0]) {
 		return Scan{}, false
 	}
@@ -836,20 +828,7 @@ func (t *Table) tryInvertedIndexScan(filters OneOrMore) (Scan, bool) {
 		return Scan{}, false
 	}
 
-	var (
-		matchedIndex SecondaryIndex
-		foundIndex   bool
-	)
-	for _, idx := range t.SecondaryIndexes {
-		if idx.Method != IndexMethodInverted || len(idx.Columns) != 1 {
-			continue
-		}
-		if idx.Columns[0].Name == columnName {
-			matchedIndex = idx
-			foundIndex = true
-			break
-		}
-	}
+	matchedIndex, foundIndex := t.singleColumnIndex(columnName, IndexMethodInverted)
 	if !foundIndex || !partialIndexImplied(matchedIndex.WhereCond, filters[0]) {
 		return Scan{}, false
 	}
@@ -947,18 +926,7 @@ func (t *Table) tryHNSWScan(stmt Statement) (Scan, bool) {
 	}
 
 	// Find an HNSW index on the named column.
-	var matchedIndex SecondaryIndex
-	foundIndex := false
-	for _, idx := range t.SecondaryIndexes {
-		if idx.Method != IndexMethodHNSW || len(idx.Columns) != 1 {
-			continue
-		}
-		if idx.Columns[0].Name == colName {
-			matchedIndex = idx
-			foundIndex = true
-			break
-		}
-	}
+	matchedIndex, foundIndex := t.singleColumnIndex(colName, IndexMethodHNSW)
 	if !foundIndex {
 		return Scan{}, false
 	}
@@ -1062,6 +1030,12 @@ func (t *Table) tryMatchIndex(indexInfo IndexInfo, group Conditions) *indexMatch
 	numMatchedColumns := numMatched
 	isPartialMatch := numMatchedColumns > 0 && numMatchedColumns < len(indexInfo.Columns)
 
+	// Composite keys with a NULL component are never stored in the index, so
+	// a prefix scan would silently miss rows whose trailing columns are NULL.
+	if isPartialMatch && !columnsNotNull(t, indexInfo.Columns[numMatchedColumns:]) {
+		return nil
+	}
+
 	var indexKeys []any
 	var rangeCondition *RangeCondition
 	var hasProperUpperBound bool
@@ -1253,9 +1227,10 @@ func (t *Table) findAdditionalIndexScans(group Conditions, primaryMatch *indexMa
 		}
 		rangeScan.Filters = nil // The intersection parent handles remaining filters.
 		scans = append(scans, rangeScan)
-		// Mark all conditions for this column as covered.
+		// Mark all conditions for this column as covered, except LIKE whose
+		// prefix only bounds the range.
 		for ci, c := range group {
-			if c.Operand1.Type == OperandField {
+			if c.Operand1.Type == OperandField && c.Operator != Like {
 				if f, ok2 := c.Operand1.Value.(Field); ok2 && f.Name == field.Name {
 					covered[ci] = true
 				}
@@ -1477,7 +1452,7 @@ func (p *QueryPlan) setIndexScans(t *Table, conditions OneOrMore) error {
 			for _, rs := range rangeSubScans {
 				colName := rs.IndexColumns[0].Name
 				for condIdx, cond := range group {
-					if cond.Operand1.Type == OperandField {
+					if cond.Operand1.Type == OperandField && cond.Operator != Like {
 						if f, ok2 := cond.Operand1.Value.(Field); ok2 && f.Name == colName {
 							covered[condIdx] = true
 						}
@@ -1531,20 +1506,19 @@ func (p *QueryPlan) setIndexScans(t *Table, conditions OneOrMore) error {
 		})
 	}
 
-	// Only override the default plan if at least one scan uses a real index.
-	// When all groups fall back to sequential scan (no index usable), keep the
-	// default single sequential scan which already holds the full DNF filter.
-	hasRealIndexScan := false
-	allIndexed := true
+	// Only override the default plan if every OR group uses a real index. When
+	// any group falls back to a sequential scan (no usable index, or the index
+	// is not selective enough), that scan has to read the whole table anyway,
+	// so keep the default single sequential scan which already holds the full
+	// DNF filter. Mixing it with index scans would return rows matched by both
+	// kinds of scan twice.
 	for _, scan := range indexScans {
-		if scan.Type != ScanTypeSequential {
-			hasRealIndexScan = true
-		} else {
-			allIndexed = false
+		if scan.Type == ScanTypeSequential {
+			return nil
 		}
 	}
-	if len(indexScans) > 0 && hasRealIndexScan {
-		if allIndexed && len(indexScans) > 1 {
+	if len(indexScans) > 0 {
+		if len(indexScans) > 1 {
 			// Every OR group has an index-backed scan → merge into a single union scan.
 			// The executor collects RowIDs from each sub-scan, deduplicates, then
 			// fetches each surviving row once and re-checks the full WHERE clause.
@@ -1606,6 +1580,17 @@ func equalityKeys(col Column, cond Condition) ([]any, error) {
 	return keyValues, nil
 }
 
+// columnsNotNull reports whether every given column is declared NOT NULL in the table.
+func columnsNotNull(t *Table, columns []Column) bool {
+	for _, c := range columns {
+		col, ok := t.ColumnByName(c.Name)
+		if !ok || col.Nullable {
+			return false
+		}
+	}
+	return true
+}
+
 // incrementValue returns the next value after the given value for creating upper bounds in range scans.
 // Returns nil if the value cannot be safely incremented (e.g., max value or unsupported type).
 func incrementValue(val any) any {
@@ -1639,6 +1624,21 @@ func incrementValue(val any) any {
 	}
 }
 
+// likeRangePrefix returns the literal prefix of a LIKE condition on a VARCHAR
+// index column. It fails for other column kinds, non-constant patterns and
+// patterns starting with a wildcard, which cannot bound an index range.
+func likeRangePrefix(col Column, cond Condition) (string, bool) {
+	if col.Kind != Varchar || cond.Operand2.Type != OperandQuotedString {
+		return "", false
+	}
+	pattern, err := castKeyValue(col, cond.Operand2.Value)
+	if err != nil {
+		return "", false
+	}
+	prefix := likePrefix(pattern.(string))
+	return prefix, prefix != ""
+}
+
 func tryRangeScan(tableName string, indexInfo IndexInfo, filters Conditions, stats *IndexStats) (Scan, bool, error) {
 	var (
 		rangeCondition   = RangeCondition{}
@@ -1670,21 +1670,82 @@ func tryRangeScan(tableName string, indexInfo IndexInfo, filters Conditions, sta
 			return Scan{}, false, nil
 		}
 
+		if cond.Operator == IsNull || cond.Operator == IsNotNull {
+			// id IS NULL / id IS NOT NULL - NULL keys are not ordered in the index
+			return Scan{}, false, nil
+		}
+
 		if cond.Operator == In || cond.Operator == NotIn {
 			// id != X , id NOT IN (...) - we will be doing a sequential scan so just return
 			return Scan{}, false, nil
 		}
 
-		if cond.Operator == Like || cond.Operator == NotLike {
-			// LIKE / NOT LIKE requires a full sequential scan — no range bound possible
+		if cond.Operator == Like {
+			// col LIKE 'abc%' only matches keys in ['abc', 'abc\xFF'). The
+			// pattern itself is still checked as a filter on every key.
+			prefix, ok := likeRangePrefix(indexInfo.Columns[0], cond)
+			if !ok {
+				return Scan{}, false, nil
+			}
+			if rangeCondition.Lower == nil ||
+				compareAny(prefix, rangeCondition.Lower.Value) > 0 {
+				rangeCondition.Lower = &RangeBound{
+					Value:     prefix,
+					Inclusive: true,
+				}
+			}
+			if upper := prefix + "\xFF"; rangeCondition.Upper == nil ||
+				compareAny(upper, rangeCondition.Upper.Value) < 0 {
+				rangeCondition.Upper = &RangeBound{
+					Value:     upper,
+					Inclusive: false,
+				}
+			}
+			remainingFilters = append(remainingFilters, cond)
+			continue
+		}
+
+		if cond.Operator == NotLike {
+			// NOT LIKE requires a full sequential scan — no range bound possible
 			return Scan{}, false, nil
 		}
 
-		if cond.Operator == Between || cond.Operator == NotBetween {
-			// BETWEEN / NOT BETWEEN uses sequential scan for now
+		if cond.Operator == NotBetween {
+			// NOT BETWEEN matches two disjoint ranges — use a sequential scan
 			return Scan{}, false, nil
 		}
 
+		if cond.Operator == Between {
+			// id BETWEEN X AND Y is the inclusive range [X, Y]
+			bounds, ok := cond.Operand2.Value.([]any)
+			if !ok || len(bounds) != 2 {
+				return Scan{}, false, nil
+			}
+			lowerValue, err := castKeyValue(indexInfo.Columns[0], bounds[0])
+			if err != nil {
+				return Scan{}, false, err
+			}
+			upperValue, err := castKeyValue(indexInfo.Columns[0], bounds[1])
+			if err != nil {
+				return Scan{}, false, err
+			}
+			if rangeCondition.Lower == nil ||
+				compareAny(lowerValue, rangeCondition.Lower.Value) > 0 {
+				rangeCondition.Lower = &RangeBound{
+					Value:     lowerValue,
+					Inclusive: true,
+				}
+			}
+			if rangeCondition.Upper == nil ||
+				compareAny(upperValue, rangeCondition.Upper.Value) < 0 {
+				rangeCondition.Upper = &RangeBound{
+					Value:     upperValue,
+					Inclusive: true,
+				}
+			}
+			continue
+		}
+
 		conditionValue, err := castKeyValue(indexInfo.Columns[0], cond.Operand2.Value)
 		if err != nil {
 			return Scan{}, false, err
@@ -412,6 +412,43 @@ func TestTable_PlanQuery_CompositeIndex(t *testing.T) {
 				},
 			},
 		},
+		{
+			"Composite secondary index with nullable trailing column: Match only first column - sequential scan",
+			func() *Table {
+				columns := append([]Column{}, compositeColumns...)
+				columns[2].Nullable = true
+				return NewTable(
+					zap.NewNop(), nil, nil, "users", columns, 0, nil,
+					WithSecondaryIndex(SecondaryIndex{
+						IndexInfo: IndexInfo{
+							Name:    compositeSecondaryIndexName,
+							Columns: columns[1:3],
+						},
+					}),
+				)
+			}(),
+			Statement{
+				Kind: Select,
+				Conditions: OneOrMore{
+					{
+						FieldIsEqual(Field{Name: "first_name"}, OperandQuotedString, NewTextPointer([]byte("John"))),
+					},
+				},
+			},
+			QueryPlan{
+				Scans: []Scan{
+					{
+						TableName: "users",
+						Type:      ScanTypeSequential,
+						Filters: OneOrMore{
+							{
+								FieldIsEqual(Field{Name: "first_name"}, OperandQuotedString, NewTextPointer([]byte("John"))),
+							},
+						},
+					},
+				},
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 
 	minisqlErrors "github.com/RichardKnop/minisql/pkg/errors"
 )
@@ -28,6 +29,9 @@ type joinMemo struct {
 	innerCoveringFilter func(Row) (bool, error) // compileScanFilter for covering index rows
 	innerCovering       bool                    // whether inner scan is a covering index
 	innerIndexCols      []Column                // index columns for inner covering scan
+
+	// whereFilter checks QueryPlan.JoinFilters; set on the last join level only.
+	whereFilter func(Row) (bool, error)
 }
 
 // buildCombinedColumns returns the alias-prefixed column list for the first join
@@ -112,11 +116,12 @@ func (t *Table) planJoinQuery(ctx context.Context, stmt Statement) (QueryPlan, e
 	}
 
 	// Fallback: user-specified order.
-	baseTableFilters, joinTableFilters := pushDownFilters(stmt.Conditions, stmt.TableAlias, stmt.Joins)
+	baseTableFilters, joinTableFilters, residualFilters := splitJoinFilters(stmt.Conditions, stmt.TableAlias, stmt.Joins)
 
 	plan := QueryPlan{
 		Scans:        []Scan{planJoinTableScan(t, t.Name, stmt.TableAlias, baseTableFilters)},
 		Joins:        make([]JoinPlan, 0),
+		JoinFilters:  residualFilters,
 		OrderBy:      stmt.OrderBy,
 		SortInMemory: len(stmt.OrderBy) > 0,
 	}
@@ -437,18 +442,17 @@ func (t *Table) planJoinQueryGreedy(ctx context.Context, stmt Statement) (QueryP
 
 	// Build per-alias filters (push-down regardless of which table is base).
 	allFilters := make(map[string]OneOrMore, len(nodes))
-	{
-		baseFilters, joinFilters := pushDownFilters(stmt.Conditions, stmt.TableAlias, stmt.Joins)
-		allFilters[stmt.TableAlias] = baseFilters
-		for alias, f := range joinFilters {
-			allFilters[alias] = f
-		}
+	baseFilters, joinFilters, residualFilters := splitJoinFilters(stmt.Conditions, stmt.TableAlias, stmt.Joins)
+	allFilters[stmt.TableAlias] = baseFilters
+	for alias, f := range joinFilters {
+		allFilters[alias] = f
 	}
 
 	plan := QueryPlan{
 		OrderBy:      stmt.OrderBy,
 		SortInMemory: len(stmt.OrderBy) > 0,
 		Joins:        make([]JoinPlan, 0, len(orderedNodes)-1),
+		JoinFilters:  residualFilters,
 		Scans:        make([]Scan, 0, len(orderedNodes)),
 	}
 
@@ -838,7 +842,7 @@ func planJoinTableScan(t *Table, tableName, tableAlias string, conditions OneOrM
 // the filter evaluation reads column values by index and must see decoded data.
 func neededOuterFields(p QueryPlan, baseTable *Table, baseScan Scan, selectedFields []Field) []Field {
 	// With filters we cannot safely skip columns (filter may read any column).
-	if len(baseScan.Filters) > 0 {
+	if len(baseScan.Filters) > 0 || len(p.JoinFilters) > 0 {
 		return fieldsFromColumns(baseTable.Columns...)
 	}
 
@@ -945,6 +949,124 @@ func pushDownFilters(conditions OneOrMore, baseTableAlias string, joins []Join)
 	return baseFilters, joinFilters
 }
 
+// splitJoinFilters separates WHERE conditions like pushDownFilters, but keeps
+// back the conditions that would change the result if applied to one table
+// before the join. Those are returned as residual filters to check on each
+// combined row:
+//
+//   - a condition that references more than one table, such as u.id = o.id;
+//   - a condition on a table that an outer join NULL-extends. Filtering o
+//     before "u LEFT JOIN o" would turn a removed match into a NULL-padded
+//     row instead of dropping it, and o.id IS NULL could never hold;
+//   - an OR whose branches reference different tables, which cannot be split
+//     into per-table filters without turning it into an AND.
+func splitJoinFilters(conditions OneOrMore, baseTableAlias string, joins []Join) (OneOrMore, map[string]OneOrMore, OneOrMore) {
+	allJoinAliases := make(map[string]struct{})
+	collectJoinAliases(joins, allJoinAliases)
+	nullable := nullableJoinAliases(baseTableAlias, joins)
+
+	pushableAlias := func(condition Condition) (string, bool) {
+		aliases := conditionTableAliases(condition, baseTableAlias, allJoinAliases)
+		if len(aliases) != 1 {
+			return "", false
+		}
+		alias := aliases[0]
+		_, isNullable := nullable[alias]
+		return alias, !isNullable
+	}
+
+	if len(conditions) > 1 {
+		var groupAlias string
+		for _, group := range conditions {
+			for _, condition := range group {
+				alias, ok := pushableAlias(condition)
+				if !ok || (groupAlias != "" && alias != groupAlias) {
+					baseFilters, joinFilters := pushDownFilters(nil, baseTableAlias, joins)
+					return baseFilters, joinFilters, conditions
+				}
+				groupAlias = alias
+			}
+		}
+		baseFilters, joinFilters := pushDownFilters(conditions, baseTableAlias, joins)
+		return baseFilters, joinFilters, nil
+	}
+
+	var pushed, residual Conditions
+	for _, group := range conditions {
+		for _, condition := range group {
+			if _, ok := pushableAlias(condition); ok {
+				pushed = append(pushed, condition)
+			} else {
+				residual = append(residual, condition)
+			}
+		}
+	}
+	var pushedFilters, residualFilters OneOrMore
+	if len(pushed) > 0 {
+		pushedFilters = OneOrMore{pushed}
+	}
+	if len(residual) > 0 {
+		residualFilters = OneOrMore{residual}
+	}
+	baseFilters, joinFilters := pushDownFilters(pushedFilters, baseTableAlias, joins)
+	return baseFilters, joinFilters, residualFilters
+}
+
+// nullableJoinAliases returns the aliases whose columns an outer join can
+// fill with NULLs: the right side of a LEFT JOIN, including anything joined
+// to it. A RIGHT or FULL OUTER JOIN anywhere makes every alias nullable.
+func nullableJoinAliases(baseTableAlias string, joins []Join) map[string]struct{} {
+	nullable := make(map[string]struct{})
+	var walk func(joins []Join, underOuter bool) bool
+	walk = func(joins []Join, underOuter bool) bool {
+		for _, j := range joins {
+			if j.Type == Right || j.Type == FullOuter {
+				return false
+			}
+			outer := underOuter || j.Type == Left
+			if outer {
+				nullable[j.TableAlias] = struct{}{}
+			}
+			if !walk(j.Joins, outer) {
+				return false
+			}
+		}
+		return true
+	}
+	if !walk(joins, false) {
+		nullable[baseTableAlias] = struct{}{}
+		collectJoinAliases(joins, nullable)
+	}
+	return nullable
+}
+
+// conditionTableAliases returns the distinct table aliases referenced by the
+// field operands of condition. Fields without a known alias prefix are
+// attributed to the base table, as in getConditionTableAlias.
+func conditionTableAliases(condition Condition, baseTableAlias string, allJoinAliases map[string]struct{}) []string {
+	var aliases []string
+	for _, operand := range []Operand{condition.Operand1, condition.Operand2} {
+		if operand.Type != OperandField {
+			continue
+		}
+		field, ok := operand.Value.(Field)
+		if !ok {
+			continue
+		}
+		alias := baseTableAlias
+		if _, known := allJoinAliases[field.AliasPrefix]; known {
+			alias = field.AliasPrefix
+		}
+		if !slices.Contains(aliases, alias) {
+			aliases = append(aliases, alias)
+		}
+	}
+	if len(aliases) == 0 {
+		aliases = append(aliases, baseTableAlias)
+	}
+	return aliases
+}
+
 // getConditionTableAlias determines which table alias a condition belongs to.
 // allJoinAliases is the complete set of join table aliases (all depths).
 func getConditionTableAlias(condition Condition, baseTableAlias string, allJoinAliases map[string]struct{}) string {
@@ -1045,6 +1167,8 @@ func (p QueryPlan) executeNestedLoopJoin(ctx context.Context, provider TableProv
 			innerIndexCols:      innerScanForMemo.IndexColumns,
 		}
 	}
+	last := &joinMemos[len(joinMemos)-1]
+	last.whereFilter = compileRowFilterForColumns(last.combinedColumns, p.JoinFilters)
 
 	// RowView outer scan: for sequential non-virtual hash joins, iterate the base
 	// table as RowViews to avoid materialising unmatched outer rows.
@@ -1105,7 +1229,7 @@ func (p QueryPlan) executeNestedLoopJoin(ctx context.Context, provider TableProv
 // channel-based fallback path.
 func canUseOuterRowViewScan(p QueryPlan, baseTable *Table, baseScan Scan) bool {
 	return baseScan.Type == ScanTypeSequential &&
-		baseTable.virtualRows == nil &&
+		!baseTable.isVirtual() &&
 		!baseTable.parallelScan &&
 		len(p.Joins) > 0 &&
 		p.Joins[0].Algorithm == JoinAlgorithmHash &&
@@ -1241,6 +1365,12 @@ func (p QueryPlan) executeNestedLoopJoinOuterRowView(
 // memos holds per-join-level state precomputed once by executeNestedLoopJoin.
 func (p QueryPlan) executeJoinsForRow(ctx context.Context, provider TableProvider, currentRow Row, joinIndex int, filteredPipe chan<- Row, hashTables map[int]*hashJoinBucket, memos []joinMemo) error {
 	if joinIndex >= len(p.Joins) {
+		if filter := memos[len(memos)-1].whereFilter; filter != nil {
+			ok, err := filter(currentRow)
+			if err != nil || !ok {
+				return err
+			}
+		}
 		select {
 		case filteredPipe <- currentRow:
 		case <-ctx.Done():
@@ -1479,6 +1609,9 @@ func (p QueryPlan) executeJoinsForRow(ctx context.Context, provider TableProvide
 
 	// Anti-semi-join: emit the outer row only when there was no inner match.
 	if join.Type == AntiSemi {
+		if matched {
+			return nil
+		}
 		outerRow := currentRow
 		if joinIndex == 0 {
 			outerRow = prefixRowAlias(currentRow, fromAlias)
@@ -1678,6 +1811,15 @@ func (p QueryPlan) executeRightJoinPass(ctx context.Context, provider TableProvi
 			if err != nil {
 				return err
 			}
+			if len(p.JoinFilters) > 0 {
+				ok, err := combined.CheckOneOrMore(p.JoinFilters)
+				if err != nil {
+					return err
+				}
+				if !ok {
+					continue
+				}
+			}
 
 			select {
 			case filteredPipe <- combined:
@@ -216,6 +216,124 @@ func TestPushDownFilters(t *testing.T) {
 	})
 }
 
+func TestSplitJoinFilters(t *testing.T) {
+	t.Parallel()
+
+	var (
+		userID   = Field{AliasPrefix: "u", Name: "id"}
+		userName = Field{AliasPrefix: "u", Name: "name"}
+		orderID  = Field{AliasPrefix: "o", Name: "id"}
+		orderUID = Field{AliasPrefix: "o", Name: "user_id"}
+		admin    = NewTextPointer([]byte("admin"))
+	)
+
+	t.Run("Inner join pushes single-table conditions down", func(t *testing.T) {
+		// WHERE u.name = 'admin' AND o.id > 10
+		conditions := OneOrMore{{
+			FieldIsEqual(userName, OperandQuotedString, admin),
+			FieldIsGreater(orderID, OperandInteger, int64(10)),
+		}}
+		joins := []Join{{TableName: "orders", TableAlias: "o", Type: Inner}}
+
+		baseFilters, joinFilters, residual := splitJoinFilters(conditions, "u", joins)
+
+		assert.Equal(t, OneOrMore{{conditions[0][0]}}, baseFilters)
+		assert.Equal(t, OneOrMore{{conditions[0][1]}}, joinFilters["o"])
+		assert.Empty(t, residual)
+	})
+
+	t.Run("Left join keeps conditions on the right table after the join", func(t *testing.T) {
+		// WHERE u.name = 'admin' AND o.id IS NULL
+		conditions := OneOrMore{{
+			FieldIsEqual(userName, OperandQuotedString, admin),
+			FieldIsNull(orderID),
+		}}
+		joins := []Join{{TableName: "orders", TableAlias: "o", Type: Left}}
+
+		baseFilters, joinFilters, residual := splitJoinFilters(conditions, "u", joins)
+
+		assert.Equal(t, OneOrMore{{conditions[0][0]}}, baseFilters)
+		assert.Empty(t, joinFilters["o"])
+		assert.Equal(t, OneOrMore{{conditions[0][1]}}, residual)
+	})
+
+	t.Run("Tables joined to the right side of a left join are nullable too", func(t *testing.T) {
+		conditions := OneOrMore{{
+			FieldIsGreater(Field{AliasPrefix: "i", Name: "qty"}, OperandInteger, int64(1)),
+		}}
+		joins := []Join{{
+			TableName:  "orders",
+			TableAlias: "o",
+			Type:       Left,
+			Joins:      []Join{{TableName: "items", TableAlias: "i", Type: Inner}},
+		}}
+
+		_, joinFilters, residual := splitJoinFilters(conditions, "u", joins)
+
+		assert.Empty(t, joinFilters["i"])
+		assert.Equal(t, conditions, residual)
+	})
+
+	t.Run("Conditions comparing two tables are checked after the join", func(t *testing.T) {
+		// WHERE u.id = o.user_id
+		conditions := OneOrMore{{
+			{
+				Operand1: Operand{Type: OperandField, Value: userID},
+				Operator: Eq,
+				Operand2: Operand{Type: OperandField, Value: orderUID},
+			},
+		}}
+		joins := []Join{{TableName: "orders", TableAlias: "o", Type: Inner}}
+
+		baseFilters, joinFilters, residual := splitJoinFilters(conditions, "u", joins)
+
+		assert.Empty(t, baseFilters)
+		assert.Empty(t, joinFilters["o"])
+		assert.Equal(t, conditions, residual)
+	})
+
+	t.Run("OR across tables is kept whole", func(t *testing.T) {
+		// WHERE u.name = 'admin' OR o.id > 10
+		conditions := OneOrMore{
+			{FieldIsEqual(userName, OperandQuotedString, admin)},
+			{FieldIsGreater(orderID, OperandInteger, int64(10))},
+		}
+		joins := []Join{{TableName: "orders", TableAlias: "o", Type: Inner}}
+
+		baseFilters, joinFilters, residual := splitJoinFilters(conditions, "u", joins)
+
+		assert.Empty(t, baseFilters)
+		assert.Empty(t, joinFilters["o"])
+		assert.Equal(t, conditions, residual)
+	})
+
+	t.Run("OR on one table is pushed down", func(t *testing.T) {
+		// WHERE u.name = 'admin' OR u.id = 1
+		conditions := OneOrMore{
+			{FieldIsEqual(userName, OperandQuotedString, admin)},
+			{FieldIsEqual(userID, OperandInteger, int64(1))},
+		}
+		joins := []Join{{TableName: "orders", TableAlias: "o", Type: Left}}
+
+		baseFilters, _, residual := splitJoinFilters(conditions, "u", joins)
+
+		assert.Equal(t, conditions, baseFilters)
+		assert.Empty(t, residual)
+	})
+
+	t.Run("Right and full outer joins keep every condition after the join", func(t *testing.T) {
+		conditions := OneOrMore{{FieldIsEqual(userName, OperandQuotedString, admin)}}
+		for _, joinType := range []JoinType{Right, FullOuter} {
+			joins := []Join{{TableName: "orders", TableAlias: "o", Type: joinType}}
+
+			baseFilters, _, residual := splitJoinFilters(conditions, "u", joins)
+
+			assert.Empty(t, baseFilters)
+			assert.Equal(t, conditions, residual)
+		}
+	})
+}
+
 func TestExtractJoinColumnPairs(t *testing.T) {
 	t.Parallel()
 
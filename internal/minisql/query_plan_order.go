@@ -7,11 +7,11 @@ func (p QueryPlan) optimizeOrdering(t *Table, originalConditions OneOrMore) Quer
 	}
 
 	if len(p.OrderBy) > 1 {
-		// Multiple ORDER BY columns: try to use a composite index whose columns match
-		// the ORDER BY clause exactly (same columns, same order). This only works when
+		// Multiple ORDER BY columns: try to use a composite index whose leading columns
+		// match the ORDER BY clause exactly (same columns, same order). This only works when
 		// all ORDER BY directions are the same, because the index scan direction is a
 		// single bit (SortReverse) — per-column DESC markers are not supported.
-		if len(p.Scans) == 1 && p.Scans[0].Type == ScanTypeSequential && len(p.Scans[0].Filters) == 0 {
+		if len(p.Scans) == 1 && p.Scans[0].Type == ScanTypeSequential && len(p.Scans[0].Filters) == 0 && p.orderByColumnsNotNull(t) {
 			if info, ok := p.tryCompositeIndexForOrderBy(t); ok {
 				p.Scans[0].Type = ScanTypeIndexAll
 				p.Scans[0].IndexName = info.Name
@@ -38,8 +38,8 @@ func (p QueryPlan) optimizeOrdering(t *Table, originalConditions OneOrMore) Quer
 
 	// Sequential scan - no filters, just ordering
 	if len(p.Scans) == 1 && p.Scans[0].Type == ScanTypeSequential && len(p.Scans[0].Filters) == 0 {
-		// Use index for ordering if available
-		if info, ok := t.IndexInfoByColumnName(orderCol); ok {
+		// Use index for ordering if available and it holds an entry for every row
+		if info, ok := t.IndexInfoByColumnName(orderCol); ok && p.orderByColumnsNotNull(t) {
 			p.Scans[0].Type = ScanTypeIndexAll
 			p.Scans[0].IndexName = info.Name
 			p.Scans[0].IndexColumns = info.Columns
@@ -75,7 +75,7 @@ func (p QueryPlan) optimizeOrdering(t *Table, originalConditions OneOrMore) Quer
 		// If already using the ORDER BY index, check if sort is needed
 		// We have filters on one index and ORDER BY on another
 		// Decide: filter index + sort vs. ORDER BY index + filter
-		if !p.canUseOrderByIndexWithFilters(t, orderByInfo) {
+		if !p.orderByColumnsNotNull(t) || !p.canUseOrderByIndexWithFilters(t, orderByInfo) {
 			p.SortInMemory = true
 			return p
 		}
@@ -106,6 +106,19 @@ func (p QueryPlan) optimizeOrdering(t *Table, originalConditions OneOrMore) Quer
 	return p
 }
 
+// orderByColumnsNotNull reports whether every ORDER BY column is a NOT NULL
+// column of t. Indexes do not store NULL keys, so a full index walk over a
+// nullable column would silently drop rows; such orderings are sorted in memory.
+func (p QueryPlan) orderByColumnsNotNull(t *Table) bool {
+	for _, ob := range p.OrderBy {
+		col, ok := t.ColumnByName(ob.Field.Name)
+		if !ok || col.Nullable {
+			return false
+		}
+	}
+	return true
+}
+
 // canUseOrderByIndexWithFilters checks if we can use the ORDER BY index
 // when we have filters (they'd need to be applied in memory)
 func (p QueryPlan) canUseOrderByIndexWithFilters(t *Table, orderByInfo IndexInfo) bool {
@@ -121,10 +134,15 @@ func (p QueryPlan) canUseOrderByIndexWithFilters(t *Table, orderByInfo IndexInfo
 	return true
 }
 
-// tryCompositeIndexForOrderBy checks whether a composite index exists whose columns (in
-// order) exactly match the ORDER BY clause. It also requires that all ORDER BY directions
+// tryCompositeIndexForOrderBy looks for an index whose leading columns (in order)
+// exactly match the ORDER BY clause. It also requires that all ORDER BY directions
 // are the same (all ASC or all DESC), because the index scan is controlled by a single
 // SortReverse bit — per-column direction markers are not supported.
+//
+// An index with extra trailing columns still returns rows in ORDER BY order, but
+// only if every one of those columns is NOT NULL: keys with a NULL component are
+// not stored, so a full walk would drop rows. Partial and expression indexes are
+// never used. When several indexes qualify, the one with the fewest columns wins.
 func (p QueryPlan) tryCompositeIndexForOrderBy(t *Table) (IndexInfo, bool) {
 	// All directions must match
 	dir := p.OrderBy[0].Direction
@@ -134,17 +152,51 @@ func (p QueryPlan) tryCompositeIndexForOrderBy(t *Table) (IndexInfo, bool) {
 		}
 	}
 
-	// Build a Column slice from the ORDER BY fields so we can hash them
-	cols := make([]Column, 0, len(p.OrderBy))
-	for _, ob := range p.OrderBy {
-		col, ok := t.ColumnByName(ob.Field.Name)
-		if !ok {
-			return IndexInfo{}, false
+	candidates := make([]IndexInfo, 0, len(t.UniqueIndexes)+len(t.SecondaryIndexes)+1)
+	if t.HasPrimaryKey() {
+		candidates = append(candidates, t.PrimaryKey.IndexInfo)
+	}
+	for _, idx := range t.UniqueIndexes {
+		candidates = append(candidates, idx.IndexInfo)
+	}
+	for _, idx := range t.SecondaryIndexes {
+		if !idx.IsBTree() || idx.Expression != nil {
+			continue
 		}
-		cols = append(cols, col)
+		candidates = append(candidates, idx.IndexInfo)
 	}
 
-	return t.IndexInfoByColumns(cols)
+	var (
+		best  IndexInfo
+		found bool
+	)
+	for _, info := range candidates {
+		if len(info.WhereCond) > 0 || !p.orderByIsIndexPrefix(info) {
+			continue
+		}
+		if !columnsNotNull(t, info.Columns[len(p.OrderBy):]) {
+			continue
+		}
+		if !found || len(info.Columns) < len(best.Columns) ||
+			(len(info.Columns) == len(best.Columns) && info.Name < best.Name) {
+			best, found = info, true
+		}
+	}
+	return best, found
+}
+
+// orderByIsIndexPrefix reports whether the ORDER BY columns are the leading
+// columns of info, in the same order.
+func (p QueryPlan) orderByIsIndexPrefix(info IndexInfo) bool {
+	if len(info.Columns) < len(p.OrderBy) {
+		return false
+	}
+	for i, ob := range p.OrderBy {
+		if ob.Field.Expr != nil || info.Columns[i].Name != ob.Field.Name {
+			return false
+		}
+	}
+	return true
 }
 
 // shouldSwitchToOrderByIndex decides whether to switch from filter index to ORDER BY index
@@ -489,4 +489,102 @@ func TestQueryPlan_CompositeIndexForOrdering(t *testing.T) {
 		// No 3-column composite index exists
 		assert.True(t, plan.SortInMemory, "expected SortInMemory = true when no composite index matches all ORDER BY columns")
 	})
+
+	newTableWithIndex := func(index SecondaryIndex, cols []Column) *Table {
+		tbl := NewTable(logger, nil, nil, "results", cols, 0, nil,
+			WithPrimaryKey(NewPrimaryKey("pk_id", cols[0:1], false)),
+		)
+		tbl.SetSecondaryIndex(index)
+		return tbl
+	}
+
+	t.Run("ORDER BY is a prefix of a wider composite index - use index, no sort", func(t *testing.T) {
+		t.Parallel()
+
+		tbl := newTableWithIndex(SecondaryIndex{
+			IndexInfo: IndexInfo{
+				Name:    "idx_level_score_id",
+				Columns: []Column{columns[1], columns[2], columns[0]},
+			},
+		}, columns)
+		stmt := Statement{
+			Conditions: OneOrMore{},
+			OrderBy: []OrderBy{
+				{Field: Field{Name: "level"}, Direction: Desc},
+				{Field: Field{Name: "score"}, Direction: Desc},
+			},
+		}
+
+		plan, err := tbl.PlanQuery(ctx, stmt)
+		require.NoError(t, err)
+
+		assert.False(t, plan.SortInMemory)
+		assert.True(t, plan.SortReverse)
+		assert.Equal(t, ScanTypeIndexAll, plan.Scans[0].Type)
+		assert.Equal(t, "idx_level_score_id", plan.Scans[0].IndexName)
+	})
+
+	t.Run("ORDER BY prefix with nullable trailing index column - sort in memory", func(t *testing.T) {
+		t.Parallel()
+
+		nullableColumns := []Column{
+			columns[0],
+			columns[1],
+			columns[2],
+			{Name: "note", Kind: Int4, Size: 4, Nullable: true},
+		}
+		tbl := newTableWithIndex(SecondaryIndex{
+			IndexInfo: IndexInfo{
+				Name:    "idx_level_score_note",
+				Columns: []Column{nullableColumns[1], nullableColumns[2], nullableColumns[3]},
+			},
+		}, nullableColumns)
+		stmt := Statement{
+			Conditions: OneOrMore{},
+			OrderBy: []OrderBy{
+				{Field: Field{Name: "level"}, Direction: Asc},
+				{Field: Field{Name: "score"}, Direction: Asc},
+			},
+		}
+
+		plan, err := tbl.PlanQuery(ctx, stmt)
+		require.NoError(t, err)
+
+		// Rows with a NULL note have no index entry, so the index cannot be walked.
+		assert.True(t, plan.SortInMemory)
+		assert.Equal(t, ScanTypeSequential, plan.Scans[0].Type)
+	})
+
+	t.Run("ORDER BY matches a partial index - sort in memory", func(t *testing.T) {
+		t.Parallel()
+
+		tbl := newTableWithIndex(SecondaryIndex{
+			IndexInfo: IndexInfo{
+				Name:    "idx_level_score_partial",
+				Columns: []Column{columns[1], columns[2]},
+				WhereCond: OneOrMore{
+					{
+						{
+							Operand1: Operand{Type: OperandField, Value: Field{Name: "level"}},
+							Operator: Gt,
+							Operand2: Operand{Type: OperandInteger, Value: int32(5)},
+						},
+					},
+				},
+			},
+		}, columns)
+		stmt := Statement{
+			Conditions: OneOrMore{},
+			OrderBy: []OrderBy{
+				{Field: Field{Name: "level"}, Direction: Asc},
+				{Field: Field{Name: "score"}, Direction: Asc},
+			},
+		}
+
+		plan, err := tbl.PlanQuery(ctx, stmt)
+		require.NoError(t, err)
+
+		assert.True(t, plan.SortInMemory)
+		assert.Equal(t, ScanTypeSequential, plan.Scans[0].Type)
+	})
 }
@@ -161,6 +161,60 @@ func TestTryRangeScan(t *testing.T) {
 			},
 			true,
 		},
+		{
+			"BETWEEN uses inclusive lower and upper bounds",
+			Conditions{
+				FieldIsBetween(Field{Name: "id"}, int64(5), int64(10)),
+			},
+			Scan{
+				TableName:    "users",
+				Type:         ScanTypeIndexRange,
+				IndexName:    indexName,
+				IndexColumns: testColumns[0:1],
+				RangeCondition: RangeCondition{
+					Lower: &RangeBound{
+						Value:     int64(5),
+						Inclusive: true,
+					},
+					Upper: &RangeBound{
+						Value:     int64(10),
+						Inclusive: true,
+					},
+				},
+			},
+			true,
+		},
+		{
+			"BETWEEN is narrowed by a tighter bound",
+			Conditions{
+				FieldIsBetween(Field{Name: "id"}, int64(5), int64(10)),
+				FieldIsLess(Field{Name: "id"}, OperandInteger, int64(8)),
+			},
+			Scan{
+				TableName:    "users",
+				Type:         ScanTypeIndexRange,
+				IndexName:    indexName,
+				IndexColumns: testColumns[0:1],
+				RangeCondition: RangeCondition{
+					Lower: &RangeBound{
+						Value:     int64(5),
+						Inclusive: true,
+					},
+					Upper: &RangeBound{
+						Value: int64(8),
+					},
+				},
+			},
+			true,
+		},
+		{
+			"NOT BETWEEN does not qualify for range scan",
+			Conditions{
+				FieldIsNotBetween(Field{Name: "id"}, int64(5), int64(10)),
+			},
+			Scan{},
+			false,
+		},
 	}
 
 	for _, aTestCase := range testCases {
@@ -175,6 +229,70 @@ func TestTryRangeScan(t *testing.T) {
 	}
 }
 
+func TestTryRangeScan_LikePrefix(t *testing.T) {
+	t.Parallel()
+
+	indexInfo := IndexInfo{
+		Name:    "idx_email",
+		Columns: testColumns[1:2],
+	}
+	like := func(pattern string) Condition {
+		return FieldIsLike(Field{Name: "email"}, OperandQuotedString, NewTextPointer([]byte(pattern)))
+	}
+
+	t.Run("literal prefix bounds the range and keeps the pattern as a filter", func(t *testing.T) {
+		t.Parallel()
+
+		cond := like(`a\_b%c`)
+		scan, ok, err := tryRangeScan("users", indexInfo, Conditions{cond}, nil)
+		require.NoError(t, err)
+		require.True(t, ok)
+		assert.Equal(t, Scan{
+			TableName:    "users",
+			Type:         ScanTypeIndexRange,
+			IndexName:    "idx_email",
+			IndexColumns: testColumns[1:2],
+			RangeCondition: RangeCondition{
+				Lower: &RangeBound{Value: "a_b", Inclusive: true},
+				Upper: &RangeBound{Value: "a_b\xFF"},
+			},
+			Filters: OneOrMore{{cond}},
+		}, scan)
+	})
+
+	t.Run("tighter bound narrows the prefix range", func(t *testing.T) {
+		t.Parallel()
+
+		scan, ok, err := tryRangeScan("users", indexInfo, Conditions{
+			like("ab%"),
+			FieldIsGreaterOrEqual(Field{Name: "email"}, OperandQuotedString, NewTextPointer([]byte("abc"))),
+		}, nil)
+		require.NoError(t, err)
+		require.True(t, ok)
+		assert.Equal(t, &RangeBound{Value: "abc", Inclusive: true}, scan.RangeCondition.Lower)
+		assert.Equal(t, &RangeBound{Value: "ab\xFF"}, scan.RangeCondition.Upper)
+	})
+
+	for _, pattern := range []string{"%a", "_a%", ""} {
+		t.Run("no literal prefix in "+pattern, func(t *testing.T) {
+			t.Parallel()
+
+			_, ok, err := tryRangeScan("users", indexInfo, Conditions{like(pattern)}, nil)
+			require.NoError(t, err)
+			assert.False(t, ok)
+		})
+	}
+
+	t.Run("NOT LIKE does not qualify", func(t *testing.T) {
+		t.Parallel()
+
+		cond := FieldIsNotLike(Field{Name: "email"}, OperandQuotedString, NewTextPointer([]byte("a%")))
+		_, ok, err := tryRangeScan("users", indexInfo, Conditions{cond}, nil)
+		require.NoError(t, err)
+		assert.False(t, ok)
+	})
+}
+
 // TestTryRangeScanWithStats demonstrates how statistics influence
 // the query planner's decision to use index vs table scan for range queries
 func TestTryRangeScan_WithStats(t *testing.T) {
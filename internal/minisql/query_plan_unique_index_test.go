@@ -353,7 +353,7 @@ func TestTable_PlanQuery_SingleUniqueIndex(t *testing.T) {
 			},
 		},
 		{
-			"Ordered by index key descending - index scan",
+			"Ordered by nullable index key descending - sort in memory",
 			Statement{
 				Kind: Select,
 				OrderBy: []OrderBy{
@@ -366,13 +366,12 @@ func TestTable_PlanQuery_SingleUniqueIndex(t *testing.T) {
 			QueryPlan{
 				Scans: []Scan{
 					{
-						TableName:    testTableName,
-						Type:         ScanTypeIndexAll,
-						IndexName:    indexName,
-						IndexColumns: testColumns[1:2],
+						TableName: testTableName,
+						Type:      ScanTypeSequential,
 					},
 				},
-				SortReverse: true,
+				SortInMemory: true,
+				SortReverse:  true,
 				OrderBy: []OrderBy{
 					{
 						Field:     Field{Name: "email"},
@@ -569,3 +568,42 @@ func TestTable_PlanQuery_SingleUniqueIndex(t *testing.T) {
 		})
 	}
 }
+
+func TestTable_PlanQuery_OrderByNotNullUniqueIndex(t *testing.T) {
+	t.Parallel()
+
+	var (
+		indexName = "key__test_table__email"
+		columns   = []Column{testColumns[0], testColumns[1]}
+	)
+	columns[1].Nullable = false
+	table := NewTable(zap.NewNop(), nil, nil, testTableName, columns, 0, nil, WithUniqueIndex(
+		UniqueIndex{
+			IndexInfo: IndexInfo{
+				Name:    indexName,
+				Columns: columns[1:2],
+			},
+		},
+	))
+
+	for _, direction := range []Direction{Asc, Desc} {
+		stmt := Statement{
+			Kind:    Select,
+			OrderBy: []OrderBy{{Field: Field{Name: "email"}, Direction: direction}},
+		}
+		actual, err := table.PlanQuery(context.Background(), stmt)
+		require.NoError(t, err)
+		assert.Equal(t, QueryPlan{
+			Scans: []Scan{
+				{
+					TableName:    testTableName,
+					Type:         ScanTypeIndexAll,
+					IndexName:    indexName,
+					IndexColumns: columns[1:2],
+				},
+			},
+			SortReverse: direction == Desc,
+			OrderBy:     stmt.OrderBy,
+		}, actual)
+	}
+}
@@ -0,0 +1,292 @@
+package minisql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"go.uber.org/zap"
+
+	"github.com/RichardKnop/minisql/pkg/bitwise"
+)
+
+// ErrRecoverEncrypted is returned by Recover for encrypted databases: pages
+// cannot be inspected without the key.
+var ErrRecoverEncrypted = errors.New("cannot recover an encrypted database")
+
+// RecoveredTable describes a table rebuilt from an orphaned B+ tree root.
+type RecoveredTable struct {
+	Name     string
+	RootPage PageIndex
+	Rows     int
+	DDL      string
+}
+
+// SkippedRoot describes a root page that looked like a table but could not
+// be recovered.
+type SkippedRoot struct {
+	RootPage PageIndex
+	Reason   string
+}
+
+// RecoveryReport summarises the result of Recover.
+type RecoveryReport struct {
+	Tables       []RecoveredTable
+	Skipped      []SkippedRoot
+	TotalPages   uint32
+	HeaderReset  bool // the database header was unreadable and has been rewritten
+	WALRecovered bool // committed WAL frames were folded into the file first
+}
+
+// Recover is a best-effort repair tool for a database whose schema table is
+// damaged. It scans every page for table B+ tree roots, infers a column list
+// for each from the type codes stored in its cells, and writes a fresh schema
+// table that registers every root found as recovered_<root page>.
+//
+// Column names (c1, c2, ...) and kinds are reconstructed from the data, so
+// constraints, primary keys and defaults are lost and every column is
+// nullable. Index pages are not recovered; recreate indexes once the data has
+// been copied to a healthy database. The database must not be open while
+// Recover runs.
+func Recover(ctx context.Context, logger *zap.Logger, dbFilePath string, parser Parser) (RecoveryReport, error) {
+	var report RecoveryReport
+
+	dbFile, err := os.OpenFile(dbFilePath, os.O_RDWR, 0o600)
+	if err != nil {
+		return report, err
+	}
+
+	// Fold committed WAL frames into the file so the scan sees the latest
+	// version of every page, and so a stale page 0 is not replayed over the
+	// rebuilt schema table on the next open.
+	report.WALRecovered, err = RecoverFromWAL(dbFilePath, dbFile, PageSize)
+	if err != nil {
+		return report, errors.Join(err, dbFile.Close())
+	}
+
+	info, err := dbFile.Stat()
+	if err != nil {
+		return report, errors.Join(err, dbFile.Close())
+	}
+	if info.Size() == 0 || info.Size()%PageSize != 0 {
+		return report, errors.Join(fmt.Errorf("db file size %d is not a positive multiple of page size", info.Size()), dbFile.Close())
+	}
+	report.TotalPages = uint32(info.Size() / PageSize)
+
+	var (
+		dbHeader  DatabaseHeader
+		headerBuf [RootPageConfigSize]byte
+	)
+	if _, err := dbFile.ReadAt(headerBuf[:], 0); err != nil {
+		return report, errors.Join(err, dbFile.Close())
+	}
+	if err := UnmarshalDatabaseHeader(headerBuf[:], &dbHeader); err != nil {
+		// The free list is lost with the header; free pages are merely leaked
+		// until the next VACUUM.
+		logger.Warn("database header unreadable, rewriting it", zap.Error(err))
+		dbHeader = DatabaseHeader{}
+		report.HeaderReset = true
+		if err := dbHeader.MarshalTo(headerBuf[:]); err != nil {
+			return report, errors.Join(err, dbFile.Close())
+		}
+		if _, err := dbFile.WriteAt(headerBuf[:], 0); err != nil {
+			return report, errors.Join(err, dbFile.Close())
+		}
+	}
+	if dbHeader.EncryptionMode != EncryptionModeNone {
+		return report, errors.Join(ErrRecoverEncrypted, dbFile.Close())
+	}
+
+	var schemas []Schema
+	buf := make([]byte, PageSize)
+	for pageIdx := PageIndex(1); uint32(pageIdx) < report.TotalPages; pageIdx++ {
+		if _, err := dbFile.ReadAt(buf, int64(pageIdx)*PageSize); err != nil {
+			return report, errors.Join(err, dbFile.Close())
+		}
+		if !isTableRootPage(buf) || verifyPageChecksum(buf, pageIdx) != nil {
+			continue
+		}
+
+		recovered, err := recoverTable(dbFile, pageIdx)
+		if err != nil {
+			report.Skipped = append(report.Skipped, SkippedRoot{RootPage: pageIdx, Reason: err.Error()})
+			continue
+		}
+		report.Tables = append(report.Tables, recovered)
+		schemas = append(schemas, Schema{
+			Type:     SchemaTable,
+			Name:     recovered.Name,
+			RootPage: recovered.RootPage,
+			DDL:      recovered.DDL,
+		})
+	}
+
+	// Replace page 0 with an empty schema table root.
+	pager, err := NewPager(dbFile, PageSize, 0)
+	if err != nil {
+		return report, errors.Join(err, dbFile.Close())
+	}
+	schemaRoot := NewLeafNode()
+	schemaRoot.Header.IsRoot = true
+	if err := pager.writeRootPage(&Page{Index: 0, LeafNode: schemaRoot}, &dbHeader); err != nil {
+		return report, errors.Join(err, dbFile.Close())
+	}
+
+	db, err := NewDatabase(ctx, logger, dbFilePath, parser, pager, pager, nil)
+	if err != nil {
+		return report, errors.Join(err, dbFile.Close())
+	}
+	err = db.txManager.ExecuteInTransaction(ctx, func(ctx context.Context) error {
+		if err := db.insertSchema(ctx, Schema{
+			Type:     SchemaTable,
+			Name:     SchemaTableName,
+			RootPage: 0,
+			DDL:      MainTableSQL,
+		}); err != nil {
+			return err
+		}
+		for _, schema := range schemas {
+			if err := db.insertSchema(ctx, schema); err != nil {
+				return fmt.Errorf("register %s: %w", schema.Name, err)
+			}
+		}
+		return nil
+	})
+	return report, errors.Join(err, db.Close())
+}
+
+// isTableRootPage reports whether buf holds a leaf or internal table page
+// with the root flag set.
+func isTableRootPage(buf []byte) bool {
+	return (buf[0] == PageTypeLeaf || buf[0] == PageTypeInternal) && buf[1]&headerFlagRoot != 0
+}
+
+// recoverTable walks the B+ tree rooted at root and builds a CREATE TABLE
+// statement matching the cells found in its leaves.
+func recoverTable(dbFile *os.File, root PageIndex) (RecoveredTable, error) {
+	recovered := RecoveredTable{
+		Name:     fmt.Sprintf("recovered_%d", root),
+		RootPage: root,
+	}
+
+	var (
+		typeCodes []TypeCode
+		columns   []Column
+		visited   = map[PageIndex]struct{}{}
+		stack     = []PageIndex{root}
+		buf       = make([]byte, PageSize)
+	)
+	for len(stack) > 0 {
+		pageIdx := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if _, ok := visited[pageIdx]; ok {
+			return recovered, fmt.Errorf("page %d is reachable twice", pageIdx)
+		}
+		visited[pageIdx] = struct{}{}
+
+		if _, err := dbFile.ReadAt(buf, int64(pageIdx)*PageSize); err != nil {
+			return recovered, fmt.Errorf("page %d: %w", pageIdx, err)
+		}
+		if err := verifyPageChecksum(buf, pageIdx); err != nil {
+			return recovered, err
+		}
+		if pageIdx != root && buf[1]&headerFlagRoot != 0 {
+			return recovered, fmt.Errorf("page %d is the root of another tree", pageIdx)
+		}
+
+		switch buf[0] {
+		case PageTypeInternal:
+			node := NewInternalNode()
+			if _, err := node.Unmarshal(buf); err != nil {
+				return recovered, fmt.Errorf("page %d: %w", pageIdx, err)
+			}
+			for _, iCell := range node.ICells[:node.Header.KeysNum] {
+				stack = append(stack, iCell.Child)
+			}
+			stack = append(stack, node.Header.RightChild)
+		case PageTypeLeaf:
+			leaf := NewLeafNode()
+			if _, err := leaf.Unmarshal(buf); err != nil {
+				return recovered, fmt.Errorf("page %d: %w", pageIdx, err)
+			}
+			for _, cell := range leaf.Cells {
+				if err := inferCellColumns(cell, &typeCodes, &columns); err != nil {
+					return recovered, fmt.Errorf("page %d: %w", pageIdx, err)
+				}
+			}
+			recovered.Rows += len(leaf.Cells)
+		default:
+			return recovered, fmt.Errorf("page %d is not a table page", pageIdx)
+		}
+	}
+
+	if len(columns) == 0 {
+		return recovered, fmt.Errorf("no rows to infer columns from")
+	}
+	for i := range columns {
+		columns[i].Name = fmt.Sprintf("c%d", i+1)
+		columns[i].Nullable = true
+		if columns[i].Kind == 0 {
+			// Every row stored NULL here, e.g. a dropped column.
+			columns[i].Kind = Text
+		}
+	}
+	recovered.DDL = Statement{Kind: CreateTable, TableName: recovered.Name, Columns: columns}.DDL()
+	return recovered, nil
+}
+
+// inferCellColumns widens columns to the cell's column count and fills in the
+// kind of every position still unknown from the cell's first non-NULL value.
+func inferCellColumns(cell Cell, typeCodes *[]TypeCode, columns *[]Column) error {
+	for len(*columns) < int(cell.ColumnCount) {
+		*typeCodes = append(*typeCodes, TypeCodeNull)
+		*columns = append(*columns, Column{})
+	}
+	view := NewRowView(nil, cell)
+	for i := 0; i < int(cell.ColumnCount); i++ {
+		tc := TypeCode(cell.TypeCodes[i])
+		if tc == TypeCodeNull || bitwise.IsSet(cell.NullBitmask, i) {
+			continue
+		}
+		if known := (*typeCodes)[i]; known != TypeCodeNull {
+			if known != tc {
+				return fmt.Errorf("column %d holds both type code %d and %d", i+1, known, tc)
+			}
+			continue
+		}
+
+		col := &(*columns)[i]
+		switch tc {
+		case TypeCodeBool:
+			*col = Column{Kind: Boolean, Size: 1}
+		case TypeCodeInt4:
+			*col = Column{Kind: Int4, Size: 4}
+		case TypeCodeInt8:
+			*col = Column{Kind: Int8, Size: 8}
+		case TypeCodeReal:
+			*col = Column{Kind: Real, Size: 4}
+		case TypeCodeDouble:
+			*col = Column{Kind: Double, Size: 8}
+		case TypeCodeTimestamp:
+			*col = Column{Kind: Timestamp, Size: 8}
+		case TypeCodeUUID:
+			*col = Column{Kind: UUID, Size: 16}
+		case TypeCodeText:
+			*col = Column{Kind: Text}
+		case TypeCodeVector:
+			offset, err := view.offsetOf(i)
+			if err != nil {
+				return err
+			}
+			if offset+4 > len(cell.Value) {
+				return fmt.Errorf("column %d: truncated vector", i+1)
+			}
+			*col = Column{Kind: Vector, Size: unmarshalUint32(cell.Value, uint64(offset))}
+		default:
+			return fmt.Errorf("column %d: unsupported type code %d", i+1, tc)
+		}
+		(*typeCodes)[i] = tc
+	}
+	return nil
+}
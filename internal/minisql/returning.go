@@ -19,6 +19,19 @@ func returningColumns(fields []Field, tableColumns []Column) []Column {
 	return out
 }
 
+// affectedKey returns the AffectedKey of a full table row.
+func (t *Table) affectedKey(row Row) AffectedKey {
+	if !t.HasPrimaryKey() {
+		return AffectedKey{{Value: int64(row.Key), Valid: true}}
+	}
+	key := make(AffectedKey, 0, len(t.PrimaryKey.Columns))
+	for _, col := range t.PrimaryKey.Columns {
+		val, _ := row.GetValue(col.Name)
+		key = append(key, val)
+	}
+	return key
+}
+
 // applyReturning projects rows through RETURNING fields and populates result.
 // When fields is empty the function is a no-op (RETURNING clause absent).
 func applyReturning(result *StatementResult, rows []Row, fields []Field, tableColumns []Column) error {
@@ -1,9 +1,11 @@
 package minisql
 
 import (
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
 
@@ -115,3 +117,110 @@ func TestProjectReturning(t *testing.T) {
 		assert.False(t, got.Values[0].Valid)
 	})
 }
+
+func TestTable_AffectedKeys(t *testing.T) {
+	t.Parallel()
+
+	columns := []Column{
+		{Kind: Int8, Size: 8, Name: "id"},
+		{Kind: Int8, Size: 8, Name: "n", Nullable: true},
+	}
+	withPK := Statement{
+		Kind:       CreateTable,
+		TableName:  "with_pk",
+		Columns:    columns,
+		PrimaryKey: NewPrimaryKey(PrimaryKeyName("with_pk"), columns[0:1], false),
+	}
+	withoutPK := Statement{
+		Kind:      CreateTable,
+		TableName: "without_pk",
+		Columns:   columns,
+	}
+
+	mockParser := new(MockParser)
+	mockParser.On("Parse", mock.Anything, withPK.DDL()).Return([]Statement{withPK}, nil)
+	mockParser.On("Parse", mock.Anything, withoutPK.DDL()).Return([]Statement{withoutPK}, nil)
+
+	db, _ := newVacuumTestDB(t, mockParser)
+	defer func() { require.NoError(t, db.Close()) }()
+
+	idField := Field{Name: "id"}
+	exec := func(ctx context.Context, t *testing.T, builder interface{ Build() (Statement, error) }) StatementResult {
+		stmt, err := builder.Build()
+		require.NoError(t, err)
+		result, err := db.ExecuteStatement(ctx, stmt)
+		require.NoError(t, err)
+		return result
+	}
+	pkKeys := func(ids ...int64) []AffectedKey {
+		keys := make([]AffectedKey, 0, len(ids))
+		for _, id := range ids {
+			keys = append(keys, AffectedKey{{Value: id, Valid: true}})
+		}
+		return keys
+	}
+
+	execInTx(t, db, func(ctx context.Context) {
+		for _, stmt := range []Statement{withPK, withoutPK} {
+			_, err := db.ExecuteStatement(ctx, stmt)
+			require.NoError(t, err)
+		}
+	})
+	execInTx(t, db, func(ctx context.Context) {
+		for _, tableName := range []string{"with_pk", "without_pk"} {
+			builder := InsertInto(tableName).Columns("id", "n")
+			// Insert in descending order so primary key order differs from
+			// insertion order.
+			for id := 100; id > 0; id-- {
+				builder.Values(id, id)
+			}
+			exec(ctx, t, builder)
+		}
+	})
+
+	t.Run("ranged DELETE returns deleted primary keys in order", func(t *testing.T) {
+		execInTx(t, db, func(ctx context.Context) {
+			// The range is served by a primary key range scan, so rows are
+			// deleted, and reported, in key order.
+			result := exec(ctx, t, DeleteFrom("with_pk").Where(
+				FieldIsGreaterOrEqual(idField, OperandInteger, int64(20)),
+				FieldIsLessOrEqual(idField, OperandInteger, int64(25)),
+			).ReturningKeys())
+			assert.Equal(t, 6, result.RowsAffected)
+			assert.Equal(t, pkKeys(20, 21, 22, 23, 24, 25), result.AffectedKeys)
+		})
+		assert.Equal(t, 94, countRowsInDB(t, db, "with_pk"))
+	})
+
+	t.Run("UPDATE returns keys of changed rows", func(t *testing.T) {
+		execInTx(t, db, func(ctx context.Context) {
+			result := exec(ctx, t, UpdateTable("with_pk").Set("n", int64(0)).Where(FieldIsGreater(idField, OperandInteger, int64(97))).ReturningKeys())
+			assert.Equal(t, pkKeys(98, 99, 100), result.AffectedKeys)
+
+			// Rows whose values do not change are not reported.
+			result = exec(ctx, t, UpdateTable("with_pk").Set("n", int64(0)).Where(FieldIsGreater(idField, OperandInteger, int64(96))).ReturningKeys())
+			assert.Equal(t, pkKeys(97), result.AffectedKeys)
+		})
+	})
+
+	t.Run("tables without a primary key return row IDs", func(t *testing.T) {
+		execInTx(t, db, func(ctx context.Context) {
+			result := exec(ctx, t, DeleteFrom("without_pk").Where(FieldIsLess(idField, OperandInteger, int64(4))).ReturningKeys())
+			// Rows were inserted with descending ids starting from row ID 0,
+			// so ids 3, 2 and 1 live at row IDs 97, 98 and 99.
+			assert.Equal(t, []AffectedKey{
+				{{Value: int64(97), Valid: true}},
+				{{Value: int64(98), Valid: true}},
+				{{Value: int64(99), Valid: true}},
+			}, result.AffectedKeys)
+		})
+	})
+
+	t.Run("keys are not collected unless requested", func(t *testing.T) {
+		execInTx(t, db, func(ctx context.Context) {
+			result := exec(ctx, t, DeleteFrom("with_pk").Where(FieldIsLess(idField, OperandInteger, int64(5))))
+			assert.Equal(t, 4, result.RowsAffected)
+			assert.Nil(t, result.AffectedKeys)
+		})
+	})
+}
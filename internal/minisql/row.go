@@ -28,7 +28,7 @@ type Row struct {
 }
 
 func maxCells(rowSize uint64) uint32 {
-	// base header is +6, leaf/internal header +8
+	// base header is +6, leaf header +12
 	// and uint64 row ID per cell
 	// hence we divide by rowSize + 8 + 8
 	return uint32((PageSize - headerSize()) / (rowSize + 8 + 8))
@@ -57,6 +57,10 @@ func (r Row) Size() uint64 {
 			size += 8 // 4-byte dims + 4-byte first overflow page index
 			continue
 		}
+		if codec, ok := customValueCodec(col.Kind); ok {
+			size += customValueSize(codec, r.Values[i].Value)
+			continue
+		}
 		if !col.Kind.IsText() {
 			size += uint64(col.Size)
 			continue
@@ -88,6 +92,28 @@ func (r Row) Size() uint64 {
 	return size
 }
 
+// MemorySize estimates the bytes a materialised row holds in memory. It
+// differs from Size for overflow TEXT and VECTOR values: Size counts only the
+// on-page pointer, while MemorySize counts the assembled payload, which is
+// what sorts and result buffers actually keep alive.
+func (r Row) MemorySize() uint64 {
+	size := r.Size()
+	for i, col := range r.Columns {
+		if i >= len(r.Values) || !r.Values[i].Valid {
+			continue
+		}
+		switch v := r.Values[i].Value.(type) {
+		case TextPointer:
+			if col.Kind.IsText() && uint64(len(v.Data)) > MaxInlineVarchar {
+				size += uint64(len(v.Data))
+			}
+		case VectorPointer:
+			size += uint64(len(v.Data)) * 4
+		}
+	}
+	return size
+}
+
 // OnlyFields returns a new Row containing only the specified fields.
 // The result is accessed positionally (Values[i]) by all downstream consumers
 // (Rows.Next, rowDistinctKey, selectGroupBy, deduplicateRows).  Name-based
@@ -413,6 +439,16 @@ func (r Row) Marshal() ([]byte, error) {
 			}
 			vp.Marshal(buf, offset)
 			offset += 8
+		default:
+			codec, ok := customValueCodec(col.Kind)
+			if !ok {
+				return nil, fmt.Errorf("unsupported column kind %s for column %s", col.Kind, col.Name)
+			}
+			n, err := marshalCustomValue(codec, col, buf, r.Values[i].Value, offset)
+			if err != nil {
+				return nil, err
+			}
+			offset += n
 		}
 	}
 
@@ -536,25 +572,19 @@ func (r Row) checkConditionsWithColumnIndexes(condGroup Conditions, columnIndexe
 // compareScalarToOperand compares a computed value (e.g., the result of a JSON
 // path expression) against the right-hand operand of a WHERE condition.
 func compareScalarToOperand(val any, op2 Operand, operator Operator) (bool, error) {
+	switch operator {
+	case IsNull:
+		return val == nil, nil
+	case IsNotNull:
+		return val != nil, nil
+	}
 	if val == nil {
-		switch operator {
-		case Eq:
-			return op2.Type == OperandNull, nil
-		case Ne:
-			return op2.Type != OperandNull, nil
-		default:
-			return false, nil
-		}
+		return operator == Ne && op2.Type != OperandNull, nil
 	}
 	if op2.Type == OperandNull {
-		switch operator {
-		case Eq:
-			return false, nil
-		case Ne:
-			return true, nil
-		default:
-			return false, nil
-		}
+		// Any other comparison with NULL (e.g. a scalar subquery that
+		// returned no rows) is unknown, so it never matches.
+		return false, nil
 	}
 	switch v1 := val.(type) {
 	case int64:
@@ -568,7 +598,7 @@ func compareScalarToOperand(val any, op2 Operand, operator Operator) (bool, erro
 	case TextPointer:
 		return compareText(v1, op2.Value.(TextPointer), operator)
 	case bool:
-		return compareBoolean(v1, op2.Value.(bool), operator)
+		return compareBooleanValues(v1, op2.Value, operator)
 	default:
 		return false, fmt.Errorf("unsupported expression result type %T", val)
 	}
@@ -601,6 +631,9 @@ func (r Row) checkCondition(cond Condition) (bool, error) {
 	}
 
 	// both left and right are literal values, compare them
+	if cond.Operator == IsNull || cond.Operator == IsNotNull {
+		return compareNull(cond.Operand1.Type != OperandNull, cond.Operator), nil
+	}
 	return cond.Operand1.Value == cond.Operand2.Value, nil
 }
 
@@ -631,6 +664,9 @@ func (r Row) checkConditionWithColumnIndexes(cond Condition, columnIndexes map[s
 	}
 
 	// both left and right are literal values, compare them
+	if cond.Operator == IsNull || cond.Operator == IsNotNull {
+		return compareNull(cond.Operand1.Type != OperandNull, cond.Operator), nil
+	}
 	return cond.Operand1.Value == cond.Operand2.Value, nil
 }
 
@@ -659,14 +695,7 @@ func (r Row) compareFieldValue(fieldOperand, valueOperand Operand, operator Oper
 
 	switch valueOperand.Type {
 	case OperandNull:
-		switch operator {
-		case Eq:
-			return !fieldValue.Valid, nil
-		case Ne:
-			return fieldValue.Valid, nil
-		default:
-			return false, errors.New("only '=' and '!=' operators supported when comparing against NULL")
-		}
+		return compareNull(fieldValue.Valid, operator), nil
 	case OperandList:
 		switch operator {
 		case In, NotIn:
@@ -724,6 +753,9 @@ func (r Row) compareFieldValue(fieldOperand, valueOperand Operand, operator Oper
 			if !ok || len(list) != 2 {
 				return false, errors.New("BETWEEN requires exactly 2 bounds")
 			}
+			if !fieldValue.Valid {
+				return false, nil // NULL matches neither BETWEEN nor NOT BETWEEN
+			}
 			var (
 				inRange bool
 				err     error
@@ -769,7 +801,7 @@ func (r Row) compareFieldValue(fieldOperand, valueOperand Operand, operator Oper
 
 	switch col.Kind {
 	case Boolean:
-		return compareBoolean(fieldValue.Value.(bool), valueOperand.Value.(bool), operator)
+		return compareBooleanValues(fieldValue.Value, valueOperand.Value, operator)
 	case Int4:
 		// Int values from parser always come back as int64, int4 row data
 		// will come back as int32 and int8 as int64
@@ -833,14 +865,7 @@ func (r Row) compareFieldValueWithColumnIndexes(fieldOperand, valueOperand Opera
 
 	switch valueOperand.Type {
 	case OperandNull:
-		switch operator {
-		case Eq:
-			return !fieldValue.Valid, nil
-		case Ne:
-			return fieldValue.Valid, nil
-		default:
-			return false, errors.New("only '=' and '!=' operators supported when comparing against NULL")
-		}
+		return compareNull(fieldValue.Valid, operator), nil
 	case OperandList:
 		switch operator {
 		case In, NotIn:
@@ -898,6 +923,9 @@ func (r Row) compareFieldValueWithColumnIndexes(fieldOperand, valueOperand Opera
 			if !ok || len(list) != 2 {
 				return false, errors.New("BETWEEN requires exactly 2 bounds")
 			}
+			if !fieldValue.Valid {
+				return false, nil // NULL matches neither BETWEEN nor NOT BETWEEN
+			}
 			var (
 				inRange bool
 				err     error
@@ -943,7 +971,7 @@ func (r Row) compareFieldValueWithColumnIndexes(fieldOperand, valueOperand Opera
 
 	switch col.Kind {
 	case Boolean:
-		return compareBoolean(fieldValue.Value.(bool), valueOperand.Value.(bool), operator)
+		return compareBooleanValues(fieldValue.Value, valueOperand.Value, operator)
 	case Int4:
 		return compareInt4(int64(fieldValue.Value.(int32)), valueOperand.Value.(int64), operator)
 	case Int8:
@@ -1010,7 +1038,7 @@ func (r Row) compareFields(field1, field2 Operand, operator Operator) (bool, err
 
 	switch col1.Kind {
 	case Boolean:
-		return compareBoolean(value1.Value.(bool), value2.Value.(bool), operator)
+		return compareBooleanValues(value1.Value, value2.Value, operator)
 	case Int4:
 		return compareInt4(int64(value1.Value.(int32)), int64(value2.Value.(int32)), operator)
 	case Int8:
@@ -1090,7 +1118,7 @@ func (r Row) compareFieldsWithColumnIndexes(field1, field2 Operand, operator Ope
 
 	switch col1.Kind {
 	case Boolean:
-		return compareBoolean(value1.Value.(bool), value2.Value.(bool), operator)
+		return compareBooleanValues(value1.Value, value2.Value, operator)
 	case Int4:
 		return compareInt4(int64(value1.Value.(int32)), int64(value2.Value.(int32)), operator)
 	case Int8:
@@ -1191,7 +1219,16 @@ func UnmarshalRow(buf []byte, columns []Column, key RowID, nullBitmask uint64) (
 			values[i] = OptionalValue{Value: vp, Valid: true}
 			offset += 8
 		default:
-			return Row{}, fmt.Errorf("UnmarshalRow: unsupported column kind %s", col.Kind)
+			codec, ok := customValueCodec(col.Kind)
+			if !ok {
+				return Row{}, fmt.Errorf("UnmarshalRow: unsupported column kind %s", col.Kind)
+			}
+			value, n, err := unmarshalCustomValue(codec, buf, offset)
+			if err != nil {
+				return Row{}, fmt.Errorf("UnmarshalRow: column %d (%s): %w", i, col.Name, err)
+			}
+			values[i] = OptionalValue{Value: value, Valid: true}
+			offset += n
 		}
 	}
 	return Row{Columns: columns, Values: values, Key: key}, nil
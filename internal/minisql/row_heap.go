@@ -37,17 +37,14 @@ func (h *rowHeap) Less(i, j int) bool {
 			continue
 		}
 
-		cmp := compareValues(valI, valJ)
+		cmp := clause.compare(valI, valJ)
 
 		if cmp == 0 {
 			continue // Equal, check next ORDER BY column
 		}
 
-		// For ASC: keep largest at top (min-heap inverted), so reverse comparison
-		// For DESC: keep smallest at top (min-heap inverted), so keep normal comparison
-		if clause.Direction == Desc {
-			return cmp < 0
-		}
+		// Keep the row that sorts last at the top (min-heap inverted), so
+		// reverse the comparison.
 		return cmp > 0
 	}
 	return false
@@ -94,19 +91,14 @@ func (h *rowHeap) PushRow(row Row) {
 				continue
 			}
 
-			cmp := compareValues(valNew, valRoot)
+			cmp := clause.compare(valNew, valRoot)
 
 			if cmp == 0 {
 				continue // Equal, check next column
 			}
 
-			// For ASC (or unset): replace root if new < root (we keep smallest values)
-			// For DESC: replace root if new > root (we keep largest values)
-			if clause.Direction == Desc {
-				shouldReplace = cmp > 0
-			} else {
-				shouldReplace = cmp < 0
-			}
+			// Replace root if the new row sorts before it (we keep the first rows)
+			shouldReplace = cmp < 0
 			break
 		}
 
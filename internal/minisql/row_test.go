@@ -771,14 +771,15 @@ func TestRow_CompareFieldValueWithColumnIndexes_Errors(t *testing.T) {
 		assert.Error(t, err)
 	})
 
-	t.Run("null comparison with unsupported operator", func(t *testing.T) {
-		_, err := row.compareFieldValueWithColumnIndexes(
+	t.Run("comparison with NULL other than IS NULL never matches", func(t *testing.T) {
+		ok, err := row.compareFieldValueWithColumnIndexes(
 			Operand{Type: OperandField, Value: Field{Name: "id"}},
 			Operand{Type: OperandNull},
 			Gt,
 			columnIndexes,
 		)
-		assert.Error(t, err)
+		require.NoError(t, err)
+		assert.False(t, ok)
 	})
 
 	t.Run("IN not supported for boolean", func(t *testing.T) {
@@ -1018,7 +1019,7 @@ func TestRow_CompareFieldValue_IsNull(t *testing.T) {
 		row := NewRowWithValues(cols, []OptionalValue{{Valid: false}})
 		cond := Condition{
 			Operand1: Operand{Type: OperandField, Value: Field{Name: "x"}},
-			Operator: Eq,
+			Operator: IsNull,
 			Operand2: Operand{Type: OperandNull},
 		}
 		ok, err := row.checkCondition(cond)
@@ -1030,7 +1031,7 @@ func TestRow_CompareFieldValue_IsNull(t *testing.T) {
 		row := NewRowWithValues(cols, []OptionalValue{{Value: int64(1), Valid: true}})
 		cond := Condition{
 			Operand1: Operand{Type: OperandField, Value: Field{Name: "x"}},
-			Operator: Eq,
+			Operator: IsNull,
 			Operand2: Operand{Type: OperandNull},
 		}
 		ok, err := row.checkCondition(cond)
@@ -1042,25 +1043,44 @@ func TestRow_CompareFieldValue_IsNull(t *testing.T) {
 		row := NewRowWithValues(cols, []OptionalValue{{Value: int64(1), Valid: true}})
 		cond := Condition{
 			Operand1: Operand{Type: OperandField, Value: Field{Name: "x"}},
-			Operator: Ne,
+			Operator: IsNotNull,
 			Operand2: Operand{Type: OperandNull},
 		}
 		ok, err := row.checkCondition(cond)
 		require.NoError(t, err)
 		assert.True(t, ok)
 	})
+
+	t.Run("= NULL does not match null field", func(t *testing.T) {
+		row := NewRowWithValues(cols, []OptionalValue{{Valid: false}})
+		cond := Condition{
+			Operand1: Operand{Type: OperandField, Value: Field{Name: "x"}},
+			Operator: Eq,
+			Operand2: Operand{Type: OperandNull},
+		}
+		ok, err := row.checkCondition(cond)
+		require.NoError(t, err)
+		assert.False(t, ok)
+	})
 }
 
 func TestCompareScalarToOperand(t *testing.T) {
 	t.Parallel()
 
-	t.Run("nil val eq null operand", func(t *testing.T) {
+	t.Run("nil val IS NULL", func(t *testing.T) {
 		t.Parallel()
-		ok, err := compareScalarToOperand(nil, Operand{Type: OperandNull}, Eq)
+		ok, err := compareScalarToOperand(nil, Operand{Type: OperandNull}, IsNull)
 		require.NoError(t, err)
 		assert.True(t, ok)
 	})
 
+	t.Run("nil val eq null operand is false", func(t *testing.T) {
+		t.Parallel()
+		ok, err := compareScalarToOperand(nil, Operand{Type: OperandNull}, Eq)
+		require.NoError(t, err)
+		assert.False(t, ok)
+	})
+
 	t.Run("nil val ne null operand is false", func(t *testing.T) {
 		t.Parallel()
 		ok, err := compareScalarToOperand(nil, Operand{Type: OperandNull}, Ne)
@@ -1096,10 +1116,17 @@ func TestCompareScalarToOperand(t *testing.T) {
 		assert.False(t, ok)
 	})
 
-	t.Run("non-null val ne null operand is true", func(t *testing.T) {
+	t.Run("non-null val ne null operand is false", func(t *testing.T) {
 		t.Parallel()
 		ok, err := compareScalarToOperand(int64(5), Operand{Type: OperandNull}, Ne)
 		require.NoError(t, err)
+		assert.False(t, ok)
+	})
+
+	t.Run("non-null val IS NOT NULL", func(t *testing.T) {
+		t.Parallel()
+		ok, err := compareScalarToOperand(int64(5), Operand{Type: OperandNull}, IsNotNull)
+		require.NoError(t, err)
 		assert.True(t, ok)
 	})
 
@@ -1208,7 +1235,7 @@ func TestRow_CompareFieldValue_UUID(t *testing.T) {
 		row := NewRowWithValues(cols, []OptionalValue{{Valid: false}})
 		cond := Condition{
 			Operand1: Operand{Type: OperandField, Value: Field{Name: "id"}},
-			Operator: Eq,
+			Operator: IsNull,
 			Operand2: Operand{Type: OperandNull},
 		}
 		ok, err := row.checkCondition(cond)
@@ -197,7 +197,15 @@ func (rv RowView) ValueAt(idx int) (OptionalValue, error) {
 		vp.Unmarshal(rv.value, uint64(offset))
 		return OptionalValue{Value: vp, Valid: true}, nil
 	default:
-		return OptionalValue{}, fmt.Errorf("unsupported column kind %s", col.Kind)
+		codec, ok := customValueCodec(col.Kind)
+		if !ok {
+			return OptionalValue{}, fmt.Errorf("unsupported column kind %s", col.Kind)
+		}
+		value, _, err := unmarshalCustomValue(codec, rv.value, uint64(offset))
+		if err != nil {
+			return OptionalValue{}, fmt.Errorf("column %d (%s): %w", idx, col.Name, err)
+		}
+		return OptionalValue{Value: value, Valid: true}, nil
 	}
 }
 
@@ -508,18 +516,11 @@ func (rv RowView) offsetOf(targetIdx int) (int, error) {
 		if bitwise.IsSet(rv.nullBitmask, i) {
 			continue // NULL: no bytes in value area
 		}
-		tc := TypeCode(rv.typeCodes[i])
-		sz := typeCodeFixedSize(tc)
-		if sz >= 0 {
-			offset += sz
-		} else {
-			// TypeCodeText: 4-byte length prefix + data.
-			if offset+varcharLengthPrefixSize > len(rv.value) {
-				return 0, fmt.Errorf("text column %d offset %d exceeds encoded row length %d", i, offset, len(rv.value))
-			}
-			length := unmarshalUint32(rv.value, uint64(offset))
-			offset += int(TextPointer{Length: length}.Size())
+		sz, err := encodedValueSize(TypeCode(rv.typeCodes[i]), rv.value, uint64(offset))
+		if err != nil {
+			return 0, fmt.Errorf("column %d: %w", i, err)
 		}
+		offset += int(sz)
 	}
 	if offset > len(rv.value) {
 		return 0, fmt.Errorf("column offset %d exceeds encoded row length %d", offset, len(rv.value))
@@ -98,19 +98,10 @@ func compileSimpleRowViewFieldValueCondition(
 	}
 	col := columns[colIdx]
 	if valueOperand.Type == OperandNull {
-		switch operator {
-		case Eq:
-			return func(view RowView) (bool, error) {
-				return view.IsNull(colIdx)
-			}, true
-		case Ne:
-			return func(view RowView) (bool, error) {
-				isNull, err := view.IsNull(colIdx)
-				return !isNull, err
-			}, true
-		default:
-			return nil, false
-		}
+		return func(view RowView) (bool, error) {
+			isNull, err := view.IsNull(colIdx)
+			return compareNull(!isNull, operator), err
+		}, true
 	}
 	switch col.Kind {
 	case Boolean:
@@ -266,6 +257,9 @@ func (rv RowView) checkConditionWithColumnIndexes(ctx context.Context, pager TxP
 		return rv.compareFieldsWithColumnIndexes(ctx, pager, cond.Operand1, cond.Operand2, cond.Operator, columnIndexes)
 	}
 
+	if cond.Operator == IsNull || cond.Operator == IsNotNull {
+		return compareNull(cond.Operand1.Type != OperandNull, cond.Operator), nil
+	}
 	return cond.Operand1.Value == cond.Operand2.Value, nil
 }
 
@@ -290,14 +284,7 @@ func (rv RowView) compareFieldValueWithColumnIndexes(ctx context.Context, pager
 
 	switch valueOperand.Type {
 	case OperandNull:
-		switch operator {
-		case Eq:
-			return !fieldValue.Valid, nil
-		case Ne:
-			return fieldValue.Valid, nil
-		default:
-			return false, errors.New("only '=' and '!=' operators supported when comparing against NULL")
-		}
+		return compareNull(fieldValue.Valid, operator), nil
 	case OperandList:
 		return compareRowViewFieldList(col.Kind, fieldValue, valueOperand, operator)
 	}
@@ -367,6 +354,9 @@ func compareRowViewFieldList(kind ColumnKind, fieldValue OptionalValue, valueOpe
 		}
 		return !found, err
 	case Between, NotBetween:
+		if !fieldValue.Valid {
+			return false, nil // NULL matches neither BETWEEN nor NOT BETWEEN
+		}
 		inRange, err := isRowViewValueBetween(kind, fieldValue, valueOperand.Value)
 		if err != nil {
 			return false, err
@@ -381,13 +371,18 @@ func compareRowViewFieldList(kind ColumnKind, fieldValue OptionalValue, valueOpe
 }
 
 func compareRowViewFieldValue(kind ColumnKind, fieldValue OptionalValue, valueOperand Operand, operator Operator) (bool, error) {
-	if (operator == Like || operator == NotLike) && kind != Varchar && kind != Text {
-		return false, errors.New("LIKE / NOT LIKE operator only supported for TEXT and VARCHAR columns")
+	if operator == Like || operator == NotLike {
+		if kind != Varchar && kind != Text {
+			return false, errors.New("LIKE / NOT LIKE operator only supported for TEXT and VARCHAR columns")
+		}
+		if _, ok := valueOperand.Value.(TextPointer); !ok {
+			return false, errors.New("LIKE / NOT LIKE pattern must be a string")
+		}
 	}
 
 	switch kind {
 	case Boolean:
-		return compareBoolean(fieldValue.Value.(bool), valueOperand.Value.(bool), operator)
+		return compareBooleanValues(fieldValue.Value, valueOperand.Value, operator)
 	case Int4:
 		return compareInt4(int64(fieldValue.Value.(int32)), valueOperand.Value.(int64), operator)
 	case Int8:
@@ -418,7 +413,7 @@ func compareRowViewFieldValue(kind ColumnKind, fieldValue OptionalValue, valueOp
 func compareRowViewValues(kind ColumnKind, value1, value2 OptionalValue, operator Operator) (bool, error) {
 	switch kind {
 	case Boolean:
-		return compareBoolean(value1.Value.(bool), value2.Value.(bool), operator)
+		return compareBooleanValues(value1.Value, value2.Value, operator)
 	case Int4:
 		return compareInt4(int64(value1.Value.(int32)), int64(value2.Value.(int32)), operator)
 	case Int8:
@@ -0,0 +1,213 @@
+package minisql
+
+import (
+	"context"
+	"math"
+)
+
+// RowIDColumnName is the name of the pseudo-column that exposes a row's
+// internal B-tree key. It can be selected, filtered and ordered by in a
+// single-table SELECT unless the table has a real column with the same name.
+const RowIDColumnName = "rowid"
+
+var rowIDColumn = Column{Kind: Int8, Size: 8, Name: RowIDColumnName}
+
+// selectsRowID reports whether a single-table SELECT reads the rowid
+// pseudo-column of table, in its field list, WHERE clause or ORDER BY.
+func selectsRowID(stmt Statement, table *Table) bool {
+	if stmt.Kind != Select || len(stmt.Joins) > 0 || table.isVirtual() {
+		return false
+	}
+	if _, ok := table.ColumnByName(RowIDColumnName); ok {
+		// A real column named rowid shadows the pseudo-column.
+		return false
+	}
+	isRowID := func(f Field) bool {
+		if f.Expr != nil {
+			for _, col := range f.Expr.Columns() {
+				if col == RowIDColumnName {
+					return true
+				}
+			}
+			return false
+		}
+		return f.Name == RowIDColumnName
+	}
+	for _, f := range stmt.Fields {
+		if isRowID(f) {
+			return true
+		}
+	}
+	for _, o := range stmt.OrderBy {
+		if isRowID(o.Field) {
+			return true
+		}
+	}
+	for _, group := range stmt.Conditions {
+		for _, cond := range group {
+			for _, operand := range []Operand{cond.Operand1, cond.Operand2} {
+				switch v := operand.Value.(type) {
+				case Field:
+					if isRowID(v) {
+						return true
+					}
+				case *Expr:
+					if isRowID(Field{Expr: v}) {
+						return true
+					}
+				}
+			}
+		}
+	}
+	return false
+}
+
+// selectWithRowID runs a SELECT that reads the rowid pseudo-column. The
+// statement runs against a virtual table that streams the table's rows with
+// their key appended as an INT8 rowid column, like a derived table whose rows
+// are never all held in memory. WHERE rowid comparisons narrow the scan to a
+// key range, so WHERE rowid = N seeks straight to the row and keyset
+// pagination (WHERE rowid > N ORDER BY rowid [DESC] LIMIT n) reads only the
+// leaves it returns.
+func (d *Database) selectWithRowID(ctx context.Context, table *Table, stmt Statement) (StatementResult, error) {
+	columns := make([]Column, 0, len(table.Columns)+1)
+	for _, col := range table.Columns {
+		if !col.Deleted {
+			columns = append(columns, col)
+		}
+	}
+	fields := fieldsFromColumns(columns...)
+	columns = append(columns, rowIDColumn)
+
+	// Rows stream in rowid order, walking the leaves backwards for
+	// ORDER BY rowid DESC, so the virtual table needs no sort.
+	scanKeyRange := table.scanKeyRange
+	if ordered, desc := orderedByRowID(stmt); ordered {
+		stmt.OrderBy = nil
+		if desc {
+			scanKeyRange = table.scanKeyRangeReverse
+		}
+	}
+
+	keyRange, ok := rowIDRangeFromConditions(stmt.Conditions)
+	scan := func(ctx context.Context, out func(Row) error) error {
+		if !ok {
+			return nil
+		}
+		return scanKeyRange(ctx, keyRange, func(row Row) error {
+			values := make([]OptionalValue, 0, len(columns))
+			for _, col := range columns[:len(columns)-1] {
+				value, _ := row.GetValue(col.Name)
+				values = append(values, value)
+			}
+			values = append(values, OptionalValue{Value: int64(row.Key), Valid: true})
+			return out(Row{Key: row.Key, Columns: columns, Values: values})
+		}, fields...)
+	}
+	// SELECT * lists the table's columns only, never the pseudo-column.
+	if stmt.IsSelectAll() {
+		stmt.Fields = fields
+	}
+	vt := newStreamingVirtualTable(d.logger, table.Name, columns, scan)
+	return vt.Select(ctx, stmt)
+}
+
+// orderedByRowID reports whether stmt only orders its rows by rowid and
+// returns them without grouping, so a scan in key order needs no sort, and
+// whether the order is descending.
+func orderedByRowID(stmt Statement) (bool, bool) {
+	if len(stmt.OrderBy) != 1 || stmt.IsSelectGroupBy() || stmt.IsSelectAggregate() || stmt.HasWindowFuncs() {
+		return false, false
+	}
+	orderBy := stmt.OrderBy[0]
+	if orderBy.Field.Expr != nil || orderBy.Field.Name != RowIDColumnName {
+		return false, false
+	}
+	return true, orderBy.Direction == Desc
+}
+
+// rowIDRange is an inclusive range of row IDs.
+type rowIDRange struct {
+	From RowID
+	To   RowID
+}
+
+// rowIDRangeFromConditions returns the row IDs a WHERE clause can match when
+// it is a single AND group, narrowed by its rowid comparisons with integers.
+// The conditions are still evaluated against every row in the range. It
+// returns false when no row can match.
+func rowIDRangeFromConditions(conditions OneOrMore) (rowIDRange, bool) {
+	r := rowIDRange{From: 0, To: math.MaxUint64}
+	if len(conditions) != 1 {
+		return r, true
+	}
+	for _, cond := range conditions[0] {
+		operator, n, ok := rowIDComparison(cond)
+		if !ok {
+			continue
+		}
+		switch operator {
+		case Eq:
+			if n < 0 {
+				return r, false
+			}
+			r.From, r.To = max(r.From, RowID(n)), min(r.To, RowID(n))
+		case Gt:
+			if n >= 0 {
+				r.From = max(r.From, RowID(n)+1)
+			}
+		case Gte:
+			if n > 0 {
+				r.From = max(r.From, RowID(n))
+			}
+		case Lt:
+			if n <= 0 {
+				return r, false
+			}
+			r.To = min(r.To, RowID(n)-1)
+		case Lte:
+			if n < 0 {
+				return r, false
+			}
+			r.To = min(r.To, RowID(n))
+		}
+	}
+	return r, r.From <= r.To
+}
+
+// rowIDComparison returns the operator and integer of a condition comparing
+// the rowid pseudo-column with an integer, as if rowid were on the left.
+func rowIDComparison(cond Condition) (Operator, int64, bool) {
+	isRowID := func(operand Operand) bool {
+		f, ok := operand.Value.(Field)
+		return operand.Type == OperandField && ok && f.Expr == nil && f.Name == RowIDColumnName
+	}
+	operator := cond.Operator
+	field, value := cond.Operand1, cond.Operand2
+	if !isRowID(field) {
+		field, value = value, field
+		switch operator {
+		case Gt:
+			operator = Lt
+		case Lt:
+			operator = Gt
+		case Gte:
+			operator = Lte
+		case Lte:
+			operator = Gte
+		}
+	}
+	if !isRowID(field) || value.Type != OperandInteger {
+		return 0, 0, false
+	}
+	n, ok := value.Value.(int64)
+	if !ok {
+		return 0, 0, false
+	}
+	switch operator {
+	case Eq, Gt, Gte, Lt, Lte:
+		return operator, n, true
+	default:
+		return 0, 0, false
+	}
+}
@@ -0,0 +1,120 @@
+package minisql
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRowIDRangeFromConditions(t *testing.T) {
+	t.Parallel()
+
+	rowID := Field{Name: RowIDColumnName}
+	email := Field{Name: "email"}
+	compare := func(operand1, operand2 Operand, operator Operator) Condition {
+		return Condition{Operand1: operand1, Operator: operator, Operand2: operand2}
+	}
+	rowIDOperand := Operand{Type: OperandField, Value: rowID}
+	integer := func(n int64) Operand {
+		return Operand{Type: OperandInteger, Value: n}
+	}
+
+	testCases := []struct {
+		Name       string
+		Conditions OneOrMore
+		Range      rowIDRange
+		OK         bool
+	}{
+		{
+			"no conditions",
+			nil,
+			rowIDRange{From: 0, To: math.MaxUint64},
+			true,
+		},
+		{
+			"rowid equality",
+			OneOrMore{{FieldIsEqual(rowID, OperandInteger, int64(5))}},
+			rowIDRange{From: 5, To: 5},
+			true,
+		},
+		{
+			"rowid equality alongside other predicates",
+			OneOrMore{{
+				FieldIsEqual(email, OperandQuotedString, NewTextPointer([]byte("a@b.c"))),
+				FieldIsEqual(rowID, OperandInteger, int64(7)),
+			}},
+			rowIDRange{From: 7, To: 7},
+			true,
+		},
+		{
+			"rowid lower bound",
+			OneOrMore{{compare(rowIDOperand, integer(5), Gt)}},
+			rowIDRange{From: 6, To: math.MaxUint64},
+			true,
+		},
+		{
+			"rowid between bounds",
+			OneOrMore{{compare(rowIDOperand, integer(5), Gte), compare(rowIDOperand, integer(10), Lt)}},
+			rowIDRange{From: 5, To: 9},
+			true,
+		},
+		{
+			"integer on the left",
+			OneOrMore{{compare(integer(10), rowIDOperand, Gte)}},
+			rowIDRange{From: 0, To: 10},
+			true,
+		},
+		{
+			"disjoint bounds",
+			OneOrMore{{compare(rowIDOperand, integer(10), Gt), compare(rowIDOperand, integer(5), Lte)}},
+			rowIDRange{From: 11, To: 5},
+			false,
+		},
+		{
+			"OR groups scan every row",
+			OneOrMore{
+				{FieldIsEqual(rowID, OperandInteger, int64(5))},
+				{FieldIsEqual(rowID, OperandInteger, int64(6))},
+			},
+			rowIDRange{From: 0, To: math.MaxUint64},
+			true,
+		},
+		{
+			"negative rowid",
+			OneOrMore{{FieldIsEqual(rowID, OperandInteger, int64(-1))}},
+			rowIDRange{From: 0, To: math.MaxUint64},
+			false,
+		},
+		{
+			"not equal does not narrow",
+			OneOrMore{{compare(rowIDOperand, integer(5), Ne)}},
+			rowIDRange{From: 0, To: math.MaxUint64},
+			true,
+		},
+	}
+
+	for _, aTestCase := range testCases {
+		t.Run(aTestCase.Name, func(t *testing.T) {
+			keyRange, ok := rowIDRangeFromConditions(aTestCase.Conditions)
+			assert.Equal(t, aTestCase.OK, ok)
+			if ok {
+				assert.Equal(t, aTestCase.Range, keyRange)
+			}
+		})
+	}
+}
+
+func TestSelectsRowID(t *testing.T) {
+	t.Parallel()
+
+	table := newVirtualTable(testLogger, "users", []Column{{Kind: Int8, Size: 8, Name: "id"}}, nil)
+	table.virtualRows = nil
+	stmt := Statement{Kind: Select, Fields: []Field{{Name: RowIDColumnName}, {Name: "id"}}}
+	assert.True(t, selectsRowID(stmt, table))
+	assert.False(t, selectsRowID(Statement{Kind: Select, Fields: []Field{{Name: "id"}}}, table))
+
+	shadowed := newVirtualTable(testLogger, "users", []Column{{Kind: Int8, Size: 8, Name: RowIDColumnName}}, nil)
+	shadowed.virtualRows = nil
+	assert.False(t, selectsRowID(stmt, shadowed), "a real rowid column shadows the pseudo-column")
+}
@@ -33,12 +33,15 @@ func (t *Table) Select(ctx context.Context, stmt Statement) (StatementResult, er
 	// Fast path: COUNT(*) with no WHERE clause and no JOIN.
 	// B-tree tables walk leaf page headers without deserialising row data.
 	// Virtual tables (CTEs, derived tables) already have rows in memory — return
-	// len(virtualRows) directly without a second scan pass.
+	// len(virtualRows) directly without a second scan pass. Streaming virtual
+	// tables are counted by the general path below.
 	if stmt.IsSelectCountAll() && len(stmt.Conditions) == 0 && len(stmt.Joins) == 0 {
 		if t.virtualRows != nil {
 			return countResult(int64(len(t.virtualRows))), nil
 		}
-		return t.countAllLeafWalk(ctx)
+		if t.virtualScan == nil {
+			return t.countAllLeafWalk(ctx)
+		}
 	}
 
 	// Window-function queries: materialise all rows first, then apply window logic.
@@ -60,13 +63,16 @@ func (t *Table) Select(ctx context.Context, stmt Statement) (StatementResult, er
 	// combined alias-prefixed column list so that groupByAccumulator and newAggStates
 	// can resolve column indices against the combined join schema (e.g. "o.user_id",
 	// "u.name") rather than the base table schema.
-	if len(plan.Joins) > 0 && (stmt.IsSelectGroupBy() || stmt.IsSelectAggregate()) {
+	if len(plan.Joins) > 0 {
 		if combined, ok := combinedJoinSchema(ctx, plan, t.provider); ok {
-			stmt.Columns = combined
+			stmt.joinColumns = combined
+			if stmt.IsSelectGroupBy() || stmt.IsSelectAggregate() {
+				stmt.Columns = combined
+			}
 		}
 	}
 
-	if stmt.IsSelectCountAll() && len(stmt.Joins) == 0 && t.virtualRows == nil {
+	if stmt.IsSelectCountAll() && len(stmt.Joins) == 0 && !t.isVirtual() {
 		result, ok, err := t.tryCountFromExactInvertedIndex(ctx, plan)
 		if err != nil {
 			return StatementResult{}, err
@@ -144,6 +150,7 @@ func (t *Table) Select(ctx context.Context, stmt Statement) (StatementResult, er
 					selectedFields = appendOperandSourceFields(selectedFields, cond.Operand2)
 				}
 			}
+			selectedFields = t.appendOrderByExprSourceFields(selectedFields, plan.OrderBy)
 		}
 	}
 
@@ -245,7 +252,7 @@ func (t *Table) Select(ctx context.Context, stmt Statement) (StatementResult, er
 	}
 
 	if stmt.IsSelectAggregate() {
-		if len(plan.Joins) == 0 && len(plan.Scans) == 1 && plan.Scans[0].Type == ScanTypeSequential && t.virtualRows == nil && !t.parallelScan {
+		if len(plan.Joins) == 0 && len(plan.Scans) == 1 && plan.Scans[0].Type == ScanTypeSequential && !t.isVirtual() && !t.parallelScan {
 			return t.selectAggregateSequentialRowView(ctx, stmt, plan.Scans[0], selectedFields)
 		}
 		return t.selectAggregateStreaming(ctx, stmt, plan, selectedFields)
@@ -338,7 +345,11 @@ func (t *Table) Select(ctx context.Context, stmt Statement) (StatementResult, er
 	}
 
 	var rows []Row
+	budget := queryBudgetFromContext(ctx)
 	err = plan.Execute(ctx, t.provider, selectedFields, func(row Row) error {
+		if err := budget.growMemory(int64(row.MemorySize())); err != nil {
+			return err
+		}
 		rows = append(rows, row)
 		if joinScanLimit > 0 && int64(len(rows)) >= joinScanLimit {
 			return errLimitReached
@@ -420,52 +431,65 @@ func combinedJoinSchema(ctx context.Context, plan QueryPlan, provider TableProvi
 	return combined, true
 }
 
-// countAllLeafWalk counts every row in the table.
+// countAllLeafWalk answers COUNT(*) with no WHERE clause and no JOIN from
+// RowCount, without reading any row data.
+func (t *Table) countAllLeafWalk(ctx context.Context) (StatementResult, error) {
+	count, err := t.RowCount(ctx)
+	if err != nil {
+		return StatementResult{}, fmt.Errorf("count all: %w", err)
+	}
+	return countResult(count), nil
+}
+
+// RowCount returns the number of rows in the table.
 //
 // Fast path: if a row-count getter has been registered (set by the Database
 // after loading the table), returns the cached count in O(1) without any I/O.
-// The fast path is skipped when an active write transaction is in context
-// because its uncommitted changes (in the WriteSet) are not yet reflected in
-// the row-count cache — the page walk uses ReadPage which checks the WriteSet.
+// The cache is maintained incrementally on insert, delete and truncate but
+// only reflects committed transactions, so inside a write transaction its own
+// uncommitted delta is added on top; the single-writer model guarantees no
+// other commit lands in between.
 //
 // Fallback: walks the B+ tree leaf page chain and sums Header.Cells on each
-// page — O(leaf pages), no row data read or deserialised.
-//
-// This is only valid for COUNT(*) with no WHERE clause and no JOIN.
-func (t *Table) countAllLeafWalk(ctx context.Context) (StatementResult, error) {
-	var count int64
-	tx := TxFromContext(ctx)
-	useCache := t.getRowCount != nil && (tx == nil || tx.ReadOnly)
-	if useCache {
-		count = t.getRowCount()
-	} else {
-		cursor, err := t.SeekFirst(ctx)
-		if err != nil {
-			return StatementResult{}, fmt.Errorf("count all: %w", err)
+// page — O(leaf pages), no row data read or deserialised. The Database uses
+// this walk to seed the cache when it opens a table, after the WAL has been
+// recovered, so the cached count always starts out consistent with the file.
+func (t *Table) RowCount(ctx context.Context) (int64, error) {
+	if t.getRowCount != nil {
+		count := t.getRowCount()
+		if tx := TxFromContext(ctx); tx != nil && !tx.ReadOnly {
+			count += tx.RowCountDelta(t.Name)
 		}
+		return count, nil
+	}
 
-		pageIdx := cursor.PageIdx
-		for {
-			select {
-			case <-ctx.Done():
-				return StatementResult{}, ctx.Err()
-			default:
-			}
+	cursor, err := t.SeekFirst(ctx)
+	if err != nil {
+		return 0, err
+	}
 
-			page, err := t.pager.ReadPage(ctx, pageIdx)
-			if err != nil {
-				return StatementResult{}, fmt.Errorf("count all: read page %d: %w", pageIdx, err)
-			}
-			count += int64(page.LeafNode.Header.Cells)
+	var (
+		count   int64
+		pageIdx = cursor.PageIdx
+	)
+	for {
+		select {
+		case <-ctx.Done():
+			return 0, ctx.Err()
+		default:
+		}
 
-			if page.LeafNode.Header.NextLeaf == 0 {
-				break
-			}
-			pageIdx = page.LeafNode.Header.NextLeaf
+		page, err := t.pager.ReadPage(ctx, pageIdx)
+		if err != nil {
+			return 0, fmt.Errorf("read page %d: %w", pageIdx, err)
 		}
-	}
+		count += int64(page.LeafNode.Header.Cells)
 
-	return countResult(count), nil
+		if page.LeafNode.Header.NextLeaf == 0 {
+			return count, nil
+		}
+		pageIdx = page.LeafNode.Header.NextLeaf
+	}
 }
 
 // aggState holds the running accumulator for a single aggregate expression.
@@ -478,6 +502,8 @@ type aggState struct {
 	sumF      float64
 	useIntSum bool
 	hasValue  bool
+	// distinct holds the keys of values already counted by COUNT(DISTINCT col).
+	distinct map[string]struct{}
 }
 
 // groupAggState is the per-group accumulator used inside groupByAccumulator.
@@ -521,21 +547,25 @@ func (t *Table) selectAggregateSequentialRowView(ctx context.Context, stmt State
 	filter := t.compileRowViewScanFilter(scan, selectedFields)
 	states, aggColIdx := t.newAggStates(stmt)
 
-	page, err := t.pager.ReadPage(ctx, cursor.PageIdx)
+	pageFilter := t.compilePageHintFilter(scan.Filters)
+	page, err := t.readScanPage(ctx, cursor, pageFilter)
 	if err != nil {
 		return StatementResult{}, fmt.Errorf("aggregate sequential scan: %w", err)
 	}
-	cursor.EndOfTable = page.LeafNode.Header.Cells == 0
+	cursor.EndOfTable = cursor.EndOfTable || page.LeafNode.Header.Cells == 0
 
 	for !cursor.EndOfTable {
 		if err := ctx.Err(); err != nil {
 			return StatementResult{}, err
 		}
 		if page.Index != cursor.PageIdx {
-			page, err = t.pager.ReadPage(ctx, cursor.PageIdx)
+			page, err = t.readScanPage(ctx, cursor, pageFilter)
 			if err != nil {
 				return StatementResult{}, fmt.Errorf("aggregate sequential scan: %w", err)
 			}
+			if cursor.EndOfTable {
+				break
+			}
 		}
 		if cursor.CellIdx > page.LeafNode.Header.Cells-1 || len(page.LeafNode.Cells) == 0 {
 			return StatementResult{}, fmt.Errorf("cell index %d out of bounds, max %d", cursor.CellIdx, page.LeafNode.Header.Cells-1)
@@ -573,6 +603,11 @@ func (t *Table) newAggStates(stmt Statement) ([]aggState, []int) {
 			states[i].useIntSum = col.Kind == Int4 || col.Kind == Int8
 		}
 	}
+	for i, agg := range stmt.Aggregates {
+		if agg.Distinct {
+			states[i].distinct = make(map[string]struct{})
+		}
+	}
 
 	// Pre-compute column index for each aggregate's source column to avoid
 	// per-row linear scans through column names.
@@ -596,6 +631,15 @@ func accumulateAggregateRow(stmt Statement, states []aggState, aggColIdx []int,
 	for i, agg := range stmt.Aggregates {
 		switch agg.Kind {
 		case AggregateCount:
+			if agg.Column != "" {
+				val, ok := aggregateRowValue(row, agg.Column, aggColIdx[i])
+				if !ok || !val.Valid {
+					continue
+				}
+				if agg.Distinct && !states[i].addDistinct(val) {
+					continue
+				}
+			}
 			states[i].count += 1
 
 		case AggregateSum, AggregateAvg:
@@ -649,6 +693,22 @@ func accumulateAggregateRowView(ctx context.Context, pager TxPager, aggregates [
 	for i, agg := range aggregates {
 		switch agg.Kind {
 		case AggregateCount:
+			if agg.Distinct {
+				// Resolve overflow text so the set is keyed by the string itself.
+				val, ok, err := aggregateRowViewValue(ctx, pager, view, aggColIdx[i])
+				if err != nil {
+					return err
+				}
+				if !ok || !val.Valid || !states[i].addDistinct(val) {
+					continue
+				}
+			} else if agg.Column != "" {
+				if isNull, err := aggregateRowViewIsNull(view, aggColIdx[i]); err != nil {
+					return err
+				} else if isNull {
+					continue
+				}
+			}
 			states[i].count += 1
 
 		case AggregateSum, AggregateAvg:
@@ -707,6 +767,23 @@ func accumulateAggregateRowView(ctx context.Context, pager TxPager, aggregates [
 	return nil
 }
 
+// addDistinct records val in the COUNT(DISTINCT) set and reports whether it
+// had not been seen before.
+func (s *aggState) addDistinct(val OptionalValue) bool {
+	key := distinctValueKey(val)
+	if _, seen := s.distinct[key]; seen {
+		return false
+	}
+	s.distinct[key] = struct{}{}
+	return true
+}
+
+// distinctValueKey encodes a single value the same way rowDistinctKey does,
+// so text is compared by content rather than by pointer.
+func distinctValueKey(val OptionalValue) string {
+	return Row{Values: []OptionalValue{val}}.rowDistinctKey()
+}
+
 func aggregateRowValue(row Row, colName string, colIdx int) (OptionalValue, bool) {
 	if colIdx >= 0 && colIdx < len(row.Values) && colIdx < len(row.Columns) && row.Columns[colIdx].Name == colName {
 		return row.Values[colIdx], true
@@ -725,6 +802,15 @@ func aggregateRowViewValue(ctx context.Context, pager TxPager, view RowView, col
 	return value, true, nil
 }
 
+// aggregateRowViewIsNull reports whether column colIdx of view is NULL,
+// without decoding the value. A column missing from the view counts as NULL.
+func aggregateRowViewIsNull(view RowView, colIdx int) (bool, error) {
+	if colIdx < 0 || colIdx >= len(view.Columns()) {
+		return true, nil
+	}
+	return view.IsNull(colIdx)
+}
+
 func (t *Table) aggregateResult(stmt Statement, states []aggState) StatementResult {
 	// Build result columns and a single result row.
 	resultColumns := make([]Column, len(stmt.Aggregates))
@@ -868,6 +954,10 @@ type groupByAccumulator struct {
 	minMaxPool    []OptionalValue
 	minMaxAggSlot []int // aggIdx → slot within group's minMax block (-1 if not MIN/MAX)
 	numMinMax     int
+	// distinctSeen holds one entry per (group, aggregate, value) already counted
+	// by COUNT(DISTINCT col). Only allocated when the query has such an aggregate.
+	distinctSeen map[string]struct{}
+	distinctBuf  []byte
 }
 
 func newGroupByAccumulator(stmt Statement, t *Table, estRows int) *groupByAccumulator {
@@ -895,7 +985,7 @@ func newGroupByAccumulator(stmt Statement, t *Table, estRows int) *groupByAccumu
 	aggColIdx := make([]int, numAggs)
 	for i, agg := range stmt.Aggregates {
 		aggColIdx[i] = -1
-		if agg.Column == "" || agg.Kind == 0 || agg.Kind == AggregateCount {
+		if agg.Column == "" || agg.Kind == 0 {
 			continue
 		}
 		for j, col := range stmt.Columns {
@@ -978,6 +1068,11 @@ func newGroupByAccumulator(stmt Statement, t *Table, estRows int) *groupByAccumu
 		minMaxPool = make([]OptionalValue, 0, estGroups*numMinMax)
 	}
 
+	var distinctSeen map[string]struct{}
+	if stmt.HasDistinctAggregate() {
+		distinctSeen = make(map[string]struct{})
+	}
+
 	return &groupByAccumulator{
 		aggregates:        stmt.Aggregates,
 		useIntSum:         useIntSum,
@@ -991,6 +1086,7 @@ func newGroupByAccumulator(stmt Statement, t *Table, estRows int) *groupByAccumu
 		minMaxPool:        minMaxPool,
 		minMaxAggSlot:     minMaxAggSlot,
 		numMinMax:         numMinMax,
+		distinctSeen:      distinctSeen,
 	}
 }
 
@@ -1042,6 +1138,12 @@ func (acc *groupByAccumulator) process(row Row) {
 		case 0:
 			// Non-aggregate GROUP BY column — no accumulation needed.
 		case AggregateCount:
+			if colIdx := acc.aggColIdx[i]; agg.Column != "" && (colIdx < 0 || colIdx >= len(row.Values) || !row.Values[colIdx].Valid) {
+				continue
+			}
+			if agg.Distinct && !acc.addDistinct(gsIdx, i, row.Values[acc.aggColIdx[i]]) {
+				continue
+			}
 			acc.aggStatePool[aggBase+i].count += 1
 		case AggregateSum, AggregateAvg:
 			colIdx := acc.aggColIdx[i]
@@ -1099,6 +1201,24 @@ func (acc *groupByAccumulator) process(row Row) {
 	}
 }
 
+// addDistinct records val in the COUNT(DISTINCT) set of aggregate aggIdx
+// within group gsIdx and reports whether it had not been seen before.
+func (acc *groupByAccumulator) addDistinct(gsIdx int32, aggIdx int, val OptionalValue) bool {
+	acc.distinctBuf = strconv.AppendInt(acc.distinctBuf[:0], int64(gsIdx), 10)
+	acc.distinctBuf = append(acc.distinctBuf, ':')
+	acc.distinctBuf = strconv.AppendInt(acc.distinctBuf, int64(aggIdx), 10)
+	acc.distinctBuf = append(acc.distinctBuf, ':')
+	acc.distinctBuf = append(acc.distinctBuf, distinctValueKey(val)...)
+	if _, seen := acc.distinctSeen[string(acc.distinctBuf)]; seen {
+		return false
+	}
+	acc.distinctSeen[string(acc.distinctBuf)] = struct{}{}
+	return true
+}
+
+// processView accumulates one RowView into the group state. It does not handle
+// COUNT(DISTINCT col), which needs resolved text values; callers route those
+// queries through process instead.
 func (acc *groupByAccumulator) processView(view RowView) error {
 	var err error
 	acc.keyBuf, err = buildGroupKeyFromRowView(acc.keyBuf[:0], view, acc.groupByColIdx)
@@ -1151,6 +1271,13 @@ func (acc *groupByAccumulator) processView(view RowView) error {
 		case 0:
 			// Non-aggregate GROUP BY column — no accumulation needed.
 		case AggregateCount:
+			if agg.Column != "" {
+				if isNull, err := aggregateRowViewIsNull(view, acc.aggColIdx[i]); err != nil {
+					return err
+				} else if isNull {
+					continue
+				}
+			}
 			state.count += 1
 		case AggregateSum, AggregateAvg:
 			colIdx := acc.aggColIdx[i]
@@ -1245,9 +1372,9 @@ func (t *Table) selectGroupByStreaming(ctx context.Context, stmt Statement, plan
 
 // selectGroupByZeroAlloc handles GROUP BY over a single sequential scan by
 // accumulating directly from RowView. Falls back to the general path for virtual
-// tables and parallel scans.
+// tables, parallel scans and COUNT(DISTINCT col).
 func (t *Table) selectGroupByZeroAlloc(ctx context.Context, stmt Statement, scan Scan, selectedFields []Field) (StatementResult, error) {
-	if t.virtualRows != nil || t.parallelScan {
+	if t.isVirtual() || t.parallelScan || stmt.HasDistinctAggregate() {
 		estRows := int(t.estimatedRowCount())
 		if estRows <= 0 {
 			estRows = 160 // conservative default (estGroups = 16)
@@ -1275,11 +1402,12 @@ func (t *Table) selectGroupByZeroAlloc(ctx context.Context, stmt Statement, scan
 	}
 	acc := newGroupByAccumulator(stmt, t, estRows)
 
-	page, err := t.pager.ReadPage(ctx, cursor.PageIdx)
+	pageFilter := t.compilePageHintFilter(scan.Filters)
+	page, err := t.readScanPage(ctx, cursor, pageFilter)
 	if err != nil {
 		return StatementResult{}, fmt.Errorf("group by sequential scan: %w", err)
 	}
-	cursor.EndOfTable = page.LeafNode.Header.Cells == 0
+	cursor.EndOfTable = cursor.EndOfTable || page.LeafNode.Header.Cells == 0
 
 	for !cursor.EndOfTable {
 		if err := ctx.Err(); err != nil {
@@ -1287,10 +1415,13 @@ func (t *Table) selectGroupByZeroAlloc(ctx context.Context, stmt Statement, scan
 		}
 
 		if page.Index != cursor.PageIdx {
-			page, err = t.pager.ReadPage(ctx, cursor.PageIdx)
+			page, err = t.readScanPage(ctx, cursor, pageFilter)
 			if err != nil {
 				return StatementResult{}, fmt.Errorf("group by sequential scan: %w", err)
 			}
+			if cursor.EndOfTable {
+				break
+			}
 		}
 
 		cell := page.LeafNode.Cells[cursor.CellIdx]
@@ -1449,14 +1580,9 @@ func (acc *groupByAccumulator) buildResult(stmt Statement, t *Table) (StatementR
 				if !foundA || !foundB {
 					continue
 				}
-				cmp := compareValues(valA, valB)
-				if cmp == 0 {
-					continue
+				if cmp := clause.compare(valA, valB); cmp != 0 {
+					return cmp
 				}
-				if clause.Direction == Desc {
-					return -cmp
-				}
-				return cmp
 			}
 			return 0
 		})
@@ -1551,6 +1677,7 @@ func (t *Table) selectStreamingDirect(
 		seen = make(map[string]struct{})
 	}
 	var prevDistinctKey string
+	budget := queryBudgetFromContext(ctx)
 
 	// When LIMIT is present, pre-size to exactly the limit so append never reallocates.
 	// Without LIMIT, start empty and grow as needed.
@@ -1584,6 +1711,9 @@ func (t *Table) selectStreamingDirect(
 			offset -= 1
 			return nil
 		}
+		if err := budget.growMemory(int64(p.MemorySize())); err != nil {
+			return err
+		}
 		projected = append(projected, p)
 		if hasLimit {
 			remaining -= 1
@@ -1803,7 +1933,7 @@ func (t *Table) selectStreamingDirectRowView(
 	plan QueryPlan,
 	requestedFields []Field,
 ) (StatementResult, bool, error) {
-	if t.virtualRows != nil || len(plan.Scans) != 1 {
+	if t.isVirtual() || len(plan.Scans) != 1 {
 		return StatementResult{}, false, nil
 	}
 	var fieldIndexes []int
@@ -1869,7 +1999,7 @@ func (t *Table) selectStreamingDirectRowView(
 		if t.parallelScan {
 			iterFactory, err = t.parallelSequentialRowViewIteratorFactory(ctx, tableFilter, iterRemaining, iterOffset, iterHasLimit, iterHasOffset)
 		} else {
-			iterFactory, err = t.sequentialRowViewIteratorFactory(ctx, tableFilter, iterRemaining, iterOffset, iterHasLimit, iterHasOffset)
+			iterFactory, err = t.sequentialRowViewIteratorFactory(ctx, tableFilter, t.compilePageHintFilter(scan.Filters), iterRemaining, iterOffset, iterHasLimit, iterHasOffset)
 		}
 		if err != nil {
 			return StatementResult{}, true, err
@@ -1966,10 +2096,10 @@ func (t *Table) selectSemiJoinDirectRowView(
 	plan QueryPlan,
 	requestedFields []Field,
 ) (StatementResult, bool, error) {
-	if len(plan.Joins) != 1 || plan.Joins[0].Type != Semi {
+	if len(plan.Joins) != 1 || plan.Joins[0].Type != Semi || len(plan.JoinFilters) > 0 {
 		return StatementResult{}, false, nil
 	}
-	if t.virtualRows != nil || t.parallelScan {
+	if t.isVirtual() || t.parallelScan {
 		return StatementResult{}, false, nil
 	}
 	if stmt.IsSelectGroupBy() || stmt.IsSelectAggregate() || stmt.Distinct || plan.SortInMemory {
@@ -2043,7 +2173,8 @@ func (t *Table) selectHashJoinDirectRowView(
 	plan QueryPlan,
 	requestedFields []Field,
 ) (StatementResult, bool, error) {
-	if len(plan.Joins) != 1 {
+	// WHERE conditions left for the combined row need the generic join path.
+	if len(plan.Joins) != 1 || len(plan.JoinFilters) > 0 {
 		return StatementResult{}, false, nil
 	}
 	join := plan.Joins[0]
@@ -2070,7 +2201,7 @@ func (t *Table) selectHashJoinDirectRowView(
 	if !ok {
 		return StatementResult{}, true, minisqlErrors.ErrNoSuchTable{Name: outerScan.TableName}
 	}
-	if outerTable.virtualRows != nil || outerTable.parallelScan {
+	if outerTable.isVirtual() || outerTable.parallelScan {
 		return StatementResult{}, false, nil
 	}
 	if !rowViewFilterSupports(outerTable.Columns, outerScan.Filters) {
@@ -2143,7 +2274,8 @@ func (t *Table) selectINLJDirectRowView(
 	plan QueryPlan,
 	requestedFields []Field,
 ) (StatementResult, bool, error) {
-	if len(plan.Joins) != 1 {
+	// WHERE conditions left for the combined row need the generic join path.
+	if len(plan.Joins) != 1 || len(plan.JoinFilters) > 0 {
 		return StatementResult{}, false, nil
 	}
 	join := plan.Joins[0]
@@ -2170,7 +2302,7 @@ func (t *Table) selectINLJDirectRowView(
 	if !ok {
 		return StatementResult{}, true, minisqlErrors.ErrNoSuchTable{Name: outerScan.TableName}
 	}
-	if outerTable.virtualRows != nil || outerTable.parallelScan {
+	if outerTable.isVirtual() || outerTable.parallelScan {
 		return StatementResult{}, false, nil
 	}
 	if !rowViewFilterSupports(outerTable.Columns, outerScan.Filters) {
@@ -2277,6 +2409,7 @@ func projectCoveringIndexKey(key any, indexColumns []Column, rowID RowID, projec
 func (t *Table) sequentialRowViewIteratorFactory(
 	ctx context.Context,
 	tableFilter func(context.Context, RowView) (bool, error),
+	pageFilter func(context.Context, *Page) bool,
 	remaining int64,
 	offset int64,
 	hasLimit bool,
@@ -2286,11 +2419,11 @@ func (t *Table) sequentialRowViewIteratorFactory(
 	if err != nil {
 		return nil, err
 	}
-	page, err := t.pager.ReadPage(ctx, cursor.PageIdx)
+	page, err := t.readScanPage(ctx, cursor, pageFilter)
 	if err != nil {
 		return nil, fmt.Errorf("row view sequential scan: %w", err)
 	}
-	cursor.EndOfTable = page.LeafNode.Header.Cells == 0
+	cursor.EndOfTable = cursor.EndOfTable || page.LeafNode.Header.Cells == 0
 
 	return func() RowViewIterator {
 		iterCursor := cursor
@@ -2304,10 +2437,13 @@ func (t *Table) sequentialRowViewIteratorFactory(
 				}
 				if iterPage.Index != iterCursor.PageIdx {
 					var err error
-					iterPage, err = t.pager.ReadPage(iterCtx, iterCursor.PageIdx)
+					iterPage, err = t.readScanPage(iterCtx, iterCursor, pageFilter)
 					if err != nil {
 						return RowView{}, fmt.Errorf("row view sequential scan: %w", err)
 					}
+					if iterCursor.EndOfTable {
+						break
+					}
 				}
 
 				cell := iterPage.LeafNode.Cells[iterCursor.CellIdx]
@@ -3772,7 +3908,7 @@ func (t *Table) selectWithSortStreamingLimitRowView(
 	if len(plan.Joins) > 0 ||
 		len(plan.Scans) != 1 ||
 		plan.Scans[0].Type != ScanTypeSequential ||
-		t.virtualRows != nil ||
+		t.isVirtual() ||
 		!plan.SortInMemory ||
 		!stmt.Limit.Valid ||
 		len(plan.OrderBy) == 0 ||
@@ -3865,7 +4001,7 @@ func (t *Table) selectWithSortRowView(
 	if len(plan.Joins) > 0 ||
 		len(plan.Scans) != 1 ||
 		plan.Scans[0].Type != ScanTypeSequential ||
-		t.virtualRows != nil ||
+		t.isVirtual() ||
 		!plan.SortInMemory ||
 		stmt.Limit.Valid ||
 		len(plan.OrderBy) == 0 ||
@@ -3908,6 +4044,7 @@ func (t *Table) selectWithSortRowView(
 	}
 
 	scan := plan.Scans[0]
+	budget := queryBudgetFromContext(ctx)
 	var (
 		allRows    []Row
 		accumBytes int64
@@ -3940,13 +4077,18 @@ func (t *Table) selectWithSortRowView(
 			m.sortSpillRuns.Add(1)
 			m.sortSpillBytes.Add(accumBytes)
 		}
+		budget.releaseMemory(accumBytes)
 		allRows = allRows[:0]
 		accumBytes = 0
 		return nil
 	}
 
 	err := t.scanProjectedRowViews(ctx, scan, selectedFields, fieldIndexes, sortColumns, func(row Row) error {
-		accumBytes += int64(row.Size()) + 16 // 8-byte RowID + 8-byte NullBitmask
+		rowBytes := int64(row.MemorySize()) + 16 // 8-byte RowID + 8-byte NullBitmask
+		if err := budget.growMemory(rowBytes); err != nil {
+			return err
+		}
+		accumBytes += rowBytes
 		allRows = append(allRows, row)
 		if t.sortMemLimit > 0 && accumBytes >= t.sortMemLimit {
 			return flushRun()
@@ -4076,21 +4218,25 @@ func (t *Table) scanProjectedRowViews(
 	if err != nil {
 		return err
 	}
-	page, err := t.pager.ReadPage(ctx, cursor.PageIdx)
+	pageFilter := t.compilePageHintFilter(scan.Filters)
+	page, err := t.readScanPage(ctx, cursor, pageFilter)
 	if err != nil {
 		return fmt.Errorf("row view scan: %w", err)
 	}
-	cursor.EndOfTable = page.LeafNode.Header.Cells == 0
+	cursor.EndOfTable = cursor.EndOfTable || page.LeafNode.Header.Cells == 0
 
 	for !cursor.EndOfTable {
 		if err := ctx.Err(); err != nil {
 			return err
 		}
 		if page.Index != cursor.PageIdx {
-			page, err = t.pager.ReadPage(ctx, cursor.PageIdx)
+			page, err = t.readScanPage(ctx, cursor, pageFilter)
 			if err != nil {
 				return fmt.Errorf("row view scan: %w", err)
 			}
+			if cursor.EndOfTable {
+				break
+			}
 		}
 		if cursor.CellIdx > page.LeafNode.Header.Cells-1 || len(page.LeafNode.Cells) == 0 {
 			return fmt.Errorf("cell index %d out of bounds, max %d", cursor.CellIdx, page.LeafNode.Header.Cells-1)
@@ -4237,6 +4383,7 @@ func (t *Table) selectWithSortSpill(
 	requestedFields []Field,
 ) (StatementResult, error) {
 	outputFields := orderByOutputFields(requestedFields)
+	budget := queryBudgetFromContext(ctx)
 
 	var (
 		allRows      []Row
@@ -4277,6 +4424,7 @@ func (t *Table) selectWithSortSpill(
 			m.sortSpillRuns.Add(1)
 			m.sortSpillBytes.Add(accumBytes)
 		}
+		budget.releaseMemory(accumBytes)
 		allRows = allRows[:0]
 		accumBytes = 0
 		return nil
@@ -4291,7 +4439,11 @@ func (t *Table) selectWithSortSpill(
 		if spillColumns == nil && len(row.Columns) > 0 {
 			spillColumns = row.Columns
 		}
-		accumBytes += int64(row.Size()) + 16
+		rowBytes := int64(row.MemorySize()) + 16
+		if err := budget.growMemory(rowBytes); err != nil {
+			return err
+		}
+		accumBytes += rowBytes
 		allRows = append(allRows, row)
 		if t.sortMemLimit > 0 && accumBytes >= t.sortMemLimit {
 			return flushRun()
@@ -4368,6 +4520,11 @@ func (t *Table) selectResultColumns(stmt Statement, requestedFields []Field) []C
 	for i, field := range requestedFields {
 		if field.Expr != nil {
 			columns[i] = Column{Name: field.OutputName()}
+		} else if stmt.joinColumns != nil {
+			if col, ok := joinColumnByField(stmt.joinColumns, field); ok {
+				columns[i] = col
+				columns[i].Name = field.OutputName()
+			}
 		} else if colIdx := stmt.ColumnIdx(field.Name); colIdx >= 0 {
 			columns[i] = t.Columns[colIdx]
 		}
@@ -4375,6 +4532,24 @@ func (t *Table) selectResultColumns(stmt Statement, requestedFields []Field) []C
 	return columns
 }
 
+// joinColumnByField finds field in a combined join schema. A qualified field
+// matches its alias-prefixed column; an unqualified one matches the first
+// column of that name in any table.
+func joinColumnByField(columns []Column, field Field) (Column, bool) {
+	if col, idx := columnByFieldName(columns, field.String()); idx >= 0 {
+		return col, true
+	}
+	if field.AliasPrefix != "" {
+		return Column{}, false
+	}
+	for _, col := range columns {
+		if _, name, ok := strings.Cut(col.Name, "."); ok && name == field.Name {
+			return col, true
+		}
+	}
+	return Column{}, false
+}
+
 func addOrderByOutputFields(rows []Row, fields []Field, orderBy []OrderBy) ([]Row, error) {
 	if len(rows) == 0 || len(orderBy) == 0 {
 		return rows, nil
@@ -5700,6 +5875,25 @@ func exprSourceFields(fields []Field) []Field {
 	return result
 }
 
+// appendOrderByExprSourceFields appends the table columns read by ORDER BY
+// expressions, such as age in ORDER BY CASE WHEN age >= 18 THEN 1 END, so the
+// sort key can be evaluated when those columns are not selected. Names that
+// are not columns of t refer to output aliases and are resolved after
+// projection instead.
+func (t *Table) appendOrderByExprSourceFields(fields []Field, orderBy []OrderBy) []Field {
+	for _, clause := range orderBy {
+		if clause.Field.Expr == nil {
+			continue
+		}
+		for _, colName := range clause.Field.Expr.Columns() {
+			if _, ok := t.ColumnByName(colName); ok {
+				fields = append(fields, Field{Name: colName})
+			}
+		}
+	}
+	return fields
+}
+
 func appendOperandSourceFields(fields []Field, operand Operand) []Field {
 	switch operand.Type {
 	case OperandField:
@@ -5782,6 +5976,14 @@ func appendGroupKeyValue(buf []byte, value OptionalValue) []byte {
 	case float32:
 		buf = append(buf, "f32:"...)
 		buf = strconv.AppendFloat(buf, float64(val), 'g', -1, 32)
+	case TimestampMicros:
+		buf = append(buf, "ts:"...)
+		buf = strconv.AppendInt(buf, int64(val), 10)
+	case Time:
+		// Key on the full microsecond value so timestamps that only differ
+		// below the second still land in separate groups.
+		buf = append(buf, "ts:"...)
+		buf = strconv.AppendInt(buf, val.TotalMicroseconds(), 10)
 	default:
 		buf = fmt.Appendf(buf, "?:%v", val)
 	}
@@ -5959,7 +6161,7 @@ func allProjectedInOrderBy(requestedFields []Field, orderBy []OrderBy) bool {
 }
 
 func (t *Table) sequentialScan(ctx context.Context, scan Scan, selectedFields []Field, out func(Row) error) error {
-	if t.virtualRows != nil {
+	if t.isVirtual() {
 		return t.virtualSequentialScan(ctx, scan, out)
 	}
 	if t.parallelScan {
@@ -5985,11 +6187,12 @@ func (t *Table) sequentialScan(ctx context.Context, scan Scan, selectedFields []
 		twoPhase = maskHasTrue(filterMask) && !masksEqual(filterMask, fullMask)
 	}
 
-	page, err := t.pager.ReadPage(ctx, cursor.PageIdx)
+	pageFilter := t.compilePageHintFilter(scan.Filters)
+	page, err := t.readScanPage(ctx, cursor, pageFilter)
 	if err != nil {
 		return fmt.Errorf("sequential scan: %w", err)
 	}
-	cursor.EndOfTable = page.LeafNode.Header.Cells == 0
+	cursor.EndOfTable = cursor.EndOfTable || page.LeafNode.Header.Cells == 0
 
 	for !cursor.EndOfTable {
 		if err := ctx.Err(); err != nil {
@@ -5998,10 +6201,13 @@ func (t *Table) sequentialScan(ctx context.Context, scan Scan, selectedFields []
 
 		// Re-read page when the cursor crossed a page boundary.
 		if page.Index != cursor.PageIdx {
-			page, err = t.pager.ReadPage(ctx, cursor.PageIdx)
+			page, err = t.readScanPage(ctx, cursor, pageFilter)
 			if err != nil {
 				return fmt.Errorf("sequential scan: %w", err)
 			}
+			if cursor.EndOfTable {
+				break
+			}
 		}
 
 		if cursor.CellIdx > page.LeafNode.Header.Cells-1 || len(page.LeafNode.Cells) == 0 {
@@ -6068,7 +6274,7 @@ func (t *Table) sequentialScan(ctx context.Context, scan Scan, selectedFields []
 // materialise from RowView at the predicate boundary. Virtual tables fall back to
 // the general sequentialScan path because their rows are already materialised.
 func (t *Table) countSequentialScanZeroAlloc(ctx context.Context, scan Scan, selectedFields []Field) (StatementResult, error) {
-	if t.virtualRows != nil {
+	if t.isVirtual() {
 		var count int64
 		err := t.sequentialScan(ctx, scan, selectedFields, func(Row) error {
 			count += 1
@@ -6091,11 +6297,12 @@ func (t *Table) countSequentialScanZeroAlloc(ctx context.Context, scan Scan, sel
 	fullMask := selectedColumnsMask(t.Columns, selectedFields)
 	tableFilter := compileScanFilter(t.Columns, scan.Filters)
 
-	page, err := t.pager.ReadPage(ctx, cursor.PageIdx)
+	pageFilter := t.compilePageHintFilter(scan.Filters)
+	page, err := t.readScanPage(ctx, cursor, pageFilter)
 	if err != nil {
 		return StatementResult{}, fmt.Errorf("count sequential scan: %w", err)
 	}
-	cursor.EndOfTable = page.LeafNode.Header.Cells == 0
+	cursor.EndOfTable = cursor.EndOfTable || page.LeafNode.Header.Cells == 0
 
 	var count int64
 	for !cursor.EndOfTable {
@@ -6104,10 +6311,13 @@ func (t *Table) countSequentialScanZeroAlloc(ctx context.Context, scan Scan, sel
 		}
 
 		if page.Index != cursor.PageIdx {
-			page, err = t.pager.ReadPage(ctx, cursor.PageIdx)
+			page, err = t.readScanPage(ctx, cursor, pageFilter)
 			if err != nil {
 				return StatementResult{}, fmt.Errorf("count sequential scan: %w", err)
 			}
+			if cursor.EndOfTable {
+				break
+			}
 		}
 
 		cell := page.LeafNode.Cells[cursor.CellIdx]
@@ -6206,11 +6416,12 @@ func (t *Table) countSequentialScanRowView(ctx context.Context, scan Scan) (Stat
 	if err != nil {
 		return StatementResult{}, err
 	}
-	page, err := t.pager.ReadPage(ctx, cursor.PageIdx)
+	pageFilter := t.compilePageHintFilter(scan.Filters)
+	page, err := t.readScanPage(ctx, cursor, pageFilter)
 	if err != nil {
 		return StatementResult{}, fmt.Errorf("count row view sequential scan: %w", err)
 	}
-	cursor.EndOfTable = page.LeafNode.Header.Cells == 0
+	cursor.EndOfTable = cursor.EndOfTable || page.LeafNode.Header.Cells == 0
 
 	var count int64
 	for !cursor.EndOfTable {
@@ -6219,10 +6430,13 @@ func (t *Table) countSequentialScanRowView(ctx context.Context, scan Scan) (Stat
 		}
 
 		if page.Index != cursor.PageIdx {
-			page, err = t.pager.ReadPage(ctx, cursor.PageIdx)
+			page, err = t.readScanPage(ctx, cursor, pageFilter)
 			if err != nil {
 				return StatementResult{}, fmt.Errorf("count row view sequential scan: %w", err)
 			}
+			if cursor.EndOfTable {
+				break
+			}
 		}
 
 		cell := page.LeafNode.Cells[cursor.CellIdx]
@@ -6497,25 +6711,32 @@ func jsonContainsLiteralCondition(cond Condition) (string, string, bool) {
 	return columnName, query, true
 }
 
-// virtualSequentialScan iterates the table's in-memory virtualRows, applies the
-// scan filter, and calls out for each matching row.  It is only called when
-// t.virtualRows != nil (derived-table virtual tables).
+// virtualSequentialScan iterates the table's in-memory virtualRows, or the
+// rows streamed by virtualScan, applies the scan filter, and calls out for
+// each matching row. It is only called when t.isVirtual() (derived-table and
+// rowid virtual tables).
 func (t *Table) virtualSequentialScan(ctx context.Context, scan Scan, out func(Row) error) error {
 	tableFilter := compileScanFilter(t.Columns, scan.Filters)
-	for _, row := range t.virtualRows {
-		if err := ctx.Err(); err != nil {
-			return err
-		}
+	visit := func(row Row) error {
 		if tableFilter != nil {
 			ok, err := tableFilter(row)
 			if err != nil {
 				return err
 			}
 			if !ok {
-				continue
+				return nil
 			}
 		}
-		if err := out(row); err != nil {
+		return out(row)
+	}
+	if t.virtualScan != nil {
+		return t.virtualScan(ctx, visit)
+	}
+	for _, row := range t.virtualRows {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := visit(row); err != nil {
 			return err
 		}
 	}
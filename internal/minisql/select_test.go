@@ -397,7 +397,7 @@ func TestTable_Select(t *testing.T) {
 		sort.SliceStable(expected, func(i, j int) bool {
 			ageI, _ := expected[i].GetValue("age")
 			ageJ, _ := expected[j].GetValue("age")
-			cmp := compareValues(ageI, ageJ)
+			cmp := compareDefaultNulls(ageI, ageJ, Asc)
 			if cmp != 0 {
 				return cmp < 0
 			}
@@ -427,7 +427,7 @@ func TestTable_Select(t *testing.T) {
 		sort.SliceStable(expected, func(i, j int) bool {
 			ageI, _ := expected[i].GetValue("age")
 			ageJ, _ := expected[j].GetValue("age")
-			cmp := compareValues(ageI, ageJ)
+			cmp := compareDefaultNulls(ageI, ageJ, Asc)
 			if cmp != 0 {
 				return cmp < 0
 			}
@@ -458,13 +458,13 @@ func TestTable_Select(t *testing.T) {
 		sort.SliceStable(expected, func(i, j int) bool {
 			ageI, _ := expected[i].GetValue("age")
 			ageJ, _ := expected[j].GetValue("age")
-			if cmp := compareValues(ageI, ageJ); cmp != 0 {
+			if cmp := compareDefaultNulls(ageI, ageJ, Asc); cmp != 0 {
 				return cmp < 0
 			}
 			verI, _ := expected[i].GetValue("verified")
 			verJ, _ := expected[j].GetValue("verified")
-			if cmp := compareValues(verI, verJ); cmp != 0 {
-				return cmp > 0 // DESC
+			if cmp := compareDefaultNulls(verI, verJ, Desc); cmp != 0 {
+				return cmp < 0
 			}
 			emailI, _ := expected[i].GetValue("email")
 			emailJ, _ := expected[j].GetValue("email")
@@ -1297,3 +1297,28 @@ func collectRows(ctx context.Context, r StatementResult) []Row {
 	}
 	return results
 }
+
+// compareDefaultNulls orders a and b the way ORDER BY does without NULLS
+// FIRST or NULLS LAST: NULLs sort last for ASC and first for DESC, and other
+// values compare with compareValues.
+func compareDefaultNulls(a, b OptionalValue, direction Direction) int {
+	switch {
+	case !a.Valid && !b.Valid:
+		return 0
+	case !a.Valid:
+		if direction == Asc {
+			return 1
+		}
+		return -1
+	case !b.Valid:
+		if direction == Asc {
+			return -1
+		}
+		return 1
+	}
+	cmp := compareValues(a, b)
+	if direction == Desc {
+		return -cmp
+	}
+	return cmp
+}
@@ -90,8 +90,13 @@ func hasINSubqueryConditions(conds OneOrMore) bool {
 // When a semi-join is added and the outer statement has no table alias, the
 // alias is set to stmt.TableName so that the join planner can form correct
 // ON conditions.
+//
+// Only a WHERE clause without OR is rewritten: a join filters every outer row,
+// so an IN condition that is one alternative of an OR cannot become one. The
+// subquery's own WHERE must not contain OR either, because its conditions are
+// merged into the outer AND group.
 func liftINSubqueriesToSemiJoins(stmt Statement) Statement {
-	if !hasINSubqueryConditions(stmt.Conditions) {
+	if len(stmt.Conditions) != 1 || !hasINSubqueryConditions(stmt.Conditions) {
 		return stmt
 	}
 	outerTables := outerTableNames(stmt)
@@ -102,7 +107,7 @@ func liftINSubqueriesToSemiJoins(stmt Statement) Statement {
 	// and extractJoinColumnPairs matches on "" == "".
 	outerAlias := stmt.TableAlias
 
-	extraConditions := make(OneOrMore, 0)
+	extraConditions := make(Conditions, 0)
 	newConditions := make(OneOrMore, 0, len(stmt.Conditions))
 
 	for _, group := range stmt.Conditions {
@@ -122,7 +127,7 @@ func liftINSubqueriesToSemiJoins(stmt Statement) Statement {
 			}
 
 			innerCol, eligible := semiJoinEligible(*subStmt)
-			if !eligible {
+			if !eligible || len(subStmt.Conditions) > 1 {
 				newGroup = append(newGroup, cond)
 				continue
 			}
@@ -156,11 +161,9 @@ func liftINSubqueriesToSemiJoins(stmt Statement) Statement {
 			// Re-prefix inner WHERE conditions with semiAlias so that
 			// pushDownFilters routes them to the semi-join's scan.
 			for _, innerGroup := range subStmt.Conditions {
-				rewritten := make(Conditions, len(innerGroup))
-				for k, innerCond := range innerGroup {
-					rewritten[k] = prefixConditionAlias(innerCond, semiAlias)
+				for _, innerCond := range innerGroup {
+					extraConditions = append(extraConditions, prefixConditionAlias(innerCond, semiAlias))
 				}
-				extraConditions = append(extraConditions, rewritten)
 			}
 
 			// Mark the inner table as used so a second IN clause on the same
@@ -176,14 +179,13 @@ func liftINSubqueriesToSemiJoins(stmt Statement) Statement {
 			// Drop the original IN condition — the semi-join replaces it.
 		}
 
+		// Append pushed-down inner conditions after the outer conditions so
+		// that pushDownFilters can distribute them to the right scan plan.
+		newGroup = append(newGroup, extraConditions...)
 		if len(newGroup) > 0 {
 			newConditions = append(newConditions, newGroup)
 		}
 	}
-
-	// Append pushed-down inner conditions after the outer conditions so that
-	// pushDownFilters can distribute them to the right scan plan.
-	newConditions = append(newConditions, extraConditions...)
 	stmt.Conditions = newConditions
 
 	return stmt
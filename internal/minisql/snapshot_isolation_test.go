@@ -3,6 +3,7 @@ package minisql
 import (
 	"context"
 	"errors"
+	"fmt"
 	"math"
 	"sync"
 	"testing"
@@ -363,3 +364,36 @@ func TestSnapshotIsolation_minActiveSnapshotSeqLocked(t *testing.T) {
 	txManager.mu.Unlock()
 	assert.Equal(t, uint64(math.MaxUint64), got)
 }
+
+// TestSnapshotIsolation_StreamingQueryDoesNotBlockWriters verifies that a
+// SELECT streamed through Database.Query keeps reading its snapshot while
+// writers commit between two calls to Next, and that the writers are not
+// blocked by the open cursor.
+func TestSnapshotIsolation_StreamingQueryDoesNotBlockWriters(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	db := newQueryAPITestDB(t)
+	for i := 1; i <= 3; i++ {
+		_, err := db.Exec(ctx, queryAPIInsertSQL, i, fmt.Sprintf("row %d", i), nil, nil, nil)
+		require.NoError(t, err)
+	}
+
+	rows, err := db.Query(ctx, queryAPISelectSQL)
+	require.NoError(t, err)
+	defer rows.Close()
+	require.True(t, rows.Next())
+
+	n, err := db.Exec(ctx, queryAPIInsertSQL, 4, "row 4", nil, nil, nil)
+	require.NoError(t, err, "a writer must not wait for the open cursor")
+	assert.Equal(t, int64(1), n)
+
+	seen := 1
+	for rows.Next() {
+		seen++
+	}
+	require.NoError(t, rows.Err())
+	assert.Equal(t, 3, seen, "the cursor must not see the row inserted after it started")
+
+	assert.Equal(t, 4, countRowsInDB(t, db, "events"))
+}
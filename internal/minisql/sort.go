@@ -22,6 +22,38 @@ func evalOrderByValue(clause OrderBy, row Row) (OptionalValue, bool, error) {
 	return val, found, nil
 }
 
+// nullsFirst reports whether NULLs sort before other values for this clause.
+func (o OrderBy) nullsFirst() bool {
+	switch o.Nulls {
+	case NullsFirst:
+		return true
+	case NullsLast:
+		return false
+	default:
+		return o.Direction == Desc
+	}
+}
+
+// compare orders two sort key values under the clause's direction and NULL
+// placement. A negative result means a sorts before b.
+func (o OrderBy) compare(a, b OptionalValue) int {
+	if !a.Valid || !b.Valid {
+		switch {
+		case a.Valid == b.Valid:
+			return 0
+		case !a.Valid == o.nullsFirst():
+			return -1
+		default:
+			return 1
+		}
+	}
+	cmp := compareAny(a.Value, b.Value)
+	if o.Direction == Desc {
+		return -cmp
+	}
+	return cmp
+}
+
 func (t *Table) sortRows(rows []Row, orderBy []OrderBy) error {
 	if len(orderBy) == 0 {
 		return nil
@@ -47,13 +79,10 @@ func (t *Table) sortRows(rows []Row, orderBy []OrderBy) error {
 				continue
 			}
 
-			cmp := compareValues(valI, valJ)
+			cmp := clause.compare(valI, valJ)
 			if cmp == 0 {
 				continue
 			}
-			if clause.Direction == Desc {
-				return cmp > 0
-			}
 			return cmp < 0
 		}
 		return false
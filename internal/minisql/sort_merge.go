@@ -25,13 +25,10 @@ func (h *mergeHeap) Less(i, j int) bool {
 		if !foundI || !foundJ {
 			continue
 		}
-		cmp := compareValues(vi, vj)
+		cmp := clause.compare(vi, vj)
 		if cmp == 0 {
 			continue
 		}
-		if clause.Direction == Desc {
-			return cmp > 0
-		}
 		return cmp < 0
 	}
 	return false
@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"math"
 	"os"
 )
 
@@ -19,9 +20,16 @@ const sortRunBufSize = 64 * 1024
 // Record format (per row):
 //
 //	[4 bytes: value-length, big-endian uint32]
+//	[4 bytes: overflow-length, big-endian uint32]
 //	[8 bytes: RowID, little-endian uint64]
 //	[8 bytes: NullBitmask, little-endian uint64]
 //	[value bytes, as produced by Row.Marshal()]
+//	[overflow bytes, as produced by marshalRunOverflow()]
+//
+// Row.Marshal stores overflow TEXT and VECTOR values as page pointers only,
+// but rows being sorted carry the assembled payload (which may not even have
+// been written to pages yet, e.g. computed values), so the payload is written
+// alongside the row and restored when the run is read back.
 //
 // All writes go through a bufio.Writer so that the two Write calls per row
 // (header + value) are coalesced into large sequential OS writes rather than
@@ -44,10 +52,12 @@ func (w *runWriter) writeRow(r Row) error {
 	if err != nil {
 		return fmt.Errorf("sort run: marshal row: %w", err)
 	}
-	var hdr [20]byte
+	overflowBytes := marshalRunOverflow(r)
+	var hdr [24]byte
 	binary.BigEndian.PutUint32(hdr[0:4], uint32(len(valueBytes)))
-	binary.LittleEndian.PutUint64(hdr[4:12], uint64(r.Key))
-	binary.LittleEndian.PutUint64(hdr[12:20], r.NullBitmask())
+	binary.BigEndian.PutUint32(hdr[4:8], uint32(len(overflowBytes)))
+	binary.LittleEndian.PutUint64(hdr[8:16], uint64(r.Key))
+	binary.LittleEndian.PutUint64(hdr[16:24], r.NullBitmask())
 	if _, err := w.buf.Write(hdr[:]); err != nil {
 		return fmt.Errorf("sort run: write header: %w", err)
 	}
@@ -56,6 +66,79 @@ func (w *runWriter) writeRow(r Row) error {
 			return fmt.Errorf("sort run: write value: %w", err)
 		}
 	}
+	if len(overflowBytes) > 0 {
+		if _, err := w.buf.Write(overflowBytes); err != nil {
+			return fmt.Errorf("sort run: write overflow: %w", err)
+		}
+	}
+	return nil
+}
+
+// marshalRunOverflow encodes the assembled payload of every overflow TEXT and
+// VECTOR value in r as a sequence of
+//
+//	[2 bytes: column index, big-endian uint16]
+//	[4 bytes: payload length, big-endian uint32]
+//	[payload bytes; vectors as little-endian float32 bits]
+//
+// Returns nil when the row has no overflow values.
+func marshalRunOverflow(r Row) []byte {
+	var buf []byte
+	for i, value := range r.Values {
+		if !value.Valid {
+			continue
+		}
+		switch v := value.Value.(type) {
+		case TextPointer:
+			if v.IsInline() {
+				continue
+			}
+			buf = binary.BigEndian.AppendUint16(buf, uint16(i))
+			buf = binary.BigEndian.AppendUint32(buf, uint32(len(v.Data)))
+			buf = append(buf, v.Data...)
+		case VectorPointer:
+			if len(v.Data) == 0 {
+				continue
+			}
+			buf = binary.BigEndian.AppendUint16(buf, uint16(i))
+			buf = binary.BigEndian.AppendUint32(buf, uint32(len(v.Data)*4))
+			for _, f := range v.Data {
+				buf = binary.LittleEndian.AppendUint32(buf, math.Float32bits(f))
+			}
+		}
+	}
+	return buf
+}
+
+// unmarshalRunOverflow restores the payloads written by marshalRunOverflow
+// into the values of row.
+func unmarshalRunOverflow(buf []byte, row Row) error {
+	for len(buf) > 0 {
+		if len(buf) < 6 {
+			return errors.New("truncated overflow entry")
+		}
+		idx := int(binary.BigEndian.Uint16(buf[0:2]))
+		n := int(binary.BigEndian.Uint32(buf[2:6]))
+		buf = buf[6:]
+		if idx >= len(row.Values) || n > len(buf) {
+			return fmt.Errorf("invalid overflow entry for column %d", idx)
+		}
+		payload := buf[:n]
+		buf = buf[n:]
+		switch v := row.Values[idx].Value.(type) {
+		case TextPointer:
+			v.Data = payload
+			row.Values[idx].Value = v
+		case VectorPointer:
+			v.Data = make([]float32, n/4)
+			for i := range v.Data {
+				v.Data[i] = math.Float32frombits(binary.LittleEndian.Uint32(payload[i*4:]))
+			}
+			row.Values[idx].Value = v
+		default:
+			return fmt.Errorf("unexpected overflow entry for column %d of type %T", idx, v)
+		}
+	}
 	return nil
 }
 
@@ -118,7 +201,7 @@ func (rr *runReader) Next() {
 }
 
 func (rr *runReader) advance() {
-	var hdr [20]byte
+	var hdr [24]byte
 	_, err := io.ReadFull(rr.buf, hdr[:])
 	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
 		rr.done = true
@@ -130,25 +213,31 @@ func (rr *runReader) advance() {
 		return
 	}
 	valLen := binary.BigEndian.Uint32(hdr[0:4])
-	key := RowID(binary.LittleEndian.Uint64(hdr[4:12]))
-	nullBitmask := binary.LittleEndian.Uint64(hdr[12:20])
+	overflowLen := binary.BigEndian.Uint32(hdr[4:8])
+	key := RowID(binary.LittleEndian.Uint64(hdr[8:16]))
+	nullBitmask := binary.LittleEndian.Uint64(hdr[16:24])
 
 	// Each row gets its own buffer so that TextPointer.Data (which sub-slices
 	// directly into valueBuf) remains valid for the lifetime of the row.
-	valueBuf := make([]byte, valLen)
-	if valLen > 0 {
+	valueBuf := make([]byte, valLen+overflowLen)
+	if len(valueBuf) > 0 {
 		if _, err := io.ReadFull(rr.buf, valueBuf); err != nil {
 			rr.err = fmt.Errorf("sort run: read value bytes: %w", err)
 			rr.done = true
 			return
 		}
 	}
-	row, err := UnmarshalRow(valueBuf, rr.columns, key, nullBitmask)
+	row, err := UnmarshalRow(valueBuf[:valLen], rr.columns, key, nullBitmask)
 	if err != nil {
 		rr.err = fmt.Errorf("sort run: unmarshal row: %w", err)
 		rr.done = true
 		return
 	}
+	if err := unmarshalRunOverflow(valueBuf[valLen:], row); err != nil {
+		rr.err = fmt.Errorf("sort run: unmarshal overflow: %w", err)
+		rr.done = true
+		return
+	}
 	rr.current = row
 }
 
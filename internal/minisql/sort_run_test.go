@@ -1,6 +1,7 @@
 package minisql
 
 import (
+	"bytes"
 	"os"
 	"testing"
 
@@ -144,6 +145,45 @@ func TestRunWriterReader_NullValues(t *testing.T) {
 	_ = rr.close()
 }
 
+// TestRunWriterReader_OverflowValues verifies that the assembled payload of
+// overflow TEXT and VECTOR values survives the round trip even though
+// Row.Marshal only stores their page pointers.
+func TestRunWriterReader_OverflowValues(t *testing.T) {
+	cols := []Column{
+		{Name: "body", Kind: Text},
+		{Name: "short", Kind: Text},
+		{Name: "vec", Kind: Vector, Size: 3},
+	}
+	long := bytes.Repeat([]byte("abc"), int(MaxInlineVarchar))
+	row := Row{
+		Key:     RowID(3),
+		Columns: cols,
+		Values: []OptionalValue{
+			{Value: TextPointer{Data: long, Length: uint32(len(long)), FirstPage: 12}, Valid: true},
+			{Value: NewTextPointer([]byte("inline")), Valid: true},
+			{Value: VectorPointer{Data: []float32{1.5, -2, 0.25}, Dims: 3, FirstPage: 13}, Valid: true},
+		},
+	}
+
+	w, err := newRunWriter()
+	require.NoError(t, err)
+	require.NoError(t, w.writeRow(row))
+	path := w.filePath()
+	require.NoError(t, w.close())
+	defer os.Remove(path)
+
+	rr, err := newRunReader(path, cols)
+	require.NoError(t, err)
+	got := rr.Row()
+	assert.Equal(t, long, got.Values[0].Value.(TextPointer).Data)
+	assert.Equal(t, "inline", got.Values[1].Value.(TextPointer).String())
+	assert.Equal(t, []float32{1.5, -2, 0.25}, got.Values[2].Value.(VectorPointer).Data)
+	rr.Next()
+	assert.True(t, rr.Done())
+	require.NoError(t, rr.Err())
+	_ = rr.close()
+}
+
 func TestUnmarshalRow_RoundTrip(t *testing.T) {
 	row := makeRoundTripRow(RowID(42))
 	buf, err := row.Marshal()
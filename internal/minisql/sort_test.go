@@ -163,3 +163,36 @@ func TestRowHeap_ExprOrderBy(t *testing.T) {
 		assert.Equal(t, want[i], rowStrVal(row, "ver"), "position %d", i)
 	}
 }
+
+// ── OrderBy.compare ──────────────────────────────────────────────────────────
+
+func TestOrderBy_Compare(t *testing.T) {
+	t.Parallel()
+
+	var (
+		null = OptionalValue{}
+		one  = OptionalValue{Value: int64(1), Valid: true}
+		two  = OptionalValue{Value: int64(2), Valid: true}
+	)
+
+	testCases := []struct {
+		Name    string
+		OrderBy OrderBy
+		A, B    OptionalValue
+		Want    int
+	}{
+		{"ASC values", OrderBy{Direction: Asc}, one, two, -1},
+		{"DESC values", OrderBy{Direction: Desc}, one, two, 1},
+		{"ASC puts NULLs last by default", OrderBy{Direction: Asc}, null, one, 1},
+		{"DESC puts NULLs first by default", OrderBy{Direction: Desc}, null, one, -1},
+		{"ASC NULLS FIRST", OrderBy{Direction: Asc, Nulls: NullsFirst}, null, one, -1},
+		{"DESC NULLS LAST", OrderBy{Direction: Desc, Nulls: NullsLast}, one, null, -1},
+		{"Two NULLs are equal", OrderBy{Direction: Asc, Nulls: NullsFirst}, null, null, 0},
+	}
+
+	for _, aTestCase := range testCases {
+		t.Run(aTestCase.Name, func(t *testing.T) {
+			assert.Equal(t, aTestCase.Want, aTestCase.OrderBy.compare(aTestCase.A, aTestCase.B))
+		})
+	}
+}
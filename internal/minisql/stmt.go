@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"maps"
+	"slices"
 	"strings"
 	"sync"
 	"unicode/utf8"
@@ -55,6 +56,10 @@ const (
 	Explain
 	// AlterTable is an ALTER TABLE DDL statement (ADD/DROP/RENAME COLUMN, RENAME TO).
 	AlterTable
+	// AlterIndex is an ALTER INDEX … RENAME TO DDL statement.
+	AlterIndex
+	// TruncateTable is a TRUNCATE TABLE statement that removes every row.
+	TruncateTable
 )
 
 // AlterTableAction identifies which operation an ALTER TABLE statement performs.
@@ -64,7 +69,7 @@ type AlterTableAction int
 const (
 	// AlterTableAddColumn adds a new column to an existing table.
 	AlterTableAddColumn AlterTableAction = iota + 1
-	// AlterTableDropColumn tombstones a column so new rows omit it.
+	// AlterTableDropColumn removes a column and rewrites existing rows without it.
 	AlterTableDropColumn
 	// AlterTableRenameColumn renames an existing column in-place.
 	AlterTableRenameColumn
@@ -106,6 +111,10 @@ func (s StatementKind) String() string {
 		return "EXPLAIN"
 	case AlterTable:
 		return "ALTER TABLE"
+	case AlterIndex:
+		return "ALTER INDEX"
+	case TruncateTable:
+		return "TRUNCATE TABLE"
 	default:
 		return "UNKNOWN"
 	}
@@ -116,8 +125,9 @@ type AggregateKind int
 
 // AggregateKind constants enumerate the supported aggregate functions.
 const (
-	// AggregateCount is the COUNT(*) aggregate function.
-	AggregateCount AggregateKind = iota + 1 // COUNT(*)
+	// AggregateCount is the COUNT(*) aggregate function, or COUNT(col) when
+	// the AggregateExpr names a column, which counts only non-NULL values.
+	AggregateCount AggregateKind = iota + 1 // COUNT(*), COUNT(col)
 	// AggregateSum is the SUM aggregate function.
 	AggregateSum // SUM(col)
 	// AggregateAvg is the AVG aggregate function.
@@ -151,8 +161,9 @@ func (k AggregateKind) String() string {
 // A zero-value AggregateExpr (Kind == 0) means the corresponding field is not an aggregate.
 // Aggregates is only populated when the query contains at least one aggregate function.
 type AggregateExpr struct {
-	Column string
-	Kind   AggregateKind
+	Column   string
+	Kind     AggregateKind
+	Distinct bool // COUNT(DISTINCT col): count each non-NULL value once
 }
 
 // ColumnKind identifies the data type of a table column.
@@ -206,6 +217,9 @@ func (k ColumnKind) String() string {
 	case Vector:
 		return "vector"
 	default:
+		if name, ok := customColumnKindName(k); ok {
+			return name
+		}
 		return "unknown"
 	}
 }
@@ -237,14 +251,31 @@ type Column struct {
 	Nullable                bool
 	DefaultValueNow         bool
 	DefaultValueGenRandUUID bool
-	// Deleted marks a column that has been dropped via ALTER TABLE DROP COLUMN.
-	// Deleted columns remain in the schema so that existing cells (which still
-	// carry their bytes at that position) can be decoded correctly.  They are
-	// invisible to all query processing and new rows write TypeCodeNull + set
-	// the NullBitmask bit for the slot.
+	// OnUpdateNow sets the column to the current time on every UPDATE that
+	// does not assign it explicitly (ON UPDATE NOW()).
+	OnUpdateNow bool
+	// Generated holds the raw SQL text of a GENERATED ALWAYS AS (…) STORED
+	// expression and GeneratedExpr its parsed form.  A generated column is
+	// computed from the other columns of the same row on every INSERT and
+	// UPDATE and stored like a normal column; it cannot be written directly.
+	Generated     string
+	GeneratedExpr *Expr
+	// MinMax enables per-leaf-page min/max hints for the column (see
+	// PageHint), letting range scans skip pages that cannot match.
+	MinMax bool
+	// Deleted marks a tombstoned column left behind by databases written before
+	// DROP COLUMN physically removed columns.  Tombstones remain in the schema so
+	// that existing cells (which still carry their bytes at that position) can be
+	// decoded correctly.  They are invisible to all query processing and new rows
+	// write TypeCodeNull + set the NullBitmask bit for the slot.
 	Deleted bool
 }
 
+// IsGenerated reports whether the column is a generated (computed) column.
+func (c Column) IsGenerated() bool {
+	return c.GeneratedExpr != nil
+}
+
 // MayUseOverflowText reports whether values in this column may live on overflow pages.
 func (c Column) MayUseOverflowText() bool {
 	return c.Kind == Text || c.Kind == JSON || (c.Kind == Varchar && c.Size > MaxInlineVarchar)
@@ -318,6 +349,31 @@ func (d Direction) String() string {
 	}
 }
 
+// NullsOrder specifies where NULLs sort in an ORDER BY clause.
+type NullsOrder int
+
+// NullsOrder constants. By default NULLs sort as if larger than any value, so
+// they come last in ascending and first in descending order.
+const (
+	// NullsDefault is NULLS LAST for ASC and NULLS FIRST for DESC.
+	NullsDefault NullsOrder = iota
+	// NullsFirst sorts NULLs before all other values.
+	NullsFirst
+	// NullsLast sorts NULLs after all other values.
+	NullsLast
+)
+
+func (n NullsOrder) String() string {
+	switch n {
+	case NullsFirst:
+		return "NULLS FIRST"
+	case NullsLast:
+		return "NULLS LAST"
+	default:
+		return ""
+	}
+}
+
 // IndexMethod identifies the access method used by a secondary index.
 type IndexMethod int
 
@@ -358,10 +414,12 @@ func isSupportedIndexTokenizer(tokenizer string) bool {
 	return tokenizer == TextSearchTokenizerSimple
 }
 
-// OrderBy pairs a field with its sort direction for an ORDER BY clause.
+// OrderBy pairs a field with its sort direction and NULL placement for an
+// ORDER BY clause.
 type OrderBy struct {
 	Field     Field
 	Direction Direction
+	Nulls     NullsOrder
 }
 
 // Function represents a SQL scalar function reference by name.
@@ -378,8 +436,11 @@ var FunctionNow = Function{Name: nowFunctionName}
 // FunctionGenRandomUUID is the sentinel value used for the GEN_RANDOM_UUID() scalar function in default values.
 var FunctionGenRandomUUID = Function{Name: genRandomUUIDFunctionName}
 
-// Placeholder is the sentinel type for a ? bind parameter in a prepared statement.
-type Placeholder struct{}
+// Placeholder is the sentinel type for a bind parameter in a prepared
+// statement: a positional ? or, when Name is set, a named :name.
+type Placeholder struct {
+	Name string
+}
 
 // ExcludedRef represents a reference to EXCLUDED.column_name inside an
 // ON CONFLICT DO UPDATE SET clause.  At upsert time it resolves to the value
@@ -436,18 +497,21 @@ type Statement struct {
 	Conditions           OneOrMore
 	ReturningFields      []Field
 	ExplainStatement     *Statement
-	FromSubquery         *Statement // non-nil when FROM clause is a derived table
-	FromSubqueryAlias    string     // alias for the derived table (e.g. "t" in FROM (...) t)
-	UpdateFromTable      string     // table name in UPDATE … FROM clause (empty = no UPDATE FROM)
-	UpdateFromAlias      string     // alias for the UPDATE FROM table (e.g. "d" in FROM departments d)
-	UpdateFromSubquery   *Statement // non-nil when UPDATE FROM clause is a subquery
-	InsertSelectStmt     *Statement // non-nil for INSERT INTO … SELECT
-	CTEs                 []CTE      // non-nil for WITH … SELECT statements
+	FromSubquery         *Statement        // non-nil when FROM clause is a derived table
+	FromSubqueryAlias    string            // alias for the derived table (e.g. "t" in FROM (...) t)
+	UpdateFromTable      string            // table name in UPDATE … FROM clause (empty = no UPDATE FROM)
+	UpdateFromAlias      string            // alias for the UPDATE FROM table (e.g. "d" in FROM departments d)
+	UpdateFromSubquery   *Statement        // non-nil when UPDATE FROM clause is a subquery
+	UpdateFromValues     [][]OptionalValue // literal rows of UPDATE … FROM (VALUES …) alias(cols)
+	UpdateFromColumns    []string          // column names declared for the UPDATE FROM VALUES alias
+	InsertSelectStmt     *Statement        // non-nil for INSERT INTO … SELECT
+	CTEs                 []CTE             // non-nil for WITH … SELECT statements
 	// ALTER TABLE fields
 	AlterTableAction AlterTableAction // which ALTER TABLE operation to perform
 	AlterColumnName  string           // column being dropped or old name for RENAME COLUMN
 	NewColumnName    string           // new column name for RENAME COLUMN … TO
 	NewTableName     string           // new table name for RENAME TO
+	NewIndexName     string           // new index name for ALTER INDEX … RENAME TO
 	// CacheKey is the original SQL text set by PrepareStatement; it is the key
 	// used to look up and store the query plan in the plan cache.  Empty for
 	// statements that were not prepared via PrepareStatement (ad-hoc queries).
@@ -458,10 +522,30 @@ type Statement struct {
 	IfNotExists    bool
 	ExplainAnalyze bool
 	Distinct       bool
+	// ForUpdate marks a SELECT … FOR UPDATE.  The selected rows are protected
+	// from concurrent modification until the enclosing transaction ends; with
+	// a single active writer this is achieved by running the SELECT in a
+	// write transaction rather than a read-only snapshot.
+	ForUpdate bool
+	// ReturnAffectedKeys asks UPDATE and DELETE to collect the key of every
+	// modified row into StatementResult.AffectedKeys. It is a lighter
+	// alternative to RETURNING for change-data-capture style consumers.
+	ReturnAffectedKeys bool
+	// Cascade marks DROP TABLE … CASCADE: tables whose foreign keys reference
+	// the dropped table are dropped along with it instead of blocking the drop.
+	Cascade bool
 	// insertCache is non-nil for INSERT statements prepared via PrepareStatement.
 	// It caches the static column-order metadata computed by prepareInsert so that
 	// repeated Exec calls on the same prepared statement skip the per-Exec allocation.
 	insertCache *insertPrepCache
+	// safeMode is set by the Database when safe mode is enabled and the
+	// UPDATE/DELETE statement was written without a WHERE clause.  Validate
+	// rejects such statements with ErrSafeModeNoWhere.
+	safeMode bool
+	// maxIdentifierLength is set by the Database on CREATE TABLE and CREATE
+	// INDEX statements. It stays zero when a persisted schema is re-validated
+	// on load, so names accepted before the limit existed keep working.
+	maxIdentifierLength int
 	// boundArgs holds pending prepared INSERT arguments for the table-aware
 	// prepareInsert path. It is only set for simple prepared INSERT statements
 	// where binding can be safely delayed until table columns are available.
@@ -471,6 +555,10 @@ type Statement struct {
 	// references. Populated at PrepareStatement time; nil means not cached.
 	// The slice is never mutated during execution, so it is safe to share across clones.
 	cachedSelectedFields []Field
+	// joinColumns is the combined, alias-prefixed column list of a JOIN query
+	// (e.g. "u.id", "o.total"). Select sets it so result metadata of joined
+	// fields is resolved against the right table.
+	joinColumns []Column
 }
 
 // HasWindowFuncs reports whether the SELECT field list contains at least one
@@ -485,6 +573,17 @@ func (s Statement) HasWindowFuncs() bool {
 	return false
 }
 
+// HasDistinctAggregate reports whether the statement contains at least one
+// COUNT(DISTINCT col) aggregate.
+func (s Statement) HasDistinctAggregate() bool {
+	for _, agg := range s.Aggregates {
+		if agg.Distinct {
+			return true
+		}
+	}
+	return false
+}
+
 // selectFieldsNeedCopy reports whether BindArguments will write to stmt.Fields
 // for a SELECT statement. This is only true when a SELECT field expression
 // contains a placeholder (e.g. VEC_L2(embedding, ?)).
@@ -499,7 +598,8 @@ func (s Statement) selectFieldsNeedCopy() bool {
 
 // precomputeSelectedFields populates cachedSelectedFields for simple SELECT
 // statements where the set of columns needed from disk is fully determined by
-// the static query structure (no sub-expressions, no subqueries, no JOINs).
+// the static query structure (no sub-expressions, no ORDER BY expressions, no
+// subqueries, no JOINs).
 // Called once at PrepareStatement time; the result is shared across clones.
 func (s *Statement) precomputeSelectedFields() {
 	if s.Kind != Select || s.IsSelectCountAll() || s.IsSelectGroupBy() || s.IsSelectAggregate() {
@@ -508,6 +608,11 @@ func (s *Statement) precomputeSelectedFields() {
 	if s.FromSubquery != nil || len(s.CTEs) > 0 || len(s.Joins) > 0 || len(s.Unions) > 0 {
 		return
 	}
+	for _, clause := range s.OrderBy {
+		if clause.Field.Expr != nil {
+			return
+		}
+	}
 	for _, group := range s.Conditions {
 		for _, cond := range group {
 			if cond.Operand1.Type == OperandExpr || cond.Operand2.Type == OperandExpr ||
@@ -641,6 +746,116 @@ func countExprPlaceholders(e *Expr) int {
 	return count
 }
 
+// PlaceholderNames returns the name of every placeholder in the statement, in
+// the order BindArguments consumes arguments. Positional placeholders have an
+// empty name. A name used more than once appears once per use.
+func (s Statement) PlaceholderNames() []string {
+	if s.Kind == Explain && s.ExplainStatement != nil {
+		return s.ExplainStatement.PlaceholderNames()
+	}
+
+	var names []string
+	for _, cte := range s.CTEs {
+		names = append(names, cte.Body.PlaceholderNames()...)
+	}
+
+	if s.Kind == Insert {
+		for _, anInsert := range s.Inserts {
+			for _, val := range anInsert {
+				switch v := val.Value.(type) {
+				case Placeholder:
+					names = append(names, v.Name)
+				case *Expr:
+					names = appendExprPlaceholderNames(names, v)
+				}
+			}
+		}
+		if s.ConflictAction == ConflictActionDoUpdate {
+			insertFieldCount := len(s.Fields) - len(s.Updates)
+			names = appendUpdatePlaceholderNames(names, s.Fields[max(insertFieldCount, 0):], s.Updates)
+		}
+	}
+
+	if s.Kind == Update {
+		names = appendUpdatePlaceholderNames(names, s.Fields, s.Updates)
+	}
+
+	if s.Kind == Select {
+		for _, field := range s.Fields {
+			names = appendExprPlaceholderNames(names, field.Expr)
+		}
+	}
+
+	for _, condGroup := range s.Conditions {
+		for _, cond := range condGroup {
+			if expr, ok := cond.Operand1.Value.(*Expr); ok && cond.Operand1.Type == OperandExpr {
+				names = appendExprPlaceholderNames(names, expr)
+			}
+			names = appendOperandPlaceholderNames(names, cond.Operand2, true)
+		}
+	}
+
+	for _, condGroup := range s.Having {
+		for _, cond := range condGroup {
+			names = appendOperandPlaceholderNames(names, cond.Operand2, false)
+		}
+	}
+
+	return names
+}
+
+func appendUpdatePlaceholderNames(names []string, fields []Field, updates map[string]OptionalValue) []string {
+	for _, field := range fields {
+		if v, ok := updates[field.Name].Value.(Placeholder); ok {
+			names = append(names, v.Name)
+		}
+	}
+	return names
+}
+
+func appendOperandPlaceholderNames(names []string, operand Operand, withExpr bool) []string {
+	switch operand.Type {
+	case OperandPlaceholder:
+		v, _ := operand.Value.(Placeholder)
+		names = append(names, v.Name)
+	case OperandList:
+		for _, value := range operand.Value.([]any) {
+			if v, ok := value.(Placeholder); ok {
+				names = append(names, v.Name)
+			}
+		}
+	case OperandExpr:
+		if expr, ok := operand.Value.(*Expr); ok && withExpr {
+			names = appendExprPlaceholderNames(names, expr)
+		}
+	}
+	return names
+}
+
+// appendExprPlaceholderNames appends the names of the placeholders in an Expr
+// tree in the order substituteExprPlaceholders visits them.
+func appendExprPlaceholderNames(names []string, e *Expr) []string {
+	if e == nil {
+		return names
+	}
+	if v, ok := e.Literal.(Placeholder); ok {
+		return append(names, v.Name)
+	}
+	names = appendExprPlaceholderNames(names, e.Left)
+	names = appendExprPlaceholderNames(names, e.Right)
+	names = appendExprPlaceholderNames(names, e.CastExpr)
+	names = appendExprPlaceholderNames(names, e.CaseInput)
+	names = appendExprPlaceholderNames(names, e.CaseElse)
+	for _, arg := range e.Args {
+		names = appendExprPlaceholderNames(names, arg)
+	}
+	for _, cl := range e.CaseClauses {
+		names = appendExprPlaceholderNames(names, cl.When)
+		names = appendExprPlaceholderNames(names, cl.Then)
+	}
+	return names
+}
+
 // substituteExprPlaceholders replaces Placeholder{} literals in an Expr tree
 // with values consumed from args (left-to-right). Modifies the tree in-place;
 // callers must operate on a cloned copy.
@@ -698,50 +913,15 @@ func (s Statement) Clone() Statement {
 		}
 	}
 
-	stmt := Statement{
-		Kind:                 s.Kind,
-		CacheKey:             s.CacheKey,
-		IfNotExists:          s.IfNotExists,
-		TableName:            s.TableName,
-		TableAlias:           s.TableAlias,
-		IndexName:            s.IndexName,
-		IndexWhereClause:     s.IndexWhereClause,
-		IndexExpression:      s.IndexExpression,
-		IndexExpressionSQL:   s.IndexExpressionSQL,
-		IndexTokenizer:       s.IndexTokenizer,
-		Target:               s.Target,
-		PragmaName:           s.PragmaName,
-		PragmaValue:          s.PragmaValue,
-		ConflictAction:       s.ConflictAction,
-		Columns:              s.Columns,
-		Distinct:             s.Distinct,
-		Fields:               fields,
-		Aggregates:           s.Aggregates, // slice of value types, safe to share
-		Aliases:              s.Aliases,
-		Inserts:              make([][]OptionalValue, len(s.Inserts)),
-		Functions:            s.Functions,
-		Conditions:           make(OneOrMore, len(s.Conditions)),
-		GroupBy:              s.GroupBy,
-		Having:               s.Having,
-		Joins:                s.Joins,
-		OrderBy:              s.OrderBy,
-		Limit:                s.Limit,
-		Offset:               s.Offset,
-		ReturningFields:      s.ReturningFields,
-		ExplainAnalyze:       s.ExplainAnalyze,
-		IndexMethod:          s.IndexMethod,
-		FromSubqueryAlias:    s.FromSubqueryAlias,
-		UpdateFromTable:      s.UpdateFromTable,
-		UpdateFromAlias:      s.UpdateFromAlias,
-		ForeignKeys:          s.ForeignKeys, // slice of value structs, safe to share
-		AlterTableAction:     s.AlterTableAction,
-		AlterColumnName:      s.AlterColumnName,
-		NewColumnName:        s.NewColumnName,
-		NewTableName:         s.NewTableName,
-		insertCache:          s.insertCache,
-		boundArgs:            s.boundArgs,
-		cachedSelectedFields: s.cachedSelectedFields, // immutable; safe to share
-	}
+	// Start from a shallow copy so that every field is carried over, then give
+	// the clone its own copy of everything execution or binding may modify.
+	// Aggregates, Aliases, Functions, GroupBy, Having, Joins, OrderBy, Columns,
+	// ReturningFields and ForeignKeys are read-only once parsed and stay shared.
+	stmt := s
+	stmt.Fields = fields
+	stmt.Inserts = make([][]OptionalValue, len(s.Inserts))
+	stmt.Conditions = make(OneOrMore, len(s.Conditions))
+	stmt.Updates = nil
 	for i := range s.Inserts {
 		stmt.Inserts[i] = make([]OptionalValue, len(s.Inserts[i]))
 		copy(stmt.Inserts[i], s.Inserts[i])
@@ -782,6 +962,10 @@ func (s Statement) Clone() Statement {
 		inner := s.UpdateFromSubquery.Clone()
 		stmt.UpdateFromSubquery = &inner
 	}
+	if s.InsertSelectStmt != nil {
+		inner := s.InsertSelectStmt.Clone()
+		stmt.InsertSelectStmt = &inner
+	}
 	if len(s.CTEs) > 0 {
 		stmt.CTEs = make([]CTE, len(s.CTEs))
 		for i, cte := range s.CTEs {
@@ -1036,6 +1220,114 @@ func (s Statement) BindArguments(args ...any) (Statement, error) {
 	return stmt, nil
 }
 
+// ErrMixedPlaceholders is returned for a statement that uses both positional
+// (?) and named (:name) placeholders.
+var ErrMixedPlaceholders = errors.New("cannot mix positional (?) and named (:name) placeholders")
+
+// HasNamedPlaceholders reports whether the statement uses named placeholders.
+// It returns ErrMixedPlaceholders when positional ones are used as well.
+func (s Statement) HasNamedPlaceholders() (bool, error) {
+	var positional, named bool
+	for _, name := range s.PlaceholderNames() {
+		if name == "" {
+			positional = true
+		} else {
+			named = true
+		}
+	}
+	if positional && named {
+		return false, ErrMixedPlaceholders
+	}
+	return named, nil
+}
+
+// BindNamed substitutes named :name placeholders with the values in args. A
+// name used more than once is bound to the same value at every use. Values in
+// args that no placeholder refers to are ignored. Like BindArguments, it
+// leaves the original statement unmodified.
+func (s Statement) BindNamed(args map[string]any) (Statement, error) {
+	names := s.PlaceholderNames()
+	values := make([]any, 0, len(names))
+	for _, name := range names {
+		if name == "" {
+			return Statement{}, errors.New("cannot bind named arguments to positional (?) placeholders")
+		}
+		value, ok := args[name]
+		if !ok {
+			return Statement{}, fmt.Errorf("missing value for placeholder :%s", name)
+		}
+		values = append(values, value)
+	}
+	return s.BindArguments(values...)
+}
+
+// BindMany binds one set of arguments per element of rows to a single-row
+// INSERT … VALUES statement and returns one INSERT carrying all the rows, so a
+// batch is prepared and validated once instead of once per row. The original
+// statement is not modified. It handles INSERTs whose placeholders all appear
+// in the VALUES row; callers should fall back to BindArguments per row when ok
+// is false.
+func (s Statement) BindMany(rows [][]any) (Statement, bool, error) {
+	if s.Kind != Insert || len(s.Inserts) != 1 || s.InsertSelectStmt != nil || len(s.CTEs) > 0 {
+		return Statement{}, false, nil
+	}
+
+	var (
+		template      = s.Inserts[0]
+		nPlaceholders = 0
+		hasExprArgs   = false
+	)
+	for _, val := range template {
+		switch v := val.Value.(type) {
+		case Placeholder:
+			nPlaceholders += 1
+		case *Expr:
+			if n := countExprPlaceholders(v); n > 0 {
+				nPlaceholders += n
+				hasExprArgs = true
+			}
+		}
+	}
+	if nPlaceholders != s.NumPlaceholders() {
+		// DO UPDATE SET placeholders would be shared by every row.
+		return Statement{}, false, nil
+	}
+	if len(rows) == 0 {
+		return Statement{}, true, errors.New("no rows to bind")
+	}
+	for i, args := range rows {
+		if len(args) != nPlaceholders {
+			return Statement{}, true, fmt.Errorf("batch row %d: expected %d arguments, got %d", i, nPlaceholders, len(args))
+		}
+	}
+
+	// Fast path: every row shares the template and prepareInsert consumes the
+	// flattened arguments row by row, as it does for a single prepared INSERT.
+	if s.canDelayPreparedInsertBind() && !hasExprArgs {
+		stmt := s
+		stmt.Inserts = make([][]OptionalValue, len(rows))
+		stmt.boundArgs = make([]any, 0, len(rows)*nPlaceholders)
+		for i, args := range rows {
+			stmt.Inserts[i] = template
+			stmt.boundArgs = append(stmt.boundArgs, args...)
+		}
+		return stmt, true, nil
+	}
+
+	single := s
+	single.insertCache = nil // bind eagerly so each row gets its own values
+	stmt := s.Clone()
+	stmt.Inserts = make([][]OptionalValue, len(rows))
+	for i, args := range rows {
+		bound, err := single.BindArguments(args...)
+		if err != nil {
+			return Statement{}, true, fmt.Errorf("batch row %d: %w", i, err)
+		}
+		stmt.Inserts[i] = bound.Inserts[0]
+	}
+	return stmt, true, nil
+}
+
 // BindArgumentsFrom substitutes placeholders by pulling argument values from next.
 // It handles simple UPDATE and DELETE statements without CTEs/subqueries;
 // callers should fall back to BindArguments when ok is false.
@@ -1083,7 +1375,7 @@ func (s Statement) canBindArgumentsFrom() bool {
 		if len(s.CTEs) > 0 || len(s.Having) > 0 || len(s.Unions) > 0 {
 			return false
 		}
-		if s.ExplainStatement != nil || s.FromSubquery != nil || s.UpdateFromSubquery != nil {
+		if s.ExplainStatement != nil || s.FromSubquery != nil || s.UpdateFromSubquery != nil || len(s.UpdateFromValues) > 0 {
 			return false
 		}
 		for _, group := range s.Conditions {
@@ -1174,9 +1466,9 @@ func (s Statement) ReadOnly() bool {
 }
 
 // IsDDL reports whether the statement is a data-definition statement
-// (CREATE/DROP TABLE, CREATE/DROP INDEX, or ALTER TABLE).
+// (CREATE/DROP TABLE, CREATE/DROP INDEX, ALTER TABLE or ALTER INDEX).
 func (s Statement) IsDDL() bool {
-	return s.Kind == CreateTable || s.Kind == DropTable || s.Kind == CreateIndex || s.Kind == DropIndex || s.Kind == AlterTable
+	return s.Kind == CreateTable || s.Kind == DropTable || s.Kind == CreateIndex || s.Kind == DropIndex || s.Kind == AlterTable || s.Kind == AlterIndex
 }
 
 // ColumnByName looks up a column in the statement's schema by name.
@@ -1266,6 +1558,7 @@ func (s Statement) prepareInsert(now Time) (Statement, error) {
 		}
 	}
 	nUpdateFields := len(s.Fields) - nInsertFields
+	insertFields := s.Fields[:nInsertFields]
 
 	// Build or reuse the column-field index and sorted Fields template.
 	// For prepared statements (insertCache != nil) this is computed once and cached;
@@ -1330,6 +1623,10 @@ func (s Statement) prepareInsert(now Time) (Statement, error) {
 		s.Fields = newFields
 	}
 
+	if k := unmatchedInsertField(colFieldIdx, nInsertFields); k >= 0 {
+		return Statement{}, fmt.Errorf("unknown field %q in table %q", insertFields[k].Name, s.TableName)
+	}
+
 	boundArgs := s.boundArgs
 	if len(boundArgs) > 0 && !insertFieldOrderMatchesColumnOrder(colFieldIdx, nInsertFields) {
 		var err error
@@ -1341,7 +1638,9 @@ func (s Statement) prepareInsert(now Time) (Statement, error) {
 	}
 
 	// Rebuild each insert row in column order, applying defaults and resolving
-	// NOW() / timestamp values inline — one allocation per row.
+	// NOW() / timestamp values inline — one allocation per row. Generated
+	// columns are computed last, from the finished row.
+	hasGenerated := hasGeneratedColumns(s.Columns)
 	for j := range s.Inserts {
 		newRow := make([]OptionalValue, nCols)
 		for i, col := range s.Columns {
@@ -1378,6 +1677,9 @@ func (s Statement) prepareInsert(now Time) (Statement, error) {
 				continue
 			}
 
+			if col.IsGenerated() {
+				return Statement{}, fmt.Errorf("cannot insert a value into generated column %q", col.Name)
+			}
 			var err error
 			val, err = coerceColumnValue(col, val, now, "INSERT")
 			if err != nil {
@@ -1385,12 +1687,32 @@ func (s Statement) prepareInsert(now Time) (Statement, error) {
 			}
 			newRow[i] = val
 		}
+		if hasGenerated {
+			if err := fillGeneratedColumns(Row{Columns: s.Columns, Values: newRow}); err != nil {
+				return Statement{}, err
+			}
+		}
 		s.Inserts[j] = newRow
 	}
 
 	// Resolve function values and timestamp strings in the DO UPDATE SET clause.
 	// prepareUpdate is only called for Kind==Update, so we do it explicitly here.
 	if s.ConflictAction == ConflictActionDoUpdate {
+		// DO UPDATE SET fields trail the INSERT fields in s.Fields, so every
+		// ON UPDATE NOW() assignment added here needs a matching field.
+		if updates := onUpdateNowValues(s.Columns, s.Updates, now); len(updates) != len(s.Updates) {
+			fields := slices.Clone(s.Fields)
+			for _, col := range s.Columns {
+				if _, ok := s.Updates[col.Name]; ok {
+					continue
+				}
+				if _, ok := updates[col.Name]; ok {
+					fields = append(fields, Field{Name: col.Name})
+				}
+			}
+			s.Fields = fields
+			s.Updates = updates
+		}
 		for name, val := range s.Updates {
 			if !val.Valid {
 				continue
@@ -1403,6 +1725,9 @@ func (s Statement) prepareInsert(now Time) (Statement, error) {
 			if !ok {
 				continue
 			}
+			if col.IsGenerated() {
+				return Statement{}, fmt.Errorf("cannot update generated column %q", col.Name)
+			}
 			var err error
 			val, err = coerceColumnValue(col, val, now, "ON CONFLICT DO UPDATE")
 			if err != nil {
@@ -1415,6 +1740,26 @@ func (s Statement) prepareInsert(now Time) (Statement, error) {
 	return s, nil
 }
 
+// unmatchedInsertField returns the position of the first INSERT field that
+// does not name a column of the table, or -1 when every field was matched.
+func unmatchedInsertField(colFieldIdx []int, nInsertFields int) int {
+	matched := 0
+	for _, idx := range colFieldIdx {
+		if idx >= 0 {
+			matched++
+		}
+	}
+	if matched == nInsertFields {
+		return -1
+	}
+	for fieldIdx := range nInsertFields {
+		if !slices.Contains(colFieldIdx, fieldIdx) {
+			return fieldIdx
+		}
+	}
+	return -1
+}
+
 func insertFieldOrderMatchesColumnOrder(colFieldIdx []int, nInsertFields int) bool {
 	lastColIdx := -1
 	for fieldIdx := range nInsertFields {
@@ -1462,6 +1807,8 @@ func (s Statement) prepareUpdate(now Time) (Statement, error) {
 		return s, nil
 	}
 
+	s.Updates = onUpdateNowValues(s.Columns, s.Updates, now)
+
 	for name := range s.Updates {
 		col, ok := s.ColumnByName(name)
 		if !ok {
@@ -1495,6 +1842,28 @@ func (s Statement) prepareUpdate(now Time) (Statement, error) {
 	return s, nil
 }
 
+// onUpdateNowValues returns updates extended with the current time for every
+// ON UPDATE NOW() column the statement does not assign explicitly. The map is
+// copied before it is extended so a cached statement keeps its original SET
+// list and picks up a fresh time on every execution.
+func onUpdateNowValues(columns []Column, updates map[string]OptionalValue, now Time) map[string]OptionalValue {
+	cloned := false
+	for _, col := range columns {
+		if !col.OnUpdateNow || col.Deleted {
+			continue
+		}
+		if _, ok := updates[col.Name]; ok {
+			continue
+		}
+		if !cloned {
+			updates = maps.Clone(updates)
+			cloned = true
+		}
+		updates[col.Name] = OptionalValue{Valid: true, Value: TimestampMicros(now.TotalMicroseconds())}
+	}
+	return updates
+}
+
 // coerceColumnValue resolves NOW() function literals and converts raw text
 // values in val to the internal representation required by col's kind
 // (TimestampMicros for Timestamp, UUIDValue for UUID). ctx is included in
@@ -1578,6 +1947,16 @@ func (s Statement) prepareWhere() (Statement, error) {
 			if !ok {
 				return Statement{}, fmt.Errorf("unknown field %q in table %q", field.Name, s.TableName)
 			}
+			if (col.Kind == Real || col.Kind == Double) && isBetween(cond) {
+				// The parser yields int64 for integral literals such as 1 or 1.0;
+				// widen BETWEEN bounds so they match the column's float type.
+				for k, value := range cond.Operand2.Value.([]any) {
+					if iv, ok := value.(int64); ok {
+						s.Conditions[i][j].Operand2.Value.([]any)[k] = float64(iv)
+					}
+				}
+				continue
+			}
 			if col.Kind != Timestamp && col.Kind != UUID {
 				continue
 			}
@@ -1705,10 +2084,20 @@ func (s Statement) Validate(table *Table) error {
 		return s.validateCreateIndex(table)
 	case DropIndex:
 		return s.validateDropIndex()
+	case AlterIndex:
+		return s.validateAlterIndex()
 	case Pragma:
 		return s.validatePragma()
 	}
 
+	if err := s.validateReturning(table); err != nil {
+		return err
+	}
+
+	if err := s.validateSafeMode(); err != nil {
+		return err
+	}
+
 	if err := s.validateWhere(); err != nil {
 		return err
 	}
@@ -1716,6 +2105,40 @@ func (s Statement) Validate(table *Table) error {
 	return nil
 }
 
+// validateReturning rejects RETURNING fields that are not columns of table,
+// before any row is modified.
+func (s Statement) validateReturning(table *Table) error {
+	if len(s.ReturningFields) == 0 || table == nil {
+		return nil
+	}
+	for _, field := range s.ReturningFields {
+		if field.Name == "*" {
+			continue
+		}
+		if _, ok := table.ColumnByName(field.Name); !ok {
+			return fmt.Errorf("unknown field %q in RETURNING of table %q", field.Name, table.Name)
+		}
+	}
+	return nil
+}
+
+// ErrSafeModeNoWhere is returned when safe mode is enabled and an UPDATE or
+// DELETE statement has no WHERE clause.
+var ErrSafeModeNoWhere = errors.New("safe mode: UPDATE and DELETE require a WHERE clause")
+
+// validateSafeMode rejects unconditional UPDATE and DELETE statements when
+// safe mode is enabled. An explicit always-true predicate such as WHERE 1=1
+// is accepted as a deliberate override.
+func (s Statement) validateSafeMode() error {
+	if !s.safeMode || (s.Kind != Update && s.Kind != Delete) {
+		return nil
+	}
+	if len(s.Conditions) > 0 {
+		return nil
+	}
+	return ErrSafeModeNoWhere
+}
+
 func (s Statement) validatePragma() error {
 	if s.PragmaName == "" {
 		return errors.New("pragma name is required")
@@ -1732,6 +2155,10 @@ func (s Statement) validateCreateTable() error {
 		return fmt.Errorf("table name exceeds maximum length of %d", MaxInlineVarchar)
 	}
 
+	if err := s.validateIdentifiers(); err != nil {
+		return err
+	}
+
 	if len(s.Conditions) > 0 {
 		return errors.New("CREATE TABLE cannot have WHERE conditions")
 	}
@@ -1745,7 +2172,7 @@ func (s Statement) validateCreateTable() error {
 	}
 
 	if !canInlinedRowFitInPage(s.Columns) {
-		return fmt.Errorf("potential row size exceeds maximum allowed %d", UsablePageSize)
+		return fmt.Errorf("potential row size exceeds maximum allowed %d", maxInlinedRowSize(s.Columns))
 	}
 
 	if utf8.RuneCountInString(s.DDL()) > maximumSchemaSQL {
@@ -1775,6 +2202,13 @@ func (s Statement) validateCreateTable() error {
 		nameMap[col.Name] = struct{}{}
 	}
 
+	if err := validateGeneratedColumns(s.Columns); err != nil {
+		return err
+	}
+	if err := s.validatePageHintColumns(); err != nil {
+		return err
+	}
+
 	for _, fk := range s.ForeignKeys {
 		if len(fk.Columns) == 0 {
 			return errors.New("foreign key: column list cannot be empty")
@@ -1866,6 +2300,12 @@ func (s Statement) validateCreateIndex(table *Table) error {
 		return fmt.Errorf("index name exceeds maximum length of %d", MaxInlineVarchar)
 	}
 
+	if s.maxIdentifierLength > 0 {
+		if err := validateIdentifier("index", s.IndexName, s.maxIdentifierLength); err != nil {
+			return err
+		}
+	}
+
 	if len(s.Columns) == 0 {
 		return errors.New("at least one column is required")
 	}
@@ -1962,6 +2402,17 @@ func (s Statement) validateDropIndex() error {
 	return nil
 }
 
+func (s Statement) validateAlterIndex() error {
+	if s.IndexName == "" || s.NewIndexName == "" {
+		return errors.New("index name is required")
+	}
+	if s.maxIdentifierLength > 0 {
+		return validateIdentifier("index", s.NewIndexName, s.maxIdentifierLength)
+	}
+
+	return nil
+}
+
 func columnNames(columns []Column) string {
 	var result strings.Builder
 	for i, col := range columns {
@@ -1975,17 +2426,31 @@ func columnNames(columns []Column) string {
 
 // Check whether a row with the given columns can fit in a page if all columns are inlined
 func canInlinedRowFitInPage(columns []Column) bool {
-	var used uint32
+	var used uint64
 	for _, col := range columns {
 		if col.Kind.IsText() {
 			// For TEXT and VARCHAR, assume each column has maximum inline size
-			// and will take 4+255 bytes each (length prefix + max varchar inline size)
+			// and will take 4+512 bytes each (length prefix + max varchar inline size)
 			used += varcharLengthPrefixSize + MaxInlineVarchar
 		} else {
-			used += col.Size
+			used += uint64(col.Size)
 		}
 	}
-	return used <= UsablePageSize
+	return used <= maxInlinedRowSize(columns)
+}
+
+// maxInlinedRowSize returns the largest row the given columns may encode to.
+// The limit comes from the root leaf, the leaf with the least space because of
+// the reserved database header and, for a table with a MINMAX column, the page
+// hint, minus the per-cell overhead of key, null bitmask, column count and one
+// type code per column.
+func maxInlinedRowSize(columns []Column) uint64 {
+	root := LeafNode{Header: LeafNodeHeader{
+		Header: Header{IsRoot: true},
+		Hint:   newPageHint(columns),
+	}}
+	cellOverhead := (&Cell{ColumnCount: uint8(len(columns))}).Size()
+	return root.MaxSpace() - cellOverhead
 }
 
 func (s Statement) validateInsert(table *Table) error {
@@ -2073,6 +2538,9 @@ func (s Statement) validateUpdate(table *Table) error {
 		if !ok {
 			return fmt.Errorf("unknown field %q in table %q", field.Name, table.Name)
 		}
+		if col.IsGenerated() {
+			return fmt.Errorf("cannot update generated column %q", col.Name)
+		}
 		updateVal := s.Updates[field.Name]
 		// Arithmetic expressions and correlated subqueries are evaluated at execution
 		// time — skip static type validation for both.
@@ -2089,10 +2557,61 @@ func (s Statement) validateUpdate(table *Table) error {
 	return nil
 }
 
+// ErrForUpdateReadOnly is returned when SELECT … FOR UPDATE is executed in a
+// read-only transaction, which cannot hold row locks.
+var ErrForUpdateReadOnly = errors.New("FOR UPDATE is not allowed in a read-only transaction")
+
+// ErrReadOnlyTransaction is returned when a statement or page write is
+// attempted in a read-only transaction.
+var ErrReadOnlyTransaction = errors.New("cannot write in a read-only transaction")
+
+// validateForUpdate rejects FOR UPDATE on queries whose result rows do not
+// correspond one-to-one with table rows, mirroring PostgreSQL.
+func (s Statement) validateForUpdate() error {
+	switch {
+	case s.Distinct:
+		return errors.New("FOR UPDATE cannot be used with DISTINCT")
+	case len(s.GroupBy) > 0 || s.IsSelectAggregate() || s.IsSelectCountAll():
+		return errors.New("FOR UPDATE cannot be used with aggregate functions or GROUP BY")
+	case len(s.Unions) > 0:
+		return errors.New("FOR UPDATE cannot be used with UNION")
+	}
+	return nil
+}
+
 func (s Statement) validateSelect(table *Table) error {
 	if len(s.Fields) == 0 {
 		return errors.New("at least one field to select is required")
 	}
+	// Output column names must be unambiguous for anything that reads
+	// the result by name, such as ORDER BY or a client decoding rows.
+	aliases := make(map[string]struct{}, len(s.Fields))
+	for _, field := range s.Fields {
+		if field.Alias == "" {
+			continue
+		}
+		if _, ok := aliases[field.Alias]; ok {
+			return fmt.Errorf("duplicate alias %q in select statement", field.Alias)
+		}
+		aliases[field.Alias] = struct{}{}
+	}
+	checkCase := func(e *Expr) error { return e.checkCaseBranches(table) }
+	if err := walkFieldsExprs(s.Fields, checkCase); err != nil {
+		return err
+	}
+	orderByColumns := make(map[string]struct{}, len(s.OrderBy))
+	for _, orderBy := range s.OrderBy {
+		if err := walkExpr(orderBy.Field.Expr, checkCase); err != nil {
+			return err
+		}
+		if orderBy.Field.Expr != nil {
+			continue
+		}
+		if _, ok := orderByColumns[orderBy.Field.String()]; ok {
+			return fmt.Errorf("duplicate field %q in ORDER BY clause", orderBy.Field.String())
+		}
+		orderByColumns[orderBy.Field.String()] = struct{}{}
+	}
 	if s.Limit.Valid {
 		limitValue, ok := s.Limit.Value.(int64)
 		if !ok || limitValue < 0 {
@@ -2198,6 +2717,18 @@ func (s Statement) validateSelect(table *Table) error {
 					return fmt.Errorf("column %q must be numeric for %s", agg.Column, agg.Kind)
 				}
 			}
+			if agg.Kind == AggregateMin || agg.Kind == AggregateMax {
+				switch col.Kind {
+				case JSON, Vector:
+					return fmt.Errorf("column %q must be comparable for %s", agg.Column, agg.Kind)
+				}
+			}
+			if agg.Distinct {
+				switch col.Kind {
+				case JSON, Vector:
+					return fmt.Errorf("column %q must be comparable for %s(DISTINCT)", agg.Column, agg.Kind)
+				}
+			}
 		}
 
 		// Validate GROUP BY columns exist in the table schema.
@@ -2458,6 +2989,19 @@ func (s Statement) validateWhere() error {
 				}
 			}
 
+			if err := s.validateBooleanCondition(cond); err != nil {
+				return err
+			}
+			if err := s.validateNullCondition(cond); err != nil {
+				return err
+			}
+			if err := s.validateLikeCondition(cond); err != nil {
+				return err
+			}
+			if err := s.validateBetweenCondition(cond); err != nil {
+				return err
+			}
+
 			if isEquality(cond) {
 				field := cond.Operand1.Value.(Field)
 
@@ -2486,6 +3030,125 @@ func (s Statement) validateWhere() error {
 	return nil
 }
 
+// validateBooleanCondition checks that a BOOLEAN column of the statement's
+// table is only compared with TRUE or FALSE, using = or !=. NULL checks and
+// comparisons with other columns are left to the evaluator.
+func (s Statement) validateBooleanCondition(cond Condition) error {
+	if cond.Operand1.Type != OperandField {
+		return nil
+	}
+	field := cond.Operand1.Value.(Field)
+	if field.AliasPrefix != "" && field.AliasPrefix != s.TableAlias {
+		return nil
+	}
+	col, ok := s.ColumnByName(field.Name)
+	if !ok || col.Kind != Boolean {
+		return nil
+	}
+	switch cond.Operand2.Type {
+	case OperandBoolean, OperandInteger, OperandFloat, OperandQuotedString:
+	default:
+		return nil
+	}
+	if _, ok := cond.Operand2.Value.(bool); !ok {
+		return fmt.Errorf("boolean column %q can only be compared with TRUE, FALSE or NULL", field.Name)
+	}
+	if cond.Operator != Eq && cond.Operator != Ne {
+		return fmt.Errorf("operator %s not supported for boolean column %q", cond.Operator, field.Name)
+	}
+	return nil
+}
+
+// validateNullCondition rejects IS NULL / IS NOT NULL on a NOT NULL column of
+// the statement's table, where the result is always false / always true and
+// the condition is almost certainly a mistake. JOIN queries are skipped since
+// an outer join can still produce NULLs for such columns.
+func (s Statement) validateNullCondition(cond Condition) error {
+	if (cond.Operator != IsNull && cond.Operator != IsNotNull) || cond.Operand1.Type != OperandField || len(s.Joins) > 0 {
+		return nil
+	}
+	field := cond.Operand1.Value.(Field)
+	if field.AliasPrefix != "" && field.AliasPrefix != s.TableAlias {
+		return nil
+	}
+	col, ok := s.ColumnByName(field.Name)
+	if !ok || col.Nullable {
+		return nil
+	}
+	if cond.Operator == IsNull {
+		return fmt.Errorf("column %q is NOT NULL, IS NULL is always false", field.Name)
+	}
+	return fmt.Errorf("column %q is NOT NULL, IS NOT NULL is always true", field.Name)
+}
+
+// validateLikeCondition checks that LIKE / NOT LIKE is only applied to TEXT or
+// VARCHAR columns of the statement's table and that the pattern is a string.
+// NULL patterns, column references and expressions are left to the evaluator.
+func (s Statement) validateLikeCondition(cond Condition) error {
+	if cond.Operator != Like && cond.Operator != NotLike {
+		return nil
+	}
+	switch cond.Operand2.Type {
+	case OperandInteger, OperandFloat, OperandBoolean:
+		return fmt.Errorf("%s pattern must be a string", cond.Operator)
+	}
+	if cond.Operand1.Type != OperandField {
+		return nil
+	}
+	field := cond.Operand1.Value.(Field)
+	if field.AliasPrefix != "" && field.AliasPrefix != s.TableAlias {
+		return nil
+	}
+	col, ok := s.ColumnByName(field.Name)
+	if !ok {
+		return nil
+	}
+	if col.Kind != Varchar && col.Kind != Text {
+		return fmt.Errorf("%s not supported for %s column %q", cond.Operator, col.Kind, field.Name)
+	}
+	return nil
+}
+
+// validateBetweenCondition checks that both bounds of a BETWEEN / NOT BETWEEN
+// condition on a column of the statement's table are valid values for that
+// column, using the same type checks as INSERT and UPDATE.
+func (s Statement) validateBetweenCondition(cond Condition) error {
+	if !isBetween(cond) || cond.Operand1.Type != OperandField {
+		return nil
+	}
+	bounds := cond.Operand2.Value.([]any)
+	if len(bounds) != 2 {
+		return fmt.Errorf("%s requires exactly two bounds", cond.Operator)
+	}
+	field := cond.Operand1.Value.(Field)
+	if field.AliasPrefix != "" && field.AliasPrefix != s.TableAlias {
+		return nil
+	}
+	col, ok := s.ColumnByName(field.Name)
+	if !ok {
+		return nil
+	}
+	for _, bound := range bounds {
+		if err := isValueValidForColumn(col, OptionalValue{Value: bound, Valid: true}); err != nil {
+			return fmt.Errorf("invalid %s bound: %w", cond.Operator, err)
+		}
+	}
+	return nil
+}
+
+// isBetween reports whether cond is a BETWEEN / NOT BETWEEN condition with a
+// list of bounds as its right operand.
+func isBetween(cond Condition) bool {
+	if cond.Operator != Between && cond.Operator != NotBetween {
+		return false
+	}
+	if cond.Operand2.Type != OperandList {
+		return false
+	}
+	_, ok := cond.Operand2.Value.([]any)
+	return ok
+}
+
 // DDL returns the canonical SQL DDL string for the statement (CREATE TABLE or
 // CREATE INDEX), used to persist the schema to the database header. Returns ""
 // for non-DDL statement kinds.
@@ -2508,10 +3171,10 @@ func (s Statement) createTableDDL() string {
 	if len(s.PrimaryKey.Columns) == 1 {
 		pkColumn = s.PrimaryKey.Columns[0].Name
 	}
-	uniqueKeys := map[string]struct{}{}
+	uniqueKeys := map[string]UniqueIndex{}
 	for _, uniqueIndex := range s.UniqueIndexes {
 		if len(uniqueIndex.Columns) == 1 {
-			uniqueKeys[uniqueIndex.Columns[0].Name] = struct{}{}
+			uniqueKeys[uniqueIndex.Columns[0].Name] = uniqueIndex
 		}
 	}
 
@@ -2533,8 +3196,11 @@ func (s Statement) createTableDDL() string {
 			if !col.Nullable {
 				sb.WriteString(" not null")
 			}
-			if _, ok := uniqueKeys[col.Name]; ok {
+			if uniqueIndex, ok := uniqueKeys[col.Name]; ok {
 				sb.WriteString(" unique")
+				if uniqueIndex.NullsNotDistinct {
+					sb.WriteString(" nulls not distinct")
+				}
 			}
 			switch {
 			case col.DefaultValueNow:
@@ -2559,9 +3225,18 @@ func (s Statement) createTableDDL() string {
 					fmt.Fprintf(&sb, " default '%s'", FromMicroseconds(int64(col.DefaultValue.Value.(TimestampMicros))).String())
 				}
 			}
+			if col.OnUpdateNow {
+				sb.WriteString(" on update now()")
+			}
+			if col.Generated != "" {
+				fmt.Fprintf(&sb, " generated always as (%s) stored", col.Generated)
+			}
 			if col.Check != "" {
 				fmt.Fprintf(&sb, " check (%s)", col.Check)
 			}
+			if col.MinMax {
+				sb.WriteString(" minmax")
+			}
 		}
 		if i < len(s.Columns)-1 {
 			sb.WriteString(", ")
@@ -2581,7 +3256,11 @@ func (s Statement) createTableDDL() string {
 		if len(uniqueIndex.Columns) == 1 {
 			continue
 		}
-		sb.WriteString(", unique (")
+		sb.WriteString(", unique ")
+		if uniqueIndex.NullsNotDistinct {
+			sb.WriteString("nulls not distinct ")
+		}
+		sb.WriteString("(")
 		for j, col := range uniqueIndex.Columns {
 			sb.WriteString(col.Name)
 			if j < len(uniqueIndex.Columns)-1 {
@@ -2751,7 +3430,7 @@ func (s Statement) IsSelectCountAll() bool {
 
 // IsSelectAll reports whether the statement is a SELECT * (wildcard) query.
 func (s Statement) IsSelectAll() bool {
-	return s.ReadOnly() && len(s.Fields) == 1 && s.Fields[0].Name == "*"
+	return s.ReadOnly() && len(s.Fields) == 1 && s.Fields[0].Name == "*" && s.Fields[0].AliasPrefix == ""
 }
 
 // IsSelectAggregate returns true when the SELECT list contains at least one
@@ -2766,6 +3445,12 @@ func (s Statement) IsSelectAggregate() bool {
 	return false
 }
 
+// IsUpdateFrom reports whether the statement is an UPDATE … FROM whose source
+// is a table, a subquery or a VALUES list.
+func (s Statement) IsUpdateFrom() bool {
+	return s.Kind == Update && (s.UpdateFromTable != "" || s.UpdateFromSubquery != nil || len(s.UpdateFromValues) > 0)
+}
+
 // IsSelectGroupBy returns true when the SELECT has a GROUP BY clause.
 func (s Statement) IsSelectGroupBy() bool {
 	return len(s.GroupBy) > 0
@@ -2778,7 +3463,7 @@ func isHavingAggregateRef(name string) bool {
 	if upper == "COUNT(*)" {
 		return true
 	}
-	for _, prefix := range []string{"SUM(", "AVG(", "MIN(", "MAX("} {
+	for _, prefix := range []string{"COUNT(", "SUM(", "AVG(", "MIN(", "MAX("} {
 		if strings.HasPrefix(upper, prefix) {
 			return true
 		}
@@ -1,8 +1,11 @@
 package minisql
 
 import (
+	"context"
 	"errors"
 	"fmt"
+
+	minisqlErrors "github.com/RichardKnop/minisql/pkg/errors"
 )
 
 // JoinType identifies the variety of JOIN operation to perform.
@@ -146,3 +149,75 @@ func (j Join) FromTableAlias() string {
 	}
 	return ""
 }
+
+// expandQualifiedWildcards replaces every alias.* field of a SELECT with one
+// field per column of the table the alias refers to, in column order. The
+// expanded fields are aliased with their qualified name (e.g. "u.id") so that
+// equally named columns of different tables stay apart in the result.
+func (s Statement) expandQualifiedWildcards(ctx context.Context, provider TableProvider) (Statement, error) {
+	hasWildcard := false
+	for _, field := range s.Fields {
+		if field.Name == "*" && field.AliasPrefix != "" {
+			hasWildcard = true
+			break
+		}
+	}
+	if !hasWildcard {
+		return s, nil
+	}
+
+	tableNames := map[string]string{tableAliasOrName(s.TableAlias, s.TableName): s.TableName}
+	collectJoinTableNames(s.Joins, tableNames)
+
+	var (
+		fields     = make([]Field, 0, len(s.Fields))
+		aggregates []AggregateExpr
+	)
+	if len(s.Aggregates) > 0 {
+		aggregates = make([]AggregateExpr, 0, len(s.Aggregates))
+	}
+	for i, field := range s.Fields {
+		if field.Name != "*" || field.AliasPrefix == "" {
+			fields = append(fields, field)
+			if aggregates != nil {
+				aggregates = append(aggregates, s.Aggregates[i])
+			}
+			continue
+		}
+		tableName, ok := tableNames[field.AliasPrefix]
+		if !ok {
+			return Statement{}, fmt.Errorf("unknown table alias %q in %s.*", field.AliasPrefix, field.AliasPrefix)
+		}
+		table, ok := provider.GetTable(ctx, tableName)
+		if !ok {
+			return Statement{}, minisqlErrors.ErrNoSuchTable{Name: tableName}
+		}
+		for _, col := range table.Columns {
+			fields = append(fields, Field{
+				AliasPrefix: field.AliasPrefix,
+				Name:        col.Name,
+				Alias:       field.AliasPrefix + "." + col.Name,
+			})
+			if aggregates != nil {
+				aggregates = append(aggregates, AggregateExpr{})
+			}
+		}
+	}
+	s.Fields = fields
+	s.Aggregates = aggregates
+	return s, nil
+}
+
+func collectJoinTableNames(joins []Join, dst map[string]string) {
+	for _, j := range joins {
+		dst[tableAliasOrName(j.TableAlias, j.TableName)] = j.TableName
+		collectJoinTableNames(j.Joins, dst)
+	}
+}
+
+func tableAliasOrName(alias, name string) string {
+	if alias != "" {
+		return alias
+	}
+	return name
+}
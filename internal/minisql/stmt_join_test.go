@@ -1,6 +1,7 @@
 package minisql
 
 import (
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -353,3 +354,68 @@ func TestJoin_FromTableAlias(t *testing.T) {
 	}
 	assert.Equal(t, "u", join.FromTableAlias())
 }
+
+func TestStatement_ExpandQualifiedWildcards(t *testing.T) {
+	t.Parallel()
+
+	provider := &mapTableProvider{tables: map[string]*Table{
+		"users": {
+			Name:    "users",
+			Columns: []Column{{Name: "id", Kind: Int8}, {Name: "name", Kind: Varchar}},
+		},
+		"orders": {
+			Name:    "orders",
+			Columns: []Column{{Name: "id", Kind: Int8}, {Name: "total", Kind: Int8}},
+		},
+	}}
+	stmt := Statement{
+		Kind:       Select,
+		TableName:  "users",
+		TableAlias: "u",
+		Joins: []Join{
+			{Type: Inner, TableName: "orders", TableAlias: "o"},
+		},
+	}
+
+	t.Run("expands alias.* to qualified fields", func(t *testing.T) {
+		t.Parallel()
+
+		stmt := stmt
+		stmt.Fields = []Field{
+			{AliasPrefix: "o", Name: "total", Alias: "amount"},
+			{AliasPrefix: "u", Name: "*"},
+		}
+
+		expanded, err := stmt.expandQualifiedWildcards(context.Background(), provider)
+		require.NoError(t, err)
+		assert.Equal(t, []Field{
+			{AliasPrefix: "o", Name: "total", Alias: "amount"},
+			{AliasPrefix: "u", Name: "id", Alias: "u.id"},
+			{AliasPrefix: "u", Name: "name", Alias: "u.name"},
+		}, expanded.Fields)
+	})
+
+	t.Run("keeps aggregates parallel to fields", func(t *testing.T) {
+		t.Parallel()
+
+		stmt := stmt
+		stmt.Fields = []Field{{AliasPrefix: "o", Name: "*"}, {Name: "COUNT(*)"}}
+		stmt.Aggregates = []AggregateExpr{{}, {Kind: AggregateCount}}
+
+		expanded, err := stmt.expandQualifiedWildcards(context.Background(), provider)
+		require.NoError(t, err)
+		require.Len(t, expanded.Fields, 3)
+		assert.Equal(t, []AggregateExpr{{}, {}, {Kind: AggregateCount}}, expanded.Aggregates)
+	})
+
+	t.Run("unknown alias", func(t *testing.T) {
+		t.Parallel()
+
+		stmt := stmt
+		stmt.Fields = []Field{{AliasPrefix: "x", Name: "*"}}
+
+		_, err := stmt.expandQualifiedWildcards(context.Background(), provider)
+		require.Error(t, err)
+		assert.Equal(t, `unknown table alias "x" in x.*`, err.Error())
+	})
+}
@@ -131,12 +131,16 @@ func materializeResultRows(ctx context.Context, result StatementResult) ([]Row,
 			_ = result.RowViews.Close()
 		}()
 
+		budget := queryBudgetFromContext(ctx)
 		var rows []Row
 		for result.RowViews.Next(ctx) {
 			row, err := projectRowView(ctx, result.RowViewPager, result.RowViews.RowView(), result.RowViewFieldIndexes, result.Columns)
 			if err != nil {
 				return nil, err
 			}
+			if err := budget.growMemory(int64(row.MemorySize())); err != nil {
+				return nil, err
+			}
 			rows = append(rows, row)
 		}
 		if err := result.RowViews.Err(); err != nil {
@@ -148,9 +152,14 @@ func materializeResultRows(ctx context.Context, result StatementResult) ([]Row,
 	defer func() {
 		_ = result.Rows.Close()
 	}()
+	budget := queryBudgetFromContext(ctx)
 	var rows []Row
 	for result.Rows.Next(ctx) {
-		rows = append(rows, result.Rows.Row())
+		row := result.Rows.Row()
+		if err := budget.growMemory(int64(row.MemorySize())); err != nil {
+			return nil, err
+		}
+		rows = append(rows, row)
 	}
 	if err := result.Rows.Err(); err != nil {
 		return nil, err
@@ -216,7 +225,8 @@ func (i *Iterator) Err() error {
 // StatementResult is the value returned by every DML/DDL execution. Rows is a
 // lazy iterator over result rows (non-nil even for INSERT/UPDATE/DELETE when a
 // RETURNING clause was present). RowsAffected and LastInsertId follow
-// database/sql conventions.
+// database/sql conventions. AffectedKeys is populated only when the statement
+// set ReturnAffectedKeys.
 //
 // rawRows, when non-nil, holds the same projected rows that back the Rows
 // iterator. Callers that need to materialise all rows (e.g. CTE body
@@ -231,4 +241,12 @@ type StatementResult struct {
 	RowViewFieldIndexes []int
 	RowsAffected        int
 	LastInsertID        int64
+	AffectedKeys        []AffectedKey
 }
+
+// AffectedKey identifies a row modified by an UPDATE or DELETE. It holds the
+// primary key values in primary key column order, or the internal row ID as a
+// single int64 value for tables without a primary key. Keys are reported in
+// the order rows were modified; for UPDATE the key is the one the row had
+// before the statement ran.
+type AffectedKey []OptionalValue
@@ -115,6 +115,79 @@ func TestStatement_BindArguments(t *testing.T) {
 	})
 }
 
+func TestStatement_BindNamed(t *testing.T) {
+	t.Parallel()
+
+	stmt := Statement{
+		Kind:      Update,
+		TableName: "a",
+		Fields:    []Field{{Name: "b"}, {Name: "c"}},
+		Updates: map[string]OptionalValue{
+			"b": {Value: Placeholder{Name: "value"}, Valid: true},
+			"c": {Value: Placeholder{Name: "value"}, Valid: true},
+		},
+		Conditions: OneOrMore{
+			{
+				FieldIsEqual(Field{Name: "id"}, OperandPlaceholder, Placeholder{Name: "id"}),
+				FieldIsInAny(Field{Name: "d"}, Placeholder{Name: "value"}, int64(7)),
+			},
+		},
+	}
+
+	t.Run("names in bind order", func(t *testing.T) {
+		assert.Equal(t, []string{"value", "value", "id", "value"}, stmt.PlaceholderNames())
+		assert.Equal(t, 4, stmt.NumPlaceholders())
+		named, err := stmt.HasNamedPlaceholders()
+		require.NoError(t, err)
+		assert.True(t, named)
+	})
+
+	t.Run("repeated name binds the same value", func(t *testing.T) {
+		bound, err := stmt.BindNamed(map[string]any{"id": int64(1), "value": "x", "unused": true})
+		require.NoError(t, err)
+
+		assert.Equal(t, OptionalValue{Value: "x", Valid: true}, bound.Updates["b"])
+		assert.Equal(t, OptionalValue{Value: "x", Valid: true}, bound.Updates["c"])
+		assert.Equal(t, int64(1), bound.Conditions[0][0].Operand2.Value)
+		assert.Equal(t, []any{"x", int64(7)}, bound.Conditions[0][1].Operand2.Value)
+
+		// Ensure original statement is unchanged
+		assert.Equal(t, Placeholder{Name: "value"}, stmt.Updates["b"].Value)
+	})
+
+	t.Run("missing name", func(t *testing.T) {
+		_, err := stmt.BindNamed(map[string]any{"value": "x"})
+		require.EqualError(t, err, "missing value for placeholder :id")
+	})
+
+	t.Run("positional placeholders", func(t *testing.T) {
+		positional := Statement{
+			Kind:       Select,
+			TableName:  "a",
+			Conditions: OneOrMore{{FieldIsEqual(Field{Name: "id"}, OperandPlaceholder, nil)}},
+		}
+		named, err := positional.HasNamedPlaceholders()
+		require.NoError(t, err)
+		assert.False(t, named)
+
+		_, err = positional.BindNamed(map[string]any{"id": int64(1)})
+		require.Error(t, err)
+	})
+
+	t.Run("mixed placeholders", func(t *testing.T) {
+		mixed := Statement{
+			Kind:      Select,
+			TableName: "a",
+			Conditions: OneOrMore{{
+				FieldIsEqual(Field{Name: "id"}, OperandPlaceholder, nil),
+				FieldIsEqual(Field{Name: "b"}, OperandPlaceholder, Placeholder{Name: "b"}),
+			}},
+		}
+		_, err := mixed.HasNamedPlaceholders()
+		require.ErrorIs(t, err, ErrMixedPlaceholders)
+	})
+}
+
 func TestStatement_Prepare_Insert(t *testing.T) {
 	t.Parallel()
 
@@ -167,6 +240,28 @@ func TestStatement_Prepare_Insert(t *testing.T) {
 		}, stmt.Inserts)
 	})
 
+	t.Run("Unknown fields in INSERT statements cause error", func(t *testing.T) {
+		stmt := Statement{
+			Kind:      Insert,
+			TableName: "users",
+			Columns:   testColumns[0:4],
+			Fields: []Field{
+				{Name: "email"},
+				{Name: "nickname"},
+			},
+			Inserts: [][]OptionalValue{
+				{
+					{Value: "foo@example.com", Valid: true},
+					{Value: "foo", Valid: true},
+				},
+			},
+		}
+
+		_, err := stmt.Prepare(Time{})
+		require.Error(t, err)
+		assert.ErrorContains(t, err, `unknown field "nickname" in table "users"`)
+	})
+
 	t.Run("Unknown functions in INSERT statements cause error", func(t *testing.T) {
 		stmt := Statement{
 			Kind:      Insert,
@@ -266,6 +361,145 @@ func TestStatement_Prepare_Insert(t *testing.T) {
 	})
 }
 
+func TestStatement_BindMany(t *testing.T) {
+	t.Parallel()
+
+	newInsert := func() Statement {
+		return Statement{
+			Kind:      Insert,
+			TableName: "a",
+			Fields:    []Field{{Name: "b"}, {Name: "c"}, {Name: "d"}},
+			Inserts: [][]OptionalValue{
+				{
+					{Value: NewTextPointer([]byte("foo")), Valid: true},
+					{Value: Placeholder{}, Valid: true},
+					{Value: Placeholder{}, Valid: true},
+				},
+			},
+		}
+	}
+
+	t.Run("Bind rows to INSERT statement", func(t *testing.T) {
+		stmt := newInsert()
+
+		bound, ok, err := stmt.BindMany([][]any{
+			{int64(1), "bar"},
+			{int64(2), nil},
+		})
+		require.NoError(t, err)
+		require.True(t, ok)
+
+		require.Len(t, bound.Inserts, 2)
+		assert.Equal(t, []OptionalValue{
+			{Value: NewTextPointer([]byte("foo")), Valid: true},
+			{Value: int64(1), Valid: true},
+			{Value: "bar", Valid: true},
+		}, bound.Inserts[0])
+		assert.Equal(t, []OptionalValue{
+			{Value: NewTextPointer([]byte("foo")), Valid: true},
+			{Value: int64(2), Valid: true},
+			{},
+		}, bound.Inserts[1])
+
+		// Ensure original statement is unchanged
+		assert.Equal(t, newInsert(), stmt)
+	})
+
+	t.Run("Prepared INSERT defers binding to prepareInsert", func(t *testing.T) {
+		stmt := newInsert()
+		stmt.insertCache = &insertPrepCache{}
+
+		bound, ok, err := stmt.BindMany([][]any{
+			{int64(1), "bar"},
+			{int64(2), "baz"},
+		})
+		require.NoError(t, err)
+		require.True(t, ok)
+
+		require.Len(t, bound.Inserts, 2)
+		assert.Equal(t, []any{int64(1), "bar", int64(2), "baz"}, bound.boundArgs)
+		assert.Equal(t, Placeholder{}, stmt.Inserts[0][1].Value)
+		assert.Nil(t, stmt.boundArgs)
+	})
+
+	t.Run("Wrong number of arguments in a row causes error", func(t *testing.T) {
+		_, ok, err := newInsert().BindMany([][]any{
+			{int64(1), "bar"},
+			{int64(2)},
+		})
+		require.True(t, ok)
+		assert.EqualError(t, err, "batch row 1: expected 2 arguments, got 1")
+	})
+
+	t.Run("Statements other than single-row INSERT are not supported", func(t *testing.T) {
+		multiRow := newInsert()
+		multiRow.Inserts = append(multiRow.Inserts, multiRow.Inserts[0])
+
+		update := Statement{
+			Kind:      Update,
+			TableName: "a",
+			Fields:    []Field{{Name: "b"}},
+			Updates: map[string]OptionalValue{
+				"b": {Value: Placeholder{}, Valid: true},
+			},
+		}
+
+		doUpdate := newInsert()
+		doUpdate.ConflictAction = ConflictActionDoUpdate
+		doUpdate.Fields = append(doUpdate.Fields, Field{Name: "d"})
+		doUpdate.Updates = map[string]OptionalValue{
+			"d": {Value: Placeholder{}, Valid: true},
+		}
+
+		for _, stmt := range []Statement{multiRow, update, doUpdate} {
+			_, ok, err := stmt.BindMany([][]any{{int64(1), "bar"}})
+			require.NoError(t, err)
+			assert.False(t, ok)
+		}
+	})
+}
+
+func TestStatement_Clone(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Carries over every field", func(t *testing.T) {
+		stmt := Statement{
+			Kind:          CreateTable,
+			TableName:     "users",
+			Columns:       testColumns[0:2],
+			PrimaryKey:    PrimaryKey{IndexInfo: IndexInfo{Name: PrimaryKeyName("users"), Columns: testColumns[0:1]}, Autoincrement: true},
+			UniqueIndexes: []UniqueIndex{{IndexInfo: IndexInfo{Name: UniqueIndexName("users", testColumns[1].Name), Columns: testColumns[1:2]}}},
+			IndexHNSWM:    8,
+		}
+
+		clone := stmt.Clone()
+		assert.Equal(t, stmt.PrimaryKey, clone.PrimaryKey)
+		assert.Equal(t, stmt.UniqueIndexes, clone.UniqueIndexes)
+		assert.Equal(t, 8, clone.IndexHNSWM)
+	})
+
+	t.Run("Values and nested statements are copied", func(t *testing.T) {
+		stmt := Statement{
+			Kind:      Insert,
+			TableName: "users",
+			Fields:    []Field{{Name: "id"}},
+			Inserts:   [][]OptionalValue{{{Value: int64(1), Valid: true}}},
+			InsertSelectStmt: &Statement{
+				Kind:       Select,
+				TableName:  "accounts",
+				Conditions: OneOrMore{{FieldIsEqual(Field{Name: "id"}, OperandInteger, int64(1))}},
+			},
+		}
+
+		clone := stmt.Clone()
+		clone.Inserts[0][0] = OptionalValue{Value: int64(2), Valid: true}
+		clone.InsertSelectStmt.Conditions[0][0].Operand2.Value = int64(2)
+
+		assert.Equal(t, int64(1), stmt.Inserts[0][0].Value)
+		assert.Equal(t, int64(1), stmt.InsertSelectStmt.Conditions[0][0].Operand2.Value)
+	})
+}
+
 func TestStatement_Prepare_Update(t *testing.T) {
 	t.Parallel()
 
@@ -354,6 +588,38 @@ func TestStatement_Prepare_Update(t *testing.T) {
 			Valid: true,
 		}, stmt.Updates["created"])
 	})
+
+	t.Run("Set ON UPDATE NOW() columns not assigned in UPDATE statements", func(t *testing.T) {
+		explicit := OptionalValue{Value: TimestampMicros(42), Valid: true}
+		updates := map[string]OptionalValue{
+			"name":     {Value: NewTextPointer([]byte("Jane")), Valid: true},
+			"modified": explicit,
+		}
+		stmt := Statement{
+			Kind:      Update,
+			TableName: "users",
+			Columns: []Column{
+				{Kind: Varchar, Size: 255, Name: "name"},
+				{Kind: Timestamp, Size: 8, Name: "created", DefaultValueNow: true},
+				{Kind: Timestamp, Size: 8, Name: "updated", OnUpdateNow: true},
+				{Kind: Timestamp, Size: 8, Name: "modified", OnUpdateNow: true},
+			},
+			Updates: updates,
+		}
+
+		prepared, err := stmt.Prepare(now)
+		require.NoError(t, err)
+
+		assert.Equal(t, OptionalValue{
+			Value: TimestampMicros(now.TotalMicroseconds()),
+			Valid: true,
+		}, prepared.Updates["updated"])
+		assert.Equal(t, explicit, prepared.Updates["modified"])
+		assert.NotContains(t, prepared.Updates, "created")
+		// The original SET list is left as is so a cached statement picks up a
+		// fresh time on its next execution.
+		assert.NotContains(t, updates, "updated")
+	})
 }
 
 func TestStatement_Prepare_CreateTable(t *testing.T) {
@@ -497,7 +763,7 @@ func TestStatement_Validate(t *testing.T) {
 
 		err := stmt.Validate(nil)
 		require.Error(t, err)
-		assert.ErrorContains(t, err, "potential row size exceeds maximum allowed 4061")
+		assert.ErrorContains(t, err, "potential row size exceeds maximum allowed 3949")
 	})
 
 	t.Run("CREATE TABLE with nullable primary key should fail", func(t *testing.T) {
@@ -1047,6 +1313,31 @@ func TestStatement_Validate(t *testing.T) {
 		assert.ErrorContains(t, err, `duplicate field "id" in select statement`)
 	})
 
+	t.Run("SELECT with distinct aliases should pass", func(t *testing.T) {
+		stmt := Statement{
+			Kind:      Select,
+			TableName: table.Name,
+			Columns:   table.Columns,
+			Fields:    []Field{{Name: "id", Alias: "key"}, {Name: "email", Alias: "contact"}},
+		}
+
+		err := stmt.Validate(table)
+		require.NoError(t, err)
+	})
+
+	t.Run("SELECT with duplicate alias should fail", func(t *testing.T) {
+		stmt := Statement{
+			Kind:      Select,
+			TableName: table.Name,
+			Columns:   table.Columns,
+			Fields:    []Field{{Name: "id", Alias: "contact"}, {Name: "email", Alias: "contact"}},
+		}
+
+		err := stmt.Validate(table)
+		require.Error(t, err)
+		assert.ErrorContains(t, err, `duplicate alias "contact" in select statement`)
+	})
+
 	t.Run("SELECT with unknown field should fail", func(t *testing.T) {
 		stmt := Statement{
 			Kind:      Select,
@@ -1078,6 +1369,24 @@ func TestStatement_Validate(t *testing.T) {
 		assert.ErrorContains(t, err, `unknown field "unknown_field" in ORDER BY clause`)
 	})
 
+	t.Run("SELECT with duplicate field in ORDER BY should fail", func(t *testing.T) {
+		stmt := Statement{
+			Kind:      Select,
+			TableName: table.Name,
+			Columns:   table.Columns,
+			Fields:    []Field{{Name: "id"}, {Name: "email"}},
+			OrderBy: []OrderBy{
+				{Field: Field{Name: "email"}, Direction: Desc},
+				{Field: Field{Name: "id"}},
+				{Field: Field{Name: "email"}},
+			},
+		}
+
+		err := stmt.Validate(table)
+		require.Error(t, err)
+		assert.ErrorContains(t, err, `duplicate field "email" in ORDER BY clause`)
+	})
+
 	t.Run("SELECT COUNT(*) with ORDER BY should fail", func(t *testing.T) {
 		stmt := Statement{
 			Kind:      Select,
@@ -1550,6 +1859,14 @@ func TestStatement_DDL(t *testing.T) {
 				Nullable:        true,
 				DefaultValueNow: true,
 			},
+			{
+				Kind:            Timestamp,
+				Size:            8,
+				Name:            "j",
+				Nullable:        true,
+				DefaultValueNow: true,
+				OnUpdateNow:     true,
+			},
 		}
 		stmt := Statement{
 			Kind:       CreateTable,
@@ -1565,7 +1882,7 @@ func TestStatement_DDL(t *testing.T) {
 			},
 		}
 
-		expected := `create table "users" (a int4 primary key autoincrement, b int8 not null, c varchar(255) not null unique, d text not null, e boolean not null default false, f real, g real, h timestamp not null, i timestamp default now());`
+		expected := `create table "users" (a int4 primary key autoincrement, b int8 not null, c varchar(255) not null unique, d text not null, e boolean not null default false, f real, g real, h timestamp not null, i timestamp default now(), j timestamp default now() on update now());`
 
 		actual := stmt.DDL()
 		assert.Equal(t, expected, actual)
@@ -2047,7 +2364,7 @@ func TestStatement_PrepareWhere(t *testing.T) {
 				{
 					{
 						Operand1: Operand{Type: OperandField, Value: Field{Name: "created"}},
-						Operator: Eq,
+						Operator: IsNull,
 						Operand2: Operand{Type: OperandNull},
 					},
 				},
@@ -2232,6 +2549,159 @@ func TestStatement_ValidatePragma(t *testing.T) {
 	require.Error(t, Statement{}.validatePragma())
 }
 
+func TestStatement_ValidateSafeMode(t *testing.T) {
+	t.Parallel()
+
+	where := OneOrMore{{FieldIsEqual(Field{Name: "id"}, OperandInteger, int64(1))}}
+
+	testCases := []struct {
+		name string
+		stmt Statement
+		err  error
+	}{
+		{"delete without where in normal mode", Statement{Kind: Delete}, nil},
+		{"update without where in normal mode", Statement{Kind: Update}, nil},
+		{"delete without where in safe mode", Statement{Kind: Delete, safeMode: true}, ErrSafeModeNoWhere},
+		{"update without where in safe mode", Statement{Kind: Update, safeMode: true}, ErrSafeModeNoWhere},
+		{"delete with where in safe mode", Statement{Kind: Delete, safeMode: true, Conditions: where}, nil},
+		{"truncate in safe mode", Statement{Kind: TruncateTable, safeMode: true}, nil},
+		{"select in safe mode", Statement{Kind: Select, safeMode: true}, nil},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			err := tc.stmt.validateSafeMode()
+			if tc.err != nil {
+				assert.ErrorIs(t, err, tc.err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestStatement_ValidateBooleanConditions(t *testing.T) {
+	t.Parallel()
+
+	verified := Field{Name: "verified"}
+
+	testCases := []struct {
+		name      string
+		condition Condition
+		err       string
+	}{
+		{"equals true", FieldIsEqual(verified, OperandBoolean, true), ""},
+		{"not equals false", FieldIsNotEqual(verified, OperandBoolean, false), ""},
+		{"is null", FieldIsNull(verified), ""},
+		{"integer", FieldIsEqual(verified, OperandInteger, int64(1)), `boolean column "verified" can only be compared with TRUE, FALSE or NULL`},
+		{"string", FieldIsEqual(verified, OperandQuotedString, NewTextPointer([]byte("true"))), `boolean column "verified" can only be compared with TRUE, FALSE or NULL`},
+		{"ordering", FieldIsGreater(verified, OperandBoolean, false), `operator > not supported for boolean column "verified"`},
+		{"other column", FieldIsEqual(Field{Name: "age"}, OperandInteger, int64(1)), ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			stmt := Statement{Kind: Select, Columns: testColumns, Conditions: OneOrMore{{tc.condition}}}
+			err := stmt.validateWhere()
+			if tc.err != "" {
+				assert.EqualError(t, err, tc.err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestStatement_ValidateLikeConditions(t *testing.T) {
+	t.Parallel()
+
+	email := Field{Name: "email"}
+
+	testCases := []struct {
+		name      string
+		condition Condition
+		err       string
+	}{
+		{"varchar like string", FieldIsLike(email, OperandQuotedString, NewTextPointer([]byte("%@example.com"))), ""},
+		{"varchar not like string", FieldIsNotLike(email, OperandQuotedString, NewTextPointer([]byte("a%"))), ""},
+		{"integer pattern", FieldIsLike(email, OperandInteger, int64(5)), "LIKE pattern must be a string"},
+		{"integer column", FieldIsLike(Field{Name: "age"}, OperandQuotedString, NewTextPointer([]byte("1%"))), `LIKE not supported for int4 column "age"`},
+		{"not like on integer column", FieldIsNotLike(Field{Name: "id"}, OperandQuotedString, NewTextPointer([]byte("1%"))), `NOT LIKE not supported for int8 column "id"`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			stmt := Statement{Kind: Select, Columns: testColumns, Conditions: OneOrMore{{tc.condition}}}
+			err := stmt.validateWhere()
+			if tc.err != "" {
+				assert.EqualError(t, err, tc.err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestStatement_ValidateBetweenConditions(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name      string
+		condition Condition
+		err       string
+	}{
+		{"integer bounds", FieldIsBetween(Field{Name: "age"}, int64(18), int64(65)), ""},
+		{"text bounds", FieldIsNotBetween(Field{Name: "email"}, NewTextPointer([]byte("a")), NewTextPointer([]byte("m"))), ""},
+		{"text bounds for integer column", FieldIsBetween(Field{Name: "id"}, NewTextPointer([]byte("a")), NewTextPointer([]byte("m"))), `invalid BETWEEN bound: expects INT8 value for "id"`},
+		{"integer bounds for text column", FieldIsNotBetween(Field{Name: "email"}, int64(1), int64(2)), `invalid NOT BETWEEN bound: expects a text value for "email"`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			stmt := Statement{Kind: Select, Columns: testColumns, Conditions: OneOrMore{{tc.condition}}}
+			err := stmt.validateWhere()
+			if tc.err != "" {
+				assert.EqualError(t, err, tc.err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestStatement_ValidateForUpdate(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+		stmt Statement
+		err  string
+	}{
+		{"plain select", Statement{Kind: Select, Fields: []Field{{Name: "id"}}, ForUpdate: true}, ""},
+		{"distinct", Statement{Kind: Select, Fields: []Field{{Name: "id"}}, Distinct: true, ForUpdate: true}, "FOR UPDATE cannot be used with DISTINCT"},
+		{"group by", Statement{Kind: Select, Fields: []Field{{Name: "id"}}, GroupBy: []Field{{Name: "id"}}, ForUpdate: true}, "FOR UPDATE cannot be used with aggregate functions or GROUP BY"},
+		{"aggregate", Statement{Kind: Select, Fields: []Field{{Name: "SUM(v)"}}, Aggregates: []AggregateExpr{{Kind: AggregateSum}}, ForUpdate: true}, "FOR UPDATE cannot be used with aggregate functions or GROUP BY"},
+		{"count", Statement{Kind: Select, Fields: []Field{{Name: "COUNT(*)"}}, ForUpdate: true}, "FOR UPDATE cannot be used with aggregate functions or GROUP BY"},
+		{"union", Statement{Kind: Select, Fields: []Field{{Name: "id"}}, Unions: []UnionClause{{Stmt: Statement{Kind: Select}}}, ForUpdate: true}, "FOR UPDATE cannot be used with UNION"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			err := tc.stmt.validateForUpdate()
+			if tc.err != "" {
+				assert.EqualError(t, err, tc.err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
 func TestIterator_Close(t *testing.T) {
 	t.Parallel()
 
@@ -2,9 +2,19 @@ package minisql
 
 import (
 	"context"
+	"errors"
 	"fmt"
 )
 
+// DefaultMaxSubqueryRows is the default limit on the number of distinct values
+// an IN (SELECT ...) subquery may materialise. Use WithMaxSubqueryRows to
+// change it.
+const DefaultMaxSubqueryRows = 1_000_000
+
+// ErrSubqueryTooLarge is returned when an IN (SELECT ...) subquery yields more
+// distinct values than the configured limit.
+var ErrSubqueryTooLarge = errors.New("subquery result too large")
+
 // resolveSubqueries walks stmt.Conditions, finds any OperandSubquery operands,
 // executes the subquery using the same transaction already in ctx, and replaces
 // each OperandSubquery with a concrete scalar or list value so that downstream
@@ -12,8 +22,9 @@ import (
 //
 // For scalar operators (=, !=, <, <=, >, >=) the subquery must return exactly
 // one column and at most one row.  Zero rows resolves to NULL.
-// For IN / NOT IN the subquery must return exactly one column; all rows are
-// collected into an OperandList.
+// For IN / NOT IN the subquery must return exactly one column; its distinct
+// non-NULL values are collected into an OperandList of at most
+// d.maxSubqueryRows entries.
 func (d *Database) resolveSubqueries(ctx context.Context, conditions OneOrMore) (OneOrMore, error) {
 	for i, condGroup := range conditions {
 		for j, cond := range condGroup {
@@ -38,8 +49,16 @@ func (d *Database) resolveSubqueries(ctx context.Context, conditions OneOrMore)
 					row := result.Rows.Row()
 					if len(row.Values) > 0 && row.Values[0].Valid {
 						v := row.Values[0].Value
-						if _, dup := seen[v]; !dup {
-							seen[v] = struct{}{}
+						// TextPointer holds a byte slice and cannot be a map key.
+						key := v
+						if tp, ok := v.(TextPointer); ok {
+							key = tp.String()
+						}
+						if _, dup := seen[key]; !dup {
+							if len(values) >= d.maxSubqueryRows {
+								return nil, fmt.Errorf("%w: IN subquery returned more than %d distinct values", ErrSubqueryTooLarge, d.maxSubqueryRows)
+							}
+							seen[key] = struct{}{}
 							values = append(values, v)
 						}
 					}
@@ -105,3 +124,92 @@ func scalarOperandType(v any) OperandType {
 		return OperandQuotedString
 	}
 }
+
+// checkINSubqueryTypes reports an error when an IN / NOT IN subquery projects
+// a column whose type cannot be compared with the outer column, such as
+// user_id IN (SELECT name FROM users). Only plain column references on both
+// sides are checked; expressions and columns of derived tables or CTEs are
+// left to execution.
+func (d *Database) checkINSubqueryTypes(ctx context.Context, stmt Statement) error {
+	for _, group := range stmt.Conditions {
+		for _, cond := range group {
+			if (cond.Operator != In && cond.Operator != NotIn) ||
+				cond.Operand1.Type != OperandField || cond.Operand2.Type != OperandSubquery {
+				continue
+			}
+			outerField, ok := cond.Operand1.Value.(Field)
+			if !ok {
+				continue
+			}
+			sub, ok := cond.Operand2.Value.(*Statement)
+			if !ok || sub.Kind != Select || sub.FromSubquery != nil || len(sub.Joins) > 0 || len(sub.Unions) > 0 {
+				continue
+			}
+			if len(sub.Fields) != 1 || sub.Fields[0].Name == "*" || sub.Fields[0].Expr != nil {
+				continue
+			}
+			outerCol, ok := d.statementColumn(ctx, stmt, outerField)
+			if !ok {
+				continue
+			}
+			innerCol, ok := d.statementColumn(ctx, *sub, sub.Fields[0])
+			if !ok {
+				continue
+			}
+			if !comparableColumnKinds(outerCol.Kind, innerCol.Kind) {
+				return fmt.Errorf("IN subquery column %s (%s) is not comparable with %s (%s)",
+					innerCol.Name, innerCol.Kind, outerField.String(), outerCol.Kind)
+			}
+		}
+	}
+	return nil
+}
+
+// statementColumn resolves field against the base table or a joined table of
+// stmt, matching its alias prefix against table aliases and names.
+func (d *Database) statementColumn(ctx context.Context, stmt Statement, field Field) (Column, bool) {
+	tableName := ""
+	switch field.AliasPrefix {
+	case "", stmt.TableAlias, stmt.TableName:
+		tableName = stmt.TableName
+	default:
+		var walk func(joins []Join)
+		walk = func(joins []Join) {
+			for _, j := range joins {
+				if j.TableAlias == field.AliasPrefix || j.TableName == field.AliasPrefix {
+					tableName = j.TableName
+					return
+				}
+				walk(j.Joins)
+			}
+		}
+		walk(stmt.Joins)
+	}
+	if tableName == "" {
+		return Column{}, false
+	}
+	table, ok := d.GetTable(ctx, tableName)
+	if !ok {
+		return Column{}, false
+	}
+	return table.ColumnByName(field.Name)
+}
+
+// comparableColumnKinds reports whether values of kinds a and b can be
+// compared for equality: numbers with numbers, text with text or timestamps,
+// and otherwise only values of the same kind.
+func comparableColumnKinds(a, b ColumnKind) bool {
+	isNumeric := func(k ColumnKind) bool { return k == Int4 || k == Int8 || k == Real || k == Double }
+	isText := func(k ColumnKind) bool { return k == Varchar || k == Text }
+	switch {
+	case a == b:
+		return true
+	case isNumeric(a) && isNumeric(b):
+		return true
+	case isText(a) && isText(b):
+		return true
+	case (isText(a) && b == Timestamp) || (a == Timestamp && isText(b)):
+		return true
+	}
+	return false
+}
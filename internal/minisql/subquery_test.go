@@ -32,3 +32,30 @@ func TestScalarOperandType(t *testing.T) {
 		})
 	}
 }
+
+func TestComparableColumnKinds(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		a, b ColumnKind
+		want bool
+	}{
+		{Int8, Int8, true},
+		{Int4, Double, true},
+		{Varchar, Text, true},
+		{Text, Timestamp, true},
+		{UUID, UUID, true},
+		{Int8, Text, false},
+		{Boolean, Int8, false},
+		{Timestamp, Int8, false},
+		{UUID, Varchar, false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.a.String()+"_"+tc.b.String(), func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tc.want, comparableColumnKinds(tc.a, tc.b))
+			assert.Equal(t, tc.want, comparableColumnKinds(tc.b, tc.a))
+		})
+	}
+}
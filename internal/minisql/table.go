@@ -1,9 +1,12 @@
 package minisql
 
 import (
+	"cmp"
 	"context"
 	"errors"
 	"fmt"
+	"math"
+	"slices"
 	"strings"
 	"sync/atomic"
 
@@ -30,6 +33,10 @@ type Table struct {
 	// executeSelectFromDerivedTable. When set, sequentialScan iterates these
 	// in-memory rows instead of reading from the B+ tree pager.
 	virtualRows []Row
+	// virtualScan streams the rows of a virtual table that produces them on
+	// demand instead of holding them in virtualRows, such as the rowid view
+	// built by selectWithRowID.
+	virtualScan func(ctx context.Context, out func(Row) error) error
 	// parallelScan enables concurrent leaf-page scanning via parallelSequentialScan.
 	// Toggled by PRAGMA parallel_scan = on/off.
 	parallelScan bool
@@ -43,6 +50,9 @@ type Table struct {
 	// referencedColumns is a fast-lookup set of column names in this table that
 	// are referenced by FK constraints from other tables.
 	referencedColumns map[string]bool
+	// columnIndexes maps a column name to every index whose key includes the
+	// column, so the planner can find candidate indexes without scanning them all.
+	columnIndexes map[string][]IndexRef
 	// checkChildFK is called before INSERT/UPDATE to verify outgoing FK constraints.
 	// Set by *Database when the table is created or loaded.
 	checkChildFK func(context.Context, Row) error
@@ -59,12 +69,15 @@ type Table struct {
 	// metrics is the shared engine counter store. nil when not wired (unit tests).
 	metrics *engineMetrics
 	// allFields, overflow masks, textOverflowCols, vectorOverflowCols, and cachedTypeCodes are derived
-	// from Columns at construction time and reused across calls to avoid per-call allocations.
+	// from Columns by setColumns and reused across calls to avoid per-call allocations.
 	allFields          []Field
 	textOverflowMask   []bool
 	textOverflowCols   []Column
 	vectorOverflowCols []Column
 	cachedTypeCodes    []byte
+	// hasGeneratedColumns is set when any column is GENERATED ALWAYS AS, so
+	// that UPDATE only recomputes generated values for tables that have them.
+	hasGeneratedColumns bool
 	// rightmostTablePage caches the last leaf page index for SeekNextRowID so that
 	// sequential (autoincrement) inserts skip the O(log N) root→leaf traversal.
 	// lastTxIDTablePage guards against stale hints from rolled-back transactions.
@@ -88,8 +101,6 @@ type Table struct {
 func NewTable(logger *zap.Logger, pager TxPager, txManager *TransactionManager, name string, columns []Column, rootPageIdx PageIndex, provider TableProvider, opts ...TableOption) *Table {
 	table := &Table{
 		Name:                 name,
-		Columns:              columns,
-		columnCache:          make(map[string]int, len(columns)),
 		rootPageIdx:          rootPageIdx,
 		maximumICells:        InternalNodeMaxCells,
 		logger:               logger,
@@ -98,6 +109,7 @@ func NewTable(logger *zap.Logger, pager TxPager, txManager *TransactionManager,
 		UniqueIndexes:        make(map[string]UniqueIndex),
 		SecondaryIndexes:     make(map[string]SecondaryIndex),
 		columnIndexInfoCache: make(map[string]IndexInfo),
+		columnIndexes:        make(map[string][]IndexRef),
 		indexStats:           make(map[string]IndexStats),
 		provider:             provider,
 	}
@@ -110,34 +122,7 @@ func NewTable(logger *zap.Logger, pager TxPager, txManager *TransactionManager,
 		table.provider = &singleTableProvider{table: table}
 	}
 
-	// Build column name -> column index cache; also pre-compute derived column slices.
-	for i, col := range columns {
-		table.columnCache[col.Name] = i
-	}
-	table.allFields = fieldsFromColumns(columns...)
-	for _, col := range columns {
-		if col.MayUseOverflowText() {
-			if table.textOverflowMask == nil {
-				table.textOverflowMask = make([]bool, len(columns))
-			}
-			table.textOverflowCols = append(table.textOverflowCols, col)
-			if idx, ok := table.columnCache[col.Name]; ok {
-				table.textOverflowMask[idx] = true
-			}
-		}
-		if col.MayUseOverflowVector() {
-			table.vectorOverflowCols = append(table.vectorOverflowCols, col)
-		}
-	}
-	typeCodes := make([]byte, len(columns))
-	for i, col := range columns {
-		if col.Deleted {
-			typeCodes[i] = byte(TypeCodeNull)
-		} else {
-			typeCodes[i] = byte(kindToTypeCode(col.Kind))
-		}
-	}
-	table.cachedTypeCodes = typeCodes
+	table.setColumns(columns)
 
 	// Apply options
 	for _, opt := range opts {
@@ -147,11 +132,14 @@ func NewTable(logger *zap.Logger, pager TxPager, txManager *TransactionManager,
 	// Build column name -> IndexInfo cache
 	if table.HasPrimaryKey() {
 		table.columnIndexInfoCache[indexColumnHash(table.PrimaryKey.Columns)] = table.PrimaryKey.IndexInfo
+		table.addColumnIndexRefs(table.PrimaryKey.IndexInfo, true, true)
 	}
 	for _, index := range table.UniqueIndexes {
 		table.columnIndexInfoCache[indexColumnHash(index.Columns)] = index.IndexInfo
+		table.addColumnIndexRefs(index.IndexInfo, false, true)
 	}
 	for _, index := range table.SecondaryIndexes {
+		table.addColumnIndexRefs(index.IndexInfo, false, false)
 		if !index.IsBTree() {
 			continue
 		}
@@ -161,6 +149,47 @@ func NewTable(logger *zap.Logger, pager TxPager, txManager *TransactionManager,
 	return table
 }
 
+// setColumns installs columns as the table schema and recomputes the caches
+// derived from it. ALTER TABLE passes a fresh slice rather than mutating
+// Columns in place, so readers holding the old slice are unaffected.
+// isVirtual reports whether the table is an in-memory virtual table with no
+// B+ tree behind it.
+func (t *Table) isVirtual() bool {
+	return t.virtualRows != nil || t.virtualScan != nil
+}
+
+func (t *Table) setColumns(columns []Column) {
+	t.Columns = columns
+	t.columnCache = make(map[string]int, len(columns))
+	for i, col := range columns {
+		t.columnCache[col.Name] = i
+	}
+	t.allFields = fieldsFromColumns(columns...)
+	t.hasGeneratedColumns = hasGeneratedColumns(columns)
+	t.textOverflowMask, t.textOverflowCols, t.vectorOverflowCols = nil, nil, nil
+	for i, col := range columns {
+		if col.MayUseOverflowText() {
+			if t.textOverflowMask == nil {
+				t.textOverflowMask = make([]bool, len(columns))
+			}
+			t.textOverflowCols = append(t.textOverflowCols, col)
+			t.textOverflowMask[i] = true
+		}
+		if col.MayUseOverflowVector() {
+			t.vectorOverflowCols = append(t.vectorOverflowCols, col)
+		}
+	}
+	typeCodes := make([]byte, len(columns))
+	for i, col := range columns {
+		if col.Deleted {
+			typeCodes[i] = byte(TypeCodeNull)
+		} else {
+			typeCodes[i] = byte(kindToTypeCode(col.Kind))
+		}
+	}
+	t.cachedTypeCodes = typeCodes
+}
+
 func indexColumnHash(columns []Column) string {
 	var hash strings.Builder
 	for i, col := range columns {
@@ -192,7 +221,11 @@ func (p *singleTableProvider) GetTable(ctx context.Context, name string) (*Table
 // SetSecondaryIndex registers or replaces a secondary index on the table and
 // updates the column-to-IndexInfo cache used by the query planner.
 func (t *Table) SetSecondaryIndex(si SecondaryIndex) {
+	if old, ok := t.SecondaryIndexes[si.Name]; ok {
+		t.removeColumnIndexRefs(old.IndexInfo)
+	}
 	t.SecondaryIndexes[si.Name] = si
+	t.addColumnIndexRefs(si.IndexInfo, false, false)
 	if !si.IsBTree() {
 		return
 	}
@@ -211,6 +244,7 @@ func (t *Table) RemoveSecondaryIndex(name string) {
 	if !ok {
 		return
 	}
+	t.removeColumnIndexRefs(si.IndexInfo)
 	if !si.IsBTree() {
 		delete(t.SecondaryIndexes, name)
 		return
@@ -223,6 +257,96 @@ func (t *Table) RemoveSecondaryIndex(name string) {
 	delete(t.SecondaryIndexes, name)
 }
 
+// IndexRef identifies an index whose key includes a given column.
+type IndexRef struct {
+	Name     string
+	Method   IndexMethod
+	Position int // position of the column in the index key, 0 for the leading column
+	Primary  bool
+	Unique   bool // true for the primary key and unique indexes
+	Partial  bool // the index has a WHERE predicate and omits some rows
+}
+
+// IndexesOnColumn returns every index whose key includes the named column.
+// Primary key comes first, then unique indexes, then secondary indexes; within
+// each group indexes led by the column come before those that merely contain it.
+func (t *Table) IndexesOnColumn(name string) []IndexRef {
+	return t.columnIndexes[name]
+}
+
+// IsColumnIndexed reports whether the named column is part of any index key.
+func (t *Table) IsColumnIndexed(name string) bool {
+	return len(t.columnIndexes[name]) > 0
+}
+
+// singleColumnIndex returns a secondary index using method whose only key
+// column is the named column.
+func (t *Table) singleColumnIndex(name string, method IndexMethod) (SecondaryIndex, bool) {
+	for _, ref := range t.columnIndexes[name] {
+		if ref.Primary || ref.Unique || ref.Method != method || ref.Position != 0 {
+			continue
+		}
+		if si, ok := t.SecondaryIndexes[ref.Name]; ok && len(si.Columns) == 1 {
+			return si, true
+		}
+	}
+	return SecondaryIndex{}, false
+}
+
+func (t *Table) addColumnIndexRefs(info IndexInfo, primary, unique bool) {
+	if info.Expression != nil {
+		// Expression indexes are keyed by the expression, not by its columns.
+		return
+	}
+	if t.columnIndexes == nil {
+		t.columnIndexes = make(map[string][]IndexRef)
+	}
+	for i, col := range info.Columns {
+		refs := append(t.columnIndexes[col.Name], IndexRef{
+			Name:     info.Name,
+			Method:   info.Method,
+			Position: i,
+			Primary:  primary,
+			Unique:   unique,
+			Partial:  info.WhereClause != "",
+		})
+		slices.SortFunc(refs, compareIndexRefs)
+		t.columnIndexes[col.Name] = refs
+	}
+}
+
+func (t *Table) removeColumnIndexRefs(info IndexInfo) {
+	for _, col := range info.Columns {
+		refs := slices.DeleteFunc(t.columnIndexes[col.Name], func(ref IndexRef) bool {
+			return ref.Name == info.Name
+		})
+		if len(refs) == 0 {
+			delete(t.columnIndexes, col.Name)
+			continue
+		}
+		t.columnIndexes[col.Name] = refs
+	}
+}
+
+func compareIndexRefs(a, b IndexRef) int {
+	if a.Primary != b.Primary {
+		if a.Primary {
+			return -1
+		}
+		return 1
+	}
+	if a.Unique != b.Unique {
+		if a.Unique {
+			return -1
+		}
+		return 1
+	}
+	if c := cmp.Compare(a.Position, b.Position); c != 0 {
+		return c
+	}
+	return strings.Compare(a.Name, b.Name)
+}
+
 // FindExpressionIndex returns the secondary index whose Expression tree is
 // structurally equal to expr, or (SecondaryIndex{}, false) if none exists.
 func (t *Table) FindExpressionIndex(expr *Expr) (SecondaryIndex, bool) {
@@ -266,6 +390,31 @@ func (t *Table) ColumnByName(name string) (Column, bool) {
 	return Column{}, false
 }
 
+// EstimateRowSize returns the storage a row with values, given in table column
+// order, would take if inserted: inline is the size of its leaf cell, and
+// overflow is the size of the whole overflow pages its long TEXT, JSON and
+// VECTOR values would be spread over. Values streamed from a ReaderValue have
+// no known length and count as an empty overflow chain. Index entries are not
+// included.
+func (t *Table) EstimateRowSize(values []OptionalValue) (inline int, overflow int) {
+	row := NewRowWithValues(t.Columns, values)
+	var overflowPages uint32
+	for i, col := range row.Columns {
+		if i >= len(row.Values) || !row.Values[i].Valid {
+			continue
+		}
+		switch v := row.Values[i].Value.(type) {
+		case TextPointer:
+			if col.Kind.IsText() && !v.IsInline() {
+				overflowPages += v.NumberOfPages()
+			}
+		case VectorPointer:
+			overflowPages += (v.Dims*4 + MaxOverflowPageData - 1) / MaxOverflowPageData
+		}
+	}
+	return int(cellSize(row)), int(overflowPages) * PageSize
+}
+
 // IndexColumnsByIndexName returns the ordered column list for the named index
 // (primary key, unique, or secondary), or (nil, false) if no such index exists.
 func (t *Table) IndexColumnsByIndexName(name string) ([]Column, bool) {
@@ -436,6 +585,238 @@ func (t *Table) SeekLast(ctx context.Context, pageIdx PageIndex) (*Cursor, error
 	}, nil
 }
 
+// ScanReverse calls fn for every row in the table in descending row ID order,
+// starting at the last leaf and following the PrevLeaf chain backwards. Only
+// selectedFields are decoded (all columns when empty). Returning errStopScan
+// from fn ends the scan early without an error.
+func (t *Table) ScanReverse(ctx context.Context, fn func(Row) error, selectedFields ...Field) error {
+	return t.scanKeyRangeReverse(ctx, rowIDRange{From: 0, To: math.MaxUint64}, fn, selectedFields...)
+}
+
+// scanKeyRangeReverse is scanKeyRange in descending key order: it seeks to the
+// end of the range and follows the PrevLeaf chain backwards from there.
+func (t *Table) scanKeyRangeReverse(ctx context.Context, r rowIDRange, fn func(Row) error, selectedFields ...Field) error {
+	cursor, err := t.Seek(ctx, r.To)
+	if err != nil {
+		return fmt.Errorf("scan reverse: %w", err)
+	}
+	if len(selectedFields) == 0 {
+		selectedFields = t.allFields
+	}
+	selectedMask := selectedColumnsMask(t.Columns, selectedFields)
+
+	// The seek lands on r.To or on the first key after it.
+	pageIdx, cellIdx := cursor.PageIdx, int(cursor.CellIdx)
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		page, err := t.pager.ReadPage(ctx, pageIdx)
+		if err != nil {
+			return fmt.Errorf("scan reverse: read page %d: %w", pageIdx, err)
+		}
+		for i := min(cellIdx, int(page.LeafNode.Header.Cells)-1); i >= 0; i-- {
+			cell := page.LeafNode.Cells[i]
+			if cell.Key > r.To {
+				continue
+			}
+			if cell.Key < r.From {
+				return nil
+			}
+			row, err := NewRowView(t.Columns, cell).MaterializeWithOverflow(ctx, t.pager, selectedMask)
+			if err != nil {
+				return fmt.Errorf("scan reverse: materialize row: %w", err)
+			}
+			if err := fn(row); err != nil {
+				if errors.Is(err, errStopScan) {
+					return nil
+				}
+				return err
+			}
+		}
+
+		prevLeaf, err := t.prevLeaf(ctx, page)
+		if err != nil {
+			return fmt.Errorf("scan reverse: %w", err)
+		}
+		if prevLeaf == 0 {
+			return nil
+		}
+		pageIdx, cellIdx = prevLeaf, math.MaxInt
+	}
+}
+
+// prevLeaf returns the leaf before page in row ID order, or 0 for the first
+// leaf. Legacy leaves do not store PrevLeaf, so it is found by climbing to the
+// first ancestor that has a child to the left of the path and descending the
+// rightmost path of that child.
+func (t *Table) prevLeaf(ctx context.Context, page *Page) (PageIndex, error) {
+	if !page.LeafNode.Header.LegacyLinks {
+		return page.LeafNode.Header.PrevLeaf, nil
+	}
+
+	childIdx, header := page.Index, page.LeafNode.Header.Header
+	for !header.IsRoot {
+		parent, err := t.pager.ReadPage(ctx, header.Parent)
+		if err != nil {
+			return 0, fmt.Errorf("prev leaf: read page %d: %w", header.Parent, err)
+		}
+		pos, err := parent.InternalNode.IndexOfPage(childIdx)
+		if err != nil {
+			return 0, fmt.Errorf("prev leaf: %w", err)
+		}
+		if pos > 0 {
+			pageIdx, err := parent.InternalNode.Child(pos - 1)
+			if err != nil {
+				return 0, fmt.Errorf("prev leaf: %w", err)
+			}
+			for {
+				page, err := t.pager.ReadPage(ctx, pageIdx)
+				if err != nil {
+					return 0, fmt.Errorf("prev leaf: read page %d: %w", pageIdx, err)
+				}
+				if page.LeafNode != nil {
+					return pageIdx, nil
+				}
+				pageIdx = page.InternalNode.Header.RightChild
+			}
+		}
+		childIdx, header = header.Parent, parent.InternalNode.Header.Header
+	}
+	return 0, nil
+}
+
+// ScanChan streams every row in the table, in ascending row ID order, onto
+// the returned row channel from a background goroutine so callers can process
+// rows while leaves are still being read. Both channels are closed once the
+// scan ends; a read error, or ctx.Err() when ctx is cancelled, is sent on the
+// error channel first. Cancelling ctx is the only way to stop a consumer that
+// does not drain the row channel. Any transaction in ctx must stay open until
+// the row channel is closed.
+func (t *Table) ScanChan(ctx context.Context) (<-chan Row, <-chan error) {
+	var (
+		rowsCh = make(chan Row)
+		errCh  = make(chan error, 1)
+	)
+	go func() {
+		defer close(errCh)
+		defer close(rowsCh)
+		if err := t.scanToChan(ctx, rowsCh); err != nil {
+			errCh <- err
+		}
+	}()
+	return rowsCh, errCh
+}
+
+func (t *Table) scanToChan(ctx context.Context, rowsCh chan<- Row) error {
+	return t.Scan(ctx, func(row Row) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		select {
+		case rowsCh <- row:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	})
+}
+
+// Scan calls fn for every row in the table in ascending row ID order,
+// starting at the first leaf and following the NextLeaf chain. Only
+// selectedFields are decoded (all columns when empty). Returning errStopScan
+// from fn ends the scan early without an error.
+func (t *Table) Scan(ctx context.Context, fn func(Row) error, selectedFields ...Field) error {
+	cursor, err := t.SeekFirst(ctx)
+	if err != nil {
+		return fmt.Errorf("scan: %w", err)
+	}
+	if len(selectedFields) == 0 {
+		selectedFields = t.allFields
+	}
+	selectedMask := selectedColumnsMask(t.Columns, selectedFields)
+
+	pageIdx := cursor.PageIdx
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		page, err := t.pager.ReadPage(ctx, pageIdx)
+		if err != nil {
+			return fmt.Errorf("scan: read page %d: %w", pageIdx, err)
+		}
+		for i := range page.LeafNode.Header.Cells {
+			view := NewRowView(t.Columns, page.LeafNode.Cells[i])
+			row, err := view.MaterializeWithOverflow(ctx, t.pager, selectedMask)
+			if err != nil {
+				return fmt.Errorf("scan: materialize row: %w", err)
+			}
+			if err := fn(row); err != nil {
+				if errors.Is(err, errStopScan) {
+					return nil
+				}
+				return err
+			}
+		}
+
+		if page.LeafNode.Header.NextLeaf == 0 {
+			return nil
+		}
+		pageIdx = page.LeafNode.Header.NextLeaf
+	}
+}
+
+// scanKeyRange calls fn for every row whose key lies in r, in ascending key
+// order. It seeks to the start of the range and follows the leaf chain from
+// there, so only the leaves holding the range are read. Only selectedFields
+// are decoded (all columns when empty). Returning errStopScan from fn ends the
+// scan early without an error; any other error from fn is returned as is.
+func (t *Table) scanKeyRange(ctx context.Context, r rowIDRange, fn func(Row) error, selectedFields ...Field) error {
+	cursor, err := t.Seek(ctx, r.From)
+	if err != nil {
+		return fmt.Errorf("scan key range: %w", err)
+	}
+	if len(selectedFields) == 0 {
+		selectedFields = t.allFields
+	}
+	selectedMask := selectedColumnsMask(t.Columns, selectedFields)
+
+	pageIdx, cellIdx := cursor.PageIdx, cursor.CellIdx
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		page, err := t.pager.ReadPage(ctx, pageIdx)
+		if err != nil {
+			return fmt.Errorf("scan key range: read page %d: %w", pageIdx, err)
+		}
+		for i := cellIdx; i < page.LeafNode.Header.Cells; i++ {
+			cell := page.LeafNode.Cells[i]
+			if cell.Key > r.To {
+				return nil
+			}
+			row, err := NewRowView(t.Columns, cell).MaterializeWithOverflow(ctx, t.pager, selectedMask)
+			if err != nil {
+				return fmt.Errorf("scan key range: materialize row: %w", err)
+			}
+			if err := fn(row); err != nil {
+				if errors.Is(err, errStopScan) {
+					return nil
+				}
+				return err
+			}
+		}
+
+		if page.LeafNode.Header.NextLeaf == 0 {
+			return nil
+		}
+		pageIdx, cellIdx = page.LeafNode.Header.NextLeaf, 0
+	}
+}
+
 // Seek the cursor for a key, if it does not exist then return the cursor
 // for the page and cell where it should be inserted
 func (t *Table) Seek(ctx context.Context, key RowID) (*Cursor, error) {
@@ -530,6 +911,12 @@ func (t *Table) createNewRoot(ctx context.Context, rightChildPageIdx PageIndex)
 		leftChildPage.LeafNode = NewLeafNode()
 		*leftChildPage.LeafNode = *oldRootPage.LeafNode
 		leftChildPage.LeafNode.Header.IsRoot = false
+		leftChildPage.LeafNode.Header.NextAutoincrement = 0
+		// The old root's contents moved, so the right sibling must link back
+		// to the new left child rather than the root page.
+		if rightChildPage.LeafNode != nil {
+			rightChildPage.LeafNode.Header.PrevLeaf = leftChildPage.Index
+		}
 	} else if oldRootPage.InternalNode != nil {
 		// New pages by default are leafs so we need to reset left child page
 		// as an internal node here
@@ -537,6 +924,7 @@ func (t *Table) createNewRoot(ctx context.Context, rightChildPageIdx PageIndex)
 		leftChildPage.InternalNode = NewInternalNode()
 		*leftChildPage.InternalNode = *oldRootPage.InternalNode
 		leftChildPage.InternalNode.Header.IsRoot = false
+		leftChildPage.InternalNode.Header.NextAutoincrement = 0
 		// Update parent for all child pages
 		for i := 0; i < int(leftChildPage.InternalNode.Header.KeysNum); i++ {
 			childPage, err := t.pager.ModifyPage(ctx, leftChildPage.InternalNode.ICells[i].Child)
@@ -555,6 +943,7 @@ func (t *Table) createNewRoot(ctx context.Context, rightChildPageIdx PageIndex)
 
 	// Change root node to a new internal node
 	newRootNode := NewInternalNode()
+	newRootNode.Header.NextAutoincrement = oldRootPage.nextAutoincrement()
 	oldRootPage.LeafNode = nil
 	oldRootPage.InternalNode = newRootNode
 	newRootNode.Header.IsRoot = true
@@ -1115,6 +1504,13 @@ func (t *Table) mergeLeaves(ctx context.Context, parent, left, right *Page, idx
 	}
 	left.LeafNode.AppendCells(right.LeafNode.Cells[0:right.LeafNode.Header.Cells]...)
 	left.LeafNode.Header.NextLeaf = right.LeafNode.Header.NextLeaf
+	if nextLeaf := left.LeafNode.Header.NextLeaf; nextLeaf != 0 {
+		nextPage, err := t.pager.ModifyPage(ctx, nextLeaf)
+		if err != nil {
+			return fmt.Errorf("merge leaves: get next leaf page: %w", err)
+		}
+		nextPage.LeafNode.Header.PrevLeaf = left.Index
+	}
 
 	// Remove key from parent plus the right child pointer
 	if err := parent.InternalNode.DeleteKeyAndRightChild(idx); err != nil {
@@ -1129,11 +1525,14 @@ func (t *Table) mergeLeaves(ctx context.Context, parent, left, right *Page, idx
 		if err != nil {
 			return fmt.Errorf("get root page: %w", err)
 		}
+		nextAutoincrement := rootPage.nextAutoincrement()
 		rootPage.InternalNode = nil
 		rootPage.LeafNode = left.LeafNode.DeepClone()
 		rootPage.LeafNode.Header.IsRoot = true
 		rootPage.LeafNode.Header.Parent = 0
 		rootPage.LeafNode.Header.NextLeaf = 0
+		rootPage.LeafNode.Header.PrevLeaf = 0
+		rootPage.LeafNode.Header.NextAutoincrement = nextAutoincrement
 		return t.pager.AddFreePage(ctx, left.Index)
 	}
 
@@ -1157,11 +1556,13 @@ func (t *Table) rebalanceInternal(ctx context.Context, page *Page) error {
 			if err != nil {
 				return fmt.Errorf("rebalance internal: %w", err)
 			}
+			nextAutoincrement := rootPage.nextAutoincrement()
 			switch {
 			case firstChildPage.InternalNode != nil:
 				rootPage.InternalNode = firstChildPage.InternalNode.Clone()
 				rootPage.InternalNode.Header.IsRoot = true
 				rootPage.InternalNode.Header.Parent = 0
+				rootPage.InternalNode.Header.NextAutoincrement = nextAutoincrement
 				rootPage.LeafNode = nil
 				for _, childIdx := range rootPage.InternalNode.Children() {
 					childPage, err := t.pager.ModifyPage(ctx, childIdx)
@@ -1175,6 +1576,9 @@ func (t *Table) rebalanceInternal(ctx context.Context, page *Page) error {
 				rootPage.LeafNode = firstChildPage.LeafNode.DeepClone()
 				rootPage.LeafNode.Header.IsRoot = true
 				rootPage.LeafNode.Header.Parent = 0
+				rootPage.LeafNode.Header.NextLeaf = 0
+				rootPage.LeafNode.Header.PrevLeaf = 0
+				rootPage.LeafNode.Header.NextAutoincrement = nextAutoincrement
 			default:
 				return fmt.Errorf("rebalance internal: invalid child page type %d", firstChildPage.Index)
 			}
@@ -1406,10 +1810,12 @@ func (t *Table) mergeInternalNodes(ctx context.Context, parent, left, right *Pag
 		if err != nil {
 			return fmt.Errorf("get root page: %w", err)
 		}
+		nextAutoincrement := rootPage.nextAutoincrement()
 		rootPage.InternalNode = left.InternalNode.Clone()
 		rootPage.LeafNode = nil
 		rootPage.InternalNode.Header.IsRoot = true
 		rootPage.InternalNode.Header.Parent = 0
+		rootPage.InternalNode.Header.NextAutoincrement = nextAutoincrement
 		for _, childIdx := range rootPage.InternalNode.Children() {
 			childPage, err := t.pager.ModifyPage(ctx, childIdx)
 			if err != nil {
@@ -0,0 +1,69 @@
+package minisql
+
+import (
+	"context"
+	"slices"
+	"strings"
+)
+
+// TableInfo is an at-a-glance summary of one user table.
+type TableInfo struct {
+	Name    string
+	Columns int
+	// Rows comes from the running row count, so it costs no scan.
+	Rows int64
+	// Pages counts the table's B+ tree and overflow pages together with the
+	// pages of all its indexes.
+	Pages int
+	// Indexes lists the primary key, unique and secondary index names.
+	Indexes []string
+}
+
+// TableInfo returns a summary of every user table, sorted by name. Page
+// counts come from walking each table and index from its root page, so the
+// cost grows with the size of the database.
+func (d *Database) TableInfo(ctx context.Context) ([]TableInfo, error) {
+	tables := d.snapshotTables()
+	report := IntegrityReport{TotalPages: d.saver.TotalPages()}
+
+	infos := make([]TableInfo, 0, len(tables))
+	for name, table := range tables {
+		if isSystemTable(name) {
+			continue
+		}
+		rows, err := table.RowCount(ctx)
+		if err != nil {
+			return nil, err
+		}
+		livePages := make(map[PageIndex]string)
+		d.walkTableObjectPages(ctx, report, table, livePages)
+
+		infos = append(infos, TableInfo{
+			Name:    name,
+			Columns: len(table.Columns),
+			Rows:    rows,
+			Pages:   len(livePages),
+			Indexes: tableIndexNames(table),
+		})
+	}
+	slices.SortFunc(infos, func(a, b TableInfo) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	return infos, nil
+}
+
+func tableIndexNames(table *Table) []string {
+	var names []string
+	if table.HasPrimaryKey() && table.PrimaryKey.Index != nil {
+		names = append(names, table.PrimaryKey.Name)
+	}
+	for _, index := range table.UniqueIndexes {
+		names = append(names, index.Name)
+	}
+	for _, index := range table.SecondaryIndexes {
+		names = append(names, index.Name)
+	}
+	slices.Sort(names)
+	return names
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"math"
+	"slices"
 	"strings"
 
 	"go.uber.org/zap"
@@ -51,6 +52,20 @@ func (ii IndexInfo) WhereCondColumns() []string {
 	return cols
 }
 
+// referencesColumn reports whether the index depends on the named column, either
+// as a key column, as a source of its expression, or in its partial-index predicate.
+func (ii IndexInfo) referencesColumn(name string) bool {
+	if ii.Expression == nil {
+		for _, col := range ii.Columns {
+			if col.Name == name {
+				return true
+			}
+		}
+	}
+	return slices.Contains(exprSourceColumns(ii.Expression), name) ||
+		slices.Contains(ii.WhereCondColumns(), name)
+}
+
 // PrimaryKey associates the B+ tree index with the primary key metadata and
 // records whether the key column uses AUTOINCREMENT.
 type PrimaryKey struct {
@@ -129,6 +144,9 @@ func (t *Table) insertPrimaryKey(ctx context.Context, keyParts []OptionalValue,
 	// auto-generated keys don't collide with explicitly inserted keys.
 	if t.PrimaryKey.Autoincrement {
 		if newKey, ok := castedKey.(int64); ok {
+			if _, err := t.lastAutoincrement(ctx); err != nil {
+				return 0, err
+			}
 			for {
 				cached := t.lastAutoincrementKey.Load()
 				if cached >= newKey {
@@ -149,22 +167,11 @@ func (t *Table) insertAutoincrementedPrimaryKey(ctx context.Context, rowID RowID
 		return 0, fmt.Errorf("autoincrement primary key %s must be of type INT8", t.PrimaryKey.Name)
 	}
 
-	var newPrimaryKey int64
-	if cached := t.lastAutoincrementKey.Load(); cached >= 0 {
-		// Fast path: skip B-tree traversal; the cache holds the last written key.
-		newPrimaryKey = cached + 1
-	} else {
-		// Cold path: traverse PK index to find the highest existing key.
-		lastKey, err := t.PrimaryKey.Index.SeekLastKey(ctx, t.PrimaryKey.Index.GetRootPageIdx())
-		if err != nil {
-			return 0, err
-		}
-		lastPrimaryKey, ok := lastKey.(int64)
-		if !ok {
-			return 0, errors.New("failed to cast last primary key value for autoincrement")
-		}
-		newPrimaryKey = lastPrimaryKey + 1
+	lastPrimaryKey, err := t.lastAutoincrement(ctx)
+	if err != nil {
+		return 0, err
 	}
+	newPrimaryKey := lastPrimaryKey + 1
 
 	if ce := t.logger.Check(zap.DebugLevel, "inserting autoincremented primary key"); ce != nil {
 		ce.Write(
@@ -181,6 +188,97 @@ func (t *Table) insertAutoincrementedPrimaryKey(ctx context.Context, rowID RowID
 	return newPrimaryKey, nil
 }
 
+// lastAutoincrement returns the last autoincrement value handed out. The first
+// call seeds the in-memory cache from the larger of the counter stored in the
+// table's root page and the highest key in the primary key index, so values
+// freed by deleting the newest rows are not handed out again after a reopen.
+func (t *Table) lastAutoincrement(ctx context.Context) (int64, error) {
+	if cached := t.lastAutoincrementKey.Load(); cached >= 0 {
+		// Fast path: skip B-tree traversal; the cache holds the last written key.
+		return cached, nil
+	}
+
+	// Cold path: traverse PK index to find the highest existing key.
+	lastKey, err := t.PrimaryKey.Index.SeekLastKey(ctx, t.PrimaryKey.Index.GetRootPageIdx())
+	if err != nil {
+		return 0, err
+	}
+	lastPrimaryKey, ok := lastKey.(int64)
+	if !ok {
+		return 0, errors.New("failed to cast last primary key value for autoincrement")
+	}
+	rootPage, err := t.pager.ReadPage(ctx, t.GetRootPageIdx())
+	if err != nil {
+		return 0, fmt.Errorf("read root page: %w", err)
+	}
+	if next := rootPage.nextAutoincrement(); next-1 > lastPrimaryKey {
+		lastPrimaryKey = next - 1
+	}
+	t.lastAutoincrementKey.Store(lastPrimaryKey)
+	return lastPrimaryKey, nil
+}
+
+// saveNextAutoincrement stores the autoincrement counter in the table's root
+// page once inserts have moved it past the stored value.
+func (t *Table) saveNextAutoincrement(ctx context.Context) error {
+	last := t.lastAutoincrementKey.Load()
+	if last < 0 {
+		return nil
+	}
+	rootPage, err := t.pager.ReadPage(ctx, t.GetRootPageIdx())
+	if err != nil {
+		return fmt.Errorf("read root page: %w", err)
+	}
+	if rootPage.nextAutoincrement() > last {
+		return nil
+	}
+	rootPage, err = t.pager.ModifyPage(ctx, t.GetRootPageIdx())
+	if err != nil {
+		return fmt.Errorf("modify root page: %w", err)
+	}
+	rootPage.setNextAutoincrement(last + 1)
+	return nil
+}
+
+// NextAutoincrement returns the value the next autoincrement primary key of
+// the table will get.
+func (t *Table) NextAutoincrement(ctx context.Context) (int64, error) {
+	if !t.PrimaryKey.Autoincrement {
+		return 0, fmt.Errorf("table %s has no autoincrement primary key", t.Name)
+	}
+	last, err := t.lastAutoincrement(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return last + 1, nil
+}
+
+// SetNextAutoincrement sets the value the next autoincrement primary key of
+// the table will get. A value that is not above the highest existing key is
+// raised to one past it, so the next insert cannot hit a duplicate key.
+func (t *Table) SetNextAutoincrement(ctx context.Context, next int64) error {
+	if !t.PrimaryKey.Autoincrement {
+		return fmt.Errorf("table %s has no autoincrement primary key", t.Name)
+	}
+	lastKey, err := t.PrimaryKey.Index.SeekLastKey(ctx, t.PrimaryKey.Index.GetRootPageIdx())
+	if err != nil {
+		return err
+	}
+	lastPrimaryKey, ok := lastKey.(int64)
+	if !ok {
+		return errors.New("failed to cast last primary key value for autoincrement")
+	}
+	next = max(next, lastPrimaryKey+1, 1)
+
+	rootPage, err := t.pager.ModifyPage(ctx, t.GetRootPageIdx())
+	if err != nil {
+		return fmt.Errorf("modify root page: %w", err)
+	}
+	rootPage.setNextAutoincrement(next)
+	t.lastAutoincrementKey.Store(next - 1)
+	return nil
+}
+
 func (t *Table) insertCompositePrimaryKey(ctx context.Context, keyParts []OptionalValue, rowID RowID) (any, error) {
 	if t.PrimaryKey.Index == nil {
 		return nil, fmt.Errorf("table %s has primary key but no index", t.Name)
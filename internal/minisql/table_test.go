@@ -3,7 +3,9 @@ package minisql
 import (
 	"bytes"
 	"context"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -454,6 +456,71 @@ func TestTable_IndexColumnsByIndexName(t *testing.T) {
 	assert.False(t, ok)
 }
 
+func TestTable_IndexesOnColumn(t *testing.T) {
+	t.Parallel()
+
+	idCol := Column{Kind: Int8, Size: 8, Name: "id"}
+	emailCol := Column{Kind: Varchar, Size: 255, Name: "email"}
+	nameCol := Column{Kind: Varchar, Size: 100, Name: "name"}
+	bioCol := Column{Kind: Text, Name: "bio", Nullable: true}
+
+	table := NewTable(testLogger, nil, nil, "users", []Column{idCol, emailCol, nameCol, bioCol}, 0, nil,
+		WithPrimaryKey(PrimaryKey{IndexInfo: IndexInfo{Name: "pk__users", Columns: []Column{idCol}}}),
+		WithUniqueIndex(UniqueIndex{IndexInfo: IndexInfo{Name: "unique__users__email", Columns: []Column{emailCol}}}),
+	)
+
+	assert.Equal(t, []IndexRef{{Name: "pk__users", Primary: true, Unique: true}}, table.IndexesOnColumn("id"))
+	assert.Equal(t, []IndexRef{{Name: "unique__users__email", Unique: true}}, table.IndexesOnColumn("email"))
+	assert.False(t, table.IsColumnIndexed("name"))
+
+	// CREATE INDEX idx_name_email ON users (name, email)
+	table.SetSecondaryIndex(SecondaryIndex{IndexInfo: IndexInfo{Name: "idx_name_email", Columns: []Column{nameCol, emailCol}}})
+	// CREATE INDEX idx_bio ON users USING fulltext (bio) WHERE bio IS NOT NULL
+	table.SetSecondaryIndex(SecondaryIndex{IndexInfo: IndexInfo{
+		Name:        "idx_bio",
+		Columns:     []Column{bioCol},
+		Method:      IndexMethodFullText,
+		WhereClause: "bio IS NOT NULL",
+	}})
+
+	assert.True(t, table.IsColumnIndexed("name"))
+	assert.Equal(t, []IndexRef{{Name: "idx_name_email"}}, table.IndexesOnColumn("name"))
+	assert.Equal(t, []IndexRef{
+		{Name: "unique__users__email", Unique: true},
+		{Name: "idx_name_email", Position: 1},
+	}, table.IndexesOnColumn("email"))
+	assert.Equal(t, []IndexRef{{Name: "idx_bio", Method: IndexMethodFullText, Partial: true}}, table.IndexesOnColumn("bio"))
+
+	si, ok := table.singleColumnIndex("bio", IndexMethodFullText)
+	require.True(t, ok)
+	assert.Equal(t, "idx_bio", si.Name)
+	_, ok = table.singleColumnIndex("bio", IndexMethodBTree)
+	assert.False(t, ok)
+	_, ok = table.singleColumnIndex("name", IndexMethodBTree)
+	assert.False(t, ok, "composite index is not a single-column index")
+
+	// Replacing an index with the same name swaps its columns.
+	table.SetSecondaryIndex(SecondaryIndex{IndexInfo: IndexInfo{Name: "idx_name_email", Columns: []Column{nameCol}}})
+	assert.Equal(t, []IndexRef{{Name: "unique__users__email", Unique: true}}, table.IndexesOnColumn("email"))
+	assert.Equal(t, []IndexRef{{Name: "idx_name_email"}}, table.IndexesOnColumn("name"))
+
+	// DROP INDEX
+	table.RemoveSecondaryIndex("idx_name_email")
+	table.RemoveSecondaryIndex("idx_bio")
+	assert.False(t, table.IsColumnIndexed("name"))
+	assert.False(t, table.IsColumnIndexed("bio"))
+	assert.True(t, table.IsColumnIndexed("email"))
+
+	// Expression indexes are not registered against their columns.
+	table.SetSecondaryIndex(SecondaryIndex{IndexInfo: IndexInfo{
+		Name:          "idx_lower_name",
+		Columns:       []Column{nameCol},
+		Expression:    &Expr{},
+		ExpressionSQL: "LOWER(name)",
+	}})
+	assert.False(t, table.IsColumnIndexed("name"))
+}
+
 func TestTable_EstimatedRowCount(t *testing.T) {
 	t.Parallel()
 
@@ -465,3 +532,233 @@ func TestTable_EstimatedRowCount(t *testing.T) {
 	table.getRowCount = func() int64 { return 42 }
 	assert.Equal(t, int64(42), table.estimatedRowCount())
 }
+
+func TestTable_ScanReverse(t *testing.T) {
+	var (
+		ctx           = context.Background()
+		pager, dbFile = initTest(t)
+		rows          = gen.MediumRows(60)
+		tablePager    = pager.ForTable(testMediumColumns)
+		txManager     = NewTransactionManager(zap.NewNop(), dbFile.Name(), mockPagerFactory(tablePager), pager, nil)
+		txPager       = NewTransactionalPager(tablePager, txManager, testTableName, "")
+		table         = NewTable(testLogger, txPager, txManager, testTableName, testMediumColumns, 0, nil)
+	)
+	table.maximumICells = 5
+
+	scanReverse := func(t *testing.T) []Row {
+		var scanned []Row
+		err := table.ScanReverse(ctx, func(row Row) error {
+			scanned = append(scanned, row)
+			return nil
+		})
+		require.NoError(t, err)
+		return scanned
+	}
+	assertReversed := func(t *testing.T, expected, actual []Row) {
+		require.Len(t, actual, len(expected))
+		for i := range expected {
+			assert.Equal(t, expected[len(expected)-1-i].Values, actual[i].Values, "row %d does not match expected", i)
+		}
+	}
+
+	t.Run("empty table", func(t *testing.T) {
+		assert.Empty(t, scanReverse(t))
+	})
+
+	stmt := Statement{
+		Kind:    Insert,
+		Fields:  fieldsFromColumns(testMediumColumns...),
+		Inserts: make([][]OptionalValue, 0, len(rows)),
+	}
+	for _, row := range rows {
+		stmt.Inserts = append(stmt.Inserts, row.Values)
+	}
+	mustInsert(ctx, t, table, txManager, stmt)
+
+	t.Run("multiple leaves", func(t *testing.T) {
+		assertReversed(t, rows, scanReverse(t))
+	})
+
+	t.Run("stop early", func(t *testing.T) {
+		var ids []any
+		err := table.ScanReverse(ctx, func(row Row) error {
+			ids = append(ids, row.Values[0].Value)
+			if len(ids) == 3 {
+				return errStopScan
+			}
+			return nil
+		}, Field{Name: "id"})
+		require.NoError(t, err)
+		assert.Equal(t, []any{
+			rows[len(rows)-1].Values[0].Value,
+			rows[len(rows)-2].Values[0].Value,
+			rows[len(rows)-3].Values[0].Value,
+		}, ids)
+	})
+
+	t.Run("key range", func(t *testing.T) {
+		var keys []RowID
+		err := table.scanKeyRangeReverse(ctx, rowIDRange{From: 10, To: 20}, func(row Row) error {
+			keys = append(keys, row.Key)
+			return nil
+		}, Field{Name: "id"})
+		require.NoError(t, err)
+		require.Len(t, keys, 11)
+		for i, key := range keys {
+			assert.Equal(t, RowID(20-i), key)
+		}
+	})
+
+	t.Run("legacy leaves without PrevLeaf", func(t *testing.T) {
+		// Simulate leaves written before PrevLeaf existed; the previous leaf is
+		// then found through the parent pages.
+		cursor, err := table.SeekFirst(ctx)
+		require.NoError(t, err)
+		prevLeaves := map[PageIndex]PageIndex{}
+		for pageIdx := cursor.PageIdx; pageIdx != 0; {
+			page, err := tablePager.GetPage(ctx, pageIdx)
+			require.NoError(t, err)
+			prevLeaves[pageIdx] = page.LeafNode.Header.PrevLeaf
+			page.LeafNode.Header.LegacyLinks = true
+			page.LeafNode.Header.PrevLeaf = 0
+			pageIdx = page.LeafNode.Header.NextLeaf
+		}
+		require.Greater(t, len(prevLeaves), 2)
+		t.Cleanup(func() {
+			for pageIdx, prevLeaf := range prevLeaves {
+				page, err := tablePager.GetPage(ctx, pageIdx)
+				require.NoError(t, err)
+				page.LeafNode.Header.LegacyLinks = false
+				page.LeafNode.Header.PrevLeaf = prevLeaf
+			}
+		})
+
+		assertReversed(t, rows, scanReverse(t))
+	})
+
+	t.Run("after deletes merge leaves", func(t *testing.T) {
+		remaining := make([]Row, 0, len(rows))
+		for i, row := range rows {
+			if i%3 != 0 {
+				remaining = append(remaining, row)
+				continue
+			}
+			result := mustDelete(ctx, t, table, txManager, pager, Statement{
+				Kind:       Delete,
+				Conditions: OneOrMore{{FieldIsInAny(Field{Name: "id"}, rowIDs(row)...)}},
+			})
+			require.Equal(t, 1, result.RowsAffected)
+		}
+		assertTableBTreeInvariants(t, pager, table)
+		assertReversed(t, remaining, scanReverse(t))
+	})
+}
+
+func TestTable_ScanChan(t *testing.T) {
+	var (
+		ctx           = context.Background()
+		pager, dbFile = initTest(t)
+		rows          = gen.MediumRows(60)
+		tablePager    = pager.ForTable(testMediumColumns)
+		txManager     = NewTransactionManager(zap.NewNop(), dbFile.Name(), mockPagerFactory(tablePager), pager, nil)
+		txPager       = NewTransactionalPager(tablePager, txManager, testTableName, "")
+		table         = NewTable(testLogger, txPager, txManager, testTableName, testMediumColumns, 0, nil)
+	)
+	table.maximumICells = 5
+
+	stmt := Statement{
+		Kind:    Insert,
+		Fields:  fieldsFromColumns(testMediumColumns...),
+		Inserts: make([][]OptionalValue, 0, len(rows)),
+	}
+	for _, row := range rows {
+		stmt.Inserts = append(stmt.Inserts, row.Values)
+	}
+	mustInsert(ctx, t, table, txManager, stmt)
+
+	t.Run("streams every row", func(t *testing.T) {
+		rowsCh, errCh := table.ScanChan(ctx)
+		var scanned []Row
+		for row := range rowsCh {
+			scanned = append(scanned, row)
+		}
+		require.NoError(t, <-errCh)
+		require.Len(t, scanned, len(rows))
+		for i := range rows {
+			assert.Equal(t, rows[i].Values, scanned[i].Values, "row %d does not match expected", i)
+		}
+	})
+
+	t.Run("cancel stops the producer", func(t *testing.T) {
+		scanCtx, cancel := context.WithCancel(ctx)
+		rowsCh, errCh := table.ScanChan(scanCtx)
+		for range 5 {
+			_, ok := <-rowsCh
+			require.True(t, ok)
+		}
+		cancel()
+
+		// The producer goroutine closes both channels as it returns, so seeing
+		// them closed proves it stopped; at most one row already in flight
+		// can still be delivered.
+		received := 0
+		for range rowsCh {
+			received++
+		}
+		assert.LessOrEqual(t, received, 1)
+		select {
+		case err := <-errCh:
+			assert.ErrorIs(t, err, context.Canceled)
+		case <-time.After(time.Second):
+			t.Fatal("producer did not stop after cancel")
+		}
+		_, ok := <-errCh
+		assert.False(t, ok, "error channel is closed")
+	})
+}
+
+func TestTable_EstimateRowSize(t *testing.T) {
+	t.Parallel()
+
+	const tableName = "items"
+	createStmt := vacuumCreateStmt(tableName)
+	mockParser := new(MockParser)
+	mockParser.On("Parse", mock.Anything, createStmt.DDL()).Return([]Statement{createStmt}, nil)
+
+	db, _ := newVacuumTestDB(t, mockParser)
+	execInTx(t, db, func(ctx context.Context) {
+		_, err := db.ExecuteStatement(ctx, createStmt)
+		require.NoError(t, err)
+	})
+	table := db.tables[tableName]
+	pager := db.factory.ForTable(mainTableColumns)
+
+	t.Run("inline row", func(t *testing.T) {
+		name := "short"
+		inline, overflow := table.EstimateRowSize([]OptionalValue{
+			{Value: int64(1), Valid: true},
+			{Value: NewTextPointer([]byte(name)), Valid: true},
+		})
+		// 8B id + 4B text length + text bytes, plus the cell overhead.
+		assert.Equal(t, 8+4+len(name)+8+8+1+2, inline)
+		assert.Equal(t, 0, overflow)
+
+		_, overflow = table.EstimateRowSize([]OptionalValue{{Value: int64(1), Valid: true}, {}})
+		assert.Equal(t, 0, overflow)
+	})
+
+	t.Run("overflow row matches pages used", func(t *testing.T) {
+		name := strings.Repeat("x", 3*MaxOverflowPageData+10)
+		inline, overflow := table.EstimateRowSize([]OptionalValue{
+			{Value: int64(2), Valid: true},
+			{Value: NewTextPointer([]byte(name)), Valid: true},
+		})
+		assert.Equal(t, 4*PageSize, overflow)
+
+		before := pager.TotalPages()
+		insertRowInDB(t, db, tableName, 2, name)
+		assert.Equal(t, overflow/PageSize, int(pager.TotalPages()-before))
+		// The cell keeps only the length and first overflow page of the text.
+		assert.Equal(t, 8+4+4+8+8+1+2, inline)
+	})
+}
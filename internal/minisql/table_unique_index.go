@@ -13,9 +13,15 @@ import (
 
 // UniqueIndex associates a unique-enforcing B+ tree index with its metadata.
 // Attempting to insert a duplicate key into the underlying Index returns ErrDuplicateKey.
+//
+// NULL keys are never stored in the B+ tree, so by default any number of rows
+// may hold NULL in a unique column (NULLs are distinct). With NullsNotDistinct
+// set (UNIQUE NULLS NOT DISTINCT) NULLs compare equal: a key containing NULL is
+// checked against the existing rows with a table scan instead.
 type UniqueIndex struct {
 	Index BTreeIndex
 	IndexInfo
+	NullsNotDistinct bool
 }
 
 // UniqueIndexName returns the canonical internal name for a unique index,
@@ -49,7 +55,7 @@ func (t *Table) insertUniqueIndexKey(ctx context.Context, uniqueIndex UniqueInde
 
 	// We only need to insert into the unique index if the key is not NULL
 	if !key.Valid {
-		return nil
+		return t.checkNullUniqueKey(ctx, uniqueIndex, keyParts, rowID)
 	}
 
 	castedKey, err := castKeyValue(uniqueIndex.Columns[0], key.Value)
@@ -81,7 +87,7 @@ func (t *Table) insertUniqueCompositeIndexKey(ctx context.Context, uniqueIndex U
 	// don't apply when any indexed column contains NULL
 	for _, key := range keyParts {
 		if !key.Valid {
-			return nil
+			return t.checkNullUniqueKey(ctx, uniqueIndex, keyParts, rowID)
 		}
 	}
 
@@ -150,6 +156,8 @@ func (t *Table) updateUniqueIndexKey(ctx context.Context, uniqueIndex UniqueInde
 			}
 			return fmt.Errorf("failed to insert key for unique index %s: %w", uniqueIndex.Name, err)
 		}
+	} else if err := t.checkNullUniqueKey(ctx, uniqueIndex, []OptionalValue{newKey}, rowID); err != nil {
+		return err
 	}
 
 	if err := uniqueIndex.Index.Delete(ctx, castedOldKey, rowID); err != nil {
@@ -182,14 +190,19 @@ func (t *Table) updateCompositeUniqueIndexKey(ctx context.Context, uniqueIndex U
 	// Check if new key should be in the index (all columns non-NULL)
 	newKeyInIndex := true
 	newKeyValues := make([]any, 0, len(oldKeyParts))
+	newKeyParts := make([]OptionalValue, 0, len(uniqueIndex.Columns))
 	for _, col := range uniqueIndex.Columns {
 		keyValue, ok := row.GetValue(col.Name)
 		if !ok {
 			return fmt.Errorf("failed to get value for new unique index %s", uniqueIndex.Name)
 		}
+		newKeyParts = append(newKeyParts, keyValue)
 		if !keyValue.Valid {
 			newKeyInIndex = false
-			break
+			continue
+		}
+		if !newKeyInIndex {
+			continue
 		}
 		castedKey, err := castKeyValue(col, keyValue.Value)
 		if err != nil {
@@ -211,6 +224,8 @@ func (t *Table) updateCompositeUniqueIndexKey(ctx context.Context, uniqueIndex U
 			}
 			return fmt.Errorf("failed to insert key for unique index %s: %w", uniqueIndex.Name, err)
 		}
+	} else if err := t.checkNullUniqueKey(ctx, uniqueIndex, newKeyParts, rowID); err != nil {
+		return err
 	}
 
 	// Delete old key if it was in the index (all old columns were non-NULL)
@@ -224,6 +239,60 @@ func (t *Table) updateCompositeUniqueIndexKey(ctx context.Context, uniqueIndex U
 	return nil
 }
 
+// checkNullUniqueKey enforces UNIQUE NULLS NOT DISTINCT for a key that
+// contains at least one NULL and therefore has no entry in the B+ tree. It
+// scans the table for another row whose key matches, treating NULL as equal
+// to NULL. It is a no-op for indexes where NULLs are distinct.
+func (t *Table) checkNullUniqueKey(ctx context.Context, uniqueIndex UniqueIndex, keyParts []OptionalValue, rowID RowID) error {
+	if !uniqueIndex.NullsNotDistinct {
+		return nil
+	}
+
+	keyValues := make([]any, len(keyParts))
+	for i, key := range keyParts {
+		if !key.Valid {
+			continue
+		}
+		castedKey, err := castKeyValue(uniqueIndex.Columns[i], key.Value)
+		if err != nil {
+			return fmt.Errorf("failed to cast unique index value for %s: %w", uniqueIndex.Name, err)
+		}
+		keyValues[i] = castedKey
+	}
+
+	found := false
+	err := t.sequentialScan(ctx, Scan{}, t.allFields, func(row Row) error {
+		if row.Key == rowID {
+			return nil
+		}
+		for i, col := range uniqueIndex.Columns {
+			value, ok := row.GetValue(col.Name)
+			if !ok || value.Valid != keyParts[i].Valid {
+				return nil
+			}
+			if !value.Valid {
+				continue
+			}
+			castedValue, err := castKeyValue(col, value.Value)
+			if err != nil {
+				return fmt.Errorf("failed to cast unique index value for %s: %w", uniqueIndex.Name, err)
+			}
+			if castedValue != keyValues[i] {
+				return nil
+			}
+		}
+		found = true
+		return errStopScan
+	})
+	if err != nil && !errors.Is(err, errStopScan) {
+		return err
+	}
+	if found {
+		return newUniqueViolationError(t.Name, uniqueIndex.Name, uniqueIndex.Columns, ErrDuplicateKey)
+	}
+	return nil
+}
+
 func newUniqueViolationError(tableName, indexName string, columns []Column, cause error) error {
 	cols := make([]string, len(columns))
 	for i, c := range columns {
@@ -158,6 +158,20 @@ func (tx *Transaction) RowCountDeltas() map[string]int64 {
 	return tx.rowCountDeltas
 }
 
+// RowCountDelta returns the net uncommitted row-count change the transaction
+// has made to the named table.
+func (tx *Transaction) RowCountDelta(table string) int64 {
+	tx.mu.RLock()
+	defer tx.mu.RUnlock()
+	if tx.rowCountDeltas != nil {
+		return tx.rowCountDeltas[table]
+	}
+	if tx.hasRowCount && tx.rowCountTable == table {
+		return tx.rowCountDelta
+	}
+	return 0
+}
+
 // ForEachRowCountDelta calls fn for each accumulated row-count delta without
 // materialising a map for the common single-table transaction case.
 func (tx *Transaction) ForEachRowCountDelta(fn func(string, int64)) {
@@ -295,6 +309,30 @@ type DDLChanges struct {
 	DropIndexes   map[string]SecondaryIndex
 	CreateTables  []*Table
 	DropTables    []string
+	RenameTables  []TableRename
+	// TruncateTables lists tables emptied by TRUNCATE TABLE. Their contents
+	// are restored with the pages on rollback, only in-memory caches derived
+	// from them need to be reset.
+	TruncateTables []string
+	// AlterColumns records the column list replaced by each ALTER TABLE
+	// ADD, DROP or RENAME COLUMN. The rows are restored with the pages on
+	// rollback, the in-memory schema has to be put back from here.
+	AlterColumns []ColumnsChange
+}
+
+// TableRename records an ALTER TABLE ... RENAME TO within a transaction.  The
+// rename is applied to the in-memory schema immediately so later statements in
+// the same transaction see the new name; rollback reverts it.
+type TableRename struct {
+	OldName string
+	NewName string
+}
+
+// ColumnsChange records the columns a table had before an ALTER TABLE
+// within a transaction replaced them.
+type ColumnsChange struct {
+	Table   *Table
+	Columns []Column
 }
 
 // CreatedTable records a table creation in the DDL change set.
@@ -327,10 +365,31 @@ func (d DDLChanges) DroppedIndex(tableName string, index SecondaryIndex) DDLChan
 	return d
 }
 
+// RenamedTable records a table rename in the DDL change set.
+func (d DDLChanges) RenamedTable(oldName, newName string) DDLChanges {
+	d.RenameTables = append(d.RenameTables, TableRename{OldName: oldName, NewName: newName})
+	return d
+}
+
+// TruncatedTable records a TRUNCATE TABLE in the DDL change set.
+func (d DDLChanges) TruncatedTable(tableName string) DDLChanges {
+	d.TruncateTables = append(d.TruncateTables, tableName)
+	return d
+}
+
+// AlteredColumns records that an ALTER TABLE replaced the table's columns.
+func (d DDLChanges) AlteredColumns(t *Table, oldColumns []Column) DDLChanges {
+	d.AlterColumns = append(d.AlterColumns, ColumnsChange{Table: t, Columns: oldColumns})
+	return d
+}
+
 // HasChanges reports whether there are any uncommitted DDL changes.
 func (d DDLChanges) HasChanges() bool {
 	return len(d.CreateTables) > 0 ||
 		len(d.DropTables) > 0 ||
 		len(d.CreateIndexes) > 0 ||
-		len(d.DropIndexes) > 0
+		len(d.DropIndexes) > 0 ||
+		len(d.RenameTables) > 0 ||
+		len(d.TruncateTables) > 0 ||
+		len(d.AlterColumns) > 0
 }
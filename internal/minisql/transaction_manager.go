@@ -40,11 +40,13 @@ type TransactionManager struct {
 	ddlSaver             DDLSaver
 	factory              TxPagerFactory
 	checkpointFn         func() error
+	afterCommitFn        func()
 	rowCountApplier      func(map[string]int64)
 	rowCountDeltaApplier func(string, int64)
 	logger               *zap.Logger
 	cipher               *pkgcrypto.PageCipher // nil when encryption is disabled
 	metrics              *engineMetrics         // nil when metrics are not wired
+	tableWriteSeq        map[string]uint64      // nil unless TrackTableWrites was called
 	pageLastCommittedSeq map[PageIndex]uint64
 	pageVersionHistory   map[PageIndex][]pageVersion
 	commitHook           func(commitPhase)
@@ -107,6 +109,46 @@ func (tm *TransactionManager) SetCheckpointFunc(fn func() error) {
 	tm.checkpointFn = fn
 }
 
+// SetAfterCommitFunc registers a callback that is invoked after every
+// successful write commit, once all transaction manager locks are released.
+// The Database uses it to check whether an automatic VACUUM is due.
+func (tm *TransactionManager) SetAfterCommitFunc(fn func()) {
+	tm.afterCommitFn = fn
+}
+
+// TrackTableWrites makes every write commit record its commit sequence against
+// each table it modified, so TableWriteSeq can tell whether a table changed
+// after a given snapshot. The Database enables it for the query cache.
+func (tm *TransactionManager) TrackTableWrites() {
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+	if tm.tableWriteSeq == nil {
+		tm.tableWriteSeq = make(map[string]uint64)
+	}
+}
+
+// TableWriteSeq returns the commit sequence of the most recent committed write
+// to any of tables, or 0 if none of them has been written since tracking was
+// enabled. A result no greater than a transaction's SnapshotSeq means the
+// transaction sees the current contents of all of tables.
+func (tm *TransactionManager) TableWriteSeq(tables ...string) uint64 {
+	tm.mu.RLock()
+	defer tm.mu.RUnlock()
+	var seq uint64
+	for _, table := range tables {
+		seq = max(seq, tm.tableWriteSeq[table])
+	}
+	return seq
+}
+
+// hasActiveTransactions reports whether any read or write transaction is
+// currently registered with the manager.
+func (tm *TransactionManager) hasActiveTransactions() bool {
+	tm.mu.RLock()
+	defer tm.mu.RUnlock()
+	return len(tm.transactions) > 0
+}
+
 // SetRowCountApplier registers a callback that is invoked after each
 // successful write commit with the net row-count deltas for the transaction.
 // The Database uses this to keep in-memory row counts up to date so that
@@ -121,6 +163,17 @@ func (tm *TransactionManager) SetRowCountDeltaApplier(fn func(string, int64)) {
 	tm.rowCountDeltaApplier = fn
 }
 
+// walFrameCount returns the number of frames in the WAL, read under
+// walWriteMu so it is safe to call concurrently with commits.
+func (tm *TransactionManager) walFrameCount() int64 {
+	if tm.wal == nil {
+		return 0
+	}
+	tm.walWriteMu.Lock()
+	defer tm.walWriteMu.Unlock()
+	return tm.wal.FrameCount()
+}
+
 // CheckpointWAL checkpoints the WAL into dbFile, then truncates it.
 //
 // Checkpoint sequence (all under walWriteMu so no concurrent writers can
@@ -191,8 +244,9 @@ func (tm *TransactionManager) CheckpointWAL(dbFile DBFile) error {
 var ErrNotWALMode = errors.New("WAL mode is not enabled")
 
 // ExecuteReadOnlyTransaction runs fn within a read-only transaction.  Read
-// tracking is disabled so no ReadSet is built and conflict validation is
-// skipped at commit time.  fn must not perform any writes.
+// tracking is disabled so no ReadSet is built, no pages are journaled and
+// conflict validation is skipped at commit time.  Any write attempted by fn
+// fails with ErrReadOnlyTransaction.
 func (tm *TransactionManager) ExecuteReadOnlyTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
 	if TxFromContext(ctx) != nil {
 		return fn(ctx)
@@ -214,6 +268,12 @@ func (tm *TransactionManager) ExecuteReadOnlyTransaction(ctx context.Context, fn
 	return nil
 }
 
+// ExecuteInReadOnlyTransaction is ExecuteReadOnlyTransaction, named to pair
+// with ExecuteInTransaction.
+func (tm *TransactionManager) ExecuteInReadOnlyTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return tm.ExecuteReadOnlyTransaction(ctx, fn)
+}
+
 // ExecuteInTransaction runs fn within a transaction, committing on success or rolling back on failure.
 func (tm *TransactionManager) ExecuteInTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
 	// If there is a transaction already in context, use it.
@@ -223,7 +283,7 @@ func (tm *TransactionManager) ExecuteInTransaction(ctx context.Context, fn func(
 		return fn(ctx)
 	}
 
-	tx, err := tm.beginTransaction(ctx, true)
+	tx, err := tm.beginTransaction(ctx, true, false)
 	if err != nil {
 		return err
 	}
@@ -248,15 +308,27 @@ func (tm *TransactionManager) ExecuteInTransaction(ctx context.Context, fn func(
 // BeginTransaction starts a new write transaction and registers it with the manager.
 // Returns ErrConcurrentWriter if another write transaction is already active.
 func (tm *TransactionManager) BeginTransaction(ctx context.Context) (*Transaction, error) {
-	return tm.beginTransaction(ctx, false)
+	return tm.beginTransaction(ctx, false, false)
 }
 
-func (tm *TransactionManager) beginTransaction(ctx context.Context, pooled bool) (*Transaction, error) {
+// errActiveTransactions is returned by beginTransaction in exclusive mode when
+// any other transaction is registered.
+var errActiveTransactions = errors.New("other transactions are active")
+
+// beginTransaction registers a new write transaction. In exclusive mode it
+// also fails while any read-only transaction is active; the check and the
+// registration happen under one hold of tm.mu, so no transaction can start in
+// between.
+func (tm *TransactionManager) beginTransaction(ctx context.Context, pooled, exclusive bool) (*Transaction, error) {
 	tm.mu.Lock()
 	if tm.activeWriters.Load() > 0 {
 		tm.mu.Unlock()
 		return nil, ErrConcurrentWriter
 	}
+	if exclusive && len(tm.transactions) > 0 {
+		tm.mu.Unlock()
+		return nil, errActiveTransactions
+	}
 	tm.activeWriters.Add(1)
 	var tx *Transaction
 	if pooled {
@@ -443,10 +515,16 @@ func (tm *TransactionManager) PageVersionAtSnapshot(pageIdx PageIndex, snapshotS
 // When a WAL is configured it uses the WAL commit path; otherwise it writes directly to the pager
 // (used by unit tests that do not set up a WAL file).
 func (tm *TransactionManager) CommitTransaction(ctx context.Context, tx *Transaction) error {
+	var err error
 	if tm.wal != nil {
-		return tm.commitWithWAL(ctx, tx)
+		err = tm.commitWithWAL(ctx, tx)
+	} else {
+		err = tm.commitDirect(ctx, tx)
 	}
-	return tm.commitDirect(ctx, tx)
+	if err == nil && !tx.ReadOnly && tm.afterCommitFn != nil {
+		tm.afterCommitFn()
+	}
+	return err
 }
 
 // commitDirect is the non-WAL commit path: write pages straight to the pager.
@@ -475,6 +553,8 @@ func (tm *TransactionManager) commitDirect(ctx context.Context, tx *Transaction)
 		return nil
 	}
 
+	refreshPageHints(tx)
+
 	// Advance the commit sequence for MVCC snapshot isolation.
 	tm.commitSeq += 1
 	newSeq := tm.commitSeq
@@ -496,6 +576,9 @@ func (tm *TransactionManager) commitDirect(ctx context.Context, tx *Transaction)
 		}
 		tm.saver.SavePage(ctx, pageIdx, info.Page)
 		tm.pageLastCommittedSeq[pageIdx] = newSeq
+		if tm.tableWriteSeq != nil && info.Table != "" {
+			tm.tableWriteSeq[info.Table] = newSeq
+		}
 		pagesToFlush = append(pagesToFlush, pageIdx)
 	})
 
@@ -583,7 +666,9 @@ func (tm *TransactionManager) commitWithWAL(ctx context.Context, tx *Transaction
 	// re-acquire it without deadlocking.
 	tm.walWriteMu.Lock()
 
-	// Step 2: Serialise modified pages into WAL frames (outside both locks).
+	// Step 2: Serialise modified pages into WAL frames (outside both locks),
+	// refreshing leaf page hints first so the frames carry exact summaries.
+	refreshPageHints(tx)
 	walPages, err := tm.serializeWritesForWAL(ctx, tx)
 	if err != nil {
 		tx.Abort()
@@ -617,6 +702,9 @@ func (tm *TransactionManager) commitWithWAL(ctx context.Context, tx *Transaction
 		}
 		tm.saver.SavePage(ctx, pageIdx, info.Page)
 		tm.pageLastCommittedSeq[pageIdx] = newSeq
+		if tm.tableWriteSeq != nil && info.Table != "" {
+			tm.tableWriteSeq[info.Table] = newSeq
+		}
 	})
 	if tx.DDLChanges.HasChanges() {
 		tm.ddlSaver.SaveDDLChanges(ctx, tx.DDLChanges)
@@ -822,6 +910,9 @@ func (tm *TransactionManager) RollbackTransaction(ctx context.Context, tx *Trans
 			tm.saver.InvalidatePage(pageIdx)
 		}
 	})
+	if tx.DDLChanges.HasChanges() {
+		tm.ddlSaver.DiscardDDLChanges(ctx, tx.DDLChanges)
+	}
 	tx.Abort()
 
 	// Clean up transaction and GC any version history that is no longer needed.
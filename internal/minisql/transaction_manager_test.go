@@ -117,6 +117,26 @@ func TestTransactionManager_Commit(t *testing.T) {
 
 		mock.AssertExpectationsForObjects(t, saverMock)
 	})
+
+	t.Run("Exclusive write tx is refused while a reader is active", func(t *testing.T) {
+		var (
+			ctx       = context.Background()
+			saverMock = new(MockPageSaver)
+			txManager = NewTransactionManager(zap.NewNop(), testDBName, nil, saverMock, nil)
+		)
+
+		readTx := txManager.BeginReadOnlyTransaction(ctx)
+		_, err := txManager.beginTransaction(ctx, false, true)
+		require.ErrorIs(t, err, errActiveTransactions)
+		assert.Equal(t, int32(0), txManager.activeWriters.Load(), "counter must not change on rejection")
+		txManager.RollbackTransaction(ctx, readTx)
+
+		tx, err := txManager.beginTransaction(ctx, false, true)
+		require.NoError(t, err)
+		_, err = txManager.BeginTransaction(ctx)
+		require.ErrorIs(t, err, ErrConcurrentWriter)
+		txManager.RollbackTransaction(ctx, tx)
+	})
 }
 
 func TestTransactionManager_Rollback(t *testing.T) {
@@ -529,3 +549,65 @@ func TestTransactionManager_SetCheckpointFunc(t *testing.T) {
 	require.NoError(t, tm.checkpointFn())
 	assert.True(t, called)
 }
+
+func TestTransactionManager_ExecuteInReadOnlyTransaction(t *testing.T) {
+	t.Parallel()
+
+	const tableName = "items"
+	createStmt := vacuumCreateStmt(tableName)
+	mockParser := new(MockParser)
+	mockParser.On("Parse", mock.Anything, createStmt.DDL()).Return([]Statement{createStmt}, nil)
+
+	db, _ := newVacuumTestDB(t, mockParser)
+	execInTx(t, db, func(ctx context.Context) {
+		_, err := db.ExecuteStatement(ctx, createStmt)
+		require.NoError(t, err)
+	})
+	insertRowInDB(t, db, tableName, 1, "one")
+	commitSeq := db.txManager.commitSeq
+
+	t.Run("Reads do not journal pages", func(t *testing.T) {
+		err := db.txManager.ExecuteInReadOnlyTransaction(context.Background(), func(ctx context.Context) error {
+			result, err := db.ExecuteStatement(ctx, Statement{
+				Kind:      Select,
+				TableName: tableName,
+				Fields:    fieldsFromColumns(vacuumColumns...),
+			})
+			require.NoError(t, err)
+			rows, err := materializeResultRows(ctx, result)
+			require.NoError(t, err)
+			assert.Len(t, rows, 1)
+
+			tx := TxFromContext(ctx)
+			assert.True(t, tx.ReadOnly)
+			assert.Equal(t, 0, tx.WriteCount())
+			return nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, commitSeq, db.txManager.commitSeq)
+	})
+
+	t.Run("Writes are rejected", func(t *testing.T) {
+		err := db.txManager.ExecuteInReadOnlyTransaction(context.Background(), func(ctx context.Context) error {
+			_, err := db.ExecuteStatement(ctx, Statement{
+				Kind:      Insert,
+				TableName: tableName,
+				Fields:    fieldsFromColumns(vacuumColumns...),
+				Inserts: [][]OptionalValue{{
+					{Value: int64(2), Valid: true},
+					{Value: NewTextPointer([]byte("two")), Valid: true},
+				}},
+			})
+			return err
+		})
+		require.ErrorIs(t, err, ErrReadOnlyTransaction)
+
+		err = db.txManager.ExecuteInReadOnlyTransaction(context.Background(), func(ctx context.Context) error {
+			tp := NewTransactionalPager(db.factory.ForTable(mainTableColumns), db.txManager, SchemaTableName, "")
+			_, err := tp.ModifyPage(ctx, 0)
+			return err
+		})
+		require.ErrorIs(t, err, ErrReadOnlyTransaction)
+		assert.Equal(t, commitSeq, db.txManager.commitSeq)
+	})
+}
@@ -102,6 +102,9 @@ func (tp *TransactionalPager) ModifyPage(ctx context.Context, pageIdx PageIndex)
 	if tx == nil {
 		return nil, errors.New("cannot modify page outside transaction")
 	}
+	if tx.ReadOnly {
+		return nil, ErrReadOnlyTransaction
+	}
 
 	// Check if we already have a copy in write set
 	modifiedPage, exists := tx.GetModifiedPage(pageIdx)
@@ -0,0 +1,220 @@
+package minisql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/RichardKnop/minisql/pkg/bitwise"
+	"go.uber.org/zap"
+)
+
+// indexTruncater is implemented by index types that can drop every key at
+// once by freeing their pages instead of deleting keys one by one.
+type indexTruncater interface {
+	Truncate(ctx context.Context) error
+}
+
+// truncateTable executes TRUNCATE TABLE. Tables referenced by another table's
+// foreign key, and tables with full-text, inverted or HNSW indexes, fall back
+// to a row-by-row delete so that ON DELETE actions run and the dedicated index
+// storage is maintained.
+func (d *Database) truncateTable(ctx context.Context, table *Table) (StatementResult, error) {
+	if !table.canTruncate() || (d.foreignKeysEnabled && len(d.referencingTables(table.Name)) > 0) {
+		return table.Delete(ctx, Statement{Kind: Delete, TableName: table.Name})
+	}
+
+	result, err := table.Truncate(ctx)
+	if err != nil {
+		return result, err
+	}
+
+	tx := MustTxFromContext(ctx)
+	tx.DDLChanges = tx.DDLChanges.TruncatedTable(table.Name)
+
+	return result, nil
+}
+
+// canTruncate reports whether every index of the table is a B+ tree that can
+// be truncated in place.
+func (t *Table) canTruncate() bool {
+	if t.HasPrimaryKey() {
+		if _, ok := t.PrimaryKey.Index.(indexTruncater); !ok {
+			return false
+		}
+	}
+	for _, uniqueIndex := range t.UniqueIndexes {
+		if _, ok := uniqueIndex.Index.(indexTruncater); !ok {
+			return false
+		}
+	}
+	for _, secondaryIndex := range t.SecondaryIndexes {
+		if secondaryIndex.Method != IndexMethodBTree {
+			return false
+		}
+		if _, ok := secondaryIndex.Index.(indexTruncater); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// Truncate removes every row from the table without visiting rows one at a
+// time. All pages of the row B+ tree below the root, their text and vector
+// overflow pages and the pages of every index are returned to the free list,
+// and each tree is reset to an empty root leaf. Root page indexes do not
+// change, so the schema entries stay as they are. The autoincrement counter
+// restarts from 1. Returns the number of rows removed.
+func (t *Table) Truncate(ctx context.Context) (StatementResult, error) {
+	var (
+		leaves    []*Page
+		toFree    []PageIndex
+		rowsCount int
+	)
+	if err := t.BFS(ctx, func(page *Page) {
+		if page.LeafNode != nil {
+			leaves = append(leaves, page)
+			rowsCount += int(page.LeafNode.Header.Cells)
+		}
+		if page.Index != t.GetRootPageIdx() {
+			toFree = append(toFree, page.Index)
+		}
+	}); err != nil {
+		return StatementResult{}, fmt.Errorf("truncate table %s: %w", t.Name, err)
+	}
+
+	// Overflow pages must be freed while the leaf cells pointing to them
+	// are still readable.
+	if err := t.freeAllOverflowPages(ctx, leaves); err != nil {
+		return StatementResult{}, fmt.Errorf("truncate table %s: %w", t.Name, err)
+	}
+	for _, pageIdx := range toFree {
+		if err := t.pager.AddFreePage(ctx, pageIdx); err != nil {
+			return StatementResult{}, fmt.Errorf("truncate table %s: free page %d: %w", t.Name, pageIdx, err)
+		}
+	}
+
+	rootPage, err := t.pager.ModifyPage(ctx, t.GetRootPageIdx())
+	if err != nil {
+		return StatementResult{}, fmt.Errorf("truncate table %s: %w", t.Name, err)
+	}
+	rootPage.InternalNode = nil
+	rootPage.LeafNode = NewLeafNode()
+	rootPage.LeafNode.Header.IsRoot = true
+	rootPage.LeafNode.Header.Hint = newPageHint(t.Columns)
+
+	if t.HasPrimaryKey() {
+		if err := t.PrimaryKey.Index.(indexTruncater).Truncate(ctx); err != nil {
+			return StatementResult{}, fmt.Errorf("truncate primary key %s: %w", t.PrimaryKey.Name, err)
+		}
+	}
+	for _, uniqueIndex := range t.UniqueIndexes {
+		if err := uniqueIndex.Index.(indexTruncater).Truncate(ctx); err != nil {
+			return StatementResult{}, fmt.Errorf("truncate unique index %s: %w", uniqueIndex.Name, err)
+		}
+	}
+	for _, secondaryIndex := range t.SecondaryIndexes {
+		if err := secondaryIndex.Index.(indexTruncater).Truncate(ctx); err != nil {
+			return StatementResult{}, fmt.Errorf("truncate secondary index %s: %w", secondaryIndex.Name, err)
+		}
+	}
+
+	t.rightmostTablePage.Store(-1)
+	t.lastAutoincrementKey.Store(0)
+
+	if t.getRowCount != nil && rowsCount > 0 {
+		if tx := TxFromContext(ctx); tx != nil {
+			tx.AddRowCountDelta(t.Name, -int64(rowsCount))
+		}
+	}
+
+	if ce := t.logger.Check(zap.DebugLevel, "truncated table"); ce != nil {
+		ce.Write(zap.String("name", t.Name), zap.Int("rows", rowsCount), zap.Int("freed pages", len(toFree)))
+	}
+
+	return StatementResult{RowsAffected: rowsCount}, nil
+}
+
+// freeAllOverflowPages frees the text and vector overflow pages referenced by
+// every cell of the given leaves.
+func (t *Table) freeAllOverflowPages(ctx context.Context, leaves []*Page) error {
+	if len(t.textOverflowCols) == 0 && len(t.vectorOverflowCols) == 0 {
+		return nil
+	}
+
+	overflowColumns := make([]int, 0, len(t.textOverflowCols)+len(t.vectorOverflowCols))
+	for i, col := range t.Columns {
+		if !col.Deleted && (col.MayUseOverflowText() || col.MayUseOverflowVector()) {
+			overflowColumns = append(overflowColumns, i)
+		}
+	}
+
+	for _, leaf := range leaves {
+		for _, cell := range leaf.LeafNode.Cells[:leaf.LeafNode.Header.Cells] {
+			view := NewRowView(t.Columns, cell)
+			for _, colIdx := range overflowColumns {
+				if colIdx >= int(cell.ColumnCount) || bitwise.IsSet(cell.NullBitmask, colIdx) {
+					continue
+				}
+				value, err := view.ValueAt(colIdx)
+				if err != nil {
+					return fmt.Errorf("leaf %d: %w", leaf.Index, err)
+				}
+				row := Row{Columns: []Column{t.Columns[colIdx]}, Values: []OptionalValue{value}, Key: cell.Key}
+				if err := t.freeOverflowPages(ctx, row); err != nil {
+					return fmt.Errorf("leaf %d: %w", leaf.Index, err)
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// Truncate removes every key from the index. All pages below the root,
+// including row ID overflow pages, are returned to the free list and the root
+// is reset to an empty leaf, keeping its page index.
+func (ui *Index[T]) Truncate(ctx context.Context) error {
+	var (
+		toFree        []PageIndex
+		overflowHeads []PageIndex
+	)
+	if err := ui.BFS(ctx, func(page *Page) {
+		node := page.IndexNode.(*IndexNode[T])
+		for i := range node.Header.Keys {
+			if node.Cells[i].Overflow != 0 {
+				overflowHeads = append(overflowHeads, node.Cells[i].Overflow)
+			}
+		}
+		if page.Index != ui.GetRootPageIdx() {
+			toFree = append(toFree, page.Index)
+		}
+	}); err != nil {
+		return err
+	}
+
+	for _, overflowIdx := range overflowHeads {
+		for overflowIdx != 0 {
+			overflowPage, err := ui.pager.ReadPage(ctx, overflowIdx)
+			if err != nil {
+				return fmt.Errorf("read index overflow page %d: %w", overflowIdx, err)
+			}
+			toFree = append(toFree, overflowIdx)
+			overflowIdx = overflowPage.IndexOverflowNode.Header.NextPage
+		}
+	}
+
+	for _, pageIdx := range toFree {
+		if err := ui.pager.AddFreePage(ctx, pageIdx); err != nil {
+			return fmt.Errorf("free page %d: %w", pageIdx, err)
+		}
+	}
+
+	rootPage, err := ui.pager.ModifyPage(ctx, ui.GetRootPageIdx())
+	if err != nil {
+		return err
+	}
+	rootPage.IndexNode = NewRootIndexNode[T](ui.unique)
+	ui.rightmostLeaf.Store(-1)
+
+	return nil
+}
@@ -0,0 +1,113 @@
+package minisql
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestTable_Truncate(t *testing.T) {
+	var (
+		pager, dbFile = initTest(t)
+		ctx           = context.Background()
+		numRows       = 20
+		rows          = gen.MediumRows(numRows)
+		tablePager    = pager.ForTable(testMediumColumns)
+		txManager     = NewTransactionManager(zap.NewNop(), dbFile.Name(), mockPagerFactory(tablePager), pager, nil)
+		txPager       = NewTransactionalPager(tablePager, txManager, testTableName, "")
+		table         = NewTable(testLogger, txPager, txManager, testTableName, testMediumColumns, 0, nil)
+	)
+
+	stmt := Statement{
+		Kind:    Insert,
+		Fields:  fieldsFromColumns(testMediumColumns...),
+		Inserts: [][]OptionalValue{},
+	}
+	for _, row := range rows {
+		stmt.Inserts = append(stmt.Inserts, row.Values)
+	}
+
+	mustInsert(ctx, t, table, txManager, stmt)
+
+	// Root internal node on page 0 with four leaves on pages 1 to 4.
+	require.Equal(t, 5, int(pager.TotalPages()))
+	require.NotNil(t, pager.pages[0].InternalNode)
+
+	result := mustTruncate(ctx, t, table, txManager)
+
+	assert.Equal(t, numRows, result.RowsAffected)
+	checkRows(ctx, t, table, nil)
+
+	// Every page but the root is on the free list, the root is an empty leaf.
+	require.Equal(t, 5, int(pager.TotalPages()))
+	freePages, err := FreePages(ctx, tablePager)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []PageIndex{1, 2, 3, 4}, freePages)
+	assert.Nil(t, pager.pages[0].InternalNode)
+	require.NotNil(t, pager.pages[0].LeafNode)
+	assert.True(t, pager.pages[0].LeafNode.Header.IsRoot)
+	assert.Equal(t, 0, int(pager.pages[0].LeafNode.Header.Cells))
+
+	t.Run("Insert after truncate reuses free pages", func(t *testing.T) {
+		mustInsert(ctx, t, table, txManager, stmt)
+
+		checkRows(ctx, t, table, rows)
+		require.Equal(t, 5, int(pager.TotalPages()))
+		assertFreePages(t, tablePager, nil)
+	})
+}
+
+func TestTable_Truncate_Overflow(t *testing.T) {
+	var (
+		pager, dbFile = initTest(t)
+		ctx           = context.Background()
+		tablePager    = pager.ForTable(testOverflowColumns)
+		txManager     = NewTransactionManager(zap.NewNop(), dbFile.Name(), mockPagerFactory(tablePager), pager, nil)
+		txPager       = NewTransactionalPager(tablePager, txManager, testTableName, "")
+		table         = NewTable(testLogger, txPager, txManager, testTableName, testOverflowColumns, 0, nil)
+		rows          = gen.OverflowRows(3, []uint32{
+			MaxInlineVarchar,          // inline text
+			MaxInlineVarchar + 100,    // text overflows to 1 page
+			MaxOverflowPageData + 100, // text overflows to multiple pages
+		})
+	)
+
+	stmt := Statement{
+		Kind:    Insert,
+		Fields:  fieldsFromColumns(testOverflowColumns...),
+		Inserts: [][]OptionalValue{},
+	}
+	for _, row := range rows {
+		stmt.Inserts = append(stmt.Inserts, row.Values)
+	}
+
+	mustInsert(ctx, t, table, txManager, stmt)
+
+	require.Equal(t, 4, int(pager.TotalPages()))
+
+	result := mustTruncate(ctx, t, table, txManager)
+
+	assert.Equal(t, 3, result.RowsAffected)
+	checkRows(ctx, t, table, nil)
+
+	// All three text overflow pages are freed together with the rows.
+	require.Equal(t, 4, int(pager.TotalPages()))
+	freePages, err := FreePages(ctx, tablePager)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []PageIndex{1, 2, 3}, freePages)
+}
+
+func mustTruncate(ctx context.Context, t *testing.T, table *Table, txManager *TransactionManager) StatementResult {
+	t.Helper()
+	var result StatementResult
+	err := txManager.ExecuteInTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		result, err = table.Truncate(ctx)
+		return err
+	})
+	require.NoError(t, err)
+	return result
+}
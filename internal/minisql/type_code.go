@@ -7,15 +7,18 @@ package minisql
 //   - Cell.Unmarshal no longer needs the table schema to decode byte widths.
 //   - Lazy ADD COLUMN: rows written before a column was added carry a smaller
 //     ColumnCount; readers return the column default for positions ≥ ColumnCount.
-//   - Tombstone DROP COLUMN: the schema marks a column Deleted=true.  Old rows
-//     still have real bytes at that position (TypeCode carries the original kind
-//     so the reader can advance past them).  New rows written after DROP carry
-//     TypeCodeNull at the deleted position (0 bytes, NullBitmask bit set).
+//   - DROP COLUMN splices the column out of every cell, removing its TypeCode
+//     and NullBitmask bit.  Schemas from older databases may still carry
+//     tombstoned columns (Deleted=true); old rows keep real bytes at that
+//     position (TypeCode carries the original kind so the reader can advance
+//     past them) and newer rows carry TypeCodeNull there (0 bytes, NullBitmask
+//     bit set).
 type TypeCode byte
 
 // TypeCode constants map each ColumnKind to its one-byte on-disk tag.
 // TypeCodeNull marks dropped-column placeholder slots (0 bytes in the value area).
 // TypeCodeText covers Varchar, Text, and JSON — all use the 4-byte length-prefix + data encoding.
+// TypeCodeCustom covers kinds with a registered ValueCodec — a 4-byte length prefix + codec bytes.
 const (
 	TypeCodeNull      TypeCode = 0
 	TypeCodeBool      TypeCode = 1
@@ -27,6 +30,7 @@ const (
 	TypeCodeUUID      TypeCode = 7
 	TypeCodeText      TypeCode = 8
 	TypeCodeVector    TypeCode = 9
+	TypeCodeCustom    TypeCode = 10
 )
 
 // kindToTypeCode maps a ColumnKind to its TypeCode.
@@ -51,6 +55,9 @@ func kindToTypeCode(k ColumnKind) TypeCode {
 	case Vector:
 		return TypeCodeVector
 	default:
+		if isCustomColumnKind(k) {
+			return TypeCodeCustom
+		}
 		return TypeCodeNull
 	}
 }
@@ -71,7 +78,8 @@ func TypeCodesFromColumns(columns []Column) []byte {
 
 // typeCodeFixedSize returns the fixed byte width for the TypeCode's value in
 // the packed cell buffer.  Returns 0 for TypeCodeNull and -1 for TypeCodeText
-// (variable-length; caller must read the 4-byte length prefix).
+// and TypeCodeCustom (variable-length; use encodedValueSize to read the 4-byte
+// length prefix).
 func typeCodeFixedSize(tc TypeCode) int {
 	switch tc {
 	case TypeCodeNull:
@@ -84,7 +92,7 @@ func typeCodeFixedSize(tc TypeCode) int {
 		return 8
 	case TypeCodeUUID:
 		return 16
-	case TypeCodeText:
+	case TypeCodeText, TypeCodeCustom:
 		return -1
 	case TypeCodeVector:
 		return 8 // 4-byte dims + 4-byte first overflow page index
@@ -90,6 +90,13 @@ func (t *Table) Update(ctx context.Context, stmt Statement) (StatementResult, er
 				break
 			}
 
+			// A change to a generation source column may change the generated
+			// value, and with it its size and index keys.
+			if t.hasGeneratedColumns && generatedColumnDependsOn(t.Columns, colName) {
+				indexChanges = true
+				break
+			}
+
 			if t.HasIndexOnColumn(colName) && !compareValue(col.Kind, oldValue, newValue) {
 				// Updating indexed column, can't update in place
 				indexChanges = true
@@ -129,6 +136,9 @@ func (t *Table) Update(ctx context.Context, stmt Statement) (StatementResult, er
 				if len(stmt.ReturningFields) > 0 {
 					updatedKeys = append(updatedKeys, row.Key)
 				}
+				if stmt.ReturnAffectedKeys {
+					result.AffectedKeys = append(result.AffectedKeys, t.affectedKey(row))
+				}
 			}
 			return nil
 		}
@@ -142,6 +152,9 @@ func (t *Table) Update(ctx context.Context, stmt Statement) (StatementResult, er
 
 	// Apply deferred updates for rows that couldn't be updated in place.
 	for _, pending := range cantUpdateInPlace {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
 		cursor, err := t.Seek(ctx, pending.row.Key)
 		if err != nil {
 			return result, err
@@ -157,6 +170,9 @@ func (t *Table) Update(ctx context.Context, stmt Statement) (StatementResult, er
 			if len(stmt.ReturningFields) > 0 {
 				updatedKeys = append(updatedKeys, pending.row.Key)
 			}
+			if stmt.ReturnAffectedKeys {
+				result.AffectedKeys = append(result.AffectedKeys, t.affectedKey(pending.row))
+			}
 		}
 	}
 
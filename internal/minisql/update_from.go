@@ -2,6 +2,7 @@ package minisql
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"go.uber.org/zap"
@@ -25,17 +26,23 @@ func updateFromRowsFromContext(ctx context.Context) ([]Row, bool) {
 // executeUpdateFrom implements PostgreSQL-style UPDATE … FROM:
 //
 //	UPDATE t1 [AS alias] SET col = expr FROM t2 [AS alias] WHERE join_cond
+//	UPDATE t1 SET col = v.col FROM (VALUES (1, 'a'), (2, 'b')) AS v(id, col) WHERE t1.id = v.id
 //
 // It materialises all FROM-source rows, scans the target table without WHERE
 // filtering (so that cross-table conditions can be evaluated), builds a merged
 // row for each (target, from) pair, evaluates the full WHERE clause against
 // that merged row, resolves any *Expr SET values against the merged row, then
 // applies the update. Each target row may match at most one FROM row.
+//
+// When the WHERE clause equates a target column with a FROM column (e.g. a
+// primary-key join), the FROM rows are bucketed by that key so each target row
+// is only checked against the FROM rows that can possibly match it.
 func (d *Database) executeUpdateFrom(ctx context.Context, stmt Statement) (StatementResult, error) {
 	targetTable, ok := d.tables[stmt.TableName]
 	if !ok {
 		return StatementResult{}, minisqlErrors.ErrNoSuchTable{Name: stmt.TableName}
 	}
+	stmt.Columns = targetTable.Columns
 
 	// FROM rows are pre-materialised in ExecuteStatement (before the write lock
 	// is acquired) to prevent re-entrant dbLock acquisition.  If somehow we
@@ -54,6 +61,16 @@ func (d *Database) executeUpdateFrom(ctx context.Context, stmt Statement) (State
 	if targetAlias == "" {
 		targetAlias = stmt.TableName
 	}
+	fromAlias := stmt.UpdateFromAlias
+	if fromAlias == "" {
+		fromAlias = stmt.UpdateFromTable
+	}
+
+	targetKey, fromKey, hasJoinKey := updateFromJoinKey(stmt.Conditions, targetTable, targetAlias, fromAlias)
+	var fromBuckets map[string][]int
+	if hasJoinKey {
+		fromBuckets = bucketUpdateFromRows(fromRows, fromKey)
+	}
 
 	// Scan all target rows without WHERE filtering (conditions span both tables).
 	scanStmt := stmt
@@ -82,7 +99,18 @@ func (d *Database) executeUpdateFrom(ctx context.Context, stmt Statement) (State
 		// Find the unique FROM row that matches the WHERE clause.
 		var matched *Row
 		mergedRow := Row{}
-		for _, fromRow := range fromRows {
+		candidates := fromRows
+		if hasJoinKey {
+			idxs := fromBuckets[updateFromKeyString(targetRow, targetKey)]
+			if len(idxs) == 0 {
+				return nil // no FROM row shares the join key — target row unchanged
+			}
+			candidates = make([]Row, 0, len(idxs))
+			for _, idx := range idxs {
+				candidates = append(candidates, fromRows[idx])
+			}
+		}
+		for _, fromRow := range candidates {
 			mr := buildUpdateFromMergedRow(targetRow, targetAlias, fromRow)
 			ok, err := mr.CheckOneOrMore(stmt.Conditions)
 			if err != nil {
@@ -131,6 +159,9 @@ func (d *Database) executeUpdateFrom(ctx context.Context, stmt Statement) (State
 			if len(stmt.ReturningFields) > 0 {
 				updatedKeys = append(updatedKeys, pu.row.Key)
 			}
+			if stmt.ReturnAffectedKeys {
+				result.AffectedKeys = append(result.AffectedKeys, targetTable.affectedKey(pu.row))
+			}
 		}
 	}
 
@@ -154,11 +185,16 @@ func (d *Database) executeUpdateFrom(ctx context.Context, stmt Statement) (State
 	return result, nil
 }
 
-// materialiseFromSource fetches all rows from the UPDATE FROM source (table or
-// subquery) and returns them with column names prefixed by the FROM alias.
+// materialiseFromSource fetches all rows from the UPDATE FROM source (table,
+// subquery or VALUES list) and returns them with column names prefixed by the
+// FROM alias.
 func (d *Database) materialiseFromSource(ctx context.Context, stmt Statement) ([]Row, error) {
 	fromAlias := stmt.UpdateFromAlias
 
+	if len(stmt.UpdateFromValues) > 0 {
+		return updateFromValuesRows(stmt.UpdateFromValues, stmt.UpdateFromColumns, fromAlias)
+	}
+
 	if stmt.UpdateFromSubquery != nil {
 		result, err := d.ExecuteStatement(ctx, *stmt.UpdateFromSubquery)
 		if err != nil {
@@ -261,7 +297,8 @@ func resolveUpdateFromExprs(stmt Statement, mergedRow Row) (Statement, error) {
 			if result == nil {
 				resolved[colName] = OptionalValue{Valid: false}
 			} else {
-				resolved[colName] = OptionalValue{Value: result, Valid: true}
+				col, _ := stmt.ColumnByName(colName)
+				resolved[colName] = OptionalValue{Value: expr.wrapInt4(col.Kind, result), Valid: true}
 			}
 		} else {
 			resolved[colName] = val
@@ -270,3 +307,111 @@ func resolveUpdateFromExprs(stmt Statement, mergedRow Row) (Statement, error) {
 	stmt.Updates = resolved
 	return stmt, nil
 }
+
+// updateFromValuesRows converts the literal rows of an UPDATE … FROM (VALUES …)
+// source into alias-prefixed rows. Each column takes its kind from the first
+// non-NULL value in that position.
+func updateFromValuesRows(values [][]OptionalValue, columnNames []string, alias string) ([]Row, error) {
+	if len(columnNames) == 0 {
+		return nil, errors.New("UPDATE FROM VALUES: column list is required")
+	}
+	columns := make([]Column, len(columnNames))
+	for i, name := range columnNames {
+		columns[i] = Column{Name: name, Kind: Text, Nullable: true}
+		for _, row := range values {
+			if i < len(row) && row[i].Valid {
+				columns[i].Kind, columns[i].Size = valuesColumnKind(row[i].Value)
+				break
+			}
+		}
+	}
+
+	rows := make([]Row, 0, len(values))
+	for _, vals := range values {
+		if len(vals) != len(columns) {
+			return nil, fmt.Errorf("UPDATE FROM VALUES: row has %d values, expected %d", len(vals), len(columns))
+		}
+		rows = append(rows, prefixRowColumns(NewRowWithValues(columns, vals), alias))
+	}
+	return rows, nil
+}
+
+// valuesColumnKind maps a parsed literal to the column kind and size used for
+// the corresponding VALUES column.
+func valuesColumnKind(value any) (ColumnKind, uint32) {
+	switch value.(type) {
+	case bool:
+		return Boolean, 1
+	case int64:
+		return Int8, 8
+	case float64:
+		return Double, 8
+	default:
+		return Text, 0
+	}
+}
+
+// updateFromJoinKey looks for an equality condition that links a target column
+// to a FROM column, e.g. "users.id = v.id". It only applies when the WHERE
+// clause is a single AND group, so every matching pair must satisfy it. The
+// target column must be an integer or text column so that hashed key strings
+// agree with the comparison semantics of the full WHERE evaluation.
+func updateFromJoinKey(conditions OneOrMore, targetTable *Table, targetAlias, fromAlias string) (string, string, bool) {
+	if len(conditions) != 1 || fromAlias == "" {
+		return "", "", false
+	}
+	isTarget := func(f Field) bool {
+		return f.AliasPrefix == "" || f.AliasPrefix == targetAlias
+	}
+	for _, cond := range conditions[0] {
+		if cond.Operator != Eq || cond.Operand1.Type != OperandField || cond.Operand2.Type != OperandField {
+			continue
+		}
+		left, ok1 := cond.Operand1.Value.(Field)
+		right, ok2 := cond.Operand2.Value.(Field)
+		if !ok1 || !ok2 {
+			continue
+		}
+		var targetField, fromField Field
+		switch {
+		case isTarget(left) && right.AliasPrefix == fromAlias:
+			targetField, fromField = left, right
+		case isTarget(right) && left.AliasPrefix == fromAlias:
+			targetField, fromField = right, left
+		default:
+			continue
+		}
+		col, ok := targetTable.ColumnByName(targetField.Name)
+		if !ok || !(col.Kind.IsInt() || col.Kind.IsText()) {
+			continue
+		}
+		return targetField.Name, fromAlias + "." + fromField.Name, true
+	}
+	return "", "", false
+}
+
+// bucketUpdateFromRows groups FROM row positions by the hashed value of the
+// join column. Rows with a NULL key can never satisfy an equality and are
+// left out.
+func bucketUpdateFromRows(fromRows []Row, keyColumn string) map[string][]int {
+	buckets := make(map[string][]int, len(fromRows))
+	for i, row := range fromRows {
+		key := updateFromKeyString(row, keyColumn)
+		if key == "" {
+			continue
+		}
+		buckets[key] = append(buckets[key], i)
+	}
+	return buckets
+}
+
+// updateFromKeyString encodes the value of column in row as a bucket key.
+// It returns "" for NULL or missing values. Keys carry a one-byte prefix so
+// that an empty string value still produces a non-empty key.
+func updateFromKeyString(row Row, column string) string {
+	val, ok := row.GetValue(column)
+	if !ok || !val.Valid {
+		return ""
+	}
+	return string(appendHashKeyPart([]byte{'k'}, val.Value))
+}
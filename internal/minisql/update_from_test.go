@@ -333,6 +333,61 @@ func TestMaterialiseFromSource_Table(t *testing.T) {
 	_ = deptCols
 }
 
+func TestUpdateFromValuesRows(t *testing.T) {
+	t.Parallel()
+
+	rows, err := updateFromValuesRows([][]OptionalValue{
+		{{Valid: true, Value: int64(1)}, {}},
+		{{Valid: true, Value: int64(2)}, {Valid: true, Value: NewTextPointer([]byte("b"))}},
+	}, []string{"id", "email"}, "v")
+	require.NoError(t, err)
+	require.Len(t, rows, 2)
+
+	assert.Equal(t, []Column{
+		{Name: "v.id", Kind: Int8, Size: 8, Nullable: true},
+		{Name: "v.email", Kind: Text, Nullable: true},
+	}, rows[0].Columns)
+	email, ok := rows[1].GetValue("v.email")
+	require.True(t, ok)
+	assert.Equal(t, "b", email.Value.(TextPointer).String())
+
+	_, err = updateFromValuesRows([][]OptionalValue{{{Valid: true, Value: int64(1)}}}, []string{"id", "email"}, "v")
+	require.Error(t, err)
+}
+
+func TestUpdateFromJoinKey(t *testing.T) {
+	t.Parallel()
+
+	table := NewTable(testLogger, nil, nil, "users", []Column{
+		{Name: "id", Kind: Int8, Size: 8},
+		{Name: "score", Kind: Double, Size: 8},
+	}, 0, nil)
+	joinOn := func(left, right Field) OneOrMore {
+		return OneOrMore{{FieldIsEqual(left, OperandField, right)}}
+	}
+
+	targetKey, fromKey, ok := updateFromJoinKey(joinOn(Field{AliasPrefix: "u", Name: "id"}, Field{AliasPrefix: "v", Name: "id"}), table, "u", "v")
+	require.True(t, ok)
+	assert.Equal(t, "id", targetKey)
+	assert.Equal(t, "v.id", fromKey)
+
+	// Operands may appear in either order.
+	targetKey, fromKey, ok = updateFromJoinKey(joinOn(Field{AliasPrefix: "v", Name: "uid"}, Field{Name: "id"}), table, "u", "v")
+	require.True(t, ok)
+	assert.Equal(t, "id", targetKey)
+	assert.Equal(t, "v.uid", fromKey)
+
+	// Floating point columns are not bucketed.
+	_, _, ok = updateFromJoinKey(joinOn(Field{Name: "score"}, Field{AliasPrefix: "v", Name: "score"}), table, "u", "v")
+	assert.False(t, ok)
+
+	// OR groups fall back to the nested loop.
+	conds := joinOn(Field{Name: "id"}, Field{AliasPrefix: "v", Name: "id"})
+	conds = append(conds, conds[0])
+	_, _, ok = updateFromJoinKey(conds, table, "u", "v")
+	assert.False(t, ok)
+}
+
 func TestMaterialiseFromSource_NonExistentTable(t *testing.T) {
 	db := updateFromTestDB(t)
 	ctx := context.Background()
@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 	"os"
+
+	"go.uber.org/zap"
 )
 
 // vacuumCopier pre-computes the column-to-index maps for a table so that the
@@ -131,9 +133,12 @@ func (vc *vacuumCopier) copyRow(ctx context.Context, row Row) error {
 // data is intact in live.bak.  On restart the caller should check for a
 // live.bak file and rename it back if the expected database file is missing.
 //
+// Vacuum returns the number of pages reclaimed, the difference between the
+// page counts of the original and the compacted file.
+//
 // VACUUM must not be called from inside an explicit user transaction; doing so
 // returns an error.
-func (d *Database) Vacuum(ctx context.Context) error {
+func (d *Database) Vacuum(ctx context.Context) (int, error) {
 	return d.vacuumWithKey(ctx, d.encryptionKey)
 }
 
@@ -150,13 +155,19 @@ func (d *Database) Vacuum(ctx context.Context) error {
 //
 // ReKey must not be called from inside an explicit user transaction.
 func (d *Database) ReKey(ctx context.Context, newKey []byte) error {
-	return d.vacuumWithKey(ctx, newKey)
+	_, err := d.vacuumWithKey(ctx, newKey)
+	return err
 }
 
 // vacuumWithKey is the shared implementation for Vacuum and ReKey.
 // It creates a temp database encrypted with newKey (nil = plaintext), copies
-// all data, atomically swaps the files, and reopens with newKey.
-func (d *Database) vacuumWithKey(ctx context.Context, newKey []byte) error {
+// all data, atomically swaps the files, and reopens with newKey. It returns
+// the number of pages reclaimed.
+func (d *Database) vacuumWithKey(ctx context.Context, newKey []byte) (int, error) {
+	if d.InMemory() {
+		return 0, fmt.Errorf("vacuum: %w", ErrInMemoryDatabase)
+	}
+
 	tempFile := d.GetFileName() + ".tmp"
 	backupFile := d.GetFileName() + ".bak"
 
@@ -177,32 +188,39 @@ func (d *Database) vacuumWithKey(ctx context.Context, newKey []byte) error {
 	// of any outer transaction that the caller may have placed on ctx.
 	f, err := os.OpenFile(tempFile, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0o600)
 	if err != nil {
-		return fmt.Errorf("vacuum: create temp file: %w", err)
+		return 0, fmt.Errorf("vacuum: create temp file: %w", err)
 	}
 
 	tempPager, err := NewPager(f, PageSize, PageCacheSize)
 	if err != nil {
 		f.Close()
-		return fmt.Errorf("vacuum: create temp pager: %w", err)
+		return 0, fmt.Errorf("vacuum: create temp pager: %w", err)
 	}
 	// Skip per-commit fsyncs for the temp DB — it is a scratch file discarded on
 	// crash (the original is intact until the atomic rename succeeds). One fsync
 	// happens in Close() before the rename, which is sufficient for crash safety.
 	tempPager.SetNoIntermediateSync(true)
 
-	tempDBOpts := []DatabaseOption{WithHNSWVecCacheSize(d.hnswVecCacheSize)}
+	// Names already in the schema were accepted when they were created, so the
+	// copy must not reject them under a stricter identifier length limit.
+	tempDBOpts := []DatabaseOption{
+		WithHNSWVecCacheSize(d.hnswVecCacheSize),
+		WithMaxIdentifierLength(MaxInlineVarchar),
+	}
 	if len(newKey) > 0 {
 		tempDBOpts = append(tempDBOpts, WithEncryptionKey(newKey))
 	}
 	tempDB, err := NewDatabase(context.Background(), d.logger, tempFile, d.parser, tempPager, tempPager, nil, tempDBOpts...)
 	if err != nil {
-		return fmt.Errorf("vacuum: init temp database: %w", err)
+		return 0, fmt.Errorf("vacuum: init temp database: %w", err)
 	}
 
 	// --- PHASE 2: Acquire exclusive lock — blocks all concurrent operations. ---
 	d.dbLock.Lock()
 	defer d.dbLock.Unlock()
 
+	pagesBefore := int(d.saver.TotalPages())
+
 	// --- PHASE 3: Read all schema records from the live DB. ---
 	// listSchemas accesses d.tables directly and calls mainTable.Select(),
 	// both of which bypass the dbLock, so no deadlock occurs here.
@@ -212,7 +230,7 @@ func (d *Database) vacuumWithKey(ctx context.Context, newKey []byte) error {
 		schemas, err = d.listSchemas(txCtx)
 		return err
 	}); err != nil {
-		return fmt.Errorf("vacuum: list schemas: %w", err)
+		return 0, fmt.Errorf("vacuum: list schemas: %w", err)
 	}
 
 	// --- PHASE 4: Recreate schema in temp DB — tables first, then indexes. ---
@@ -226,13 +244,13 @@ func (d *Database) vacuumWithKey(ctx context.Context, newKey []byte) error {
 		}
 		stmts, err := d.parser.Parse(tempCtx, schema.DDL)
 		if err != nil {
-			return fmt.Errorf("vacuum: parse table DDL for %q: %w", schema.Name, err)
+			return 0, fmt.Errorf("vacuum: parse table DDL for %q: %w", schema.Name, err)
 		}
 		if err := tempDB.txManager.ExecuteInTransaction(tempCtx, func(txCtx context.Context) error {
 			_, err := tempDB.ExecuteStatement(txCtx, stmts[0])
 			return err
 		}); err != nil {
-			return fmt.Errorf("vacuum: recreate table %q: %w", schema.Name, err)
+			return 0, fmt.Errorf("vacuum: recreate table %q: %w", schema.Name, err)
 		}
 	}
 
@@ -245,7 +263,7 @@ func (d *Database) vacuumWithKey(ctx context.Context, newKey []byte) error {
 		}
 		stmts, err := d.parser.Parse(tempCtx, schema.DDL)
 		if err != nil {
-			return fmt.Errorf("vacuum: parse index DDL for table %q: %w", schema.TableName, err)
+			return 0, fmt.Errorf("vacuum: parse index DDL for table %q: %w", schema.TableName, err)
 		}
 		if stmts[0].IndexMethod == IndexMethodHNSW {
 			continue // deferred until after row copy
@@ -254,7 +272,7 @@ func (d *Database) vacuumWithKey(ctx context.Context, newKey []byte) error {
 			_, err := tempDB.ExecuteStatement(txCtx, stmts[0])
 			return err
 		}); err != nil {
-			return fmt.Errorf("vacuum: recreate index for table %q: %w", schema.TableName, err)
+			return 0, fmt.Errorf("vacuum: recreate index for table %q: %w", schema.TableName, err)
 		}
 	}
 
@@ -266,11 +284,11 @@ func (d *Database) vacuumWithKey(ctx context.Context, newKey []byte) error {
 
 		liveTable, ok := d.tables[schema.Name]
 		if !ok {
-			return fmt.Errorf("vacuum: live table %q not found", schema.Name)
+			return 0, fmt.Errorf("vacuum: live table %q not found", schema.Name)
 		}
 		tempTable, ok := tempDB.tables[schema.Name]
 		if !ok {
-			return fmt.Errorf("vacuum: temp table %q not found", schema.Name)
+			return 0, fmt.Errorf("vacuum: temp table %q not found", schema.Name)
 		}
 
 		liveFields := fieldsFromColumns(liveTable.Columns...)
@@ -294,10 +312,22 @@ func (d *Database) vacuumWithKey(ctx context.Context, newKey []byte) error {
 						return err
 					}
 				}
-				return result.Rows.Err()
+				if err := result.Rows.Err(); err != nil {
+					return err
+				}
+				// Carry the autoincrement counter over so that values freed by
+				// deleted rows are not handed out again after VACUUM.
+				if liveTable.PrimaryKey.Autoincrement {
+					next, err := liveTable.NextAutoincrement(readCtx)
+					if err != nil {
+						return err
+					}
+					return tempTable.SetNextAutoincrement(copyCtx, next)
+				}
+				return nil
 			})
 		}); err != nil {
-			return fmt.Errorf("vacuum: copy rows for table %q: %w", schema.Name, err)
+			return 0, fmt.Errorf("vacuum: copy rows for table %q: %w", schema.Name, err)
 		}
 	}
 
@@ -310,7 +340,7 @@ func (d *Database) vacuumWithKey(ctx context.Context, newKey []byte) error {
 		}
 		stmts, err := d.parser.Parse(tempCtx, schema.DDL)
 		if err != nil {
-			return fmt.Errorf("vacuum: parse HNSW index DDL for table %q: %w", schema.TableName, err)
+			return 0, fmt.Errorf("vacuum: parse HNSW index DDL for table %q: %w", schema.TableName, err)
 		}
 		if stmts[0].IndexMethod != IndexMethodHNSW {
 			continue
@@ -319,20 +349,20 @@ func (d *Database) vacuumWithKey(ctx context.Context, newKey []byte) error {
 			_, err := tempDB.ExecuteStatement(txCtx, stmts[0])
 			return err
 		}); err != nil {
-			return fmt.Errorf("vacuum: recreate HNSW index for table %q: %w", schema.TableName, err)
+			return 0, fmt.Errorf("vacuum: recreate HNSW index for table %q: %w", schema.TableName, err)
 		}
 	}
 
 	// --- PHASE 8: Flush and close both databases. ---
 	if err := tempDB.Close(); err != nil {
-		return fmt.Errorf("vacuum: close temp database: %w", err)
+		return 0, fmt.Errorf("vacuum: close temp database: %w", err)
 	}
 	// Close the live database without checkpointing or syncing — it is about to
 	// be replaced by the compacted copy. Delete the WAL file before renaming to
 	// prevent stale frames from being replayed against the new database on restart.
 	liveWALPath := d.GetFileName() + "-wal"
 	if err := d.closeForDiscard(); err != nil {
-		return fmt.Errorf("vacuum: close live database: %w", err)
+		return 0, fmt.Errorf("vacuum: close live database: %w", err)
 	}
 	os.Remove(liveWALPath)
 
@@ -343,17 +373,17 @@ func (d *Database) vacuumWithKey(ctx context.Context, newKey []byte) error {
 	// Move the live file to the backup path.  If this fails, the live file is
 	// untouched and no data is lost.
 	if err := os.Rename(d.GetFileName(), backupFile); err != nil {
-		return fmt.Errorf("vacuum: rename live to backup: %w", err)
+		return 0, fmt.Errorf("vacuum: rename live to backup: %w", err)
 	}
 
 	// Move the temp file into the live path.  On failure, restore from backup.
 	if err := os.Rename(tempFile, d.GetFileName()); err != nil {
 		if restoreErr := os.Rename(backupFile, d.GetFileName()); restoreErr != nil {
-			return fmt.Errorf(
+			return 0, fmt.Errorf(
 				"vacuum: swap failed (%w) and restore also failed (%v): "+
 					"original database is in %s", err, restoreErr, backupFile)
 		}
-		return fmt.Errorf("vacuum: swap failed, original database restored from backup: %w", err)
+		return 0, fmt.Errorf("vacuum: swap failed, original database restored from backup: %w", err)
 	}
 
 	// Swap succeeded — remove the backup.
@@ -363,13 +393,17 @@ func (d *Database) vacuumWithKey(ctx context.Context, newKey []byte) error {
 	// --- PHASE 10: Reopen the database with the compacted file. ---
 	newFile, err := os.OpenFile(d.GetFileName(), os.O_RDWR|os.O_CREATE, 0o600)
 	if err != nil {
-		return fmt.Errorf("vacuum: reopen database file: %w", err)
+		return 0, fmt.Errorf("vacuum: reopen database file: %w", err)
 	}
 
-	newPager, err := NewPager(newFile, PageSize, PageCacheSize)
+	var pagerOpts []PagerOption
+	if p, ok := d.saver.(interface{ GrowChunkPages() int }); ok {
+		pagerOpts = append(pagerOpts, WithGrowChunkPages(p.GrowChunkPages()))
+	}
+	newPager, err := NewPager(newFile, PageSize, PageCacheSize, pagerOpts...)
 	if err != nil {
 		newFile.Close()
-		return fmt.Errorf("vacuum: create new pager: %w", err)
+		return 0, fmt.Errorf("vacuum: create new pager: %w", err)
 	}
 
 	// Update the encryption key before reopening so that setupEncryption inside
@@ -382,7 +416,7 @@ func (d *Database) vacuumWithKey(ctx context.Context, newKey []byte) error {
 	// Note: d.wal is nil here (set by closeForDiscard), so the Reopen init
 	// transaction commits via commitDirect, avoiding WAL frame allocs.
 	if err := d.Reopen(tempCtx, newPager, newPager); err != nil {
-		return fmt.Errorf("vacuum: reopen database: %w", err)
+		return 0, fmt.Errorf("vacuum: reopen database: %w", err)
 	}
 
 	// Restore WAL AFTER Reopen so the init transaction above used commitDirect.
@@ -393,14 +427,19 @@ func (d *Database) vacuumWithKey(ctx context.Context, newKey []byte) error {
 		d.walIndex.Reset()
 		newWAL, _, walErr := OpenWALAndRebuildIndex(d.GetFileName(), PageSize, d.walIndex)
 		if walErr != nil {
-			return fmt.Errorf("vacuum: create WAL for reopened database: %w", walErr)
+			return 0, fmt.Errorf("vacuum: create WAL for reopened database: %w", walErr)
 		}
 		d.wal = newWAL
-		d.walDBFile = newFile
+		d.walDBFile = newPager.File()
 		d.txManager.wal = newWAL
 		d.txManager.walIndex = d.walIndex
 		newPager.SetWALIndex(d.walIndex)
 	}
 
-	return nil
+	reclaimed := max(pagesBefore-int(newPager.TotalPages()), 0)
+	if ce := d.logger.Check(zap.InfoLevel, "vacuum completed"); ce != nil {
+		ce.Write(zap.Int("pages before", pagesBefore), zap.Int("reclaimed pages", reclaimed))
+	}
+
+	return reclaimed, nil
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -29,7 +30,7 @@ func vacuumCreateStmt(tableName string) Statement {
 
 // newVacuumTestDB creates a fresh temp-file-backed Database with the given
 // mockParser.  The file is automatically removed on test cleanup.
-func newVacuumTestDB(t *testing.T, aParser Parser) (*Database, string) {
+func newVacuumTestDB(t *testing.T, aParser Parser, opts ...DatabaseOption) (*Database, string) {
 	t.Helper()
 
 	f, err := os.CreateTemp("", "vacuum_test_*.db")
@@ -43,7 +44,7 @@ func newVacuumTestDB(t *testing.T, aParser Parser) (*Database, string) {
 	pager, err := NewPager(f, PageSize, PageCacheSize)
 	require.NoError(t, err)
 
-	db, err := NewDatabase(context.Background(), testLogger, f.Name(), aParser, pager, pager, nil)
+	db, err := NewDatabase(context.Background(), testLogger, f.Name(), aParser, pager, pager, nil, opts...)
 	require.NoError(t, err)
 
 	return db, f.Name()
@@ -120,7 +121,8 @@ func TestVacuum_EmptyDatabase(t *testing.T) {
 	db, _ := newVacuumTestDB(t, nil)
 
 	ctx := context.Background()
-	require.NoError(t, db.Vacuum(ctx))
+	_, err := db.Vacuum(ctx)
+	require.NoError(t, err)
 
 	assert.Contains(t, db.ListTableNames(ctx), SchemaTableName)
 }
@@ -130,7 +132,8 @@ func TestVacuum_TempAndBackupFilesRemovedOnSuccess(t *testing.T) {
 	db, fileName := newVacuumTestDB(t, nil)
 
 	ctx := context.Background()
-	require.NoError(t, db.Vacuum(ctx))
+	_, err := db.Vacuum(ctx)
+	require.NoError(t, err)
 
 	_, errTmp := os.Stat(fileName + ".tmp")
 	assert.True(t, os.IsNotExist(errTmp), ".tmp file must be removed after successful vacuum")
@@ -163,7 +166,8 @@ func TestVacuum_SingleTableDataPreserved(t *testing.T) {
 	require.Equal(t, 5, countRowsInDB(t, db, tableName))
 
 	ctx := context.Background()
-	require.NoError(t, db.Vacuum(ctx))
+	_, err := db.Vacuum(ctx)
+	require.NoError(t, err)
 
 	// All rows must survive vacuum
 	assert.Equal(t, 5, countRowsInDB(t, db, tableName))
@@ -188,7 +192,8 @@ func TestVacuum_DatabaseWritableAfterVacuum(t *testing.T) {
 	require.Equal(t, 1, countRowsInDB(t, db, tableName))
 
 	ctx := context.Background()
-	require.NoError(t, db.Vacuum(ctx))
+	_, err := db.Vacuum(ctx)
+	require.NoError(t, err)
 
 	// Insert after vacuum must work
 	insertRowInDB(t, db, tableName, 2, "after")
@@ -223,7 +228,8 @@ func TestVacuum_MultipleTablesPreserved(t *testing.T) {
 	}
 
 	ctx := context.Background()
-	require.NoError(t, db.Vacuum(ctx))
+	_, err := db.Vacuum(ctx)
+	require.NoError(t, err)
 
 	assert.Equal(t, 3, countRowsInDB(t, db, "table_a"))
 	assert.Equal(t, 7, countRowsInDB(t, db, "table_b"))
@@ -249,8 +255,74 @@ func TestVacuum_RepeatedVacuumsAreIdempotent(t *testing.T) {
 
 	ctx := context.Background()
 	for range 3 {
-		require.NoError(t, db.Vacuum(ctx), "vacuum %d failed", 3)
+		_, err := db.Vacuum(ctx)
+		require.NoError(t, err, "vacuum %d failed", 3)
 	}
 
 	assert.Equal(t, 10, countRowsInDB(t, db, tableName))
 }
+
+func TestVacuum_ReportsReclaimedPages(t *testing.T) {
+	t.Parallel()
+	const (
+		tableName = "items"
+		numRows   = 1000
+		keepRows  = 50
+	)
+	createStmt := vacuumCreateStmt(tableName)
+
+	mockParser := new(MockParser)
+	mockParser.On("Parse", mock.Anything, createStmt.DDL()).Return([]Statement{createStmt}, nil)
+
+	db, _ := newVacuumTestDB(t, mockParser)
+	ctx := context.Background()
+
+	execInTx(t, db, func(ctx context.Context) {
+		_, err := db.ExecuteStatement(ctx, createStmt)
+		require.NoError(t, err)
+	})
+	execInTx(t, db, func(ctx context.Context) {
+		tbl := db.tables[tableName]
+		for i := int64(1); i <= numRows; i++ {
+			_, err := tbl.Insert(ctx, Statement{
+				Kind:   Insert,
+				Fields: fieldsFromColumns(tbl.Columns...),
+				Inserts: [][]OptionalValue{{
+					{Value: i, Valid: true},
+					{Value: NewTextPointer([]byte(fmt.Sprintf("%d-%s", i, strings.Repeat("x", 90)))), Valid: true},
+				}},
+			})
+			require.NoError(t, err)
+		}
+	})
+	execInTx(t, db, func(ctx context.Context) {
+		_, err := db.ExecuteStatement(ctx, Statement{
+			Kind:       Delete,
+			TableName:  tableName,
+			Conditions: OneOrMore{{FieldIsGreater(Field{Name: "id"}, OperandInteger, int64(keepRows))}},
+		})
+		require.NoError(t, err)
+	})
+
+	pagesBefore := int(db.saver.TotalPages())
+	freeBefore := int(db.factory.ForTable(mainTableColumns).GetHeader(ctx).FreePageCount)
+	require.Positive(t, freeBefore)
+
+	var result StatementResult
+	execInTx(t, db, func(ctx context.Context) {
+		var err error
+		result, err = db.ExecuteStatement(ctx, Statement{Kind: Vacuum})
+		require.NoError(t, err)
+	})
+
+	assert.Positive(t, result.RowsAffected)
+	assert.GreaterOrEqual(t, result.RowsAffected, freeBefore)
+	assert.Equal(t, pagesBefore-result.RowsAffected, int(db.saver.TotalPages()))
+	assert.Zero(t, db.factory.ForTable(mainTableColumns).GetHeader(ctx).FreePageCount)
+	assert.Equal(t, keepRows, countRowsInDB(t, db, tableName))
+
+	// Nothing is left to reclaim on a freshly compacted file.
+	reclaimed, err := db.Vacuum(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, reclaimed)
+}
@@ -0,0 +1,217 @@
+package minisql
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// ValueCodec encodes and decodes the non-NULL values of a custom column kind
+// registered with RegisterValueCodec. Values arrive as the engine holds SQL
+// values: bool, int64, float64 or string. Unmarshal must return one of those
+// types.
+type ValueCodec interface {
+	// Size returns the number of bytes Append adds for value. It is called
+	// first and must not panic on a value of the wrong type; Append reports
+	// the error.
+	Size(value any) uint64
+	// Append appends the encoding of value to dst and returns the extended
+	// slice.
+	Append(dst []byte, value any) ([]byte, error)
+	// Unmarshal decodes a value from buf, which holds exactly the bytes
+	// added by Append.
+	Unmarshal(buf []byte) (any, error)
+}
+
+var (
+	// ErrBuiltinColumnKind is returned when registering a codec under the name
+	// of a built-in column type.
+	ErrBuiltinColumnKind = errors.New("cannot register a value codec for a built-in column type")
+	// ErrValueCodecExists is returned when a codec is already registered under
+	// the type name.
+	ErrValueCodecExists = errors.New("value codec already registered for column type")
+)
+
+// maxColumnKinds bounds the ColumnKind values, built-in and custom.
+const maxColumnKinds = 256
+
+// customColumnKind is a column type registered with RegisterValueCodec.
+type customColumnKind struct {
+	name  string
+	codec ValueCodec
+}
+
+// customColumnKindTable maps a ColumnKind above Vector to its registration.
+type customColumnKindTable [maxColumnKinds]customColumnKind
+
+// customColumnKinds is the registry of custom column kinds. It is copied on
+// write so the lookup on the row (de)serialisation path is a lock-free array
+// access.
+var (
+	customColumnKinds   atomic.Pointer[customColumnKindTable]
+	customColumnKindsMu sync.Mutex
+)
+
+func init() {
+	customColumnKinds.Store(new(customColumnKindTable))
+}
+
+// RegisterValueCodec registers codec as the on-disk encoding of a custom
+// column type called name and returns the ColumnKind assigned to it. Columns
+// are declared with the name in CREATE TABLE and ALTER TABLE ADD COLUMN, so
+// codecs must be registered before a database using them is opened. Custom
+// values are stored behind a 4-byte length prefix so cells stay
+// self-describing. They can be stored and read back but cannot be indexed or
+// compared.
+func RegisterValueCodec(name string, codec ValueCodec) (ColumnKind, error) {
+	if !isValidFunctionName(name) {
+		return 0, fmt.Errorf("invalid column type name %q", name)
+	}
+	if codec == nil {
+		return 0, fmt.Errorf("column type %s: codec must not be nil", name)
+	}
+	name = strings.ToLower(name)
+	for kind := Boolean; kind <= Vector; kind++ {
+		if kind.String() == name {
+			return 0, fmt.Errorf("%w: %s", ErrBuiltinColumnKind, name)
+		}
+	}
+
+	customColumnKindsMu.Lock()
+	defer customColumnKindsMu.Unlock()
+	kinds := *customColumnKinds.Load()
+	free := ColumnKind(0)
+	for kind := Vector + 1; kind < maxColumnKinds; kind++ {
+		switch {
+		case kinds[kind].name == name:
+			return 0, fmt.Errorf("%w: %s", ErrValueCodecExists, name)
+		case kinds[kind].codec == nil && free == 0:
+			free = kind
+		}
+	}
+	if free == 0 {
+		return 0, fmt.Errorf("column type %s: at most %d custom column types can be registered", name, maxColumnKinds-1-int(Vector))
+	}
+	kinds[free] = customColumnKind{name: name, codec: codec}
+	customColumnKinds.Store(&kinds)
+	return free, nil
+}
+
+// unregisterValueCodec removes a custom column kind.
+func unregisterValueCodec(kind ColumnKind) {
+	customColumnKindsMu.Lock()
+	defer customColumnKindsMu.Unlock()
+	kinds := *customColumnKinds.Load()
+	kinds[kind] = customColumnKind{}
+	customColumnKinds.Store(&kinds)
+}
+
+// CustomColumnKind returns the kind registered under the column type name.
+func CustomColumnKind(name string) (ColumnKind, bool) {
+	name = strings.ToLower(name)
+	kinds := customColumnKinds.Load()
+	for kind := Vector + 1; kind < maxColumnKinds; kind++ {
+		if kinds[kind].codec != nil && kinds[kind].name == name {
+			return kind, true
+		}
+	}
+	return 0, false
+}
+
+// customValueCodec returns the codec of a custom kind, or false if kind is
+// built in or not registered.
+func customValueCodec(kind ColumnKind) (ValueCodec, bool) {
+	if kind <= Vector || kind >= maxColumnKinds {
+		return nil, false
+	}
+	codec := customColumnKinds.Load()[kind].codec
+	return codec, codec != nil
+}
+
+// customColumnKindName returns the type name of a custom kind.
+func customColumnKindName(kind ColumnKind) (string, bool) {
+	if kind <= Vector || kind >= maxColumnKinds {
+		return "", false
+	}
+	custom := customColumnKinds.Load()[kind]
+	return custom.name, custom.codec != nil
+}
+
+// isCustomColumnKind reports whether kind is a registered custom column kind.
+func isCustomColumnKind(kind ColumnKind) bool {
+	_, ok := customValueCodec(kind)
+	return ok
+}
+
+// customCodecValue converts an engine value to the Go type documented on
+// ValueCodec.
+func customCodecValue(value any) any {
+	if tp, ok := value.(TextPointer); ok {
+		return tp.String()
+	}
+	return value
+}
+
+// customValueSize returns the number of bytes a custom value occupies in the
+// packed row: the 4-byte length prefix and the encoding.
+func customValueSize(codec ValueCodec, value any) uint64 {
+	return varcharLengthPrefixSize + codec.Size(customCodecValue(value))
+}
+
+// marshalCustomValue writes the length-prefixed encoding of value to buf at
+// offset and returns the number of bytes written. buf must have room for
+// customValueSize bytes.
+func marshalCustomValue(codec ValueCodec, col Column, buf []byte, value any, offset uint64) (uint64, error) {
+	value = customCodecValue(value)
+	size := codec.Size(value)
+	start := offset + varcharLengthPrefixSize
+	// Appending to an empty slice of buf encodes in place.
+	data, err := codec.Append(buf[start:start:start+size], value)
+	if err != nil {
+		return 0, fmt.Errorf("column %s: %w", col.Name, err)
+	}
+	if uint64(len(data)) != size {
+		return 0, fmt.Errorf("column %s: codec appended %d bytes, Size reported %d", col.Name, len(data), size)
+	}
+	copy(buf[start:], data)
+	marshalUint32(buf, uint32(size), offset)
+	return varcharLengthPrefixSize + size, nil
+}
+
+// unmarshalCustomValue decodes the length-prefixed custom value at offset in
+// buf and returns it with the number of bytes it occupies.
+func unmarshalCustomValue(codec ValueCodec, buf []byte, offset uint64) (any, uint64, error) {
+	size, err := encodedValueSize(TypeCodeCustom, buf, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	if offset+size > uint64(len(buf)) {
+		return nil, 0, fmt.Errorf("value data truncated (need %d bytes at offset %d, have %d)", size, offset, len(buf))
+	}
+	value, err := codec.Unmarshal(buf[offset+varcharLengthPrefixSize : offset+size])
+	if err != nil {
+		return nil, 0, err
+	}
+	if s, ok := value.(string); ok {
+		return NewTextPointer([]byte(s)), size, nil
+	}
+	return value, size, nil
+}
+
+// encodedValueSize returns the number of bytes the non-NULL value tagged with
+// tc occupies at offset in buf.
+func encodedValueSize(tc TypeCode, buf []byte, offset uint64) (uint64, error) {
+	if size := typeCodeFixedSize(tc); size >= 0 {
+		return uint64(size), nil
+	}
+	if offset+varcharLengthPrefixSize > uint64(len(buf)) {
+		return 0, fmt.Errorf("length prefix at offset %d exceeds encoded row length %d", offset, len(buf))
+	}
+	length := unmarshalUint32(buf, offset)
+	if tc == TypeCodeCustom {
+		return varcharLengthPrefixSize + uint64(length), nil
+	}
+	return TextPointer{Length: length}.Size(), nil
+}
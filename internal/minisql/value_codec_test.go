@@ -0,0 +1,171 @@
+package minisql
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// varintCodec stores int64 values as zig-zag varints, so small values take
+// fewer bytes than the 8 used by INT8.
+type varintCodec struct{}
+
+func (varintCodec) Size(value any) uint64 {
+	n, _ := value.(int64) // Append reports other types
+	var buf [binary.MaxVarintLen64]byte
+	return uint64(binary.PutVarint(buf[:], n))
+}
+
+func (varintCodec) Append(dst []byte, value any) ([]byte, error) {
+	n, ok := value.(int64)
+	if !ok {
+		return nil, fmt.Errorf("could not cast %T to int64", value)
+	}
+	return binary.AppendVarint(dst, n), nil
+}
+
+func (varintCodec) Unmarshal(buf []byte) (any, error) {
+	n, size := binary.Varint(buf)
+	if size != len(buf) {
+		return nil, errors.New("invalid varint")
+	}
+	return n, nil
+}
+
+// reverseCodec stores strings reversed.
+type reverseCodec struct{}
+
+func (reverseCodec) Size(value any) uint64 {
+	s, _ := value.(string)
+	return uint64(len(s))
+}
+
+func (reverseCodec) Append(dst []byte, value any) ([]byte, error) {
+	s := value.(string)
+	for i := len(s) - 1; i >= 0; i-- {
+		dst = append(dst, s[i])
+	}
+	return dst, nil
+}
+
+func (c reverseCodec) Unmarshal(buf []byte) (any, error) {
+	out, _ := c.Append(nil, string(buf))
+	return string(out), nil
+}
+
+func TestRegisterValueCodec(t *testing.T) {
+	// Not parallel: registering a codec is global state.
+
+	varintKind, err := RegisterValueCodec("varint", varintCodec{})
+	require.NoError(t, err)
+	defer unregisterValueCodec(varintKind)
+	reverseKind, err := RegisterValueCodec("Reversed", reverseCodec{})
+	require.NoError(t, err)
+	defer unregisterValueCodec(reverseKind)
+
+	t.Run("names", func(t *testing.T) {
+		assert.Greater(t, varintKind, Vector)
+		assert.Equal(t, "varint", varintKind.String())
+		assert.Equal(t, "reversed", reverseKind.String())
+		kind, ok := CustomColumnKind("REVERSED")
+		assert.True(t, ok)
+		assert.Equal(t, reverseKind, kind)
+		_, ok = CustomColumnKind("int8")
+		assert.False(t, ok)
+	})
+
+	t.Run("built-in, duplicate and invalid names are rejected", func(t *testing.T) {
+		_, err := RegisterValueCodec("INT8", varintCodec{})
+		assert.True(t, errors.Is(err, ErrBuiltinColumnKind))
+		_, err = RegisterValueCodec("VarInt", varintCodec{})
+		assert.True(t, errors.Is(err, ErrValueCodecExists))
+		_, err = RegisterValueCodec("var int", varintCodec{})
+		assert.Error(t, err)
+		_, err = RegisterValueCodec("nothing", nil)
+		assert.Error(t, err)
+	})
+
+	columns := []Column{
+		{Kind: Int8, Size: 8, Name: "id"},
+		{Kind: varintKind, Name: "delta"},
+		{Kind: Varchar, Size: 20, Name: "name"},
+		{Kind: varintKind, Name: "other", Nullable: true},
+		{Kind: reverseKind, Name: "code", Nullable: true},
+	}
+	rows := []Row{
+		NewRowWithValues(columns, []OptionalValue{
+			{Value: int64(1), Valid: true},
+			{Value: int64(3), Valid: true},
+			{Value: NewTextPointer([]byte("small")), Valid: true},
+			{Value: int64(-2), Valid: true},
+			{Value: NewTextPointer([]byte("abc")), Valid: true},
+		}),
+		NewRowWithValues(columns, []OptionalValue{
+			{Value: int64(2), Valid: true},
+			{Value: int64(1 << 40), Valid: true},
+			{Value: NewTextPointer([]byte("large")), Valid: true},
+			{},
+			{},
+		}),
+	}
+
+	t.Run("row round trip", func(t *testing.T) {
+		for _, row := range rows {
+			data, err := row.Marshal()
+			require.NoError(t, err)
+			require.Len(t, data, int(row.Size()))
+
+			actual, err := UnmarshalRow(data, columns, row.Key, row.NullBitmask())
+			require.NoError(t, err)
+			assert.Equal(t, row.Values, actual.Values)
+		}
+
+		// A small value takes a 4-byte length prefix plus a single byte.
+		assert.Equal(t, uint64(8+5+(4+5)+5+(4+3)), rows[0].Size())
+		data, err := rows[0].Marshal()
+		require.NoError(t, err)
+		assert.Equal(t, "cba", string(data[len(data)-3:]))
+	})
+
+	t.Run("cells stay self-describing", func(t *testing.T) {
+		for _, row := range rows {
+			data, err := row.Marshal()
+			require.NoError(t, err)
+			cell := Cell{
+				Value:       data,
+				TypeCodes:   TypeCodesFromColumns(columns),
+				NullBitmask: row.NullBitmask(),
+				ColumnCount: uint8(len(columns)),
+			}
+			assert.Equal(t, byte(TypeCodeCustom), cell.TypeCodes[1])
+
+			buf := make([]byte, cell.Size())
+			cell.Marshal(buf)
+			var actual Cell
+			_, err = actual.Unmarshal(buf)
+			require.NoError(t, err)
+			require.Equal(t, data, actual.Value)
+
+			view := NewRowView(columns, actual)
+			for i, expected := range row.Values {
+				value, err := view.ValueAt(i)
+				require.NoError(t, err)
+				assert.Equal(t, expected, value, "column %s", columns[i].Name)
+			}
+		}
+	})
+
+	t.Run("codec errors are reported", func(t *testing.T) {
+		row := NewRowWithValues(columns[:2], []OptionalValue{
+			{Value: int64(1), Valid: true},
+			{Value: true, Valid: true},
+		})
+		_, err := row.Marshal()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "column delta")
+	})
+}
@@ -211,13 +211,10 @@ func sortPartition(indices []int, rows []Row, orderBy []OrderBy) {
 		for _, ob := range orderBy {
 			va, _ := ra.getValueQualified(ob.Field.AliasPrefix, ob.Field.Name)
 			vb, _ := rb.getValueQualified(ob.Field.AliasPrefix, ob.Field.Name)
-			cmp := compareValues(va, vb)
+			cmp := ob.compare(va, vb)
 			if cmp == 0 {
 				continue
 			}
-			if ob.Direction == Desc {
-				return cmp > 0
-			}
 			return cmp < 0
 		}
 		return false
@@ -129,6 +129,11 @@ func (p *parserItem) doParseAlterTable() error {
 					Valid: true,
 				}
 			}
+		case "ON UPDATE":
+			p.pop()
+			if err := p.parseOnUpdateNow("ALTER TABLE ADD COLUMN"); err != nil {
+				return err
+			}
 		default:
 			// No more column constraints.
 			p.step = stepStatementEnd
@@ -0,0 +1,61 @@
+package parser
+
+import (
+	"errors"
+	"strings"
+)
+
+var errUnterminatedBlockComment = errors.New("unterminated block comment")
+
+// stripComments replaces `-- line comments` and `/* block comments */` with a
+// single space so they separate tokens the same way whitespace does. Comment
+// markers inside quoted string literals and double-quoted identifiers are left
+// untouched. Block comments do not nest.
+func stripComments(sql string) (string, error) {
+	if !strings.Contains(sql, "--") && !strings.Contains(sql, "/*") {
+		return sql, nil
+	}
+
+	var (
+		out      strings.Builder
+		inSingle bool
+		inDouble bool
+	)
+	out.Grow(len(sql))
+	for i := 0; i < len(sql); i++ {
+		c := sql[i]
+		switch {
+		case inSingle:
+			if c == '\'' && sql[i-1] != '\\' {
+				inSingle = false
+			}
+		case inDouble:
+			if c == '"' {
+				inDouble = false
+			}
+		case c == '\'':
+			inSingle = true
+		case c == '"':
+			inDouble = true
+		case c == '-' && i+1 < len(sql) && sql[i+1] == '-':
+			end := strings.IndexByte(sql[i:], '\n')
+			if end < 0 {
+				i = len(sql)
+			} else {
+				i += end
+			}
+			out.WriteByte(' ')
+			continue
+		case c == '/' && i+1 < len(sql) && sql[i+1] == '*':
+			end := strings.Index(sql[i+2:], "*/")
+			if end < 0 {
+				return "", errUnterminatedBlockComment
+			}
+			i += end + 3
+			out.WriteByte(' ')
+			continue
+		}
+		out.WriteByte(c)
+	}
+	return out.String(), nil
+}
@@ -0,0 +1,95 @@
+package parser
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/RichardKnop/minisql/internal/minisql"
+)
+
+func TestParse_Comments(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		Name  string
+		SQL   string
+		Clean string
+	}{
+		{
+			"line comments",
+			"-- recompute statistics\nANALYZE users; -- only users\nANALYZE;",
+			"ANALYZE users; ANALYZE;",
+		},
+		{
+			"line comment at end of input",
+			"DROP TABLE foo; -- done",
+			"DROP TABLE foo;",
+		},
+		{
+			"block comment spanning lines",
+			"/* cleanup\n * script\n */\nDROP TABLE /* old */ foo;",
+			"DROP TABLE foo;",
+		},
+		{
+			"block comment separates tokens",
+			"DROP/**/TABLE foo;",
+			"DROP TABLE foo;",
+		},
+		{
+			"comment markers inside string literals are kept",
+			"SELECT * FROM foo WHERE a = '-- not a comment' AND b = '/* nor this */'; -- but this is",
+			"SELECT * FROM foo WHERE a = '-- not a comment' AND b = '/* nor this */';",
+		},
+		{
+			"empty and trailing statements",
+			";; ANALYZE users;;\n; ANALYZE; ;",
+			"ANALYZE users; ANALYZE;",
+		},
+		{
+			"statement without semicolon after empty statement",
+			"; VACUUM",
+			"VACUUM;",
+		},
+	}
+
+	for _, aTestCase := range testCases {
+		t.Run(aTestCase.Name, func(t *testing.T) {
+			expected, err := New().Parse(context.Background(), aTestCase.Clean)
+			require.NoError(t, err)
+
+			statements, err := New().Parse(context.Background(), aTestCase.SQL)
+			require.NoError(t, err)
+			assert.Equal(t, expected, statements)
+		})
+	}
+
+	t.Run("only comments and empty statements", func(t *testing.T) {
+		for _, sql := range []string{
+			"-- nothing here\n; /* at all */ ;",
+			"-- trailing comment",
+			"/* block\ncomment */",
+			";;",
+		} {
+			statements, err := New().Parse(context.Background(), sql)
+			require.NoError(t, err, sql)
+			assert.Empty(t, statements, sql)
+		}
+	})
+
+	t.Run("unterminated block comment", func(t *testing.T) {
+		_, err := New().Parse(context.Background(), "ANALYZE; /* oops")
+		require.ErrorIs(t, err, errUnterminatedBlockComment)
+	})
+
+	t.Run("statement count", func(t *testing.T) {
+		statements, err := New().Parse(context.Background(), "ANALYZE a; -- first\n;\n/* second */ ANALYZE b;")
+		require.NoError(t, err)
+		assert.Equal(t, []minisql.Statement{
+			{Kind: minisql.Analyze, Target: "a"},
+			{Kind: minisql.Analyze, Target: "b"},
+		}, statements)
+	})
+}
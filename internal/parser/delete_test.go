@@ -41,6 +41,17 @@ func TestParse_Delete(t *testing.T) {
 			},
 			nil,
 		},
+		{
+			"TRUNCATE TABLE works",
+			"TRUNCATE TABLE 'a';",
+			[]minisql.Statement{
+				{
+					Kind:      minisql.TruncateTable,
+					TableName: "a",
+				},
+			},
+			nil,
+		},
 		{
 			"DELETE with empty WHERE fails",
 			"DELETE FROM 'a' WHERE",
@@ -15,6 +15,7 @@ func (p *parserItem) parseExplain(analyze bool) error {
 		sql:      p.sql[p.i:],
 		upperSQL: p.upperSQL[p.i:],
 		step:     stepBeginning,
+		limits:   p.limits,
 	}
 	statements, err := rest.doParse()
 	if err != nil {
@@ -9,11 +9,30 @@ import (
 
 // parseExpr parses an arithmetic expression with correct operator precedence:
 //
-//	expr    := term    (('+' | '-') term)*
+//	expr    := sum     ('||' sum)*
+//	sum     := term    (('+' | '-') term)*
 //	term    := jsonExpr (('*' | '/') jsonExpr)*
 //	jsonExpr := factor (('->' | '->>') factor)*
 //	factor  := '-' factor | '(' expr ')' | column_ref | numeric_literal
+//
+// As in PostgreSQL, || binds looser than arithmetic: 'n' || 1 + 2 is 'n3'.
 func (p *parserItem) parseExpr() (*minisql.Expr, error) {
+	left, err := p.parseSum()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek() == "||" {
+		p.pop()
+		right, err := p.parseSum()
+		if err != nil {
+			return nil, err
+		}
+		left = &minisql.Expr{Left: left, Right: right, Op: minisql.ArithConcat}
+	}
+	return left, nil
+}
+
+func (p *parserItem) parseSum() (*minisql.Expr, error) {
 	left, err := p.parseTerm()
 	if err != nil {
 		return nil, err
@@ -90,6 +109,17 @@ func (p *parserItem) parseJSONExpr() (*minisql.Expr, error) {
 }
 
 func (p *parserItem) parseFactor() (*minisql.Expr, error) {
+	// peek strips the quotes of a string literal, so check for one first:
+	// '-' or '(' must not be mistaken for an operator.
+	if p.i < len(p.sql) && p.sql[p.i] == '\'' {
+		value, ln := p.peekQuotedStringWithLength()
+		if ln == 0 {
+			return nil, fmt.Errorf("unterminated string literal in arithmetic expression")
+		}
+		p.pop()
+		return &minisql.Expr{Literal: minisql.NewTextPointer([]byte(value))}, nil
+	}
+
 	token := p.peek()
 
 	// Unary minus: wrap as (0 - inner)
@@ -127,9 +157,9 @@ func (p *parserItem) parseFactor() (*minisql.Expr, error) {
 	}
 
 	// Bind-parameter placeholder
-	if token == "?" {
+	if p.isPlaceholder(token) {
 		p.pop()
-		return &minisql.Expr{Literal: minisql.Placeholder{}}, nil
+		return &minisql.Expr{Literal: placeholder(token)}, nil
 	}
 
 	// CASE expression
@@ -185,6 +215,11 @@ func (p *parserItem) parseFactor() (*minisql.Expr, error) {
 		}
 	}
 
+	// Call to a function registered from Go, e.g. double(x).
+	if name, ok := p.peekFuncCall(); ok {
+		return p.parseFuncCall(name)
+	}
+
 	// Function call or column reference
 	if isIdentifier(token) {
 		upperToken := strings.ToUpper(token)
@@ -199,7 +234,7 @@ func (p *parserItem) parseFactor() (*minisql.Expr, error) {
 }
 
 // parseFuncCall parses FUNCNAME(arg, arg, ...) after the caller has confirmed
-// the token is a known built-in function name (already upper-cased).
+// the token is a function name (already upper-cased).
 func (p *parserItem) parseFuncCall(funcName string) (*minisql.Expr, error) {
 	p.pop() // consume function name
 	if p.peek() != "(" {
@@ -467,6 +502,36 @@ func (p *parserItem) parseIntervalLiteral() (*minisql.Expr, error) {
 	return &minisql.Expr{Literal: iv}, nil
 }
 
+// peekFuncCall reports whether the next token calls a function that is not
+// built in, returning its upper-cased name. Such functions are registered from
+// Go, so the parser cannot know their names: any unquoted name directly
+// followed by "(" qualifies, even one that is otherwise a keyword such as
+// DOUBLE. The engine rejects unknown names when it prepares the statement.
+func (p *parserItem) peekFuncCall() (string, bool) {
+	if p.i >= len(p.sql) {
+		return "", false
+	}
+	name := identifierRegexp.FindString(p.sql[p.i:])
+	if name == "" || strings.ContainsAny(name, `."`) {
+		return "", false
+	}
+	if end := p.i + len(name); end >= len(p.sql) || p.sql[end] != '(' {
+		return "", false
+	}
+	name = strings.ToUpper(name)
+	if isBuiltinFunction(name) || name == "CAST" {
+		return "", false
+	}
+	// Aggregates, NOW(), VARCHAR(n) and the like are tokenised together
+	// with their opening parenthesis.
+	for _, rWord := range reservedWords {
+		if strings.HasPrefix(rWord, name+"(") {
+			return "", false
+		}
+	}
+	return name, true
+}
+
 // isBuiltinFunction reports whether name (upper-cased) is a recognised scalar
 // function that can appear inside an arithmetic expression.
 func isBuiltinFunction(name string) bool {
@@ -593,17 +658,11 @@ func (p *parserItem) parseWindowSpec() (minisql.WindowSpec, error) {
 				name = col[dot+1:]
 			}
 			p.pop()
-			dir := minisql.Asc
-			switch strings.ToUpper(p.peek()) {
-			case "ASC":
-				p.pop()
-			case "DESC":
-				dir = minisql.Desc
-				p.pop()
-			}
+			dir, nulls := p.parseOrderDirection()
 			spec.OrderBy = append(spec.OrderBy, minisql.OrderBy{
 				Field:     minisql.Field{Name: name, AliasPrefix: aliasPrefix},
 				Direction: dir,
+				Nulls:     nulls,
 			})
 			if p.peek() != "," {
 				break
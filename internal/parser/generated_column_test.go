@@ -0,0 +1,73 @@
+package parser
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/RichardKnop/minisql/internal/minisql"
+)
+
+func TestParse_GeneratedColumn(t *testing.T) {
+	t.Parallel()
+
+	t.Run("GENERATED ALWAYS AS sets Generated and GeneratedExpr on column", func(t *testing.T) {
+		t.Parallel()
+		got, err := New().Parse(context.Background(), "CREATE TABLE lines (price double, qty int8, total double not null generated always as (price * qty) stored check (total >= 0));")
+		require.NoError(t, err)
+		require.Len(t, got, 1)
+		require.Len(t, got[0].Columns, 3)
+
+		total := got[0].Columns[2]
+		assert.Equal(t, "total", total.Name)
+		assert.False(t, total.Nullable)
+		assert.Equal(t, "price * qty", total.Generated)
+		assert.Equal(t, &minisql.Expr{
+			Left:  &minisql.Expr{Column: "price"},
+			Op:    minisql.ArithMul,
+			Right: &minisql.Expr{Column: "qty"},
+		}, total.GeneratedExpr)
+		assert.Equal(t, "total >= 0", total.Check)
+		assert.True(t, total.IsGenerated())
+		assert.False(t, got[0].Columns[0].IsGenerated())
+	})
+
+	t.Run("DDL round-trips the generation expression", func(t *testing.T) {
+		t.Parallel()
+		got, err := New().Parse(context.Background(), "CREATE TABLE lines (price double, qty int8, total double generated always as (price * qty) stored);")
+		require.NoError(t, err)
+		ddl := got[0].DDL()
+		assert.Contains(t, ddl, "total double generated always as (price * qty) stored")
+
+		again, err := New().Parse(context.Background(), ddl)
+		require.NoError(t, err)
+		assert.Equal(t, got[0].Columns, again[0].Columns)
+	})
+
+	testCases := []struct {
+		Name string
+		SQL  string
+		Err  string
+	}{
+		{
+			"missing opening parens",
+			"CREATE TABLE t (a int8, b int8 generated always as a stored);",
+			"expected '(' after GENERATED ALWAYS AS",
+		},
+		{
+			"missing STORED",
+			"CREATE TABLE t (a int8, b int8 generated always as (a + 1));",
+			"expected STORED after generation expression",
+		},
+	}
+	for _, aTestCase := range testCases {
+		t.Run(aTestCase.Name, func(t *testing.T) {
+			t.Parallel()
+			_, err := New().Parse(context.Background(), aTestCase.SQL)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), aTestCase.Err)
+		})
+	}
+}
@@ -12,6 +12,7 @@ import (
 var (
 	errCreateIndexExpectedOpeningParens = errors.New("at CREATE INDEX: expected opening parens")
 	errCreateIndexNoColumns             = errors.New("at CREATE INDEX: no columns specified")
+	errAlterIndexExpectedRenameTo       = errors.New("at ALTER INDEX: expected RENAME TO")
 )
 
 func (p *parserItem) doParseCreateIndex() error {
@@ -184,3 +185,29 @@ func (p *parserItem) doParseDropIndex() error {
 	}
 	return nil
 }
+
+func (p *parserItem) doParseAlterIndex() error {
+	switch p.step {
+	case stepAlterIndexName:
+		indexName := p.peek()
+		if !isIdentifier(indexName) {
+			return p.errorf("at ALTER INDEX: expected index name, got %q", indexName)
+		}
+		p.IndexName = indexName
+		p.pop()
+		p.step = stepAlterIndexRenameTo
+	case stepAlterIndexRenameTo:
+		if strings.ToUpper(p.peek()) != "RENAME TO" {
+			return p.wrapErr(errAlterIndexExpectedRenameTo)
+		}
+		p.pop()
+		newName := p.peek()
+		if !isIdentifier(newName) {
+			return p.errorf("at ALTER INDEX RENAME TO: expected new index name, got %q", newName)
+		}
+		p.NewIndexName = newName
+		p.pop()
+		p.step = stepStatementEnd
+	}
+	return nil
+}
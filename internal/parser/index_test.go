@@ -339,3 +339,40 @@ func TestParse_DropIndex(t *testing.T) {
 		})
 	}
 }
+
+func TestParse_AlterIndex(t *testing.T) {
+	t.Parallel()
+
+	testCases := []testCase{
+		{
+			Name:     "ALTER INDEX without RENAME TO fails",
+			SQL:      "ALTER INDEX foo;",
+			Expected: nil,
+			Err:      errAlterIndexExpectedRenameTo,
+		},
+		{
+			Name: "ALTER INDEX RENAME TO works",
+			SQL:  `ALTER INDEX foo RENAME TO "bar";`,
+			Expected: []minisql.Statement{
+				{
+					Kind:         minisql.AlterIndex,
+					IndexName:    "foo",
+					NewIndexName: "bar",
+				},
+			},
+		},
+	}
+
+	for _, aTestCase := range testCases {
+		t.Run(aTestCase.Name, func(t *testing.T) {
+			aStatement, err := New().Parse(context.Background(), aTestCase.SQL)
+			if aTestCase.Err != nil {
+				require.Error(t, err)
+				require.ErrorIs(t, err, aTestCase.Err)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, aTestCase.Expected, aStatement)
+		})
+	}
+}
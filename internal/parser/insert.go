@@ -110,6 +110,7 @@ func (p *parserItem) doParseInsert() error {
 				sql:      p.sql[p.i : p.i+boundary],
 				upperSQL: p.upperSQL[p.i : p.i+boundary],
 				step:     stepBeginning,
+				limits:   p.limits,
 			}
 			selectStmts, err := rest.doParse()
 			if err != nil {
@@ -143,13 +144,13 @@ func (p *parserItem) doParseInsert() error {
 		p.pop()
 		p.step = stepInsertValues
 	case stepInsertValues:
-		specialValue := strings.ToUpper(p.peek())
-		if specialValue == "?" {
-			p.Inserts[len(p.Inserts)-1] = append(p.Inserts[len(p.Inserts)-1], minisql.OptionalValue{Value: minisql.Placeholder{}, Valid: true})
+		if token := p.peek(); p.isPlaceholder(token) {
+			p.Inserts[len(p.Inserts)-1] = append(p.Inserts[len(p.Inserts)-1], minisql.OptionalValue{Value: placeholder(token), Valid: true})
 			p.pop()
 			p.step = stepInsertValuesCommaOrClosingParens
 			return nil
 		}
+		specialValue := strings.ToUpper(p.peek())
 		if specialValue == "NULL" {
 			p.Inserts[len(p.Inserts)-1] = append(p.Inserts[len(p.Inserts)-1], minisql.OptionalValue{Valid: false})
 			p.pop()
@@ -277,9 +278,12 @@ func (p *parserItem) doParseInsert() error {
 			return nil
 		}
 		specialValue := strings.ToUpper(token)
+		if p.isPlaceholder(token) {
+			specialValue = "?"
+		}
 		switch specialValue {
 		case "?":
-			p.setUpdate(p.nextUpdateField, minisql.OptionalValue{Value: minisql.Placeholder{}, Valid: true})
+			p.setUpdate(p.nextUpdateField, minisql.OptionalValue{Value: placeholder(token), Valid: true})
 			p.nextUpdateField = ""
 			p.pop()
 		case "NULL":
@@ -69,6 +69,22 @@ func TestParse_Insert(t *testing.T) {
 			},
 			nil,
 		},
+		{
+			"INSERT with doubled quotes in a string works",
+			"INSERT INTO 'a' (b, c) VALUES ('it''s', '''');",
+			[]minisql.Statement{
+				{
+					Kind:      minisql.Insert,
+					TableName: "a",
+					Fields:    []minisql.Field{{Name: "b"}, {Name: "c"}},
+					Inserts: [][]minisql.OptionalValue{{
+						{Value: minisql.NewTextPointer([]byte("it's")), Valid: true},
+						{Value: minisql.NewTextPointer([]byte("'")), Valid: true},
+					}},
+				},
+			},
+			nil,
+		},
 		{
 			"INSERT * fails",
 			"INSERT INTO 'a' (*) VALUES ('1')",
@@ -177,6 +193,47 @@ func TestParse_Insert(t *testing.T) {
 			},
 			nil,
 		},
+		{
+			"INSERT with named placeholders works",
+			"INSERT INTO 'a' (b, c, d) VALUES (:b, :c_1, NOW());",
+			[]minisql.Statement{
+				{
+					Kind:      minisql.Insert,
+					TableName: "a",
+					Fields:    []minisql.Field{{Name: "b"}, {Name: "c"}, {Name: "d"}},
+					Inserts: [][]minisql.OptionalValue{
+						{
+							{Value: minisql.Placeholder{Name: "b"}, Valid: true},
+							{Value: minisql.Placeholder{Name: "c_1"}, Valid: true},
+							{Value: minisql.FunctionNow, Valid: true},
+						},
+					},
+				},
+			},
+			nil,
+		},
+		{
+			"INSERT with quoted strings that look like placeholders works",
+			"INSERT INTO 'a' (b, c) VALUES ('12:30', ':x'), (':30', '?');",
+			[]minisql.Statement{
+				{
+					Kind:      minisql.Insert,
+					TableName: "a",
+					Fields:    []minisql.Field{{Name: "b"}, {Name: "c"}},
+					Inserts: [][]minisql.OptionalValue{
+						{
+							{Value: minisql.NewTextPointer([]byte("12:30")), Valid: true},
+							{Value: minisql.NewTextPointer([]byte(":x")), Valid: true},
+						},
+						{
+							{Value: minisql.NewTextPointer([]byte(":30")), Valid: true},
+							{Value: minisql.NewTextPointer([]byte("?")), Valid: true},
+						},
+					},
+				},
+			},
+			nil,
+		},
 		{
 			"INSERT ON CONFLICT DO NOTHING with semicolon works",
 			"INSERT INTO 'a' (b, c) VALUES (1, 'foo') ON CONFLICT DO NOTHING;",
@@ -420,6 +477,19 @@ func TestParse_Insert(t *testing.T) {
 			},
 			nil,
 		},
+		{
+			"INSERT with whole-number float literals keeps them float",
+			"INSERT INTO 'a' (b, c) VALUES (2.0, -3.0);",
+			[]minisql.Statement{
+				{
+					Kind:      minisql.Insert,
+					TableName: "a",
+					Fields:    []minisql.Field{{Name: "b"}, {Name: "c"}},
+					Inserts:   [][]minisql.OptionalValue{{{Value: float64(2), Valid: true}, {Value: float64(-3), Valid: true}}},
+				},
+			},
+			nil,
+		},
 		{
 			"INSERT with mixed positive and negative values works",
 			"INSERT INTO 'a' (b, c) VALUES (-1, 2);",
@@ -0,0 +1,71 @@
+package parser
+
+import (
+	"errors"
+	"fmt"
+)
+
+var errParseLimitExceeded = errors.New("parse limit exceeded")
+
+// parseLimits bounds the size of the statements the parser accepts, so that
+// a hostile query cannot exhaust memory or stack while being parsed. A zero
+// limit means unlimited.
+type parseLimits struct {
+	maxStatementLength int
+	maxINListSize      int
+	maxConditionDepth  int
+	maxCreateColumns   int
+}
+
+// MaxStatementLength rejects SQL text longer than n bytes before it is parsed.
+func MaxStatementLength(n int) Option {
+	return func(p *parser) {
+		p.limits.maxStatementLength = n
+	}
+}
+
+// MaxINListSize rejects IN (...) and NOT IN (...) lists with more than n values.
+func MaxINListSize(n int) Option {
+	return func(p *parser) {
+		p.limits.maxINListSize = n
+	}
+}
+
+// MaxConditionDepth rejects WHERE, HAVING and ON conditions nested more than
+// n levels deep, counting both parenthesised groups and subqueries.
+func MaxConditionDepth(n int) Option {
+	return func(p *parser) {
+		p.limits.maxConditionDepth = n
+	}
+}
+
+// MaxCreateColumns rejects CREATE TABLE statements defining more than n columns.
+func MaxCreateColumns(n int) Option {
+	return func(p *parser) {
+		p.limits.maxCreateColumns = n
+	}
+}
+
+// limitErr reports that a parse limit was exceeded at the current position.
+func (p *parserItem) limitErr(format string, args ...any) error {
+	return &ParseError{
+		Pos:  p.i,
+		Near: p.near(),
+		Msg:  fmt.Sprintf("%s: %s", errParseLimitExceeded, fmt.Sprintf(format, args...)),
+		err:  errParseLimitExceeded,
+	}
+}
+
+// enterCondition increments the condition nesting depth, failing once it
+// exceeds the configured maximum. Callers must call leaveCondition afterwards.
+func (p *parserItem) enterCondition() error {
+	p.condDepth += 1
+	if p.limits.maxConditionDepth > 0 && p.condDepth > p.limits.maxConditionDepth {
+		return p.limitErr("conditions are nested deeper than the maximum depth of %d", p.limits.maxConditionDepth)
+	}
+	return nil
+}
+
+func (p *parserItem) leaveCondition() {
+	p.condDepth -= 1
+}
@@ -0,0 +1,78 @@
+package parser
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse_Limits(t *testing.T) {
+	t.Parallel()
+
+	t.Run("statement length", func(t *testing.T) {
+		sql := "SELECT * FROM users WHERE id = 1;"
+
+		_, err := New(MaxStatementLength(len(sql))).Parse(context.Background(), sql)
+		require.NoError(t, err)
+
+		_, err = New(MaxStatementLength(len(sql)-1)).Parse(context.Background(), sql)
+		require.ErrorIs(t, err, errParseLimitExceeded)
+		assert.Contains(t, err.Error(), "parse limit exceeded: statement is 33 bytes long, the maximum is 32")
+	})
+
+	t.Run("IN list size", func(t *testing.T) {
+		_, err := New(MaxINListSize(3)).Parse(context.Background(), "SELECT * FROM users WHERE id IN (1, 2, 3);")
+		require.NoError(t, err)
+
+		_, err = New(MaxINListSize(3)).Parse(context.Background(), "SELECT * FROM users WHERE id NOT IN (1, 2, 3, 4);")
+		require.ErrorIs(t, err, errParseLimitExceeded)
+		assert.Contains(t, err.Error(), "parse limit exceeded: IN list has more than 3 values")
+	})
+
+	t.Run("condition nesting depth", func(t *testing.T) {
+		nested := func(depth int) string {
+			return "SELECT * FROM users WHERE " + strings.Repeat("(", depth) + "id = 1" + strings.Repeat(")", depth) + ";"
+		}
+
+		_, err := New(MaxConditionDepth(3)).Parse(context.Background(), nested(3))
+		require.NoError(t, err)
+
+		_, err = New(MaxConditionDepth(3)).Parse(context.Background(), nested(4))
+		require.ErrorIs(t, err, errParseLimitExceeded)
+		assert.Contains(t, err.Error(), "parse limit exceeded: conditions are nested deeper than the maximum depth of 3")
+	})
+
+	t.Run("condition nesting depth counts subqueries", func(t *testing.T) {
+		sql := "SELECT * FROM users WHERE id IN (SELECT user_id FROM orders WHERE (total > 10));"
+
+		_, err := New(MaxConditionDepth(2)).Parse(context.Background(), sql)
+		require.NoError(t, err)
+
+		_, err = New(MaxConditionDepth(1)).Parse(context.Background(), sql)
+		require.ErrorIs(t, err, errParseLimitExceeded)
+		assert.Contains(t, err.Error(), "parse limit exceeded: conditions are nested deeper than the maximum depth of 1")
+	})
+
+	t.Run("CREATE TABLE columns", func(t *testing.T) {
+		sql := "CREATE TABLE users (id INT8 PRIMARY KEY, name VARCHAR(255), email VARCHAR(255));"
+
+		_, err := New(MaxCreateColumns(3)).Parse(context.Background(), sql)
+		require.NoError(t, err)
+
+		_, err = New(MaxCreateColumns(2)).Parse(context.Background(), sql)
+		require.ErrorIs(t, err, errParseLimitExceeded)
+		assert.Contains(t, err.Error(), "parse limit exceeded: CREATE TABLE defines more than 2 columns")
+	})
+
+	t.Run("no limits by default", func(t *testing.T) {
+		values := make([]string, 5000)
+		for i := range values {
+			values[i] = "1"
+		}
+		_, err := New().Parse(context.Background(), "SELECT * FROM users WHERE id IN ("+strings.Join(values, ", ")+");")
+		require.NoError(t, err)
+	})
+}
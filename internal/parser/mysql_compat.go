@@ -0,0 +1,41 @@
+package parser
+
+import (
+	"errors"
+	"strings"
+)
+
+var errUnterminatedBacktick = errors.New("unterminated backtick-quoted identifier")
+
+// backticksToDoubleQuotes rewrites MySQL-style `identifiers` as "identifiers"
+// so the rest of the lexer only has to know about one identifier delimiter.
+// Backticks inside quoted string literals are left untouched.
+func backticksToDoubleQuotes(sql string) (string, error) {
+	if !strings.Contains(sql, "`") {
+		return sql, nil
+	}
+
+	var (
+		out        = []byte(sql)
+		inSingle   bool
+		inBacktick bool
+	)
+	for i := 0; i < len(sql); i++ {
+		c := sql[i]
+		switch {
+		case inSingle:
+			if c == '\'' && sql[i-1] != '\\' {
+				inSingle = false
+			}
+		case c == '\'' && !inBacktick:
+			inSingle = true
+		case c == '`':
+			inBacktick = !inBacktick
+			out[i] = '"'
+		}
+	}
+	if inBacktick {
+		return "", errUnterminatedBacktick
+	}
+	return string(out), nil
+}
@@ -0,0 +1,50 @@
+package parser
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/RichardKnop/minisql/internal/minisql"
+)
+
+func TestParse_MySQLCompatBackticks(t *testing.T) {
+	t.Parallel()
+
+	t.Run("compat mode accepts backticks", func(t *testing.T) {
+		stmts, err := New(MySQLCompat()).Parse(context.Background(), "CREATE TABLE `order` (`id` INT8 PRIMARY KEY AUTOINCREMENT, `name` VARCHAR(255) NOT NULL);")
+		require.NoError(t, err)
+		require.Len(t, stmts, 1)
+		assert.Equal(t, "order", stmts[0].TableName)
+		require.Len(t, stmts[0].Columns, 2)
+		assert.Equal(t, "id", stmts[0].Columns[0].Name)
+		assert.Equal(t, "name", stmts[0].Columns[1].Name)
+
+		expected, err := New().Parse(context.Background(), `CREATE TABLE "order" ("id" INT8 PRIMARY KEY AUTOINCREMENT, "name" VARCHAR(255) NOT NULL);`)
+		require.NoError(t, err)
+		assert.Equal(t, expected, stmts)
+	})
+
+	t.Run("backticks inside string literals are kept", func(t *testing.T) {
+		stmts, err := New(MySQLCompat()).Parse(context.Background(), "SELECT `id` FROM `order` WHERE `note` = 'a `quoted` word';")
+		require.NoError(t, err)
+		require.Len(t, stmts, 1)
+		assert.Equal(t, "order", stmts[0].TableName)
+		assert.Equal(t, "id", stmts[0].Fields[0].Name)
+		require.Len(t, stmts[0].Conditions, 1)
+		assert.Equal(t, minisql.NewTextPointer([]byte("a `quoted` word")), stmts[0].Conditions[0][0].Operand2.Value)
+	})
+
+	t.Run("unterminated backtick", func(t *testing.T) {
+		_, err := New(MySQLCompat()).Parse(context.Background(), "SELECT `id FROM t;")
+		require.ErrorIs(t, err, errUnterminatedBacktick)
+	})
+
+	t.Run("default mode rejects backticks", func(t *testing.T) {
+		_, err := New().Parse(context.Background(), "CREATE TABLE `order` (`id` INT8 PRIMARY KEY);")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "expected table name")
+	})
+}
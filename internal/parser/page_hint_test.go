@@ -0,0 +1,53 @@
+package parser
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse_MinMaxColumn(t *testing.T) {
+	t.Parallel()
+
+	t.Run("MINMAX sets MinMax on column", func(t *testing.T) {
+		t.Parallel()
+		got, err := New().Parse(context.Background(), "CREATE TABLE events (id int8 primary key, created_at timestamp not null check (created_at > '2000-01-01 00:00:00') minmax, payload text);")
+		require.NoError(t, err)
+		require.Len(t, got, 1)
+		require.Len(t, got[0].Columns, 3)
+
+		assert.False(t, got[0].Columns[0].MinMax)
+		assert.True(t, got[0].Columns[1].MinMax)
+		assert.False(t, got[0].Columns[1].Nullable)
+		assert.NotEmpty(t, got[0].Columns[1].Check)
+		assert.False(t, got[0].Columns[2].MinMax)
+	})
+
+	t.Run("MINMAX before inline REFERENCES", func(t *testing.T) {
+		t.Parallel()
+		got, err := New().Parse(context.Background(), "CREATE TABLE readings (id int8 primary key, sensor_id int8 minmax references sensors(id));")
+		require.NoError(t, err)
+		assert.True(t, got[0].Columns[1].MinMax)
+		require.Len(t, got[0].ForeignKeys, 1)
+	})
+
+	t.Run("DDL round-trips MINMAX", func(t *testing.T) {
+		t.Parallel()
+		got, err := New().Parse(context.Background(), "CREATE TABLE events (id int8 primary key, created_at timestamp minmax);")
+		require.NoError(t, err)
+		ddl := got[0].DDL()
+		assert.Contains(t, ddl, "created_at timestamp minmax")
+
+		again, err := New().Parse(context.Background(), ddl)
+		require.NoError(t, err)
+		assert.Equal(t, got[0].Columns, again[0].Columns)
+	})
+
+	t.Run("MINMAX is not accepted by ALTER TABLE ADD COLUMN", func(t *testing.T) {
+		t.Parallel()
+		_, err := New().Parse(context.Background(), "ALTER TABLE events ADD COLUMN seen_at timestamp minmax;")
+		require.Error(t, err)
+	})
+}
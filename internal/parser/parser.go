@@ -37,25 +37,28 @@ var reservedWords = []string{
 	// operators
 	"(", ")", ">=", "<=", "!=", ",", "=", ">", "<", "IN (", "NOT IN (", "?",
 	// arithmetic operators (JSON arrow ops must come before "-" for longest-match tokenization)
-	"+", "->>", "->", "-", "/",
+	"+", "->>", "->", "-", "/", "||",
 	// column types
 	"BOOLEAN", "INT4", "INT8", "REAL", "DOUBLE", "TEXT", "VARCHAR(", "TIMESTAMP", "JSON", "UUID", "VECTOR(",
 	// statement types
 	"EXPLAIN ANALYZE", "EXPLAIN",
 	"CREATE TABLE", "DROP TABLE", "CREATE FULLTEXT INDEX", "CREATE INVERTED INDEX", "CREATE HNSW INDEX", "CREATE INDEX", "DROP INDEX",
-	"ALTER TABLE", "ADD COLUMN", "DROP COLUMN", "RENAME COLUMN", "RENAME TO", "DROPPED",
+	"ALTER TABLE", "ALTER INDEX", "ADD COLUMN", "DROP COLUMN", "RENAME COLUMN", "RENAME TO", "DROPPED",
 	"SELECT", "INSERT INTO", "VALUES", "UPDATE", "DELETE FROM", "TRUNCATE TABLE",
 	// statement other
-	"*", "COUNT(*)", "SUM(", "AVG(", "MIN(", "MAX(", "GROUP BY", "HAVING", "ORDER BY", "LIMIT", "OFFSET",
-	"PRIMARY KEY AUTOINCREMENT", "PRIMARY KEY", "DEFAULT", "NOT NULL", "NULL", "UNIQUE",
+	"*", "COUNT(*)", "COUNT(", "SUM(", "AVG(", "MIN(", "MAX(", "GROUP BY", "HAVING", "ORDER BY", "LIMIT", "OFFSET",
+	"FETCH FIRST", "FETCH NEXT", "ROWS ONLY", "ROW ONLY",
+	"PRIMARY KEY AUTOINCREMENT", "PRIMARY KEY", "DEFAULT", "NOT NULL", "NULLS NOT DISTINCT", "NULLS DISTINCT", "NULLS FIRST", "NULLS LAST", "NULL", "UNIQUE",
 	"IS NULL", "IS NOT NULL", "NOT BETWEEN", "NOT LIKE", "BETWEEN", "LIKE", "TRUE", "FALSE", "NOW()", "GEN_RANDOM_UUID()",
-	"CHECK",
+	"CHECK", "GENERATED ALWAYS AS", "MINMAX",
 	"IF NOT EXISTS", "WHERE", "FROM", "SET NULL", "SET", "ASC", "DESC", "AS",
+	"BEGIN TRANSACTION", "COMMIT TRANSACTION", "ROLLBACK TRANSACTION",
 	"BEGIN", "COMMIT", "ROLLBACK", "ANALYZE", "VACUUM",
 	"PRAGMA",
-	"FULL OUTER JOIN", "FULL JOIN", "INNER JOIN", "LEFT JOIN", "RIGHT JOIN", "ON CONFLICT", "ON DELETE", "ON UPDATE", "ON",
+	"FULL OUTER JOIN", "FULL JOIN", "INNER JOIN", "LEFT OUTER JOIN", "LEFT JOIN", "RIGHT OUTER JOIN", "RIGHT JOIN", "ON CONFLICT", "ON DELETE", "ON UPDATE", "ON",
 	"DO UPDATE", "DO NOTHING",
 	"DISTINCT",
+	"FOR UPDATE",
 	"UNION ALL", "UNION",
 	"RETURNING",
 	"WITH",
@@ -86,7 +89,10 @@ const (
 	stepCreateTableColumnNullNotNull
 	stepCreateTableColumnUnique
 	stepCreateTableColumnDefaultValue
+	stepCreateTableColumnOnUpdate
+	stepCreateTableColumnGenerated
 	stepCreateTableColumnCheck
+	stepCreateTableColumnMinMax
 	stepCreateTableConstraint
 	stepCreateTableConstraintPrimaryKey
 	stepCreateTableConstraintUniqueKey
@@ -117,6 +123,8 @@ const (
 	stepCreateIndexWithOrWhereOrEnd
 	stepCreateIndexWhereOrEnd
 	stepDropIndexName
+	stepAlterIndexName
+	stepAlterIndexRenameTo
 	stepInsertTable
 	stepInsertFieldsOpeningParens
 	stepInsertFields
@@ -181,10 +189,27 @@ const (
 	stepStatementEnd
 )
 
-type parser struct{}
+type parser struct {
+	limits      parseLimits
+	mysqlCompat bool
+}
+
+// Option configures optional parser behaviour.
+type Option func(*parser)
+
+// MySQLCompat makes the parser accept MySQL-style backtick-quoted identifiers
+// (`order`) wherever double-quoted identifiers are allowed, to ease loading
+// MySQL dumps.
+func MySQLCompat() Option {
+	return func(p *parser) {
+		p.mysqlCompat = true
+	}
+}
 
 type parserItem struct {
 	minisql.Statement
+	limits            parseLimits
+	condDepth         int    // current WHERE/HAVING/ON nesting depth, see enterCondition
 	i                 int    // where we are in the query
 	sql               string // original (case-preserved) SQL, used for literals and identifiers
 	upperSQL          string // strings.ToUpper(sql), computed once; used for keyword matching
@@ -199,12 +224,35 @@ type parserItem struct {
 }
 
 // New returns a new SQL parser.
-func New() *parser {
-	return new(parser)
+func New(opts ...Option) *parser {
+	p := new(parser)
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
 }
 
 // Parse parses the given SQL string and returns a slice of statements.
 func (p *parser) Parse(ctx context.Context, sql string) ([]minisql.Statement, error) {
+	if limit := p.limits.maxStatementLength; limit > 0 && len(sql) > limit {
+		return nil, &ParseError{
+			Msg: fmt.Sprintf("%s: statement is %d bytes long, the maximum is %d", errParseLimitExceeded, len(sql), limit),
+			err: errParseLimitExceeded,
+		}
+	}
+	// Comments are stripped first: a line comment ends at a newline, which the
+	// normalisation below would otherwise turn into a plain space.
+	raw := sql
+	sql, err := stripComments(sql)
+	if err != nil {
+		return nil, err
+	}
+	if p.mysqlCompat {
+		sql, err = backticksToDoubleQuotes(sql)
+		if err != nil {
+			return nil, err
+		}
+	}
 	// Replace all control characters with spaces before splitting. strings.Fields
 	// normalises common whitespace (tab, newline, etc.) but leaves other control
 	// characters such as \x15 (NAK) in place. The tokenizer has no rule for them
@@ -217,10 +265,16 @@ func (p *parser) Parse(ctx context.Context, sql string) ([]minisql.Statement, er
 		return r
 	}, sql)
 	normalised := strings.Join(strings.Fields(stripped), " ")
+	// Input holding nothing but comments and empty statements, such as a
+	// script's trailing comment, has no statements to run.
+	if strings.Trim(normalised, "; ") == "" && strings.TrimSpace(raw) != "" {
+		return nil, nil
+	}
 	item := &parserItem{
 		sql:      normalised,
 		upperSQL: strings.ToUpper(normalised),
 		step:     stepBeginning,
+		limits:   p.limits,
 	}
 	statements, err := item.doParse()
 	return statements, err
@@ -235,6 +289,9 @@ func (p *parserItem) doParse() ([]minisql.Statement, error) {
 		//------------------
 		case stepBeginning:
 			switch strings.ToUpper(p.peek()) {
+			case ";":
+				// Empty statement, e.g. a stray or doubled semicolon.
+				p.pop()
 			case "CREATE TABLE":
 				p.Kind = minisql.CreateTable
 				p.pop()
@@ -272,6 +329,10 @@ func (p *parserItem) doParse() ([]minisql.Statement, error) {
 				p.Kind = minisql.AlterTable
 				p.pop()
 				p.step = stepAlterTableName
+			case "ALTER INDEX":
+				p.Kind = minisql.AlterIndex
+				p.pop()
+				p.step = stepAlterIndexName
 			case "WITH":
 				p.pop()
 				p.step = stepWithCTEName
@@ -292,7 +353,7 @@ func (p *parserItem) doParse() ([]minisql.Statement, error) {
 				p.pop()
 				p.step = stepDeleteFromTable
 			case "TRUNCATE TABLE":
-				p.Kind = minisql.Delete
+				p.Kind = minisql.TruncateTable
 				p.pop()
 				p.step = stepTruncateTable
 			case "ANALYZE":
@@ -303,6 +364,18 @@ func (p *parserItem) doParse() ([]minisql.Statement, error) {
 				p.Kind = minisql.Vacuum
 				p.pop()
 				p.step = stepStatementEnd
+			case "BEGIN", "BEGIN TRANSACTION":
+				p.Kind = minisql.BeginTransaction
+				p.pop()
+				p.step = stepStatementEnd
+			case "COMMIT", "COMMIT TRANSACTION":
+				p.Kind = minisql.CommitTransaction
+				p.pop()
+				p.step = stepStatementEnd
+			case "ROLLBACK", "ROLLBACK TRANSACTION":
+				p.Kind = minisql.RollbackTransaction
+				p.pop()
+				p.step = stepStatementEnd
 			case "PRAGMA":
 				p.Kind = minisql.Pragma
 				p.pop()
@@ -332,7 +405,10 @@ func (p *parserItem) doParse() ([]minisql.Statement, error) {
 			stepCreateTableColumnNullNotNull,
 			stepCreateTableColumnUnique,
 			stepCreateTableColumnDefaultValue,
+			stepCreateTableColumnOnUpdate,
+			stepCreateTableColumnGenerated,
 			stepCreateTableColumnCheck,
+			stepCreateTableColumnMinMax,
 			stepCreateTableConstraint,
 			stepCreateTableConstraintPrimaryKey,
 			stepCreateTableConstraintUniqueKey,
@@ -383,6 +459,13 @@ func (p *parserItem) doParse() ([]minisql.Statement, error) {
 				return statements, err
 			}
 		// -----------------
+		// ALTER INDEX
+		//------------------
+		case stepAlterIndexName, stepAlterIndexRenameTo:
+			if err := p.doParseAlterIndex(); err != nil {
+				return statements, err
+			}
+		// -----------------
 		// ALTER TABLE
 		//------------------
 		case stepAlterTableName,
@@ -507,6 +590,14 @@ func (p *parserItem) doParse() ([]minisql.Statement, error) {
 			// For SELECT statements, intercept UNION / UNION ALL before requiring a semicolon.
 			if p.Kind == minisql.Select {
 				next := strings.ToUpper(p.peek())
+				if next == "FOR UPDATE" {
+					if p.ForUpdate {
+						return statements, p.errorf("at FOR UPDATE: duplicate FOR UPDATE clause")
+					}
+					p.pop()
+					p.ForUpdate = true
+					continue
+				}
 				if next == "UNION ALL" || next == "UNION" {
 					all := next == "UNION ALL"
 					p.pop() // consume "UNION [ALL]"
@@ -516,6 +607,7 @@ func (p *parserItem) doParse() ([]minisql.Statement, error) {
 						sql:      sub,
 						upperSQL: p.upperSQL[p.i:],
 						step:     stepBeginning,
+						limits:   p.limits,
 					}
 					unionStmts, err := rest.doParse()
 					if err != nil {
@@ -585,6 +677,12 @@ func (p *parserItem) doParse() ([]minisql.Statement, error) {
 	// Also handle statements (e.g. VACUUM) that are valid at EOF without a
 	// trailing semicolon: they set p.step = stepStatementEnd before the loop
 	// ends but are not yet in the slice.
+	// Trailing empty statements leave the parser at stepBeginning with nothing
+	// parsed; they only count as an (invalid) statement when the input held
+	// no real statements at all.
+	if p.step == stepBeginning && p.Kind == 0 && len(statements) > 0 {
+		return statements, nil
+	}
 	if p.step != stepStatementEnd || p.Kind != 0 {
 		if err := p.validate(p.Statement); err != nil {
 			return nil, err
@@ -667,18 +765,56 @@ func (p *parserItem) peekWithLength() (string, int) {
 		return p.sql[p.i:end], end - p.i
 	}
 
+	// Named placeholders (:name)
+	if p.sql[p.i] == ':' {
+		end := p.i + 1
+		for end < len(p.sql) && p.sql[end] != '"' && isIdentChar(p.sql[end]) {
+			end += 1
+		}
+		if end > p.i+1 && !unicode.IsDigit(rune(p.sql[p.i+1])) {
+			return p.sql[p.i:end], end - p.i
+		}
+	}
+
 	// And finally for identifiers
 	return p.peekIdentifierWithLength()
 }
 
+// isPlaceholder reports whether token, as returned by peek at the current
+// position, is a positional (?) or named (:name) placeholder. It looks at the
+// raw SQL because peek strips quotes, so a literal such as ':x' or '?' must not
+// be mistaken for a placeholder.
+func (p *parserItem) isPlaceholder(token string) bool {
+	if p.i >= len(p.sql) || (p.sql[p.i] != '?' && p.sql[p.i] != ':') {
+		return false
+	}
+	return token == "?" || (len(token) > 1 && token[0] == ':')
+}
+
+// placeholder returns the placeholder value for a token accepted by
+// isPlaceholder.
+func placeholder(token string) minisql.Placeholder {
+	if token == "?" {
+		return minisql.Placeholder{}
+	}
+	return minisql.Placeholder{Name: token[1:]}
+}
+
 func (p *parserItem) peekQuotedStringWithLength() (string, int) {
 	if p.i >= len(p.sql) || p.sql[p.i] != '\'' {
 		return "", 0
 	}
 	for i := p.i + 1; i < len(p.sql); i++ {
-		if p.sql[i] == '\'' && p.sql[i-1] != '\\' {
-			return p.sql[p.i+1 : i], len(p.sql[p.i+1:i]) + 2 // +2 for the two quotes
+		if p.sql[i] != '\'' || p.sql[i-1] == '\\' {
+			continue
 		}
+		if i+1 < len(p.sql) && p.sql[i+1] == '\'' {
+			// A doubled quote stands for one quote character.
+			i += 1
+			continue
+		}
+		raw := p.sql[p.i+1 : i]
+		return strings.ReplaceAll(raw, "''", "'"), len(raw) + 2 // +2 for the two quotes
 	}
 	return "", 0
 }
@@ -738,9 +874,10 @@ func (p *parserItem) peekValue() (any, int) {
 	if ln > 0 {
 		return boolean, ln
 	}
+	// A literal written with a decimal point, such as 2.0, stays a float.
 	number, ln := p.peekNumberWithLength()
 	if ln > 0 {
-		if float64(int64(number)) == number {
+		if float64(int64(number)) == number && !strings.Contains(p.sql[p.i:p.i+ln], ".") {
 			return int64(number), ln
 		}
 		return number, ln
@@ -754,7 +891,7 @@ func (p *parserItem) peekValue() (any, int) {
 		if ln > 0 {
 			number = -number
 			totalLen := 1 + ln
-			if float64(int64(number)) == number {
+			if float64(int64(number)) == number && !strings.Contains(p.sql[p.i:p.i+totalLen], ".") {
 				return int64(number), totalLen
 			}
 			return number, totalLen
@@ -789,7 +926,10 @@ func (p *parserItem) validate(stmt minisql.Statement) error {
 	if stmt.Kind == 0 {
 		return errEmptyStatementKind
 	}
-	if stmt.Kind == minisql.CreateIndex || stmt.Kind == minisql.DropIndex {
+	if _, err := stmt.HasNamedPlaceholders(); err != nil {
+		return err
+	}
+	if stmt.Kind == minisql.CreateIndex || stmt.Kind == minisql.DropIndex || stmt.Kind == minisql.AlterIndex {
 		if stmt.IndexName == "" {
 			return errEmptyIndexName
 		}
@@ -803,6 +943,9 @@ func (p *parserItem) validate(stmt minisql.Statement) error {
 		stmt.FromSubquery == nil &&
 		stmt.Kind != minisql.Analyze &&
 		stmt.Kind != minisql.Vacuum &&
+		stmt.Kind != minisql.BeginTransaction &&
+		stmt.Kind != minisql.CommitTransaction &&
+		stmt.Kind != minisql.RollbackTransaction &&
 		stmt.Kind != minisql.Pragma &&
 		stmt.Kind != minisql.Explain {
 		return errEmptyTableName
@@ -13,11 +13,15 @@ var (
 	errSelectExpectedTableName = errors.New("at SELECT: expected table name identifier")
 	errCannotCombineAsterisk   = fmt.Errorf(`at SELECT: cannot combine "*" with other fields`)
 	errExpectedFrom            = errors.New("at SELECT: expected FROM")
+	errFetchExpectedRowsOnly   = errors.New("at SELECT: expected ROWS ONLY after FETCH FIRST")
 )
 
 // aggregateKindFromToken maps the reserved-word token (e.g. "SUM(") to its AggregateKind.
+// "COUNT(" is COUNT(col); COUNT(*) is its own token.
 func aggregateKindFromToken(upper string) minisql.AggregateKind {
 	switch upper {
+	case "COUNT(":
+		return minisql.AggregateCount
 	case "SUM(":
 		return minisql.AggregateSum
 	case "AVG(":
@@ -31,6 +35,48 @@ func aggregateKindFromToken(upper string) minisql.AggregateKind {
 	}
 }
 
+// parseAggregateArgument consumes an aggregate call such as "SUM(price)" or
+// "COUNT(DISTINCT email)" starting at its opening token and returns the
+// column name and whether DISTINCT was given. Only COUNT accepts DISTINCT,
+// and it takes exactly one column.
+func (p *parserItem) parseAggregateArgument(clause, upperIdent string) (string, bool, error) {
+	funcName := strings.TrimSuffix(upperIdent, "(")
+	p.pop() // consume "SUM(" etc.
+
+	distinct := false
+	if upperIdent == "COUNT(" && strings.ToUpper(p.peek()) == "DISTINCT" {
+		p.pop() // consume "DISTINCT"
+		distinct = true
+		if p.peek() == "*" {
+			return "", false, p.errorf("at %s: COUNT(DISTINCT *) is not supported, name a column", clause)
+		}
+	}
+
+	colName := p.peek()
+	if !isIdentifier(colName) {
+		return "", false, p.errorf("at %s: expected column name in %s", clause, funcName)
+	}
+	p.pop() // consume column name
+	if distinct && p.peek() == "," {
+		return "", false, p.errorf("at %s: COUNT(DISTINCT) takes exactly one column", clause)
+	}
+	if p.peek() != ")" {
+		return "", false, p.errorf("at %s: expected ')' after column name in %s", clause, funcName)
+	}
+	p.pop() // consume ")"
+	return colName, distinct, nil
+}
+
+// aggregateFieldName builds the synthetic output column name of an aggregate
+// call, e.g. "SUM(price)" or "COUNT(DISTINCT email)".
+func aggregateFieldName(upperIdent, colName string, distinct bool) string {
+	funcName := strings.TrimSuffix(upperIdent, "(")
+	if distinct {
+		return funcName + "(DISTINCT " + colName + ")"
+	}
+	return funcName + "(" + colName + ")"
+}
+
 /*
 SELECT select_list
 
@@ -38,7 +84,9 @@ SELECT select_list
 		[ WHERE ... ]
 	    [ ORDER BY ... ]
 	    [ LIMIT { count | ALL } ]
-	    [ OFFSET start ]
+	    [ OFFSET start [ ROW | ROWS ] ]
+	    [ FETCH { FIRST | NEXT } [ count ] { ROW | ROWS } ONLY ]
+	    [ FOR UPDATE ]
 */
 func (p *parserItem) doParseSelect() error {
 	switch p.step {
@@ -53,26 +101,24 @@ func (p *parserItem) doParseSelect() error {
 		upperIdent := strings.ToUpper(identifier)
 		isAggFunc := aggregateKindFromToken(upperIdent) != 0
 
-		if !isIdentifier(identifier) && identifier != "*" && upperIdent != "COUNT(*)" && !isAggFunc && upperIdent != "NOW()" && upperIdent != "GEN_RANDOM_UUID()" {
+		_, isFuncCall := p.peekFuncCall()
+		if !isIdentifier(identifier) && identifier != "*" && upperIdent != "COUNT(*)" && !isAggFunc && upperIdent != "NOW()" && upperIdent != "GEN_RANDOM_UUID()" && !isFuncCall {
 			return p.wrapErr(errSelectWithoutFields)
 		}
 
-		// Handle aggregate function calls: SUM(col), AVG(col), MIN(col), MAX(col)
+		// Handle aggregate function calls: COUNT(col), SUM(col), AVG(col), MIN(col), MAX(col)
 		if isAggFunc {
 			aggKind := aggregateKindFromToken(upperIdent)
-			p.pop() // consume "SUM(" etc.
-			colName := p.peek()
-			if !isIdentifier(colName) {
-				return p.errorf("at SELECT: expected column name in %s", strings.TrimSuffix(upperIdent, "("))
-			}
-			p.pop() // consume column name
-			if p.peek() != ")" {
-				return p.errorf("at SELECT: expected ')' after column name in %s", strings.TrimSuffix(upperIdent, "("))
+			colName, distinct, err := p.parseAggregateArgument("SELECT", upperIdent)
+			if err != nil {
+				return err
 			}
-			p.pop() // consume ")"
 
 			// SUM(col) OVER (...) — window aggregate, not a plain aggregate.
 			if strings.ToUpper(p.peek()) == "OVER" {
+				if distinct {
+					return p.errorf("at SELECT: DISTINCT is not supported in window functions")
+				}
 				p.pop() // consume "OVER"
 				spec, err := p.parseWindowSpec()
 				if err != nil {
@@ -109,8 +155,7 @@ func (p *parserItem) doParseSelect() error {
 			}
 
 			// Build synthetic field name e.g. "SUM(price)"
-			funcName := strings.TrimSuffix(upperIdent, "(")
-			fieldName := funcName + "(" + colName + ")"
+			fieldName := aggregateFieldName(upperIdent, colName, distinct)
 
 			// Keep Aggregates parallel to Fields.
 			// If this is the first aggregate, backfill zeros for any regular fields already added.
@@ -118,7 +163,7 @@ func (p *parserItem) doParseSelect() error {
 				p.Aggregates = append(p.Aggregates, minisql.AggregateExpr{})
 			}
 			p.Fields = append(p.Fields, minisql.Field{Name: fieldName})
-			p.Aggregates = append(p.Aggregates, minisql.AggregateExpr{Kind: aggKind, Column: colName})
+			p.Aggregates = append(p.Aggregates, minisql.AggregateExpr{Kind: aggKind, Column: colName, Distinct: distinct})
 
 			// Optional alias: SUM(price) AS total
 			if strings.ToUpper(p.peek()) == "AS" {
@@ -140,6 +185,24 @@ func (p *parserItem) doParseSelect() error {
 			return nil
 		}
 
+		// Handle alias.* — all columns of one table, expanded at execution time
+		// once the alias is resolved against the FROM and JOIN tables.
+		if alias, ok := p.peekQualifiedWildcard(); ok {
+			p.pop() // consume alias
+			p.i += len(".*")
+			p.popWhitespace()
+			if len(p.Aggregates) > 0 {
+				p.Aggregates = append(p.Aggregates, minisql.AggregateExpr{})
+			}
+			p.Fields = append(p.Fields, minisql.Field{AliasPrefix: alias, Name: "*"})
+			if strings.ToUpper(p.peek()) == "FROM" {
+				p.step = stepSelectFrom
+				return nil
+			}
+			p.step = stepSelectComma
+			return nil
+		}
+
 		// Handle * for selecting all rows — consume immediately before expression parsing.
 		if identifier == "*" {
 			p.Fields = append(p.Fields, fieldFromIdentifier(identifier))
@@ -193,14 +256,9 @@ func (p *parserItem) doParseSelect() error {
 			}
 
 			p.Fields = append(p.Fields, minisql.Field{Name: identifier})
-			if len(p.Aggregates) > 0 {
-				p.Aggregates = append(p.Aggregates, minisql.AggregateExpr{})
-			}
 			if len(p.Fields) > 1 {
-				if len(p.Aggregates) < len(p.Fields)-1 {
-					for len(p.Aggregates) < len(p.Fields)-1 {
-						p.Aggregates = append(p.Aggregates, minisql.AggregateExpr{})
-					}
+				for len(p.Aggregates) < len(p.Fields)-1 {
+					p.Aggregates = append(p.Aggregates, minisql.AggregateExpr{})
 				}
 				p.Aggregates = append(p.Aggregates, minisql.AggregateExpr{Kind: minisql.AggregateCount})
 				// Optional alias: COUNT(*) AS cnt
@@ -354,11 +412,11 @@ func (p *parserItem) doParseSelect() error {
 			p.pop()
 			p.joinInProgress.Type = minisql.Inner
 			p.step = stepSelectJoinTable
-		case "LEFT JOIN":
+		case "LEFT JOIN", "LEFT OUTER JOIN":
 			p.joinInProgress.Type = minisql.Left
 			p.step = stepSelectJoinTable
 			p.pop()
-		case "RIGHT JOIN":
+		case "RIGHT JOIN", "RIGHT OUTER JOIN":
 			p.joinInProgress.Type = minisql.Right
 			p.step = stepSelectJoinTable
 			p.pop()
@@ -476,7 +534,7 @@ func (p *parserItem) doParseSelect() error {
 		}
 		p.Having = node.ToDNF()
 		next := strings.ToUpper(p.peek())
-		if next == "ORDER BY" || next == "LIMIT" || next == "OFFSET" {
+		if next == "ORDER BY" || next == "LIMIT" || next == "OFFSET" || next == "FETCH FIRST" || next == "FETCH NEXT" {
 			p.step = stepSelectOrderBy
 		} else {
 			p.step = stepStatementEnd
@@ -502,22 +560,18 @@ func (p *parserItem) doParseSelect() error {
 			return p.errorf(`at ORDER BY: cannot order by "*"`)
 		}
 
-		// If the token is a known scalar function, parse the full call as an expression.
-		if isBuiltinFunction(strings.ToUpper(identifier)) {
+		// If the token is a known scalar function or starts a CASE expression,
+		// parse the full expression.
+		if upper := strings.ToUpper(identifier); isBuiltinFunction(upper) || upper == "CASE" {
 			expr, err := p.parseExpr()
 			if err != nil {
 				return p.errorf("at ORDER BY: %v", err)
 			}
-			theDirection := minisql.Asc
-			if direction := strings.ToUpper(p.peek()); direction == "ASC" || direction == "DESC" {
-				if direction == "DESC" {
-					theDirection = minisql.Desc
-				}
-				p.pop()
-			}
+			theDirection, nulls := p.parseOrderDirection()
 			p.OrderBy = append(p.OrderBy, minisql.OrderBy{
 				Field:     minisql.Field{Expr: expr},
 				Direction: theDirection,
+				Nulls:     nulls,
 			})
 			p.step = stepSelectOrderByComma
 			return nil
@@ -534,17 +588,11 @@ func (p *parserItem) doParseSelect() error {
 			fieldName = identifier
 		}
 
-		// Start with default direction as ASC
-		theDirection := minisql.Asc
-		if direction := strings.ToUpper(p.peek()); direction == "ASC" || direction == "DESC" {
-			if direction == "DESC" {
-				theDirection = minisql.Desc
-			}
-			p.pop()
-		}
+		theDirection, nulls := p.parseOrderDirection()
 		p.OrderBy = append(p.OrderBy, minisql.OrderBy{
 			Field:     minisql.Field{Name: fieldName, AliasPrefix: aliasPrefix},
 			Direction: theDirection,
+			Nulls:     nulls,
 		})
 		p.step = stepSelectOrderByComma
 	case stepSelectOrderByComma:
@@ -556,8 +604,15 @@ func (p *parserItem) doParseSelect() error {
 		p.pop()
 		p.step = stepSelectOrderByField
 	case stepSelectLimit:
-		limitRWord := p.peek()
-		if strings.ToUpper(limitRWord) != "LIMIT" {
+		limitRWord := strings.ToUpper(p.peek())
+		if limitRWord == "FETCH FIRST" || limitRWord == "FETCH NEXT" {
+			if err := p.parseFetchFirst(); err != nil {
+				return err
+			}
+			p.step = stepSelectOffset
+			return nil
+		}
+		if limitRWord != "LIMIT" {
 			p.step = stepSelectOffset
 			return nil
 		}
@@ -586,11 +641,72 @@ func (p *parserItem) doParseSelect() error {
 		}
 		p.Offset = minisql.OptionalValue{Value: offsetValue, Valid: true}
 		p.pop()
+		if rowsRWord := strings.ToUpper(p.peek()); rowsRWord == "ROW" || rowsRWord == "ROWS" {
+			p.pop()
+		}
+		// The SQL standard puts OFFSET before FETCH FIRST.
+		if next := strings.ToUpper(p.peek()); !p.Limit.Valid && (next == "FETCH FIRST" || next == "FETCH NEXT") {
+			p.step = stepSelectLimit
+			return nil
+		}
 		p.step = stepStatementEnd
 	}
 	return nil
 }
 
+// parseOrderDirection consumes an optional ASC or DESC followed by an optional
+// NULLS FIRST or NULLS LAST. The direction defaults to ASC.
+func (p *parserItem) parseOrderDirection() (minisql.Direction, minisql.NullsOrder) {
+	direction := minisql.Asc
+	switch strings.ToUpper(p.peek()) {
+	case "ASC":
+		p.pop()
+	case "DESC":
+		direction = minisql.Desc
+		p.pop()
+	}
+	nulls := minisql.NullsDefault
+	switch strings.ToUpper(p.peek()) {
+	case "NULLS FIRST":
+		nulls = minisql.NullsFirst
+		p.pop()
+	case "NULLS LAST":
+		nulls = minisql.NullsLast
+		p.pop()
+	}
+	return direction, nulls
+}
+
+// parseFetchFirst parses the SQL standard FETCH { FIRST | NEXT } [ count ]
+// { ROW | ROWS } ONLY clause into Limit. The count defaults to 1.
+func (p *parserItem) parseFetchFirst() error {
+	p.pop() // consume "FETCH FIRST" / "FETCH NEXT"
+	limitValue := int64(1)
+	if value, n := p.peekIntWithLength(); n > 0 {
+		limitValue = value
+		p.pop()
+	}
+	if onlyRWord := strings.ToUpper(p.peek()); onlyRWord != "ROWS ONLY" && onlyRWord != "ROW ONLY" {
+		return p.wrapErr(errFetchExpectedRowsOnly)
+	}
+	p.pop()
+	p.Limit = minisql.OptionalValue{Value: limitValue, Valid: true}
+	return nil
+}
+
+// peekQualifiedWildcard reports whether the next token is alias.* and returns
+// the alias.
+func (p *parserItem) peekQualifiedWildcard() (string, bool) {
+	identifier, n := p.peekWithLength()
+	if !isIdentifier(identifier) || strings.Contains(identifier, ".") {
+		return "", false
+	}
+	if !strings.HasPrefix(p.sql[p.i+n:], ".*") {
+		return "", false
+	}
+	return identifier, true
+}
+
 func fieldFromIdentifier(identifier string) minisql.Field {
 	if parts := strings.SplitN(identifier, ".", 2); len(parts) == 2 {
 		return minisql.Field{
@@ -294,6 +294,34 @@ func TestParse_Select(t *testing.T) {
 			},
 			nil,
 		},
+		{
+			"SELECT with ORDER BY NULLS FIRST / NULLS LAST works",
+			"SELECT * FROM b ORDER BY a DESC NULLS LAST, c NULLS FIRST, d;",
+			[]minisql.Statement{
+				{
+					Kind:      minisql.Select,
+					TableName: "b",
+					Fields:    []minisql.Field{{Name: "*"}},
+					OrderBy: []minisql.OrderBy{
+						{
+							Field:     minisql.Field{Name: "a"},
+							Direction: minisql.Desc,
+							Nulls:     minisql.NullsLast,
+						},
+						{
+							Field:     minisql.Field{Name: "c"},
+							Direction: minisql.Asc,
+							Nulls:     minisql.NullsFirst,
+						},
+						{
+							Field:     minisql.Field{Name: "d"},
+							Direction: minisql.Asc,
+						},
+					},
+				},
+			},
+			nil,
+		},
 		{
 			"SELECT with LIMIT works",
 			"SELECT * FROM b LIMIT 10;",
@@ -500,6 +528,50 @@ func TestParse_Select(t *testing.T) {
 			},
 			nil,
 		},
+		{
+			"SELECT with named placeholders",
+			`SELECT a FROM "b" WHERE a = :id AND c IN (:email, :Email2) AND d = :id;`,
+			[]minisql.Statement{
+				{
+					Kind:      minisql.Select,
+					TableName: "b",
+					Fields:    []minisql.Field{{Name: "a"}},
+					Conditions: minisql.OneOrMore{
+						{
+							minisql.FieldIsEqual(minisql.Field{Name: "a"}, minisql.OperandPlaceholder, minisql.Placeholder{Name: "id"}),
+							minisql.FieldIsInAny(minisql.Field{Name: "c"}, minisql.Placeholder{Name: "email"}, minisql.Placeholder{Name: "Email2"}),
+							minisql.FieldIsEqual(minisql.Field{Name: "d"}, minisql.OperandPlaceholder, minisql.Placeholder{Name: "id"}),
+						},
+					},
+				},
+			},
+			nil,
+		},
+		{
+			"SELECT with quoted strings that look like placeholders",
+			`SELECT a FROM "b" WHERE a = ':x' AND c IN ('12:30', ':y') AND d = ?;`,
+			[]minisql.Statement{
+				{
+					Kind:      minisql.Select,
+					TableName: "b",
+					Fields:    []minisql.Field{{Name: "a"}},
+					Conditions: minisql.OneOrMore{
+						{
+							minisql.FieldIsEqual(minisql.Field{Name: "a"}, minisql.OperandQuotedString, minisql.NewTextPointer([]byte(":x"))),
+							minisql.FieldIsInAny(minisql.Field{Name: "c"}, minisql.NewTextPointer([]byte("12:30")), minisql.NewTextPointer([]byte(":y"))),
+							minisql.FieldIsEqual(minisql.Field{Name: "d"}, minisql.OperandPlaceholder, nil),
+						},
+					},
+				},
+			},
+			nil,
+		},
+		{
+			"SELECT mixing positional and named placeholders fails",
+			`SELECT a FROM "b" WHERE a = :id AND c = ?;`,
+			nil,
+			minisql.ErrMixedPlaceholders,
+		},
 		{
 			"SELECT with INNER JOIN",
 			"SELECT u.id, p.name FROM users AS u INNER JOIN profiles AS p ON u.id = p.user_id;",
@@ -536,6 +608,43 @@ func TestParse_Select(t *testing.T) {
 			},
 			nil,
 		},
+		{
+			"SELECT with qualified wildcard in INNER JOIN",
+			"SELECT u.*, p.name, p.* FROM users AS u INNER JOIN profiles AS p ON u.id = p.user_id;",
+			[]minisql.Statement{
+				{
+					Kind:       minisql.Select,
+					TableName:  "users",
+					TableAlias: "u",
+					Fields: []minisql.Field{
+						{AliasPrefix: "u", Name: "*"},
+						{AliasPrefix: "p", Name: "name"},
+						{AliasPrefix: "p", Name: "*"},
+					},
+					Joins: []minisql.Join{
+						{
+							Type:       minisql.Inner,
+							TableName:  "profiles",
+							TableAlias: "p",
+							Conditions: minisql.Conditions{
+								minisql.FieldIsEqual(
+									minisql.Field{
+										AliasPrefix: "u",
+										Name:        "id",
+									},
+									minisql.OperandField,
+									minisql.Field{
+										AliasPrefix: "p",
+										Name:        "user_id",
+									},
+								),
+							},
+						},
+					},
+				},
+			},
+			nil,
+		},
 		{
 			"SELECT with INNER JOIN and WHERE clause",
 			`SELECT u.id, p.name FROM users AS u
@@ -642,6 +751,66 @@ func TestParse_Select(t *testing.T) {
 			},
 			nil,
 		},
+		{
+			"SELECT with LEFT OUTER JOIN",
+			"SELECT u.id, o.id FROM users AS u LEFT OUTER JOIN orders AS o ON u.id = o.user_id;",
+			[]minisql.Statement{
+				{
+					Kind:       minisql.Select,
+					TableName:  "users",
+					TableAlias: "u",
+					Fields: []minisql.Field{
+						{AliasPrefix: "u", Name: "id"},
+						{AliasPrefix: "o", Name: "id"},
+					},
+					Joins: []minisql.Join{
+						{
+							Type:       minisql.Left,
+							TableName:  "orders",
+							TableAlias: "o",
+							Conditions: minisql.Conditions{
+								minisql.FieldIsEqual(
+									minisql.Field{AliasPrefix: "u", Name: "id"},
+									minisql.OperandField,
+									minisql.Field{AliasPrefix: "o", Name: "user_id"},
+								),
+							},
+						},
+					},
+				},
+			},
+			nil,
+		},
+		{
+			"SELECT with RIGHT OUTER JOIN",
+			"SELECT u.id, o.id FROM users AS u RIGHT OUTER JOIN orders AS o ON u.id = o.user_id;",
+			[]minisql.Statement{
+				{
+					Kind:       minisql.Select,
+					TableName:  "users",
+					TableAlias: "u",
+					Fields: []minisql.Field{
+						{AliasPrefix: "u", Name: "id"},
+						{AliasPrefix: "o", Name: "id"},
+					},
+					Joins: []minisql.Join{
+						{
+							Type:       minisql.Right,
+							TableName:  "orders",
+							TableAlias: "o",
+							Conditions: minisql.Conditions{
+								minisql.FieldIsEqual(
+									minisql.Field{AliasPrefix: "u", Name: "id"},
+									minisql.OperandField,
+									minisql.Field{AliasPrefix: "o", Name: "user_id"},
+								),
+							},
+						},
+					},
+				},
+			},
+			nil,
+		},
 		{
 			"SELECT with nested INNER JOIN",
 			`SELECT u.id, p.name FROM users AS u
@@ -716,6 +885,128 @@ func TestParse_Select(t *testing.T) {
 	}
 }
 
+func TestParse_SelectFetchFirst(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		Name       string
+		SQL        string
+		Equivalent string
+	}{
+		{"FETCH FIRST n ROWS ONLY", "SELECT * FROM b FETCH FIRST 10 ROWS ONLY;", "SELECT * FROM b LIMIT 10;"},
+		{"FETCH NEXT n ROW ONLY", "SELECT * FROM b fetch next 10 row only;", "SELECT * FROM b LIMIT 10;"},
+		{"FETCH FIRST without count", "SELECT * FROM b FETCH FIRST ROW ONLY;", "SELECT * FROM b LIMIT 1;"},
+		{"OFFSET n ROWS", "SELECT * FROM b OFFSET 20 ROWS;", "SELECT * FROM b OFFSET 20;"},
+		{
+			"OFFSET n ROWS FETCH FIRST",
+			"SELECT * FROM b ORDER BY a OFFSET 20 ROWS FETCH FIRST 10 ROWS ONLY;",
+			"SELECT * FROM b ORDER BY a LIMIT 10 OFFSET 20;",
+		},
+		{
+			"FETCH FIRST then OFFSET",
+			"SELECT * FROM b FETCH FIRST 10 ROWS ONLY OFFSET 20 ROWS;",
+			"SELECT * FROM b LIMIT 10 OFFSET 20;",
+		},
+		{
+			"WHERE and FETCH FIRST",
+			`SELECT a FROM "b" WHERE a = 2 FETCH FIRST 5 ROWS ONLY FOR UPDATE;`,
+			`SELECT a FROM "b" WHERE a = 2 LIMIT 5 FOR UPDATE;`,
+		},
+	}
+
+	for _, aTestCase := range testCases {
+		t.Run(aTestCase.Name, func(t *testing.T) {
+			expected, err := New().Parse(context.Background(), aTestCase.Equivalent)
+			require.NoError(t, err)
+			aStatement, err := New().Parse(context.Background(), aTestCase.SQL)
+			require.NoError(t, err)
+			assert.Equal(t, expected, aStatement)
+		})
+	}
+
+	t.Run("FETCH FIRST without ROWS ONLY fails", func(t *testing.T) {
+		_, err := New().Parse(context.Background(), "SELECT * FROM b FETCH FIRST 10;")
+		require.ErrorIs(t, err, errFetchExpectedRowsOnly)
+	})
+}
+
+func TestParse_SelectForUpdate(t *testing.T) {
+	t.Parallel()
+
+	testCases := []testCase{
+		{
+			"SELECT FOR UPDATE without WHERE",
+			"SELECT * FROM accounts FOR UPDATE;",
+			[]minisql.Statement{
+				{
+					Kind:      minisql.Select,
+					TableName: "accounts",
+					Fields:    []minisql.Field{{Name: "*"}},
+					ForUpdate: true,
+				},
+			},
+			nil,
+		},
+		{
+			"SELECT FOR UPDATE with WHERE",
+			"SELECT balance FROM accounts WHERE id = 1 for update;",
+			[]minisql.Statement{
+				{
+					Kind:      minisql.Select,
+					TableName: "accounts",
+					Fields:    []minisql.Field{{Name: "balance"}},
+					Conditions: minisql.OneOrMore{
+						{
+							minisql.FieldIsEqual(minisql.Field{Name: "id"}, minisql.OperandInteger, int64(1)),
+						},
+					},
+					ForUpdate: true,
+				},
+			},
+			nil,
+		},
+		{
+			"SELECT FOR UPDATE with ORDER BY, LIMIT and OFFSET",
+			"SELECT id FROM accounts ORDER BY id LIMIT 10 OFFSET 5 FOR UPDATE",
+			[]minisql.Statement{
+				{
+					Kind:      minisql.Select,
+					TableName: "accounts",
+					Fields:    []minisql.Field{{Name: "id"}},
+					OrderBy:   []minisql.OrderBy{{Field: minisql.Field{Name: "id"}, Direction: minisql.Asc}},
+					Limit:     minisql.OptionalValue{Value: int64(10), Valid: true},
+					Offset:    minisql.OptionalValue{Value: int64(5), Valid: true},
+					ForUpdate: true,
+				},
+			},
+			nil,
+		},
+	}
+
+	for _, aTestCase := range testCases {
+		t.Run(aTestCase.Name, func(t *testing.T) {
+			aStatement, err := New().Parse(context.Background(), aTestCase.SQL)
+			require.NoError(t, err)
+			assert.Equal(t, aTestCase.Expected, aStatement)
+		})
+	}
+
+	errorCases := []struct {
+		Name string
+		SQL  string
+		Err  string
+	}{
+		{"duplicate FOR UPDATE", "SELECT * FROM accounts FOR UPDATE FOR UPDATE;", "duplicate FOR UPDATE clause"},
+		{"FOR UPDATE before WHERE", "SELECT * FROM accounts FOR UPDATE WHERE id = 1;", "expected semicolon"},
+	}
+	for _, aTestCase := range errorCases {
+		t.Run(aTestCase.Name, func(t *testing.T) {
+			_, err := New().Parse(context.Background(), aTestCase.SQL)
+			require.ErrorContains(t, err, aTestCase.Err)
+		})
+	}
+}
+
 func TestParse_SelectGroupBy(t *testing.T) {
 	t.Parallel()
 
@@ -989,6 +1280,54 @@ func TestParse_SelectHaving(t *testing.T) {
 			},
 			nil,
 		},
+		{
+			"COUNT(column) next to COUNT(*)",
+			"SELECT user_id, COUNT(coupon), COUNT(*) FROM orders GROUP BY user_id HAVING COUNT(coupon) > 1;",
+			[]minisql.Statement{
+				{
+					Kind:      minisql.Select,
+					TableName: "orders",
+					Fields: []minisql.Field{
+						{Name: "user_id"},
+						{Name: "COUNT(coupon)"},
+						{Name: "COUNT(*)"},
+					},
+					Aggregates: []minisql.AggregateExpr{
+						{},
+						{Kind: minisql.AggregateCount, Column: "coupon"},
+						{Kind: minisql.AggregateCount},
+					},
+					GroupBy: []minisql.Field{{Name: "user_id"}},
+					Having: minisql.OneOrMore{
+						{minisql.FieldIsGreater(minisql.Field{Name: "COUNT(coupon)"}, minisql.OperandInteger, int64(1))},
+					},
+				},
+			},
+			nil,
+		},
+		{
+			"COUNT(DISTINCT column) with HAVING",
+			"SELECT user_id, count(distinct coupon) FROM orders GROUP BY user_id HAVING COUNT(DISTINCT coupon) > 1;",
+			[]minisql.Statement{
+				{
+					Kind:      minisql.Select,
+					TableName: "orders",
+					Fields: []minisql.Field{
+						{Name: "user_id"},
+						{Name: "COUNT(DISTINCT coupon)"},
+					},
+					Aggregates: []minisql.AggregateExpr{
+						{},
+						{Kind: minisql.AggregateCount, Column: "coupon", Distinct: true},
+					},
+					GroupBy: []minisql.Field{{Name: "user_id"}},
+					Having: minisql.OneOrMore{
+						{minisql.FieldIsGreater(minisql.Field{Name: "COUNT(DISTINCT coupon)"}, minisql.OperandInteger, int64(1))},
+					},
+				},
+			},
+			nil,
+		},
 		{
 			"WHERE and HAVING both with placeholders",
 			"SELECT user_id, COUNT(*) FROM orders WHERE status = ? GROUP BY user_id HAVING COUNT(*) >= ?;",
@@ -1133,6 +1472,35 @@ func TestParse_SelectArithmetic(t *testing.T) {
 			},
 			nil,
 		},
+		{
+			"SELECT concatenation binds looser than arithmetic",
+			"SELECT name || '-' || n + 1 FROM t;",
+			[]minisql.Statement{
+				{
+					Kind:      minisql.Select,
+					TableName: "t",
+					Fields: []minisql.Field{
+						{
+							Name: "(name || -) || (n + 1)",
+							Expr: &minisql.Expr{
+								Left: &minisql.Expr{
+									Left:  &minisql.Expr{Column: "name"},
+									Right: &minisql.Expr{Literal: minisql.NewTextPointer([]byte("-"))},
+									Op:    minisql.ArithConcat,
+								},
+								Right: &minisql.Expr{
+									Left:  &minisql.Expr{Column: "n"},
+									Right: &minisql.Expr{Literal: int64(1)},
+									Op:    minisql.ArithAdd,
+								},
+								Op: minisql.ArithConcat,
+							},
+						},
+					},
+				},
+			},
+			nil,
+		},
 	}
 
 	for _, aTestCase := range testCases {
@@ -1176,6 +1544,41 @@ func TestParse_SelectScalarFunctions(t *testing.T) {
 			},
 			nil,
 		},
+		{
+			"Registered function calls, including a keyword name",
+			"SELECT double(n) FROM t WHERE my_fn(n, 2) > 3;",
+			[]minisql.Statement{
+				{
+					Kind:      minisql.Select,
+					TableName: "t",
+					Fields: []minisql.Field{
+						{
+							Name: "DOUBLE(n)",
+							Expr: &minisql.Expr{
+								FuncName: "DOUBLE",
+								Args:     []*minisql.Expr{{Column: "n"}},
+							},
+						},
+					},
+					Conditions: minisql.OneOrMore{
+						{
+							{
+								Operand1: minisql.Operand{
+									Type: minisql.OperandExpr,
+									Value: &minisql.Expr{
+										FuncName: "MY_FN",
+										Args:     []*minisql.Expr{{Column: "n"}, {Literal: int64(2)}},
+									},
+								},
+								Operator: minisql.Gt,
+								Operand2: minisql.Operand{Type: minisql.OperandInteger, Value: int64(3)},
+							},
+						},
+					},
+				},
+			},
+			nil,
+		},
 		{
 			"COALESCE with literal fallback",
 			"SELECT COALESCE(score, 0) FROM t;",
@@ -1364,6 +1767,50 @@ func TestParse_SelectCaseWhen(t *testing.T) {
 			},
 			nil,
 		},
+		{
+			"searched CASE in ORDER BY",
+			"SELECT name FROM t ORDER BY CASE WHEN score >= 90 THEN 1 ELSE 0 END DESC, name;",
+			[]minisql.Statement{
+				{
+					Kind:      minisql.Select,
+					TableName: "t",
+					Fields:    []minisql.Field{{Name: "name"}},
+					OrderBy: []minisql.OrderBy{
+						{
+							Field: minisql.Field{
+								Expr: &minisql.Expr{
+									CaseClauses: []minisql.CaseWhen{
+										{
+											Cond: &minisql.ConditionNode{
+												Leaf: &minisql.Condition{
+													Operand1: minisql.Operand{
+														Type:  minisql.OperandField,
+														Value: minisql.Field{Name: "score"},
+													},
+													Operator: minisql.Gte,
+													Operand2: minisql.Operand{
+														Type:  minisql.OperandInteger,
+														Value: int64(90),
+													},
+												},
+											},
+											Then: &minisql.Expr{Literal: int64(1)},
+										},
+									},
+									CaseElse: &minisql.Expr{Literal: int64(0)},
+								},
+							},
+							Direction: minisql.Desc,
+						},
+						{
+							Field:     minisql.Field{Name: "name"},
+							Direction: minisql.Asc,
+						},
+					},
+				},
+			},
+			nil,
+		},
 		{
 			"searched CASE with AS alias",
 			"SELECT CASE WHEN score >= 90 THEN 1 ELSE 0 END AS grade FROM t;",
@@ -61,6 +61,9 @@ func (p *parserItem) doParseCreateTable() error {
 		if !isIdentifier(token) {
 			return p.wrapErr(errCreateTableNoColumns)
 		}
+		if limit := p.limits.maxCreateColumns; limit > 0 && len(p.Columns) >= limit {
+			return p.limitErr("CREATE TABLE defines more than %d columns", limit)
+		}
 		p.Columns = append(p.Columns, minisql.Column{
 			Name: token,
 		})
@@ -194,11 +197,12 @@ func (p *parserItem) doParseCreateTable() error {
 			},
 		})
 		p.pop()
-		// Allow DEFAULT and CHECK to follow UNIQUE.
+		p.parseUniqueNullsDistinct()
+		// Allow DEFAULT, GENERATED and CHECK to follow UNIQUE.
 		p.step = stepCreateTableColumnDefaultValue
 	case stepCreateTableColumnDefaultValue:
 		defaultRWord := p.peek()
-		p.step = stepCreateTableColumnCheck
+		p.step = stepCreateTableColumnOnUpdate
 		if defaultRWord != "DEFAULT" {
 			return nil
 		}
@@ -234,9 +238,44 @@ func (p *parserItem) doParseCreateTable() error {
 			Value: defaultValue,
 			Valid: true,
 		}
+	case stepCreateTableColumnOnUpdate:
+		p.step = stepCreateTableColumnGenerated
+		if strings.ToUpper(p.peek()) != "ON UPDATE" {
+			return nil
+		}
+		p.pop() // consume "ON UPDATE"
+		if err := p.parseOnUpdateNow("CREATE TABLE"); err != nil {
+			return err
+		}
+	case stepCreateTableColumnGenerated:
+		p.step = stepCreateTableColumnCheck
+		if strings.ToUpper(p.peek()) != "GENERATED ALWAYS AS" {
+			return nil
+		}
+		p.pop() // consume "GENERATED ALWAYS AS"
+		if p.peek() != "(" {
+			return p.errorf("at CREATE TABLE: expected '(' after GENERATED ALWAYS AS")
+		}
+		p.pop() // consume "("
+		startPos := p.i
+		expr, err := p.parseExpr()
+		if err != nil {
+			return err
+		}
+		if p.peek() != ")" {
+			return p.errorf("at CREATE TABLE: expected ')' after generation expression")
+		}
+		rawExpr := strings.TrimSpace(p.sql[startPos:p.i])
+		p.pop() // consume ")"
+		if strings.ToUpper(p.peek()) != "STORED" {
+			return p.errorf("at CREATE TABLE: expected STORED after generation expression")
+		}
+		p.pop() // consume "STORED"
+		p.Columns[len(p.Columns)-1].Generated = rawExpr
+		p.Columns[len(p.Columns)-1].GeneratedExpr = expr
 	case stepCreateTableColumnCheck:
 		checkRWord := strings.ToUpper(p.peek())
-		p.step = stepCreateTableColumnFKRef
+		p.step = stepCreateTableColumnMinMax
 		if checkRWord != "CHECK" {
 			return nil
 		}
@@ -257,6 +296,13 @@ func (p *parserItem) doParseCreateTable() error {
 		p.pop() // consume ")"
 		p.Columns[len(p.Columns)-1].Check = rawExpr
 		p.Columns[len(p.Columns)-1].CheckCond = node
+	case stepCreateTableColumnMinMax:
+		p.step = stepCreateTableColumnFKRef
+		if strings.ToUpper(p.peek()) != "MINMAX" {
+			return nil
+		}
+		p.pop() // consume "MINMAX"
+		p.Columns[len(p.Columns)-1].MinMax = true
 	case stepCreateTableConstraint:
 		token := strings.ToUpper(p.peek())
 		switch token {
@@ -307,12 +353,13 @@ func (p *parserItem) doParseCreateTable() error {
 		p.PrimaryKey.Name = minisql.PrimaryKeyName(p.TableName)
 		p.step = stepCreateTableConstraintPrimaryKeyColumn
 	case stepCreateTableConstraintUniqueKey:
+		p.UniqueIndexes = append(p.UniqueIndexes, minisql.UniqueIndex{})
+		p.parseUniqueNullsDistinct()
 		openingParens := p.peek()
 		if len(openingParens) != 1 || openingParens != "(" {
 			return p.wrapErr(errCreateTableExpectedOpeningParens)
 		}
 		p.pop()
-		p.UniqueIndexes = append(p.UniqueIndexes, minisql.UniqueIndex{})
 		p.step = stepCreateTableConstraintUniqueKeyColumn
 	case stepCreateTableConstraintPrimaryKeyColumn:
 		columnName := p.peek()
@@ -513,6 +560,18 @@ func (p *parserItem) doParseCreateTable() error {
 	return nil
 }
 
+// parseUniqueNullsDistinct consumes an optional NULLS [NOT] DISTINCT clause
+// following UNIQUE and records it on the last unique index.
+func (p *parserItem) parseUniqueNullsDistinct() {
+	switch strings.ToUpper(p.peek()) {
+	case "NULLS NOT DISTINCT":
+		p.UniqueIndexes[len(p.UniqueIndexes)-1].NullsNotDistinct = true
+		p.pop()
+	case "NULLS DISTINCT":
+		p.pop()
+	}
+}
+
 // finalizeFKInProgress assigns a name (if not set) and appends fkInProgress to ForeignKeys.
 func (p *parserItem) finalizeFKInProgress() {
 	fk := p.fkInProgress
@@ -537,6 +596,20 @@ func columnNames(columns []minisql.Column) []string {
 	return names
 }
 
+// parseOnUpdateNow parses the NOW() that follows ON UPDATE in a column
+// definition and marks the last defined column as refreshed on every UPDATE.
+func (p *parserItem) parseOnUpdateNow(clause string) error {
+	if strings.ToUpper(p.peek()) != "NOW()" {
+		return p.errorf("at %s: expected NOW() after ON UPDATE", clause)
+	}
+	if p.Columns[len(p.Columns)-1].Kind != minisql.Timestamp {
+		return p.errorf("at %s: ON UPDATE NOW() is only valid for TIMESTAMP columns", clause)
+	}
+	p.pop() // consume "NOW()"
+	p.Columns[len(p.Columns)-1].OnUpdateNow = true
+	return nil
+}
+
 func isDefaultValueValid(column minisql.Column, valueToken any) error {
 	switch column.Kind {
 	case minisql.Boolean:
@@ -571,6 +644,13 @@ func (p *parserItem) doParseDropTable() error {
 		}
 		p.TableName = tableName
 		p.pop()
+		switch strings.ToUpper(p.peek()) {
+		case "CASCADE":
+			p.Cascade = true
+			p.pop()
+		case "RESTRICT":
+			p.pop()
+		}
 		p.step = stepStatementEnd
 	}
 	return nil
@@ -601,6 +681,9 @@ func isColumnDef(token string) (minisql.Column, bool) {
 	case "VECTOR(":
 		return minisql.Column{Kind: minisql.Vector}, true
 	default:
+		if kind, ok := minisql.CustomColumnKind(token); ok {
+			return minisql.Column{Kind: kind}, true
+		}
 		return minisql.Column{}, false
 	}
 }
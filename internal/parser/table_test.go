@@ -459,6 +459,33 @@ func TestParse_CreateTable(t *testing.T) {
 			},
 			nil,
 		},
+		{
+			"CREATE TABLE with unique nulls not distinct index key",
+			`CREATE TABLE foo (
+				email varchar(255) unique nulls not distinct
+			);`,
+			[]minisql.Statement{
+				{
+					Kind:      minisql.CreateTable,
+					TableName: "foo",
+					Columns: []minisql.Column{
+						emailColumn,
+					},
+					UniqueIndexes: []minisql.UniqueIndex{
+						{
+							IndexInfo: minisql.IndexInfo{
+								Name: minisql.UniqueIndexName("foo", emailColumn.Name),
+								Columns: []minisql.Column{
+									emailColumn,
+								},
+							},
+							NullsNotDistinct: true,
+						},
+					},
+				},
+			},
+			nil,
+		},
 		{
 			"CREATE TABLE with both primary key and unique index key",
 			`create table "users" (
@@ -605,6 +632,40 @@ func TestParse_CreateTable(t *testing.T) {
 			},
 			nil,
 		},
+		{
+			"CREATE TABLE with a timestamp column refreshed on update works",
+			"CREATE TABLE foo (created timestamp default now(), updated timestamp not null default now() on update now(), touched timestamp on update now());",
+			[]minisql.Statement{
+				{
+					Kind:      minisql.CreateTable,
+					TableName: "foo",
+					Columns: []minisql.Column{
+						{
+							Name:            "created",
+							Kind:            minisql.Timestamp,
+							Size:            8,
+							Nullable:        true,
+							DefaultValueNow: true,
+						},
+						{
+							Name:            "updated",
+							Kind:            minisql.Timestamp,
+							Size:            8,
+							DefaultValueNow: true,
+							OnUpdateNow:     true,
+						},
+						{
+							Name:        "touched",
+							Kind:        minisql.Timestamp,
+							Size:        8,
+							Nullable:    true,
+							OnUpdateNow: true,
+						},
+					},
+				},
+			},
+			nil,
+		},
 		{
 			"CREATE TABLE with column which part matches reserved word works",
 			"CREATE TABLE foo (id int8 primary key, description text);",
@@ -720,6 +781,53 @@ func TestParse_CreateTable(t *testing.T) {
 			},
 			nil,
 		},
+		{
+			"CREATE TABLE with composite unique nulls not distinct constraint",
+			"CREATE TABLE foo (bar int8, baz int8, unique nulls not distinct (bar, baz));",
+			[]minisql.Statement{
+				{
+					Kind:      minisql.CreateTable,
+					TableName: "foo",
+					Columns: []minisql.Column{
+						{
+							Name:     "bar",
+							Kind:     minisql.Int8,
+							Size:     8,
+							Nullable: true,
+						},
+						{
+							Name:     "baz",
+							Kind:     minisql.Int8,
+							Size:     8,
+							Nullable: true,
+						},
+					},
+					UniqueIndexes: []minisql.UniqueIndex{
+						{
+							IndexInfo: minisql.IndexInfo{
+								Name: minisql.UniqueIndexName("foo", "bar", "baz"),
+								Columns: []minisql.Column{
+									{
+										Name:     "bar",
+										Kind:     minisql.Int8,
+										Size:     8,
+										Nullable: true,
+									},
+									{
+										Name:     "baz",
+										Kind:     minisql.Int8,
+										Size:     8,
+										Nullable: true,
+									},
+								},
+							},
+							NullsNotDistinct: true,
+						},
+					},
+				},
+			},
+			nil,
+		},
 	}
 
 	for _, aTestCase := range testCases {
@@ -756,6 +864,27 @@ func TestParse_DropTable(t *testing.T) {
 				},
 			},
 		},
+		{
+			Name: "DROP TABLE with CASCADE works",
+			SQL:  "DROP TABLE foo CASCADE;",
+			Expected: []minisql.Statement{
+				{
+					Kind:      minisql.DropTable,
+					TableName: "foo",
+					Cascade:   true,
+				},
+			},
+		},
+		{
+			Name: "DROP TABLE with RESTRICT works",
+			SQL:  "drop table foo restrict;",
+			Expected: []minisql.Statement{
+				{
+					Kind:      minisql.DropTable,
+					TableName: "foo",
+				},
+			},
+		},
 	}
 
 	for _, aTestCase := range testCases {
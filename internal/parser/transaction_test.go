@@ -0,0 +1,76 @@
+package parser
+
+import (
+	"context"
+	"testing"
+
+	"github.com/RichardKnop/minisql/internal/minisql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse_Transaction(t *testing.T) {
+	t.Parallel()
+
+	testCases := []testCase{
+		{
+			"BEGIN",
+			"BEGIN;",
+			[]minisql.Statement{{Kind: minisql.BeginTransaction}},
+			nil,
+		},
+		{
+			"BEGIN TRANSACTION",
+			"begin transaction;",
+			[]minisql.Statement{{Kind: minisql.BeginTransaction}},
+			nil,
+		},
+		{
+			"COMMIT",
+			"COMMIT;",
+			[]minisql.Statement{{Kind: minisql.CommitTransaction}},
+			nil,
+		},
+		{
+			"COMMIT TRANSACTION",
+			"COMMIT TRANSACTION;",
+			[]minisql.Statement{{Kind: minisql.CommitTransaction}},
+			nil,
+		},
+		{
+			"ROLLBACK",
+			"ROLLBACK;",
+			[]minisql.Statement{{Kind: minisql.RollbackTransaction}},
+			nil,
+		},
+		{
+			"ROLLBACK TRANSACTION",
+			"ROLLBACK TRANSACTION;",
+			[]minisql.Statement{{Kind: minisql.RollbackTransaction}},
+			nil,
+		},
+		{
+			"BEGIN followed by other statements",
+			"BEGIN; VACUUM; COMMIT;",
+			[]minisql.Statement{
+				{Kind: minisql.BeginTransaction},
+				{Kind: minisql.Vacuum},
+				{Kind: minisql.CommitTransaction},
+			},
+			nil,
+		},
+	}
+
+	for _, aTestCase := range testCases {
+		t.Run(aTestCase.Name, func(t *testing.T) {
+			aStatement, err := New().Parse(context.Background(), aTestCase.SQL)
+			if aTestCase.Err != nil {
+				require.Error(t, err)
+				require.ErrorIs(t, err, aTestCase.Err)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, aTestCase.Expected, aStatement)
+		})
+	}
+}
@@ -12,6 +12,7 @@ var (
 	errUpdateExpectedEquals           = errors.New("at UPDATE: expected '='")
 	errUpdateExpectedQuotedValueOrInt = errors.New("at UPDATE: expected quoted value or int")
 	errNoFieldsToUpdate               = errors.New("at UPDATE: expected at least one field to update")
+	errUpdateFromValuesColumnCount    = errors.New("at UPDATE FROM VALUES: value count doesn't match column count")
 )
 
 func (p *parserItem) doParseUpdate() error {
@@ -85,10 +86,14 @@ func (p *parserItem) doParseUpdate() error {
 				return nil
 			}
 		}
-		specialValue := strings.ToUpper(p.peek())
+		token := p.peek()
+		specialValue := strings.ToUpper(token)
+		if p.isPlaceholder(token) {
+			specialValue = "?"
+		}
 		switch specialValue {
 		case "?":
-			p.setUpdate(p.nextUpdateField, minisql.OptionalValue{Value: minisql.Placeholder{}, Valid: true})
+			p.setUpdate(p.nextUpdateField, minisql.OptionalValue{Value: placeholder(token), Valid: true})
 			p.nextUpdateField = ""
 			p.pop()
 		case "NULL":
@@ -180,8 +185,21 @@ func (p *parserItem) doParseUpdate() error {
 		if next == "(" {
 			// FROM (SELECT …) alias
 			p.pop() // consume "("
+			if strings.ToUpper(p.peek()) == "VALUES" {
+				// FROM (VALUES (…), (…)) [AS] alias (col, …)
+				p.pop()
+				if err := p.parseUpdateFromValues(); err != nil {
+					return err
+				}
+				if strings.ToUpper(p.peek()) == "WHERE" {
+					p.step = stepWhere
+				} else {
+					p.step = stepStatementEnd
+				}
+				return nil
+			}
 			if strings.ToUpper(p.peek()) != "SELECT" {
-				return p.errorf("at UPDATE FROM: expected SELECT inside parentheses")
+				return p.errorf("at UPDATE FROM: expected SELECT or VALUES inside parentheses")
 			}
 			subStmt, err := p.parseSubquery()
 			if err != nil {
@@ -230,6 +248,104 @@ func (p *parserItem) doParseUpdate() error {
 	return nil
 }
 
+// parseUpdateFromValues parses the remainder of an UPDATE … FROM (VALUES …)
+// source after the VALUES keyword: one or more parenthesised literal rows, the
+// closing parenthesis, an optional AS, the alias and its column list.
+func (p *parserItem) parseUpdateFromValues() error {
+	for {
+		if p.peek() != "(" {
+			return p.errorf("at UPDATE FROM VALUES: expected opening parens")
+		}
+		p.pop()
+		var row []minisql.OptionalValue
+		for {
+			value, err := p.parseUpdateFromValue()
+			if err != nil {
+				return err
+			}
+			row = append(row, value)
+			next := p.peek()
+			if next != "," && next != ")" {
+				return p.errorf("at UPDATE FROM VALUES: expected comma or closing parens")
+			}
+			p.pop()
+			if next == ")" {
+				break
+			}
+		}
+		p.UpdateFromValues = append(p.UpdateFromValues, row)
+
+		next := p.peek()
+		if next != "," && next != ")" {
+			return p.errorf("at UPDATE FROM VALUES: expected comma or closing parens")
+		}
+		p.pop()
+		if next == ")" {
+			break
+		}
+	}
+
+	if strings.ToUpper(p.peek()) == "AS" {
+		p.pop()
+	}
+	alias, _ := p.peekIdentifierWithLength()
+	if !isIdentifier(alias) {
+		return p.errorf("at UPDATE FROM VALUES: expected alias after VALUES list")
+	}
+	p.UpdateFromAlias = alias
+	p.pop()
+
+	if p.peek() != "(" {
+		return p.errorf("at UPDATE FROM VALUES: expected column list after alias")
+	}
+	p.pop()
+	for {
+		column, _ := p.peekIdentifierWithLength()
+		if !isIdentifier(column) {
+			return p.errorf("at UPDATE FROM VALUES: expected column name")
+		}
+		p.UpdateFromColumns = append(p.UpdateFromColumns, column)
+		p.pop()
+		next := p.peek()
+		if next != "," && next != ")" {
+			return p.errorf("at UPDATE FROM VALUES: expected comma or closing parens")
+		}
+		p.pop()
+		if next == ")" {
+			break
+		}
+	}
+
+	for _, row := range p.UpdateFromValues {
+		if len(row) != len(p.UpdateFromColumns) {
+			return p.wrapErr(errUpdateFromValuesColumnCount)
+		}
+	}
+	return nil
+}
+
+// parseUpdateFromValue parses a single literal inside an UPDATE … FROM
+// (VALUES …) row. Only literals and NULL are accepted.
+func (p *parserItem) parseUpdateFromValue() (minisql.OptionalValue, error) {
+	token := p.peek()
+	if p.isPlaceholder(token) {
+		return minisql.OptionalValue{}, p.errorf("at UPDATE FROM VALUES: placeholders are not supported")
+	}
+	if strings.ToUpper(token) == "NULL" {
+		p.pop()
+		return minisql.OptionalValue{}, nil
+	}
+	value, ln := p.peekValue()
+	if ln == 0 {
+		return minisql.OptionalValue{}, p.errorf("at UPDATE FROM VALUES: expected value")
+	}
+	p.pop()
+	if strValue, ok := value.(string); ok {
+		return minisql.OptionalValue{Value: minisql.NewTextPointer([]byte(strValue)), Valid: true}, nil
+	}
+	return minisql.OptionalValue{Value: value, Valid: true}, nil
+}
+
 func (p *parserItem) setUpdate(field string, value minisql.OptionalValue) {
 	if p.Updates == nil {
 		p.Updates = make(map[string]minisql.OptionalValue)
@@ -56,6 +56,27 @@ func TestParse_Update(t *testing.T) {
 			nil,
 			errWhereUnknownOperator,
 		},
+		{
+			"UPDATE with quoted strings that look like placeholders works",
+			"UPDATE 'a' SET b = ':x', c = '12:30' WHERE a = ':30';",
+			[]minisql.Statement{
+				{
+					Kind:      minisql.Update,
+					TableName: "a",
+					Fields:    []minisql.Field{{Name: "b"}, {Name: "c"}},
+					Updates: map[string]minisql.OptionalValue{
+						"b": {Value: minisql.NewTextPointer([]byte(":x")), Valid: true},
+						"c": {Value: minisql.NewTextPointer([]byte("12:30")), Valid: true},
+					},
+					Conditions: minisql.OneOrMore{
+						{
+							minisql.FieldIsEqual(minisql.Field{Name: "a"}, minisql.OperandQuotedString, minisql.NewTextPointer([]byte(":30"))),
+						},
+					},
+				},
+			},
+			nil,
+		},
 		{
 			"UPDATE works",
 			"UPDATE 'a' SET b = 'hello' WHERE a = '1';",
@@ -363,3 +384,63 @@ func TestParse_UpdateArithmetic(t *testing.T) {
 		})
 	}
 }
+
+func TestParse_UpdateFromValues(t *testing.T) {
+	t.Parallel()
+
+	t.Run("VALUES list with alias and column list", func(t *testing.T) {
+		t.Parallel()
+
+		stmts, err := New().Parse(context.Background(),
+			`UPDATE users SET email = v.email FROM (VALUES (1, 'a'), (2, NULL), (3, 'c')) AS v(id, email) WHERE users.id = v.id`)
+		require.NoError(t, err)
+		require.Len(t, stmts, 1)
+
+		stmt := stmts[0]
+		assert.Equal(t, minisql.Update, stmt.Kind)
+		assert.Equal(t, "users", stmt.TableName)
+		assert.Equal(t, "v", stmt.UpdateFromAlias)
+		assert.Equal(t, []string{"id", "email"}, stmt.UpdateFromColumns)
+		assert.Equal(t, [][]minisql.OptionalValue{
+			{{Value: int64(1), Valid: true}, {Value: minisql.NewTextPointer([]byte("a")), Valid: true}},
+			{{Value: int64(2), Valid: true}, {}},
+			{{Value: int64(3), Valid: true}, {Value: minisql.NewTextPointer([]byte("c")), Valid: true}},
+		}, stmt.UpdateFromValues)
+		assert.Equal(t, minisql.OneOrMore{
+			{
+				minisql.FieldIsEqual(
+					minisql.Field{AliasPrefix: "users", Name: "id"},
+					minisql.OperandField,
+					minisql.Field{AliasPrefix: "v", Name: "id"},
+				),
+			},
+		}, stmt.Conditions)
+	})
+
+	t.Run("AS is optional", func(t *testing.T) {
+		t.Parallel()
+
+		stmts, err := New().Parse(context.Background(),
+			`UPDATE users SET name = v.name FROM (VALUES (1, 'x')) v(id, name) WHERE users.id = v.id`)
+		require.NoError(t, err)
+		require.Len(t, stmts, 1)
+		assert.Equal(t, "v", stmts[0].UpdateFromAlias)
+		assert.Equal(t, []string{"id", "name"}, stmts[0].UpdateFromColumns)
+	})
+
+	t.Run("value count must match column count", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := New().Parse(context.Background(),
+			`UPDATE users SET name = v.name FROM (VALUES (1, 'x'), (2)) AS v(id, name) WHERE users.id = v.id`)
+		require.ErrorIs(t, err, errUpdateFromValuesColumnCount)
+	})
+
+	t.Run("column list is required", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := New().Parse(context.Background(),
+			`UPDATE users SET name = v.name FROM (VALUES (1, 'x')) AS v WHERE users.id = v.id`)
+		require.Error(t, err)
+	})
+}
@@ -1,7 +1,6 @@
 package parser
 
 import (
-	"context"
 	"errors"
 	"strings"
 
@@ -15,6 +14,8 @@ var (
 	errWhereExpectedPlaceholderOrValue           = errors.New("at WHERE: expected placeholder or value")
 	errWhereExpectedIdentifierPlaceholderOrValue = errors.New("at WHERE: expected identifier, placeholder or value")
 	errWhereUnknownOperator                      = errors.New("at WHERE: unknown operator")
+	errWhereLikeEscapeExpectedString             = errors.New("at WHERE: expected quoted string after ESCAPE")
+	errWhereLikeEscapeRequiresLiteral            = errors.New("at WHERE: ESCAPE requires a quoted string LIKE pattern")
 )
 
 // doParseWhere handles the stepWhere step. It parses the optional WHERE clause
@@ -31,7 +32,7 @@ func (p *parserItem) doParseWhere() error {
 
 	whereRWord := strings.ToUpper(whereOrEnd)
 
-	// GROUP BY / HAVING / ORDER BY / LIMIT / OFFSET / UNION / RETURNING appearing
+	// GROUP BY / HAVING / ORDER BY / LIMIT / OFFSET / FETCH / UNION / RETURNING appearing
 	// before WHERE means no WHERE clause.
 	switch whereRWord {
 	case "GROUP BY":
@@ -40,10 +41,10 @@ func (p *parserItem) doParseWhere() error {
 	case "HAVING":
 		p.step = stepSelectHaving
 		return nil
-	case "ORDER BY", "LIMIT", "OFFSET":
+	case "ORDER BY", "LIMIT", "OFFSET", "FETCH FIRST", "FETCH NEXT":
 		p.step = stepSelectOrderBy
 		return nil
-	case "UNION ALL", "UNION":
+	case "UNION ALL", "UNION", "FOR UPDATE":
 		p.step = stepStatementEnd
 		return nil
 	case "RETURNING":
@@ -80,7 +81,7 @@ func (p *parserItem) doParseWhere() error {
 		p.step = stepSelectGroupBy
 	case "HAVING":
 		p.step = stepSelectHaving
-	case "ORDER BY", "LIMIT", "OFFSET":
+	case "ORDER BY", "LIMIT", "OFFSET", "FETCH FIRST", "FETCH NEXT":
 		p.step = stepSelectOrderBy
 	case "RETURNING":
 		p.pop()
@@ -143,6 +144,10 @@ func (p *parserItem) parseAndExpr() (*minisql.ConditionNode, error) {
 // parsePrimaryCondExpr parses a parenthesised group or a single leaf condition.
 func (p *parserItem) parsePrimaryCondExpr() (*minisql.ConditionNode, error) {
 	if p.peek() == "(" {
+		if err := p.enterCondition(); err != nil {
+			return nil, err
+		}
+		defer p.leaveCondition()
 		p.pop() // consume "("
 		node, err := p.parseCondExpr()
 		if err != nil {
@@ -192,9 +197,10 @@ func (p *parserItem) parseLeafCondition() (*minisql.ConditionNode, error) {
 		return p.parseCondOperatorAndRHS(&cond)
 	}
 
-	// Built-in function call or CAST as WHERE left operand:
+	// Function call or CAST as WHERE left operand:
 	//   LOWER(email) = ?, DATE_TRUNC('month', ts) = ?, CAST(x AS INT8) > 0, etc.
-	if isBuiltinFunction(upperIdent) || upperIdent == "CAST" {
+	_, isFuncCall := p.peekFuncCall()
+	if isBuiltinFunction(upperIdent) || upperIdent == "CAST" || isFuncCall {
 		expr, err := p.parseExpr()
 		if err != nil {
 			return nil, err
@@ -212,18 +218,11 @@ func (p *parserItem) parseLeafCondition() (*minisql.ConditionNode, error) {
 
 	// Handle aggregate function references (HAVING SUM(col) > x, etc.).
 	if aggKind := aggregateKindFromToken(upperIdent); aggKind != 0 {
-		p.pop() // consume e.g. "SUM("
-		colName := p.peek()
-		if !isIdentifier(colName) {
-			return nil, p.errorf("at HAVING: expected column name in %s", strings.TrimSuffix(upperIdent, "("))
-		}
-		p.pop()
-		if p.peek() != ")" {
-			return nil, p.errorf("at HAVING: expected ')' after column name in %s", strings.TrimSuffix(upperIdent, "("))
+		colName, distinct, err := p.parseAggregateArgument("HAVING", upperIdent)
+		if err != nil {
+			return nil, err
 		}
-		p.pop()
-		funcName := strings.TrimSuffix(upperIdent, "(")
-		identifier = funcName + "(" + colName + ")" // e.g. "SUM(total_paid)"
+		identifier = aggregateFieldName(upperIdent, colName, distinct) // e.g. "SUM(total_paid)"
 	} else if upperIdent == "COUNT(*)" {
 		p.pop()
 		identifier = "COUNT(*)"
@@ -258,11 +257,11 @@ func (p *parserItem) parseLeafCondition() (*minisql.ConditionNode, error) {
 	op := strings.ToUpper(p.peek())
 	switch op {
 	case "IS NULL":
-		cond.Operator = minisql.Eq
+		cond.Operator = minisql.IsNull
 		cond.Operand2 = minisql.Operand{Type: minisql.OperandNull}
 		p.pop()
 	case "IS NOT NULL":
-		cond.Operator = minisql.Ne
+		cond.Operator = minisql.IsNotNull
 		cond.Operand2 = minisql.Operand{Type: minisql.OperandNull}
 		p.pop()
 	case "IN (":
@@ -335,12 +334,18 @@ func (p *parserItem) parseLeafCondition() (*minisql.ConditionNode, error) {
 		if err := p.parseCondScalarValue(&cond); err != nil {
 			return nil, err
 		}
+		if err := p.parseLikeEscape(&cond); err != nil {
+			return nil, err
+		}
 	case "NOT LIKE":
 		cond.Operator = minisql.NotLike
 		p.pop()
 		if err := p.parseCondScalarValue(&cond); err != nil {
 			return nil, err
 		}
+		if err := p.parseLikeEscape(&cond); err != nil {
+			return nil, err
+		}
 	default:
 		return nil, p.wrapErr(errWhereUnknownOperator)
 	}
@@ -354,11 +359,11 @@ func (p *parserItem) parseCondOperatorAndRHS(cond *minisql.Condition) (*minisql.
 	op := strings.ToUpper(p.peek())
 	switch op {
 	case "IS NULL":
-		cond.Operator = minisql.Eq
+		cond.Operator = minisql.IsNull
 		cond.Operand2 = minisql.Operand{Type: minisql.OperandNull}
 		p.pop()
 	case "IS NOT NULL":
-		cond.Operator = minisql.Ne
+		cond.Operator = minisql.IsNotNull
 		cond.Operand2 = minisql.Operand{Type: minisql.OperandNull}
 		p.pop()
 	case "=":
@@ -403,12 +408,18 @@ func (p *parserItem) parseCondOperatorAndRHS(cond *minisql.Condition) (*minisql.
 		if err := p.parseCondScalarValue(cond); err != nil {
 			return nil, err
 		}
+		if err := p.parseLikeEscape(cond); err != nil {
+			return nil, err
+		}
 	case "NOT LIKE":
 		cond.Operator = minisql.NotLike
 		p.pop()
 		if err := p.parseCondScalarValue(cond); err != nil {
 			return nil, err
 		}
+		if err := p.parseLikeEscape(cond); err != nil {
+			return nil, err
+		}
 	case "IN (":
 		cond.Operator = minisql.In
 		cond.Operand2 = minisql.Operand{Type: minisql.OperandList, Value: []any{}}
@@ -465,8 +476,11 @@ func (p *parserItem) parseCondScalarValue(cond *minisql.Condition) error {
 		p.pop()
 		return nil
 	}
-	if p.peek() == "?" {
+	if token := p.peek(); p.isPlaceholder(token) {
 		cond.Operand2 = minisql.Operand{Type: minisql.OperandPlaceholder}
+		if ph := placeholder(token); ph.Name != "" {
+			cond.Operand2.Value = ph
+		}
 		p.pop()
 		return nil
 	}
@@ -504,6 +518,44 @@ func (p *parserItem) parseCondScalarValue(cond *minisql.Condition) error {
 	return p.wrapErr(errWhereExpectedIdentifierPlaceholderOrValue)
 }
 
+// parseLikeEscape parses the optional ESCAPE 'c' clause following a LIKE
+// pattern. The pattern is rewritten to use the default backslash escape, so
+// the clause is only accepted after a quoted string pattern.
+func (p *parserItem) parseLikeEscape(cond *minisql.Condition) error {
+	if strings.ToUpper(p.peek()) != "ESCAPE" {
+		return nil
+	}
+	p.pop() // consume ESCAPE
+
+	var escape string
+	if strings.HasPrefix(p.sql[p.i:], `'\'`) {
+		// The lexer treats \' as an escaped quote, so a lone backslash
+		// escape character has to be recognised before peekValue.
+		escape = `\`
+		p.i += len(`'\'`)
+		p.popWhitespace()
+	} else {
+		value, ln := p.peekQuotedStringWithLength()
+		if ln == 0 {
+			return p.wrapErr(errWhereLikeEscapeExpectedString)
+		}
+		escape = value
+		p.i += ln
+		p.popWhitespace()
+	}
+
+	pattern, ok := cond.Operand2.Value.(minisql.TextPointer)
+	if cond.Operand2.Type != minisql.OperandQuotedString || !ok {
+		return p.wrapErr(errWhereLikeEscapeRequiresLiteral)
+	}
+	rewritten, err := minisql.LikePatternWithEscape(pattern.String(), escape)
+	if err != nil {
+		return p.wrapErr(err)
+	}
+	cond.Operand2.Value = minisql.NewTextPointer([]byte(rewritten))
+	return nil
+}
+
 // parseSubquery extracts the SQL from p.i to the matching closing paren,
 // parses it as a SELECT statement, and returns the parsed *Statement.
 // p.i must be positioned at the start of the SELECT keyword when called.
@@ -539,7 +591,21 @@ func (p *parserItem) parseSubquery() (*minisql.Statement, error) {
 	p.i = scanI + 1 // skip ")"
 	p.popWhitespace()
 
-	stmts, err := New().Parse(context.Background(), subSQL)
+	// The subquery counts as one more level of condition nesting.
+	rest := &parserItem{
+		sql:       subSQL,
+		upperSQL:  strings.ToUpper(subSQL),
+		step:      stepBeginning,
+		limits:    p.limits,
+		condDepth: p.condDepth,
+	}
+	if err := rest.enterCondition(); err != nil {
+		return nil, err
+	}
+	stmts, err := rest.doParse()
+	if errors.Is(err, errParseLimitExceeded) {
+		return nil, err
+	}
 	if err != nil {
 		return nil, p.errorf("at WHERE: subquery parse error: %v", err)
 	}
@@ -564,6 +630,9 @@ func (p *parserItem) parseCondListValues(cond *minisql.Condition) error {
 	}
 
 	for {
+		if limit := p.limits.maxINListSize; limit > 0 && len(cond.Operand2.Value.([]any)) >= limit {
+			return p.limitErr("IN list has more than %d values", limit)
+		}
 		value, ln := p.peekValue()
 		switch {
 		case ln != 0:
@@ -573,9 +642,8 @@ func (p *parserItem) parseCondListValues(cond *minisql.Condition) error {
 			}
 			cond.Operand2.Value = append(cond.Operand2.Value.([]any), v)
 			p.pop()
-		case p.peek() == "?":
-			cond.Operand2.Value = append(cond.Operand2.Value.([]any), minisql.Placeholder{})
-			p.pop()
+		case p.isPlaceholder(p.peek()):
+			cond.Operand2.Value = append(cond.Operand2.Value.([]any), placeholder(p.pop()))
 		default:
 			return p.wrapErr(errWhereExpectedPlaceholderOrValue)
 		}
@@ -607,9 +675,8 @@ func (p *parserItem) parseCondBetweenValues(cond *minisql.Condition) error {
 		}
 		cond.Operand2.Value = append(cond.Operand2.Value.([]any), v)
 		p.pop()
-	case p.peek() == "?":
-		cond.Operand2.Value = append(cond.Operand2.Value.([]any), minisql.Placeholder{})
-		p.pop()
+	case p.isPlaceholder(p.peek()):
+		cond.Operand2.Value = append(cond.Operand2.Value.([]any), placeholder(p.pop()))
 	default:
 		return p.errorf("at WHERE BETWEEN: expected value or placeholder for lower bound")
 	}
@@ -630,9 +697,8 @@ func (p *parserItem) parseCondBetweenValues(cond *minisql.Condition) error {
 		}
 		cond.Operand2.Value = append(cond.Operand2.Value.([]any), v)
 		p.pop()
-	case p.peek() == "?":
-		cond.Operand2.Value = append(cond.Operand2.Value.([]any), minisql.Placeholder{})
-		p.pop()
+	case p.isPlaceholder(p.peek()):
+		cond.Operand2.Value = append(cond.Operand2.Value.([]any), placeholder(p.pop()))
 	default:
 		return p.errorf("at WHERE BETWEEN: expected value or placeholder for upper bound")
 	}
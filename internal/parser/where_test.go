@@ -282,6 +282,45 @@ func TestParse_Where(t *testing.T) {
 			},
 			nil,
 		},
+		{
+			"WHERE with LIKE and backslash ESCAPE",
+			`WHERE name LIKE '100\%' ESCAPE '\'`,
+			minisql.OneOrMore{
+				{
+					minisql.FieldIsLike(minisql.Field{Name: "name"}, minisql.OperandQuotedString, minisql.NewTextPointer([]byte(`100\%`))),
+				},
+			},
+			nil,
+		},
+		{
+			"WHERE with NOT LIKE and custom ESCAPE",
+			"WHERE code NOT LIKE 'a!_%' ESCAPE '!' AND id = 1",
+			minisql.OneOrMore{
+				{
+					minisql.FieldIsNotLike(minisql.Field{Name: "code"}, minisql.OperandQuotedString, minisql.NewTextPointer([]byte(`a\_%`))),
+					minisql.FieldIsEqual(minisql.Field{Name: "id"}, minisql.OperandInteger, int64(1)),
+				},
+			},
+			nil,
+		},
+		{
+			"WHERE with LIKE and multi-character ESCAPE",
+			"WHERE name LIKE 'a%' ESCAPE '!!'",
+			nil,
+			minisql.ErrInvalidLikeEscape,
+		},
+		{
+			"WHERE with LIKE placeholder and ESCAPE",
+			"WHERE name LIKE ? ESCAPE '!'",
+			nil,
+			errWhereLikeEscapeRequiresLiteral,
+		},
+		{
+			"WHERE with LIKE and unquoted ESCAPE",
+			"WHERE name LIKE 'a%' ESCAPE x",
+			nil,
+			errWhereLikeEscapeExpectedString,
+		},
 		{
 			"WHERE with LIKE combined with AND",
 			"WHERE name LIKE 'foo%' AND email LIKE '%@example.com'",
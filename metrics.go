@@ -11,7 +11,7 @@ import (
 // Gauge fields (Size, CurrentFrames) reflect the instantaneous state.
 type Metrics struct {
 	// PageCache reflects the LRU page cache (controlled by max_cached_pages).
-	// HitRate = PageCacheHits / (PageCacheHits + PageCacheMisses).
+	// See PageCacheHitRatio.
 	PageCacheHits      int64 // requests served from cache
 	PageCacheMisses    int64 // requests that required a WAL or disk read
 	PageCacheEvictions int64 // pages removed to make room for new ones
@@ -32,10 +32,21 @@ type Metrics struct {
 	QueriesTotal int64 // cumulative calls since open
 	QueriesSlow  int64 // calls that exceeded slow_query_threshold
 
+	// QueryCache reflects the SELECT result cache (controlled by query_cache).
+	QueryCacheHits   int64 // SELECTs answered from the cache
+	QueryCacheMisses int64 // cacheable SELECTs that had to run
+
+	// StmtCache reflects the parsed statement cache (controlled by statement_cache).
+	StmtCacheHits   int64 // queries that skipped the parser
+	StmtCacheMisses int64 // queries that had to be parsed
+
 	// Sort reflects ORDER BY behaviour.
 	SortsInMemory  int64 // ORDER BY completed entirely in memory
 	SortSpillRuns  int64 // cumulative run files written to disk for external merge sort
 	SortSpillBytes int64 // cumulative bytes written to run files
+
+	// AutoVacuums counts VACUUM runs triggered by the auto_vacuum free-page ratio.
+	AutoVacuums int64
 }
 
 // ReadMetrics returns a point-in-time snapshot of engine statistics for db.
@@ -45,7 +56,7 @@ type Metrics struct {
 //
 //	m, err := minisql.ReadMetrics(ctx, db)
 //	if err != nil { ... }
-//	hitRate := float64(m.PageCacheHits) / float64(m.PageCacheHits + m.PageCacheMisses)
+//	hitRatio := m.PageCacheHitRatio()
 func ReadMetrics(ctx context.Context, db *sql.DB) (Metrics, error) {
 	conn, err := db.Conn(ctx)
 	if err != nil {
@@ -65,6 +76,18 @@ func ReadMetrics(ctx context.Context, db *sql.DB) (Metrics, error) {
 	return m, err
 }
 
+// PageCacheHitRatio returns the fraction of page requests served from the
+// page cache, PageCacheHits / (PageCacheHits + PageCacheMisses), between 0
+// and 1. It returns 0 before any page has been requested. A low ratio on a
+// read-heavy workload means max_cached_pages is too small for the working set.
+func (m Metrics) PageCacheHitRatio() float64 {
+	total := m.PageCacheHits + m.PageCacheMisses
+	if total == 0 {
+		return 0
+	}
+	return float64(m.PageCacheHits) / float64(total)
+}
+
 // readMetrics takes a snapshot from the engine metrics store.
 func (c *Conn) readMetrics() Metrics {
 	s := c.db.ReadEngineMetrics()
@@ -81,8 +104,13 @@ func (c *Conn) readMetrics() Metrics {
 		TxRollbacks:        s.TxRollbacks,
 		QueriesTotal:       s.QueriesTotal,
 		QueriesSlow:        s.QueriesSlow,
+		QueryCacheHits:     s.QueryCacheHits,
+		QueryCacheMisses:   s.QueryCacheMisses,
+		StmtCacheHits:      s.StmtCacheHits,
+		StmtCacheMisses:    s.StmtCacheMisses,
 		SortsInMemory:      s.SortsInMemory,
 		SortSpillRuns:      s.SortSpillRuns,
 		SortSpillBytes:     s.SortSpillBytes,
+		AutoVacuums:        s.AutoVacuums,
 	}
 }
@@ -19,6 +19,9 @@ import (
 
 const (
 	driverName = "minisql"
+	// queryLogZap is the query_log connection parameter value that routes the
+	// query log through the driver's zap logger instead of a file.
+	queryLogZap = "zap"
 )
 
 func init() {
@@ -30,12 +33,22 @@ func init() {
 // use SetMaxOpenConns(1) on the *sql.DB.
 var ErrDatabaseAlreadyOpen = errors.New("database file is already open: use SetMaxOpenConns(1)")
 
+// MemoryPath opens an ephemeral in-memory database when used as the connection
+// string path. Each connection gets its own private database, so pair it with
+// SetMaxOpenConns(1).
+const MemoryPath = minisql.MemoryPath
+
+// ErrInMemoryDatabase is returned by operations that need a file on disk, such
+// as VACUUM and Backup, on an in-memory database.
+var ErrInMemoryDatabase = minisql.ErrInMemoryDatabase
+
 // Driver implements the database/sql/driver.Driver interface.
 type Driver struct {
 	mu        sync.Mutex
 	parser    minisql.Parser
 	logger    *zap.Logger
 	openFiles map[string]bool
+	connCount uint64
 }
 
 // Open returns a new connection to the database.
@@ -44,6 +57,7 @@ type Driver struct {
 //   - "./my.db?wal_checkpoint_threshold=500" - auto-checkpoint after 500 WAL frames
 //   - "./my.db?log_level=debug" - enable debug logging
 //   - "./my.db?wal_checkpoint_threshold=500&log_level=info" - multiple parameters
+//   - ":memory:" - ephemeral in-memory database, dropped when the connection closes
 func (d *Driver) Open(name string) (driver.Conn, error) {
 	d.mu.Lock()
 	defer d.mu.Unlock()
@@ -61,10 +75,15 @@ func (d *Driver) Open(name string) (driver.Conn, error) {
 	if d.openFiles == nil {
 		d.openFiles = make(map[string]bool)
 	}
-	if d.openFiles[config.FilePath] {
+	// In-memory databases are private to their connection, so any number of
+	// them may be open at once.
+	inMemory := config.FilePath == minisql.MemoryPath
+	if !inMemory && d.openFiles[config.FilePath] {
 		return nil, fmt.Errorf("%w: %s", ErrDatabaseAlreadyOpen, config.FilePath)
 	}
-	d.openFiles[config.FilePath] = true
+	if !inMemory {
+		d.openFiles[config.FilePath] = true
+	}
 
 	// Initialize logger if not set
 	if d.logger == nil {
@@ -82,19 +101,39 @@ func (d *Driver) Open(name string) (driver.Conn, error) {
 		d.parser = parser.New()
 	}
 
-	db, err := d.newDB(config)
+	var queryLogFile *os.File
+	if config.QueryLog != "" && config.QueryLog != queryLogZap {
+		queryLogFile, err = os.OpenFile(config.QueryLog, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o600)
+		if err != nil {
+			delete(d.openFiles, config.FilePath)
+			return nil, fmt.Errorf("failed to open query log: %w", err)
+		}
+	}
+
+	var queryLog io.Writer
+	if queryLogFile != nil {
+		queryLog = queryLogFile
+	}
+	db, err := d.newDB(config, queryLog)
 	if err != nil {
+		if queryLogFile != nil {
+			_ = queryLogFile.Close()
+		}
 		delete(d.openFiles, config.FilePath)
 		return nil, fmt.Errorf("failed to open database: %w", err)
 	}
 
+	d.connCount++
 	filePath := config.FilePath
 	return &Conn{
 		db:                 db,
-		parser:             d.parser,
 		logger:             d.logger,
+		clientID:           fmt.Sprintf("conn-%d", d.connCount),
 		slowQueryThreshold: config.SlowQueryThreshold,
 		closeFunc: func() {
+			if queryLogFile != nil {
+				_ = queryLogFile.Close()
+			}
 			d.mu.Lock()
 			delete(d.openFiles, filePath)
 			d.mu.Unlock()
@@ -102,14 +141,30 @@ func (d *Driver) Open(name string) (driver.Conn, error) {
 	}, nil
 }
 
-func (d *Driver) newDB(config *ConnectionConfig) (*minisql.Database, error) {
+func (d *Driver) newDB(config *ConnectionConfig, queryLog io.Writer) (*minisql.Database, error) {
+	dbOpts := databaseOptions(config, queryLog)
+
+	// An in-memory database has no WAL: there is nothing to recover after a
+	// crash, and committed pages go straight to the in-memory page store.
+	if config.FilePath == minisql.MemoryPath {
+		pager, err := minisql.NewMemoryPager(minisql.PageSize, config.MaxCachedPages)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create pager: %w", err)
+		}
+		return minisql.NewDatabase(context.Background(), d.logger, config.FilePath, d.parser, pager, pager, nil, dbOpts...)
+	}
+
 	// Open or create database file
 	dbFile, err := os.OpenFile(config.FilePath, os.O_RDWR|os.O_CREATE, 0o600)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open database file: %w", err)
 	}
 
-	pager, err := minisql.NewPager(dbFile, minisql.PageSize, config.MaxCachedPages)
+	var pagerOpts []minisql.PagerOption
+	if config.GrowChunkPages > 0 {
+		pagerOpts = append(pagerOpts, minisql.WithGrowChunkPages(config.GrowChunkPages))
+	}
+	pager, err := minisql.NewPager(dbFile, minisql.PageSize, config.MaxCachedPages, pagerOpts...)
 	if err != nil {
 		_ = dbFile.Close()
 		return nil, fmt.Errorf("failed to create pager: %w", err)
@@ -128,16 +183,6 @@ func (d *Driver) newDB(config *ConnectionConfig) (*minisql.Database, error) {
 			zap.Int("frames_in_index", walIndex.Size()))
 	}
 
-	dbOpts := []minisql.DatabaseOption{}
-	if config.ParallelScan {
-		dbOpts = append(dbOpts, minisql.WithParallelScanEnabled())
-	}
-	if len(config.EncryptionKey) > 0 {
-		dbOpts = append(dbOpts, minisql.WithEncryptionKey(config.EncryptionKey))
-	}
-	dbOpts = append(dbOpts, minisql.WithSortMemLimit(config.SortMemLimit))
-	dbOpts = append(dbOpts, minisql.WithHNSWVecCacheSize(config.HNSWVecCacheSize))
-
 	return minisql.NewDatabase(
 		context.Background(),
 		d.logger,
@@ -148,7 +193,7 @@ func (d *Driver) newDB(config *ConnectionConfig) (*minisql.Database, error) {
 		&minisql.WALConfig{
 			WAL:                 wal,
 			Index:               walIndex,
-			DBFile:              dbFile,
+			DBFile:              pager.File(),
 			CheckpointThreshold: config.WALCheckpointThreshold,
 			WALWriteBufferSize:  config.WALWriteBufferSize,
 			Synchronous:         config.Synchronous,
@@ -157,15 +202,76 @@ func (d *Driver) newDB(config *ConnectionConfig) (*minisql.Database, error) {
 	)
 }
 
+// databaseOptions translates the connection string settings into options for
+// minisql.NewDatabase.
+func databaseOptions(config *ConnectionConfig, queryLog io.Writer) []minisql.DatabaseOption {
+	dbOpts := []minisql.DatabaseOption{}
+	if config.ParallelScan {
+		dbOpts = append(dbOpts, minisql.WithParallelScanEnabled())
+	}
+	if config.SafeMode {
+		dbOpts = append(dbOpts, minisql.WithSafeMode(true))
+	}
+	if config.IntegerOverflow != IntegerOverflowError {
+		dbOpts = append(dbOpts, minisql.WithIntegerOverflow(config.IntegerOverflow))
+	}
+	if config.InsertBatchSize > 0 {
+		dbOpts = append(dbOpts, minisql.WithInsertBatchSize(config.InsertBatchSize))
+	}
+	if config.InsertBatchCommit {
+		dbOpts = append(dbOpts, minisql.WithInsertBatchCommit())
+	}
+	if config.MaxIdentifierLength > 0 {
+		dbOpts = append(dbOpts, minisql.WithMaxIdentifierLength(config.MaxIdentifierLength))
+	}
+	if config.MaxSubqueryRows > 0 {
+		dbOpts = append(dbOpts, minisql.WithMaxSubqueryRows(config.MaxSubqueryRows))
+	}
+	if config.QueryTimeout > 0 {
+		dbOpts = append(dbOpts, minisql.WithQueryTimeout(config.QueryTimeout))
+	}
+	if config.AutoVacuumThreshold > 0 {
+		dbOpts = append(dbOpts, minisql.WithAutoVacuum(config.AutoVacuumThreshold))
+	}
+	if config.QueryCacheSize > 0 {
+		dbOpts = append(dbOpts, minisql.WithQueryCache(config.QueryCacheSize))
+	}
+	if config.StatementCacheSize > 0 {
+		dbOpts = append(dbOpts, minisql.WithMaxCachedStatements(config.StatementCacheSize))
+	}
+	if config.CheckpointInterval > 0 {
+		dbOpts = append(dbOpts, minisql.WithCheckpointInterval(config.CheckpointInterval))
+	}
+	if config.QueryLog == queryLogZap {
+		dbOpts = append(dbOpts, minisql.WithZapQueryLog())
+	} else if queryLog != nil {
+		dbOpts = append(dbOpts, minisql.WithQueryLog(queryLog))
+	}
+	if config.QueryLogRedact {
+		dbOpts = append(dbOpts, minisql.WithQueryLogRedaction(true))
+	}
+	if len(config.EncryptionKey) > 0 {
+		dbOpts = append(dbOpts, minisql.WithEncryptionKey(config.EncryptionKey))
+	}
+	dbOpts = append(dbOpts, minisql.WithSortMemLimit(config.SortMemLimit))
+	dbOpts = append(dbOpts, minisql.WithHNSWVecCacheSize(config.HNSWVecCacheSize))
+
+	return dbOpts
+}
+
 // Conn implements the database/sql/driver.Conn interface.
 type Conn struct {
 	db                 *minisql.Database
-	parser             minisql.Parser
 	transaction        *minisql.Transaction
 	logger             *zap.Logger
 	closeFunc          func()
+	clientID           string // identifies this connection in query log entries
 	mu                 sync.RWMutex
 	slowQueryThreshold time.Duration
+	// sqlTransaction is set when transaction was started by a BEGIN
+	// statement rather than by BeginTx, so only COMMIT or ROLLBACK
+	// statements may end it.
+	sqlTransaction bool
 	// txCtx cache: avoids calling WithTransaction on every row within an explicit transaction.
 	lastExecBaseCtx context.Context
 	lastExecTxCtx   context.Context
@@ -187,12 +293,15 @@ func (c *Conn) Ping(ctx context.Context) error {
 // Close flushes the WAL write-buffer, releases the page cache, and marks
 // the database file as available for a new connection. It is called
 // automatically by database/sql when the connection is returned to the pool.
+// A transaction still open on the connection is rolled back.
 func (c *Conn) Close() error {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
 	if c.transaction != nil {
+		c.db.GetTransactionManager().RollbackTransaction(context.Background(), c.transaction)
 		c.transaction = nil
+		c.sqlTransaction = false
 	}
 
 	err := c.db.Close()
@@ -239,9 +348,17 @@ func (c *Conn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, e
 		return nil, fmt.Errorf("transaction already in progress")
 	}
 
-	tx, err := c.db.GetTransactionManager().BeginTransaction(ctx)
-	if err != nil {
-		return nil, err
+	var tx *minisql.Transaction
+	if opts.ReadOnly {
+		// A read-only transaction reads from a snapshot and does not block
+		// writers; writes inside it fail with ErrReadOnlyTransaction.
+		tx = c.db.GetTransactionManager().BeginReadOnlyTransaction(ctx)
+	} else {
+		var err error
+		tx, err = c.db.GetTransactionManager().BeginTransaction(ctx)
+		if err != nil {
+			return nil, err
+		}
 	}
 	c.transaction = tx
 
@@ -263,14 +380,16 @@ func (c *Conn) ExecContext(ctx context.Context, query string, args []driver.Name
 			c.logSlowQuery(query, elapsed, err)
 		}
 	}()
+	ctx = c.queryLogContext(ctx, query, args)
 
-	statements, err := c.parser.Parse(ctx, query)
+	statements, err := c.db.PrepareStatements(ctx, query)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse query: %w", err)
 	}
 
 	if len(statements) == 0 {
-		return nil, fmt.Errorf("no statements in query")
+		// Only comments and empty statements; there is nothing to run.
+		return Result{}, nil
 	}
 
 	internalArgs, err := toInternalArgs(args)
@@ -278,11 +397,21 @@ func (c *Conn) ExecContext(ctx context.Context, query string, args []driver.Name
 		return nil, err
 	}
 
+	named, err := namedArgs(args)
+	if err != nil {
+		return nil, err
+	}
+
 	var totalRowsAffected int64
 	var lastInsertID int64
 
 	for _, stmt := range statements {
-		if len(internalArgs) > 0 {
+		if named != nil {
+			stmt, err = stmt.BindNamed(named)
+			if err != nil {
+				return nil, err
+			}
+		} else if len(internalArgs) > 0 {
 			stmt, err = stmt.BindArguments(internalArgs...)
 			if err != nil {
 				return nil, err
@@ -312,14 +441,16 @@ func (c *Conn) QueryContext(ctx context.Context, query string, args []driver.Nam
 			c.logSlowQuery(query, elapsed, err)
 		}
 	}()
+	ctx = c.queryLogContext(ctx, query, args)
 
-	statements, err := c.parser.Parse(ctx, query)
+	statements, err := c.db.PrepareStatements(ctx, query)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse query: %w", err)
 	}
 
 	if len(statements) == 0 {
-		return nil, fmt.Errorf("no statements in query")
+		// Only comments and empty statements: an empty result.
+		return &Rows{iter: minisql.NewSliceIterator(nil), ctx: ctx}, nil
 	}
 
 	if len(statements) > 1 {
@@ -331,14 +462,35 @@ func (c *Conn) QueryContext(ctx context.Context, query string, args []driver.Nam
 		return nil, err
 	}
 
+	named, err := namedArgs(args)
+	if err != nil {
+		return nil, err
+	}
+
 	stmt := statements[0]
-	if len(internalArgs) > 0 {
+	if named != nil {
+		stmt, err = stmt.BindNamed(named)
+		if err != nil {
+			return nil, err
+		}
+	} else if len(internalArgs) > 0 {
 		stmt, err = stmt.BindArguments(internalArgs...)
 		if err != nil {
 			return nil, err
 		}
 	}
 
+	mrows, err := c.queryStatement(ctx, stmt)
+	if err != nil {
+		return nil, err
+	}
+	return mrows, nil
+}
+
+// queryStatement executes a bound statement and returns its rows. A read-only
+// snapshot transaction opened for a SELECT is released when the rows are
+// closed.
+func (c *Conn) queryStatement(ctx context.Context, stmt minisql.Statement) (*Rows, error) {
 	result, rowsCtx, readTx, err := c.executeQueryStatement(ctx, stmt)
 	if err != nil {
 		return nil, err
@@ -366,6 +518,7 @@ func (c *Conn) SetTransaction(tx *minisql.Transaction) {
 	defer c.mu.Unlock()
 	c.transaction = tx
 	if tx == nil {
+		c.sqlTransaction = false
 		c.lastExecBaseCtx = nil
 		c.lastExecTxCtx = nil
 	}
@@ -406,7 +559,7 @@ func (c *Conn) TransactionContext(ctx context.Context) context.Context {
 }
 
 func (c *Conn) executeQueryStatement(ctx context.Context, stmt minisql.Statement) (minisql.StatementResult, context.Context, *minisql.Transaction, error) {
-	if c.HasActiveTransaction() || (stmt.Kind != minisql.Select && stmt.Kind != minisql.Explain) {
+	if c.HasActiveTransaction() || stmt.ForUpdate || (stmt.Kind != minisql.Select && stmt.Kind != minisql.Explain) {
 		result, err := c.executeStatement(ctx, stmt)
 		// When there is an active write transaction, the row view iterator
 		// fetches rows lazily using the returned context. Passing the
@@ -442,6 +595,11 @@ func (c *Conn) executeQueryStatement(ctx context.Context, stmt minisql.Statement
 }
 
 func (c *Conn) executeStatement(ctx context.Context, stmt minisql.Statement) (minisql.StatementResult, error) {
+	switch stmt.Kind {
+	case minisql.BeginTransaction, minisql.CommitTransaction, minisql.RollbackTransaction:
+		return minisql.StatementResult{}, c.executeTransactionStatement(ctx, stmt.Kind)
+	}
+
 	if c.HasActiveTransaction() {
 		if ctx != c.lastExecBaseCtx {
 			c.lastExecBaseCtx = ctx
@@ -450,23 +608,75 @@ func (c *Conn) executeStatement(ctx context.Context, stmt minisql.Statement) (mi
 		return c.db.ExecuteStatement(c.lastExecTxCtx, stmt)
 	}
 
-	// Execute in auto-commit transaction.  Use a read-only transaction for
-	// SELECT statements so that per-page read tracking is skipped entirely,
-	// eliminating per-page map writes and mutex acquisitions.
-	var result minisql.StatementResult
-	txFn := func(txCtx context.Context) error {
-		var err error
-		result, err = c.db.ExecuteStatement(txCtx, stmt)
-		return err
+	return c.db.ExecuteAutoCommit(ctx, stmt)
+}
+
+// executeTransactionStatement handles BEGIN, COMMIT and ROLLBACK statements.
+// BEGIN opens a write transaction that stays on the connection, so every
+// following statement runs in it until COMMIT or ROLLBACK ends it. A failed
+// COMMIT rolls the transaction back. Transactions started with BeginTx must
+// be ended through the returned driver.Tx instead.
+func (c *Conn) executeTransactionStatement(ctx context.Context, kind minisql.StatementKind) error {
+	c.mu.Lock()
+	tx, sqlTransaction := c.transaction, c.sqlTransaction
+	c.mu.Unlock()
+
+	txManager := c.db.GetTransactionManager()
+	if kind == minisql.BeginTransaction {
+		if tx != nil {
+			return fmt.Errorf("transaction already in progress")
+		}
+		tx, err := txManager.BeginTransaction(ctx)
+		if err != nil {
+			return err
+		}
+		c.SetTransaction(tx)
+		c.mu.Lock()
+		c.sqlTransaction = true
+		c.mu.Unlock()
+		return nil
 	}
-	var err error
-	if stmt.Kind == minisql.Select || stmt.Kind == minisql.Explain {
-		err = c.db.GetTransactionManager().ExecuteReadOnlyTransaction(ctx, txFn)
-	} else {
-		err = c.db.GetTransactionManager().ExecuteInTransaction(ctx, txFn)
+
+	if tx == nil {
+		return fmt.Errorf("no transaction in progress")
+	}
+	if !sqlTransaction {
+		return fmt.Errorf("%s: transaction was started with BeginTx; use Tx.Commit or Tx.Rollback", kind)
 	}
 
-	return result, err
+	txCtx := minisql.WithTransaction(ctx, tx)
+	if kind == minisql.RollbackTransaction {
+		txManager.RollbackTransaction(txCtx, tx)
+		c.SetTransaction(nil)
+		return nil
+	}
+
+	if err := txManager.CommitTransaction(txCtx, tx); err != nil {
+		txManager.RollbackTransaction(txCtx, tx)
+		c.SetTransaction(nil)
+		return err
+	}
+	c.SetTransaction(nil)
+	c.db.AutoVacuumAfterCommit(ctx)
+	return nil
+}
+
+// queryLogContext attaches the SQL text, bound arguments and connection id
+// for the engine's query log and query cache. ctx is returned unchanged when
+// neither is configured, so the common path allocates nothing.
+func (c *Conn) queryLogContext(ctx context.Context, query string, args []driver.NamedValue) context.Context {
+	if !c.db.QueryLogEnabled() && !c.db.QueryCacheEnabled() {
+		return ctx
+	}
+	values := make([]any, len(args))
+	for i, arg := range args {
+		values[i] = arg.Value
+	}
+	return minisql.WithQueryLogInfo(ctx, minisql.QueryLogInfo{
+		SQL:    query,
+		Args:   values,
+		Client: c.clientID,
+	})
 }
 
 func (c *Conn) logSlowQuery(query string, elapsed time.Duration, err error) {
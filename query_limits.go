@@ -0,0 +1,32 @@
+package minisql
+
+import (
+	"context"
+
+	"github.com/RichardKnop/minisql/internal/minisql"
+)
+
+// QueryLimits re-exports the internal type so callers can set per-query
+// resource limits without importing the internal package. A zero field means
+// no limit.
+type QueryLimits = minisql.QueryLimits
+
+// Errors returned when a statement exceeds its QueryLimits.
+var (
+	ErrQueryRowLimitExceeded    = minisql.ErrQueryRowLimitExceeded
+	ErrQueryTimeLimitExceeded   = minisql.ErrQueryTimeLimitExceeded
+	ErrQueryMemoryLimitExceeded = minisql.ErrQueryMemoryLimitExceeded
+)
+
+// WithQueryLimits returns a context that applies limits to every statement
+// executed with it, for example:
+//
+//	ctx := minisql.WithQueryLimits(ctx, minisql.QueryLimits{
+//		MaxRows:     1000,
+//		MaxDuration: 2 * time.Second,
+//		MaxMemory:   16 << 20,
+//	})
+//	rows, err := db.QueryContext(ctx, "SELECT * FROM events ORDER BY created_at")
+func WithQueryLimits(ctx context.Context, limits QueryLimits) context.Context {
+	return minisql.WithQueryLimits(ctx, limits)
+}
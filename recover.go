@@ -0,0 +1,34 @@
+package minisql
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	"github.com/RichardKnop/minisql/internal/minisql"
+	"github.com/RichardKnop/minisql/internal/parser"
+)
+
+// RecoveryReport lists the tables Recover registered and the root pages it
+// had to skip.
+type RecoveryReport = minisql.RecoveryReport
+
+// Recover is a best-effort repair for a database file whose schema table is
+// corrupted. It scans all pages for table B+ tree roots and writes a new
+// schema table registering each one as recovered_<root page>, with column
+// names c1, c2, ... and kinds inferred from the stored rows:
+//
+//	report, err := minisql.Recover(ctx, "/path/to/broken.db")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	for _, table := range report.Tables {
+//	    fmt.Println(table.Name, table.Rows)
+//	}
+//
+// Constraints and indexes are not recovered, so copy the data into a fresh
+// database once it is readable again. Take a copy of the file first: Recover
+// rewrites page 0 in place. The database must not be open while Recover runs.
+func Recover(ctx context.Context, path string) (RecoveryReport, error) {
+	return minisql.Recover(ctx, zap.NewNop(), path, parser.New())
+}
@@ -5,6 +5,7 @@ package minisql
 type Result struct {
 	rowsAffected int64
 	lastInsertID int64
+	affectedKeys [][]any
 }
 
 // LastInsertId returns the auto-generated primary key of the last inserted row.
@@ -20,3 +21,10 @@ func (r Result) LastInsertId() (int64, error) {
 func (r Result) RowsAffected() (int64, error) {
 	return r.rowsAffected, nil
 }
+
+// AffectedKeys returns the key of every row modified by an UPDATE or DELETE
+// built with ReturningKeys and run with ExecStatement, in the format of the
+// AffectedKeys function. It is nil for other statements.
+func (r Result) AffectedKeys() [][]any {
+	return r.affectedKeys
+}
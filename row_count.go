@@ -0,0 +1,35 @@
+package minisql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// RowCount returns the number of rows in table. The engine keeps a running
+// count for every table, so this does not scan the table. db must have been
+// opened with sql.Open("minisql", dsn).
+//
+// Example:
+//
+//	n, err := minisql.RowCount(ctx, db, "users")
+//	if err != nil { ... }
+func RowCount(ctx context.Context, db *sql.DB, table string) (int64, error) {
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("minisql: RowCount: acquire connection: %w", err)
+	}
+	defer conn.Close()
+
+	var count int64
+	err = conn.Raw(func(c any) error {
+		mc, ok := c.(*Conn)
+		if !ok {
+			return fmt.Errorf("minisql: RowCount: unexpected connection type %T", c)
+		}
+		n, err := mc.db.RowCount(ctx, table)
+		count = n
+		return err
+	})
+	return count, err
+}
@@ -5,6 +5,9 @@ import (
 	"database/sql/driver"
 	"fmt"
 	"io"
+	"reflect"
+	"strings"
+	"time"
 
 	"github.com/RichardKnop/minisql/internal/minisql"
 )
@@ -47,6 +50,54 @@ func (r Rows) Columns() []string {
 	return buildColumnNames(r.columns)
 }
 
+// ColumnTypeDatabaseTypeName returns the SQL type name of a column, such as
+// "INT8" or "VARCHAR". It returns an empty string for computed columns whose
+// type is not known from the table schema.
+func (r Rows) ColumnTypeDatabaseTypeName(index int) string {
+	kind := r.columns[index].Kind
+	if kind == 0 || kind.String() == "unknown" {
+		return ""
+	}
+	return strings.ToUpper(kind.String())
+}
+
+// ColumnTypeNullable reports whether a column may contain NULL. ok is false
+// for computed columns whose nullability is not known from the table schema.
+func (r Rows) ColumnTypeNullable(index int) (nullable, ok bool) {
+	col := r.columns[index]
+	if col.Kind == 0 {
+		return false, false
+	}
+	return col.Nullable, true
+}
+
+var (
+	scanTypeBool    = reflect.TypeFor[bool]()
+	scanTypeInt64   = reflect.TypeFor[int64]()
+	scanTypeFloat64 = reflect.TypeFor[float64]()
+	scanTypeString  = reflect.TypeFor[string]()
+	scanTypeTime    = reflect.TypeFor[time.Time]()
+	scanTypeAny     = reflect.TypeFor[any]()
+)
+
+// ColumnTypeScanType returns the Go type Next produces for a column.
+func (r Rows) ColumnTypeScanType(index int) reflect.Type {
+	switch r.columns[index].Kind {
+	case minisql.Boolean:
+		return scanTypeBool
+	case minisql.Int4, minisql.Int8:
+		return scanTypeInt64
+	case minisql.Real, minisql.Double:
+		return scanTypeFloat64
+	case minisql.Varchar, minisql.Text, minisql.JSON, minisql.UUID, minisql.Vector:
+		return scanTypeString
+	case minisql.Timestamp:
+		return scanTypeTime
+	default:
+		return scanTypeAny
+	}
+}
+
 // Close closes the rows iterator.
 func (r *Rows) Close() error {
 	if r.useRowViews {
@@ -86,27 +137,36 @@ func (r *Rows) Next(dest []driver.Value) error {
 	}
 
 	for i := range dest {
-		if !aRow.Values[i].Valid {
-			dest[i] = nil
-			continue
-		}
-		switch v := aRow.Values[i].Value.(type) {
-		case minisql.TextPointer:
-			dest[i] = string(v.Data)
-		case minisql.TimestampMicros:
-			dest[i] = minisql.FromMicroseconds(int64(v)).GoTime()
-		case minisql.UUIDValue:
-			dest[i] = v.String()
-		case minisql.VectorPointer:
-			dest[i] = minisql.FormatVector(v)
-		default:
-			dest[i] = aRow.Values[i].Value
-		}
+		dest[i] = driverValue(aRow.Values[i])
 	}
 
 	return nil
 }
 
+// driverValue converts an engine value to the driver.Value returned to
+// database/sql.
+func driverValue(value minisql.OptionalValue) driver.Value {
+	if !value.Valid {
+		return nil
+	}
+	switch v := value.Value.(type) {
+	case minisql.TextPointer:
+		return string(v.Data)
+	case minisql.TimestampMicros:
+		return minisql.FromMicroseconds(int64(v)).GoTime()
+	case minisql.UUIDValue:
+		return v.String()
+	case minisql.VectorPointer:
+		return minisql.FormatVector(v)
+	case int32:
+		return int64(v)
+	case float32:
+		return float64(v)
+	default:
+		return value.Value
+	}
+}
+
 func (r *Rows) nextRowView(dest []driver.Value) error {
 	if !r.rowViewIter.Next(r.ctx) {
 		if err := r.rowViewIter.Err(); err != nil {
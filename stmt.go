@@ -5,9 +5,7 @@ import (
 	"database/sql/driver"
 	"errors"
 	"fmt"
-	"io"
 	"time"
-	"unsafe"
 
 	"github.com/RichardKnop/minisql/internal/minisql"
 )
@@ -36,8 +34,23 @@ func (s Stmt) Close() error {
 // NumInput may also return -1, if the driver doesn't know
 // its number of placeholders. In that case, the sql package
 // will not sanity check Exec or Query argument counts.
+//
+// A statement with named placeholders takes one argument per distinct name,
+// passed with sql.Named.
 func (s Stmt) NumInput() int {
-	return s.statement.NumPlaceholders()
+	return numInput(s.statement)
+}
+
+func numInput(statement minisql.Statement) int {
+	named, err := statement.HasNamedPlaceholders()
+	if err != nil || !named {
+		return statement.NumPlaceholders()
+	}
+	distinct := make(map[string]struct{})
+	for _, name := range statement.PlaceholderNames() {
+		distinct[name] = struct{}{}
+	}
+	return len(distinct)
 }
 
 // Exec executes a query that doesn't return rows, such
@@ -56,6 +69,7 @@ func (s Stmt) ExecContext(ctx context.Context, args []driver.NamedValue) (result
 	defer func() {
 		s.conn.logSlowQuery(s.query, time.Since(start), err)
 	}()
+	ctx = s.conn.queryLogContext(ctx, s.query, args)
 
 	stmtWithArgs, err := s.bindNamedArguments(args)
 	if err != nil {
@@ -86,30 +100,18 @@ func (s Stmt) QueryContext(ctx context.Context, args []driver.NamedValue) (rows
 	defer func() {
 		s.conn.logSlowQuery(s.query, time.Since(start), err)
 	}()
+	ctx = s.conn.queryLogContext(ctx, s.query, args)
 
 	stmtWithArgs, err := s.bindNamedArguments(args)
 	if err != nil {
 		return nil, err
 	}
 
-	result, rowsCtx, readTx, err := s.conn.executeQueryStatement(ctx, stmtWithArgs)
+	mrows, err := s.conn.queryStatement(ctx, stmtWithArgs)
 	if err != nil {
 		return nil, err
 	}
-	useRowViews := len(result.RowViewFieldIndexes) > 0
-
-	return &Rows{
-		columns:             result.Columns,
-		columnNames:         buildColumnNames(result.Columns),
-		iter:                result.Rows,
-		rowViewIter:         result.RowViews,
-		rowViewPager:        result.RowViewPager,
-		rowViewFieldIndexes: result.RowViewFieldIndexes,
-		useRowViews:         useRowViews,
-		ctx:                 rowsCtx,
-		txManager:           s.conn.db.GetTransactionManager(),
-		tx:                  readTx,
-	}, nil
+	return mrows, nil
 }
 
 type namedArgReader struct {
@@ -127,6 +129,12 @@ func (r *namedArgReader) next() (any, error) {
 }
 
 func (s Stmt) bindNamedArguments(args []driver.NamedValue) (minisql.Statement, error) {
+	if named, err := namedArgs(args); err != nil {
+		return minisql.Statement{}, err
+	} else if named != nil {
+		return s.statement.BindNamed(named)
+	}
+
 	reader := namedArgReader{args: args}
 	if stmt, ok, err := s.statement.BindArgumentsFrom(reader.next); ok || err != nil {
 		return stmt, err
@@ -165,6 +173,33 @@ func (s Stmt) bindNamedArguments(args []driver.NamedValue) (minisql.Statement, e
 	return s.statement.BindArguments(internalArgs...)
 }
 
+// namedArgs returns the arguments keyed by name when they were passed with
+// sql.Named, or nil when they are positional.
+func namedArgs(args []driver.NamedValue) (map[string]any, error) {
+	if len(args) == 0 || args[0].Name == "" {
+		for _, arg := range args {
+			if arg.Name != "" {
+				return nil, errMixedArgs
+			}
+		}
+		return nil, nil
+	}
+	named := make(map[string]any, len(args))
+	for _, arg := range args {
+		if arg.Name == "" {
+			return nil, errMixedArgs
+		}
+		value, err := toInternalArg(arg)
+		if err != nil {
+			return nil, err
+		}
+		named[arg.Name] = value
+	}
+	return named, nil
+}
+
+var errMixedArgs = errors.New("cannot mix named and positional arguments")
+
 func toInternalArgs(args []driver.NamedValue) ([]any, error) {
 	internalArgs := make([]any, len(args))
 	// Supported argument types: int64, float64, bool, []byte, string, time.Time
@@ -179,34 +214,5 @@ func toInternalArgs(args []driver.NamedValue) ([]any, error) {
 }
 
 func toInternalArg(arg driver.NamedValue) (any, error) {
-	switch v := arg.Value.(type) {
-	case nil:
-		return nil, nil
-	case int64, float64, bool:
-		return v, nil
-	case []float32:
-		return minisql.VectorPointer{Dims: uint32(len(v)), Data: v}, nil
-	case string:
-		// Reuse the string's backing bytes without copying. The TextPointer is
-		// valid only for the duration of this Exec/Query call: `args` (and thus
-		// the underlying string data) is kept alive by the caller's stack frame,
-		// and the TextPointer is consumed before ExecContext/QueryContext returns.
-		b := unsafe.Slice(unsafe.StringData(v), len(v))
-		return minisql.NewTextPointer(b), nil
-	case io.Reader:
-		return minisql.ReaderValue{R: v}, nil
-	case time.Time:
-		t := minisql.Time{
-			Year:         int32(v.Year()),
-			Month:        int8(v.Month()),
-			Day:          int8(v.Day()),
-			Hour:         int8(v.Hour()),
-			Minutes:      int8(v.Minute()),
-			Seconds:      int8(v.Second()),
-			Microseconds: int32(v.Nanosecond() / 1000),
-		}
-		return minisql.TimestampMicros(t.TotalMicroseconds()), nil
-	default:
-		return nil, fmt.Errorf("unsupported argument type: %T", arg)
-	}
+	return minisql.ToStatementArg(arg.Value)
 }
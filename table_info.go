@@ -0,0 +1,44 @@
+package minisql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/RichardKnop/minisql/internal/minisql"
+)
+
+// TableInfo summarises one user table: its column, row and page counts and
+// the names of its indexes.
+type TableInfo = minisql.TableInfo
+
+// Tables returns a summary of every user table in db, sorted by name. Row
+// counts come from the engine's running counts; page counts require walking
+// each table and index, so they cost more on large databases. db must have
+// been opened with sql.Open("minisql", dsn).
+//
+// Example:
+//
+//	tables, err := minisql.Tables(ctx, db)
+//	if err != nil { ... }
+//	for _, t := range tables {
+//		fmt.Println(t.Name, t.Rows, t.Pages)
+//	}
+func Tables(ctx context.Context, db *sql.DB) ([]TableInfo, error) {
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("minisql: Tables: acquire connection: %w", err)
+	}
+	defer conn.Close()
+
+	var tables []TableInfo
+	err = conn.Raw(func(c any) error {
+		mc, ok := c.(*Conn)
+		if !ok {
+			return fmt.Errorf("minisql: Tables: unexpected connection type %T", c)
+		}
+		tables, err = mc.db.TableInfo(ctx)
+		return err
+	})
+	return tables, err
+}
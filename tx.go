@@ -6,6 +6,10 @@ import (
 	"github.com/RichardKnop/minisql/internal/minisql"
 )
 
+// ErrReadOnlyTransaction is returned when a statement that writes is executed
+// in a transaction begun with sql.TxOptions{ReadOnly: true}.
+var ErrReadOnlyTransaction = minisql.ErrReadOnlyTransaction
+
 // Tx is a database/sql/driver.Tx implementation representing an explicit
 // BEGIN/COMMIT/ROLLBACK transaction. Write transactions use Optimistic
 // Concurrency Control (OCC); conflicts return pkg/errors.ErrTxConflict.
@@ -23,6 +27,7 @@ func (tx Tx) Commit() error {
 		return err
 	}
 	tx.conn.SetTransaction(nil)
+	tx.conn.db.AutoVacuumAfterCommit(context.Background())
 	return nil
 }
 
@@ -0,0 +1,34 @@
+package minisql
+
+import (
+	"github.com/RichardKnop/minisql/internal/minisql"
+)
+
+// ValueCodec encodes and decodes the values of a custom column type; see
+// RegisterValueCodec.
+type ValueCodec = minisql.ValueCodec
+
+var (
+	// ErrBuiltinColumnKind is returned when registering a codec under the name
+	// of a built-in column type.
+	ErrBuiltinColumnKind = minisql.ErrBuiltinColumnKind
+	// ErrValueCodecExists is returned when a codec is already registered under
+	// the type name.
+	ErrValueCodecExists = minisql.ErrValueCodecExists
+)
+
+// RegisterValueCodec registers codec as the on-disk encoding of a custom
+// column type called name, which can then be used in CREATE TABLE and ALTER
+// TABLE ADD COLUMN. The registry is process-wide and the schema names the
+// type, so register codecs, for example from an init function, before
+// opening a database that uses them. Custom columns can be stored and read
+// back but cannot be indexed or compared.
+//
+// Example:
+//
+//	kind, err := minisql.RegisterValueCodec("varint", varintCodec{})
+//	...
+//	_, err = db.Exec(`create table "events" (id int8 primary key, delta varint)`)
+func RegisterValueCodec(name string, codec ValueCodec) (ColumnKind, error) {
+	return minisql.RegisterValueCodec(name, codec)
+}